@@ -1,18 +1,47 @@
 package main
 
 import (
+	"context"
+
 	_ "go.bankyaya.org/app/backend/cmd/swagger/docs"
 	"go.bankyaya.org/app/backend/internal/adapter/http/server"
+	"go.bankyaya.org/app/backend/internal/adapter/worker"
 	"go.bankyaya.org/app/backend/internal/pkg/config"
 )
 
 type app struct {
 	ss *server.Server
+	sw *worker.Schedule
+	ow *worker.StandingOrder
+	rw *worker.Reconciler
+	xw *worker.Outbox
+	cw *worker.SequenceCleanup
+	bw *worker.BulkTransfer
+	pw *worker.PaymentRequestExpiry
+	tw *worker.Settlement
 }
 
-func newApp(ss *server.Server) *app {
+func newApp(
+	ss *server.Server,
+	sw *worker.Schedule,
+	ow *worker.StandingOrder,
+	rw *worker.Reconciler,
+	xw *worker.Outbox,
+	cw *worker.SequenceCleanup,
+	bw *worker.BulkTransfer,
+	pw *worker.PaymentRequestExpiry,
+	tw *worker.Settlement,
+) *app {
 	return &app{
 		ss: ss,
+		sw: sw,
+		ow: ow,
+		rw: rw,
+		xw: xw,
+		cw: cw,
+		bw: bw,
+		pw: pw,
+		tw: tw,
 	}
 }
 
@@ -34,5 +63,13 @@ func main() {
 	c := config.Load()
 	a := initApp(c)
 
+	go a.sw.Run(context.Background())
+	go a.ow.Run(context.Background())
+	go a.rw.Run(context.Background())
+	go a.xw.Run(context.Background())
+	go a.cw.Run(context.Background())
+	go a.bw.Run(context.Background())
+	go a.pw.Run(context.Background())
+	go a.tw.Run(context.Background())
 	a.ss.Serve()
 }
@@ -8,6 +8,7 @@ package main
 
 import (
 	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter"
 	corebanking2 "go.bankyaya.org/app/backend/internal/adapter/corebanking"
 	"go.bankyaya.org/app/backend/internal/adapter/email"
 	"go.bankyaya.org/app/backend/internal/adapter/http/handler"
@@ -15,11 +16,24 @@ import (
 	"go.bankyaya.org/app/backend/internal/adapter/notification"
 	"go.bankyaya.org/app/backend/internal/adapter/otp"
 	"go.bankyaya.org/app/backend/internal/adapter/password"
+	"go.bankyaya.org/app/backend/internal/adapter/qrimage"
 	"go.bankyaya.org/app/backend/internal/adapter/sequence"
+	"go.bankyaya.org/app/backend/internal/adapter/statementfile"
 	"go.bankyaya.org/app/backend/internal/adapter/storage/repo"
 	"go.bankyaya.org/app/backend/internal/adapter/token"
+	"go.bankyaya.org/app/backend/internal/adapter/worker"
+	"go.bankyaya.org/app/backend/internal/domain/beneficiary"
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/domain/bulktransfer"
+	"go.bankyaya.org/app/backend/internal/domain/ewallet"
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
 	"go.bankyaya.org/app/backend/internal/domain/intrabank"
 	otp2 "go.bankyaya.org/app/backend/internal/domain/otp"
+	"go.bankyaya.org/app/backend/internal/domain/paymentrequest"
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+	"go.bankyaya.org/app/backend/internal/domain/schedule"
+	"go.bankyaya.org/app/backend/internal/domain/standingorder"
+	"go.bankyaya.org/app/backend/internal/domain/statement"
 	"go.bankyaya.org/app/backend/internal/domain/user"
 	"go.bankyaya.org/app/backend/internal/pkg/config"
 	"go.bankyaya.org/app/backend/internal/pkg/corebanking"
@@ -51,21 +65,76 @@ func initApp(cfg *config.Configs) *app {
 	intrabankEmail := email.NewTransferEmail(loggerLogger, mailtrapClient)
 	firebaseClient := firebase.New()
 	intrabankNotification := notification.NewIntrabankNotification(firebaseClient)
-	service := intrabank.NewService(loggerLogger, intrabankRepo, intrabankCoreBanking, uuid, intrabankEmail, intrabankNotification)
-	handlerIntrabank := handler.NewIntrabankHandler(service)
+	sequenceValidity := adapter.ProvideSequenceValidity(cfg)
+	otpRepo := repo.NewOTPRepo(db)
+	otpOTP := otp.NewOTP()
+	otpEmail := email.NewOTPEmail(loggerLogger, mailtrapClient)
+	service := otp2.NewService(loggerLogger, otpRepo, otpOTP, otpEmail)
+	stepUpPolicy := adapter.ProvideStepUpPolicy(cfg)
+	feePolicy := adapter.ProvideFeePolicy(cfg)
+	intrabankService := intrabank.NewService(loggerLogger, intrabankRepo, intrabankCoreBanking, uuid, intrabankEmail, intrabankNotification, sequenceValidity, service, stepUpPolicy, feePolicy)
+	handlerIntrabank := handler.NewIntrabankHandler(intrabankService)
 	userRepo := repo.NewUserRepo(db)
 	bcryptHasher := password.NewBcryptHasher(loggerLogger)
 	jwt := token.NewJWT(cfg)
 	userService := user.NewService(loggerLogger, userRepo, bcryptHasher, jwt)
 	userHandler := handler.NewUserHandler(userService)
 	validator := validation.New()
-	otpRepo := repo.NewOTPRepo(db)
-	otpOTP := otp.NewOTP()
-	otpEmail := email.NewOTPEmail(loggerLogger, mailtrapClient)
-	otpService := otp2.NewService(loggerLogger, otpRepo, otpOTP, otpEmail)
-	otpHandler := handler.NewOTPHandler(validator, otpService)
-	router := server.NewRouter(cfg, loggerLogger, echoEcho, handlerIntrabank, userHandler, otpHandler)
+	otpHandler := handler.NewOTPHandler(validator, service)
+	scheduleRepo := repo.NewScheduleRepo(db)
+	scheduleService := schedule.NewService(loggerLogger, scheduleRepo, intrabankService, intrabankNotification, service)
+	handlerSchedule := handler.NewScheduleHandler(validator, scheduleService)
+	standingOrderRepo := repo.NewStandingOrderRepo(db)
+	standingorderService := standingorder.NewService(loggerLogger, standingOrderRepo, intrabankService, intrabankNotification, service)
+	standingOrder := handler.NewStandingOrderHandler(validator, standingorderService)
+	beneficiaryRepo := repo.NewBeneficiaryRepo(db)
+	beneficiaryService := beneficiary.NewService(loggerLogger, beneficiaryRepo, intrabankCoreBanking)
+	handlerBeneficiary := handler.NewBeneficiaryHandler(validator, beneficiaryService)
+	interbankRepo := repo.NewInterbankRepo(db)
+	interbankCoreBanking := corebanking2.NewInterbankCoreBanking(intrabankCoreBanking, cfg)
+	interbankGateway := adapter.ProvideInterbankGateway(cfg)
+	interbankService := interbank.NewService(loggerLogger, interbankRepo, interbankCoreBanking, interbankGateway, uuid, sequenceValidity, service, stepUpPolicy, feePolicy)
+	handlerInterbank := handler.NewInterbankHandler(validator, interbankService)
+	bulkTransferRepo := repo.NewBulkTransferRepo(db)
+	bulktransferService := bulktransfer.NewService(loggerLogger, bulkTransferRepo, intrabankService, service)
+	bulkTransfer := handler.NewBulkTransferHandler(validator, bulktransferService)
+	paymentRequestRepo := repo.NewPaymentRequestRepo(db)
+	paymentRequestNotification := notification.NewPaymentRequestNotification(firebaseClient)
+	paymentrequestService := paymentrequest.NewService(loggerLogger, paymentRequestRepo, paymentRequestRepo, intrabankService, paymentRequestNotification)
+	paymentRequest := handler.NewPaymentRequestHandler(validator, paymentrequestService)
+	qrisRepo := repo.NewQRISRepo(db)
+	qrisCoreBanking := corebanking2.NewQRISCoreBanking(intrabankCoreBanking, cfg)
+	acquirer := adapter.ProvideQRISAcquirer(cfg)
+	issuer := adapter.ProvideQRISIssuer(cfg)
+	pngRenderer := qrimage.NewPNGRenderer()
+	qrisService := qris.NewService(loggerLogger, qrisRepo, qrisCoreBanking, acquirer, uuid, sequenceValidity, service, stepUpPolicy, feePolicy, issuer, pngRenderer)
+	handlerQRIS := handler.NewQRISHandler(validator, qrisService)
+	billPaymentRepo := repo.NewBillPaymentRepo(db)
+	billPaymentCoreBanking := corebanking2.NewBillPaymentCoreBanking(intrabankCoreBanking)
+	biller := adapter.ProvideBiller(cfg)
+	billpaymentService := billpayment.NewService(loggerLogger, billPaymentRepo, billPaymentCoreBanking, biller, uuid, sequenceValidity, service, stepUpPolicy, feePolicy)
+	billPayment := handler.NewBillPaymentHandler(validator, billpaymentService)
+	eWalletRepo := repo.NewEWalletRepo(db)
+	eWalletCoreBanking := corebanking2.NewEWalletCoreBanking(intrabankCoreBanking)
+	directory := adapter.ProvideEWalletDirectory(cfg)
+	catalog := adapter.ProvideEWalletCatalog(cfg)
+	ewalletService := ewallet.NewService(loggerLogger, eWalletRepo, eWalletCoreBanking, directory, uuid, sequenceValidity, service, stepUpPolicy, catalog)
+	eWallet := handler.NewEWalletHandler(validator, ewalletService)
+	statementRepo := repo.NewStatementRepo(db)
+	renderer := statementfile.NewRenderer()
+	statementEmail := email.NewStatementEmail(loggerLogger, mailtrapClient)
+	statementService := statement.NewService(loggerLogger, statementRepo, intrabankCoreBanking, renderer, statementEmail, uuid)
+	handlerStatement := handler.NewStatementHandler(validator, statementService)
+	router := server.NewRouter(cfg, loggerLogger, echoEcho, handlerIntrabank, userHandler, otpHandler, handlerSchedule, standingOrder, handlerBeneficiary, handlerInterbank, bulkTransfer, paymentRequest, handlerQRIS, billPayment, eWallet, handlerStatement)
 	serverServer := server.New(router)
-	mainApp := newApp(serverServer)
+	workerSchedule := worker.NewScheduleWorker(cfg, loggerLogger, scheduleService)
+	workerStandingOrder := worker.NewStandingOrderWorker(cfg, loggerLogger, standingorderService)
+	reconciler := worker.NewReconcilerWorker(cfg, loggerLogger, intrabankService, billpaymentService)
+	outbox := worker.NewOutboxWorker(cfg, loggerLogger, intrabankService)
+	sequenceCleanup := worker.NewSequenceCleanupWorker(cfg, loggerLogger, intrabankService)
+	workerBulkTransfer := worker.NewBulkTransferWorker(cfg, loggerLogger, bulktransferService)
+	paymentRequestExpiry := worker.NewPaymentRequestExpiryWorker(cfg, loggerLogger, paymentrequestService)
+	settlement := worker.NewSettlementWorker(cfg, loggerLogger, interbankService, qrisService, billpaymentService, ewalletService)
+	mainApp := newApp(serverServer, workerSchedule, workerStandingOrder, reconciler, outbox, sequenceCleanup, workerBulkTransfer, paymentRequestExpiry, settlement)
 	return mainApp
 }
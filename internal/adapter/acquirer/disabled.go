@@ -0,0 +1,34 @@
+package acquirer
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+)
+
+// unavailableStatusCode is the response code of a QRIS network that cannot be reached.
+const unavailableStatusCode = "91"
+
+// DisabledAcquirer is provided when no QRIS network is configured, so the QRIS merchant payments are unavailable
+// while the rest of the application keeps running. No payment ever reaches the QRIS network,
+// the payments are rejected so their debits are reversed.
+type DisabledAcquirer struct{}
+
+func NewDisabledAcquirer() *DisabledAcquirer {
+	return &DisabledAcquirer{}
+}
+
+func (a *DisabledAcquirer) CheckMerchant(ctx context.Context, payload *qris.Payload) (*qris.Merchant, error) {
+	return nil, qris.ErrRailUnavailable
+}
+
+func (a *DisabledAcquirer) Pay(ctx context.Context, in *qris.AcquirerPayment) (*qris.AcquirerResult, error) {
+	return nil, &qris.PaymentRejection{
+		StatusCode:  unavailableStatusCode,
+		Description: qris.ErrRailUnavailable.Error(),
+	}
+}
+
+func (a *DisabledAcquirer) PaymentStatus(ctx context.Context, reference string) (*qris.AcquirerResult, error) {
+	return nil, qris.ErrPaymentNotReceived
+}
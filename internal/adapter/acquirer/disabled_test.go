@@ -0,0 +1,28 @@
+package acquirer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+)
+
+func TestDisabledAcquirer(t *testing.T) {
+	acquirer := NewDisabledAcquirer()
+
+	merchant, err := acquirer.CheckMerchant(context.Background(), &qris.Payload{Merchant: &qris.Merchant{PAN: "936000140000000001"}})
+	assert.Nil(t, merchant)
+	assert.ErrorIs(t, err, qris.ErrRailUnavailable)
+
+	result, err := acquirer.Pay(context.Background(), &qris.AcquirerPayment{Reference: "123456"})
+	assert.Nil(t, result)
+	var rejection *qris.PaymentRejection
+	assert.True(t, errors.As(err, &rejection))
+	assert.Equal(t, "91", rejection.StatusCode)
+
+	result, err = acquirer.PaymentStatus(context.Background(), "123456")
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, qris.ErrPaymentNotReceived)
+}
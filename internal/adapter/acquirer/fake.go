@@ -0,0 +1,86 @@
+// Package acquirer provides the adapters of the QRIS network that reaches the acquirers of the merchants.
+package acquirer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+)
+
+const (
+	// unknownMerchantSuffix marks the merchant PANs that are not registered at the fake acquirers.
+	unknownMerchantSuffix = "0000"
+	// rejectedMerchantSuffix marks the merchant PANs whose payments are rejected by the fake acquirers.
+	rejectedMerchantSuffix = "9999"
+	rejectedStatusCode     = "76"
+)
+
+// FakeAcquirer stands in for the QRIS network in tests and local development.
+// Every merchant is registered as printed on its QR code, except the merchants whose PAN
+// ends in 0000, which are not found, and the merchants whose PAN ends in 9999,
+// whose payments are rejected.
+// The outcome of every payment is kept by its reference for the status checks.
+type FakeAcquirer struct {
+	journal  atomic.Int64
+	payments sync.Map
+}
+
+func NewFakeAcquirer() *FakeAcquirer {
+	return &FakeAcquirer{}
+}
+
+func (a *FakeAcquirer) CheckMerchant(ctx context.Context, payload *qris.Payload) (*qris.Merchant, error) {
+	if payload.Merchant == nil || strings.HasSuffix(payload.Merchant.PAN, unknownMerchantSuffix) {
+		return nil, qris.ErrMerchantNotFound
+	}
+	merchant := *payload.Merchant
+	return &merchant, nil
+}
+
+func (a *FakeAcquirer) Pay(ctx context.Context, in *qris.AcquirerPayment) (*qris.AcquirerResult, error) {
+	result, err := a.pay(in)
+	a.payments.Store(in.Reference, fakeOutcome{result: result, err: err})
+	return result, err
+}
+
+func (a *FakeAcquirer) PaymentStatus(ctx context.Context, reference string) (*qris.AcquirerResult, error) {
+	outcome, ok := a.payments.Load(reference)
+	if !ok {
+		return nil, qris.ErrPaymentNotReceived
+	}
+	return outcome.(fakeOutcome).result, outcome.(fakeOutcome).err
+}
+
+func (a *FakeAcquirer) pay(in *qris.AcquirerPayment) (*qris.AcquirerResult, error) {
+	if strings.HasSuffix(in.Merchant.PAN, unknownMerchantSuffix) {
+		return nil, &qris.PaymentRejection{
+			StatusCode:  rejectedStatusCode,
+			Description: "invalid merchant",
+			Payload:     fmt.Sprintf(`{"code":%q,"description":"invalid merchant"}`, rejectedStatusCode),
+		}
+	}
+	if strings.HasSuffix(in.Merchant.PAN, rejectedMerchantSuffix) {
+		return nil, &qris.PaymentRejection{
+			StatusCode:  rejectedStatusCode,
+			Description: "transaction rejected by the acquirer",
+			Payload:     fmt.Sprintf(`{"code":%q,"description":"transaction rejected by the acquirer"}`, rejectedStatusCode),
+		}
+	}
+
+	journal := a.journal.Add(1)
+	return &qris.AcquirerResult{
+		JournalSequence:      fmt.Sprintf("%06d", journal),
+		TransactionReference: fmt.Sprintf("QRIS%s%06d", time.Now().Format("20060102150405"), journal),
+	}, nil
+}
+
+// fakeOutcome is the outcome of a payment kept by the fake acquirer.
+type fakeOutcome struct {
+	result *qris.AcquirerResult
+	err    error
+}
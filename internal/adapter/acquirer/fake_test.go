@@ -0,0 +1,69 @@
+package acquirer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+)
+
+func TestFakeAcquirerCheckMerchant(t *testing.T) {
+	acquirer := NewFakeAcquirer()
+
+	merchant, err := acquirer.CheckMerchant(context.Background(), &qris.Payload{
+		Merchant: &qris.Merchant{PAN: "936000140000000001", Name: "KOPI KENANGAN"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &qris.Merchant{PAN: "936000140000000001", Name: "KOPI KENANGAN"}, merchant)
+
+	merchant, err = acquirer.CheckMerchant(context.Background(), &qris.Payload{
+		Merchant: &qris.Merchant{PAN: "936000140000000000", Name: "KOPI KENANGAN"},
+	})
+	assert.Nil(t, merchant)
+	assert.ErrorIs(t, err, qris.ErrMerchantNotFound)
+}
+
+func TestFakeAcquirerPay(t *testing.T) {
+	acquirer := NewFakeAcquirer()
+
+	result, err := acquirer.Pay(context.Background(), &qris.AcquirerPayment{
+		SourceAccount: "001001234567891",
+		Merchant:      &qris.Merchant{PAN: "936000140000000001"},
+		Amount:        50000,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "000001", result.JournalSequence)
+	assert.NotEmpty(t, result.TransactionReference)
+
+	result, err = acquirer.Pay(context.Background(), &qris.AcquirerPayment{
+		SourceAccount: "001001234567891",
+		Merchant:      &qris.Merchant{PAN: "936000140000009999"},
+		Amount:        50000,
+	})
+	assert.Nil(t, result)
+	var rejection *qris.PaymentRejection
+	assert.True(t, errors.As(err, &rejection))
+	assert.Equal(t, "76", rejection.StatusCode)
+}
+
+func TestFakeAcquirerPaymentStatus(t *testing.T) {
+	acquirer := NewFakeAcquirer()
+
+	paid, err := acquirer.Pay(context.Background(), &qris.AcquirerPayment{
+		SourceAccount: "001001234567891",
+		Merchant:      &qris.Merchant{PAN: "936000140000000001"},
+		Amount:        50000,
+		Reference:     "123456",
+	})
+	assert.NoError(t, err)
+
+	result, err := acquirer.PaymentStatus(context.Background(), "123456")
+	assert.NoError(t, err)
+	assert.Equal(t, paid, result)
+
+	result, err = acquirer.PaymentStatus(context.Background(), "654321")
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, qris.ErrPaymentNotReceived)
+}
@@ -0,0 +1,35 @@
+package biller
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// unavailableStatusCode is the response code of a biller that cannot be reached.
+const unavailableStatusCode = "91"
+
+// DisabledBiller is provided when no biller aggregator is configured, so the bill payments are unavailable
+// while the rest of the application keeps running. No payment ever reaches a biller,
+// the payments are rejected so their debits are reversed.
+type DisabledBiller struct{}
+
+func NewDisabledBiller() *DisabledBiller {
+	return &DisabledBiller{}
+}
+
+func (b *DisabledBiller) Inquiry(ctx context.Context, product *billpayment.Product, customerID string, amount intrabank.Money) (*billpayment.Bill, error) {
+	return nil, billpayment.ErrRailUnavailable
+}
+
+func (b *DisabledBiller) Pay(ctx context.Context, in *billpayment.BillerPayment) (*billpayment.BillerReceipt, error) {
+	return nil, &billpayment.PaymentRejection{
+		StatusCode:  unavailableStatusCode,
+		Description: billpayment.ErrRailUnavailable.Error(),
+	}
+}
+
+func (b *DisabledBiller) PaymentStatus(ctx context.Context, product *billpayment.Product, sequenceNumber string) (*billpayment.BillerReceipt, error) {
+	return nil, billpayment.ErrPaymentNotReceived
+}
@@ -0,0 +1,29 @@
+package biller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+)
+
+func TestDisabledBiller(t *testing.T) {
+	biller := NewDisabledBiller()
+	product := &billpayment.Product{Code: "PLNPOST", Category: billpayment.CategoryElectricity}
+
+	bill, err := biller.Inquiry(context.Background(), product, "532100000001", 0)
+	assert.Nil(t, bill)
+	assert.ErrorIs(t, err, billpayment.ErrRailUnavailable)
+
+	receipt, err := biller.Pay(context.Background(), &billpayment.BillerPayment{})
+	assert.Nil(t, receipt)
+	var rejection *billpayment.PaymentRejection
+	assert.True(t, errors.As(err, &rejection))
+	assert.Equal(t, "91", rejection.StatusCode)
+
+	receipt, err = biller.PaymentStatus(context.Background(), product, "123456")
+	assert.Nil(t, receipt)
+	assert.ErrorIs(t, err, billpayment.ErrPaymentNotReceived)
+}
@@ -0,0 +1,122 @@
+// Package biller provides the adapters of the billers that issue the electricity, water, BPJS and phone bills.
+package biller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+const (
+	// unknownCustomerSuffix marks the customer IDs that are not registered at the fake billers.
+	unknownCustomerSuffix = "0000"
+	// paidCustomerSuffix marks the customer IDs that have no outstanding bill at the fake billers.
+	paidCustomerSuffix = "1111"
+	// rejectedCustomerSuffix marks the customer IDs whose payments are rejected by the fake billers.
+	rejectedCustomerSuffix = "9999"
+	rejectedStatusCode     = "14"
+
+	fakeCustomerName = "OLIVIA RODRIGO"
+	fakeAdminFee     = 2500
+	fakeBillAmount   = 150000
+	// fakeTariffPerKWh is the price of a kWh of the R1/1300VA tariff, in rupiah.
+	fakeTariffPerKWh = 1444.70
+)
+
+// FakeBiller stands in for the billers in tests and local development.
+// Every customer owes a bill of 150000 for the current period, or buys the chosen PLN token,
+// except the customers whose ID ends in 0000, which are not found, the customers whose ID ends in 1111,
+// which have no outstanding bill, and the customers whose ID ends in 9999, whose payments are rejected.
+// The outcome of every payment is kept by its sequence number for the status checks.
+type FakeBiller struct {
+	journal  atomic.Int64
+	payments sync.Map
+}
+
+func NewFakeBiller() *FakeBiller {
+	return &FakeBiller{}
+}
+
+func (b *FakeBiller) Inquiry(ctx context.Context, product *billpayment.Product, customerID string, amount intrabank.Money) (*billpayment.Bill, error) {
+	if strings.HasSuffix(customerID, unknownCustomerSuffix) {
+		return nil, billpayment.ErrCustomerNotFound
+	}
+
+	bill := &billpayment.Bill{
+		CustomerID:   customerID,
+		CustomerName: fakeCustomerName,
+		AdminFee:     fakeAdminFee,
+		Reference:    fmt.Sprintf("INQ%s%s", time.Now().Format("20060102150405"), customerID),
+	}
+	if product.Prepaid() {
+		bill.Amount = amount
+		bill.Details = []billpayment.Detail{{Label: "Tariff/Power", Value: "R1/1300VA"}}
+		return bill, nil
+	}
+
+	if strings.HasSuffix(customerID, paidCustomerSuffix) {
+		return nil, billpayment.ErrNoOutstandingBill
+	}
+	bill.Amount = fakeBillAmount
+	bill.Period = time.Now().Format("January 2006")
+	bill.Details = []billpayment.Detail{{Label: "Period", Value: bill.Period}}
+	return bill, nil
+}
+
+func (b *FakeBiller) Pay(ctx context.Context, in *billpayment.BillerPayment) (*billpayment.BillerReceipt, error) {
+	receipt, err := b.pay(in)
+	b.payments.Store(in.SequenceNumber, fakeOutcome{receipt: receipt, err: err})
+	return receipt, err
+}
+
+func (b *FakeBiller) PaymentStatus(ctx context.Context, product *billpayment.Product, sequenceNumber string) (*billpayment.BillerReceipt, error) {
+	outcome, ok := b.payments.Load(sequenceNumber)
+	if !ok {
+		return nil, billpayment.ErrPaymentNotReceived
+	}
+	return outcome.(fakeOutcome).receipt, outcome.(fakeOutcome).err
+}
+
+func (b *FakeBiller) pay(in *billpayment.BillerPayment) (*billpayment.BillerReceipt, error) {
+	if strings.HasSuffix(in.CustomerID, unknownCustomerSuffix) || strings.HasSuffix(in.CustomerID, rejectedCustomerSuffix) {
+		return nil, &billpayment.PaymentRejection{
+			StatusCode:  rejectedStatusCode,
+			Description: "payment rejected by the biller",
+			Payload:     fmt.Sprintf(`{"code":%q,"description":"payment rejected by the biller"}`, rejectedStatusCode),
+		}
+	}
+
+	journal := b.journal.Add(1)
+	receipt := &billpayment.BillerReceipt{
+		Reference: fmt.Sprintf("BILL%s%06d", time.Now().Format("20060102150405"), journal),
+	}
+	if in.Product.Prepaid() {
+		receipt.Details = []billpayment.Detail{
+			{Label: "Token", Value: token(journal)},
+			{Label: "kWh", Value: fmt.Sprintf("%.1f", float64(in.Amount)/fakeTariffPerKWh)},
+		}
+	}
+	return receipt, nil
+}
+
+// token formats the journal as a 20 digit PLN token in groups of four digits, e.g. "0000 0000 0000 0000 0001".
+func token(journal int64) string {
+	digits := fmt.Sprintf("%020d", journal)
+	groups := make([]string, 0, 5)
+	for i := 0; i < len(digits); i += 4 {
+		groups = append(groups, digits[i:i+4])
+	}
+	return strings.Join(groups, " ")
+}
+
+// fakeOutcome is the outcome of a payment kept by the fake biller.
+type fakeOutcome struct {
+	receipt *billpayment.BillerReceipt
+	err     error
+}
@@ -0,0 +1,84 @@
+package biller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+var (
+	plnToken = &billpayment.Product{Code: "PLN_PREPAID", Denominations: []intrabank.Money{20000, 50000, 100000}}
+	water    = &billpayment.Product{Code: "PDAM_JAKARTA", Category: billpayment.CategoryWater}
+)
+
+func TestFakeBillerInquiry(t *testing.T) {
+	biller := NewFakeBiller()
+
+	bill, err := biller.Inquiry(context.Background(), plnToken, "532100000001", 50000)
+	assert.NoError(t, err)
+	assert.Equal(t, intrabank.Money(50000), bill.Amount)
+	assert.Equal(t, "OLIVIA RODRIGO", bill.CustomerName)
+
+	bill, err = biller.Inquiry(context.Background(), water, "0012345678", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, intrabank.Money(150000), bill.Amount)
+	assert.NotEmpty(t, bill.Period)
+
+	bill, err = biller.Inquiry(context.Background(), water, "0012341111", 0)
+	assert.Nil(t, bill)
+	assert.ErrorIs(t, err, billpayment.ErrNoOutstandingBill)
+
+	bill, err = biller.Inquiry(context.Background(), plnToken, "532100000000", 50000)
+	assert.Nil(t, bill)
+	assert.ErrorIs(t, err, billpayment.ErrCustomerNotFound)
+}
+
+func TestFakeBillerPay(t *testing.T) {
+	biller := NewFakeBiller()
+
+	receipt, err := biller.Pay(context.Background(), &billpayment.BillerPayment{
+		Product:    plnToken,
+		CustomerID: "532100000001",
+		Amount:     50000,
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, receipt.Reference)
+	assert.Equal(t, []billpayment.Detail{
+		{Label: "Token", Value: "0000 0000 0000 0000 0001"},
+		{Label: "kWh", Value: "34.6"},
+	}, receipt.Details)
+
+	receipt, err = biller.Pay(context.Background(), &billpayment.BillerPayment{
+		Product:    water,
+		CustomerID: "0012349999",
+		Amount:     150000,
+	})
+	assert.Nil(t, receipt)
+	var rejection *billpayment.PaymentRejection
+	assert.True(t, errors.As(err, &rejection))
+	assert.Equal(t, "14", rejection.StatusCode)
+}
+
+func TestFakeBillerPaymentStatus(t *testing.T) {
+	biller := NewFakeBiller()
+
+	paid, err := biller.Pay(context.Background(), &billpayment.BillerPayment{
+		Product:        water,
+		CustomerID:     "0012340001",
+		Amount:         150000,
+		SequenceNumber: "123456",
+	})
+	assert.NoError(t, err)
+
+	receipt, err := biller.PaymentStatus(context.Background(), water, "123456")
+	assert.NoError(t, err)
+	assert.Equal(t, paid, receipt)
+
+	receipt, err = biller.PaymentStatus(context.Background(), water, "654321")
+	assert.Nil(t, receipt)
+	assert.ErrorIs(t, err, billpayment.ErrPaymentNotReceived)
+}
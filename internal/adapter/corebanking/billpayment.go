@@ -0,0 +1,51 @@
+package corebanking
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/corebanking"
+)
+
+const (
+	billTransactionType         = "sa-ovb-bill"
+	billReversalTransactionType = "sa-rev-bill"
+)
+
+// BillPaymentCoreBanking posts the bill payments to the settlement accounts of the billers.
+type BillPaymentCoreBanking struct {
+	*IntrabankCoreBanking
+}
+
+func NewBillPaymentCoreBanking(cb *IntrabankCoreBanking) *BillPaymentCoreBanking {
+	return &BillPaymentCoreBanking{IntrabankCoreBanking: cb}
+}
+
+func (cb *BillPaymentCoreBanking) DebitBill(ctx context.Context, in *billpayment.Debit) (*intrabank.OverbookingResult, error) {
+	return cb.overbook(ctx, corebanking.OverbookRequest{
+		TransactionType: billTransactionType,
+		AccNoSrc:        in.SourceAccount,
+		Amount:          in.Amount.String(),
+		TransactionInfo: in.Remark,
+		AccNoCredit:     in.SettlementAccount,
+		Fee:             in.Fee.String(),
+		Provider:        in.Provider,
+		Reference:       in.Reference,
+	})
+}
+
+// ReverseBill credits the amount back from the settlement account, the fee charged with the debit is returned with it.
+// The reversal has its own reference derived from the debit, so the core banking system posts it only once.
+func (cb *BillPaymentCoreBanking) ReverseBill(ctx context.Context, in *billpayment.Debit) (*intrabank.OverbookingResult, error) {
+	return cb.overbook(ctx, corebanking.OverbookRequest{
+		TransactionType: billReversalTransactionType,
+		AccNoSrc:        in.SettlementAccount,
+		Amount:          (in.Amount + in.Fee).String(),
+		TransactionInfo: "REV " + in.Remark,
+		AccNoCredit:     in.SourceAccount,
+		Fee:             intrabank.Money(0).String(),
+		Provider:        in.Provider,
+		Reference:       reversalReference(in.Reference),
+	})
+}
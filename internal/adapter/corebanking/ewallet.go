@@ -0,0 +1,35 @@
+package corebanking
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/ewallet"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/corebanking"
+)
+
+const eWalletTransactionType = "sa-topup-ewallet"
+
+// EWalletCoreBanking posts the e-wallet top-ups, the core banking system routes them by their e-wallet type.
+type EWalletCoreBanking struct {
+	*IntrabankCoreBanking
+}
+
+func NewEWalletCoreBanking(cb *IntrabankCoreBanking) *EWalletCoreBanking {
+	return &EWalletCoreBanking{IntrabankCoreBanking: cb}
+}
+
+// TopUp credits the e-wallet of the phone number, which is sent as the credit account.
+func (cb *EWalletCoreBanking) TopUp(ctx context.Context, in *ewallet.Posting) (*intrabank.OverbookingResult, error) {
+	return cb.overbook(ctx, corebanking.OverbookRequest{
+		TransactionType: eWalletTransactionType,
+		EWalletType:     in.WalletType,
+		AccNoSrc:        in.SourceAccount,
+		Amount:          in.Amount.String(),
+		TransactionInfo: in.Remark,
+		AccNoCredit:     in.PhoneNumber,
+		Fee:             in.Fee.String(),
+		Provider:        in.Provider,
+		Reference:       in.Reference,
+	})
+}
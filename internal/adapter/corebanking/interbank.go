@@ -0,0 +1,54 @@
+package corebanking
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+	"go.bankyaya.org/app/backend/internal/pkg/corebanking"
+)
+
+const (
+	interbankTransactionType         = "sa-ovb-interbank"
+	interbankReversalTransactionType = "sa-rev-interbank"
+)
+
+// InterbankCoreBanking posts the interbank transfers to the settlement account of the switching network.
+type InterbankCoreBanking struct {
+	*IntrabankCoreBanking
+	settlementAccount string
+}
+
+func NewInterbankCoreBanking(cb *IntrabankCoreBanking, cfg *config.Configs) *InterbankCoreBanking {
+	return &InterbankCoreBanking{
+		IntrabankCoreBanking: cb,
+		settlementAccount:    cfg.Interbank.SettlementAccount,
+	}
+}
+
+func (cb *InterbankCoreBanking) DebitTransfer(ctx context.Context, in *interbank.Debit) (*intrabank.OverbookingResult, error) {
+	return cb.overbook(ctx, corebanking.OverbookRequest{
+		TransactionType: interbankTransactionType,
+		AccNoSrc:        in.SourceAccount,
+		Amount:          in.Amount.String(),
+		TransactionInfo: in.Remark,
+		AccNoCredit:     cb.settlementAccount,
+		Fee:             in.Fee.String(),
+		Reference:       in.Reference,
+	})
+}
+
+// ReverseTransfer credits the amount back from the settlement account, the fee charged with the debit is returned with it.
+// The reversal has its own reference derived from the debit, so the core banking system posts it only once.
+func (cb *InterbankCoreBanking) ReverseTransfer(ctx context.Context, in *interbank.Debit) (*intrabank.OverbookingResult, error) {
+	return cb.overbook(ctx, corebanking.OverbookRequest{
+		TransactionType: interbankReversalTransactionType,
+		AccNoSrc:        cb.settlementAccount,
+		Amount:          (in.Amount + in.Fee).String(),
+		TransactionInfo: "REV " + in.Remark,
+		AccNoCredit:     in.SourceAccount,
+		Fee:             intrabank.Money(0).String(),
+		Reference:       reversalReference(in.Reference),
+	})
+}
@@ -2,6 +2,7 @@ package corebanking
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"go.bankyaya.org/app/backend/internal/domain/intrabank"
@@ -11,6 +12,8 @@ import (
 const (
 	transactionType = "sa-ovb-sa"
 	successCode     = "00"
+	// postingNotFoundCode is the status code of a status check for a reference the core has never received.
+	postingNotFoundCode = "25"
 )
 
 type IntrabankCoreBanking struct {
@@ -78,19 +81,56 @@ func (cb *IntrabankCoreBanking) GetAccountDetails(ctx context.Context, accountNu
 }
 
 func (cb *IntrabankCoreBanking) PerformOverbooking(ctx context.Context, req *intrabank.OverbookingInput) (*intrabank.OverbookingResult, error) {
-	ovb, err := cb.client.Overbook(ctx, corebanking.OverbookRequest{
+	return cb.overbook(ctx, corebanking.OverbookRequest{
 		TransactionType: transactionType,
 		AccNoSrc:        req.SourceAccount,
 		Amount:          req.Amount.String(),
 		TransactionInfo: req.Remark,
 		AccNoCredit:     req.DestinationAccount,
 		Fee:             req.Fee.String(),
+		Reference:       req.Reference,
 	})
+}
+
+// GetPostingStatus checks the posting with the reference, the data of a found posting is its original overbook response.
+func (cb *IntrabankCoreBanking) GetPostingStatus(ctx context.Context, reference string) (*intrabank.OverbookingResult, error) {
+	resp, err := cb.client.PostingStatus(ctx, reference)
 	if err != nil {
 		return nil, err
 	}
-	if ovb.Code != "00" {
-		return nil, fmt.Errorf("core banking overbook failed: %s (%s)", ovb.Description, ovb.Code)
+	if resp.Code == postingNotFoundCode {
+		return nil, intrabank.ErrPostingNotFound
+	}
+	if resp.Code != successCode || resp.Data == nil {
+		return nil, fmt.Errorf("core banking: %s (%s)", resp.Description, resp.Code)
+	}
+	return overbookResult(resp.Data)
+}
+
+// overbook posts the overbooking request.
+func (cb *IntrabankCoreBanking) overbook(ctx context.Context, req corebanking.OverbookRequest) (*intrabank.OverbookingResult, error) {
+	ovb, err := cb.client.Overbook(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return overbookResult(ovb)
+}
+
+// reversalReference returns the reference of the reversal of the posting with the reference.
+func reversalReference(reference string) string {
+	return "REV" + reference
+}
+
+// overbookResult maps the overbook response, a status code other than success is a rejection.
+func overbookResult(ovb *corebanking.OverbookResponse) (*intrabank.OverbookingResult, error) {
+	if ovb.Code != successCode {
+		// The response is kept as the audit payload of the failed transaction.
+		payload, _ := json.Marshal(ovb)
+		return nil, &intrabank.OverbookingRejection{
+			StatusCode:  ovb.Code,
+			Description: ovb.Description,
+			Payload:     string(payload),
+		}
 	}
 	return &intrabank.OverbookingResult{
 		JournalSequence:      ovb.JournalSequence,
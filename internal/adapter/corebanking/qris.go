@@ -0,0 +1,68 @@
+package corebanking
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+	"go.bankyaya.org/app/backend/internal/pkg/corebanking"
+)
+
+const (
+	qrisTransactionType         = "sa-ovb-qris"
+	qrisReversalTransactionType = "sa-rev-qris"
+	qrisCreditTransactionType   = "qris-ovb-sa"
+)
+
+// QRISCoreBanking posts the QRIS payments to the QRIS settlement account.
+type QRISCoreBanking struct {
+	*IntrabankCoreBanking
+	settlementAccount string
+}
+
+func NewQRISCoreBanking(cb *IntrabankCoreBanking, cfg *config.Configs) *QRISCoreBanking {
+	return &QRISCoreBanking{
+		IntrabankCoreBanking: cb,
+		settlementAccount:    cfg.QRIS.SettlementAccount,
+	}
+}
+
+func (cb *QRISCoreBanking) DebitPayment(ctx context.Context, in *qris.Debit) (*intrabank.OverbookingResult, error) {
+	return cb.overbook(ctx, corebanking.OverbookRequest{
+		TransactionType: qrisTransactionType,
+		AccNoSrc:        in.SourceAccount,
+		Amount:          in.Amount.String(),
+		TransactionInfo: in.Remark,
+		AccNoCredit:     cb.settlementAccount,
+		Fee:             in.Fee.String(),
+		Reference:       in.Reference,
+	})
+}
+
+// ReversePayment credits the amount back from the settlement account, the fee charged with the debit is returned with it.
+// The reversal has its own reference derived from the debit, so the core banking system posts it only once.
+func (cb *QRISCoreBanking) ReversePayment(ctx context.Context, in *qris.Debit) (*intrabank.OverbookingResult, error) {
+	return cb.overbook(ctx, corebanking.OverbookRequest{
+		TransactionType: qrisReversalTransactionType,
+		AccNoSrc:        cb.settlementAccount,
+		Amount:          (in.Amount + in.Fee).String(),
+		TransactionInfo: "REV " + in.Remark,
+		AccNoCredit:     in.SourceAccount,
+		Fee:             intrabank.Money(0).String(),
+		Reference:       reversalReference(in.Reference),
+	})
+}
+
+// CreditInbound credits the money settled by the QRIS network from the settlement account to the account of the code.
+func (cb *QRISCoreBanking) CreditInbound(ctx context.Context, credit *qris.InboundCredit) (*intrabank.OverbookingResult, error) {
+	return cb.overbook(ctx, corebanking.OverbookRequest{
+		TransactionType: qrisCreditTransactionType,
+		AccNoSrc:        cb.settlementAccount,
+		Amount:          credit.Amount.String(),
+		TransactionInfo: credit.Remark(),
+		AccNoCredit:     credit.AccountNumber,
+		Fee:             intrabank.Money(0).String(),
+		Reference:       credit.NetworkReference,
+	})
+}
@@ -0,0 +1,100 @@
+package corebanking
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/statement"
+	"go.bankyaya.org/app/backend/internal/pkg/corebanking"
+)
+
+const (
+	// historyDateLayout is the layout of the dates of the account history, e.g. "25-03-2025".
+	historyDateLayout = "02-01-2006"
+	// historyTimeLayout is the layout of the posting time of a mutation, in the business time zone.
+	historyTimeLayout = "02-01-2006 15:04:05"
+	debitMutation     = "D"
+	creditMutation    = "C"
+)
+
+// GetLedger reads every page of the account history of the business days in the [from, to) time range.
+// The opening balance is taken from the first page and the closing balance from the last one,
+// any failed page or missing mutation fails the whole ledger.
+func (cb *IntrabankCoreBanking) GetLedger(ctx context.Context, accountNumber string, from, to time.Time) (*statement.Ledger, error) {
+	req := corebanking.AccountHistoryRequest{
+		AccountNumber: accountNumber,
+		StartDate:     from.In(intrabank.BusinessLocation).Format(historyDateLayout),
+		EndDate:       to.In(intrabank.BusinessLocation).AddDate(0, 0, -1).Format(historyDateLayout),
+	}
+
+	ledger := new(statement.Ledger)
+	totalRecords := 0
+	for page, totalPages := 1, 1; page <= totalPages; page++ {
+		req.Page = page
+		resp, err := cb.client.AccountHistory(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code != successCode || resp.Data == nil {
+			return nil, fmt.Errorf("core banking: %s (%s)", resp.Description, resp.Code)
+		}
+		data := resp.Data
+		if data.Page != page {
+			return nil, fmt.Errorf("account history: requested page %d, got page %d", page, data.Page)
+		}
+
+		if page == 1 {
+			totalPages, totalRecords = data.TotalPages, data.TotalRecords
+			if ledger.OpeningBalance, err = intrabank.ParseMoney(data.OpeningBalance); err != nil {
+				return nil, err
+			}
+		}
+		if page == totalPages {
+			if ledger.ClosingBalance, err = intrabank.ParseMoney(data.ClosingBalance); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, m := range data.Mutations {
+			mutation, err := ledgerMutation(m)
+			if err != nil {
+				return nil, err
+			}
+			ledger.Mutations = append(ledger.Mutations, mutation)
+		}
+	}
+	if len(ledger.Mutations) != totalRecords {
+		return nil, fmt.Errorf("account history: expected %d mutations, got %d", totalRecords, len(ledger.Mutations))
+	}
+
+	return ledger, nil
+}
+
+// ledgerMutation maps a mutation of the account history.
+func ledgerMutation(m corebanking.AccountMutation) (*statement.Mutation, error) {
+	postedAt, err := time.ParseInLocation(historyTimeLayout, m.PostedAt, intrabank.BusinessLocation)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := intrabank.ParseMoney(m.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	mutation := &statement.Mutation{
+		PostedAt:    postedAt,
+		Description: m.Description,
+		Reference:   m.Reference,
+	}
+	switch m.Type {
+	case debitMutation:
+		mutation.Debit = amount
+	case creditMutation:
+		mutation.Credit = amount
+	default:
+		return nil, fmt.Errorf("account history: mutation (%s) has unknown type %q", m.Reference, m.Type)
+	}
+	return mutation, nil
+}
@@ -47,6 +47,41 @@ var intrabankTmpl = `<!DOCTYPE html>
 </body>
 </html>`
 
+var intrabankFailedTmpl = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>Your transfer has failed</title>
+</head>
+<body style="max-width: 1024px;margin: 0 auto">
+<div style="font-family: Helvetica,Arial,sans-serif;min-width:1000px;overflow:auto;line-height:2">
+  <div style="margin:50px auto;width:70%;padding:20px 0">
+    <div style="border-bottom:1px solid #eee">
+      <a href="" style="font-size:1.4em;color: #00466a;text-decoration:none;font-weight:600">
+          {{.CompanyName}}
+      </a>
+    </div>
+    <p style="font-size:1.1em">Hi, {{.SourceName}}!</p>
+    <p>Your transfer has failed and no money has been taken from your account</p>
+    <p>Source Account: {{.SourceAccount}}</p>
+    <p>To: {{.DestinationName}}</p>
+    <p>Destination Account: {{.DestinationAccount}}</p>
+    <p>Destination Bank: {{.DestinationBank}}</p>
+    <p>Amount: {{.Amount}}</p>
+    <p>Note: {{.Note}}</p>
+    <p>Please contact 1069 069 if you need any help.</p>
+    <p style="font-size:0.9em;">Regards,<br/>{{.CompanyName}}</p>
+    <hr style="border:none;border-top:1px solid #eee"/>
+    <div style="float:right;padding:8px 0;color:#aaa;font-size:0.8em;line-height:1;font-weight:300">
+      <p>{{.CompanyName}}</p>
+      <p>Jakarta</p>
+      <p>Indonesia</p>
+    </div>
+  </div>
+</div>
+</body>
+</html>`
+
 type IntrabankEmail struct {
 	log    *logger.Logger
 	client *mailtrap.Client
@@ -60,7 +95,11 @@ func NewTransferEmail(log *logger.Logger, client *mailtrap.Client) *IntrabankEma
 }
 
 func (e *IntrabankEmail) SendReceipt(_ context.Context, data *intrabank.EmailData) error {
-	body, err := parseIntrabankTemplate(map[string]any{
+	parse := parseIntrabankTemplate
+	if data.Status == intrabank.TransactionFailed {
+		parse = parseIntrabankFailedTemplate
+	}
+	body, err := parse(map[string]any{
 		"CompanyName":        constant.BankYayaCompanyName,
 		"SourceName":         data.SourceName,
 		"SourceAccount":      data.SourceAccount,
@@ -94,8 +133,18 @@ var intrabankTmplBuf = new(bytes.Buffer)
 // parseTransferTemplate generates an email template with provided data.
 // It returns the generated template as a byte slice or an error if template execution fails.
 func parseIntrabankTemplate(data map[string]any) ([]byte, error) {
+	return executeIntrabankTemplate("intrabank", intrabankTmpl, data)
+}
+
+// parseIntrabankFailedTemplate generates the failed transfer email template with provided data.
+func parseIntrabankFailedTemplate(data map[string]any) ([]byte, error) {
+	return executeIntrabankTemplate("intrabank-failed", intrabankFailedTmpl, data)
+}
+
+// executeIntrabankTemplate parses the template text and executes it with provided data.
+func executeIntrabankTemplate(name, text string, data map[string]any) ([]byte, error) {
 	defer intrabankTmplBuf.Reset()
-	tmpl := template.Must(template.New("intrabank").Parse(intrabankTmpl))
+	tmpl := template.Must(template.New(name).Parse(text))
 	err := tmpl.Execute(intrabankTmplBuf, data)
 	if err != nil {
 		return nil, err
@@ -61,3 +61,19 @@ func TestParseIntrabankTemplate(t *testing.T) {
 
 	assert.Equal(t, receiptHTML, tmpl)
 }
+
+func TestParseIntrabankFailedTemplate(t *testing.T) {
+	tmpl, err := parseIntrabankFailedTemplate(map[string]any{
+		"CompanyName":        constant.BankYayaCompanyName,
+		"SourceName":         "Oyen",
+		"SourceAccount":      "12345",
+		"DestinationName":    "Chiko",
+		"DestinationAccount": "54321",
+		"DestinationBank":    constant.BankYayaCompanyName,
+		"Amount":             50000,
+		"Note":               "test",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, string(tmpl), "<p>Your transfer has failed and no money has been taken from your account</p>")
+	assert.Contains(t, string(tmpl), "<p>To: Chiko</p>")
+}
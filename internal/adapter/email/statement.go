@@ -0,0 +1,93 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+
+	"go.bankyaya.org/app/backend/internal/domain/statement"
+	"go.bankyaya.org/app/backend/internal/pkg/constant"
+	"go.bankyaya.org/app/backend/internal/pkg/email/mailtrap"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+)
+
+var statementTmpl = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>Your account statement</title>
+</head>
+<body style="max-width: 1024px;margin: 0 auto">
+<div style="font-family: Helvetica,Arial,sans-serif;min-width:1000px;overflow:auto;line-height:2">
+  <div style="margin:50px auto;width:70%;padding:20px 0">
+    <div style="border-bottom:1px solid #eee">
+      <a href="" style="font-size:1.4em;color: #00466a;text-decoration:none;font-weight:600">
+          {{.CompanyName}}
+      </a>
+    </div>
+    <p style="font-size:1.1em">Hi, {{.Name}}!</p>
+    <p>Attached is the statement of your account {{.AccountNumber}} for {{.From}} - {{.To}}.</p>
+    <p>Verification reference: <b>{{.Reference}}</b></p>
+    <p style="font-size:0.9em;">Regards,<br/>{{.CompanyName}}</p>
+    <hr style="border:none;border-top:1px solid #eee"/>
+    <div style="float:right;padding:8px 0;color:#aaa;font-size:0.8em;line-height:1;font-weight:300">
+      <p>{{.CompanyName}}</p>
+      <p>Jakarta</p>
+      <p>Indonesia</p>
+    </div>
+  </div>
+</div>
+</body>
+</html>`
+
+type StatementEmail struct {
+	log    *logger.Logger
+	client *mailtrap.Client
+}
+
+func NewStatementEmail(log *logger.Logger, client *mailtrap.Client) *StatementEmail {
+	return &StatementEmail{
+		log:    log,
+		client: client,
+	}
+}
+
+func (e *StatementEmail) SendStatement(_ context.Context, delivery *statement.Delivery) error {
+	doc := delivery.Document
+	body, err := parseStatementTemplate(map[string]any{
+		"CompanyName":   constant.BankYayaCompanyName,
+		"Name":          delivery.Name,
+		"AccountNumber": doc.Statement.AccountNumber,
+		"From":          doc.Statement.Period.From.Format("02 Jan 2006"),
+		"To":            doc.Statement.Period.To.Format("02 Jan 2006"),
+		"Reference":     doc.Statement.Reference,
+	})
+	if err != nil {
+		e.log.Errorf("SendStatement error: %v", err)
+		return err
+	}
+	err = e.client.Send(mailtrap.Data{
+		Recipient: delivery.Recipient,
+		Subject:   "Your account statement " + doc.Statement.AccountNumber,
+		Body:      body,
+		Attachments: []mailtrap.Attachment{
+			{Name: doc.FileName, ContentType: doc.ContentType, Content: doc.Content},
+		},
+	})
+	if err != nil {
+		e.log.Errorf("SendStatement error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// parseStatementTemplate generates the statement email template with provided data.
+// It returns the generated template as a byte slice or an error if template execution fails.
+func parseStatementTemplate(data map[string]any) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	tmpl := template.Must(template.New("statement").Parse(statementTmpl))
+	if err := tmpl.Execute(buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,24 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/pkg/constant"
+)
+
+func TestParseStatementTemplate(t *testing.T) {
+	tmpl, err := parseStatementTemplate(map[string]any{
+		"CompanyName":   constant.BankYayaCompanyName,
+		"Name":          "Oyen",
+		"AccountNumber": "001001234567891",
+		"From":          "01 Sep 2026",
+		"To":            "30 Sep 2026",
+		"Reference":     "STMB3C2D1E0F9A8",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, string(tmpl), "PT. Bank Yaya Sumber Uang Tiada Tara (Persero)")
+	assert.Contains(t, string(tmpl), "Hi, Oyen!")
+	assert.Contains(t, string(tmpl), "Attached is the statement of your account 001001234567891 for 01 Sep 2026 - 30 Sep 2026.")
+	assert.Contains(t, string(tmpl), "Verification reference: <b>STMB3C2D1E0F9A8</b>")
+}
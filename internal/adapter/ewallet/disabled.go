@@ -0,0 +1,19 @@
+package ewallet
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/ewallet"
+)
+
+// DisabledDirectory is provided when no e-wallet provider is configured, so the e-wallet top-ups are unavailable
+// while the rest of the application keeps running.
+type DisabledDirectory struct{}
+
+func NewDisabledDirectory() *DisabledDirectory {
+	return &DisabledDirectory{}
+}
+
+func (d *DisabledDirectory) FindAccount(ctx context.Context, provider *ewallet.Provider, phoneNumber string) (*ewallet.Account, error) {
+	return nil, ewallet.ErrRailUnavailable
+}
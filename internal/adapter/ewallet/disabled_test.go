@@ -0,0 +1,17 @@
+package ewallet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/ewallet"
+)
+
+func TestDisabledDirectoryFindAccount(t *testing.T) {
+	directory := NewDisabledDirectory()
+
+	account, err := directory.FindAccount(context.Background(), &ewallet.Provider{Code: "GOPAY", Name: "GoPay"}, "081234567890")
+	assert.Nil(t, account)
+	assert.ErrorIs(t, err, ewallet.ErrRailUnavailable)
+}
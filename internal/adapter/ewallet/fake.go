@@ -0,0 +1,34 @@
+// Package ewallet provides the adapters of the e-wallet providers that hold the e-wallet accounts.
+package ewallet
+
+import (
+	"context"
+	"strings"
+
+	"go.bankyaya.org/app/backend/internal/domain/ewallet"
+)
+
+const (
+	// unknownPhoneSuffix marks the phone numbers that have no account at the fake providers.
+	unknownPhoneSuffix = "0000"
+
+	fakeAccountName = "OLIVIA RODRIGO"
+)
+
+// FakeDirectory stands in for the account lookups of the e-wallet providers in tests and local development.
+// Every phone number has an account at every provider, except the phone numbers ending in 0000.
+type FakeDirectory struct{}
+
+func NewFakeDirectory() *FakeDirectory {
+	return &FakeDirectory{}
+}
+
+func (d *FakeDirectory) FindAccount(ctx context.Context, provider *ewallet.Provider, phoneNumber string) (*ewallet.Account, error) {
+	if strings.HasSuffix(phoneNumber, unknownPhoneSuffix) {
+		return nil, ewallet.ErrAccountNotFound
+	}
+	return &ewallet.Account{
+		PhoneNumber: phoneNumber,
+		Name:        fakeAccountName,
+	}, nil
+}
@@ -0,0 +1,22 @@
+package ewallet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/ewallet"
+)
+
+func TestFakeDirectoryFindAccount(t *testing.T) {
+	directory := NewFakeDirectory()
+	gopay := &ewallet.Provider{Code: "GOPAY", Name: "GoPay"}
+
+	account, err := directory.FindAccount(context.Background(), gopay, "081234567890")
+	assert.NoError(t, err)
+	assert.Equal(t, &ewallet.Account{PhoneNumber: "081234567890", Name: "OLIVIA RODRIGO"}, account)
+
+	account, err = directory.FindAccount(context.Background(), gopay, "081234560000")
+	assert.Nil(t, account)
+	assert.ErrorIs(t, err, ewallet.ErrAccountNotFound)
+}
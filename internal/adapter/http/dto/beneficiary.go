@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/beneficiary"
+)
+
+type BeneficiaryRequest struct {
+	AccountNumber string `json:"accountNumber" validate:"required"`
+	Nickname      string `json:"nickname"`
+}
+
+type BeneficiaryNicknameRequest struct {
+	Nickname string `json:"nickname"`
+}
+
+type BeneficiaryResponse struct {
+	ID            int64     `json:"id"`
+	AccountNumber string    `json:"accountNumber"`
+	AccountName   string    `json:"accountName"`
+	Nickname      string    `json:"nickname"`
+	DisplayName   string    `json:"displayName"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+func NewBeneficiaryResponse(b *beneficiary.Beneficiary) *BeneficiaryResponse {
+	return &BeneficiaryResponse{
+		ID:            b.ID,
+		AccountNumber: b.AccountNumber,
+		AccountName:   b.AccountName,
+		Nickname:      b.Nickname,
+		DisplayName:   b.DisplayName(),
+		CreatedAt:     b.CreatedAt,
+		UpdatedAt:     b.UpdatedAt,
+	}
+}
+
+func NewBeneficiaryListResponse(beneficiaries []*beneficiary.Beneficiary) []*BeneficiaryResponse {
+	resp := make([]*BeneficiaryResponse, 0, len(beneficiaries))
+	for _, b := range beneficiaries {
+		resp = append(resp, NewBeneficiaryResponse(b))
+	}
+	return resp
+}
@@ -0,0 +1,156 @@
+package dto
+
+import (
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+type BillProductResponse struct {
+	Code          string  `json:"code"`
+	Name          string  `json:"name"`
+	Category      string  `json:"category"`
+	Denominations []int64 `json:"denominations"`
+}
+
+func newBillProductResponse(product *billpayment.Product) *BillProductResponse {
+	resp := &BillProductResponse{
+		Code:          product.Code,
+		Name:          product.Name,
+		Category:      string(product.Category),
+		Denominations: make([]int64, 0, len(product.Denominations)),
+	}
+	for _, denomination := range product.Denominations {
+		resp.Denominations = append(resp.Denominations, int64(denomination))
+	}
+	return resp
+}
+
+func NewBillCatalogResponse(products []*billpayment.Product) []*BillProductResponse {
+	resp := make([]*BillProductResponse, 0, len(products))
+	for _, product := range products {
+		resp = append(resp, newBillProductResponse(product))
+	}
+	return resp
+}
+
+type BillDetailResponse struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+func newBillDetailResponses(details []billpayment.Detail) []*BillDetailResponse {
+	resp := make([]*BillDetailResponse, 0, len(details))
+	for _, detail := range details {
+		resp = append(resp, &BillDetailResponse{Label: detail.Label, Value: detail.Value})
+	}
+	return resp
+}
+
+type BillInquiryRequest struct {
+	ProductCode   string `json:"productCode" validate:"required"`
+	CustomerID    string `json:"customerId" validate:"required"`
+	SourceAccount string `json:"sourceAccount" validate:"required"`
+	// Amount is the denomination bought for a prepaid product, e.g. a PLN token.
+	Amount int64 `json:"amount" validate:"gte=0"`
+}
+
+// ToInquiryInput converts the request to an inquiry made through the channel.
+func (r *BillInquiryRequest) ToInquiryInput(channel string) *billpayment.InquiryInput {
+	return &billpayment.InquiryInput{
+		ProductCode:   r.ProductCode,
+		CustomerID:    r.CustomerID,
+		Amount:        intrabank.Money(r.Amount),
+		SourceAccount: r.SourceAccount,
+		Channel:       channel,
+	}
+}
+
+type BillInquiryResponse struct {
+	SequenceNumber string                `json:"sequenceNumber"`
+	SourceAccount  string                `json:"sourceAccount"`
+	Product        *BillProductResponse  `json:"product"`
+	CustomerID     string                `json:"customerId"`
+	CustomerName   string                `json:"customerName"`
+	Period         string                `json:"period"`
+	Amount         int64                 `json:"amount"`
+	AdminFee       int64                 `json:"adminFee"`
+	Fee            int64                 `json:"fee"`
+	TotalAmount    int64                 `json:"totalAmount"`
+	Details        []*BillDetailResponse `json:"details"`
+}
+
+func NewBillInquiryResponse(payment *billpayment.Payment) *BillInquiryResponse {
+	return &BillInquiryResponse{
+		SequenceNumber: payment.SequenceNumber,
+		SourceAccount:  payment.SourceAccount,
+		Product:        newBillProductResponse(payment.Product),
+		CustomerID:     payment.CustomerID,
+		CustomerName:   payment.CustomerName,
+		Period:         payment.Period,
+		Amount:         int64(payment.Amount),
+		AdminFee:       int64(payment.AdminFee),
+		Fee:            int64(payment.Fee),
+		TotalAmount:    int64(payment.Total()),
+		Details:        newBillDetailResponses(payment.Details),
+	}
+}
+
+// BillPaymentRequest pays a bill payment sequence, the amount is the bill and the admin fee of the inquiry.
+type BillPaymentRequest struct {
+	CustomerID    string `json:"customerId" validate:"required"`
+	SourceAccount string `json:"sourceAccount" validate:"required"`
+	Amount        int64  `json:"amount" validate:"required"`
+	Sequence      string `json:"sequence" validate:"required"`
+	// OTPID and OTPCode carry the transaction OTP sent for the sequence,
+	// required above the step-up threshold.
+	OTPID   int    `json:"otpId"`
+	OTPCode string `json:"otpCode"`
+}
+
+func (r *BillPaymentRequest) ToPaymentInput(idempotencyKey string) *intrabank.PaymentInput {
+	return &intrabank.PaymentInput{
+		SequenceNumber:     r.Sequence,
+		SourceAccount:      r.SourceAccount,
+		DestinationAccount: r.CustomerID,
+		Amount:             intrabank.Money(r.Amount),
+		IdempotencyKey:     idempotencyKey,
+		OTPID:              r.OTPID,
+		OTPCode:            r.OTPCode,
+	}
+}
+
+// BillReceiptResponse carries the receipt data of the biller, e.g. the token number of a PLN token.
+type BillReceiptResponse struct {
+	JournalSequence      string                `json:"journalSequence"`
+	ProductCode          string                `json:"productCode"`
+	ProductName          string                `json:"productName"`
+	CustomerID           string                `json:"customerId"`
+	CustomerName         string                `json:"customerName"`
+	Period               string                `json:"period"`
+	Amount               int64                 `json:"amount"`
+	Fee                  string                `json:"fee"`
+	TransactionReference string                `json:"transactionReference"`
+	BillerReference      string                `json:"billerReference"`
+	Remark               string                `json:"remark"`
+	Status               string                `json:"status"`
+	Receipt              []*BillDetailResponse `json:"receipt"`
+}
+
+func NewBillReceiptResponse(receipt *billpayment.Receipt) *BillReceiptResponse {
+	transaction, payment := receipt.Transaction, receipt.Payment
+	return &BillReceiptResponse{
+		JournalSequence:      transaction.SequenceJournal,
+		ProductCode:          payment.Product.Code,
+		ProductName:          payment.Product.Name,
+		CustomerID:           payment.CustomerID,
+		CustomerName:         payment.CustomerName,
+		Period:               payment.Period,
+		Amount:               int64(transaction.Amount),
+		Fee:                  transaction.Fee,
+		TransactionReference: transaction.TransactionReference,
+		BillerReference:      payment.BillerReference,
+		Remark:               transaction.Remarks,
+		Status:               transaction.Status,
+		Receipt:              newBillDetailResponses(payment.Receipt),
+	}
+}
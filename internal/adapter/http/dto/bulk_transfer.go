@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/bulktransfer"
+)
+
+type BulkTransferConfirmRequest struct {
+	// OTPID and OTPCode carry the transaction OTP sent for the bulk transfer reference.
+	OTPID   int    `json:"otpId" validate:"required"`
+	OTPCode string `json:"otpCode" validate:"required"`
+}
+
+type BulkTransferRowResponse struct {
+	Line                 int    `json:"line"`
+	DestinationAccount   string `json:"destinationAccount"`
+	DestinationName      string `json:"destinationName"`
+	Amount               int64  `json:"amount"`
+	Fee                  int64  `json:"fee"`
+	Note                 string `json:"note"`
+	Status               string `json:"status"`
+	Error                string `json:"error,omitempty"`
+	TransactionReference string `json:"transactionReference,omitempty"`
+}
+
+type BulkTransferResponse struct {
+	ID            int64                      `json:"id"`
+	Reference     string                     `json:"reference"`
+	SourceAccount string                     `json:"sourceAccount"`
+	FileName      string                     `json:"fileName"`
+	Status        string                     `json:"status"`
+	TotalRows     int                        `json:"totalRows"`
+	ValidRows     int                        `json:"validRows"`
+	Succeeded     int                        `json:"succeeded"`
+	Failed        int                        `json:"failed"`
+	TotalAmount   int64                      `json:"totalAmount"`
+	Rows          []*BulkTransferRowResponse `json:"rows"`
+	CreatedAt     time.Time                  `json:"createdAt"`
+	CompletedAt   *time.Time                 `json:"completedAt,omitempty"`
+}
+
+func NewBulkTransferResponse(batch *bulktransfer.Batch) *BulkTransferResponse {
+	resp := &BulkTransferResponse{
+		ID:            batch.ID,
+		Reference:     batch.Reference(),
+		SourceAccount: batch.SourceAccount,
+		FileName:      batch.FileName,
+		Status:        batch.Status,
+		TotalRows:     len(batch.Rows),
+		ValidRows:     batch.ValidRows(),
+		Succeeded:     batch.Succeeded(),
+		Failed:        batch.Failed(),
+		TotalAmount:   int64(batch.TotalAmount()),
+		Rows:          make([]*BulkTransferRowResponse, 0, len(batch.Rows)),
+		CreatedAt:     batch.CreatedAt,
+	}
+	if !batch.CompletedAt.IsZero() {
+		resp.CompletedAt = &batch.CompletedAt
+	}
+	for _, row := range batch.Rows {
+		resp.Rows = append(resp.Rows, &BulkTransferRowResponse{
+			Line:                 row.Line,
+			DestinationAccount:   row.DestinationAccount,
+			DestinationName:      row.DestinationName,
+			Amount:               int64(row.Amount),
+			Fee:                  int64(row.Fee),
+			Note:                 row.Note,
+			Status:               row.Status,
+			Error:                row.Error,
+			TransactionReference: row.TransactionReference,
+		})
+	}
+	return resp
+}
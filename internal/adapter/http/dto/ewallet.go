@@ -0,0 +1,122 @@
+package dto
+
+import (
+	"go.bankyaya.org/app/backend/internal/domain/ewallet"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+type EWalletProviderResponse struct {
+	Code           string `json:"code"`
+	Name           string `json:"name"`
+	Fee            int64  `json:"fee"`
+	MinAmount      int64  `json:"minAmount"`
+	MaxAmount      int64  `json:"maxAmount"`
+	MaxDailyAmount int64  `json:"maxDailyAmount"`
+}
+
+func NewEWalletProvidersResponse(providers []*ewallet.Provider) []*EWalletProviderResponse {
+	resp := make([]*EWalletProviderResponse, 0, len(providers))
+	for _, p := range providers {
+		resp = append(resp, &EWalletProviderResponse{
+			Code:           p.Code,
+			Name:           p.Name,
+			Fee:            int64(p.Limits.Fee),
+			MinAmount:      int64(p.Limits.MinAmount),
+			MaxAmount:      int64(p.Limits.MaxAmount),
+			MaxDailyAmount: int64(p.Limits.MaxDailyAmount),
+		})
+	}
+	return resp
+}
+
+type EWalletInquiryRequest struct {
+	ProviderCode  string `json:"providerCode" validate:"required"`
+	PhoneNumber   string `json:"phoneNumber" validate:"required"`
+	SourceAccount string `json:"sourceAccount" validate:"required"`
+	Amount        int64  `json:"amount" validate:"required"`
+}
+
+// ToInquiryInput converts the request to an inquiry made through the channel.
+func (r *EWalletInquiryRequest) ToInquiryInput(channel string) *ewallet.InquiryInput {
+	return &ewallet.InquiryInput{
+		ProviderCode:  r.ProviderCode,
+		PhoneNumber:   r.PhoneNumber,
+		Amount:        intrabank.Money(r.Amount),
+		SourceAccount: r.SourceAccount,
+		Channel:       channel,
+	}
+}
+
+type EWalletInquiryResponse struct {
+	SequenceNumber string `json:"sequenceNumber"`
+	SourceAccount  string `json:"sourceAccount"`
+	ProviderCode   string `json:"providerCode"`
+	ProviderName   string `json:"providerName"`
+	PhoneNumber    string `json:"phoneNumber"`
+	AccountName    string `json:"accountName"`
+	Amount         int64  `json:"amount"`
+	Fee            int64  `json:"fee"`
+	TotalAmount    int64  `json:"totalAmount"`
+}
+
+func NewEWalletInquiryResponse(topUp *ewallet.TopUp) *EWalletInquiryResponse {
+	return &EWalletInquiryResponse{
+		SequenceNumber: topUp.SequenceNumber,
+		SourceAccount:  topUp.SourceAccount,
+		ProviderCode:   topUp.ProviderCode,
+		ProviderName:   topUp.ProviderName,
+		PhoneNumber:    topUp.PhoneNumber,
+		AccountName:    topUp.AccountName,
+		Amount:         int64(topUp.Amount),
+		Fee:            int64(topUp.Fee),
+		TotalAmount:    int64(topUp.Total()),
+	}
+}
+
+// EWalletPaymentRequest pays a top-up sequence, the phone number is the normalized one of the inquiry.
+type EWalletPaymentRequest struct {
+	PhoneNumber   string `json:"phoneNumber" validate:"required"`
+	SourceAccount string `json:"sourceAccount" validate:"required"`
+	Amount        int64  `json:"amount" validate:"required"`
+	Sequence      string `json:"sequence" validate:"required"`
+	// OTPID and OTPCode carry the transaction OTP sent for the sequence,
+	// required above the step-up threshold.
+	OTPID   int    `json:"otpId"`
+	OTPCode string `json:"otpCode"`
+}
+
+func (r *EWalletPaymentRequest) ToPaymentInput(idempotencyKey string) *intrabank.PaymentInput {
+	return &intrabank.PaymentInput{
+		SequenceNumber:     r.Sequence,
+		SourceAccount:      r.SourceAccount,
+		DestinationAccount: r.PhoneNumber,
+		Amount:             intrabank.Money(r.Amount),
+		IdempotencyKey:     idempotencyKey,
+		OTPID:              r.OTPID,
+		OTPCode:            r.OTPCode,
+	}
+}
+
+type EWalletPaymentResponse struct {
+	JournalSequence      string `json:"journalSequence"`
+	PhoneNumber          string `json:"phoneNumber"`
+	AccountName          string `json:"accountName"`
+	Amount               int64  `json:"amount"`
+	Fee                  string `json:"fee"`
+	TransactionReference string `json:"transactionReference"`
+	Remark               string `json:"remark"`
+	Status               string `json:"status"`
+}
+
+func NewEWalletPaymentResponse(transaction *intrabank.Transaction) *EWalletPaymentResponse {
+	return &EWalletPaymentResponse{
+		JournalSequence:      transaction.SequenceJournal,
+		PhoneNumber:          transaction.Destination,
+		AccountName:          transaction.DestinationName,
+		Amount:               int64(transaction.Amount),
+		Fee:                  transaction.Fee,
+		TransactionReference: transaction.TransactionReference,
+		Remark:               transaction.Remarks,
+		Status:               transaction.Status,
+	}
+}
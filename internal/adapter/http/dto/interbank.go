@@ -0,0 +1,125 @@
+package dto
+
+import (
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+type BankResponse struct {
+	Code  string   `json:"code"`
+	Name  string   `json:"name"`
+	Rails []string `json:"rails"`
+}
+
+func NewBankListResponse(banks []*interbank.Bank) []*BankResponse {
+	resp := make([]*BankResponse, 0, len(banks))
+	for _, bank := range banks {
+		rails := make([]string, 0, len(bank.Rails))
+		for _, rail := range bank.Rails {
+			rails = append(rails, rail.String())
+		}
+		resp = append(resp, &BankResponse{
+			Code:  bank.Code,
+			Name:  bank.Name,
+			Rails: rails,
+		})
+	}
+	return resp
+}
+
+type InterbankInquiryRequest struct {
+	Amount             int64  `json:"amount" validate:"required"`
+	SourceAccount      string `json:"sourceAccount" validate:"required"`
+	BankCode           string `json:"bankCode" validate:"required"`
+	DestinationAccount string `json:"destinationAccount" validate:"required"`
+	Rail               string `json:"rail" validate:"required,oneof=ONLINE BIFAST SKN RTGS"`
+}
+
+// ToSequence converts the request to a sequence inquired through the channel.
+func (r *InterbankInquiryRequest) ToSequence(channel string) *intrabank.Sequence {
+	return &intrabank.Sequence{
+		Amount:             intrabank.Money(r.Amount),
+		SourceAccount:      r.SourceAccount,
+		DestinationAccount: r.DestinationAccount,
+		BankCode:           r.BankCode,
+		Rail:               r.Rail,
+		Channel:            channel,
+	}
+}
+
+type InterbankInquiryResponse struct {
+	SequenceNumber     string `json:"sequenceNumber"`
+	SourceAccount      string `json:"sourceAccount"`
+	BankCode           string `json:"bankCode"`
+	DestinationAccount string `json:"destinationAccount"`
+	DestinationName    string `json:"destinationName"`
+	Rail               string `json:"rail"`
+	Amount             int64  `json:"amount"`
+	Fee                int64  `json:"fee"`
+	TotalAmount        int64  `json:"totalAmount"`
+}
+
+func NewInterbankInquiryResponse(sequence *intrabank.Sequence) *InterbankInquiryResponse {
+	return &InterbankInquiryResponse{
+		SequenceNumber:     sequence.SequenceNumber,
+		SourceAccount:      sequence.SourceAccount,
+		BankCode:           sequence.BankCode,
+		DestinationAccount: sequence.DestinationAccount,
+		DestinationName:    sequence.DestinationName,
+		Rail:               sequence.Rail,
+		Amount:             int64(sequence.Amount),
+		Fee:                int64(sequence.Fee),
+		TotalAmount:        int64(sequence.Amount + sequence.Fee),
+	}
+}
+
+// InterbankPaymentRequest pays an interbank sequence,
+// the destination bank and rail are taken from the sequence.
+type InterbankPaymentRequest struct {
+	DestinationAccount string `json:"destinationAccount" validate:"required"`
+	SourceAccount      string `json:"sourceAccount" validate:"required"`
+	Amount             int64  `json:"amount" validate:"required"`
+	Sequence           string `json:"sequence" validate:"required"`
+	// OTPID and OTPCode carry the transaction OTP sent for the sequence,
+	// required above the step-up threshold or for a new destination.
+	OTPID   int    `json:"otpId"`
+	OTPCode string `json:"otpCode"`
+}
+
+func (r *InterbankPaymentRequest) ToPaymentInput(idempotencyKey string) *intrabank.PaymentInput {
+	return &intrabank.PaymentInput{
+		SequenceNumber:     r.Sequence,
+		SourceAccount:      r.SourceAccount,
+		DestinationAccount: r.DestinationAccount,
+		Amount:             intrabank.Money(r.Amount),
+		IdempotencyKey:     idempotencyKey,
+		OTPID:              r.OTPID,
+		OTPCode:            r.OTPCode,
+	}
+}
+
+type InterbankPaymentResponse struct {
+	JournalSequence        string `json:"journalSequence"`
+	BankCode               string `json:"bankCode"`
+	DestinationAccount     string `json:"destinationAccount"`
+	DestinationAccountName string `json:"destinationAccountName"`
+	Amount                 int64  `json:"amount"`
+	Fee                    string `json:"fee"`
+	TransactionReference   string `json:"transactionReference"`
+	Remark                 string `json:"remark"`
+	Status                 string `json:"status"`
+}
+
+func NewInterbankPaymentResponse(transaction *intrabank.Transaction) *InterbankPaymentResponse {
+	return &InterbankPaymentResponse{
+		JournalSequence:        transaction.SequenceJournal,
+		BankCode:               transaction.BankCode,
+		DestinationAccount:     transaction.Destination,
+		DestinationAccountName: transaction.DestinationName,
+		Amount:                 int64(transaction.Amount),
+		Fee:                    transaction.Fee,
+		TransactionReference:   transaction.TransactionReference,
+		Remark:                 transaction.Remarks,
+		Status:                 transaction.Status,
+	}
+}
@@ -1,26 +1,43 @@
 package dto
 
 import (
+	"encoding/base64"
+	"errors"
 	"strconv"
+	"time"
 
 	"go.bankyaya.org/app/backend/internal/domain/intrabank"
 )
 
+// dateLayout is the date format of the date query parameters.
+const dateLayout = "2006-01-02"
+
+var (
+	errInvalidDate   = errors.New("date must use the YYYY-MM-DD format")
+	errInvalidCursor = errors.New("invalid cursor")
+)
+
+// IntrabankInquiryRequest identifies the destination either by the account number
+// or by the ID of a saved beneficiary.
 type IntrabankInquiryRequest struct {
 	Amount             int64  `json:"amount" validate:"required"`
 	SourceAccount      string `json:"sourceAccount" validate:"required"`
-	DestinationAccount string `json:"destinationAccount" validate:"required"`
+	DestinationAccount string `json:"destinationAccount" validate:"required_without=BeneficiaryID"`
+	BeneficiaryID      int64  `json:"beneficiaryId"`
 }
 
 func (r *IntrabankInquiryRequest) StringAmount() string {
 	return strconv.Itoa(int(r.Amount))
 }
 
-func (r *IntrabankInquiryRequest) ToSequence() *intrabank.Sequence {
+// ToSequence converts the request to a sequence inquired through the channel.
+func (r *IntrabankInquiryRequest) ToSequence(channel string) *intrabank.Sequence {
 	return &intrabank.Sequence{
 		Amount:             intrabank.Money(r.Amount),
 		SourceAccount:      r.SourceAccount,
 		DestinationAccount: r.DestinationAccount,
+		BeneficiaryID:      r.BeneficiaryID,
+		Channel:            channel,
 	}
 }
 
@@ -28,6 +45,9 @@ type IntrabankInquiryResponse struct {
 	SequenceNumber     string `json:"sequenceNumber"`
 	SourceAccount      string `json:"sourceAccount"`
 	DestinationAccount string `json:"destinationAccount"`
+	Amount             int64  `json:"amount"`
+	Fee                int64  `json:"fee"`
+	TotalAmount        int64  `json:"totalAmount"`
 	Status             string `json:"status"`
 }
 
@@ -36,6 +56,10 @@ func NewIntrabankInquiryResponse(sequence *intrabank.Sequence) *IntrabankInquiry
 		SequenceNumber:     sequence.SequenceNumber,
 		SourceAccount:      sequence.SourceAccount,
 		DestinationAccount: sequence.DestinationAccount,
+		Amount:             int64(sequence.Amount),
+		Fee:                int64(sequence.Fee),
+		TotalAmount:        int64(sequence.Amount + sequence.Fee),
+		Status:             sequence.Status,
 	}
 }
 
@@ -45,6 +69,22 @@ type IntrabankPaymentRequest struct {
 	Amount             int64  `json:"amount" validate:"required"`
 	Sequence           string `json:"sequence" validate:"required"`
 	Notes              string `json:"notes"`
+	// OTPID and OTPCode carry the transaction OTP sent for the sequence,
+	// required above the step-up threshold or for a new destination.
+	OTPID   int    `json:"otpId"`
+	OTPCode string `json:"otpCode"`
+}
+
+func (r *IntrabankPaymentRequest) ToPaymentInput(idempotencyKey string) *intrabank.PaymentInput {
+	return &intrabank.PaymentInput{
+		SequenceNumber:     r.Sequence,
+		SourceAccount:      r.SourceAccount,
+		DestinationAccount: r.DestinationAccount,
+		Amount:             intrabank.Money(r.Amount),
+		IdempotencyKey:     idempotencyKey,
+		OTPID:              r.OTPID,
+		OTPCode:            r.OTPCode,
+	}
 }
 
 type IntrabankPaymentResponse struct {
@@ -78,3 +118,156 @@ func NewIntrabankPaymentResponse(transaction *intrabank.Transaction) *IntrabankP
 type IntrabankGetAccountRequest struct {
 	AccountNumber string `json:"accountNumber" validate:"required"`
 }
+
+type TransferHistoryRequest struct {
+	From            string `query:"from"`
+	To              string `query:"to"`
+	MinAmount       int64  `query:"minAmount"`
+	MaxAmount       int64  `query:"maxAmount"`
+	Status          string `query:"status"`
+	TransactionType string `query:"type"`
+	Cursor          string `query:"cursor"`
+	Limit           int    `query:"limit"`
+}
+
+// ToTransactionFilter converts the request into a transaction filter.
+// The date range is inclusive, both dates are business days in Jakarta time.
+func (r *TransferHistoryRequest) ToTransactionFilter() (*intrabank.TransactionFilter, error) {
+	filter := &intrabank.TransactionFilter{
+		MinAmount:       intrabank.Money(r.MinAmount),
+		MaxAmount:       intrabank.Money(r.MaxAmount),
+		Status:          r.Status,
+		TransactionType: r.TransactionType,
+		Limit:           r.Limit,
+	}
+	if r.From != "" {
+		from, err := time.Parse(dateLayout, r.From)
+		if err != nil {
+			return nil, errInvalidDate
+		}
+		filter.From, _ = intrabank.BusinessDay(from)
+	}
+	if r.To != "" {
+		to, err := time.Parse(dateLayout, r.To)
+		if err != nil {
+			return nil, errInvalidDate
+		}
+		_, filter.To = intrabank.BusinessDay(to)
+	}
+	if r.Cursor != "" {
+		cursor, err := decodeCursor(r.Cursor)
+		if err != nil {
+			return nil, errInvalidCursor
+		}
+		filter.Cursor = cursor
+	}
+	return filter, nil
+}
+
+type TransferHistoryResponse struct {
+	Transactions []*TransactionResponse `json:"transactions"`
+	NextCursor   string                 `json:"nextCursor,omitempty"`
+}
+
+func NewTransferHistoryResponse(page *intrabank.TransactionPage) *TransferHistoryResponse {
+	resp := &TransferHistoryResponse{
+		Transactions: make([]*TransactionResponse, 0, len(page.Transactions)),
+	}
+	for _, transaction := range page.Transactions {
+		resp.Transactions = append(resp.Transactions, NewTransactionResponse(transaction))
+	}
+	if page.NextCursor > 0 {
+		resp.NextCursor = encodeCursor(page.NextCursor)
+	}
+	return resp
+}
+
+type TransactionResponse struct {
+	TransactionReference   string    `json:"transactionReference"`
+	TransactionType        string    `json:"transactionType"`
+	DestinationAccount     string    `json:"destinationAccount"`
+	DestinationAccountName string    `json:"destinationAccountName"`
+	Amount                 int64     `json:"amount"`
+	Fee                    string    `json:"fee"`
+	Status                 string    `json:"status"`
+	Remark                 string    `json:"remark"`
+	Notes                  string    `json:"notes"`
+	StandingOrderID        int64     `json:"standingOrderId,omitempty"`
+	CreatedAt              time.Time `json:"createdAt"`
+}
+
+func NewTransactionResponse(transaction *intrabank.Transaction) *TransactionResponse {
+	return &TransactionResponse{
+		TransactionReference:   transaction.TransactionReference,
+		TransactionType:        transaction.TransactionType,
+		DestinationAccount:     transaction.Destination,
+		DestinationAccountName: transaction.DestinationName,
+		Amount:                 int64(transaction.Amount),
+		Fee:                    transaction.Fee,
+		Status:                 transaction.Status,
+		Remark:                 transaction.Remarks,
+		Notes:                  transaction.Note,
+		StandingOrderID:        transaction.StandingOrderID,
+		CreatedAt:              transaction.CreatedAt,
+	}
+}
+
+type TransactionDetailResponse struct {
+	TransactionReference   string     `json:"transactionReference"`
+	JournalSequence        string     `json:"journalSequence"`
+	SequenceNumber         string     `json:"sequenceNumber"`
+	TransactionType        string     `json:"transactionType"`
+	SourceAccount          string     `json:"sourceAccount"`
+	SourceAccountName      string     `json:"sourceAccountName"`
+	DestinationAccount     string     `json:"destinationAccount"`
+	DestinationAccountName string     `json:"destinationAccountName"`
+	BankCode               string     `json:"bankCode"`
+	Amount                 int64      `json:"amount"`
+	Fee                    string     `json:"fee"`
+	Status                 string     `json:"status"`
+	Remark                 string     `json:"remark"`
+	Notes                  string     `json:"notes"`
+	StandingOrderID        int64      `json:"standingOrderId,omitempty"`
+	CreatedAt              time.Time  `json:"createdAt"`
+	SuccessTransactionDate *time.Time `json:"successTransactionDate,omitempty"`
+}
+
+func NewTransactionDetailResponse(detail *intrabank.TransactionDetail) *TransactionDetailResponse {
+	transaction := detail.Transaction
+	resp := &TransactionDetailResponse{
+		TransactionReference:   transaction.TransactionReference,
+		JournalSequence:        transaction.SequenceJournal,
+		SequenceNumber:         transaction.SequenceNumber,
+		TransactionType:        transaction.TransactionType,
+		SourceAccount:          detail.Sequence.SourceAccount,
+		SourceAccountName:      detail.Sequence.SourceName,
+		DestinationAccount:     transaction.Destination,
+		DestinationAccountName: transaction.DestinationName,
+		BankCode:               transaction.BankCode,
+		Amount:                 int64(transaction.Amount),
+		Fee:                    transaction.Fee,
+		Status:                 transaction.Status,
+		Remark:                 transaction.Remarks,
+		Notes:                  transaction.Note,
+		StandingOrderID:        transaction.StandingOrderID,
+		CreatedAt:              transaction.CreatedAt,
+	}
+	if !transaction.SuccessTransactionDate.IsZero() {
+		resp.SuccessTransactionDate = &transaction.SuccessTransactionDate
+	}
+	return resp
+}
+
+// encodeCursor encodes the transaction ID into an opaque cursor.
+func encodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// decodeCursor decodes the opaque cursor into a transaction ID.
+func decodeCursor(cursor string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(b), 10, 64)
+}
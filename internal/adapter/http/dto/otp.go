@@ -11,13 +11,15 @@ type OTPRequest struct {
 	Phone   string `json:"phone"`
 	Channel string `json:"channel"`
 	Purpose string `json:"purpose"`
+	// Reference is the sequence number of the transfer authorized by a transaction OTP.
+	Reference string `json:"reference"`
 }
 
 type OTPResponse struct {
 	ID        int          `json:"id"`
-	Code      string       `json:"code"`
 	Channel   string       `json:"channel"`
 	Purpose   string       `json:"purpose"`
+	Reference string       `json:"reference,omitempty"`
 	Recipient OTPRecipient `json:"recipient"`
 	CreatedAt time.Time    `json:"createdAt"`
 	ExpiredAt time.Time    `json:"expiredAt"`
@@ -30,10 +32,10 @@ type OTPRecipient struct {
 
 func NewOTPResponse(otp *otp.OTP) *OTPResponse {
 	return &OTPResponse{
-		ID:      otp.ID,
-		Code:    otp.Code,
-		Channel: otp.Channel.String(),
-		Purpose: otp.Purpose.String(),
+		ID:        otp.ID,
+		Channel:   otp.Channel.String(),
+		Purpose:   otp.Purpose.String(),
+		Reference: otp.Reference,
 		Recipient: OTPRecipient{
 			Email: otp.User.Email,
 			Phone: otp.User.Phone,
@@ -51,13 +53,16 @@ type VerifyOTPRequest struct {
 	Phone   string `json:"phone"`
 	Channel string `json:"channel"`
 	Purpose string `json:"purpose"`
+	// Reference is the sequence number of the transfer authorized by a transaction OTP.
+	Reference string `json:"reference"`
 }
 
 func (r *VerifyOTPRequest) ToOTP() *otp.OTP {
 	return &otp.OTP{
-		Code:    r.Code,
-		User:    otp.NewUser(r.UserID, r.Name, r.Email, r.Phone),
-		Channel: otp.NewChannel(r.Channel),
-		Purpose: otp.NewPurpose(r.Purpose),
+		Code:      r.Code,
+		Reference: r.Reference,
+		User:      otp.NewUser(r.UserID, r.Name, r.Email, r.Phone),
+		Channel:   otp.NewChannel(r.Channel),
+		Purpose:   otp.NewPurpose(r.Purpose),
 	}
 }
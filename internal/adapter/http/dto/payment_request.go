@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/paymentrequest"
+)
+
+type PaymentRequestRequest struct {
+	PayerPhoneNumber string `json:"payerPhoneNumber" validate:"required"`
+	Amount           int64  `json:"amount" validate:"required"`
+	Note             string `json:"note"`
+	// ValidityDays is how many days the request stays payable, the default validity is used when it is empty.
+	ValidityDays int `json:"validityDays"`
+}
+
+// ToPaymentRequest converts the request into a payment request created at the given time.
+func (r *PaymentRequestRequest) ToPaymentRequest(now time.Time) *paymentrequest.PaymentRequest {
+	req := &paymentrequest.PaymentRequest{
+		Amount: intrabank.Money(r.Amount),
+		Note:   r.Note,
+	}
+	if r.ValidityDays != 0 {
+		req.ExpiresAt = now.AddDate(0, 0, r.ValidityDays)
+	}
+	return req
+}
+
+type PaymentRequestInquiryRequest struct {
+	SourceAccount string `json:"sourceAccount" validate:"required"`
+}
+
+type PaymentRequestPayRequest struct {
+	SourceAccount string `json:"sourceAccount" validate:"required"`
+	Sequence      string `json:"sequence" validate:"required"`
+	// OTPID and OTPCode carry the transaction OTP sent for the sequence,
+	// required above the step-up threshold or for a new destination.
+	OTPID   int    `json:"otpId"`
+	OTPCode string `json:"otpCode"`
+}
+
+func (r *PaymentRequestPayRequest) ToPaymentInput() *intrabank.PaymentInput {
+	return &intrabank.PaymentInput{
+		SequenceNumber: r.Sequence,
+		SourceAccount:  r.SourceAccount,
+		OTPID:          r.OTPID,
+		OTPCode:        r.OTPCode,
+	}
+}
+
+type PaymentRequestResponse struct {
+	ID                   int64      `json:"id"`
+	RequesterName        string     `json:"requesterName"`
+	PayerName            string     `json:"payerName"`
+	DestinationAccount   string     `json:"destinationAccount"`
+	Amount               int64      `json:"amount"`
+	Note                 string     `json:"note"`
+	Status               string     `json:"status"`
+	ExpiresAt            time.Time  `json:"expiresAt"`
+	TransactionReference string     `json:"transactionReference,omitempty"`
+	RespondedAt          *time.Time `json:"respondedAt,omitempty"`
+	CreatedAt            time.Time  `json:"createdAt"`
+}
+
+func NewPaymentRequestResponse(req *paymentrequest.PaymentRequest) *PaymentRequestResponse {
+	resp := &PaymentRequestResponse{
+		ID:                   req.ID,
+		RequesterName:        req.Requester.Name,
+		PayerName:            req.Payer.Name,
+		DestinationAccount:   req.DestinationAccount,
+		Amount:               int64(req.Amount),
+		Note:                 req.Note,
+		Status:               req.Status,
+		ExpiresAt:            req.ExpiresAt,
+		TransactionReference: req.TransactionReference,
+		CreatedAt:            req.CreatedAt,
+	}
+	if !req.RespondedAt.IsZero() {
+		resp.RespondedAt = &req.RespondedAt
+	}
+	return resp
+}
+
+func NewPaymentRequestListResponse(requests []*paymentrequest.PaymentRequest) []*PaymentRequestResponse {
+	resp := make([]*PaymentRequestResponse, 0, len(requests))
+	for _, req := range requests {
+		resp = append(resp, NewPaymentRequestResponse(req))
+	}
+	return resp
+}
@@ -0,0 +1,243 @@
+package dto
+
+import (
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+)
+
+type QRISScanRequest struct {
+	Payload string `json:"payload" validate:"required"`
+}
+
+type QRISMerchantResponse struct {
+	PAN          string `json:"pan"`
+	ID           string `json:"id"`
+	NMID         string `json:"nmid"`
+	Name         string `json:"name"`
+	City         string `json:"city"`
+	CategoryCode string `json:"categoryCode"`
+}
+
+func newQRISMerchantResponse(merchant *qris.Merchant) *QRISMerchantResponse {
+	return &QRISMerchantResponse{
+		PAN:          merchant.PAN,
+		ID:           merchant.ID,
+		NMID:         merchant.NMID,
+		Name:         merchant.Name,
+		City:         merchant.City,
+		CategoryCode: merchant.CategoryCode,
+	}
+}
+
+// QRISScanResponse tells the app whether the customer enters the amount or the tip.
+type QRISScanResponse struct {
+	Merchant       *QRISMerchantResponse `json:"merchant"`
+	Dynamic        bool                  `json:"dynamic"`
+	Amount         int64                 `json:"amount"`
+	TipIndicator   string                `json:"tipIndicator"`
+	FixedTip       int64                 `json:"fixedTip"`
+	TipBasisPoints int64                 `json:"tipBasisPoints"`
+	BillNumber     string                `json:"billNumber"`
+	TerminalLabel  string                `json:"terminalLabel"`
+}
+
+func NewQRISScanResponse(payload *qris.Payload) *QRISScanResponse {
+	return &QRISScanResponse{
+		Merchant:       newQRISMerchantResponse(payload.Merchant),
+		Dynamic:        payload.IsDynamic(),
+		Amount:         int64(payload.Amount),
+		TipIndicator:   payload.TipIndicator,
+		FixedTip:       int64(payload.FixedTip),
+		TipBasisPoints: payload.TipBasisPoints,
+		BillNumber:     payload.BillNumber,
+		TerminalLabel:  payload.TerminalLabel,
+	}
+}
+
+type QRISInquiryRequest struct {
+	Payload       string `json:"payload" validate:"required"`
+	SourceAccount string `json:"sourceAccount" validate:"required"`
+	// Amount is only read when the QR code carries no amount.
+	Amount int64 `json:"amount"`
+	// Tip is only read when the QR code prompts the customer for a tip.
+	Tip int64 `json:"tip"`
+}
+
+// ToInquiryInput converts the request to an inquiry made through the channel.
+func (r *QRISInquiryRequest) ToInquiryInput(channel string) *qris.InquiryInput {
+	return &qris.InquiryInput{
+		Payload:       r.Payload,
+		SourceAccount: r.SourceAccount,
+		Amount:        intrabank.Money(r.Amount),
+		Tip:           intrabank.Money(r.Tip),
+		Channel:       channel,
+	}
+}
+
+type QRISInquiryResponse struct {
+	SequenceNumber string                `json:"sequenceNumber"`
+	SourceAccount  string                `json:"sourceAccount"`
+	Merchant       *QRISMerchantResponse `json:"merchant"`
+	Amount         int64                 `json:"amount"`
+	Tip            int64                 `json:"tip"`
+	Fee            int64                 `json:"fee"`
+	TotalAmount    int64                 `json:"totalAmount"`
+}
+
+func NewQRISInquiryResponse(payment *qris.Payment) *QRISInquiryResponse {
+	return &QRISInquiryResponse{
+		SequenceNumber: payment.SequenceNumber,
+		SourceAccount:  payment.SourceAccount,
+		Merchant:       newQRISMerchantResponse(payment.Merchant),
+		Amount:         int64(payment.Amount),
+		Tip:            int64(payment.Tip),
+		Fee:            int64(payment.Fee),
+		TotalAmount:    int64(payment.Total()),
+	}
+}
+
+// QRISPaymentRequest pays a QRIS sequence, the amount is the amount and tip of the inquiry.
+type QRISPaymentRequest struct {
+	MerchantPAN   string `json:"merchantPan" validate:"required"`
+	SourceAccount string `json:"sourceAccount" validate:"required"`
+	Amount        int64  `json:"amount" validate:"required"`
+	Sequence      string `json:"sequence" validate:"required"`
+	// OTPID and OTPCode carry the transaction OTP sent for the sequence,
+	// required above the step-up threshold.
+	OTPID   int    `json:"otpId"`
+	OTPCode string `json:"otpCode"`
+}
+
+func (r *QRISPaymentRequest) ToPaymentInput(idempotencyKey string) *intrabank.PaymentInput {
+	return &intrabank.PaymentInput{
+		SequenceNumber:     r.Sequence,
+		SourceAccount:      r.SourceAccount,
+		DestinationAccount: r.MerchantPAN,
+		Amount:             intrabank.Money(r.Amount),
+		IdempotencyKey:     idempotencyKey,
+		OTPID:              r.OTPID,
+		OTPCode:            r.OTPCode,
+	}
+}
+
+type QRISPaymentResponse struct {
+	JournalSequence      string `json:"journalSequence"`
+	MerchantPAN          string `json:"merchantPan"`
+	MerchantName         string `json:"merchantName"`
+	Amount               int64  `json:"amount"`
+	Fee                  string `json:"fee"`
+	TransactionReference string `json:"transactionReference"`
+	Remark               string `json:"remark"`
+	Status               string `json:"status"`
+}
+
+func NewQRISPaymentResponse(transaction *intrabank.Transaction) *QRISPaymentResponse {
+	return &QRISPaymentResponse{
+		JournalSequence:      transaction.SequenceJournal,
+		MerchantPAN:          transaction.Destination,
+		MerchantName:         transaction.DestinationName,
+		Amount:               int64(transaction.Amount),
+		Fee:                  transaction.Fee,
+		TransactionReference: transaction.TransactionReference,
+		Remark:               transaction.Remarks,
+		Status:               transaction.Status,
+	}
+}
+
+// QRISCodeRequest generates a QRIS code to receive money into the account,
+// a code with an amount expires after ExpiresInMinutes, one hour when it is zero.
+type QRISCodeRequest struct {
+	AccountNumber    string `json:"accountNumber" validate:"required"`
+	Amount           int64  `json:"amount" validate:"gte=0"`
+	ExpiresInMinutes int    `json:"expiresInMinutes" validate:"gte=0"`
+}
+
+func (r *QRISCodeRequest) ToCodeInput() *qris.CodeInput {
+	return &qris.CodeInput{
+		AccountNumber: r.AccountNumber,
+		Amount:        intrabank.Money(r.Amount),
+		ExpiresIn:     time.Duration(r.ExpiresInMinutes) * time.Minute,
+	}
+}
+
+// QRISCodeResponse carries the payload of the code and its PNG image encoded in base64.
+type QRISCodeResponse struct {
+	ID            int64                  `json:"id"`
+	AccountNumber string                 `json:"accountNumber"`
+	Name          string                 `json:"name"`
+	Amount        int64                  `json:"amount"`
+	Payload       string                 `json:"payload"`
+	Image         []byte                 `json:"image"`
+	ExpiresAt     *time.Time             `json:"expiresAt,omitempty"`
+	CreatedAt     time.Time              `json:"createdAt"`
+	Transfers     []*TransactionResponse `json:"transfers"`
+	Credits       []*QRISCreditResponse  `json:"credits"`
+}
+
+func NewQRISCodeResponse(code *qris.Code) *QRISCodeResponse {
+	resp := &QRISCodeResponse{
+		ID:            code.ID,
+		AccountNumber: code.AccountNumber,
+		Name:          code.Name,
+		Amount:        int64(code.Amount),
+		Payload:       code.Payload,
+		Image:         code.Image,
+		CreatedAt:     code.CreatedAt,
+		Transfers:     make([]*TransactionResponse, 0, len(code.Transfers)),
+		Credits:       make([]*QRISCreditResponse, 0, len(code.Credits)),
+	}
+	if !code.ExpiresAt.IsZero() {
+		resp.ExpiresAt = &code.ExpiresAt
+	}
+	for _, transaction := range code.Transfers {
+		resp.Transfers = append(resp.Transfers, NewTransactionResponse(transaction))
+	}
+	for _, credit := range code.Credits {
+		resp.Credits = append(resp.Credits, NewQRISCreditResponse(credit))
+	}
+	return resp
+}
+
+// QRISCreditRequest is the credit the QRIS network notifies when a code of the bank is paid from another bank,
+// the code is identified by its reference label, its PAN or both.
+type QRISCreditRequest struct {
+	NetworkReference string `json:"networkReference" validate:"required"`
+	PAN              string `json:"pan" validate:"required_without=ReferenceLabel"`
+	ReferenceLabel   string `json:"referenceLabel" validate:"required_without=PAN"`
+	Amount           int64  `json:"amount" validate:"gt=0"`
+	PayerName        string `json:"payerName"`
+	PayerBank        string `json:"payerBank"`
+}
+
+func (r *QRISCreditRequest) ToInboundCredit() *qris.InboundCredit {
+	return &qris.InboundCredit{
+		NetworkReference: r.NetworkReference,
+		PAN:              r.PAN,
+		ReferenceLabel:   r.ReferenceLabel,
+		Amount:           intrabank.Money(r.Amount),
+		PayerName:        r.PayerName,
+		PayerBank:        r.PayerBank,
+	}
+}
+
+type QRISCreditResponse struct {
+	NetworkReference     string    `json:"networkReference"`
+	Amount               int64     `json:"amount"`
+	PayerName            string    `json:"payerName"`
+	PayerBank            string    `json:"payerBank"`
+	TransactionReference string    `json:"transactionReference"`
+	CreatedAt            time.Time `json:"createdAt"`
+}
+
+func NewQRISCreditResponse(credit *qris.InboundCredit) *QRISCreditResponse {
+	return &QRISCreditResponse{
+		NetworkReference:     credit.NetworkReference,
+		Amount:               int64(credit.Amount),
+		PayerName:            credit.PayerName,
+		PayerBank:            credit.PayerBank,
+		TransactionReference: credit.TransactionReference,
+		CreatedAt:            credit.CreatedAt,
+	}
+}
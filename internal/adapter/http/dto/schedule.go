@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/schedule"
+)
+
+type ScheduleRequest struct {
+	SourceAccount      string `json:"sourceAccount" validate:"required"`
+	DestinationAccount string `json:"destinationAccount" validate:"required"`
+	Amount             int64  `json:"amount" validate:"required"`
+	ExecutionDate      string `json:"executionDate" validate:"required"`
+	// OTPID and OTPCode carry the transaction OTP sent for the schedule reference,
+	// "SCHEDULE-<sourceAccount>-<destinationAccount>-<amount>-<executionDate as YYYYMMDD>".
+	OTPID   int    `json:"otpId" validate:"required"`
+	OTPCode string `json:"otpCode" validate:"required"`
+}
+
+// ToSchedule converts the request into a schedule executed on the execution date in Jakarta time.
+func (r *ScheduleRequest) ToSchedule() (*schedule.Schedule, error) {
+	date, err := time.Parse(dateLayout, r.ExecutionDate)
+	if err != nil {
+		return nil, errInvalidDate
+	}
+	return &schedule.Schedule{
+		SourceAccount:      r.SourceAccount,
+		DestinationAccount: r.DestinationAccount,
+		Amount:             intrabank.Money(r.Amount),
+		ExecuteAt:          schedule.ExecutionTime(date),
+	}, nil
+}
+
+type ScheduleResponse struct {
+	ID                     int64      `json:"id"`
+	SourceAccount          string     `json:"sourceAccount"`
+	DestinationAccount     string     `json:"destinationAccount"`
+	DestinationAccountName string     `json:"destinationAccountName"`
+	Amount                 int64      `json:"amount"`
+	ExecuteAt              time.Time  `json:"executeAt"`
+	Status                 string     `json:"status"`
+	TransactionReference   string     `json:"transactionReference,omitempty"`
+	FailureReason          string     `json:"failureReason,omitempty"`
+	ExecutedAt             *time.Time `json:"executedAt,omitempty"`
+	CreatedAt              time.Time  `json:"createdAt"`
+}
+
+func NewScheduleResponse(sch *schedule.Schedule) *ScheduleResponse {
+	resp := &ScheduleResponse{
+		ID:                     sch.ID,
+		SourceAccount:          sch.SourceAccount,
+		DestinationAccount:     sch.DestinationAccount,
+		DestinationAccountName: sch.DestinationName,
+		Amount:                 int64(sch.Amount),
+		ExecuteAt:              sch.ExecuteAt,
+		Status:                 sch.Status,
+		TransactionReference:   sch.TransactionReference,
+		FailureReason:          sch.FailureReason,
+		CreatedAt:              sch.CreatedAt,
+	}
+	if !sch.ExecutedAt.IsZero() {
+		resp.ExecutedAt = &sch.ExecutedAt
+	}
+	return resp
+}
+
+func NewScheduleListResponse(schedules []*schedule.Schedule) []*ScheduleResponse {
+	resp := make([]*ScheduleResponse, 0, len(schedules))
+	for _, sch := range schedules {
+		resp = append(resp, NewScheduleResponse(sch))
+	}
+	return resp
+}
@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/standingorder"
+)
+
+type StandingOrderRequest struct {
+	SourceAccount      string `json:"sourceAccount" validate:"required"`
+	DestinationAccount string `json:"destinationAccount" validate:"required"`
+	Amount             int64  `json:"amount" validate:"required"`
+	Frequency          string `json:"frequency" validate:"required,oneof=daily weekly monthly end_of_month"`
+	BalancePolicy      string `json:"balancePolicy" validate:"required,oneof=skip retry"`
+	StartDate          string `json:"startDate" validate:"required"`
+	EndDate            string `json:"endDate"`
+	MaxRuns            int    `json:"maxRuns" validate:"gte=0"`
+	// OTPID and OTPCode carry the transaction OTP sent for the standing order reference,
+	// "STANDING-ORDER-<sourceAccount>-<destinationAccount>-<amount>-<frequency>-<startDate as YYYYMMDD>".
+	OTPID   int    `json:"otpId" validate:"required"`
+	OTPCode string `json:"otpCode" validate:"required"`
+}
+
+// ToStandingOrder converts the request into a standing order.
+// The end date is inclusive, runs on the end date are still made.
+func (r *StandingOrderRequest) ToStandingOrder() (*standingorder.StandingOrder, error) {
+	start, err := time.Parse(dateLayout, r.StartDate)
+	if err != nil {
+		return nil, errInvalidDate
+	}
+	order := &standingorder.StandingOrder{
+		SourceAccount:      r.SourceAccount,
+		DestinationAccount: r.DestinationAccount,
+		Amount:             intrabank.Money(r.Amount),
+		Frequency:          standingorder.Frequency(r.Frequency),
+		BalancePolicy:      standingorder.BalancePolicy(r.BalancePolicy),
+		StartAt:            standingorder.StartTime(start),
+		MaxRuns:            r.MaxRuns,
+	}
+	if r.EndDate != "" {
+		end, err := time.Parse(dateLayout, r.EndDate)
+		if err != nil {
+			return nil, errInvalidDate
+		}
+		order.EndAt = standingorder.StartTime(end)
+	}
+	return order, nil
+}
+
+type StandingOrderResponse struct {
+	ID                       int64      `json:"id"`
+	SourceAccount            string     `json:"sourceAccount"`
+	DestinationAccount       string     `json:"destinationAccount"`
+	DestinationAccountName   string     `json:"destinationAccountName"`
+	Amount                   int64      `json:"amount"`
+	Frequency                string     `json:"frequency"`
+	BalancePolicy            string     `json:"balancePolicy"`
+	StartAt                  time.Time  `json:"startAt"`
+	EndAt                    *time.Time `json:"endAt,omitempty"`
+	MaxRuns                  int        `json:"maxRuns,omitempty"`
+	Runs                     int        `json:"runs"`
+	NextRunAt                *time.Time `json:"nextRunAt,omitempty"`
+	Status                   string     `json:"status"`
+	LastRunAt                *time.Time `json:"lastRunAt,omitempty"`
+	LastTransactionReference string     `json:"lastTransactionReference,omitempty"`
+	LastFailureReason        string     `json:"lastFailureReason,omitempty"`
+	CreatedAt                time.Time  `json:"createdAt"`
+}
+
+func NewStandingOrderResponse(order *standingorder.StandingOrder) *StandingOrderResponse {
+	resp := &StandingOrderResponse{
+		ID:                       order.ID,
+		SourceAccount:            order.SourceAccount,
+		DestinationAccount:       order.DestinationAccount,
+		DestinationAccountName:   order.DestinationName,
+		Amount:                   int64(order.Amount),
+		Frequency:                string(order.Frequency),
+		BalancePolicy:            string(order.BalancePolicy),
+		StartAt:                  order.StartAt,
+		MaxRuns:                  order.MaxRuns,
+		Runs:                     order.Runs,
+		Status:                   order.Status,
+		LastTransactionReference: order.LastTransactionReference,
+		LastFailureReason:        order.LastFailureReason,
+		CreatedAt:                order.CreatedAt,
+	}
+	if !order.EndAt.IsZero() {
+		resp.EndAt = &order.EndAt
+	}
+	if order.Status == standingorder.StatusActive {
+		resp.NextRunAt = &order.NextRunAt
+	}
+	if !order.LastRunAt.IsZero() {
+		resp.LastRunAt = &order.LastRunAt
+	}
+	return resp
+}
+
+func NewStandingOrderListResponse(orders []*standingorder.StandingOrder) []*StandingOrderResponse {
+	resp := make([]*StandingOrderResponse, 0, len(orders))
+	for _, order := range orders {
+		resp = append(resp, NewStandingOrderResponse(order))
+	}
+	return resp
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/statement"
+)
+
+// StatementRequest is read from the query of a download and from the body of an email request.
+type StatementRequest struct {
+	AccountNumber string `query:"accountNumber" json:"accountNumber" validate:"required"`
+	From          string `query:"from" json:"from" validate:"required"`
+	To            string `query:"to" json:"to" validate:"required"`
+	Format        string `query:"format" json:"format"`
+}
+
+// ToInput converts the request into a statement input.
+// Both dates are included and are business days in Jakarta time, the format defaults to a PDF.
+func (r *StatementRequest) ToInput() (*statement.Input, error) {
+	from, err := time.ParseInLocation(dateLayout, r.From, intrabank.BusinessLocation)
+	if err != nil {
+		return nil, errInvalidDate
+	}
+	to, err := time.ParseInLocation(dateLayout, r.To, intrabank.BusinessLocation)
+	if err != nil {
+		return nil, errInvalidDate
+	}
+	format, err := statement.ParseFormat(r.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &statement.Input{
+		AccountNumber: r.AccountNumber,
+		Period:        statement.Period{From: from, To: to},
+		Format:        format,
+	}, nil
+}
+
+type StatementResponse struct {
+	Reference      string    `json:"reference"`
+	AccountNumber  string    `json:"accountNumber"`
+	AccountName    string    `json:"accountName"`
+	Currency       string    `json:"currency"`
+	From           string    `json:"from"`
+	To             string    `json:"to"`
+	OpeningBalance int64     `json:"openingBalance"`
+	ClosingBalance int64     `json:"closingBalance"`
+	TotalDebit     int64     `json:"totalDebit"`
+	TotalCredit    int64     `json:"totalCredit"`
+	IssuedAt       time.Time `json:"issuedAt"`
+}
+
+func NewStatementResponse(st *statement.Statement) *StatementResponse {
+	return &StatementResponse{
+		Reference:      st.Reference,
+		AccountNumber:  st.AccountNumber,
+		AccountName:    st.AccountName,
+		Currency:       st.Currency,
+		From:           st.Period.From.Format(dateLayout),
+		To:             st.Period.To.Format(dateLayout),
+		OpeningBalance: int64(st.OpeningBalance),
+		ClosingBalance: int64(st.ClosingBalance),
+		TotalDebit:     int64(st.TotalDebit),
+		TotalCredit:    int64(st.TotalCredit),
+		IssuedAt:       st.CreatedAt,
+	}
+}
+
+// StatementVerificationResponse is returned to the third parties verifying a statement, the account is masked.
+type StatementVerificationResponse struct {
+	Reference     string    `json:"reference"`
+	AccountNumber string    `json:"accountNumber"`
+	AccountName   string    `json:"accountName"`
+	From          string    `json:"from"`
+	To            string    `json:"to"`
+	IssuedAt      time.Time `json:"issuedAt"`
+}
+
+func NewStatementVerificationResponse(v *statement.Verification) *StatementVerificationResponse {
+	return &StatementVerificationResponse{
+		Reference:     v.Reference,
+		AccountNumber: v.AccountNumber,
+		AccountName:   v.AccountName,
+		From:          v.Period.From.Format(dateLayout),
+		To:            v.Period.To.Format(dateLayout),
+		IssuedAt:      v.IssuedAt,
+	}
+}
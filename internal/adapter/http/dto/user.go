@@ -13,7 +13,7 @@ type LoginRequest struct {
 	FirebaseID string `json:"firebaseID" validate:"required"`
 }
 
-func (r *LoginRequest) ToUser() *user.User {
+func (r *LoginRequest) ToUser(client string) *user.User {
 	return &user.User{
 		CIF:           "",
 		Password:      "",
@@ -22,7 +22,11 @@ func (r *LoginRequest) ToUser() *user.User {
 		Email:         "",
 		PhoneNumber:   r.Phone,
 		NIK:           "",
-		Device:        nil,
+		Device: &user.Device{
+			DeviceID:   r.DeviceID,
+			FirebaseID: r.FirebaseID,
+			Client:     client,
+		},
 	}
 }
 
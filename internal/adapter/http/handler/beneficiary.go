@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter/http/dto"
+	"go.bankyaya.org/app/backend/internal/adapter/http/response"
+	"go.bankyaya.org/app/backend/internal/domain/beneficiary"
+	"go.bankyaya.org/app/backend/internal/pkg/validation"
+)
+
+var errInvalidBeneficiaryID = errors.New("invalid beneficiary id")
+
+type Beneficiary struct {
+	va  *validation.Validator
+	svc *beneficiary.Service
+}
+
+func NewBeneficiaryHandler(va *validation.Validator, svc *beneficiary.Service) *Beneficiary {
+	return &Beneficiary{
+		va:  va,
+		svc: svc,
+	}
+}
+
+// Add swaggo annotation.
+//
+//	@Summary		Add beneficiary
+//	@Description	Verify an account and save it as a beneficiary
+//	@Tags			beneficiary
+//	@Accept			json
+//	@Produce		json
+//	@Param			BeneficiaryRequest	body		dto.BeneficiaryRequest	true	"Beneficiary request"
+//	@Success		200					{object}	response.Response
+//	@Failure		400					{object}	response.Response
+//	@Failure		401					{object}	response.Response
+//	@Failure		409					{object}	response.Response
+//	@Failure		500					{object}	response.Response
+//	@Router			/transfer/beneficiaries [post]
+func (h *Beneficiary) Add(ctx echo.Context) error {
+	req := new(dto.BeneficiaryRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	b, err := h.svc.Add(ctx.Request().Context(), req.AccountNumber, req.Nickname)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBeneficiaryResponse(b)
+	return ctx.JSON(response.Success(resp))
+}
+
+// List swaggo annotation.
+//
+//	@Summary		List beneficiaries
+//	@Description	Get all beneficiaries of the user
+//	@Tags			beneficiary
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/beneficiaries [get]
+func (h *Beneficiary) List(ctx echo.Context) error {
+	beneficiaries, err := h.svc.List(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBeneficiaryListResponse(beneficiaries)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Get swaggo annotation.
+//
+//	@Summary		Beneficiary detail
+//	@Description	Get a beneficiary of the user
+//	@Tags			beneficiary
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Beneficiary ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/beneficiaries/{id} [get]
+func (h *Beneficiary) Get(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidBeneficiaryID))
+	}
+	b, err := h.svc.Get(ctx.Request().Context(), id)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBeneficiaryResponse(b)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Update swaggo annotation.
+//
+//	@Summary		Update beneficiary
+//	@Description	Change the nickname of a beneficiary
+//	@Tags			beneficiary
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		int								true	"Beneficiary ID"
+//	@Param			BeneficiaryNicknameRequest	body		dto.BeneficiaryNicknameRequest	true	"Nickname request"
+//	@Success		200							{object}	response.Response
+//	@Failure		400							{object}	response.Response
+//	@Failure		401							{object}	response.Response
+//	@Failure		404							{object}	response.Response
+//	@Failure		500							{object}	response.Response
+//	@Router			/transfer/beneficiaries/{id} [put]
+func (h *Beneficiary) Update(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidBeneficiaryID))
+	}
+	req := new(dto.BeneficiaryNicknameRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	b, err := h.svc.UpdateNickname(ctx.Request().Context(), id, req.Nickname)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBeneficiaryResponse(b)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Delete swaggo annotation.
+//
+//	@Summary		Delete beneficiary
+//	@Description	Remove a beneficiary of the user
+//	@Tags			beneficiary
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Beneficiary ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/beneficiaries/{id} [delete]
+func (h *Beneficiary) Delete(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidBeneficiaryID))
+	}
+	if err := h.svc.Delete(ctx.Request().Context(), id); err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(nil))
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter/http/dto"
+	"go.bankyaya.org/app/backend/internal/adapter/http/response"
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/pkg/validation"
+)
+
+type BillPayment struct {
+	va  *validation.Validator
+	svc *billpayment.Service
+}
+
+func NewBillPaymentHandler(va *validation.Validator, svc *billpayment.Service) *BillPayment {
+	return &BillPayment{
+		va:  va,
+		svc: svc,
+	}
+}
+
+// Catalog swaggo annotation.
+//
+//	@Summary		Bill catalog
+//	@Description	Get the bills that can be paid
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/bills/catalog [get]
+func (h *BillPayment) Catalog(ctx echo.Context) error {
+	products, err := h.svc.Catalog(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBillCatalogResponse(products)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Inquiry swaggo annotation.
+//
+//	@Summary		Bill payment inquiry
+//	@Description	Check the outstanding bill of the customer and create new inquiry bill payment
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			InquiryRequest	body		dto.BillInquiryRequest	true	"Inquiry request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfer/bills/inquiry [post]
+func (h *BillPayment) Inquiry(ctx echo.Context) error {
+	req := new(dto.BillInquiryRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	payment, err := h.svc.Inquiry(ctx.Request().Context(), req.ToInquiryInput(channel(ctx)))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBillInquiryResponse(payment)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Payment swaggo annotation.
+//
+//	@Summary		Bill payment
+//	@Description	Performs bill payment and returns the receipt of the biller
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			PaymentRequest	body		dto.BillPaymentRequest	true	"Payment request"
+//	@Param			Idempotency-Key	header		string					false	"Idempotency key"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfer/bills/payment [post]
+func (h *BillPayment) Payment(ctx echo.Context) error {
+	req := new(dto.BillPaymentRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	idempotencyKey, err := clientIdempotencyKey(ctx)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	receipt, err := h.svc.DoPayment(ctx.Request().Context(), req.ToPaymentInput(idempotencyKey))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBillReceiptResponse(receipt)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Receipt swaggo annotation.
+//
+//	@Summary		Bill payment receipt
+//	@Description	Get the receipt of the paid bill with the receipt data of the biller
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			sequence	path		string	true	"Sequence number"
+//	@Success		200			{object}	response.Response
+//	@Failure		401			{object}	response.Response
+//	@Failure		404			{object}	response.Response
+//	@Failure		500			{object}	response.Response
+//	@Router			/transfer/bills/receipts/{sequence} [get]
+func (h *BillPayment) Receipt(ctx echo.Context) error {
+	receipt, err := h.svc.GetReceipt(ctx.Request().Context(), ctx.Param("sequence"))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBillReceiptResponse(receipt)
+	return ctx.JSON(response.Success(resp))
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter/http/dto"
+	"go.bankyaya.org/app/backend/internal/adapter/http/response"
+	"go.bankyaya.org/app/backend/internal/domain/bulktransfer"
+	"go.bankyaya.org/app/backend/internal/pkg/validation"
+)
+
+// maxBulkTransferFileSize is the maximum size of an uploaded bulk transfer file.
+const maxBulkTransferFileSize = 1 << 20
+
+var (
+	errInvalidBulkTransferID  = errors.New("invalid bulk transfer id")
+	errMissingSourceAccount   = errors.New("sourceAccount is required")
+	errBulkTransferFileTooBig = errors.New("the file must not be larger than 1 MB")
+)
+
+type BulkTransfer struct {
+	va  *validation.Validator
+	svc *bulktransfer.Service
+}
+
+func NewBulkTransferHandler(va *validation.Validator, svc *bulktransfer.Service) *BulkTransfer {
+	return &BulkTransfer{
+		va:  va,
+		svc: svc,
+	}
+}
+
+// Preview swaggo annotation.
+//
+//	@Summary		Upload bulk transfer
+//	@Description	Upload a CSV file of destination account, amount and note, and validate every row
+//	@Tags			transfer
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			sourceAccount	formData	string	true	"Source account"
+//	@Param			file			formData	file	true	"CSV file"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfer/bulk [post]
+func (h *BulkTransfer) Preview(ctx echo.Context) error {
+	sourceAccount := ctx.FormValue("sourceAccount")
+	if sourceAccount == "" {
+		return ctx.JSON(response.BadRequest(errMissingSourceAccount))
+	}
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if header.Size > maxBulkTransferFileSize {
+		return ctx.JSON(response.BadRequest(errBulkTransferFileTooBig))
+	}
+	file, err := header.Open()
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	defer file.Close()
+
+	batch, err := h.svc.Preview(ctx.Request().Context(), sourceAccount, header.Filename, file)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBulkTransferResponse(batch)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Confirm swaggo annotation.
+//
+//	@Summary		Confirm bulk transfer
+//	@Description	Queue the valid rows of the bulk transfer, verified with the OTP sent for its reference
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		int								true	"Bulk transfer ID"
+//	@Param			BulkTransferConfirmRequest	body		dto.BulkTransferConfirmRequest	true	"Confirm request"
+//	@Success		200							{object}	response.Response
+//	@Failure		400							{object}	response.Response
+//	@Failure		401							{object}	response.Response
+//	@Failure		403							{object}	response.Response
+//	@Failure		404							{object}	response.Response
+//	@Failure		500							{object}	response.Response
+//	@Router			/transfer/bulk/{id}/confirm [post]
+func (h *BulkTransfer) Confirm(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidBulkTransferID))
+	}
+	req := new(dto.BulkTransferConfirmRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	batch, err := h.svc.Confirm(ctx.Request().Context(), id, req.OTPID, req.OTPCode)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBulkTransferResponse(batch)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Get swaggo annotation.
+//
+//	@Summary		Get bulk transfer
+//	@Description	Get the status of the bulk transfer and each of its rows
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Bulk transfer ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/bulk/{id} [get]
+func (h *BulkTransfer) Get(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidBulkTransferID))
+	}
+	batch, err := h.svc.Get(ctx.Request().Context(), id)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBulkTransferResponse(batch)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Result swaggo annotation.
+//
+//	@Summary		Download bulk transfer result
+//	@Description	Download the result of every row of the completed bulk transfer as a CSV file
+//	@Tags			transfer
+//	@Produce		text/csv
+//	@Param			id	path		int	true	"Bulk transfer ID"
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/bulk/{id}/result [get]
+func (h *BulkTransfer) Result(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidBulkTransferID))
+	}
+	result, err := h.svc.Result(ctx.Request().Context(), id)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="bulk-transfer-%d-result.csv"`, id))
+	return ctx.Blob(http.StatusOK, "text/csv", result)
+}
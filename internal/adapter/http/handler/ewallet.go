@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter/http/dto"
+	"go.bankyaya.org/app/backend/internal/adapter/http/response"
+	"go.bankyaya.org/app/backend/internal/domain/ewallet"
+	"go.bankyaya.org/app/backend/internal/pkg/validation"
+)
+
+type EWallet struct {
+	va  *validation.Validator
+	svc *ewallet.Service
+}
+
+func NewEWalletHandler(va *validation.Validator, svc *ewallet.Service) *EWallet {
+	return &EWallet{
+		va:  va,
+		svc: svc,
+	}
+}
+
+// Providers swaggo annotation.
+//
+//	@Summary		E-wallet providers
+//	@Description	Get the e-wallet providers that can be topped up with their fee and limits
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Router			/transfer/ewallet/providers [get]
+func (h *EWallet) Providers(ctx echo.Context) error {
+	providers := h.svc.Providers(ctx.Request().Context())
+	resp := dto.NewEWalletProvidersResponse(providers)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Inquiry swaggo annotation.
+//
+//	@Summary		E-wallet top-up inquiry
+//	@Description	Look up the e-wallet account of the phone number and create new inquiry top-up
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			InquiryRequest	body		dto.EWalletInquiryRequest	true	"Inquiry request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfer/ewallet/inquiry [post]
+func (h *EWallet) Inquiry(ctx echo.Context) error {
+	req := new(dto.EWalletInquiryRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	topUp, err := h.svc.Inquiry(ctx.Request().Context(), req.ToInquiryInput(channel(ctx)))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewEWalletInquiryResponse(topUp)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Payment swaggo annotation.
+//
+//	@Summary		E-wallet top-up
+//	@Description	Performs e-wallet top-up
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			PaymentRequest	body		dto.EWalletPaymentRequest	true	"Payment request"
+//	@Param			Idempotency-Key	header		string						false	"Idempotency key"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfer/ewallet/payment [post]
+func (h *EWallet) Payment(ctx echo.Context) error {
+	req := new(dto.EWalletPaymentRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	idempotencyKey, err := clientIdempotencyKey(ctx)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	transaction, err := h.svc.DoPayment(ctx.Request().Context(), req.ToPaymentInput(idempotencyKey))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewEWalletPaymentResponse(transaction)
+	return ctx.JSON(response.Success(resp))
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter/http/dto"
+	"go.bankyaya.org/app/backend/internal/adapter/http/response"
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
+	"go.bankyaya.org/app/backend/internal/pkg/validation"
+)
+
+type Interbank struct {
+	va  *validation.Validator
+	svc *interbank.Service
+}
+
+func NewInterbankHandler(va *validation.Validator, svc *interbank.Service) *Interbank {
+	return &Interbank{
+		va:  va,
+		svc: svc,
+	}
+}
+
+// Banks swaggo annotation.
+//
+//	@Summary		List banks
+//	@Description	Get the bank directory with the rails each bank supports
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/interbank/banks [get]
+func (h *Interbank) Banks(ctx echo.Context) error {
+	banks, err := h.svc.Banks(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewBankListResponse(banks)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Inquiry swaggo annotation.
+//
+//	@Summary		Interbank transfer inquiry
+//	@Description	Check the destination account at another bank and create new inquiry interbank transfer
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			InquiryRequest	body		dto.InterbankInquiryRequest	true	"Inquiry request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfer/interbank/inquiry [post]
+func (h *Interbank) Inquiry(ctx echo.Context) error {
+	req := new(dto.InterbankInquiryRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	sequence, err := h.svc.Inquiry(ctx.Request().Context(), req.ToSequence(channel(ctx)))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewInterbankInquiryResponse(sequence)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Payment swaggo annotation.
+//
+//	@Summary		Interbank payment
+//	@Description	Performs interbank transfer payment
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			PaymentRequest	body		dto.InterbankPaymentRequest	true	"Payment request"
+//	@Param			Idempotency-Key	header		string						false	"Idempotency key"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfer/interbank/payment [post]
+func (h *Interbank) Payment(ctx echo.Context) error {
+	req := new(dto.InterbankPaymentRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	idempotencyKey, err := clientIdempotencyKey(ctx)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	transaction, err := h.svc.DoPayment(ctx.Request().Context(), req.ToPaymentInput(idempotencyKey))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewInterbankPaymentResponse(transaction)
+	return ctx.JSON(response.Success(resp))
+}
@@ -5,8 +5,36 @@ import (
 	"go.bankyaya.org/app/backend/internal/adapter/http/dto"
 	"go.bankyaya.org/app/backend/internal/adapter/http/response"
 	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
 )
 
+const (
+	// idempotencyKeyHeader is the optional request header used to deduplicate payment retries.
+	idempotencyKeyHeader = "Idempotency-Key"
+	// clientNameHeader is the request header of the client app, only trusted at login.
+	clientNameHeader = "X-Client-Name"
+)
+
+// channel returns the client app of the authenticated user, used as the transfer channel.
+// It comes from the token so that a client cannot pick the channel of its fees.
+func channel(ctx echo.Context) string {
+	user, ok := ctxt.UserFromContext(ctx.Request().Context())
+	if !ok {
+		return ""
+	}
+	return user.Client
+}
+
+// clientIdempotencyKey returns the idempotency key of the request,
+// a key in the namespace of the payments made by the bank itself is rejected.
+func clientIdempotencyKey(ctx echo.Context) (string, error) {
+	key := ctx.Request().Header.Get(idempotencyKeyHeader)
+	if err := intrabank.ValidateIdempotencyKey(key); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
 type Intrabank struct {
 	svc *intrabank.Service
 }
@@ -35,7 +63,7 @@ func (h *Intrabank) Inquiry(ctx echo.Context) error {
 	if err := ctx.Bind(&req); err != nil {
 		return ctx.JSON(response.BadRequest(err))
 	}
-	sequence, err := h.svc.Inquiry(ctx.Request().Context(), req.ToSequence())
+	sequence, err := h.svc.Inquiry(ctx.Request().Context(), req.ToSequence(channel(ctx)))
 	if err != nil {
 		return ctx.JSON(response.Error(err))
 	}
@@ -51,9 +79,11 @@ func (h *Intrabank) Inquiry(ctx echo.Context) error {
 //	@Accept				json
 //	@Produce			json
 //	@Param				PaymentRequest	body		dto.IntrabankPaymentRequest	true	"Payment request"
+//	@Param				Idempotency-Key	header		string						false	"Idempotency key"
 //	@successResponse	200																										{object}	response.Response
 //	@Failure			400				{object}	response.Response
 //	@Failure			404				{object}	response.Response
+//	@Failure			409				{object}	response.Response
 //	@Failure			500				{object}	response.Response
 //	@Router				/transfer/intrabank/payment [post]
 func (h *Intrabank) Payment(ctx echo.Context) error {
@@ -61,10 +91,95 @@ func (h *Intrabank) Payment(ctx echo.Context) error {
 	if err := ctx.Bind(req); err != nil {
 		return ctx.JSON(response.BadRequest(err))
 	}
-	transaction, err := h.svc.DoPayment(ctx.Request().Context(), req.Sequence)
+	idempotencyKey, err := clientIdempotencyKey(ctx)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	transaction, err := h.svc.DoPayment(ctx.Request().Context(), req.ToPaymentInput(idempotencyKey))
 	if err != nil {
 		return ctx.JSON(response.Error(err))
 	}
 	resp := dto.NewIntrabankPaymentResponse(transaction)
 	return ctx.JSON(response.Success(resp))
 }
+
+// History swaggo annotation.
+//
+//	@Summary		Transfer history
+//	@Description	Get the transaction history of the user
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			from		query		string	false	"Start date (YYYY-MM-DD)"
+//	@Param			to			query		string	false	"End date (YYYY-MM-DD)"
+//	@Param			minAmount	query		int		false	"Minimum amount"
+//	@Param			maxAmount	query		int		false	"Maximum amount"
+//	@Param			status		query		string	false	"Transaction status"
+//	@Param			type		query		string	false	"Transaction type"
+//	@Param			cursor		query		string	false	"Next page cursor"
+//	@Param			limit		query		int		false	"Page size"
+//	@Success		200			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Failure		401			{object}	response.Response
+//	@Failure		500			{object}	response.Response
+//	@Router			/transfer/history [get]
+func (h *Intrabank) History(ctx echo.Context) error {
+	req := new(dto.TransferHistoryRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	filter, err := req.ToTransactionFilter()
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	page, err := h.svc.History(ctx.Request().Context(), filter)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewTransferHistoryResponse(page)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Detail swaggo annotation.
+//
+//	@Summary		Transfer detail
+//	@Description	Get the detail of the user's transaction
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			transactionReference	path		string	true	"Transaction reference"
+//	@Success		200						{object}	response.Response
+//	@Failure		401						{object}	response.Response
+//	@Failure		404						{object}	response.Response
+//	@Failure		500						{object}	response.Response
+//	@Router			/transfer/{transactionReference} [get]
+func (h *Intrabank) Detail(ctx echo.Context) error {
+	detail, err := h.svc.Detail(ctx.Request().Context(), ctx.Param("transactionReference"))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewTransactionDetailResponse(detail)
+	return ctx.JSON(response.Success(resp))
+}
+
+// ResendReceipt swaggo annotation.
+//
+//	@Summary		Resend transfer receipt
+//	@Description	Send the receipt email of the user's successful transaction again
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			transactionReference	path		string	true	"Transaction reference"
+//	@Success		200						{object}	response.Response
+//	@Failure		400						{object}	response.Response
+//	@Failure		401						{object}	response.Response
+//	@Failure		404						{object}	response.Response
+//	@Failure		500						{object}	response.Response
+//	@Router			/transfer/{transactionReference}/receipt [post]
+func (h *Intrabank) ResendReceipt(ctx echo.Context) error {
+	err := h.svc.ResendReceipt(ctx.Request().Context(), ctx.Param("transactionReference"))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(nil))
+}
@@ -23,13 +23,14 @@ func NewOTPHandler(va *validation.Validator, svc *otp.Service) *OTPHandler {
 // SendOTP swaggo annotation.
 //
 //	@Summary		Send new OTP
-//	@Description	Send new OTP to user
+//	@Description	Send new OTP to the logged in user, the code is only delivered through the channel
 //	@Tags			otp
 //	@Accept			json
 //	@Produce		json
 //	@Param			OTPRequest	body		dto.OTPRequest	true	"OTP request"
 //	@Success		200				{object}	response.Response
 //	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
 //	@Failure		404				{object}	response.Response
 //	@Failure		500				{object}	response.Response
 //	@Router			/otp/send [post]
@@ -41,7 +42,7 @@ func (h *OTPHandler) SendOTP(ctx echo.Context) error {
 	if err := h.va.Validate(req); err != nil {
 		return ctx.JSON(response.BadRequest(err))
 	}
-	otpRes, err := h.svc.Send(ctx.Request().Context(), otp.NewPurpose(req.Purpose), otp.NewChannel(req.Channel))
+	otpRes, err := h.svc.Send(ctx.Request().Context(), otp.NewPurpose(req.Purpose), otp.NewChannel(req.Channel), req.Reference)
 	if err != nil {
 		return ctx.JSON(response.Error(err))
 	}
@@ -59,6 +60,7 @@ func (h *OTPHandler) SendOTP(ctx echo.Context) error {
 //	@Param			 VerifyOTPRequest	body		dto.VerifyOTPRequest	true	"Verify OTP request"
 //	@Success		200				{object}	response.Response
 //	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
 //	@Failure		404				{object}	response.Response
 //	@Failure		500				{object}	response.Response
 //	@Router			/otp/verify [post]
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter/http/dto"
+	"go.bankyaya.org/app/backend/internal/adapter/http/response"
+	"go.bankyaya.org/app/backend/internal/domain/paymentrequest"
+	"go.bankyaya.org/app/backend/internal/pkg/validation"
+)
+
+var errInvalidPaymentRequestID = errors.New("invalid payment request id")
+
+type PaymentRequest struct {
+	va  *validation.Validator
+	svc *paymentrequest.Service
+}
+
+func NewPaymentRequestHandler(va *validation.Validator, svc *paymentrequest.Service) *PaymentRequest {
+	return &PaymentRequest{
+		va:  va,
+		svc: svc,
+	}
+}
+
+// Create swaggo annotation.
+//
+//	@Summary		Create payment request
+//	@Description	Ask another user for money, the payer is notified
+//	@Tags			payment-request
+//	@Accept			json
+//	@Produce		json
+//	@Param			PaymentRequestRequest	body		dto.PaymentRequestRequest	true	"Payment request"
+//	@Success		200						{object}	response.Response
+//	@Failure		400						{object}	response.Response
+//	@Failure		401						{object}	response.Response
+//	@Failure		404						{object}	response.Response
+//	@Failure		500						{object}	response.Response
+//	@Router			/payment-requests [post]
+func (h *PaymentRequest) Create(ctx echo.Context) error {
+	req := new(dto.PaymentRequestRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	pr, err := h.svc.Create(ctx.Request().Context(), req.PayerPhoneNumber, req.ToPaymentRequest(time.Now()))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewPaymentRequestResponse(pr)
+	return ctx.JSON(response.Success(resp))
+}
+
+// ListSent swaggo annotation.
+//
+//	@Summary		List sent payment requests
+//	@Description	Get the payment requests made by the user
+//	@Tags			payment-request
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/payment-requests/sent [get]
+func (h *PaymentRequest) ListSent(ctx echo.Context) error {
+	requests, err := h.svc.ListSent(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewPaymentRequestListResponse(requests)
+	return ctx.JSON(response.Success(resp))
+}
+
+// ListReceived swaggo annotation.
+//
+//	@Summary		List received payment requests
+//	@Description	Get the payment requests addressed to the user
+//	@Tags			payment-request
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/payment-requests/received [get]
+func (h *PaymentRequest) ListReceived(ctx echo.Context) error {
+	requests, err := h.svc.ListReceived(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewPaymentRequestListResponse(requests)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Get swaggo annotation.
+//
+//	@Summary		Payment request detail
+//	@Description	Get a payment request made by or addressed to the user
+//	@Tags			payment-request
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Payment request ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/payment-requests/{id} [get]
+func (h *PaymentRequest) Get(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidPaymentRequestID))
+	}
+	pr, err := h.svc.Get(ctx.Request().Context(), id)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewPaymentRequestResponse(pr)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Inquiry swaggo annotation.
+//
+//	@Summary		Payment request inquiry
+//	@Description	Create the intrabank inquiry of the transfer that pays the request
+//	@Tags			payment-request
+//	@Accept			json
+//	@Produce		json
+//	@Param			id								path		int									true	"Payment request ID"
+//	@Param			PaymentRequestInquiryRequest	body		dto.PaymentRequestInquiryRequest	true	"Inquiry request"
+//	@Success		200								{object}	response.Response
+//	@Failure		400								{object}	response.Response
+//	@Failure		401								{object}	response.Response
+//	@Failure		403								{object}	response.Response
+//	@Failure		404								{object}	response.Response
+//	@Failure		500								{object}	response.Response
+//	@Router			/payment-requests/{id}/inquiry [post]
+func (h *PaymentRequest) Inquiry(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidPaymentRequestID))
+	}
+	req := new(dto.PaymentRequestInquiryRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	sequence, err := h.svc.Inquiry(ctx.Request().Context(), id, req.SourceAccount, channel(ctx))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewIntrabankInquiryResponse(sequence)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Pay swaggo annotation.
+//
+//	@Summary		Pay payment request
+//	@Description	Pay the request with the sequence of its inquiry
+//	@Tags			payment-request
+//	@Accept			json
+//	@Produce		json
+//	@Param			id							path		int								true	"Payment request ID"
+//	@Param			PaymentRequestPayRequest	body		dto.PaymentRequestPayRequest	true	"Pay request"
+//	@Success		200							{object}	response.Response
+//	@Failure		400							{object}	response.Response
+//	@Failure		401							{object}	response.Response
+//	@Failure		403							{object}	response.Response
+//	@Failure		404							{object}	response.Response
+//	@Failure		500							{object}	response.Response
+//	@Router			/payment-requests/{id}/pay [post]
+func (h *PaymentRequest) Pay(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidPaymentRequestID))
+	}
+	req := new(dto.PaymentRequestPayRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	pr, err := h.svc.Pay(ctx.Request().Context(), id, req.ToPaymentInput())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewPaymentRequestResponse(pr)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Decline swaggo annotation.
+//
+//	@Summary		Decline payment request
+//	@Description	Decline a payment request addressed to the user
+//	@Tags			payment-request
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Payment request ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		403	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/payment-requests/{id}/decline [post]
+func (h *PaymentRequest) Decline(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidPaymentRequestID))
+	}
+	pr, err := h.svc.Decline(ctx.Request().Context(), id)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewPaymentRequestResponse(pr)
+	return ctx.JSON(response.Success(resp))
+}
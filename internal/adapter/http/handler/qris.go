@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter/http/dto"
+	"go.bankyaya.org/app/backend/internal/adapter/http/response"
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+	"go.bankyaya.org/app/backend/internal/pkg/validation"
+)
+
+var errInvalidQRISCodeID = errors.New("invalid qris code id")
+
+type QRIS struct {
+	va  *validation.Validator
+	svc *qris.Service
+}
+
+func NewQRISHandler(va *validation.Validator, svc *qris.Service) *QRIS {
+	return &QRIS{
+		va:  va,
+		svc: svc,
+	}
+}
+
+// Scan swaggo annotation.
+//
+//	@Summary		Scan QRIS code
+//	@Description	Parse the scanned QRIS code and check its merchant
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			ScanRequest	body		dto.QRISScanRequest	true	"Scan request"
+//	@Success		200			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Failure		401			{object}	response.Response
+//	@Failure		500			{object}	response.Response
+//	@Router			/transfer/qris/scan [post]
+func (h *QRIS) Scan(ctx echo.Context) error {
+	req := new(dto.QRISScanRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	payload, err := h.svc.Scan(ctx.Request().Context(), req.Payload)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewQRISScanResponse(payload)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Inquiry swaggo annotation.
+//
+//	@Summary		QRIS payment inquiry
+//	@Description	Create new inquiry QRIS payment of the scanned code
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			InquiryRequest	body		dto.QRISInquiryRequest	true	"Inquiry request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfer/qris/inquiry [post]
+func (h *QRIS) Inquiry(ctx echo.Context) error {
+	req := new(dto.QRISInquiryRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	payment, err := h.svc.Inquiry(ctx.Request().Context(), req.ToInquiryInput(channel(ctx)))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewQRISInquiryResponse(payment)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Payment swaggo annotation.
+//
+//	@Summary		QRIS payment
+//	@Description	Performs QRIS merchant payment
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			PaymentRequest	body		dto.QRISPaymentRequest	true	"Payment request"
+//	@Param			Idempotency-Key	header		string					false	"Idempotency key"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfer/qris/payment [post]
+func (h *QRIS) Payment(ctx echo.Context) error {
+	req := new(dto.QRISPaymentRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	idempotencyKey, err := clientIdempotencyKey(ctx)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	transaction, err := h.svc.DoPayment(ctx.Request().Context(), req.ToPaymentInput(idempotencyKey))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewQRISPaymentResponse(transaction)
+	return ctx.JSON(response.Success(resp))
+}
+
+// GenerateCode swaggo annotation.
+//
+//	@Summary		Generate QRIS code
+//	@Description	Generate a static QRIS code, or one with a fixed amount and expiry, to receive money into the account
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			CodeRequest	body		dto.QRISCodeRequest	true	"Code request"
+//	@Success		200			{object}	response.Response
+//	@Failure		400			{object}	response.Response
+//	@Failure		401			{object}	response.Response
+//	@Failure		403			{object}	response.Response
+//	@Failure		500			{object}	response.Response
+//	@Router			/transfer/qris/codes [post]
+func (h *QRIS) GenerateCode(ctx echo.Context) error {
+	req := new(dto.QRISCodeRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	code, err := h.svc.GenerateCode(ctx.Request().Context(), req.ToCodeInput())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewQRISCodeResponse(code)
+	return ctx.JSON(response.Success(resp))
+}
+
+// GetCode swaggo annotation.
+//
+//	@Summary		Get QRIS code
+//	@Description	Get the generated QRIS code with the transfers received through it
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"QRIS code ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/qris/codes/{id} [get]
+func (h *QRIS) GetCode(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidQRISCodeID))
+	}
+	code, err := h.svc.GetCode(ctx.Request().Context(), id)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewQRISCodeResponse(code)
+	return ctx.JSON(response.Success(resp))
+}
+
+// CodeImage swaggo annotation.
+//
+//	@Summary		Get QRIS code image
+//	@Description	Get the PNG image of the generated QRIS code
+//	@Tags			transfer
+//	@Produce		png
+//	@Param			id	path		int	true	"QRIS code ID"
+//	@Success		200	{file}		binary
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/qris/codes/{id}/image [get]
+func (h *QRIS) CodeImage(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidQRISCodeID))
+	}
+	code, err := h.svc.GetCode(ctx.Request().Context(), id)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.Blob(http.StatusOK, "image/png", code.Image)
+}
+
+// ReceiveCredit swaggo annotation.
+//
+//	@Summary		Receive QRIS credit
+//	@Description	Credit the account of a QRIS code of the bank paid from another bank, notified by the QRIS network
+//	@Tags			qris
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Api-Key		header		string					true	"API key of the QRIS network"
+//	@Param			CreditRequest	body		dto.QRISCreditRequest	true	"Credit request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/qris/network/credits [post]
+func (h *QRIS) ReceiveCredit(ctx echo.Context) error {
+	req := new(dto.QRISCreditRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	credit, err := h.svc.ReceiveCredit(ctx.Request().Context(), req.ToInboundCredit())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewQRISCreditResponse(credit)
+	return ctx.JSON(response.Success(resp))
+}
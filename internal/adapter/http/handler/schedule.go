@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter/http/dto"
+	"go.bankyaya.org/app/backend/internal/adapter/http/response"
+	"go.bankyaya.org/app/backend/internal/domain/schedule"
+	"go.bankyaya.org/app/backend/internal/pkg/validation"
+)
+
+var errInvalidScheduleID = errors.New("invalid schedule id")
+
+type Schedule struct {
+	va  *validation.Validator
+	svc *schedule.Service
+}
+
+func NewScheduleHandler(va *validation.Validator, svc *schedule.Service) *Schedule {
+	return &Schedule{
+		va:  va,
+		svc: svc,
+	}
+}
+
+// Create swaggo annotation.
+//
+//	@Summary		Create scheduled transfer
+//	@Description	Schedule an intrabank transfer for a future date
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			ScheduleRequest	body		dto.ScheduleRequest	true	"Schedule request"
+//	@Success		200				{object}	response.Response
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfer/schedules [post]
+func (h *Schedule) Create(ctx echo.Context) error {
+	req := new(dto.ScheduleRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	in, err := req.ToSchedule()
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	sch, err := h.svc.Create(ctx.Request().Context(), in, req.OTPID, req.OTPCode)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewScheduleResponse(sch)
+	return ctx.JSON(response.Success(resp))
+}
+
+// List swaggo annotation.
+//
+//	@Summary		List scheduled transfers
+//	@Description	Get all scheduled transfers of the user
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/schedules [get]
+func (h *Schedule) List(ctx echo.Context) error {
+	schedules, err := h.svc.List(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewScheduleListResponse(schedules)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Get swaggo annotation.
+//
+//	@Summary		Scheduled transfer detail
+//	@Description	Get a scheduled transfer and the outcome of its execution
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Schedule ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/schedules/{id} [get]
+func (h *Schedule) Get(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidScheduleID))
+	}
+	sch, err := h.svc.Get(ctx.Request().Context(), id)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewScheduleResponse(sch)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Cancel swaggo annotation.
+//
+//	@Summary		Cancel scheduled transfer
+//	@Description	Cancel a scheduled transfer before it is executed
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Schedule ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/schedules/{id} [delete]
+func (h *Schedule) Cancel(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidScheduleID))
+	}
+	if err := h.svc.Cancel(ctx.Request().Context(), id); err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(nil))
+}
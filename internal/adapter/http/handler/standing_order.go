@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter/http/dto"
+	"go.bankyaya.org/app/backend/internal/adapter/http/response"
+	"go.bankyaya.org/app/backend/internal/domain/standingorder"
+	"go.bankyaya.org/app/backend/internal/pkg/validation"
+)
+
+var errInvalidStandingOrderID = errors.New("invalid standing order id")
+
+type StandingOrder struct {
+	va  *validation.Validator
+	svc *standingorder.Service
+}
+
+func NewStandingOrderHandler(va *validation.Validator, svc *standingorder.Service) *StandingOrder {
+	return &StandingOrder{
+		va:  va,
+		svc: svc,
+	}
+}
+
+// Create swaggo annotation.
+//
+//	@Summary		Create standing order
+//	@Description	Create a recurring intrabank transfer
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			StandingOrderRequest	body		dto.StandingOrderRequest	true	"Standing order request"
+//	@Success		200						{object}	response.Response
+//	@Failure		400						{object}	response.Response
+//	@Failure		401						{object}	response.Response
+//	@Failure		403						{object}	response.Response
+//	@Failure		500						{object}	response.Response
+//	@Router			/transfer/standing-orders [post]
+func (h *StandingOrder) Create(ctx echo.Context) error {
+	req := new(dto.StandingOrderRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	in, err := req.ToStandingOrder()
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	order, err := h.svc.Create(ctx.Request().Context(), in, req.OTPID, req.OTPCode)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewStandingOrderResponse(order)
+	return ctx.JSON(response.Success(resp))
+}
+
+// List swaggo annotation.
+//
+//	@Summary		List standing orders
+//	@Description	Get all standing orders of the user
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/standing-orders [get]
+func (h *StandingOrder) List(ctx echo.Context) error {
+	orders, err := h.svc.List(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewStandingOrderListResponse(orders)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Get swaggo annotation.
+//
+//	@Summary		Standing order detail
+//	@Description	Get a standing order and the outcome of its last run
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Standing order ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/standing-orders/{id} [get]
+func (h *StandingOrder) Get(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidStandingOrderID))
+	}
+	order, err := h.svc.Get(ctx.Request().Context(), id)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewStandingOrderResponse(order)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Cancel swaggo annotation.
+//
+//	@Summary		Cancel standing order
+//	@Description	Stop a standing order, no further transfers are made
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Standing order ID"
+//	@Success		200	{object}	response.Response
+//	@Failure		400	{object}	response.Response
+//	@Failure		401	{object}	response.Response
+//	@Failure		404	{object}	response.Response
+//	@Failure		500	{object}	response.Response
+//	@Router			/transfer/standing-orders/{id} [delete]
+func (h *StandingOrder) Cancel(ctx echo.Context) error {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ctx.JSON(response.BadRequest(errInvalidStandingOrderID))
+	}
+	if err := h.svc.Cancel(ctx.Request().Context(), id); err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	return ctx.JSON(response.Success(nil))
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter/http/dto"
+	"go.bankyaya.org/app/backend/internal/adapter/http/response"
+	"go.bankyaya.org/app/backend/internal/domain/statement"
+	"go.bankyaya.org/app/backend/internal/pkg/validation"
+)
+
+type Statement struct {
+	va  *validation.Validator
+	svc *statement.Service
+}
+
+func NewStatementHandler(va *validation.Validator, svc *statement.Service) *Statement {
+	return &Statement{
+		va:  va,
+		svc: svc,
+	}
+}
+
+// Download swaggo annotation.
+//
+//	@Summary		Download account statement
+//	@Description	Download the statement of the account for the period as a PDF or a CSV file
+//	@Tags			transfer
+//	@Produce		application/pdf,text/csv
+//	@Param			accountNumber	query		string	true	"Account number"
+//	@Param			from			query		string	true	"Start date (YYYY-MM-DD)"
+//	@Param			to				query		string	true	"End date (YYYY-MM-DD)"
+//	@Param			format			query		string	false	"File format, pdf or csv"
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		500				{object}	response.Response
+//	@Router			/transfer/statements [get]
+func (h *Statement) Download(ctx echo.Context) error {
+	req := new(dto.StatementRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	input, err := req.ToInput()
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	doc, err := h.svc.Generate(ctx.Request().Context(), input)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, doc.FileName))
+	return ctx.Blob(http.StatusOK, doc.ContentType, doc.Content)
+}
+
+// Email swaggo annotation.
+//
+//	@Summary		Email account statement
+//	@Description	Email the statement of the account for the period to the user as a PDF or a CSV attachment
+//	@Tags			transfer
+//	@Accept			json
+//	@Produce		json
+//	@Param			StatementRequest	body		dto.StatementRequest	true	"Statement request"
+//	@Success		200					{object}	response.Response
+//	@Failure		400					{object}	response.Response
+//	@Failure		401					{object}	response.Response
+//	@Failure		403					{object}	response.Response
+//	@Failure		500					{object}	response.Response
+//	@Router			/transfer/statements/email [post]
+func (h *Statement) Email(ctx echo.Context) error {
+	req := new(dto.StatementRequest)
+	if err := ctx.Bind(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	if err := h.va.Validate(req); err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	input, err := req.ToInput()
+	if err != nil {
+		return ctx.JSON(response.BadRequest(err))
+	}
+	doc, err := h.svc.Email(ctx.Request().Context(), input)
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewStatementResponse(doc.Statement)
+	return ctx.JSON(response.Success(resp))
+}
+
+// Verify swaggo annotation.
+//
+//	@Summary		Verify account statement
+//	@Description	Get the period and the masked account of the statement issued with the verification reference, so a third party can check it
+//	@Tags			statement
+//	@Produce		json
+//	@Param			reference	path		string	true	"Verification reference"
+//	@Success		200			{object}	response.Response
+//	@Failure		404			{object}	response.Response
+//	@Failure		500			{object}	response.Response
+//	@Router			/statements/verify/{reference} [get]
+func (h *Statement) Verify(ctx echo.Context) error {
+	v, err := h.svc.Verify(ctx.Request().Context(), ctx.Param("reference"))
+	if err != nil {
+		return ctx.JSON(response.Error(err))
+	}
+	resp := dto.NewStatementVerificationResponse(v)
+	return ctx.JSON(response.Success(resp))
+}
@@ -24,9 +24,12 @@ func NewUserHandler(svc *user.Service) *UserHandler {
 //	@Tags			user
 //	@Accept			json
 //	@Produce		json
-//	@Param			LoginRequest	body		dto.LoginRequest	true	"Login request"
+//	@Param			X-Client-Name		header		string				true	"Client app name"
+//	@Param			X-Client-Version	header		string				true	"Client app version"
+//	@Param			LoginRequest		body		dto.LoginRequest	true	"Login request"
 //	@Success		200				{object}	response.Response
 //	@Failure		400				{object}	response.Response
+//	@Failure		403				{object}	response.Response
 //	@Failure		404				{object}	response.Response
 //	@Failure		500				{object}	response.Response
 //	@Router			/user/login [post]
@@ -35,7 +38,7 @@ func (h *UserHandler) Login(ctx echo.Context) error {
 	if err := ctx.Bind(req); err != nil {
 		return ctx.JSON(response.BadRequest(err))
 	}
-	token, err := h.svc.Login(ctx.Request().Context(), req.ToUser())
+	token, err := h.svc.Login(ctx.Request().Context(), req.ToUser(ctx.Request().Header.Get(clientNameHeader)))
 	if err != nil {
 		return ctx.JSON(response.Error(err))
 	}
@@ -9,18 +9,21 @@ import (
 	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
 )
 
-// jwtConfig contains configuration for JWT auth middleware.
-var jwtConfig = echojwt.Config{
-	ContextKey:     ctxt.UserContextKey.String(),
-	SigningKey:     []byte(config.Load().Token.Secret),
-	SuccessHandler: successHandler,
-	ErrorHandler:   errorHandler,
-}
-
 // AuthenticateUser returns a middleware function that validates token from headers
 // and extract user information.
 func AuthenticateUser() echo.MiddlewareFunc {
-	return echojwt.WithConfig(jwtConfig)
+	cfg := config.Load()
+	return echojwt.WithConfig(jwtConfig(cfg.Token.Secret))
+}
+
+// jwtConfig returns configuration for JWT auth middleware signed with the secret.
+func jwtConfig(secret string) echojwt.Config {
+	return echojwt.Config{
+		ContextKey:     ctxt.UserContextKey.String(),
+		SigningKey:     []byte(secret),
+		SuccessHandler: successHandler,
+		ErrorHandler:   errorHandler,
+	}
 }
 
 // successHandler extract user information from token
@@ -43,7 +46,8 @@ func userFromToken(token *jwt.Token) ctxt.User {
 	if !ok {
 		return ctxt.User{}
 	}
-	userID, ok := claims["userID"].(int)
+	// JSON numbers are decoded as float64.
+	userID, ok := claims["userId"].(float64)
 	if !ok {
 		return ctxt.User{}
 	}
@@ -55,11 +59,17 @@ func userFromToken(token *jwt.Token) ctxt.User {
 	if !ok {
 		return ctxt.User{}
 	}
+	phone, _ := claims["phone"].(string)
+	deviceID, _ := claims["deviceId"].(string)
+	client, _ := claims["client"].(string)
 	return ctxt.User{
-		CIF:   cif,
-		ID:    userID,
-		Name:  fullName,
-		Email: email,
+		CIF:      cif,
+		ID:       int(userID),
+		Name:     fullName,
+		Email:    email,
+		Phone:    phone,
+		DeviceID: deviceID,
+		Client:   client,
 	}
 }
 
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/adapter/token"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/user"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+)
+
+const testSecret = "test-secret"
+
+func TestAuthenticateUser_LoginTokenOwnsAccount(t *testing.T) {
+	cfg := &config.Configs{}
+	cfg.Token.Secret = testSecret
+
+	loginToken, err := token.NewJWT(cfg).Create(&user.User{
+		ID:          7,
+		CIF:         "CIF0000007",
+		FullName:    "Budi Santoso",
+		Email:       "budi@example.com",
+		PhoneNumber: "081338442777",
+		Device: &user.Device{
+			DeviceID: "456",
+			Client:   "bankyaya-android",
+		},
+	}, 15*time.Minute)
+	assert.Nil(t, err)
+
+	var got *ctxt.User
+	e := echo.New()
+	e.GET("/", func(ctx echo.Context) error {
+		got, _ = ctxt.UserFromContext(ctx.Request().Context())
+		return ctx.NoContent(http.StatusOK)
+	}, echojwt.WithConfig(jwtConfig(testSecret)))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+loginToken.AccessToken)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, &ctxt.User{
+		ID:       7,
+		CIF:      "CIF0000007",
+		Name:     "Budi Santoso",
+		Email:    "budi@example.com",
+		Phone:    "081338442777",
+		DeviceID: "456",
+		Client:   "bankyaya-android",
+	}, got)
+
+	account := &intrabank.Account{CIF: "CIF0000007"}
+	assert.True(t, account.IsOwnedBy(got.CIF))
+}
+
+func TestAuthenticateUser_InvalidToken(t *testing.T) {
+	e := echo.New()
+	e.GET("/", func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK)
+	}, echojwt.WithConfig(jwtConfig(testSecret)))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer invalid-token")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"github.com/labstack/echo/v4"
+	"go.bankyaya.org/app/backend/internal/adapter/http/response"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+)
+
+const apiKeyHeaderKey = "X-Api-Key"
+
+var errInvalidAPIKey = errors.New("invalid api key")
+
+// AuthenticateQRISNetwork returns an Echo middleware that authenticates the QRIS network
+// by the API key in the request headers, every request is refused when no key is configured.
+func AuthenticateQRISNetwork() echo.MiddlewareFunc {
+	cfg := config.Load()
+	apiKey := []byte(cfg.QRIS.NetworkAPIKey)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			keyFromHeader := []byte(ctx.Request().Header.Get(apiKeyHeaderKey))
+			if len(apiKey) == 0 || subtle.ConstantTimeCompare(keyFromHeader, apiKey) != 1 {
+				return ctx.JSON(response.Unauthorized(errInvalidAPIKey))
+			}
+			return next(ctx)
+		}
+	}
+}
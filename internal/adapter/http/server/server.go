@@ -29,12 +29,22 @@ func (s *Server) Serve() {
 
 // Router gets all requests to handlers and returns the response produce by handlers.
 type Router struct {
-	cfg              *config.Configs
-	log              *logger.Logger
-	router           *echo.Echo
-	intrabankHandler *handler.Intrabank
-	userHandler      *handler.UserHandler
-	otpHandler       *handler.OTPHandler
+	cfg                   *config.Configs
+	log                   *logger.Logger
+	router                *echo.Echo
+	intrabankHandler      *handler.Intrabank
+	userHandler           *handler.UserHandler
+	otpHandler            *handler.OTPHandler
+	scheduleHandler       *handler.Schedule
+	standingOrderHandler  *handler.StandingOrder
+	beneficiaryHandler    *handler.Beneficiary
+	interbankHandler      *handler.Interbank
+	bulkTransferHandler   *handler.BulkTransfer
+	paymentRequestHandler *handler.PaymentRequest
+	qrisHandler           *handler.QRIS
+	billPaymentHandler    *handler.BillPayment
+	eWalletHandler        *handler.EWallet
+	statementHandler      *handler.Statement
 }
 
 // NewRouter returns new Router.
@@ -45,14 +55,34 @@ func NewRouter(
 	transferHandler *handler.Intrabank,
 	userHandler *handler.UserHandler,
 	otpHandler *handler.OTPHandler,
+	scheduleHandler *handler.Schedule,
+	standingOrderHandler *handler.StandingOrder,
+	beneficiaryHandler *handler.Beneficiary,
+	interbankHandler *handler.Interbank,
+	bulkTransferHandler *handler.BulkTransfer,
+	paymentRequestHandler *handler.PaymentRequest,
+	qrisHandler *handler.QRIS,
+	billPaymentHandler *handler.BillPayment,
+	eWalletHandler *handler.EWallet,
+	statementHandler *handler.Statement,
 ) *Router {
 	return &Router{
-		cfg:              cfg,
-		log:              log,
-		router:           router,
-		intrabankHandler: transferHandler,
-		userHandler:      userHandler,
-		otpHandler:       otpHandler,
+		cfg:                   cfg,
+		log:                   log,
+		router:                router,
+		intrabankHandler:      transferHandler,
+		userHandler:           userHandler,
+		otpHandler:            otpHandler,
+		scheduleHandler:       scheduleHandler,
+		standingOrderHandler:  standingOrderHandler,
+		beneficiaryHandler:    beneficiaryHandler,
+		interbankHandler:      interbankHandler,
+		bulkTransferHandler:   bulkTransferHandler,
+		paymentRequestHandler: paymentRequestHandler,
+		qrisHandler:           qrisHandler,
+		billPaymentHandler:    billPaymentHandler,
+		eWalletHandler:        eWalletHandler,
+		statementHandler:      statementHandler,
 	}
 }
 
@@ -61,6 +91,9 @@ func (r *Router) Run() {
 	r.useMiddlewares()
 	r.swagger()
 	r.setTransferRoutes()
+	r.setPaymentRequestRoutes()
+	r.setStatementRoutes()
+	r.setQRISNetworkRoutes()
 	r.setUserRoutes()
 	r.setOTPRoutes()
 	r.run()
@@ -84,19 +117,85 @@ func (r *Router) run() {
 }
 
 func (r *Router) setTransferRoutes() {
-	tr := r.router.Group("/transfer/intrabank")
+	tr := r.router.Group("/transfer")
 	tr.Use(middleware.AuthenticateUser())
 
-	tr.POST("/inquiry", r.intrabankHandler.Inquiry)
-	tr.POST("/payment", r.intrabankHandler.Payment)
+	tr.GET("/history", r.intrabankHandler.History)
+	tr.POST("/schedules", r.scheduleHandler.Create)
+	tr.GET("/schedules", r.scheduleHandler.List)
+	tr.GET("/schedules/:id", r.scheduleHandler.Get)
+	tr.DELETE("/schedules/:id", r.scheduleHandler.Cancel)
+	tr.POST("/standing-orders", r.standingOrderHandler.Create)
+	tr.GET("/standing-orders", r.standingOrderHandler.List)
+	tr.GET("/standing-orders/:id", r.standingOrderHandler.Get)
+	tr.DELETE("/standing-orders/:id", r.standingOrderHandler.Cancel)
+	tr.POST("/beneficiaries", r.beneficiaryHandler.Add)
+	tr.GET("/beneficiaries", r.beneficiaryHandler.List)
+	tr.GET("/beneficiaries/:id", r.beneficiaryHandler.Get)
+	tr.PUT("/beneficiaries/:id", r.beneficiaryHandler.Update)
+	tr.DELETE("/beneficiaries/:id", r.beneficiaryHandler.Delete)
+	tr.POST("/intrabank/inquiry", r.intrabankHandler.Inquiry)
+	tr.POST("/intrabank/payment", r.intrabankHandler.Payment)
+	tr.GET("/interbank/banks", r.interbankHandler.Banks)
+	tr.POST("/interbank/inquiry", r.interbankHandler.Inquiry)
+	tr.POST("/interbank/payment", r.interbankHandler.Payment)
+	tr.POST("/qris/scan", r.qrisHandler.Scan)
+	tr.POST("/qris/inquiry", r.qrisHandler.Inquiry)
+	tr.POST("/qris/payment", r.qrisHandler.Payment)
+	tr.POST("/qris/codes", r.qrisHandler.GenerateCode)
+	tr.GET("/qris/codes/:id", r.qrisHandler.GetCode)
+	tr.GET("/qris/codes/:id/image", r.qrisHandler.CodeImage)
+	tr.GET("/bills/catalog", r.billPaymentHandler.Catalog)
+	tr.POST("/bills/inquiry", r.billPaymentHandler.Inquiry)
+	tr.POST("/bills/payment", r.billPaymentHandler.Payment)
+	tr.GET("/bills/receipts/:sequence", r.billPaymentHandler.Receipt)
+	tr.GET("/ewallet/providers", r.eWalletHandler.Providers)
+	tr.POST("/ewallet/inquiry", r.eWalletHandler.Inquiry)
+	tr.POST("/ewallet/payment", r.eWalletHandler.Payment)
+	tr.GET("/statements", r.statementHandler.Download)
+	tr.POST("/statements/email", r.statementHandler.Email)
+	tr.POST("/bulk", r.bulkTransferHandler.Preview)
+	tr.GET("/bulk/:id", r.bulkTransferHandler.Get)
+	tr.POST("/bulk/:id/confirm", r.bulkTransferHandler.Confirm)
+	tr.GET("/bulk/:id/result", r.bulkTransferHandler.Result)
+	tr.GET("/:transactionReference", r.intrabankHandler.Detail)
+	tr.POST("/:transactionReference/receipt", r.intrabankHandler.ResendReceipt)
+}
+
+func (r *Router) setPaymentRequestRoutes() {
+	pr := r.router.Group("/payment-requests")
+	pr.Use(middleware.AuthenticateUser())
+
+	pr.POST("", r.paymentRequestHandler.Create)
+	pr.GET("/sent", r.paymentRequestHandler.ListSent)
+	pr.GET("/received", r.paymentRequestHandler.ListReceived)
+	pr.GET("/:id", r.paymentRequestHandler.Get)
+	pr.POST("/:id/inquiry", r.paymentRequestHandler.Inquiry)
+	pr.POST("/:id/pay", r.paymentRequestHandler.Pay)
+	pr.POST("/:id/decline", r.paymentRequestHandler.Decline)
+}
+
+// setStatementRoutes sets the public routes of the statements, they are verified by third parties without logging in.
+func (r *Router) setStatementRoutes() {
+	r.router.GET("/statements/verify/:reference", r.statementHandler.Verify)
+}
+
+// setQRISNetworkRoutes sets the routes called by the QRIS network, they are authenticated by its API key.
+func (r *Router) setQRISNetworkRoutes() {
+	qr := r.router.Group("/qris/network")
+	qr.Use(middleware.AuthenticateQRISNetwork())
+
+	qr.POST("/credits", r.qrisHandler.ReceiveCredit)
 }
 
 func (r *Router) setUserRoutes() {
-	r.router.POST("/user/login", r.userHandler.Login)
+	r.router.POST("/user/login", r.userHandler.Login, middleware.ValidateClients())
 }
 
 func (r *Router) setOTPRoutes() {
 	or := r.router.Group("/otp")
+	or.Use(middleware.AuthenticateUser())
+
 	or.POST("/send", r.otpHandler.SendOTP)
 	or.POST("/verify", r.otpHandler.VerifyOTP)
 }
@@ -0,0 +1,26 @@
+package notification
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/paymentrequest"
+	"go.bankyaya.org/app/backend/internal/pkg/notification/firebase"
+)
+
+type PaymentRequestNotification struct {
+	firebase *firebase.Client
+}
+
+func NewPaymentRequestNotification(firebaseClient *firebase.Client) *PaymentRequestNotification {
+	return &PaymentRequestNotification{
+		firebase: firebaseClient,
+	}
+}
+
+func (n *PaymentRequestNotification) Notify(ctx context.Context, notification *paymentrequest.Notification) error {
+	return n.firebase.Send(ctx, &firebase.Message{
+		FirebaseID: notification.FirebaseID,
+		Title:      notification.Subject,
+		Body:       notification.String(),
+	})
+}
@@ -2,19 +2,37 @@ package adapter
 
 import (
 	"github.com/google/wire"
+	"go.bankyaya.org/app/backend/internal/adapter/acquirer"
+	"go.bankyaya.org/app/backend/internal/adapter/biller"
 	"go.bankyaya.org/app/backend/internal/adapter/corebanking"
 	"go.bankyaya.org/app/backend/internal/adapter/email"
+	"go.bankyaya.org/app/backend/internal/adapter/ewallet"
 	"go.bankyaya.org/app/backend/internal/adapter/http/handler"
 	"go.bankyaya.org/app/backend/internal/adapter/http/server"
 	"go.bankyaya.org/app/backend/internal/adapter/notification"
 	"go.bankyaya.org/app/backend/internal/adapter/otp"
 	"go.bankyaya.org/app/backend/internal/adapter/password"
+	"go.bankyaya.org/app/backend/internal/adapter/qrimage"
 	"go.bankyaya.org/app/backend/internal/adapter/sequence"
+	"go.bankyaya.org/app/backend/internal/adapter/statementfile"
 	"go.bankyaya.org/app/backend/internal/adapter/storage/repo"
+	"go.bankyaya.org/app/backend/internal/adapter/switching"
 	"go.bankyaya.org/app/backend/internal/adapter/token"
+	"go.bankyaya.org/app/backend/internal/adapter/worker"
+	"go.bankyaya.org/app/backend/internal/domain/beneficiary"
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/domain/bulktransfer"
+	ewalletdomain "go.bankyaya.org/app/backend/internal/domain/ewallet"
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
 	"go.bankyaya.org/app/backend/internal/domain/intrabank"
 	otpdomain "go.bankyaya.org/app/backend/internal/domain/otp"
+	"go.bankyaya.org/app/backend/internal/domain/paymentrequest"
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+	"go.bankyaya.org/app/backend/internal/domain/schedule"
+	"go.bankyaya.org/app/backend/internal/domain/standingorder"
+	"go.bankyaya.org/app/backend/internal/domain/statement"
 	"go.bankyaya.org/app/backend/internal/domain/user"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
 )
 
 var tokenProviderSet = wire.NewSet(
@@ -26,21 +44,180 @@ var passwordProviderSet = wire.NewSet(
 
 var coreBankingProviderSet = wire.NewSet(
 	corebanking.NewIntrabankCoreBanking, wire.Bind(new(intrabank.CoreBanking), new(*corebanking.IntrabankCoreBanking)),
+	wire.Bind(new(beneficiary.CoreBanking), new(*corebanking.IntrabankCoreBanking)),
+	wire.Bind(new(statement.CoreBanking), new(*corebanking.IntrabankCoreBanking)),
+	corebanking.NewInterbankCoreBanking, wire.Bind(new(interbank.CoreBanking), new(*corebanking.InterbankCoreBanking)),
+	corebanking.NewQRISCoreBanking, wire.Bind(new(qris.CoreBanking), new(*corebanking.QRISCoreBanking)),
+	corebanking.NewBillPaymentCoreBanking, wire.Bind(new(billpayment.CoreBanking), new(*corebanking.BillPaymentCoreBanking)),
+	corebanking.NewEWalletCoreBanking, wire.Bind(new(ewalletdomain.CoreBanking), new(*corebanking.EWalletCoreBanking)),
+)
+
+var switchingProviderSet = wire.NewSet(
+	ProvideInterbankGateway,
+)
+
+// ProvideInterbankGateway provides the gateway of the switching network,
+// the fake gateway moves no money and is only provided when the fake partners are enabled.
+// Without a gateway the interbank transfers are unavailable.
+func ProvideInterbankGateway(cfg *config.Configs) interbank.InterbankGateway {
+	if cfg.Partners.UseFakes {
+		return switching.NewFakeGateway()
+	}
+	return switching.NewDisabledGateway()
+}
+
+var acquirerProviderSet = wire.NewSet(
+	ProvideQRISAcquirer,
+)
+
+// ProvideQRISAcquirer provides the QRIS network that reaches the acquirers of the merchants,
+// the fake acquirer moves no money and is only provided when the fake partners are enabled.
+// Without an acquirer the QRIS merchant payments are unavailable.
+func ProvideQRISAcquirer(cfg *config.Configs) qris.Acquirer {
+	if cfg.Partners.UseFakes {
+		return acquirer.NewFakeAcquirer()
+	}
+	return acquirer.NewDisabledAcquirer()
+}
+
+var billerProviderSet = wire.NewSet(
+	ProvideBiller,
+)
+
+// ProvideBiller provides the aggregator that delivers the bill payments to the billers,
+// the fake biller moves no money and is only provided when the fake partners are enabled.
+// Without a biller the bill payments are unavailable.
+func ProvideBiller(cfg *config.Configs) billpayment.Biller {
+	if cfg.Partners.UseFakes {
+		return biller.NewFakeBiller()
+	}
+	return biller.NewDisabledBiller()
+}
+
+var eWalletProviderSet = wire.NewSet(
+	ProvideEWalletCatalog,
+	ProvideEWalletDirectory,
+)
+
+// ProvideEWalletDirectory provides the account lookups of the e-wallet providers,
+// the fake directory finds an account for any phone number and is only provided when the fake partners are enabled.
+// Without a directory the e-wallet top-ups are unavailable.
+func ProvideEWalletDirectory(cfg *config.Configs) ewalletdomain.Directory {
+	if cfg.Partners.UseFakes {
+		return ewallet.NewFakeDirectory()
+	}
+	return ewallet.NewDisabledDirectory()
+}
+
+// ProvideEWalletCatalog provides the configured e-wallet providers with their fee, limits and operating hours.
+func ProvideEWalletCatalog(cfg *config.Configs) ewalletdomain.Catalog {
+	catalog := make(ewalletdomain.Catalog, 0, len(cfg.EWallet.Providers))
+	for _, p := range cfg.EWallet.Providers {
+		catalog = append(catalog, &ewalletdomain.Provider{
+			Code:       p.Code,
+			Name:       p.Name,
+			WalletType: p.WalletType,
+			Limits: intrabank.Limits{
+				MinAmount:      intrabank.Money(p.MinAmount),
+				MaxAmount:      intrabank.Money(p.MaxAmount),
+				MaxDailyAmount: intrabank.Money(p.MaxDailyAmount),
+				Fee:            intrabank.Money(p.Fee),
+				OpenHour:       p.OpenHour,
+				CloseHour:      p.CloseHour,
+				Disabled:       p.Disabled,
+			},
+		})
+	}
+	return catalog
+}
+
+var qrisCodeProviderSet = wire.NewSet(
+	ProvideQRISIssuer,
+	qrimage.NewPNGRenderer, wire.Bind(new(qris.CodeRenderer), new(*qrimage.PNGRenderer)),
+)
+
+// ProvideQRISIssuer provides the configured identity of the bank printed on the generated QRIS codes.
+func ProvideQRISIssuer(cfg *config.Configs) qris.Issuer {
+	return qris.Issuer{
+		GUID:       cfg.QRIS.GUID,
+		NNS:        cfg.QRIS.NNS,
+		City:       cfg.QRIS.City,
+		PostalCode: cfg.QRIS.PostalCode,
+	}
+}
+
+var statementFileProviderSet = wire.NewSet(
+	statementfile.NewRenderer, wire.Bind(new(statement.Renderer), new(*statementfile.Renderer)),
 )
 
 var emailProviderSet = wire.NewSet(
 	email.NewTransferEmail, wire.Bind(new(intrabank.ReceiptMailer), new(*email.IntrabankEmail)),
 	email.NewOTPEmail, wire.Bind(new(otpdomain.Sender), new(*email.OTPEmail)),
+	email.NewStatementEmail, wire.Bind(new(statement.Mailer), new(*email.StatementEmail)),
 )
 
 var notificationProviderSet = wire.NewSet(
 	notification.NewIntrabankNotification, wire.Bind(new(intrabank.Notifier), new(*notification.IntrabankNotification)),
+	wire.Bind(new(schedule.Notifier), new(*notification.IntrabankNotification)),
+	wire.Bind(new(standingorder.Notifier), new(*notification.IntrabankNotification)),
+	notification.NewPaymentRequestNotification,
+	wire.Bind(new(paymentrequest.Notifier), new(*notification.PaymentRequestNotification)),
 )
 
 var sequencerProviderSet = wire.NewSet(
 	sequence.New, wire.Bind(new(intrabank.SequenceGenerator), new(*sequence.UUID)),
+	wire.Bind(new(interbank.SequenceGenerator), new(*sequence.UUID)),
+	wire.Bind(new(qris.SequenceGenerator), new(*sequence.UUID)),
+	wire.Bind(new(billpayment.SequenceGenerator), new(*sequence.UUID)),
+	wire.Bind(new(ewalletdomain.SequenceGenerator), new(*sequence.UUID)),
+	wire.Bind(new(statement.ReferenceGenerator), new(*sequence.UUID)),
+)
+
+var transferPolicyProviderSet = wire.NewSet(
+	ProvideSequenceValidity,
+	ProvideStepUpPolicy,
+	ProvideFeePolicy,
 )
 
+// ProvideSequenceValidity provides the configured validity windows of the inquiry sequences.
+func ProvideSequenceValidity(cfg *config.Configs) intrabank.SequenceValidity {
+	return cfg.Transfer.SequenceValidity
+}
+
+// ProvideStepUpPolicy provides the configured step-up OTP policy of the transfers.
+func ProvideStepUpPolicy(cfg *config.Configs) intrabank.StepUpPolicy {
+	return intrabank.StepUpPolicy{
+		Threshold: intrabank.Money(cfg.Transfer.OTPThreshold),
+	}
+}
+
+// ProvideFeePolicy provides the configured fee rules and free transfer quotas.
+func ProvideFeePolicy(cfg *config.Configs) intrabank.FeePolicy {
+	fees := cfg.Transfer.Fees
+	policy := intrabank.FeePolicy{
+		Rules:      make([]intrabank.FeeRule, 0, len(fees.Rules)),
+		FreeQuotas: make([]intrabank.FreeQuota, 0, len(fees.FreeQuotas)),
+	}
+	for _, r := range fees.Rules {
+		policy.Rules = append(policy.Rules, intrabank.FeeRule{
+			Method:    r.Method,
+			Channel:   r.Channel,
+			Segment:   r.Segment,
+			MinAmount: intrabank.Money(r.MinAmount),
+			MaxAmount: intrabank.Money(r.MaxAmount),
+			Fee:       intrabank.Money(r.Fee),
+		})
+	}
+	for _, q := range fees.FreeQuotas {
+		policy.FreeQuotas = append(policy.FreeQuotas, intrabank.FreeQuota{
+			Method:    q.Method,
+			Segment:   q.Segment,
+			Transfers: q.Transfers,
+		})
+	}
+	return policy
+}
+
 var otpProviderSet = wire.NewSet(
 	otp.NewOTP, wire.Bind(new(otpdomain.Generator), new(*otp.OTP)),
 )
@@ -49,12 +226,44 @@ var repositoryProviderSet = wire.NewSet(
 	repo.NewIntrabankRepo, wire.Bind(new(intrabank.Repository), new(*repo.IntrabankRepo)),
 	repo.NewUserRepo, wire.Bind(new(user.Repository), new(*repo.UserRepo)),
 	repo.NewOTPRepo, wire.Bind(new(otpdomain.Repository), new(*repo.OTPRepo)),
+	repo.NewScheduleRepo, wire.Bind(new(schedule.Repository), new(*repo.ScheduleRepo)),
+	repo.NewStandingOrderRepo, wire.Bind(new(standingorder.Repository), new(*repo.StandingOrderRepo)),
+	repo.NewBeneficiaryRepo, wire.Bind(new(beneficiary.Repository), new(*repo.BeneficiaryRepo)),
+	repo.NewInterbankRepo, wire.Bind(new(interbank.Repository), new(*repo.InterbankRepo)),
+	repo.NewBulkTransferRepo, wire.Bind(new(bulktransfer.Repository), new(*repo.BulkTransferRepo)),
+	repo.NewPaymentRequestRepo, wire.Bind(new(paymentrequest.Repository), new(*repo.PaymentRequestRepo)),
+	wire.Bind(new(paymentrequest.UserDirectory), new(*repo.PaymentRequestRepo)),
+	repo.NewQRISRepo, wire.Bind(new(qris.Repository), new(*repo.QRISRepo)),
+	repo.NewBillPaymentRepo, wire.Bind(new(billpayment.Repository), new(*repo.BillPaymentRepo)),
+	repo.NewEWalletRepo, wire.Bind(new(ewalletdomain.Repository), new(*repo.EWalletRepo)),
+	repo.NewStatementRepo, wire.Bind(new(statement.Repository), new(*repo.StatementRepo)),
 )
 
 var handlerProviderSet = wire.NewSet(
 	handler.NewIntrabankHandler,
 	handler.NewUserHandler,
 	handler.NewOTPHandler,
+	handler.NewScheduleHandler,
+	handler.NewStandingOrderHandler,
+	handler.NewBeneficiaryHandler,
+	handler.NewInterbankHandler,
+	handler.NewBulkTransferHandler,
+	handler.NewPaymentRequestHandler,
+	handler.NewQRISHandler,
+	handler.NewBillPaymentHandler,
+	handler.NewEWalletHandler,
+	handler.NewStatementHandler,
+)
+
+var workerProviderSet = wire.NewSet(
+	worker.NewScheduleWorker,
+	worker.NewStandingOrderWorker,
+	worker.NewReconcilerWorker,
+	worker.NewOutboxWorker,
+	worker.NewSequenceCleanupWorker,
+	worker.NewBulkTransferWorker,
+	worker.NewPaymentRequestExpiryWorker,
+	worker.NewSettlementWorker,
 )
 
 var serverProviderSet = wire.NewSet(
@@ -66,11 +275,19 @@ var ProviderSet = wire.NewSet(
 	tokenProviderSet,
 	passwordProviderSet,
 	coreBankingProviderSet,
+	switchingProviderSet,
+	acquirerProviderSet,
+	billerProviderSet,
+	eWalletProviderSet,
+	qrisCodeProviderSet,
+	statementFileProviderSet,
 	emailProviderSet,
 	notificationProviderSet,
 	sequencerProviderSet,
+	transferPolicyProviderSet,
 	otpProviderSet,
 	repositoryProviderSet,
 	handlerProviderSet,
+	workerProviderSet,
 	serverProviderSet,
 )
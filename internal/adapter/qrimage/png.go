@@ -0,0 +1,21 @@
+// Package qrimage renders the QRIS payloads as QR code images.
+package qrimage
+
+import qrcode "github.com/skip2/go-qrcode"
+
+const (
+	// imageSize is the width and height of the rendered images in pixels.
+	imageSize = 512
+)
+
+// PNGRenderer renders the QR codes as PNG images with the medium error correction level,
+// which recovers 15% of the code.
+type PNGRenderer struct{}
+
+func NewPNGRenderer() *PNGRenderer {
+	return &PNGRenderer{}
+}
+
+func (r *PNGRenderer) PNG(payload string) ([]byte, error) {
+	return qrcode.Encode(payload, qrcode.Medium, imageSize)
+}
@@ -0,0 +1,20 @@
+package qrimage
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPNGRendererPNG(t *testing.T) {
+	renderer := NewPNGRenderer()
+
+	b, err := renderer.PNG("00020101021126570018ID.CO.BANKYAYA.WWW6304ABCD")
+	assert.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(b))
+	assert.NoError(t, err)
+	assert.Equal(t, 512, img.Bounds().Dx())
+}
@@ -0,0 +1,202 @@
+// Package statementfile renders the account statements as PDF and CSV files.
+package statementfile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/statement"
+	"go.bankyaya.org/app/backend/internal/pkg/constant"
+)
+
+const (
+	dateLayout     = "02 Jan 2006"
+	dateTimeLayout = "02/01/2006 15:04"
+	csvTimeLayout  = "2006-01-02 15:04:05"
+
+	// descriptionLength is the longest description printed in the PDF table before it is cut.
+	descriptionLength = 34
+)
+
+// columns of the PDF table with their widths in millimetres, they fill the A4 page within the margins.
+var columns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{title: "Date", width: 27, align: "L"},
+	{title: "Description", width: 58, align: "L"},
+	{title: "Reference", width: 29, align: "L"},
+	{title: "Debit", width: 22, align: "R"},
+	{title: "Credit", width: 22, align: "R"},
+	{title: "Balance", width: 22, align: "R"},
+}
+
+// Renderer renders the statements with the branding of the bank.
+type Renderer struct{}
+
+func NewRenderer() *Renderer {
+	return &Renderer{}
+}
+
+func (r *Renderer) PDF(st *statement.Statement) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.SetTitle("Account Statement "+st.AccountNumber, true)
+	pdf.SetAuthor(constant.BankYayaCompanyName, true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "I", 7)
+		pdf.SetTextColor(110, 110, 110)
+		pdf.CellFormat(120, 4, tr("Verification reference: "+st.Reference), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 4, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 1, "R", false, 0, "")
+		pdf.CellFormat(0, 4, tr("This statement is issued electronically by "+constant.BankYayaCompanyName+
+			" and is valid without a signature."), "", 0, "L", false, 0, "")
+	})
+	pdf.AliasNbPages("")
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.SetTextColor(0, 70, 106)
+	pdf.CellFormat(0, 7, tr(constant.BankYayaCompanyName), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(0, 7, "ACCOUNT STATEMENT", "B", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	details := [][2]string{
+		{"Account Name", st.AccountName},
+		{"Account Number", st.AccountNumber},
+		{"Currency", st.Currency},
+		{"Period", st.Period.From.Format(dateLayout) + " - " + st.Period.To.Format(dateLayout)},
+		{"Issued At", st.CreatedAt.In(intrabank.BusinessLocation).Format(dateTimeLayout) + " WIB"},
+		{"Verification Reference", st.Reference},
+	}
+	pdf.SetFont("Helvetica", "", 9)
+	for _, d := range details {
+		pdf.CellFormat(40, 5, d[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 5, tr(": "+d[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(3)
+
+	summary := [][2]string{
+		{"Opening Balance", formatMoney(st.OpeningBalance)},
+		{"Total Credit", formatMoney(st.TotalCredit)},
+		{"Total Debit", formatMoney(st.TotalDebit)},
+		{"Closing Balance", formatMoney(st.ClosingBalance)},
+	}
+	pdf.SetFillColor(235, 242, 247)
+	pdf.SetFont("Helvetica", "B", 8)
+	for _, s := range summary {
+		pdf.CellFormat(45, 6, s[0], "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetFont("Helvetica", "", 9)
+	for _, s := range summary {
+		pdf.CellFormat(45, 7, s[1], "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(10)
+
+	header := func() {
+		pdf.SetFont("Helvetica", "B", 8)
+		for _, c := range columns {
+			pdf.CellFormat(c.width, 6, c.title, "1", 0, c.align, true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 8)
+	}
+	header()
+
+	row := func(values ...string) {
+		if pdf.GetY()+5 > 277 {
+			pdf.AddPage()
+			header()
+		}
+		for i, c := range columns {
+			pdf.CellFormat(c.width, 5, tr(values[i]), "LR", 0, c.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	row(st.Period.From.Format("02/01/2006"), "OPENING BALANCE", "", "", "", formatMoney(st.OpeningBalance))
+	for _, e := range st.Entries {
+		row(
+			e.PostedAt.In(intrabank.BusinessLocation).Format(dateTimeLayout),
+			truncate(e.Description, descriptionLength),
+			e.Reference,
+			formatAmount(e.Debit),
+			formatAmount(e.Credit),
+			formatMoney(e.Balance),
+		)
+	}
+	if len(st.Entries) == 0 {
+		row("", "NO TRANSACTIONS IN THIS PERIOD", "", "", "", "")
+	}
+	row(st.Period.To.Format("02/01/2006"), "CLOSING BALANCE", "", "", "", formatMoney(st.ClosingBalance))
+	pdf.CellFormat(180, 0, "", "T", 1, "L", false, 0, "")
+
+	buf := new(bytes.Buffer)
+	if err := pdf.Output(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *Renderer) CSV(st *statement.Statement) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	records := [][]string{{"Date", "Description", "Reference", "Debit", "Credit", "Balance"}}
+	for _, e := range st.Entries {
+		records = append(records, []string{
+			e.PostedAt.In(intrabank.BusinessLocation).Format(csvTimeLayout),
+			e.Description,
+			e.Reference,
+			strconv.FormatInt(int64(e.Debit), 10),
+			strconv.FormatInt(int64(e.Credit), 10),
+			strconv.FormatInt(int64(e.Balance), 10),
+		})
+	}
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatMoney formats the amount with dots as the thousand separators, e.g. "1.250.000".
+func formatMoney(m intrabank.Money) string {
+	n := int64(m)
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	digits := strconv.FormatInt(n, 10)
+	out := make([]byte, 0, len(digits)+len(digits)/3)
+	for i := range len(digits) {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, '.')
+		}
+		out = append(out, digits[i])
+	}
+	return sign + string(out)
+}
+
+// formatAmount formats the debit or credit of an entry, which is left blank when it is zero.
+func formatAmount(m intrabank.Money) string {
+	if m == 0 {
+		return ""
+	}
+	return formatMoney(m)
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-3]) + "..."
+	}
+	return s
+}
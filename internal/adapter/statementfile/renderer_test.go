@@ -0,0 +1,68 @@
+package statementfile
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/statement"
+)
+
+func testStatement() *statement.Statement {
+	day := func(d int) time.Time {
+		return time.Date(2026, time.September, d, 0, 0, 0, 0, intrabank.BusinessLocation)
+	}
+	return &statement.Statement{
+		Reference:      "STMB3C2D1E0F9A8",
+		AccountNumber:  "001001234567891",
+		AccountName:    "Olivia Rodrigo",
+		Currency:       "IDR",
+		Period:         statement.Period{From: day(1), To: day(30)},
+		OpeningBalance: 1_101_000,
+		ClosingBalance: 1_500_000,
+		TotalDebit:     101_000,
+		TotalCredit:    500_000,
+		Entries: []*statement.Entry{
+			{
+				Mutation: statement.Mutation{PostedAt: day(5).Add(9*time.Hour + 30*time.Minute), Description: "TRANSFER TO Taylor Swift", Reference: "TRX001", Debit: 101_000},
+				Balance:  1_000_000,
+			},
+			{
+				Mutation: statement.Mutation{PostedAt: day(25).Add(14 * time.Hour), Description: "TRANSFER FROM Sabrina, Carpenter", Reference: "TRX002", Credit: 500_000},
+				Balance:  1_500_000,
+			},
+		},
+		CreatedAt: day(30).Add(20 * time.Hour),
+	}
+}
+
+func TestRendererPDF(t *testing.T) {
+	b, err := NewRenderer().PDF(testStatement())
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(b, []byte("%PDF-")))
+}
+
+func TestRendererCSV(t *testing.T) {
+	b, err := NewRenderer().CSV(testStatement())
+	assert.NoError(t, err)
+	assert.Equal(t, `Date,Description,Reference,Debit,Credit,Balance
+2026-09-05 09:30:00,TRANSFER TO Taylor Swift,TRX001,101000,0,1000000
+2026-09-25 14:00:00,"TRANSFER FROM Sabrina, Carpenter",TRX002,0,500000,1500000
+`, string(b))
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := map[intrabank.Money]string{
+		0:          "0",
+		999:        "999",
+		1000:       "1.000",
+		1_250_000:  "1.250.000",
+		-101_000:   "-101.000",
+		12_345_678: "12.345.678",
+	}
+	for m, want := range tests {
+		assert.Equal(t, want, formatMoney(m))
+	}
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type AccountStatement struct {
+	ID             int64     `gorm:"column:ID;primaryKey"`
+	Reference      string    `gorm:"column:REFERENCE;uniqueIndex"`
+	UserID         int       `gorm:"column:USER_ID;index"`
+	AccountNumber  string    `gorm:"column:ACCOUNT_NUMBER"`
+	AccountName    string    `gorm:"column:ACCOUNT_NAME"`
+	Currency       string    `gorm:"column:CURRENCY"`
+	PeriodFrom     time.Time `gorm:"column:PERIOD_FROM"`
+	PeriodTo       time.Time `gorm:"column:PERIOD_TO"`
+	OpeningBalance int64     `gorm:"column:OPENING_BALANCE"`
+	ClosingBalance int64     `gorm:"column:CLOSING_BALANCE"`
+	TotalDebit     int64     `gorm:"column:TOTAL_DEBIT"`
+	TotalCredit    int64     `gorm:"column:TOTAL_CREDIT"`
+	CreatedAt      time.Time `gorm:"column:CREATED_AT"`
+}
+
+func (*AccountStatement) TableName() string {
+	return "_account_statements"
+}
@@ -0,0 +1,12 @@
+package model
+
+type Bank struct {
+	ID       int    `gorm:"column:ID;primaryKey"`
+	BankCode string `gorm:"column:BANK_CODE;uniqueIndex"`
+	Name     string `gorm:"column:NAME"`
+	Rails    string `gorm:"column:RAILS"`
+}
+
+func (*Bank) TableName() string {
+	return "_banks"
+}
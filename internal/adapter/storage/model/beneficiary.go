@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type Beneficiary struct {
+	ID            int64     `gorm:"column:ID;primaryKey"`
+	UserID        int       `gorm:"column:USER_ID;uniqueIndex:idx_beneficiary_user_account"`
+	AccountNumber string    `gorm:"column:ACCOUNT_NUMBER;uniqueIndex:idx_beneficiary_user_account"`
+	AccountName   string    `gorm:"column:ACCOUNT_NAME"`
+	Nickname      string    `gorm:"column:NICKNAME"`
+	CreatedAt     time.Time `gorm:"column:CREATED_AT"`
+	UpdatedAt     time.Time `gorm:"column:UPDATED_AT"`
+}
+
+func (*Beneficiary) TableName() string {
+	return "_beneficiaries"
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+type BillPayment struct {
+	ID               int64     `gorm:"column:ID;primaryKey"`
+	SequenceNumber   string    `gorm:"column:SEQ_NO;uniqueIndex"`
+	ProductCode      string    `gorm:"column:PRODUCT_CODE"`
+	CustomerID       string    `gorm:"column:CUSTOMER_ID"`
+	CustomerName     string    `gorm:"column:CUSTOMER_NAME"`
+	Period           string    `gorm:"column:PERIOD"`
+	Amount           int64     `gorm:"column:AMOUNT"`
+	AdminFee         int64     `gorm:"column:ADMIN_FEE"`
+	Fee              int64     `gorm:"column:FEE"`
+	InquiryReference string    `gorm:"column:INQUIRY_REFERENCE"`
+	SourceAccount    string    `gorm:"column:SOURCE_ACCOUNT"`
+	ExpiresAt        time.Time `gorm:"column:EXPIRES_AT"`
+	Details          []byte    `gorm:"column:DETAILS"`
+	BillerReference  string    `gorm:"column:BILLER_REFERENCE"`
+	Receipt          []byte    `gorm:"column:RECEIPT"`
+	CreatedAt        time.Time `gorm:"column:CREATED_AT"`
+	UpdatedAt        time.Time `gorm:"column:UPDATED_AT"`
+}
+
+func (*BillPayment) TableName() string {
+	return "_bill_payments"
+}
@@ -0,0 +1,16 @@
+package model
+
+type BillProduct struct {
+	ID                int    `gorm:"column:ID;primaryKey"`
+	Code              string `gorm:"column:CODE;uniqueIndex"`
+	Name              string `gorm:"column:NAME"`
+	Category          string `gorm:"column:CATEGORY"`
+	Provider          string `gorm:"column:PROVIDER"`
+	SettlementAccount string `gorm:"column:SETTLEMENT_ACCOUNT"`
+	Denominations     string `gorm:"column:DENOMINATIONS"`
+	Active            bool   `gorm:"column:ACTIVE"`
+}
+
+func (*BillProduct) TableName() string {
+	return "_bill_products"
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type BillReceiptRecovery struct {
+	ID              int64      `gorm:"column:ID;primaryKey"`
+	SequenceNumber  string     `gorm:"column:SEQ_NO;uniqueIndex"`
+	BillerReference string     `gorm:"column:BILLER_REFERENCE"`
+	Receipt         []byte     `gorm:"column:RECEIPT"`
+	Status          string     `gorm:"column:STATUS;index"`
+	Attempts        int        `gorm:"column:ATTEMPTS"`
+	LastError       string     `gorm:"column:LAST_ERROR"`
+	Resolution      string     `gorm:"column:RESOLUTION"`
+	CreatedAt       time.Time  `gorm:"column:CREATED_AT"`
+	UpdatedAt       time.Time  `gorm:"column:UPDATED_AT"`
+	ResolvedAt      *time.Time `gorm:"column:RESOLVED_AT"`
+}
+
+func (*BillReceiptRecovery) TableName() string {
+	return "_bill_receipt_recoveries"
+}
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+type BulkTransfer struct {
+	ID            int64      `gorm:"column:ID;primaryKey"`
+	UserID        int        `gorm:"column:USER_ID;index"`
+	SourceAccount string     `gorm:"column:SOURCE_ACCOUNT"`
+	FileName      string     `gorm:"column:FILE_NAME"`
+	Status        string     `gorm:"column:STATUS;index"`
+	CompletedAt   *time.Time `gorm:"column:COMPLETED_AT"`
+	LeasedUntil   *time.Time `gorm:"column:LEASED_UNTIL"`
+	CreatedAt     time.Time  `gorm:"column:CREATED_AT"`
+	UpdatedAt     time.Time  `gorm:"column:UPDATED_AT"`
+
+	User *User              `gorm:"foreignKey:UserID"`
+	Rows []*BulkTransferRow `gorm:"foreignKey:BatchID"`
+}
+
+func (*BulkTransfer) TableName() string {
+	return "_bulk_transfers"
+}
+
+type BulkTransferRow struct {
+	ID                   int64     `gorm:"column:ID;primaryKey"`
+	BatchID              int64     `gorm:"column:BATCH_ID;index"`
+	Line                 int       `gorm:"column:LINE"`
+	DestinationAccount   string    `gorm:"column:DESTINATION_ACCOUNT"`
+	DestinationName      string    `gorm:"column:DESTINATION_NAME"`
+	Amount               int64     `gorm:"column:AMOUNT"`
+	Fee                  int64     `gorm:"column:FEE"`
+	Note                 string    `gorm:"column:NOTE"`
+	Status               string    `gorm:"column:STATUS"`
+	Error                string    `gorm:"column:ERROR"`
+	TransactionReference string    `gorm:"column:TRANSACTION_REFERENCE"`
+	SequenceNumber       string    `gorm:"column:SEQ_NO"`
+	CreatedAt            time.Time `gorm:"column:CREATED_AT"`
+	UpdatedAt            time.Time `gorm:"column:UPDATED_AT"`
+}
+
+func (*BulkTransferRow) TableName() string {
+	return "_bulk_transfer_rows"
+}
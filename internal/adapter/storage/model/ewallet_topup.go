@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type EWalletTopUp struct {
+	ID             int64     `gorm:"column:ID;primaryKey"`
+	SequenceNumber string    `gorm:"column:SEQ_NO;uniqueIndex"`
+	ProviderCode   string    `gorm:"column:PROVIDER_CODE;index"`
+	ProviderName   string    `gorm:"column:PROVIDER_NAME"`
+	PhoneNumber    string    `gorm:"column:PHONE_NUMBER"`
+	AccountName    string    `gorm:"column:ACCOUNT_NAME"`
+	Amount         int64     `gorm:"column:AMOUNT"`
+	Fee            int64     `gorm:"column:FEE"`
+	SourceAccount  string    `gorm:"column:SOURCE_ACCOUNT"`
+	ExpiresAt      time.Time `gorm:"column:EXPIRES_AT"`
+	CreatedAt      time.Time `gorm:"column:CREATED_AT"`
+	UpdatedAt      time.Time `gorm:"column:UPDATED_AT"`
+}
+
+func (*EWalletTopUp) TableName() string {
+	return "_ewallet_topups"
+}
@@ -10,6 +10,8 @@ type OTP struct {
 	UserID     int
 	Purpose    string
 	Channel    string
+	Reference  string
+	Attempts   int
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 	VerifiedAt time.Time
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type OutboxMessage struct {
+	ID            int64      `gorm:"column:ID;primaryKey"`
+	TransactionID int64      `gorm:"column:TRANSACTION_ID;index"`
+	Kind          string     `gorm:"column:KIND"`
+	Payload       []byte     `gorm:"column:PAYLOAD"`
+	Status        string     `gorm:"column:STATUS"`
+	Attempts      int        `gorm:"column:ATTEMPTS"`
+	NextAttemptAt time.Time  `gorm:"column:NEXT_ATTEMPT_AT;index"`
+	LastError     string     `gorm:"column:LAST_ERROR"`
+	CreatedAt     time.Time  `gorm:"column:CREATED_AT"`
+	UpdatedAt     time.Time  `gorm:"column:UPDATED_AT"`
+	SentAt        *time.Time `gorm:"column:SENT_AT"`
+}
+
+func (*OutboxMessage) TableName() string {
+	return "_outbox_messages"
+}
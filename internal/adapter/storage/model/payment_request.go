@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+type PaymentRequest struct {
+	ID                   int64      `gorm:"column:ID;primaryKey"`
+	RequesterID          int        `gorm:"column:REQUESTER_ID;index"`
+	PayerID              int        `gorm:"column:PAYER_ID;index"`
+	DestinationAccount   string     `gorm:"column:DESTINATION_ACCOUNT"`
+	Amount               int64      `gorm:"column:AMOUNT"`
+	Note                 string     `gorm:"column:NOTE"`
+	Status               string     `gorm:"column:STATUS"`
+	ExpiresAt            time.Time  `gorm:"column:EXPIRES_AT;index"`
+	TransactionReference string     `gorm:"column:TRANSACTION_REFERENCE"`
+	RespondedAt          *time.Time `gorm:"column:RESPONDED_AT"`
+	CreatedAt            time.Time  `gorm:"column:CREATED_AT"`
+	UpdatedAt            time.Time  `gorm:"column:UPDATED_AT"`
+
+	Requester *User `gorm:"foreignKey:RequesterID"`
+	Payer     *User `gorm:"foreignKey:PayerID"`
+}
+
+func (*PaymentRequest) TableName() string {
+	return "_payment_requests"
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type QRISCode struct {
+	ID            int64      `gorm:"column:ID;primaryKey"`
+	UserID        int        `gorm:"column:USER_ID;index"`
+	AccountNumber string     `gorm:"column:ACCOUNT_NUMBER"`
+	Name          string     `gorm:"column:NAME"`
+	Amount        int64      `gorm:"column:AMOUNT"`
+	ExpiresAt     *time.Time `gorm:"column:EXPIRES_AT"`
+	CreatedAt     time.Time  `gorm:"column:CREATED_AT"`
+	UpdatedAt     time.Time  `gorm:"column:UPDATED_AT"`
+}
+
+func (*QRISCode) TableName() string {
+	return "_qris_codes"
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type QRISCodeCredit struct {
+	ID                   int64     `gorm:"column:ID;primaryKey"`
+	NetworkReference     string    `gorm:"column:NETWORK_REFERENCE;uniqueIndex"`
+	CodeID               int64     `gorm:"column:CODE_ID;index"`
+	AccountNumber        string    `gorm:"column:ACCOUNT_NUMBER"`
+	PAN                  string    `gorm:"column:PAN"`
+	ReferenceLabel       string    `gorm:"column:REFERENCE_LABEL"`
+	Amount               int64     `gorm:"column:AMOUNT"`
+	PayerName            string    `gorm:"column:PAYER_NAME"`
+	PayerBank            string    `gorm:"column:PAYER_BANK"`
+	JournalSequence      string    `gorm:"column:JOURNAL_SEQUENCE"`
+	TransactionReference string    `gorm:"column:TRANSACTION_REFERENCE"`
+	CreatedAt            time.Time `gorm:"column:CREATED_AT"`
+	UpdatedAt            time.Time `gorm:"column:UPDATED_AT"`
+}
+
+func (*QRISCodeCredit) TableName() string {
+	return "_qris_code_credits"
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type QRISPayment struct {
+	ID               int64     `gorm:"column:ID;primaryKey"`
+	SequenceNumber   string    `gorm:"column:SEQ_NO;uniqueIndex"`
+	Payload          string    `gorm:"column:PAYLOAD"`
+	GUID             string    `gorm:"column:GUID"`
+	MerchantPAN      string    `gorm:"column:MERCHANT_PAN"`
+	MerchantID       string    `gorm:"column:MERCHANT_ID"`
+	MerchantNMID     string    `gorm:"column:MERCHANT_NMID"`
+	MerchantCriteria string    `gorm:"column:MERCHANT_CRITERIA"`
+	MerchantName     string    `gorm:"column:MERCHANT_NAME"`
+	MerchantCity     string    `gorm:"column:MERCHANT_CITY"`
+	PostalCode       string    `gorm:"column:POSTAL_CODE"`
+	CategoryCode     string    `gorm:"column:MCC"`
+	Amount           int64     `gorm:"column:AMOUNT"`
+	Tip              int64     `gorm:"column:TIP"`
+	Fee              int64     `gorm:"column:FEE"`
+	SourceAccount    string    `gorm:"column:SOURCE_ACCOUNT"`
+	ExpiresAt        time.Time `gorm:"column:EXPIRES_AT"`
+	CodeID           *int64    `gorm:"column:CODE_ID;index"`
+	CreatedAt        time.Time `gorm:"column:CREATED_AT"`
+	UpdatedAt        time.Time `gorm:"column:UPDATED_AT"`
+}
+
+func (*QRISPayment) TableName() string {
+	return "_qris_payments"
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+type ScheduledTransfer struct {
+	ID                   int64      `gorm:"column:ID;primaryKey"`
+	UserID               int        `gorm:"column:USER_ID;index"`
+	SourceAccount        string     `gorm:"column:SOURCE_ACCOUNT"`
+	DestinationAccount   string     `gorm:"column:DESTINATION_ACCOUNT"`
+	DestinationName      string     `gorm:"column:DESTINATION_NAME"`
+	Amount               int64      `gorm:"column:AMOUNT"`
+	ExecuteAt            time.Time  `gorm:"column:EXECUTE_AT;index"`
+	Status               string     `gorm:"column:STATUS"`
+	SequenceNumber       string     `gorm:"column:SEQ_NO"`
+	TransactionReference string     `gorm:"column:TRANSACTION_REFERENCE"`
+	FailureReason        string     `gorm:"column:FAILURE_REASON"`
+	ExecutedAt           *time.Time `gorm:"column:EXECUTED_AT"`
+	LeasedUntil          *time.Time `gorm:"column:LEASED_UNTIL"`
+	CreatedAt            time.Time  `gorm:"column:CREATED_AT"`
+	UpdatedAt            time.Time  `gorm:"column:UPDATED_AT"`
+
+	User *User `gorm:"foreignKey:UserID"`
+}
+
+func (*ScheduledTransfer) TableName() string {
+	return "_scheduled_transfers"
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type Sequence struct {
+	ID                 int        `gorm:"column:ID;primaryKey"`
+	SequenceNumber     string     `gorm:"column:SEQ_NO;uniqueIndex"`
+	Amount             int64      `gorm:"column:AMOUNT"`
+	SourceAccount      string     `gorm:"column:SOURCE_ACCOUNT"`
+	DestinationAccount string     `gorm:"column:DESTINATION_ACCOUNT"`
+	SourceName         string     `gorm:"column:SOURCE_NAME"`
+	DestinationName    string     `gorm:"column:DESTINATION_NAME"`
+	TransactionType    string     `gorm:"column:TRANSACTION_TYPE"`
+	Status             string     `gorm:"column:STATUS"`
+	IdempotencyKey     *string    `gorm:"column:IDEMPOTENCY_KEY;uniqueIndex:idx_sequence_user_idempotency_key,priority:2"`
+	UserID             int        `gorm:"column:USER_ID;uniqueIndex:idx_sequence_user_idempotency_key,priority:1"`
+	DeviceID           string     `gorm:"column:DEVICE_ID"`
+	Channel            string     `gorm:"column:CHANNEL"`
+	Fee                int64      `gorm:"column:FEE"`
+	Note               string     `gorm:"column:NOTE"`
+	BankCode           string     `gorm:"column:BANK_CODE"`
+	Rail               string     `gorm:"column:RAIL"`
+	CreatedAt          time.Time  `gorm:"column:CREATED_AT"`
+	UpdatedAt          time.Time  `gorm:"column:UPDATED_AT"`
+	ExpiresAt          *time.Time `gorm:"column:EXPIRES_AT;index"`
+}
+
+func (*Sequence) TableName() string {
+	return "_transfer_sequences"
+}
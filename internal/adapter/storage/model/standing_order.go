@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+type StandingOrder struct {
+	ID                       int64      `gorm:"column:ID;primaryKey"`
+	UserID                   int        `gorm:"column:USER_ID;index"`
+	SourceAccount            string     `gorm:"column:SOURCE_ACCOUNT"`
+	DestinationAccount       string     `gorm:"column:DESTINATION_ACCOUNT"`
+	DestinationName          string     `gorm:"column:DESTINATION_NAME"`
+	Amount                   int64      `gorm:"column:AMOUNT"`
+	Frequency                string     `gorm:"column:FREQUENCY"`
+	BalancePolicy            string     `gorm:"column:BALANCE_POLICY"`
+	StartAt                  time.Time  `gorm:"column:START_AT"`
+	EndAt                    *time.Time `gorm:"column:END_AT"`
+	MaxRuns                  int        `gorm:"column:MAX_RUNS"`
+	Runs                     int        `gorm:"column:RUNS"`
+	Retries                  int        `gorm:"column:RETRIES"`
+	NextRunAt                time.Time  `gorm:"column:NEXT_RUN_AT;index"`
+	Status                   string     `gorm:"column:STATUS"`
+	LastRunAt                *time.Time `gorm:"column:LAST_RUN_AT"`
+	LastTransactionReference string     `gorm:"column:LAST_TRANSACTION_REFERENCE"`
+	LastFailureReason        string     `gorm:"column:LAST_FAILURE_REASON"`
+	SequenceNumber           string     `gorm:"column:SEQ_NO"`
+	LeasedUntil              *time.Time `gorm:"column:LEASED_UNTIL"`
+	CreatedAt                time.Time  `gorm:"column:CREATED_AT"`
+	UpdatedAt                time.Time  `gorm:"column:UPDATED_AT"`
+
+	User *User `gorm:"foreignKey:UserID"`
+}
+
+func (*StandingOrder) TableName() string {
+	return "_standing_orders"
+}
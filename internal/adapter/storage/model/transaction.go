@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+type Transaction struct {
+	ID                      int64     `gorm:"column:ID;primaryKey"`
+	UUID                    string    `gorm:"column:UUID"`
+	UserID                  string    `gorm:"column:USER_ID"`
+	WalletIDSource          int64     `gorm:"column:WALLET_ID_SOURCE"`
+	Destination             string    `gorm:"column:DESTINATION"`
+	Amount                  int64     `gorm:"column:AMOUNT"`
+	TransactionType         string    `gorm:"column:TRANSACTION_TYPE"`
+	TransactionReference    string    `gorm:"column:TRANSACTION_REFERENCE"`
+	SequenceJournal         string    `gorm:"column:SEQUENCE_JOURNAL"`
+	Remarks                 string    `gorm:"column:REMARKS"`
+	Note                    string    `gorm:"column:NOTE"`
+	CoreRequestPayload      string    `gorm:"column:CORE_REQUEST_PAYLOAD"`
+	CoreResponsePayload     string    `gorm:"column:CORE_RESPONSE_PAYLOAD"`
+	EChannelRequestPayload  string    `gorm:"column:ECHANNEL_REQUEST_PAYLOAD"`
+	EChannelResponsePayload string    `gorm:"column:ECHANNEL_RESPONSE_PAYLOAD"`
+	Status                  string    `gorm:"column:STATUS"`
+	CreatedAt               time.Time `gorm:"column:CREATED_AT"`
+	Fee                     string    `gorm:"column:FEE"`
+	DestinationName         string    `gorm:"column:DESTINATION_NAME"`
+	InitialSourceBalance    float64   `gorm:"column:INITIAL_SOURCE_BALANCE"`
+	StatusCode              string    `gorm:"column:STATUS_CODE"`
+	SequenceNumber          string    `gorm:"column:SEQ_NO;uniqueIndex"`
+	BankCode                string    `gorm:"column:BANK_CODE"`
+	SuccessTransactionDate  time.Time `gorm:"column:SUCCESS_TRANSACTION_DATE"`
+	StandingOrderID         *int64    `gorm:"column:STANDING_ORDER_ID;index"`
+}
+
+func (*Transaction) TableName() string {
+	return "_transactions"
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type TransactionRecovery struct {
+	ID                   int64      `gorm:"column:ID;primaryKey"`
+	TransactionID        int64      `gorm:"column:TRANSACTION_ID;index"`
+	SequenceNumber       string     `gorm:"column:SEQ_NO;uniqueIndex"`
+	JournalSequence      string     `gorm:"column:JOURNAL_SEQUENCE"`
+	TransactionReference string     `gorm:"column:TRANSACTION_REFERENCE"`
+	Status               string     `gorm:"column:STATUS;index"`
+	Attempts             int        `gorm:"column:ATTEMPTS"`
+	LastError            string     `gorm:"column:LAST_ERROR"`
+	Resolution           string     `gorm:"column:RESOLUTION"`
+	CreatedAt            time.Time  `gorm:"column:CREATED_AT"`
+	UpdatedAt            time.Time  `gorm:"column:UPDATED_AT"`
+	ResolvedAt           *time.Time `gorm:"column:RESOLVED_AT"`
+}
+
+func (*TransactionRecovery) TableName() string {
+	return "_transaction_recoveries"
+}
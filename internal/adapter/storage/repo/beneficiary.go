@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
+	"go.bankyaya.org/app/backend/internal/domain/beneficiary"
+	"gorm.io/gorm"
+)
+
+type BeneficiaryRepo struct {
+	db *gorm.DB
+}
+
+func NewBeneficiaryRepo(db *gorm.DB) *BeneficiaryRepo {
+	return &BeneficiaryRepo{
+		db: db,
+	}
+}
+
+func (repo *BeneficiaryRepo) Insert(ctx context.Context, b *beneficiary.Beneficiary) error {
+	m := &model.Beneficiary{
+		UserID:        b.UserID,
+		AccountNumber: b.AccountNumber,
+		AccountName:   b.AccountName,
+		Nickname:      b.Nickname,
+	}
+	res := repo.db.WithContext(ctx).Create(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return beneficiary.ErrBeneficiaryExists
+		}
+		return err
+	}
+	b.ID = m.ID
+	b.CreatedAt = m.CreatedAt
+	b.UpdatedAt = m.UpdatedAt
+	return nil
+}
+
+func (repo *BeneficiaryRepo) Get(ctx context.Context, userID int, id int64) (*beneficiary.Beneficiary, error) {
+	m := new(model.Beneficiary)
+	res := repo.db.WithContext(ctx).
+		Where(`"ID" = ? AND "USER_ID" = ?`, id, userID).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, beneficiary.ErrBeneficiaryNotFound
+		}
+		return nil, err
+	}
+	return beneficiaryFromModel(m), nil
+}
+
+func (repo *BeneficiaryRepo) List(ctx context.Context, userID int) ([]*beneficiary.Beneficiary, error) {
+	var ms []*model.Beneficiary
+	res := repo.db.WithContext(ctx).
+		Where(`"USER_ID" = ?`, userID).
+		Order(`COALESCE(NULLIF("NICKNAME", ''), "ACCOUNT_NAME")`).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	beneficiaries := make([]*beneficiary.Beneficiary, 0, len(ms))
+	for _, m := range ms {
+		beneficiaries = append(beneficiaries, beneficiaryFromModel(m))
+	}
+	return beneficiaries, nil
+}
+
+func (repo *BeneficiaryRepo) UpdateNickname(ctx context.Context, userID int, id int64, nickname string) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.Beneficiary)).
+		Where(`"ID" = ? AND "USER_ID" = ?`, id, userID).
+		Update("NICKNAME", nickname)
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return beneficiary.ErrBeneficiaryNotFound
+	}
+	return nil
+}
+
+func (repo *BeneficiaryRepo) Delete(ctx context.Context, userID int, id int64) error {
+	res := repo.db.WithContext(ctx).
+		Where(`"ID" = ? AND "USER_ID" = ?`, id, userID).
+		Delete(new(model.Beneficiary))
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return beneficiary.ErrBeneficiaryNotFound
+	}
+	return nil
+}
+
+func beneficiaryFromModel(m *model.Beneficiary) *beneficiary.Beneficiary {
+	return &beneficiary.Beneficiary{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		AccountNumber: m.AccountNumber,
+		AccountName:   m.AccountName,
+		Nickname:      m.Nickname,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
@@ -0,0 +1,306 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"gorm.io/gorm"
+)
+
+// BillPaymentRepo stores the bill payments next to the sequence and transaction tables of the intrabank transfers.
+type BillPaymentRepo struct {
+	*IntrabankRepo
+}
+
+func NewBillPaymentRepo(db *gorm.DB) *BillPaymentRepo {
+	return &BillPaymentRepo{
+		IntrabankRepo: NewIntrabankRepo(db),
+	}
+}
+
+func (repo *BillPaymentRepo) GetProducts(ctx context.Context) ([]*billpayment.Product, error) {
+	var ms []*model.BillProduct
+	res := repo.db.WithContext(ctx).
+		Where(`"ACTIVE" = ?`, true).
+		Order(`"CATEGORY" ASC, "NAME" ASC`).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	products := make([]*billpayment.Product, 0, len(ms))
+	for _, m := range ms {
+		products = append(products, billProductFromModel(m))
+	}
+	return products, nil
+}
+
+func (repo *BillPaymentRepo) GetProduct(ctx context.Context, code string) (*billpayment.Product, error) {
+	m := new(model.BillProduct)
+	res := repo.db.WithContext(ctx).
+		Where(`"CODE" = ? AND "ACTIVE" = ?`, code, true).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, billpayment.ErrProductNotFound
+		}
+		return nil, err
+	}
+	return billProductFromModel(m), nil
+}
+
+func (repo *BillPaymentRepo) GetLimits(ctx context.Context) (*intrabank.Limits, error) {
+	return repo.transferMethodLimits(ctx, billpayment.TransactionType)
+}
+
+func (repo *BillPaymentRepo) InsertPayment(ctx context.Context, payment *billpayment.Payment) error {
+	m, err := billPaymentToModel(payment)
+	if err != nil {
+		return err
+	}
+	res := repo.db.WithContext(ctx).Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	payment.ID = m.ID
+	return nil
+}
+
+// GetPayment retrieves the bill payment with its product, which is looked up regardless of being still active.
+func (repo *BillPaymentRepo) GetPayment(ctx context.Context, sequenceNumber string) (*billpayment.Payment, error) {
+	m := new(model.BillPayment)
+	res := repo.db.WithContext(ctx).
+		Where(`"SEQ_NO" = ?`, sequenceNumber).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, billpayment.ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	product := new(model.BillProduct)
+	res = repo.db.WithContext(ctx).
+		Where(`"CODE" = ?`, m.ProductCode).
+		First(product)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, billpayment.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	return billPaymentFromModel(m, billProductFromModel(product))
+}
+
+func (repo *BillPaymentRepo) UpdateReceipt(ctx context.Context, payment *billpayment.Payment) error {
+	receipt, err := json.Marshal(payment.Receipt)
+	if err != nil {
+		return err
+	}
+	res := repo.db.WithContext(ctx).
+		Model(new(model.BillPayment)).
+		Where(`"SEQ_NO" = ?`, payment.SequenceNumber).
+		Updates(map[string]any{
+			"BILLER_REFERENCE": payment.BillerReference,
+			"RECEIPT":          receipt,
+			"UPDATED_AT":       time.Now(),
+		})
+	return res.Error
+}
+
+func (repo *BillPaymentRepo) InsertReceiptRecovery(ctx context.Context, recovery *billpayment.ReceiptRecovery) error {
+	m, err := receiptRecoveryToModel(recovery)
+	if err != nil {
+		return err
+	}
+	res := repo.db.WithContext(ctx).Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	recovery.ID = m.ID
+	recovery.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (repo *BillPaymentRepo) GetOpenReceiptRecoveries(ctx context.Context, limit int) ([]*billpayment.ReceiptRecovery, error) {
+	var ms []*model.BillReceiptRecovery
+	res := repo.db.WithContext(ctx).
+		Where(`"STATUS" = ?`, intrabank.RecoveryOpen).
+		Order(`"ID"`).
+		Limit(limit).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+
+	recoveries := make([]*billpayment.ReceiptRecovery, 0, len(ms))
+	for _, m := range ms {
+		recovery, err := receiptRecoveryFromModel(m)
+		if err != nil {
+			return nil, err
+		}
+		recoveries = append(recoveries, recovery)
+	}
+	return recoveries, nil
+}
+
+func (repo *BillPaymentRepo) UpdateReceiptRecovery(ctx context.Context, recovery *billpayment.ReceiptRecovery) error {
+	updates := map[string]any{
+		"STATUS":     recovery.Status,
+		"ATTEMPTS":   recovery.Attempts,
+		"LAST_ERROR": recovery.LastError,
+		"RESOLUTION": recovery.Resolution,
+		"UPDATED_AT": time.Now(),
+	}
+	if !recovery.ResolvedAt.IsZero() {
+		updates["RESOLVED_AT"] = recovery.ResolvedAt
+	}
+	res := repo.db.WithContext(ctx).
+		Model(new(model.BillReceiptRecovery)).
+		Where(`"ID" = ?`, recovery.ID).
+		Updates(updates)
+	return res.Error
+}
+
+func (repo *BillPaymentRepo) GetPendingTransactions(ctx context.Context, before time.Time, limit int) ([]*intrabank.Transaction, error) {
+	return repo.pendingTransactionsOfType(ctx, []string{billpayment.TransactionType}, before, limit)
+}
+
+func (repo *BillPaymentRepo) CountTransfersOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (int, error) {
+	return repo.countTransfersOfType(ctx, userID, transactionType, from, to)
+}
+
+func (repo *BillPaymentRepo) SumTransferAmountOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (intrabank.Money, error) {
+	return repo.sumTransferAmountOfType(ctx, userID, transactionType, from, to)
+}
+
+// billProductFromModel maps the product row, the DENOMINATIONS column is a comma-separated list of amounts.
+func billProductFromModel(m *model.BillProduct) *billpayment.Product {
+	product := &billpayment.Product{
+		Code:              m.Code,
+		Name:              m.Name,
+		Category:          billpayment.Category(m.Category),
+		Provider:          m.Provider,
+		SettlementAccount: m.SettlementAccount,
+	}
+	for _, denomination := range strings.Split(m.Denominations, ",") {
+		amount, err := strconv.ParseInt(strings.TrimSpace(denomination), 10, 64)
+		if err == nil && amount > 0 {
+			product.Denominations = append(product.Denominations, intrabank.Money(amount))
+		}
+	}
+	return product
+}
+
+// billPaymentToModel maps the payment, the biller details and receipt are stored as JSON.
+func billPaymentToModel(p *billpayment.Payment) (*model.BillPayment, error) {
+	details, err := json.Marshal(p.Details)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := json.Marshal(p.Receipt)
+	if err != nil {
+		return nil, err
+	}
+	m := &model.BillPayment{
+		ID:               p.ID,
+		SequenceNumber:   p.SequenceNumber,
+		CustomerID:       p.CustomerID,
+		CustomerName:     p.CustomerName,
+		Period:           p.Period,
+		Amount:           int64(p.Amount),
+		AdminFee:         int64(p.AdminFee),
+		Fee:              int64(p.Fee),
+		InquiryReference: p.InquiryReference,
+		SourceAccount:    p.SourceAccount,
+		ExpiresAt:        p.ExpiresAt,
+		Details:          details,
+		BillerReference:  p.BillerReference,
+		Receipt:          receipt,
+	}
+	if p.Product != nil {
+		m.ProductCode = p.Product.Code
+	}
+	return m, nil
+}
+
+func billPaymentFromModel(m *model.BillPayment, product *billpayment.Product) (*billpayment.Payment, error) {
+	p := &billpayment.Payment{
+		ID:               m.ID,
+		SequenceNumber:   m.SequenceNumber,
+		Product:          product,
+		CustomerID:       m.CustomerID,
+		CustomerName:     m.CustomerName,
+		Period:           m.Period,
+		Amount:           intrabank.Money(m.Amount),
+		AdminFee:         intrabank.Money(m.AdminFee),
+		Fee:              intrabank.Money(m.Fee),
+		InquiryReference: m.InquiryReference,
+		SourceAccount:    m.SourceAccount,
+		ExpiresAt:        m.ExpiresAt,
+		BillerReference:  m.BillerReference,
+	}
+	if len(m.Details) > 0 {
+		if err := json.Unmarshal(m.Details, &p.Details); err != nil {
+			return nil, err
+		}
+	}
+	if len(m.Receipt) > 0 {
+		if err := json.Unmarshal(m.Receipt, &p.Receipt); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// receiptRecoveryToModel maps the recovery entry, the receipt is stored as JSON like the receipt of the payment.
+func receiptRecoveryToModel(recovery *billpayment.ReceiptRecovery) (*model.BillReceiptRecovery, error) {
+	receipt, err := json.Marshal(recovery.Receipt)
+	if err != nil {
+		return nil, err
+	}
+	m := &model.BillReceiptRecovery{
+		ID:              recovery.ID,
+		SequenceNumber:  recovery.SequenceNumber,
+		BillerReference: recovery.BillerReference,
+		Receipt:         receipt,
+		Status:          recovery.Status,
+		Attempts:        recovery.Attempts,
+		LastError:       recovery.LastError,
+		Resolution:      recovery.Resolution,
+		CreatedAt:       recovery.CreatedAt,
+	}
+	if !recovery.ResolvedAt.IsZero() {
+		m.ResolvedAt = &recovery.ResolvedAt
+	}
+	return m, nil
+}
+
+func receiptRecoveryFromModel(m *model.BillReceiptRecovery) (*billpayment.ReceiptRecovery, error) {
+	recovery := &billpayment.ReceiptRecovery{
+		ID:              m.ID,
+		SequenceNumber:  m.SequenceNumber,
+		BillerReference: m.BillerReference,
+		Status:          m.Status,
+		Attempts:        m.Attempts,
+		LastError:       m.LastError,
+		Resolution:      m.Resolution,
+		CreatedAt:       m.CreatedAt,
+	}
+	if m.ResolvedAt != nil {
+		recovery.ResolvedAt = *m.ResolvedAt
+	}
+	if len(m.Receipt) > 0 {
+		if err := json.Unmarshal(m.Receipt, &recovery.Receipt); err != nil {
+			return nil, err
+		}
+	}
+	return recovery, nil
+}
@@ -0,0 +1,228 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
+	"go.bankyaya.org/app/backend/internal/domain/bulktransfer"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BulkTransferRepo struct {
+	db *gorm.DB
+}
+
+func NewBulkTransferRepo(db *gorm.DB) *BulkTransferRepo {
+	return &BulkTransferRepo{
+		db: db,
+	}
+}
+
+func (repo *BulkTransferRepo) Insert(ctx context.Context, batch *bulktransfer.Batch) error {
+	m := bulkTransferToModel(batch)
+	// The rows are created together with the batch.
+	res := repo.db.WithContext(ctx).Omit("User").Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	batch.ID = m.ID
+	batch.CreatedAt = m.CreatedAt
+	for i, row := range batch.Rows {
+		row.ID = m.Rows[i].ID
+	}
+	return nil
+}
+
+func (repo *BulkTransferRepo) Get(ctx context.Context, userID int, id int64) (*bulktransfer.Batch, error) {
+	m := new(model.BulkTransfer)
+	res := repo.db.WithContext(ctx).
+		Preload("Rows", func(db *gorm.DB) *gorm.DB {
+			return db.Order(`"LINE"`)
+		}).
+		Where(`"ID" = ? AND "USER_ID" = ?`, id, userID).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, bulktransfer.ErrBatchNotFound
+		}
+		return nil, err
+	}
+	return bulkTransferFromModel(m), nil
+}
+
+func (repo *BulkTransferRepo) Queue(ctx context.Context, userID int, id int64) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.BulkTransfer)).
+		Where(`"ID" = ? AND "USER_ID" = ? AND "STATUS" = ?`, id, userID, bulktransfer.StatusPreview).
+		Update("STATUS", bulktransfer.StatusQueued)
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return bulktransfer.ErrBatchNotConfirmable
+	}
+	return nil
+}
+
+func (repo *BulkTransferRepo) AcquireQueued(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*bulktransfer.Batch, error) {
+	var ids []int64
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets concurrent workers acquire different batches instead of waiting.
+		res := tx.Model(new(model.BulkTransfer)).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(`"STATUS" = ? OR ("STATUS" = ? AND ("LEASED_UNTIL" IS NULL OR "LEASED_UNTIL" < ?))`,
+				bulktransfer.StatusQueued, bulktransfer.StatusProcessing, now).
+			Order(`"ID"`).
+			Limit(limit).
+			Pluck(`"ID"`, &ids)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		res = tx.Model(new(model.BulkTransfer)).
+			Where(`"ID" IN ?`, ids).
+			Updates(map[string]any{
+				"STATUS":       bulktransfer.StatusProcessing,
+				"LEASED_UNTIL": leaseUntil,
+			})
+		return res.Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var ms []*model.BulkTransfer
+	res := repo.db.WithContext(ctx).
+		Preload("User").
+		Preload("Rows", func(db *gorm.DB) *gorm.DB {
+			return db.Order(`"LINE"`)
+		}).
+		Where(`"ID" IN ?`, ids).
+		Order(`"ID"`).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	batches := make([]*bulktransfer.Batch, 0, len(ms))
+	for _, m := range ms {
+		batches = append(batches, bulkTransferFromModel(m))
+	}
+	return batches, nil
+}
+
+func (repo *BulkTransferRepo) SetRowSequence(ctx context.Context, row *bulktransfer.Row) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.BulkTransferRow)).
+		Where(`"ID" = ?`, row.ID).
+		Updates(map[string]any{
+			"SEQ_NO": row.SequenceNumber,
+			"FEE":    int64(row.Fee),
+		})
+	return res.Error
+}
+
+func (repo *BulkTransferRepo) UpdateRow(ctx context.Context, row *bulktransfer.Row) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.BulkTransferRow)).
+		Where(`"ID" = ?`, row.ID).
+		Updates(map[string]any{
+			"STATUS":                row.Status,
+			"FEE":                   int64(row.Fee),
+			"ERROR":                 row.Error,
+			"TRANSACTION_REFERENCE": row.TransactionReference,
+		})
+	return res.Error
+}
+
+func (repo *BulkTransferRepo) Finish(ctx context.Context, batch *bulktransfer.Batch) error {
+	m := bulkTransferToModel(batch)
+	res := repo.db.WithContext(ctx).
+		Model(new(model.BulkTransfer)).
+		Where(`"ID" = ?`, batch.ID).
+		Updates(map[string]any{
+			"STATUS":       m.Status,
+			"COMPLETED_AT": m.CompletedAt,
+		})
+	return res.Error
+}
+
+func bulkTransferToModel(batch *bulktransfer.Batch) *model.BulkTransfer {
+	m := &model.BulkTransfer{
+		ID:            batch.ID,
+		SourceAccount: batch.SourceAccount,
+		FileName:      batch.FileName,
+		Status:        batch.Status,
+		Rows:          make([]*model.BulkTransferRow, 0, len(batch.Rows)),
+	}
+	if batch.User != nil {
+		m.UserID = batch.User.ID
+	}
+	if !batch.CompletedAt.IsZero() {
+		m.CompletedAt = &batch.CompletedAt
+	}
+	for _, row := range batch.Rows {
+		m.Rows = append(m.Rows, &model.BulkTransferRow{
+			ID:                   row.ID,
+			BatchID:              batch.ID,
+			Line:                 row.Line,
+			DestinationAccount:   row.DestinationAccount,
+			DestinationName:      row.DestinationName,
+			Amount:               int64(row.Amount),
+			Fee:                  int64(row.Fee),
+			Note:                 row.Note,
+			Status:               row.Status,
+			Error:                row.Error,
+			TransactionReference: row.TransactionReference,
+			SequenceNumber:       row.SequenceNumber,
+		})
+	}
+	return m
+}
+
+func bulkTransferFromModel(m *model.BulkTransfer) *bulktransfer.Batch {
+	batch := &bulktransfer.Batch{
+		ID:            m.ID,
+		User:          &bulktransfer.User{ID: m.UserID},
+		SourceAccount: m.SourceAccount,
+		FileName:      m.FileName,
+		Status:        m.Status,
+		Rows:          make([]*bulktransfer.Row, 0, len(m.Rows)),
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.User != nil {
+		batch.User = &bulktransfer.User{
+			ID:    m.User.ID,
+			CIF:   m.User.CIF,
+			Name:  m.User.FullName,
+			Email: m.User.Email,
+		}
+	}
+	if m.CompletedAt != nil {
+		batch.CompletedAt = *m.CompletedAt
+	}
+	for _, row := range m.Rows {
+		batch.Rows = append(batch.Rows, &bulktransfer.Row{
+			ID:                   row.ID,
+			Line:                 row.Line,
+			DestinationAccount:   row.DestinationAccount,
+			DestinationName:      row.DestinationName,
+			Amount:               intrabank.Money(row.Amount),
+			Fee:                  intrabank.Money(row.Fee),
+			Note:                 row.Note,
+			Status:               row.Status,
+			Error:                row.Error,
+			TransactionReference: row.TransactionReference,
+			SequenceNumber:       row.SequenceNumber,
+		})
+	}
+	return batch
+}
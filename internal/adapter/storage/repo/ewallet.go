@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
+	"go.bankyaya.org/app/backend/internal/domain/ewallet"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"gorm.io/gorm"
+)
+
+// EWalletRepo stores the e-wallet top-ups next to the sequence and transaction tables of the intrabank transfers.
+type EWalletRepo struct {
+	*IntrabankRepo
+}
+
+func NewEWalletRepo(db *gorm.DB) *EWalletRepo {
+	return &EWalletRepo{
+		IntrabankRepo: NewIntrabankRepo(db),
+	}
+}
+
+func (repo *EWalletRepo) InsertTopUp(ctx context.Context, topUp *ewallet.TopUp) error {
+	m := eWalletTopUpToModel(topUp)
+	res := repo.db.WithContext(ctx).Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	topUp.ID = m.ID
+	return nil
+}
+
+func (repo *EWalletRepo) GetTopUp(ctx context.Context, sequenceNumber string) (*ewallet.TopUp, error) {
+	m := new(model.EWalletTopUp)
+	res := repo.db.WithContext(ctx).
+		Where(`"SEQ_NO" = ?`, sequenceNumber).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ewallet.ErrTopUpNotFound
+		}
+		return nil, err
+	}
+	return eWalletTopUpFromModel(m), nil
+}
+
+// SumTopUpAmount joins the transactions with the top-ups of their sequences to sum the top-ups of a single provider.
+func (repo *EWalletRepo) SumTopUpAmount(ctx context.Context, userID, providerCode string, from, to time.Time) (intrabank.Money, error) {
+	var total int64
+	res := repo.db.WithContext(ctx).
+		Model(new(model.Transaction)).
+		Select(`COALESCE(SUM("_transactions"."AMOUNT"), 0)`).
+		Joins(`JOIN "_ewallet_topups" ON "_ewallet_topups"."SEQ_NO" = "_transactions"."SEQ_NO"`).
+		Where(`"_transactions"."USER_ID" = ? AND "_transactions"."TRANSACTION_TYPE" = ?`, userID, ewallet.TransactionType).
+		Where(`"_ewallet_topups"."PROVIDER_CODE" = ?`, providerCode).
+		Where(`"_transactions"."STATUS" IN ?`, []string{intrabank.TransactionSuccess, intrabank.TransactionPending}).
+		Where(`"_transactions"."CREATED_AT" >= ? AND "_transactions"."CREATED_AT" < ?`, from, to).
+		Scan(&total)
+	if err := res.Error; err != nil {
+		return 0, err
+	}
+	return intrabank.Money(total), nil
+}
+
+func (repo *EWalletRepo) GetPendingTransactions(ctx context.Context, before time.Time, limit int) ([]*intrabank.Transaction, error) {
+	return repo.pendingTransactionsOfType(ctx, []string{ewallet.TransactionType}, before, limit)
+}
+
+func eWalletTopUpToModel(t *ewallet.TopUp) *model.EWalletTopUp {
+	return &model.EWalletTopUp{
+		ID:             t.ID,
+		SequenceNumber: t.SequenceNumber,
+		ProviderCode:   t.ProviderCode,
+		ProviderName:   t.ProviderName,
+		PhoneNumber:    t.PhoneNumber,
+		AccountName:    t.AccountName,
+		Amount:         int64(t.Amount),
+		Fee:            int64(t.Fee),
+		SourceAccount:  t.SourceAccount,
+		ExpiresAt:      t.ExpiresAt,
+	}
+}
+
+func eWalletTopUpFromModel(m *model.EWalletTopUp) *ewallet.TopUp {
+	return &ewallet.TopUp{
+		ID:             m.ID,
+		SequenceNumber: m.SequenceNumber,
+		ProviderCode:   m.ProviderCode,
+		ProviderName:   m.ProviderName,
+		PhoneNumber:    m.PhoneNumber,
+		AccountName:    m.AccountName,
+		Amount:         intrabank.Money(m.Amount),
+		Fee:            intrabank.Money(m.Fee),
+		SourceAccount:  m.SourceAccount,
+		ExpiresAt:      m.ExpiresAt,
+	}
+}
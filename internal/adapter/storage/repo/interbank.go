@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"gorm.io/gorm"
+)
+
+// InterbankRepo stores the interbank transfers in the sequence and transaction tables of the intrabank transfers.
+type InterbankRepo struct {
+	*IntrabankRepo
+}
+
+func NewInterbankRepo(db *gorm.DB) *InterbankRepo {
+	return &InterbankRepo{
+		IntrabankRepo: NewIntrabankRepo(db),
+	}
+}
+
+func (repo *InterbankRepo) GetBanks(ctx context.Context) ([]*interbank.Bank, error) {
+	var ms []*model.Bank
+	res := repo.db.WithContext(ctx).
+		Order(`"NAME" ASC`).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	banks := make([]*interbank.Bank, 0, len(ms))
+	for _, m := range ms {
+		banks = append(banks, bankFromModel(m))
+	}
+	return banks, nil
+}
+
+func (repo *InterbankRepo) GetBank(ctx context.Context, code string) (*interbank.Bank, error) {
+	m := new(model.Bank)
+	res := repo.db.WithContext(ctx).
+		Where(`"BANK_CODE" = ?`, code).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interbank.ErrBankNotFound
+		}
+		return nil, err
+	}
+	return bankFromModel(m), nil
+}
+
+func (repo *InterbankRepo) GetRailLimits(ctx context.Context, rail interbank.Rail) (*intrabank.Limits, error) {
+	return repo.transferMethodLimits(ctx, rail.TransactionType())
+}
+
+// HasTransferredToBank only counts the user's completed interbank transfers.
+func (repo *InterbankRepo) HasTransferredToBank(ctx context.Context, userID, bankCode, destination string) (bool, error) {
+	var count int64
+	res := repo.db.WithContext(ctx).
+		Model(new(model.Transaction)).
+		Where(`"USER_ID" = ? AND "TRANSACTION_TYPE" IN ?`, userID, interbank.TransactionTypes()).
+		Where(`"BANK_CODE" = ? AND "DESTINATION" = ?`, bankCode, destination).
+		Where(`"STATUS" = ?`, intrabank.TransactionSuccess).
+		Limit(1).
+		Count(&count)
+	if err := res.Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (repo *InterbankRepo) GetPendingTransactions(ctx context.Context, before time.Time, limit int) ([]*intrabank.Transaction, error) {
+	return repo.pendingTransactionsOfType(ctx, interbank.TransactionTypes(), before, limit)
+}
+
+func (repo *InterbankRepo) CountTransfersOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (int, error) {
+	return repo.countTransfersOfType(ctx, userID, transactionType, from, to)
+}
+
+func (repo *InterbankRepo) SumTransferAmountOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (intrabank.Money, error) {
+	return repo.sumTransferAmountOfType(ctx, userID, transactionType, from, to)
+}
+
+// bankFromModel maps the bank row, the RAILS column is a comma-separated list of rails.
+func bankFromModel(m *model.Bank) *interbank.Bank {
+	bank := &interbank.Bank{
+		Code: m.BankCode,
+		Name: m.Name,
+	}
+	for _, rail := range strings.Split(m.Rails, ",") {
+		if rail = strings.TrimSpace(rail); rail != "" {
+			bank.Rails = append(bank.Rails, interbank.NewRail(strings.ToUpper(rail)))
+		}
+	}
+	return bank
+}
@@ -2,10 +2,15 @@ package repo
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
 	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const intrabankTransactionType = "internal_transfer"
@@ -20,11 +25,23 @@ func NewIntrabankRepo(db *gorm.DB) *IntrabankRepo {
 	}
 }
 
+// enabledMethodStatus are the STATUS values of an enabled transfer method,
+// any other value disables the method.
+var enabledMethodStatus = map[string]bool{
+	"1":      true,
+	"ACTIVE": true,
+}
+
 func (repo *IntrabankRepo) GetTransactionLimit(ctx context.Context) (*intrabank.Limits, error) {
+	return repo.transferMethodLimits(ctx, intrabankTransactionType)
+}
+
+// transferMethodLimits retrieves the fee, limits and operating hours of the transfer method with the type.
+func (repo *IntrabankRepo) transferMethodLimits(ctx context.Context, transactionType string) (*intrabank.Limits, error) {
 	txLimit := new(model.TransactionMethod)
 	res := repo.db.WithContext(ctx).
-		Select(`"TRANSACTION_MIN_LIMIT", "TRANSACTION_LIMIT", "DAILY_LIMIT"`).
-		Where(`"TYPE" = ?`, intrabankTransactionType).
+		Select(`"TRANSACTION_MIN_LIMIT", "TRANSACTION_LIMIT", "DAILY_LIMIT", "FEE", "OPEN_HOUR", "CLOSE_HOUR", "STATUS"`).
+		Where(`"TYPE" = ?`, transactionType).
 		Find(txLimit)
 	if err := res.Error; err != nil {
 		return nil, err
@@ -37,26 +54,674 @@ func (repo *IntrabankRepo) GetTransactionLimit(ctx context.Context) (*intrabank.
 		MinAmount:      minAmount,
 		MaxAmount:      intrabank.Money(txLimit.TransactionLimit),
 		MaxDailyAmount: intrabank.Money(txLimit.DailyLimit),
+		Fee:            intrabank.Money(txLimit.Fee),
+		OpenHour:       txLimit.OpenHour,
+		CloseHour:      txLimit.CloseHour,
+		Disabled:       !enabledMethodStatus[strings.ToUpper(txLimit.Status)],
 	}, nil
 }
 
 func (repo *IntrabankRepo) InsertSequence(ctx context.Context, seq *intrabank.Sequence) error {
-	res := repo.db.WithContext(ctx).Create(seq)
-	return res.Error
+	m := sequenceToModel(seq)
+	res := repo.db.WithContext(ctx).Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	seq.ID = m.ID
+	return nil
 }
 
 func (repo *IntrabankRepo) GetSequence(ctx context.Context, sequenceNumber string) (*intrabank.Sequence, error) {
-	seq := new(intrabank.Sequence)
+	m := new(model.Sequence)
 	res := repo.db.WithContext(ctx).
 		Where(`"SEQ_NO" = ?`, sequenceNumber).
-		First(seq)
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, intrabank.ErrSequenceNotFound
+		}
+		return nil, err
+	}
+	return sequenceFromModel(m), nil
+}
+
+func (repo *IntrabankRepo) DeleteExpiredSequences(ctx context.Context, before time.Time) (int64, error) {
+	res := repo.db.WithContext(ctx).
+		Where(`"STATUS" = ?`, intrabank.SequenceCreated).
+		Where(`"EXPIRES_AT" < ? OR ("EXPIRES_AT" IS NULL AND "CREATED_AT" < ?)`,
+			before, before.Add(-intrabank.DefaultSequenceValidity)).
+		Delete(new(model.Sequence))
+	if err := res.Error; err != nil {
+		return 0, err
+	}
+	return res.RowsAffected, nil
+}
+
+func (repo *IntrabankRepo) GetSequenceByIdempotencyKey(ctx context.Context, userID int, idempotencyKey string) (*intrabank.Sequence, error) {
+	m := new(model.Sequence)
+	res := repo.db.WithContext(ctx).
+		Where(`"USER_ID" = ? AND "IDEMPOTENCY_KEY" = ?`, userID, idempotencyKey).
+		First(m)
 	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, intrabank.ErrSequenceNotFound
+		}
 		return nil, err
 	}
-	return seq, nil
+	return sequenceFromModel(m), nil
+}
+
+func (repo *IntrabankRepo) AcquireSequence(ctx context.Context, sequenceNumber, idempotencyKey string) error {
+	updates := map[string]any{"STATUS": intrabank.SequencePending}
+	if idempotencyKey != "" {
+		updates["IDEMPOTENCY_KEY"] = idempotencyKey
+	}
+	// The status condition makes the update a compare-and-swap,
+	// only one concurrent request can move the sequence out of the created status.
+	res := repo.db.WithContext(ctx).
+		Model(new(model.Sequence)).
+		Where(`"SEQ_NO" = ? AND "STATUS" = ?`, sequenceNumber, intrabank.SequenceCreated).
+		Updates(updates)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return intrabank.ErrSequenceAlreadyProcessed
+		}
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return intrabank.ErrSequenceAlreadyProcessed
+	}
+	return nil
+}
+
+func (repo *IntrabankRepo) UpdateSequenceStatus(ctx context.Context, sequenceNumber, status string) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.Sequence)).
+		Where(`"SEQ_NO" = ?`, sequenceNumber).
+		Update("STATUS", status)
+	return res.Error
 }
 
 func (repo *IntrabankRepo) InsertTransaction(ctx context.Context, transaction *intrabank.Transaction) error {
-	res := repo.db.WithContext(ctx).Create(transaction)
+	m := transactionToModel(transaction)
+	res := repo.db.WithContext(ctx).Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	transaction.ID = m.ID
+	return nil
+}
+
+func (repo *IntrabankRepo) RecordPosting(ctx context.Context, transaction *intrabank.Transaction) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.Transaction)).
+		Where(`"ID" = ? AND "STATUS" = ?`, transaction.ID, intrabank.TransactionPending).
+		Updates(map[string]any{
+			"SEQUENCE_JOURNAL":      transaction.SequenceJournal,
+			"TRANSACTION_REFERENCE": transaction.TransactionReference,
+		})
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return intrabank.ErrTransactionFinished
+	}
+	return nil
+}
+
+func (repo *IntrabankRepo) CompleteTransaction(ctx context.Context, transaction *intrabank.Transaction, outbox []*intrabank.OutboxMessage) error {
+	return repo.finishTransaction(ctx, transaction, intrabank.SequenceCompleted, map[string]any{
+		"STATUS":                   transaction.Status,
+		"SEQUENCE_JOURNAL":         transaction.SequenceJournal,
+		"TRANSACTION_REFERENCE":    transaction.TransactionReference,
+		"SUCCESS_TRANSACTION_DATE": time.Now(),
+	}, outbox)
+}
+
+func (repo *IntrabankRepo) FailTransaction(ctx context.Context, transaction *intrabank.Transaction, outbox []*intrabank.OutboxMessage) error {
+	return repo.finishTransaction(ctx, transaction, intrabank.SequenceFailed, map[string]any{
+		"STATUS":                transaction.Status,
+		"STATUS_CODE":           transaction.StatusCode,
+		"CORE_RESPONSE_PAYLOAD": transaction.CoreResponsePayload,
+	}, outbox)
+}
+
+func (repo *IntrabankRepo) GetFirebaseID(ctx context.Context, userID int) (string, error) {
+	var firebaseID string
+	res := repo.db.WithContext(ctx).
+		Model(new(model.AuthData)).
+		Select(`"FIREBASE_ID"`).
+		Where(`"USER_ID" = ?`, userID).
+		Limit(1).
+		Scan(&firebaseID)
+	if err := res.Error; err != nil {
+		return "", err
+	}
+	return firebaseID, nil
+}
+
+func (repo *IntrabankRepo) GetUser(ctx context.Context, userID int) (*ctxt.User, error) {
+	m := new(model.User)
+	res := repo.db.WithContext(ctx).
+		Where(`"ID" = ?`, userID).
+		First(m)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	return &ctxt.User{
+		ID:    m.ID,
+		CIF:   m.CIF,
+		Name:  m.FullName,
+		Email: m.Email,
+		Phone: m.PhoneNumber,
+	}, nil
+}
+
+// finishTransaction updates the pending transaction and the status of its sequence,
+// and inserts the outbox messages in one database transaction.
+func (repo *IntrabankRepo) finishTransaction(ctx context.Context, transaction *intrabank.Transaction, sequenceStatus string, updates map[string]any, outbox []*intrabank.OutboxMessage) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The status condition lets only one of the payment, the reconciler and the settlement finish the transaction.
+		res := tx.Model(new(model.Transaction)).
+			Where(`"ID" = ? AND "STATUS" = ?`, transaction.ID, intrabank.TransactionPending).
+			Updates(updates)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if res.RowsAffected == 0 {
+			return intrabank.ErrTransactionFinished
+		}
+		res = tx.Model(new(model.Sequence)).
+			Where(`"SEQ_NO" = ?`, transaction.SequenceNumber).
+			Update("STATUS", sequenceStatus)
+		if err := res.Error; err != nil {
+			return err
+		}
+		return insertOutbox(tx, outbox)
+	})
+}
+
+// insertOutbox inserts the outbox messages with the given database handle.
+func insertOutbox(tx *gorm.DB, outbox []*intrabank.OutboxMessage) error {
+	if len(outbox) == 0 {
+		return nil
+	}
+
+	ms := make([]*model.OutboxMessage, 0, len(outbox))
+	for _, message := range outbox {
+		ms = append(ms, outboxToModel(message))
+	}
+	res := tx.Create(&ms)
+	if err := res.Error; err != nil {
+		return err
+	}
+	for i, m := range ms {
+		outbox[i].ID = m.ID
+	}
+	return nil
+}
+
+func (repo *IntrabankRepo) GetPendingTransactions(ctx context.Context, before time.Time, limit int) ([]*intrabank.Transaction, error) {
+	return repo.pendingTransactionsOfType(ctx, []string{intrabankTransactionType}, before, limit)
+}
+
+// pendingTransactionsOfType retrieves the oldest pending transactions of the transaction types
+// created before the given time, limited to limit rows.
+func (repo *IntrabankRepo) pendingTransactionsOfType(ctx context.Context, transactionTypes []string, before time.Time, limit int) ([]*intrabank.Transaction, error) {
+	var ms []*model.Transaction
+	res := repo.db.WithContext(ctx).
+		Where(`"TRANSACTION_TYPE" IN ? AND "STATUS" = ?`, transactionTypes, intrabank.TransactionPending).
+		Where(`"CREATED_AT" < ?`, before).
+		Order(`"ID"`).
+		Limit(limit).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*intrabank.Transaction, 0, len(ms))
+	for _, m := range ms {
+		transactions = append(transactions, transactionFromModel(m))
+	}
+	return transactions, nil
+}
+
+func (repo *IntrabankRepo) InsertRecovery(ctx context.Context, recovery *intrabank.Recovery, outbox []*intrabank.OutboxMessage) error {
+	m := recoveryToModel(recovery)
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Create(m)
+		if err := res.Error; err != nil {
+			return err
+		}
+		return insertOutbox(tx, outbox)
+	})
+	if err != nil {
+		return err
+	}
+	recovery.ID = m.ID
+	recovery.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (repo *IntrabankRepo) GetOpenRecoveries(ctx context.Context, limit int) ([]*intrabank.Recovery, error) {
+	var ms []*model.TransactionRecovery
+	res := repo.db.WithContext(ctx).
+		Where(`"STATUS" = ?`, intrabank.RecoveryOpen).
+		Order(`"ID"`).
+		Limit(limit).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+
+	recoveries := make([]*intrabank.Recovery, 0, len(ms))
+	for _, m := range ms {
+		recoveries = append(recoveries, recoveryFromModel(m))
+	}
+	return recoveries, nil
+}
+
+func (repo *IntrabankRepo) UpdateRecovery(ctx context.Context, recovery *intrabank.Recovery) error {
+	m := recoveryToModel(recovery)
+	res := repo.db.WithContext(ctx).
+		Model(new(model.TransactionRecovery)).
+		Where(`"ID" = ?`, recovery.ID).
+		Updates(map[string]any{
+			"STATUS":      m.Status,
+			"ATTEMPTS":    m.Attempts,
+			"LAST_ERROR":  m.LastError,
+			"RESOLUTION":  m.Resolution,
+			"RESOLVED_AT": m.ResolvedAt,
+		})
+	return res.Error
+}
+
+func (repo *IntrabankRepo) AcquireOutbox(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*intrabank.OutboxMessage, error) {
+	var ms []*model.OutboxMessage
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets concurrent dispatchers acquire different messages instead of waiting.
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(`"STATUS" = ? AND "NEXT_ATTEMPT_AT" <= ?`, intrabank.OutboxPending, now).
+			Order(`"NEXT_ATTEMPT_AT"`).
+			Limit(limit).
+			Find(&ms)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if len(ms) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(ms))
+		for _, m := range ms {
+			ids = append(ids, m.ID)
+		}
+		res = tx.Model(new(model.OutboxMessage)).
+			Where(`"ID" IN ?`, ids).
+			Update("NEXT_ATTEMPT_AT", leaseUntil)
+		return res.Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*intrabank.OutboxMessage, 0, len(ms))
+	for _, m := range ms {
+		messages = append(messages, outboxFromModel(m))
+	}
+	return messages, nil
+}
+
+func (repo *IntrabankRepo) UpdateOutbox(ctx context.Context, message *intrabank.OutboxMessage) error {
+	m := outboxToModel(message)
+	res := repo.db.WithContext(ctx).
+		Model(new(model.OutboxMessage)).
+		Where(`"ID" = ?`, message.ID).
+		Updates(map[string]any{
+			"STATUS":          m.Status,
+			"ATTEMPTS":        m.Attempts,
+			"NEXT_ATTEMPT_AT": m.NextAttemptAt,
+			"LAST_ERROR":      m.LastError,
+			"SENT_AT":         m.SentAt,
+		})
 	return res.Error
 }
+
+func (repo *IntrabankRepo) GetBeneficiaryAccount(ctx context.Context, userID int, id int64) (string, error) {
+	m := new(model.Beneficiary)
+	res := repo.db.WithContext(ctx).
+		Where(`"ID" = ? AND "USER_ID" = ?`, id, userID).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", intrabank.ErrBeneficiaryNotFound
+		}
+		return "", err
+	}
+	return m.AccountNumber, nil
+}
+
+// HasTransferredTo only counts the user's completed intrabank transfers,
+// received, pending and failed transactions of the destination do not make it known.
+func (repo *IntrabankRepo) HasTransferredTo(ctx context.Context, userID string, destination string) (bool, error) {
+	var count int64
+	res := repo.db.WithContext(ctx).
+		Model(new(model.Transaction)).
+		Where(`"USER_ID" = ? AND "TRANSACTION_TYPE" = ? AND "DESTINATION" = ?`, userID, intrabankTransactionType, destination).
+		Where(`"STATUS" = ?`, intrabank.TransactionSuccess).
+		Limit(1).
+		Count(&count)
+	if err := res.Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (repo *IntrabankRepo) CountTransfers(ctx context.Context, userID string, from, to time.Time) (int, error) {
+	return repo.countTransfersOfType(ctx, userID, intrabankTransactionType, from, to)
+}
+
+func (repo *IntrabankRepo) SumTransferAmount(ctx context.Context, userID string, from, to time.Time) (intrabank.Money, error) {
+	return repo.sumTransferAmountOfType(ctx, userID, intrabankTransactionType, from, to)
+}
+
+// countTransfersOfType counts the user's successful and pending transfers of the transaction type
+// created within the [from, to) time range.
+func (repo *IntrabankRepo) countTransfersOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (int, error) {
+	var count int64
+	res := repo.db.WithContext(ctx).
+		Model(new(model.Transaction)).
+		Where(`"USER_ID" = ? AND "TRANSACTION_TYPE" = ?`, userID, transactionType).
+		Where(`"STATUS" IN ?`, []string{intrabank.TransactionSuccess, intrabank.TransactionPending}).
+		Where(`"CREATED_AT" >= ? AND "CREATED_AT" < ?`, from, to).
+		Count(&count)
+	if err := res.Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// sumTransferAmountOfType sums the amount of the user's successful and pending transfers
+// of the transaction type created within the [from, to) time range.
+func (repo *IntrabankRepo) sumTransferAmountOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (intrabank.Money, error) {
+	var total int64
+	res := repo.db.WithContext(ctx).
+		Model(new(model.Transaction)).
+		Select(`COALESCE(SUM("AMOUNT"), 0)`).
+		Where(`"USER_ID" = ? AND "TRANSACTION_TYPE" = ?`, userID, transactionType).
+		Where(`"STATUS" IN ?`, []string{intrabank.TransactionSuccess, intrabank.TransactionPending}).
+		Where(`"CREATED_AT" >= ? AND "CREATED_AT" < ?`, from, to).
+		Scan(&total)
+	if err := res.Error; err != nil {
+		return 0, err
+	}
+	return intrabank.Money(total), nil
+}
+
+func (repo *IntrabankRepo) GetTransactionBySequenceNumber(ctx context.Context, sequenceNumber string) (*intrabank.Transaction, error) {
+	m := new(model.Transaction)
+	res := repo.db.WithContext(ctx).
+		Where(`"SEQ_NO" = ?`, sequenceNumber).
+		First(m)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	return transactionFromModel(m), nil
+}
+
+func (repo *IntrabankRepo) GetTransactions(ctx context.Context, filter *intrabank.TransactionFilter) ([]*intrabank.Transaction, error) {
+	q := repo.db.WithContext(ctx).
+		Where(`"USER_ID" = ?`, filter.UserID)
+	if !filter.From.IsZero() {
+		q = q.Where(`"CREATED_AT" >= ?`, filter.From)
+	}
+	if !filter.To.IsZero() {
+		q = q.Where(`"CREATED_AT" < ?`, filter.To)
+	}
+	if filter.MinAmount > 0 {
+		q = q.Where(`"AMOUNT" >= ?`, int64(filter.MinAmount))
+	}
+	if filter.MaxAmount > 0 {
+		q = q.Where(`"AMOUNT" <= ?`, int64(filter.MaxAmount))
+	}
+	if filter.Status != "" {
+		q = q.Where(`"STATUS" = ?`, filter.Status)
+	}
+	if filter.TransactionType != "" {
+		q = q.Where(`"TRANSACTION_TYPE" = ?`, filter.TransactionType)
+	}
+	if filter.Cursor > 0 {
+		q = q.Where(`"ID" < ?`, filter.Cursor)
+	}
+
+	var ms []*model.Transaction
+	res := q.Order(`"ID" DESC`).
+		Limit(filter.Limit).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*intrabank.Transaction, 0, len(ms))
+	for _, m := range ms {
+		transactions = append(transactions, transactionFromModel(m))
+	}
+	return transactions, nil
+}
+
+func (repo *IntrabankRepo) GetTransactionByReference(ctx context.Context, userID, transactionReference string) (*intrabank.Transaction, error) {
+	m := new(model.Transaction)
+	res := repo.db.WithContext(ctx).
+		Where(`"USER_ID" = ? AND "TRANSACTION_REFERENCE" = ?`, userID, transactionReference).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, intrabank.ErrTransactionNotFound
+		}
+		return nil, err
+	}
+	return transactionFromModel(m), nil
+}
+
+func sequenceToModel(seq *intrabank.Sequence) *model.Sequence {
+	m := &model.Sequence{
+		ID:                 seq.ID,
+		SequenceNumber:     seq.SequenceNumber,
+		Amount:             int64(seq.Amount),
+		SourceAccount:      seq.SourceAccount,
+		DestinationAccount: seq.DestinationAccount,
+		SourceName:         seq.SourceName,
+		DestinationName:    seq.DestinationName,
+		TransactionType:    seq.TransactionType,
+		Status:             seq.Status,
+		UserID:             seq.UserID,
+		DeviceID:           seq.DeviceID,
+		Channel:            seq.Channel,
+		Fee:                int64(seq.Fee),
+		Note:               seq.Note,
+		BankCode:           seq.BankCode,
+		Rail:               seq.Rail,
+		CreatedAt:          seq.CreatedAt,
+	}
+	if seq.IdempotencyKey != "" {
+		m.IdempotencyKey = &seq.IdempotencyKey
+	}
+	if !seq.ExpiresAt.IsZero() {
+		m.ExpiresAt = &seq.ExpiresAt
+	}
+	return m
+}
+
+func sequenceFromModel(m *model.Sequence) *intrabank.Sequence {
+	seq := &intrabank.Sequence{
+		ID:                 m.ID,
+		SequenceNumber:     m.SequenceNumber,
+		Amount:             intrabank.Money(m.Amount),
+		SourceAccount:      m.SourceAccount,
+		DestinationAccount: m.DestinationAccount,
+		SourceName:         m.SourceName,
+		DestinationName:    m.DestinationName,
+		TransactionType:    m.TransactionType,
+		Status:             m.Status,
+		UserID:             m.UserID,
+		DeviceID:           m.DeviceID,
+		Channel:            m.Channel,
+		Fee:                intrabank.Money(m.Fee),
+		Note:               m.Note,
+		BankCode:           m.BankCode,
+		Rail:               m.Rail,
+		CreatedAt:          m.CreatedAt,
+	}
+	if m.IdempotencyKey != nil {
+		seq.IdempotencyKey = *m.IdempotencyKey
+	}
+	// Sequences created before the expiry was stored are valid for the default window.
+	seq.ExpiresAt = m.CreatedAt.Add(intrabank.DefaultSequenceValidity)
+	if m.ExpiresAt != nil {
+		seq.ExpiresAt = *m.ExpiresAt
+	}
+	return seq
+}
+
+func transactionToModel(transaction *intrabank.Transaction) *model.Transaction {
+	m := &model.Transaction{
+		ID:                      transaction.ID,
+		UUID:                    transaction.UUID,
+		UserID:                  transaction.UserID,
+		WalletIDSource:          transaction.WalletIDSource,
+		Destination:             transaction.Destination,
+		Amount:                  int64(transaction.Amount),
+		TransactionType:         transaction.TransactionType,
+		TransactionReference:    transaction.TransactionReference,
+		SequenceJournal:         transaction.SequenceJournal,
+		Remarks:                 transaction.Remarks,
+		Note:                    transaction.Note,
+		CoreRequestPayload:      transaction.CoreRequestPayload,
+		CoreResponsePayload:     transaction.CoreResponsePayload,
+		EChannelRequestPayload:  transaction.EChannelRequestPayload,
+		EChannelResponsePayload: transaction.EChannelResponsePayload,
+		Status:                  transaction.Status,
+		CreatedAt:               transaction.CreatedAt,
+		Fee:                     transaction.Fee,
+		DestinationName:         transaction.DestinationName,
+		InitialSourceBalance:    transaction.InitialSourceBalance,
+		StatusCode:              transaction.StatusCode,
+		SequenceNumber:          transaction.SequenceNumber,
+		BankCode:                transaction.BankCode,
+		SuccessTransactionDate:  transaction.SuccessTransactionDate,
+	}
+	if transaction.StandingOrderID != 0 {
+		m.StandingOrderID = &transaction.StandingOrderID
+	}
+	return m
+}
+
+func transactionFromModel(m *model.Transaction) *intrabank.Transaction {
+	transaction := &intrabank.Transaction{
+		ID:                      m.ID,
+		UUID:                    m.UUID,
+		UserID:                  m.UserID,
+		WalletIDSource:          m.WalletIDSource,
+		Destination:             m.Destination,
+		Amount:                  intrabank.Money(m.Amount),
+		TransactionType:         m.TransactionType,
+		TransactionReference:    m.TransactionReference,
+		SequenceJournal:         m.SequenceJournal,
+		Remarks:                 m.Remarks,
+		Note:                    m.Note,
+		CoreRequestPayload:      m.CoreRequestPayload,
+		CoreResponsePayload:     m.CoreResponsePayload,
+		EChannelRequestPayload:  m.EChannelRequestPayload,
+		EChannelResponsePayload: m.EChannelResponsePayload,
+		Status:                  m.Status,
+		CreatedAt:               m.CreatedAt,
+		Fee:                     m.Fee,
+		DestinationName:         m.DestinationName,
+		InitialSourceBalance:    m.InitialSourceBalance,
+		StatusCode:              m.StatusCode,
+		SequenceNumber:          m.SequenceNumber,
+		BankCode:                m.BankCode,
+		SuccessTransactionDate:  m.SuccessTransactionDate,
+	}
+	if m.StandingOrderID != nil {
+		transaction.StandingOrderID = *m.StandingOrderID
+	}
+	return transaction
+}
+
+func recoveryToModel(recovery *intrabank.Recovery) *model.TransactionRecovery {
+	m := &model.TransactionRecovery{
+		ID:                   recovery.ID,
+		TransactionID:        recovery.TransactionID,
+		SequenceNumber:       recovery.SequenceNumber,
+		JournalSequence:      recovery.JournalSequence,
+		TransactionReference: recovery.TransactionReference,
+		Status:               recovery.Status,
+		Attempts:             recovery.Attempts,
+		LastError:            recovery.LastError,
+		Resolution:           recovery.Resolution,
+		CreatedAt:            recovery.CreatedAt,
+	}
+	if !recovery.ResolvedAt.IsZero() {
+		m.ResolvedAt = &recovery.ResolvedAt
+	}
+	return m
+}
+
+func recoveryFromModel(m *model.TransactionRecovery) *intrabank.Recovery {
+	recovery := &intrabank.Recovery{
+		ID:                   m.ID,
+		TransactionID:        m.TransactionID,
+		SequenceNumber:       m.SequenceNumber,
+		JournalSequence:      m.JournalSequence,
+		TransactionReference: m.TransactionReference,
+		Status:               m.Status,
+		Attempts:             m.Attempts,
+		LastError:            m.LastError,
+		Resolution:           m.Resolution,
+		CreatedAt:            m.CreatedAt,
+	}
+	if m.ResolvedAt != nil {
+		recovery.ResolvedAt = *m.ResolvedAt
+	}
+	return recovery
+}
+
+func outboxToModel(message *intrabank.OutboxMessage) *model.OutboxMessage {
+	m := &model.OutboxMessage{
+		ID:            message.ID,
+		TransactionID: message.TransactionID,
+		Kind:          message.Kind,
+		Payload:       message.Payload,
+		Status:        message.Status,
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		CreatedAt:     message.CreatedAt,
+	}
+	if m.NextAttemptAt.IsZero() {
+		m.NextAttemptAt = time.Now()
+	}
+	if !message.SentAt.IsZero() {
+		m.SentAt = &message.SentAt
+	}
+	return m
+}
+
+func outboxFromModel(m *model.OutboxMessage) *intrabank.OutboxMessage {
+	message := &intrabank.OutboxMessage{
+		ID:            m.ID,
+		TransactionID: m.TransactionID,
+		Kind:          m.Kind,
+		Payload:       m.Payload,
+		Status:        m.Status,
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.SentAt != nil {
+		message.SentAt = *m.SentAt
+	}
+	return message
+}
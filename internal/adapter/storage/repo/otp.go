@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"errors"
 
 	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
 	"go.bankyaya.org/app/backend/internal/domain/otp"
@@ -25,6 +26,7 @@ func (o *OTPRepo) Save(ctx context.Context, otp *otp.OTP) error {
 		UserID:     otp.User.ID,
 		Purpose:    otp.Purpose.String(),
 		Channel:    otp.Channel.String(),
+		Reference:  otp.Reference,
 		VerifiedAt: otp.VerifiedAt,
 		ExpiredAt:  otp.ExpiredAt,
 	}
@@ -39,15 +41,20 @@ func (o *OTPRepo) Get(ctx context.Context, id int) (*otp.OTP, error) {
 	res := o.db.WithContext(ctx).
 		Preload("User").
 		Where("id = ?", id).
-		Find(m)
+		First(m)
 	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, otp.ErrOTPNotFound
+		}
 		return nil, err
 	}
 	return &otp.OTP{
-		ID:      m.ID,
-		Code:    m.Code,
-		Purpose: otp.NewPurpose(m.Purpose),
-		Channel: otp.NewChannel(m.Channel),
+		ID:        m.ID,
+		Code:      m.Code,
+		Purpose:   otp.NewPurpose(m.Purpose),
+		Channel:   otp.NewChannel(m.Channel),
+		Reference: m.Reference,
+		Attempts:  m.Attempts,
 		User: &otp.User{
 			ID:    m.User.ID,
 			Name:  m.User.FullName,
@@ -60,7 +67,27 @@ func (o *OTPRepo) Get(ctx context.Context, id int) (*otp.OTP, error) {
 	}, nil
 }
 
+func (o *OTPRepo) AddAttempt(ctx context.Context, id, maxAttempts int) error {
+	// The attempts condition makes the update a compare-and-swap,
+	// concurrent attempts can never go over the limit.
+	res := o.db.WithContext(ctx).
+		Model(new(model.OTP)).
+		Where("id = ? AND attempts < ?", id, maxAttempts).
+		UpdateColumn("attempts", gorm.Expr("attempts + 1"))
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return otp.ErrOTPAttemptsExceeded
+	}
+	return nil
+}
+
 func (o *OTPRepo) Update(ctx context.Context, otp *otp.OTP) error {
-	res := o.db.WithContext(ctx).Updates(otp)
+	// Only the verification is updated, the attempts are counted by AddAttempt alone.
+	res := o.db.WithContext(ctx).
+		Model(new(model.OTP)).
+		Where("id = ?", otp.ID).
+		Update("verified_at", otp.VerifiedAt)
 	return res.Error
 }
@@ -0,0 +1,217 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/paymentrequest"
+	"gorm.io/gorm"
+)
+
+type PaymentRequestRepo struct {
+	db *gorm.DB
+}
+
+func NewPaymentRequestRepo(db *gorm.DB) *PaymentRequestRepo {
+	return &PaymentRequestRepo{
+		db: db,
+	}
+}
+
+func (repo *PaymentRequestRepo) GetUser(ctx context.Context, id int) (*paymentrequest.User, error) {
+	m := new(model.User)
+	res := repo.db.WithContext(ctx).
+		Preload("AuthData").
+		Where(`"ID" = ?`, id).
+		First(m)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	return paymentRequestUserFromModel(m), nil
+}
+
+func (repo *PaymentRequestRepo) GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (*paymentrequest.User, error) {
+	m := new(model.User)
+	res := repo.db.WithContext(ctx).
+		Preload("AuthData").
+		Where(`"PHONE_NUMBER" = ?`, phoneNumber).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, paymentrequest.ErrPayerNotFound
+		}
+		return nil, err
+	}
+	return paymentRequestUserFromModel(m), nil
+}
+
+func (repo *PaymentRequestRepo) Insert(ctx context.Context, req *paymentrequest.PaymentRequest) error {
+	m := paymentRequestToModel(req)
+	res := repo.db.WithContext(ctx).Omit("Requester", "Payer").Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	req.ID = m.ID
+	req.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (repo *PaymentRequestRepo) Get(ctx context.Context, userID int, id int64) (*paymentrequest.PaymentRequest, error) {
+	m := new(model.PaymentRequest)
+	res := repo.preload(ctx).
+		Where(`"ID" = ? AND ("REQUESTER_ID" = ? OR "PAYER_ID" = ?)`, id, userID, userID).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, paymentrequest.ErrRequestNotFound
+		}
+		return nil, err
+	}
+	return paymentRequestFromModel(m), nil
+}
+
+func (repo *PaymentRequestRepo) ListSent(ctx context.Context, requesterID int) ([]*paymentrequest.PaymentRequest, error) {
+	return repo.list(ctx, `"REQUESTER_ID" = ?`, requesterID)
+}
+
+func (repo *PaymentRequestRepo) ListReceived(ctx context.Context, payerID int) ([]*paymentrequest.PaymentRequest, error) {
+	return repo.list(ctx, `"PAYER_ID" = ?`, payerID)
+}
+
+func (repo *PaymentRequestRepo) Decline(ctx context.Context, payerID int, id int64, paymentKey string, now time.Time) error {
+	// A sequence acquired with the payment key means the payer has started paying the request.
+	payment := repo.db.
+		Model(new(model.Sequence)).
+		Select("1").
+		Where(`"USER_ID" = ? AND "IDEMPOTENCY_KEY" = ?`, payerID, paymentKey)
+	res := repo.db.WithContext(ctx).
+		Model(new(model.PaymentRequest)).
+		Where(`"ID" = ? AND "PAYER_ID" = ? AND "STATUS" = ? AND "EXPIRES_AT" > ?`,
+			id, payerID, paymentrequest.StatusPending, now).
+		Where("NOT EXISTS (?)", payment).
+		Updates(map[string]any{
+			"STATUS":       paymentrequest.StatusDeclined,
+			"RESPONDED_AT": now,
+		})
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return paymentrequest.ErrRequestNotPayable
+	}
+	return nil
+}
+
+func (repo *PaymentRequestRepo) MarkPaid(ctx context.Context, req *paymentrequest.PaymentRequest) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.PaymentRequest)).
+		Where(`"ID" = ? AND "STATUS" IN ?`, req.ID, []string{paymentrequest.StatusPending, paymentrequest.StatusExpired}).
+		Updates(map[string]any{
+			"STATUS":                req.Status,
+			"TRANSACTION_REFERENCE": req.TransactionReference,
+			"RESPONDED_AT":          req.RespondedAt,
+		})
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return paymentrequest.ErrRequestNotPayable
+	}
+	return nil
+}
+
+func (repo *PaymentRequestRepo) ExpireDue(ctx context.Context, now time.Time) (int64, error) {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.PaymentRequest)).
+		Where(`"STATUS" = ? AND "EXPIRES_AT" <= ?`, paymentrequest.StatusPending, now).
+		Update("STATUS", paymentrequest.StatusExpired)
+	if err := res.Error; err != nil {
+		return 0, err
+	}
+	return res.RowsAffected, nil
+}
+
+func (repo *PaymentRequestRepo) preload(ctx context.Context) *gorm.DB {
+	return repo.db.WithContext(ctx).
+		Preload("Requester").
+		Preload("Requester.AuthData").
+		Preload("Payer").
+		Preload("Payer.AuthData")
+}
+
+func (repo *PaymentRequestRepo) list(ctx context.Context, query string, userID int) ([]*paymentrequest.PaymentRequest, error) {
+	var ms []*model.PaymentRequest
+	res := repo.preload(ctx).
+		Where(query, userID).
+		Order(`"CREATED_AT" DESC`).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	requests := make([]*paymentrequest.PaymentRequest, 0, len(ms))
+	for _, m := range ms {
+		requests = append(requests, paymentRequestFromModel(m))
+	}
+	return requests, nil
+}
+
+func paymentRequestToModel(req *paymentrequest.PaymentRequest) *model.PaymentRequest {
+	m := &model.PaymentRequest{
+		ID:                   req.ID,
+		DestinationAccount:   req.DestinationAccount,
+		Amount:               int64(req.Amount),
+		Note:                 req.Note,
+		Status:               req.Status,
+		ExpiresAt:            req.ExpiresAt,
+		TransactionReference: req.TransactionReference,
+	}
+	if req.Requester != nil {
+		m.RequesterID = req.Requester.ID
+	}
+	if req.Payer != nil {
+		m.PayerID = req.Payer.ID
+	}
+	if !req.RespondedAt.IsZero() {
+		m.RespondedAt = &req.RespondedAt
+	}
+	return m
+}
+
+func paymentRequestFromModel(m *model.PaymentRequest) *paymentrequest.PaymentRequest {
+	req := &paymentrequest.PaymentRequest{
+		ID:                   m.ID,
+		Requester:            &paymentrequest.User{ID: m.RequesterID},
+		Payer:                &paymentrequest.User{ID: m.PayerID},
+		DestinationAccount:   m.DestinationAccount,
+		Amount:               intrabank.Money(m.Amount),
+		Note:                 m.Note,
+		Status:               m.Status,
+		ExpiresAt:            m.ExpiresAt,
+		TransactionReference: m.TransactionReference,
+		CreatedAt:            m.CreatedAt,
+	}
+	if m.Requester != nil {
+		req.Requester = paymentRequestUserFromModel(m.Requester)
+	}
+	if m.Payer != nil {
+		req.Payer = paymentRequestUserFromModel(m.Payer)
+	}
+	if m.RespondedAt != nil {
+		req.RespondedAt = *m.RespondedAt
+	}
+	return req
+}
+
+func paymentRequestUserFromModel(m *model.User) *paymentrequest.User {
+	return &paymentrequest.User{
+		ID:            m.ID,
+		CIF:           m.CIF,
+		Name:          m.FullName,
+		Email:         m.Email,
+		AccountNumber: m.AccountNumber,
+		FirebaseID:    m.AuthData.FirebaseID,
+	}
+}
@@ -0,0 +1,282 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+	"gorm.io/gorm"
+)
+
+// QRISRepo stores the QRIS payments next to the sequence and transaction tables of the intrabank transfers.
+type QRISRepo struct {
+	*IntrabankRepo
+}
+
+func NewQRISRepo(db *gorm.DB) *QRISRepo {
+	return &QRISRepo{
+		IntrabankRepo: NewIntrabankRepo(db),
+	}
+}
+
+func (repo *QRISRepo) GetLimits(ctx context.Context) (*intrabank.Limits, error) {
+	return repo.transferMethodLimits(ctx, qris.TransactionType)
+}
+
+func (repo *QRISRepo) InsertPayment(ctx context.Context, payment *qris.Payment) error {
+	m := qrisPaymentToModel(payment)
+	res := repo.db.WithContext(ctx).Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	payment.ID = m.ID
+	return nil
+}
+
+func (repo *QRISRepo) GetPayment(ctx context.Context, sequenceNumber string) (*qris.Payment, error) {
+	m := new(model.QRISPayment)
+	res := repo.db.WithContext(ctx).
+		Where(`"SEQ_NO" = ?`, sequenceNumber).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, qris.ErrPaymentNotFound
+		}
+		return nil, err
+	}
+	return qrisPaymentFromModel(m), nil
+}
+
+func (repo *QRISRepo) InsertCode(ctx context.Context, code *qris.Code) error {
+	m := qrisCodeToModel(code)
+	res := repo.db.WithContext(ctx).Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	code.ID = m.ID
+	code.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (repo *QRISRepo) GetCode(ctx context.Context, id int64) (*qris.Code, error) {
+	m := new(model.QRISCode)
+	res := repo.db.WithContext(ctx).
+		Where(`"ID" = ?`, id).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, qris.ErrCodeNotFound
+		}
+		return nil, err
+	}
+	return qrisCodeFromModel(m), nil
+}
+
+// ListCodeTransfers joins the transactions with the QRIS payments of their sequences that paid the code.
+func (repo *QRISRepo) ListCodeTransfers(ctx context.Context, codeID int64) ([]*intrabank.Transaction, error) {
+	var ms []*model.Transaction
+	res := repo.db.WithContext(ctx).
+		Joins(`JOIN "_qris_payments" ON "_qris_payments"."SEQ_NO" = "_transactions"."SEQ_NO"`).
+		Where(`"_qris_payments"."CODE_ID" = ?`, codeID).
+		Where(`"_transactions"."STATUS" = ?`, intrabank.TransactionSuccess).
+		Order(`"_transactions"."ID" DESC`).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	transactions := make([]*intrabank.Transaction, 0, len(ms))
+	for _, m := range ms {
+		transactions = append(transactions, transactionFromModel(m))
+	}
+	return transactions, nil
+}
+
+func (repo *QRISRepo) GetPendingTransactions(ctx context.Context, before time.Time, limit int) ([]*intrabank.Transaction, error) {
+	return repo.pendingTransactionsOfType(ctx, []string{qris.TransactionType}, before, limit)
+}
+
+func (repo *QRISRepo) InsertCredit(ctx context.Context, credit *qris.InboundCredit) error {
+	m := qrisCodeCreditToModel(credit)
+	res := repo.db.WithContext(ctx).Create(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return qris.ErrCreditExists
+		}
+		return err
+	}
+	credit.ID = m.ID
+	credit.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (repo *QRISRepo) GetCredit(ctx context.Context, networkReference string) (*qris.InboundCredit, error) {
+	m := new(model.QRISCodeCredit)
+	res := repo.db.WithContext(ctx).
+		Where(`"NETWORK_REFERENCE" = ?`, networkReference).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, qris.ErrCreditNotFound
+		}
+		return nil, err
+	}
+	return qrisCodeCreditFromModel(m), nil
+}
+
+func (repo *QRISRepo) UpdateCreditPosting(ctx context.Context, credit *qris.InboundCredit) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.QRISCodeCredit)).
+		Where(`"ID" = ?`, credit.ID).
+		Updates(map[string]any{
+			"JOURNAL_SEQUENCE":      credit.JournalSequence,
+			"TRANSACTION_REFERENCE": credit.TransactionReference,
+		})
+	return res.Error
+}
+
+func (repo *QRISRepo) ListCodeCredits(ctx context.Context, codeID int64) ([]*qris.InboundCredit, error) {
+	var ms []*model.QRISCodeCredit
+	res := repo.db.WithContext(ctx).
+		Where(`"CODE_ID" = ? AND "JOURNAL_SEQUENCE" <> ''`, codeID).
+		Order(`"ID" DESC`).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	credits := make([]*qris.InboundCredit, 0, len(ms))
+	for _, m := range ms {
+		credits = append(credits, qrisCodeCreditFromModel(m))
+	}
+	return credits, nil
+}
+
+func (repo *QRISRepo) CountTransfersOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (int, error) {
+	return repo.countTransfersOfType(ctx, userID, transactionType, from, to)
+}
+
+func (repo *QRISRepo) SumTransferAmountOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (intrabank.Money, error) {
+	return repo.sumTransferAmountOfType(ctx, userID, transactionType, from, to)
+}
+
+func qrisPaymentToModel(p *qris.Payment) *model.QRISPayment {
+	m := &model.QRISPayment{
+		ID:             p.ID,
+		SequenceNumber: p.SequenceNumber,
+		Payload:        p.Payload,
+		Amount:         int64(p.Amount),
+		Tip:            int64(p.Tip),
+		Fee:            int64(p.Fee),
+		SourceAccount:  p.SourceAccount,
+		ExpiresAt:      p.ExpiresAt,
+	}
+	if p.CodeID != 0 {
+		m.CodeID = &p.CodeID
+	}
+	if p.Merchant != nil {
+		m.GUID = p.Merchant.GUID
+		m.MerchantPAN = p.Merchant.PAN
+		m.MerchantID = p.Merchant.ID
+		m.MerchantNMID = p.Merchant.NMID
+		m.MerchantCriteria = p.Merchant.Criteria
+		m.MerchantName = p.Merchant.Name
+		m.MerchantCity = p.Merchant.City
+		m.PostalCode = p.Merchant.PostalCode
+		m.CategoryCode = p.Merchant.CategoryCode
+	}
+	return m
+}
+
+func qrisPaymentFromModel(m *model.QRISPayment) *qris.Payment {
+	p := &qris.Payment{
+		ID:             m.ID,
+		SequenceNumber: m.SequenceNumber,
+		Payload:        m.Payload,
+		Merchant: &qris.Merchant{
+			GUID:         m.GUID,
+			PAN:          m.MerchantPAN,
+			ID:           m.MerchantID,
+			NMID:         m.MerchantNMID,
+			Criteria:     m.MerchantCriteria,
+			Name:         m.MerchantName,
+			City:         m.MerchantCity,
+			PostalCode:   m.PostalCode,
+			CategoryCode: m.CategoryCode,
+		},
+		Amount:        intrabank.Money(m.Amount),
+		Tip:           intrabank.Money(m.Tip),
+		Fee:           intrabank.Money(m.Fee),
+		SourceAccount: m.SourceAccount,
+		ExpiresAt:     m.ExpiresAt,
+	}
+	if m.CodeID != nil {
+		p.CodeID = *m.CodeID
+	}
+	return p
+}
+
+func qrisCodeToModel(code *qris.Code) *model.QRISCode {
+	m := &model.QRISCode{
+		ID:            code.ID,
+		UserID:        code.UserID,
+		AccountNumber: code.AccountNumber,
+		Name:          code.Name,
+		Amount:        int64(code.Amount),
+		CreatedAt:     code.CreatedAt,
+	}
+	if !code.ExpiresAt.IsZero() {
+		m.ExpiresAt = &code.ExpiresAt
+	}
+	return m
+}
+
+func qrisCodeFromModel(m *model.QRISCode) *qris.Code {
+	code := &qris.Code{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		AccountNumber: m.AccountNumber,
+		Name:          m.Name,
+		Amount:        intrabank.Money(m.Amount),
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.ExpiresAt != nil {
+		code.ExpiresAt = *m.ExpiresAt
+	}
+	return code
+}
+
+func qrisCodeCreditToModel(credit *qris.InboundCredit) *model.QRISCodeCredit {
+	return &model.QRISCodeCredit{
+		ID:                   credit.ID,
+		NetworkReference:     credit.NetworkReference,
+		CodeID:               credit.CodeID,
+		AccountNumber:        credit.AccountNumber,
+		PAN:                  credit.PAN,
+		ReferenceLabel:       credit.ReferenceLabel,
+		Amount:               int64(credit.Amount),
+		PayerName:            credit.PayerName,
+		PayerBank:            credit.PayerBank,
+		JournalSequence:      credit.JournalSequence,
+		TransactionReference: credit.TransactionReference,
+		CreatedAt:            credit.CreatedAt,
+	}
+}
+
+func qrisCodeCreditFromModel(m *model.QRISCodeCredit) *qris.InboundCredit {
+	return &qris.InboundCredit{
+		ID:                   m.ID,
+		NetworkReference:     m.NetworkReference,
+		CodeID:               m.CodeID,
+		AccountNumber:        m.AccountNumber,
+		PAN:                  m.PAN,
+		ReferenceLabel:       m.ReferenceLabel,
+		Amount:               intrabank.Money(m.Amount),
+		PayerName:            m.PayerName,
+		PayerBank:            m.PayerBank,
+		JournalSequence:      m.JournalSequence,
+		TransactionReference: m.TransactionReference,
+		CreatedAt:            m.CreatedAt,
+	}
+}
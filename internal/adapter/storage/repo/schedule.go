@@ -0,0 +1,201 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/schedule"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ScheduleRepo struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepo(db *gorm.DB) *ScheduleRepo {
+	return &ScheduleRepo{
+		db: db,
+	}
+}
+
+func (repo *ScheduleRepo) Insert(ctx context.Context, sch *schedule.Schedule) error {
+	m := scheduleToModel(sch)
+	res := repo.db.WithContext(ctx).Omit("User").Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	sch.ID = m.ID
+	sch.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (repo *ScheduleRepo) Get(ctx context.Context, userID int, id int64) (*schedule.Schedule, error) {
+	m := new(model.ScheduledTransfer)
+	res := repo.db.WithContext(ctx).
+		Where(`"ID" = ? AND "USER_ID" = ?`, id, userID).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, schedule.ErrScheduleNotFound
+		}
+		return nil, err
+	}
+	return scheduleFromModel(m), nil
+}
+
+func (repo *ScheduleRepo) List(ctx context.Context, userID int) ([]*schedule.Schedule, error) {
+	var ms []*model.ScheduledTransfer
+	res := repo.db.WithContext(ctx).
+		Where(`"USER_ID" = ?`, userID).
+		Order(`"EXECUTE_AT" DESC`).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	schedules := make([]*schedule.Schedule, 0, len(ms))
+	for _, m := range ms {
+		schedules = append(schedules, scheduleFromModel(m))
+	}
+	return schedules, nil
+}
+
+func (repo *ScheduleRepo) Cancel(ctx context.Context, userID int, id int64) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.ScheduledTransfer)).
+		Where(`"ID" = ? AND "USER_ID" = ? AND "STATUS" = ?`, id, userID, schedule.StatusScheduled).
+		Update("STATUS", schedule.StatusCancelled)
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return schedule.ErrScheduleNotCancellable
+	}
+	return nil
+}
+
+func (repo *ScheduleRepo) AcquireDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*schedule.Schedule, error) {
+	var ids []int64
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets concurrent workers acquire different schedules instead of waiting.
+		res := tx.Model(new(model.ScheduledTransfer)).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(`("STATUS" = ? AND "EXECUTE_AT" <= ?) OR ("STATUS" = ? AND ("LEASED_UNTIL" IS NULL OR "LEASED_UNTIL" < ?))`,
+				schedule.StatusScheduled, now, schedule.StatusProcessing, now).
+			Order(`"EXECUTE_AT"`).
+			Limit(limit).
+			Pluck(`"ID"`, &ids)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		res = tx.Model(new(model.ScheduledTransfer)).
+			Where(`"ID" IN ?`, ids).
+			Updates(map[string]any{
+				"STATUS":       schedule.StatusProcessing,
+				"LEASED_UNTIL": leaseUntil,
+			})
+		return res.Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var ms []*model.ScheduledTransfer
+	res := repo.db.WithContext(ctx).
+		Preload("User").
+		Preload("User.AuthData").
+		Where(`"ID" IN ?`, ids).
+		Order(`"EXECUTE_AT"`).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	schedules := make([]*schedule.Schedule, 0, len(ms))
+	for _, m := range ms {
+		schedules = append(schedules, scheduleFromModel(m))
+	}
+	return schedules, nil
+}
+
+func (repo *ScheduleRepo) SetSequence(ctx context.Context, id int64, sequenceNumber string) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.ScheduledTransfer)).
+		Where(`"ID" = ?`, id).
+		Update("SEQ_NO", sequenceNumber)
+	return res.Error
+}
+
+func (repo *ScheduleRepo) Finish(ctx context.Context, sch *schedule.Schedule) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.ScheduledTransfer)).
+		Where(`"ID" = ?`, sch.ID).
+		Updates(map[string]any{
+			"STATUS":                sch.Status,
+			"SEQ_NO":                sch.SequenceNumber,
+			"TRANSACTION_REFERENCE": sch.TransactionReference,
+			"FAILURE_REASON":        sch.FailureReason,
+			"EXECUTED_AT":           sch.ExecutedAt,
+		})
+	return res.Error
+}
+
+func scheduleToModel(sch *schedule.Schedule) *model.ScheduledTransfer {
+	m := &model.ScheduledTransfer{
+		ID:                   sch.ID,
+		SourceAccount:        sch.SourceAccount,
+		DestinationAccount:   sch.DestinationAccount,
+		DestinationName:      sch.DestinationName,
+		Amount:               int64(sch.Amount),
+		ExecuteAt:            sch.ExecuteAt,
+		Status:               sch.Status,
+		SequenceNumber:       sch.SequenceNumber,
+		TransactionReference: sch.TransactionReference,
+		FailureReason:        sch.FailureReason,
+	}
+	if sch.User != nil {
+		m.UserID = sch.User.ID
+	}
+	if !sch.ExecutedAt.IsZero() {
+		m.ExecutedAt = &sch.ExecutedAt
+	}
+	return m
+}
+
+func scheduleFromModel(m *model.ScheduledTransfer) *schedule.Schedule {
+	sch := &schedule.Schedule{
+		ID:                   m.ID,
+		User:                 &schedule.User{ID: m.UserID},
+		SourceAccount:        m.SourceAccount,
+		DestinationAccount:   m.DestinationAccount,
+		DestinationName:      m.DestinationName,
+		Amount:               intrabank.Money(m.Amount),
+		ExecuteAt:            m.ExecuteAt,
+		Status:               m.Status,
+		SequenceNumber:       m.SequenceNumber,
+		TransactionReference: m.TransactionReference,
+		FailureReason:        m.FailureReason,
+		CreatedAt:            m.CreatedAt,
+	}
+	if m.User != nil {
+		sch.User = &schedule.User{
+			ID:         m.User.ID,
+			CIF:        m.User.CIF,
+			Name:       m.User.FullName,
+			Email:      m.User.Email,
+			FirebaseID: m.User.AuthData.FirebaseID,
+		}
+	}
+	if m.ExecutedAt != nil {
+		sch.ExecutedAt = *m.ExecutedAt
+	}
+	return sch
+}
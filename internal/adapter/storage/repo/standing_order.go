@@ -0,0 +1,223 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/standingorder"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StandingOrderRepo struct {
+	db *gorm.DB
+}
+
+func NewStandingOrderRepo(db *gorm.DB) *StandingOrderRepo {
+	return &StandingOrderRepo{
+		db: db,
+	}
+}
+
+func (repo *StandingOrderRepo) Insert(ctx context.Context, order *standingorder.StandingOrder) error {
+	m := standingOrderToModel(order)
+	res := repo.db.WithContext(ctx).Omit("User").Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	order.ID = m.ID
+	order.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (repo *StandingOrderRepo) Get(ctx context.Context, userID int, id int64) (*standingorder.StandingOrder, error) {
+	m := new(model.StandingOrder)
+	res := repo.db.WithContext(ctx).
+		Where(`"ID" = ? AND "USER_ID" = ?`, id, userID).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, standingorder.ErrStandingOrderNotFound
+		}
+		return nil, err
+	}
+	return standingOrderFromModel(m), nil
+}
+
+func (repo *StandingOrderRepo) List(ctx context.Context, userID int) ([]*standingorder.StandingOrder, error) {
+	var ms []*model.StandingOrder
+	res := repo.db.WithContext(ctx).
+		Where(`"USER_ID" = ?`, userID).
+		Order(`"ID" DESC`).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	orders := make([]*standingorder.StandingOrder, 0, len(ms))
+	for _, m := range ms {
+		orders = append(orders, standingOrderFromModel(m))
+	}
+	return orders, nil
+}
+
+func (repo *StandingOrderRepo) Cancel(ctx context.Context, userID int, id int64) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.StandingOrder)).
+		Where(`"ID" = ? AND "USER_ID" = ? AND "STATUS" = ?`, id, userID, standingorder.StatusActive).
+		Update("STATUS", standingorder.StatusCancelled)
+	if err := res.Error; err != nil {
+		return err
+	}
+	if res.RowsAffected == 0 {
+		return standingorder.ErrStandingOrderNotCancellable
+	}
+	return nil
+}
+
+func (repo *StandingOrderRepo) AcquireDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*standingorder.StandingOrder, error) {
+	var ids []int64
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets concurrent workers acquire different standing orders instead of waiting.
+		res := tx.Model(new(model.StandingOrder)).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(`("STATUS" = ? AND "NEXT_RUN_AT" <= ?) OR ("STATUS" = ? AND ("LEASED_UNTIL" IS NULL OR "LEASED_UNTIL" < ?))`,
+				standingorder.StatusActive, now, standingorder.StatusProcessing, now).
+			Order(`"NEXT_RUN_AT"`).
+			Limit(limit).
+			Pluck(`"ID"`, &ids)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		res = tx.Model(new(model.StandingOrder)).
+			Where(`"ID" IN ?`, ids).
+			Updates(map[string]any{
+				"STATUS":       standingorder.StatusProcessing,
+				"LEASED_UNTIL": leaseUntil,
+			})
+		return res.Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var ms []*model.StandingOrder
+	res := repo.db.WithContext(ctx).
+		Preload("User").
+		Preload("User.AuthData").
+		Where(`"ID" IN ?`, ids).
+		Order(`"NEXT_RUN_AT"`).
+		Find(&ms)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+	orders := make([]*standingorder.StandingOrder, 0, len(ms))
+	for _, m := range ms {
+		orders = append(orders, standingOrderFromModel(m))
+	}
+	return orders, nil
+}
+
+func (repo *StandingOrderRepo) SetSequence(ctx context.Context, id int64, sequenceNumber string) error {
+	res := repo.db.WithContext(ctx).
+		Model(new(model.StandingOrder)).
+		Where(`"ID" = ?`, id).
+		Update("SEQ_NO", sequenceNumber)
+	return res.Error
+}
+
+func (repo *StandingOrderRepo) Finish(ctx context.Context, order *standingorder.StandingOrder) error {
+	m := standingOrderToModel(order)
+	res := repo.db.WithContext(ctx).
+		Model(new(model.StandingOrder)).
+		Where(`"ID" = ?`, order.ID).
+		Updates(map[string]any{
+			"STATUS":                     m.Status,
+			"RUNS":                       m.Runs,
+			"RETRIES":                    m.Retries,
+			"NEXT_RUN_AT":                m.NextRunAt,
+			"LAST_RUN_AT":                m.LastRunAt,
+			"LAST_TRANSACTION_REFERENCE": m.LastTransactionReference,
+			"LAST_FAILURE_REASON":        m.LastFailureReason,
+			"SEQ_NO":                     m.SequenceNumber,
+		})
+	return res.Error
+}
+
+func standingOrderToModel(order *standingorder.StandingOrder) *model.StandingOrder {
+	m := &model.StandingOrder{
+		ID:                       order.ID,
+		SourceAccount:            order.SourceAccount,
+		DestinationAccount:       order.DestinationAccount,
+		DestinationName:          order.DestinationName,
+		Amount:                   int64(order.Amount),
+		Frequency:                string(order.Frequency),
+		BalancePolicy:            string(order.BalancePolicy),
+		StartAt:                  order.StartAt,
+		MaxRuns:                  order.MaxRuns,
+		Runs:                     order.Runs,
+		Retries:                  order.Retries,
+		NextRunAt:                order.NextRunAt,
+		Status:                   order.Status,
+		LastTransactionReference: order.LastTransactionReference,
+		LastFailureReason:        order.LastFailureReason,
+		SequenceNumber:           order.SequenceNumber,
+	}
+	if order.User != nil {
+		m.UserID = order.User.ID
+	}
+	if !order.EndAt.IsZero() {
+		m.EndAt = &order.EndAt
+	}
+	if !order.LastRunAt.IsZero() {
+		m.LastRunAt = &order.LastRunAt
+	}
+	return m
+}
+
+func standingOrderFromModel(m *model.StandingOrder) *standingorder.StandingOrder {
+	order := &standingorder.StandingOrder{
+		ID:                       m.ID,
+		User:                     &standingorder.User{ID: m.UserID},
+		SourceAccount:            m.SourceAccount,
+		DestinationAccount:       m.DestinationAccount,
+		DestinationName:          m.DestinationName,
+		Amount:                   intrabank.Money(m.Amount),
+		Frequency:                standingorder.Frequency(m.Frequency),
+		BalancePolicy:            standingorder.BalancePolicy(m.BalancePolicy),
+		StartAt:                  m.StartAt,
+		MaxRuns:                  m.MaxRuns,
+		Runs:                     m.Runs,
+		Retries:                  m.Retries,
+		NextRunAt:                m.NextRunAt,
+		Status:                   m.Status,
+		LastTransactionReference: m.LastTransactionReference,
+		LastFailureReason:        m.LastFailureReason,
+		SequenceNumber:           m.SequenceNumber,
+		CreatedAt:                m.CreatedAt,
+	}
+	if m.User != nil {
+		order.User = &standingorder.User{
+			ID:         m.User.ID,
+			CIF:        m.User.CIF,
+			Name:       m.User.FullName,
+			Email:      m.User.Email,
+			FirebaseID: m.User.AuthData.FirebaseID,
+		}
+	}
+	if m.EndAt != nil {
+		order.EndAt = *m.EndAt
+	}
+	if m.LastRunAt != nil {
+		order.LastRunAt = *m.LastRunAt
+	}
+	return order
+}
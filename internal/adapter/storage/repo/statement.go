@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"go.bankyaya.org/app/backend/internal/adapter/storage/model"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/domain/statement"
+	"gorm.io/gorm"
+)
+
+// StatementRepo records the issued statements.
+type StatementRepo struct {
+	db *gorm.DB
+}
+
+func NewStatementRepo(db *gorm.DB) *StatementRepo {
+	return &StatementRepo{
+		db: db,
+	}
+}
+
+func (repo *StatementRepo) InsertStatement(ctx context.Context, st *statement.Statement) error {
+	m := accountStatementToModel(st)
+	res := repo.db.WithContext(ctx).Create(m)
+	if err := res.Error; err != nil {
+		return err
+	}
+	st.ID = m.ID
+	return nil
+}
+
+func (repo *StatementRepo) GetStatementByReference(ctx context.Context, reference string) (*statement.Statement, error) {
+	m := new(model.AccountStatement)
+	res := repo.db.WithContext(ctx).
+		Where(`"REFERENCE" = ?`, reference).
+		First(m)
+	if err := res.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, statement.ErrStatementNotFound
+		}
+		return nil, err
+	}
+	return accountStatementFromModel(m), nil
+}
+
+func accountStatementToModel(s *statement.Statement) *model.AccountStatement {
+	return &model.AccountStatement{
+		ID:             s.ID,
+		Reference:      s.Reference,
+		UserID:         s.UserID,
+		AccountNumber:  s.AccountNumber,
+		AccountName:    s.AccountName,
+		Currency:       s.Currency,
+		PeriodFrom:     s.Period.From,
+		PeriodTo:       s.Period.To,
+		OpeningBalance: int64(s.OpeningBalance),
+		ClosingBalance: int64(s.ClosingBalance),
+		TotalDebit:     int64(s.TotalDebit),
+		TotalCredit:    int64(s.TotalCredit),
+		CreatedAt:      s.CreatedAt,
+	}
+}
+
+func accountStatementFromModel(m *model.AccountStatement) *statement.Statement {
+	return &statement.Statement{
+		ID:             m.ID,
+		Reference:      m.Reference,
+		UserID:         m.UserID,
+		AccountNumber:  m.AccountNumber,
+		AccountName:    m.AccountName,
+		Currency:       m.Currency,
+		Period:         statement.Period{From: m.PeriodFrom, To: m.PeriodTo},
+		OpeningBalance: intrabank.Money(m.OpeningBalance),
+		ClosingBalance: intrabank.Money(m.ClosingBalance),
+		TotalDebit:     intrabank.Money(m.TotalDebit),
+		TotalCredit:    intrabank.Money(m.TotalCredit),
+		CreatedAt:      m.CreatedAt,
+	}
+}
@@ -0,0 +1,34 @@
+package switching
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
+)
+
+// unavailableStatusCode is the response code of a switch that cannot be reached.
+const unavailableStatusCode = "91"
+
+// DisabledGateway is provided when no switching network is configured, so the interbank transfers are unavailable
+// while the rest of the application keeps running. No transfer ever reaches the switching network,
+// the transfers are rejected so their debits are reversed.
+type DisabledGateway struct{}
+
+func NewDisabledGateway() *DisabledGateway {
+	return &DisabledGateway{}
+}
+
+func (g *DisabledGateway) CheckAccount(ctx context.Context, bankCode, accountNumber string) (*interbank.DestinationAccount, error) {
+	return nil, interbank.ErrRailUnavailable
+}
+
+func (g *DisabledGateway) Transfer(ctx context.Context, in *interbank.TransferInput) (*interbank.TransferResult, error) {
+	return nil, &interbank.TransferRejection{
+		StatusCode:  unavailableStatusCode,
+		Description: interbank.ErrRailUnavailable.Error(),
+	}
+}
+
+func (g *DisabledGateway) TransferStatus(ctx context.Context, reference string) (*interbank.TransferResult, error) {
+	return nil, interbank.ErrTransferNotFound
+}
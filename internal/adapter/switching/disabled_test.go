@@ -0,0 +1,28 @@
+package switching
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
+)
+
+func TestDisabledGateway(t *testing.T) {
+	gateway := NewDisabledGateway()
+
+	account, err := gateway.CheckAccount(context.Background(), "014", "1234567890")
+	assert.Nil(t, account)
+	assert.ErrorIs(t, err, interbank.ErrRailUnavailable)
+
+	result, err := gateway.Transfer(context.Background(), &interbank.TransferInput{Reference: "123456"})
+	assert.Nil(t, result)
+	var rejection *interbank.TransferRejection
+	assert.True(t, errors.As(err, &rejection))
+	assert.Equal(t, "91", rejection.StatusCode)
+
+	result, err = gateway.TransferStatus(context.Background(), "123456")
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, interbank.ErrTransferNotFound)
+}
@@ -0,0 +1,88 @@
+// Package switching provides the adapters of the interbank switching network.
+package switching
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
+)
+
+const (
+	// unknownAccountSuffix marks the destination accounts that do not exist at the fake banks.
+	unknownAccountSuffix = "0000"
+	// rejectedAccountSuffix marks the destination accounts whose transfers are rejected by the fake banks.
+	rejectedAccountSuffix = "9999"
+	rejectedStatusCode    = "76"
+)
+
+// FakeGateway stands in for the switching network in tests and local development.
+// Every account exists and accepts transfers, except the accounts ending in 0000,
+// which are not found, and the accounts ending in 9999, whose transfers are rejected.
+// The outcome of every transfer is kept by its reference for the status checks.
+type FakeGateway struct {
+	journal   atomic.Int64
+	transfers sync.Map
+}
+
+func NewFakeGateway() *FakeGateway {
+	return &FakeGateway{}
+}
+
+func (g *FakeGateway) CheckAccount(ctx context.Context, bankCode, accountNumber string) (*interbank.DestinationAccount, error) {
+	if strings.HasSuffix(accountNumber, unknownAccountSuffix) {
+		return nil, interbank.ErrDestinationAccountNotFound
+	}
+	return &interbank.DestinationAccount{
+		BankCode:      bankCode,
+		AccountNumber: accountNumber,
+		Name:          fmt.Sprintf("ACCOUNT %s %s", bankCode, accountNumber),
+	}, nil
+}
+
+func (g *FakeGateway) Transfer(ctx context.Context, in *interbank.TransferInput) (*interbank.TransferResult, error) {
+	result, err := g.transfer(in)
+	g.transfers.Store(in.Reference, fakeOutcome{result: result, err: err})
+	return result, err
+}
+
+func (g *FakeGateway) TransferStatus(ctx context.Context, reference string) (*interbank.TransferResult, error) {
+	outcome, ok := g.transfers.Load(reference)
+	if !ok {
+		return nil, interbank.ErrTransferNotFound
+	}
+	return outcome.(fakeOutcome).result, outcome.(fakeOutcome).err
+}
+
+func (g *FakeGateway) transfer(in *interbank.TransferInput) (*interbank.TransferResult, error) {
+	if strings.HasSuffix(in.DestinationAccount, unknownAccountSuffix) {
+		return nil, &interbank.TransferRejection{
+			StatusCode:  rejectedStatusCode,
+			Description: "invalid account",
+			Payload:     fmt.Sprintf(`{"code":%q,"description":"invalid account"}`, rejectedStatusCode),
+		}
+	}
+	if strings.HasSuffix(in.DestinationAccount, rejectedAccountSuffix) {
+		return nil, &interbank.TransferRejection{
+			StatusCode:  rejectedStatusCode,
+			Description: "transaction rejected by the destination bank",
+			Payload:     fmt.Sprintf(`{"code":%q,"description":"transaction rejected by the destination bank"}`, rejectedStatusCode),
+		}
+	}
+
+	journal := g.journal.Add(1)
+	return &interbank.TransferResult{
+		JournalSequence:      fmt.Sprintf("%06d", journal),
+		TransactionReference: fmt.Sprintf("%s%s%06d", in.Rail, time.Now().Format("20060102150405"), journal),
+	}, nil
+}
+
+// fakeOutcome is the outcome of a transfer kept by the fake gateway.
+type fakeOutcome struct {
+	result *interbank.TransferResult
+	err    error
+}
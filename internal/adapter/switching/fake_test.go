@@ -0,0 +1,72 @@
+package switching
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
+)
+
+func TestFakeGatewayCheckAccount(t *testing.T) {
+	gateway := NewFakeGateway()
+
+	account, err := gateway.CheckAccount(context.Background(), "014", "1234567890")
+	assert.NoError(t, err)
+	assert.Equal(t, &interbank.DestinationAccount{
+		BankCode:      "014",
+		AccountNumber: "1234567890",
+		Name:          "ACCOUNT 014 1234567890",
+	}, account)
+
+	account, err = gateway.CheckAccount(context.Background(), "014", "1234560000")
+	assert.Nil(t, account)
+	assert.ErrorIs(t, err, interbank.ErrDestinationAccountNotFound)
+}
+
+func TestFakeGatewayTransfer(t *testing.T) {
+	gateway := NewFakeGateway()
+
+	result, err := gateway.Transfer(context.Background(), &interbank.TransferInput{
+		Rail:               interbank.RailBIFast,
+		BankCode:           "014",
+		DestinationAccount: "1234567890",
+		Amount:             100000,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "000001", result.JournalSequence)
+	assert.NotEmpty(t, result.TransactionReference)
+
+	result, err = gateway.Transfer(context.Background(), &interbank.TransferInput{
+		Rail:               interbank.RailBIFast,
+		BankCode:           "014",
+		DestinationAccount: "1234569999",
+		Amount:             100000,
+	})
+	assert.Nil(t, result)
+	var rejection *interbank.TransferRejection
+	assert.True(t, errors.As(err, &rejection))
+	assert.Equal(t, "76", rejection.StatusCode)
+}
+
+func TestFakeGatewayTransferStatus(t *testing.T) {
+	gateway := NewFakeGateway()
+
+	transferred, err := gateway.Transfer(context.Background(), &interbank.TransferInput{
+		Rail:               interbank.RailBIFast,
+		BankCode:           "014",
+		DestinationAccount: "1234567890",
+		Amount:             100000,
+		Reference:          "123456",
+	})
+	assert.NoError(t, err)
+
+	result, err := gateway.TransferStatus(context.Background(), "123456")
+	assert.NoError(t, err)
+	assert.Equal(t, transferred, result)
+
+	result, err = gateway.TransferStatus(context.Background(), "654321")
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, interbank.ErrTransferNotFound)
+}
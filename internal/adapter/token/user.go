@@ -23,7 +23,7 @@ func (j *JWT) Create(u *user.User, duration time.Duration) (*user.Token, error)
 	now := time.Now()
 	exp := now.Add(duration)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	claims := jwt.MapClaims{
 		"jti":    uuid.New(),
 		"sub":    u.FullName,
 		"iss":    "api.bankyaya.co.id",
@@ -33,7 +33,14 @@ func (j *JWT) Create(u *user.User, duration time.Duration) (*user.Token, error)
 		"cif":    u.CIF,
 		"userId": u.ID,
 		"email":  u.Email,
-	})
+		"phone":  u.PhoneNumber,
+	}
+	if u.Device != nil {
+		claims["deviceId"] = u.Device.DeviceID
+		claims["client"] = u.Device.Client
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	token.Header["kid"] = j.cfg.Token.HeaderKid
 
 	tokenString, err := token.SignedString([]byte(j.cfg.Token.Secret))
@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/bulktransfer"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+)
+
+// BulkTransfer periodically transfers the rows of the confirmed bulk transfers.
+type BulkTransfer struct {
+	log      *logger.Logger
+	svc      *bulktransfer.Service
+	interval time.Duration
+}
+
+// NewBulkTransferWorker creates a new BulkTransfer worker.
+func NewBulkTransferWorker(cfg *config.Configs, log *logger.Logger, svc *bulktransfer.Service) *BulkTransfer {
+	return &BulkTransfer{
+		log:      log,
+		svc:      svc,
+		interval: intervalOrDefault(cfg.Worker.BulkTransferInterval),
+	}
+}
+
+// Run runs the queued bulk transfers on every tick until the context is done.
+func (w *BulkTransfer) Run(ctx context.Context) {
+	loop(ctx, w.log, "bulk transfer", w.interval, w.svc.RunQueued)
+}
@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+)
+
+// Outbox periodically delivers the receipt emails and push notifications of the transactions.
+type Outbox struct {
+	log      *logger.Logger
+	svc      *intrabank.Service
+	interval time.Duration
+}
+
+// NewOutboxWorker creates a new Outbox worker.
+func NewOutboxWorker(cfg *config.Configs, log *logger.Logger, svc *intrabank.Service) *Outbox {
+	return &Outbox{
+		log:      log,
+		svc:      svc,
+		interval: intervalOrDefault(cfg.Worker.OutboxInterval),
+	}
+}
+
+// Run dispatches the due outbox messages on every tick until the context is done.
+func (w *Outbox) Run(ctx context.Context) {
+	loop(ctx, w.log, "outbox", w.interval, w.svc.DispatchOutbox)
+}
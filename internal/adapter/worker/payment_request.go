@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/paymentrequest"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+)
+
+// PaymentRequestExpiry periodically expires the payment requests that were not answered in time.
+type PaymentRequestExpiry struct {
+	log      *logger.Logger
+	svc      *paymentrequest.Service
+	interval time.Duration
+}
+
+// NewPaymentRequestExpiryWorker creates a new PaymentRequestExpiry worker.
+func NewPaymentRequestExpiryWorker(cfg *config.Configs, log *logger.Logger, svc *paymentrequest.Service) *PaymentRequestExpiry {
+	return &PaymentRequestExpiry{
+		log:      log,
+		svc:      svc,
+		interval: intervalOrDefault(cfg.Worker.PaymentRequestExpiryInterval),
+	}
+}
+
+// Run expires the due payment requests on every tick until the context is done.
+func (w *PaymentRequestExpiry) Run(ctx context.Context) {
+	loop(ctx, w.log, "payment_request_expiry", w.interval, w.svc.ExpireDue)
+}
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+)
+
+// Reconciler periodically completes the transactions whose core posting succeeded
+// while the transaction could not be stored, and stores the receipts of the paid bills
+// that could not be stored with their payment.
+type Reconciler struct {
+	log      *logger.Logger
+	svc      *intrabank.Service
+	bills    *billpayment.Service
+	interval time.Duration
+}
+
+// NewReconcilerWorker creates a new Reconciler worker.
+func NewReconcilerWorker(cfg *config.Configs, log *logger.Logger, svc *intrabank.Service, bills *billpayment.Service) *Reconciler {
+	return &Reconciler{
+		log:      log,
+		svc:      svc,
+		bills:    bills,
+		interval: intervalOrDefault(cfg.Worker.ReconcileInterval),
+	}
+}
+
+// Run reconciles the open recovery entries on every tick until the context is done.
+func (w *Reconciler) Run(ctx context.Context) {
+	loop(ctx, w.log, "reconciler", w.interval, w.reconcile)
+}
+
+// reconcile runs every reconciliation, one failing does not hold back the others.
+func (w *Reconciler) reconcile(ctx context.Context) error {
+	return errors.Join(
+		w.svc.Reconcile(ctx),
+		w.bills.RecoverReceipts(ctx),
+	)
+}
@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/schedule"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+)
+
+// Schedule periodically executes the scheduled transfers that are due.
+type Schedule struct {
+	log      *logger.Logger
+	svc      *schedule.Service
+	interval time.Duration
+}
+
+// NewScheduleWorker creates a new Schedule worker.
+func NewScheduleWorker(cfg *config.Configs, log *logger.Logger, svc *schedule.Service) *Schedule {
+	return &Schedule{
+		log:      log,
+		svc:      svc,
+		interval: intervalOrDefault(cfg.Worker.ScheduleInterval),
+	}
+}
+
+// Run executes the due schedules on every tick until the context is done.
+func (w *Schedule) Run(ctx context.Context) {
+	loop(ctx, w.log, "schedule", w.interval, w.svc.RunDue)
+}
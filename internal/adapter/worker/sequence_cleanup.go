@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+)
+
+// SequenceCleanup periodically deletes the inquiry sequences that expired without being paid.
+type SequenceCleanup struct {
+	log      *logger.Logger
+	svc      *intrabank.Service
+	interval time.Duration
+}
+
+// NewSequenceCleanupWorker creates a new SequenceCleanup worker.
+func NewSequenceCleanupWorker(cfg *config.Configs, log *logger.Logger, svc *intrabank.Service) *SequenceCleanup {
+	return &SequenceCleanup{
+		log:      log,
+		svc:      svc,
+		interval: intervalOrDefault(cfg.Worker.SequenceCleanupInterval),
+	}
+}
+
+// Run purges the expired sequences on every tick until the context is done.
+func (w *SequenceCleanup) Run(ctx context.Context) {
+	loop(ctx, w.log, "sequence cleanup", w.interval, w.svc.PurgeExpiredSequences)
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/domain/ewallet"
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+)
+
+// Settlement periodically settles the partner payments left pending when their outcome was unknown,
+// by checking their status at the core banking system and at the partner.
+type Settlement struct {
+	log       *logger.Logger
+	interbank *interbank.Service
+	qris      *qris.Service
+	bills     *billpayment.Service
+	ewallet   *ewallet.Service
+	interval  time.Duration
+}
+
+// NewSettlementWorker creates a new Settlement worker.
+func NewSettlementWorker(
+	cfg *config.Configs,
+	log *logger.Logger,
+	interbankSvc *interbank.Service,
+	qrisSvc *qris.Service,
+	bills *billpayment.Service,
+	ewalletSvc *ewallet.Service,
+) *Settlement {
+	return &Settlement{
+		log:       log,
+		interbank: interbankSvc,
+		qris:      qrisSvc,
+		bills:     bills,
+		ewallet:   ewalletSvc,
+		interval:  intervalOrDefault(cfg.Worker.SettlementInterval),
+	}
+}
+
+// Run settles the pending payments on every tick until the context is done.
+func (w *Settlement) Run(ctx context.Context) {
+	loop(ctx, w.log, "settlement", w.interval, w.settle)
+}
+
+// settle settles the pending payments of every partner, one failing does not hold back the others.
+func (w *Settlement) settle(ctx context.Context) error {
+	return errors.Join(
+		w.interbank.SettlePending(ctx),
+		w.qris.SettlePending(ctx),
+		w.bills.SettlePending(ctx),
+		w.ewallet.SettlePending(ctx),
+	)
+}
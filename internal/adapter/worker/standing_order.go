@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/standingorder"
+	"go.bankyaya.org/app/backend/internal/pkg/config"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+)
+
+// StandingOrder periodically runs the standing orders that are due.
+type StandingOrder struct {
+	log      *logger.Logger
+	svc      *standingorder.Service
+	interval time.Duration
+}
+
+// NewStandingOrderWorker creates a new StandingOrder worker.
+func NewStandingOrderWorker(cfg *config.Configs, log *logger.Logger, svc *standingorder.Service) *StandingOrder {
+	return &StandingOrder{
+		log:      log,
+		svc:      svc,
+		interval: intervalOrDefault(cfg.Worker.StandingOrderInterval),
+	}
+}
+
+// Run runs the due standing orders on every tick until the context is done.
+func (w *StandingOrder) Run(ctx context.Context) {
+	loop(ctx, w.log, "standing order", w.interval, w.svc.RunDue)
+}
@@ -0,0 +1,39 @@
+// Package worker contains the background workers that run domain use cases periodically.
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+)
+
+// defaultInterval is used when the interval of a worker is not configured.
+const defaultInterval = time.Minute
+
+// intervalOrDefault returns the configured interval, or defaultInterval when it is not set.
+func intervalOrDefault(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return defaultInterval
+	}
+	return interval
+}
+
+// loop calls fn on every tick of the interval until the context is done.
+func loop(ctx context.Context, log *logger.Logger, name string, interval time.Duration, fn func(context.Context) error) {
+	log.Infof("%s worker running every %v", name, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				log.Errorf("%s worker: %v", name, err)
+			}
+		}
+	}
+}
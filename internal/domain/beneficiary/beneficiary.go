@@ -0,0 +1,43 @@
+// Package beneficiary provides structures and functionality for the user's saved destination accounts.
+// A beneficiary is verified against the core banking system before it is saved,
+// so transfers to it can skip retyping the destination account.
+package beneficiary
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxNicknameLength is the maximum number of characters of a nickname.
+const maxNicknameLength = 50
+
+// Beneficiary represents a saved destination account of a user.
+// The AccountName is the verified name from the core banking system.
+type Beneficiary struct {
+	ID            int64
+	UserID        int
+	AccountNumber string
+	AccountName   string
+	Nickname      string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NormalizeNickname trims the nickname.
+func NormalizeNickname(nickname string) string {
+	return strings.TrimSpace(nickname)
+}
+
+// ValidNickname checks if the nickname fits the maximum length. An empty nickname is allowed.
+func ValidNickname(nickname string) bool {
+	return utf8.RuneCountInString(nickname) <= maxNicknameLength
+}
+
+// DisplayName returns the nickname, or the verified account name when there is no nickname.
+func (b *Beneficiary) DisplayName() string {
+	if b.Nickname != "" {
+		return b.Nickname
+	}
+	return b.AccountName
+}
@@ -0,0 +1,28 @@
+package beneficiary
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidNickname(t *testing.T) {
+	assert.True(t, ValidNickname(""))
+	assert.True(t, ValidNickname("Mom"))
+	assert.True(t, ValidNickname(strings.Repeat("a", maxNicknameLength)))
+	assert.False(t, ValidNickname(strings.Repeat("a", maxNicknameLength+1)))
+}
+
+func TestNormalizeNickname(t *testing.T) {
+	assert.Equal(t, "Mom", NormalizeNickname("  Mom "))
+	assert.Equal(t, "", NormalizeNickname("   "))
+}
+
+func TestDisplayName(t *testing.T) {
+	b := &Beneficiary{AccountName: "Taylor Swift"}
+	assert.Equal(t, "Taylor Swift", b.DisplayName())
+
+	b.Nickname = "Tay"
+	assert.Equal(t, "Tay", b.DisplayName())
+}
@@ -0,0 +1,13 @@
+package beneficiary
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// CoreBanking defines the core banking operations used to verify beneficiaries.
+type CoreBanking interface {
+	// GetAccountDetails retrieves account information for the given account number.
+	GetAccountDetails(ctx context.Context, accountNumber string) (*intrabank.Account, error)
+}
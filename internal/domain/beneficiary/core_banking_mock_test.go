@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package beneficiary
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	intrabank "go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// MockCoreBanking is an autogenerated mock type for the CoreBanking type
+type MockCoreBanking struct {
+	mock.Mock
+}
+
+type MockCoreBanking_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCoreBanking) EXPECT() *MockCoreBanking_Expecter {
+	return &MockCoreBanking_Expecter{mock: &_m.Mock}
+}
+
+// GetAccountDetails provides a mock function with given fields: ctx, accountNumber
+func (_m *MockCoreBanking) GetAccountDetails(ctx context.Context, accountNumber string) (*intrabank.Account, error) {
+	ret := _m.Called(ctx, accountNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountDetails")
+	}
+
+	var r0 *intrabank.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*intrabank.Account, error)); ok {
+		return rf(ctx, accountNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *intrabank.Account); ok {
+		r0 = rf(ctx, accountNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_GetAccountDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountDetails'
+type MockCoreBanking_GetAccountDetails_Call struct {
+	*mock.Call
+}
+
+// GetAccountDetails is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+func (_e *MockCoreBanking_Expecter) GetAccountDetails(ctx interface{}, accountNumber interface{}) *MockCoreBanking_GetAccountDetails_Call {
+	return &MockCoreBanking_GetAccountDetails_Call{Call: _e.mock.On("GetAccountDetails", ctx, accountNumber)}
+}
+
+func (_c *MockCoreBanking_GetAccountDetails_Call) Run(run func(ctx context.Context, accountNumber string)) *MockCoreBanking_GetAccountDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_GetAccountDetails_Call) Return(_a0 *intrabank.Account, _a1 error) *MockCoreBanking_GetAccountDetails_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_GetAccountDetails_Call) RunAndReturn(run func(context.Context, string) (*intrabank.Account, error)) *MockCoreBanking_GetAccountDetails_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCoreBanking creates a new instance of MockCoreBanking. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCoreBanking(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCoreBanking {
+	mock := &MockCoreBanking{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
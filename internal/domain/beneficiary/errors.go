@@ -0,0 +1,23 @@
+package beneficiary
+
+import "errors"
+
+var (
+	// ErrGeneral indicates a general error.
+	ErrGeneral = errors.New("something went wrong")
+
+	// ErrUnauthenticatedUser indicates that the user is not authenticated.
+	ErrUnauthenticatedUser = errors.New("unauthenticated user")
+
+	// ErrInvalidNickname is returned when the nickname is too long.
+	ErrInvalidNickname = errors.New("invalid nickname")
+
+	// ErrAccountInactive is returned when the beneficiary account is inactive.
+	ErrAccountInactive = errors.New("account is inactive")
+
+	// ErrBeneficiaryExists is returned when the user has already saved the account.
+	ErrBeneficiaryExists = errors.New("beneficiary already exists")
+
+	// ErrBeneficiaryNotFound is returned when the requested beneficiary cannot be found.
+	ErrBeneficiaryNotFound = errors.New("beneficiary not found")
+)
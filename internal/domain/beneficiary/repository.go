@@ -0,0 +1,26 @@
+package beneficiary
+
+import "context"
+
+// Repository defines methods for managing beneficiary persistence.
+type Repository interface {
+	// Insert inserts a beneficiary into the persistence repository.
+	// Returns ErrBeneficiaryExists if the user has already saved the account.
+	Insert(ctx context.Context, beneficiary *Beneficiary) error
+
+	// Get retrieves the user's beneficiary by its ID.
+	// Returns ErrBeneficiaryNotFound if the user has no beneficiary with the ID.
+	Get(ctx context.Context, userID int, id int64) (*Beneficiary, error)
+
+	// List retrieves all beneficiaries of the user, ordered by the display name.
+	// Returns the beneficiaries and an error if retrieval fails.
+	List(ctx context.Context, userID int) ([]*Beneficiary, error)
+
+	// UpdateNickname updates the nickname of the user's beneficiary.
+	// Returns ErrBeneficiaryNotFound if the user has no beneficiary with the ID.
+	UpdateNickname(ctx context.Context, userID int, id int64, nickname string) error
+
+	// Delete deletes the user's beneficiary.
+	// Returns ErrBeneficiaryNotFound if the user has no beneficiary with the ID.
+	Delete(ctx context.Context, userID int, id int64) error
+}
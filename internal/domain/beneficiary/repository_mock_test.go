@@ -0,0 +1,299 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package beneficiary
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, userID, id
+func (_m *MockRepository) Delete(ctx context.Context, userID int, id int64) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int64
+func (_e *MockRepository_Expecter) Delete(ctx interface{}, userID interface{}, id interface{}) *MockRepository_Delete_Call {
+	return &MockRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, userID, id)}
+}
+
+func (_c *MockRepository_Delete_Call) Run(run func(ctx context.Context, userID int, id int64)) *MockRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Delete_Call) Return(_a0 error) *MockRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Delete_Call) RunAndReturn(run func(context.Context, int, int64) error) *MockRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, userID, id
+func (_m *MockRepository) Get(ctx context.Context, userID int, id int64) (*Beneficiary, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *Beneficiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) (*Beneficiary, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) *Beneficiary); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Beneficiary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int64
+func (_e *MockRepository_Expecter) Get(ctx interface{}, userID interface{}, id interface{}) *MockRepository_Get_Call {
+	return &MockRepository_Get_Call{Call: _e.mock.On("Get", ctx, userID, id)}
+}
+
+func (_c *MockRepository_Get_Call) Run(run func(ctx context.Context, userID int, id int64)) *MockRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Get_Call) Return(_a0 *Beneficiary, _a1 error) *MockRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_Get_Call) RunAndReturn(run func(context.Context, int, int64) (*Beneficiary, error)) *MockRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function with given fields: ctx, beneficiary
+func (_m *MockRepository) Insert(ctx context.Context, beneficiary *Beneficiary) error {
+	ret := _m.Called(ctx, beneficiary)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Beneficiary) error); ok {
+		r0 = rf(ctx, beneficiary)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type MockRepository_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - beneficiary *Beneficiary
+func (_e *MockRepository_Expecter) Insert(ctx interface{}, beneficiary interface{}) *MockRepository_Insert_Call {
+	return &MockRepository_Insert_Call{Call: _e.mock.On("Insert", ctx, beneficiary)}
+}
+
+func (_c *MockRepository_Insert_Call) Run(run func(ctx context.Context, beneficiary *Beneficiary)) *MockRepository_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Beneficiary))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Insert_Call) Return(_a0 error) *MockRepository_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Insert_Call) RunAndReturn(run func(context.Context, *Beneficiary) error) *MockRepository_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) List(ctx context.Context, userID int) ([]*Beneficiary, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*Beneficiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*Beneficiary, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*Beneficiary); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Beneficiary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *MockRepository_Expecter) List(ctx interface{}, userID interface{}) *MockRepository_List_Call {
+	return &MockRepository_List_Call{Call: _e.mock.On("List", ctx, userID)}
+}
+
+func (_c *MockRepository_List_Call) Run(run func(ctx context.Context, userID int)) *MockRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_List_Call) Return(_a0 []*Beneficiary, _a1 error) *MockRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_List_Call) RunAndReturn(run func(context.Context, int) ([]*Beneficiary, error)) *MockRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNickname provides a mock function with given fields: ctx, userID, id, nickname
+func (_m *MockRepository) UpdateNickname(ctx context.Context, userID int, id int64, nickname string) error {
+	ret := _m.Called(ctx, userID, id, nickname)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNickname")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64, string) error); ok {
+		r0 = rf(ctx, userID, id, nickname)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdateNickname_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNickname'
+type MockRepository_UpdateNickname_Call struct {
+	*mock.Call
+}
+
+// UpdateNickname is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int64
+//   - nickname string
+func (_e *MockRepository_Expecter) UpdateNickname(ctx interface{}, userID interface{}, id interface{}, nickname interface{}) *MockRepository_UpdateNickname_Call {
+	return &MockRepository_UpdateNickname_Call{Call: _e.mock.On("UpdateNickname", ctx, userID, id, nickname)}
+}
+
+func (_c *MockRepository_UpdateNickname_Call) Run(run func(ctx context.Context, userID int, id int64, nickname string)) *MockRepository_UpdateNickname_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateNickname_Call) Return(_a0 error) *MockRepository_UpdateNickname_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdateNickname_Call) RunAndReturn(run func(context.Context, int, int64, string) error) *MockRepository_UpdateNickname_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
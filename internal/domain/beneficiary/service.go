@@ -0,0 +1,169 @@
+package beneficiary
+
+import (
+	"context"
+	"errors"
+
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+const domainName = "beneficiary"
+
+// Service handles the user's saved beneficiaries.
+type Service struct {
+	log         *logger.Logger
+	repo        Repository
+	corebanking CoreBanking
+}
+
+// NewService creates a new instance of Service.
+func NewService(log *logger.Logger, repo Repository, corebanking CoreBanking) *Service {
+	return &Service{
+		log:         log,
+		repo:        repo,
+		corebanking: corebanking,
+	}
+}
+
+// Add verifies the account through the core banking system and saves it with the verified name.
+func (s *Service) Add(ctx context.Context, accountNumber, nickname string) (*Beneficiary, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Add").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	nickname = NormalizeNickname(nickname)
+	if !ValidNickname(nickname) {
+		s.log.DomainUsecase(domainName, "Add").Error(ErrInvalidNickname)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidNickname).
+			SetMsg("The nickname is too long.")
+	}
+
+	account, err := s.corebanking.GetAccountDetails(ctx, accountNumber)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Add").Errorf("GetAccountDetails: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !account.IsAccountActive() {
+		s.log.DomainUsecase(domainName, "Add").Errorf("account (%v) not active", accountNumber)
+		return nil, pkgerror.New(codes.BadRequest, ErrAccountInactive).
+			SetMsg("The account is inactive and cannot be saved.")
+	}
+
+	beneficiary := &Beneficiary{
+		UserID:        user.ID,
+		AccountNumber: accountNumber,
+		AccountName:   account.Name,
+		Nickname:      nickname,
+	}
+
+	err = s.repo.Insert(ctx, beneficiary)
+	if errors.Is(err, ErrBeneficiaryExists) {
+		s.log.DomainUsecase(domainName, "Add").Errorf("Insert: %v", err)
+		return nil, pkgerror.New(codes.Conflict, ErrBeneficiaryExists).
+			SetMsg("The account is already in your beneficiary list.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Add").Errorf("Insert: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return beneficiary, nil
+}
+
+// List returns all beneficiaries of the authenticated user.
+func (s *Service) List(ctx context.Context) ([]*Beneficiary, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "List").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	beneficiaries, err := s.repo.List(ctx, user.ID)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "List").Errorf("List: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return beneficiaries, nil
+}
+
+// Get returns the authenticated user's beneficiary.
+func (s *Service) Get(ctx context.Context, id int64) (*Beneficiary, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Get").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	beneficiary, err := s.repo.Get(ctx, user.ID, id)
+	if err != nil {
+		return nil, s.notFoundOrGeneral("Get", "Get", err)
+	}
+
+	return beneficiary, nil
+}
+
+// UpdateNickname changes the nickname of the authenticated user's beneficiary.
+// The account number and the verified name cannot be changed.
+func (s *Service) UpdateNickname(ctx context.Context, id int64, nickname string) (*Beneficiary, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "UpdateNickname").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	nickname = NormalizeNickname(nickname)
+	if !ValidNickname(nickname) {
+		s.log.DomainUsecase(domainName, "UpdateNickname").Error(ErrInvalidNickname)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidNickname).
+			SetMsg("The nickname is too long.")
+	}
+
+	err := s.repo.UpdateNickname(ctx, user.ID, id, nickname)
+	if err != nil {
+		return nil, s.notFoundOrGeneral("UpdateNickname", "UpdateNickname", err)
+	}
+
+	beneficiary, err := s.repo.Get(ctx, user.ID, id)
+	if err != nil {
+		return nil, s.notFoundOrGeneral("UpdateNickname", "Get", err)
+	}
+
+	return beneficiary, nil
+}
+
+// Delete removes the authenticated user's beneficiary.
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Delete").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	err := s.repo.Delete(ctx, user.ID, id)
+	if err != nil {
+		return s.notFoundOrGeneral("Delete", "Delete", err)
+	}
+
+	return nil
+}
+
+// notFoundOrGeneral logs the repository error and converts it into the error returned to the client.
+func (s *Service) notFoundOrGeneral(usecase, step string, err error) error {
+	s.log.DomainUsecase(domainName, usecase).Errorf("%s: %v", step, err)
+	if errors.Is(err, ErrBeneficiaryNotFound) {
+		return pkgerror.New(codes.NotFound, ErrBeneficiaryNotFound).
+			SetMsg("Beneficiary not found.")
+	}
+	return pkgerror.New(codes.Internal, ErrGeneral)
+}
@@ -0,0 +1,301 @@
+package beneficiary
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+func TestAddSuccess(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").Return(&intrabank.Account{
+		AccountNumber: "001001234567892",
+		Name:          "Taylor Swift",
+		Status:        "1",
+	}, nil)
+	repoMock.EXPECT().Insert(mock.Anything, &Beneficiary{
+		UserID:        123,
+		AccountNumber: "001001234567892",
+		AccountName:   "Taylor Swift",
+		Nickname:      "Tay",
+	}).Return(nil)
+
+	b, err := svc.Add(ctx, "001001234567892", "  Tay ")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Taylor Swift", b.AccountName)
+	assert.Equal(t, "Tay", b.Nickname)
+
+	repoMock.AssertExpectations(t)
+	corebankingMock.AssertExpectations(t)
+}
+
+func TestAddFailed_GetUserFromContextFailed(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+	)
+
+	b, err := svc.Add(context.Background(), "001001234567892", "")
+
+	assert.Nil(t, b)
+	assert.Equal(t, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).SetMsg("Please login to continue."), err)
+}
+
+func TestAddFailed_InvalidNickname(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	b, err := svc.Add(ctx, "001001234567892", strings.Repeat("a", maxNicknameLength+1))
+
+	assert.Nil(t, b)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidNickname).SetMsg("The nickname is too long."), err)
+}
+
+func TestAddFailed_GetAccountDetailsFailed(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").Return(nil, errors.New("timeout"))
+
+	b, err := svc.Add(ctx, "001001234567892", "")
+
+	assert.Nil(t, b)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	corebankingMock.AssertExpectations(t)
+}
+
+func TestAddFailed_AccountInactive(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").Return(&intrabank.Account{
+		AccountNumber: "001001234567892",
+		Name:          "Taylor Swift",
+		Status:        "2",
+	}, nil)
+
+	b, err := svc.Add(ctx, "001001234567892", "")
+
+	assert.Nil(t, b)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrAccountInactive).SetMsg("The account is inactive and cannot be saved."), err)
+
+	corebankingMock.AssertExpectations(t)
+}
+
+func TestAddFailed_BeneficiaryExists(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").Return(&intrabank.Account{
+		AccountNumber: "001001234567892",
+		Name:          "Taylor Swift",
+		Status:        "1",
+	}, nil)
+	repoMock.EXPECT().Insert(mock.Anything, mock.Anything).Return(ErrBeneficiaryExists)
+
+	b, err := svc.Add(ctx, "001001234567892", "")
+
+	assert.Nil(t, b)
+	assert.Equal(t, pkgerror.New(codes.Conflict, ErrBeneficiaryExists).SetMsg("The account is already in your beneficiary list."), err)
+
+	repoMock.AssertExpectations(t)
+	corebankingMock.AssertExpectations(t)
+}
+
+func TestListSuccess(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	want := []*Beneficiary{{ID: 1, UserID: 123, AccountNumber: "001001234567892", AccountName: "Taylor Swift"}}
+	repoMock.EXPECT().List(mock.Anything, 123).Return(want, nil)
+
+	got, err := svc.List(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetFailed_BeneficiaryNotFound(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(1)).Return(nil, ErrBeneficiaryNotFound)
+
+	b, err := svc.Get(ctx, 1)
+
+	assert.Nil(t, b)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrBeneficiaryNotFound).SetMsg("Beneficiary not found."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateNicknameSuccess(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	want := &Beneficiary{ID: 1, UserID: 123, AccountNumber: "001001234567892", AccountName: "Taylor Swift", Nickname: "Tay"}
+	repoMock.EXPECT().UpdateNickname(mock.Anything, 123, int64(1), "Tay").Return(nil)
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(1)).Return(want, nil)
+
+	got, err := svc.UpdateNickname(ctx, 1, " Tay")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateNicknameFailed_BeneficiaryNotFound(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().UpdateNickname(mock.Anything, 123, int64(1), "Tay").Return(ErrBeneficiaryNotFound)
+
+	b, err := svc.UpdateNickname(ctx, 1, "Tay")
+
+	assert.Nil(t, b)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrBeneficiaryNotFound).SetMsg("Beneficiary not found."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestDeleteSuccess(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().Delete(mock.Anything, 123, int64(1)).Return(nil)
+
+	err := svc.Delete(ctx, 1)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestDeleteFailed_DeleteFailed(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().Delete(mock.Anything, 123, int64(1)).Return(errors.New("connection refused"))
+
+	err := svc.Delete(ctx, 1)
+
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	repoMock.AssertExpectations(t)
+}
@@ -0,0 +1,24 @@
+package billpayment
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// Biller defines methods of the billers that issue the bills.
+type Biller interface {
+	// Inquiry retrieves the customer and the outstanding bill of the product,
+	// the amount is the denomination bought for a prepaid product and zero otherwise.
+	// Returns ErrCustomerNotFound if the customer is unknown and ErrNoOutstandingBill if nothing is due.
+	Inquiry(ctx context.Context, product *Product, customerID string, amount intrabank.Money) (*Bill, error)
+
+	// Pay delivers the debited payment to the biller and returns its receipt.
+	// It returns a *PaymentRejection when the payment has been rejected.
+	Pay(ctx context.Context, in *BillerPayment) (*BillerReceipt, error)
+
+	// PaymentStatus retrieves the outcome of the payment of the product with the sequence number and its receipt.
+	// It returns a *PaymentRejection when the payment has been rejected
+	// and ErrPaymentNotReceived if the biller has never received it.
+	PaymentStatus(ctx context.Context, product *Product, sequenceNumber string) (*BillerReceipt, error)
+}
@@ -0,0 +1,217 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package billpayment
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	intrabank "go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// MockBiller is an autogenerated mock type for the Biller type
+type MockBiller struct {
+	mock.Mock
+}
+
+type MockBiller_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBiller) EXPECT() *MockBiller_Expecter {
+	return &MockBiller_Expecter{mock: &_m.Mock}
+}
+
+// Inquiry provides a mock function with given fields: ctx, product, customerID, amount
+func (_m *MockBiller) Inquiry(ctx context.Context, product *Product, customerID string, amount intrabank.Money) (*Bill, error) {
+	ret := _m.Called(ctx, product, customerID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Inquiry")
+	}
+
+	var r0 *Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Product, string, intrabank.Money) (*Bill, error)); ok {
+		return rf(ctx, product, customerID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Product, string, intrabank.Money) *Bill); ok {
+		r0 = rf(ctx, product, customerID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Product, string, intrabank.Money) error); ok {
+		r1 = rf(ctx, product, customerID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBiller_Inquiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Inquiry'
+type MockBiller_Inquiry_Call struct {
+	*mock.Call
+}
+
+// Inquiry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - product *Product
+//   - customerID string
+//   - amount intrabank.Money
+func (_e *MockBiller_Expecter) Inquiry(ctx interface{}, product interface{}, customerID interface{}, amount interface{}) *MockBiller_Inquiry_Call {
+	return &MockBiller_Inquiry_Call{Call: _e.mock.On("Inquiry", ctx, product, customerID, amount)}
+}
+
+func (_c *MockBiller_Inquiry_Call) Run(run func(ctx context.Context, product *Product, customerID string, amount intrabank.Money)) *MockBiller_Inquiry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Product), args[2].(string), args[3].(intrabank.Money))
+	})
+	return _c
+}
+
+func (_c *MockBiller_Inquiry_Call) Return(_a0 *Bill, _a1 error) *MockBiller_Inquiry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBiller_Inquiry_Call) RunAndReturn(run func(context.Context, *Product, string, intrabank.Money) (*Bill, error)) *MockBiller_Inquiry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pay provides a mock function with given fields: ctx, in
+func (_m *MockBiller) Pay(ctx context.Context, in *BillerPayment) (*BillerReceipt, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pay")
+	}
+
+	var r0 *BillerReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *BillerPayment) (*BillerReceipt, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *BillerPayment) *BillerReceipt); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BillerReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *BillerPayment) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBiller_Pay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pay'
+type MockBiller_Pay_Call struct {
+	*mock.Call
+}
+
+// Pay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *BillerPayment
+func (_e *MockBiller_Expecter) Pay(ctx interface{}, in interface{}) *MockBiller_Pay_Call {
+	return &MockBiller_Pay_Call{Call: _e.mock.On("Pay", ctx, in)}
+}
+
+func (_c *MockBiller_Pay_Call) Run(run func(ctx context.Context, in *BillerPayment)) *MockBiller_Pay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*BillerPayment))
+	})
+	return _c
+}
+
+func (_c *MockBiller_Pay_Call) Return(_a0 *BillerReceipt, _a1 error) *MockBiller_Pay_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBiller_Pay_Call) RunAndReturn(run func(context.Context, *BillerPayment) (*BillerReceipt, error)) *MockBiller_Pay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PaymentStatus provides a mock function with given fields: ctx, product, sequenceNumber
+func (_m *MockBiller) PaymentStatus(ctx context.Context, product *Product, sequenceNumber string) (*BillerReceipt, error) {
+	ret := _m.Called(ctx, product, sequenceNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PaymentStatus")
+	}
+
+	var r0 *BillerReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Product, string) (*BillerReceipt, error)); ok {
+		return rf(ctx, product, sequenceNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Product, string) *BillerReceipt); ok {
+		r0 = rf(ctx, product, sequenceNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BillerReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Product, string) error); ok {
+		r1 = rf(ctx, product, sequenceNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBiller_PaymentStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PaymentStatus'
+type MockBiller_PaymentStatus_Call struct {
+	*mock.Call
+}
+
+// PaymentStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - product *Product
+//   - sequenceNumber string
+func (_e *MockBiller_Expecter) PaymentStatus(ctx interface{}, product interface{}, sequenceNumber interface{}) *MockBiller_PaymentStatus_Call {
+	return &MockBiller_PaymentStatus_Call{Call: _e.mock.On("PaymentStatus", ctx, product, sequenceNumber)}
+}
+
+func (_c *MockBiller_PaymentStatus_Call) Run(run func(ctx context.Context, product *Product, sequenceNumber string)) *MockBiller_PaymentStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Product), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockBiller_PaymentStatus_Call) Return(_a0 *BillerReceipt, _a1 error) *MockBiller_PaymentStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBiller_PaymentStatus_Call) RunAndReturn(run func(context.Context, *Product, string) (*BillerReceipt, error)) *MockBiller_PaymentStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBiller creates a new instance of MockBiller. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBiller(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBiller {
+	mock := &MockBiller{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,220 @@
+// Package billpayment provides the payments of the electricity, water, BPJS and phone bills.
+// The bill is checked at its biller, the payment is debited to the settlement account of the biller
+// by the core banking system and then delivered to the biller, which returns the receipt data of the bill.
+// The payments reuse the intrabank sequence and transaction model with their own transaction type.
+package billpayment
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// TransactionType is the transaction type of the bill payments,
+// the transfer method with this type holds their fee, limits and operating hours.
+const TransactionType = "bill_payment"
+
+// Category groups the products of the catalog.
+type Category string
+
+const (
+	CategoryElectricity Category = "ELECTRICITY"
+	CategoryWater       Category = "WATER"
+	CategoryBPJS        Category = "BPJS"
+	CategoryPhone       Category = "PHONE"
+)
+
+// Product represents a bill that can be paid, as listed in the biller catalog.
+type Product struct {
+	Code     string
+	Name     string
+	Category Category
+	// Provider is the code of the biller at the core banking system, sent with the debits of its bills.
+	Provider string
+	// SettlementAccount is the account of the biller credited with the payments.
+	SettlementAccount string
+	// Denominations are the amounts a prepaid product is sold in, e.g. the PLN tokens.
+	// A billed product has none, its amount is the outstanding bill.
+	Denominations []intrabank.Money
+}
+
+// Prepaid reports whether the customer buys the product in one of its denominations.
+func (p *Product) Prepaid() bool {
+	return len(p.Denominations) > 0
+}
+
+// Sells reports whether the prepaid product is sold in the amount.
+func (p *Product) Sells(amount intrabank.Money) bool {
+	return slices.Contains(p.Denominations, amount)
+}
+
+// Detail is a biller specific data of a bill or a receipt, e.g. the tariff of a PLN meter or its token number.
+type Detail struct {
+	Label string
+	Value string
+}
+
+// Bill represents the outstanding bill of the customer as returned by the biller.
+type Bill struct {
+	CustomerID   string
+	CustomerName string
+	// Period is the billing period, it is empty for a prepaid product.
+	Period string
+	// Amount is the outstanding bill, or the denomination of a prepaid product.
+	Amount intrabank.Money
+	// AdminFee is charged by the biller on top of the amount.
+	AdminFee intrabank.Money
+	// Reference is the inquiry reference of the biller, it is sent back with the payment.
+	Reference string
+	Details   []Detail
+}
+
+// Payment represents the bill payment of a sequence.
+type Payment struct {
+	ID             int64
+	SequenceNumber string
+	Product        *Product
+	CustomerID     string
+	CustomerName   string
+	Period         string
+	Amount         intrabank.Money
+	AdminFee       intrabank.Money
+	// Fee is the fee of the bank, charged on top of the amount and the admin fee.
+	Fee              intrabank.Money
+	InquiryReference string
+	SourceAccount    string
+	ExpiresAt        time.Time
+	// Details are the biller specific data of the bill shown before it is paid.
+	Details []Detail
+	// BillerReference and Receipt are returned by the biller when the payment is delivered.
+	BillerReference string
+	Receipt         []Detail
+}
+
+// Total returns the amount debited from the source account.
+func (p *Payment) Total() intrabank.Money {
+	return p.Amount + p.AdminFee + p.Fee
+}
+
+// ReceiptNote returns the receipt data of the biller as a single line, e.g. "Token: 1234 5678, kWh: 32.5".
+func (p *Payment) ReceiptNote() string {
+	lines := make([]string, 0, len(p.Receipt))
+	for _, d := range p.Receipt {
+		lines = append(lines, fmt.Sprintf("%s: %s", d.Label, d.Value))
+	}
+	return strings.Join(lines, ", ")
+}
+
+// Receipt represents a paid bill, the transaction with the payment and its receipt data.
+type Receipt struct {
+	Transaction *intrabank.Transaction
+	Payment     *Payment
+}
+
+// Debit represents the core banking posting of a bill payment,
+// from the source account to the settlement account of the biller.
+// The Reference identifies the posting, its reversal refers to it, so the debit can only be reversed once.
+type Debit struct {
+	SourceAccount     string
+	SettlementAccount string
+	Provider          string
+	Amount            intrabank.Money
+	Fee               intrabank.Money
+	Remark            string
+	Reference         string
+}
+
+// BillerPayment represents the debited payment delivered to the biller.
+type BillerPayment struct {
+	Product              *Product
+	CustomerID           string
+	Amount               intrabank.Money
+	AdminFee             intrabank.Money
+	InquiryReference     string
+	SequenceNumber       string
+	TransactionReference string
+}
+
+// BillerReceipt represents the receipt of a payment accepted by the biller.
+type BillerReceipt struct {
+	Reference string
+	Details   []Detail
+}
+
+// PaymentRejection is returned by the biller when the payment has been rejected.
+type PaymentRejection struct {
+	StatusCode  string
+	Description string
+	Payload     string
+}
+
+func (r *PaymentRejection) Error() string {
+	return fmt.Sprintf("bill payment rejected: %s (%s)", r.Description, r.StatusCode)
+}
+
+// maxReceiptRecoveryAttempts is the number of times the reconciler tries to store a receipt
+// before the recovery entry is handed over to a manual review.
+const maxReceiptRecoveryAttempts = 10
+
+// ReceiptRecovery is an entry of a receipt returned by the biller for a paid bill
+// which could not be stored with its bill payment, e.g. the token number of a prepaid electricity bill.
+// The entry is never deleted, its status and resolution are the audit trail of the case.
+type ReceiptRecovery struct {
+	ID              int64
+	SequenceNumber  string
+	BillerReference string
+	Receipt         []Detail
+	Status          string
+	Attempts        int
+	LastError       string
+	Resolution      string
+	CreatedAt       time.Time
+	ResolvedAt      time.Time
+}
+
+// NewReceiptRecovery creates an open recovery entry for the receipt of the payment.
+func NewReceiptRecovery(payment *Payment, err error) *ReceiptRecovery {
+	return &ReceiptRecovery{
+		SequenceNumber:  payment.SequenceNumber,
+		BillerReference: payment.BillerReference,
+		Receipt:         payment.Receipt,
+		Status:          intrabank.RecoveryOpen,
+		LastError:       err.Error(),
+	}
+}
+
+// Payment returns the bill payment with the receipt to be stored from the entry.
+func (r *ReceiptRecovery) Payment() *Payment {
+	return &Payment{
+		SequenceNumber:  r.SequenceNumber,
+		BillerReference: r.BillerReference,
+		Receipt:         r.Receipt,
+	}
+}
+
+// Resolve marks the entry as resolved with the description of how it was resolved.
+func (r *ReceiptRecovery) Resolve(resolution string, at time.Time) {
+	r.Attempts++
+	r.Status = intrabank.RecoveryResolved
+	r.Resolution = resolution
+	r.ResolvedAt = at
+}
+
+// Retry records a failed attempt. The entry is handed over to a manual review
+// once the attempts are exhausted.
+func (r *ReceiptRecovery) Retry(err error) {
+	r.Attempts++
+	r.LastError = err.Error()
+	if r.Attempts >= maxReceiptRecoveryAttempts {
+		r.Status = intrabank.RecoveryManual
+		r.Resolution = "receipt recovery attempts exhausted, manual review required"
+	}
+}
+
+// remark returns the transaction remark of the bill payment of the sequence.
+func remark(seq *intrabank.Sequence, product *Product) string {
+	return fmt.Sprintf("BILL %v %v %v %v", product.Code, seq.SourceAccount, seq.DestinationAccount, seq.SequenceNumber)
+}
@@ -0,0 +1,34 @@
+package billpayment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+func TestProductSells(t *testing.T) {
+	token := &Product{Code: "PLN_PREPAID", Denominations: []intrabank.Money{20000, 50000, 100000}}
+	postpaid := &Product{Code: "PLN_POSTPAID"}
+
+	assert.True(t, token.Prepaid())
+	assert.True(t, token.Sells(50000))
+	assert.False(t, token.Sells(75000))
+	assert.False(t, postpaid.Prepaid())
+}
+
+func TestPaymentTotal(t *testing.T) {
+	payment := &Payment{Amount: 100000, AdminFee: 2500, Fee: 1000}
+
+	assert.Equal(t, intrabank.Money(103500), payment.Total())
+}
+
+func TestPaymentReceiptNote(t *testing.T) {
+	payment := &Payment{Receipt: []Detail{
+		{Label: "Token", Value: "1234 5678 9012 3456 7890"},
+		{Label: "kWh", Value: "66.7"},
+	}}
+
+	assert.Equal(t, "Token: 1234 5678 9012 3456 7890, kWh: 66.7", payment.ReceiptNote())
+	assert.Equal(t, "", (&Payment{}).ReceiptNote())
+}
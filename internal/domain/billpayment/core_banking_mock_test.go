@@ -0,0 +1,331 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package billpayment
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	intrabank "go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// MockCoreBanking is an autogenerated mock type for the CoreBanking type
+type MockCoreBanking struct {
+	mock.Mock
+}
+
+type MockCoreBanking_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCoreBanking) EXPECT() *MockCoreBanking_Expecter {
+	return &MockCoreBanking_Expecter{mock: &_m.Mock}
+}
+
+// DebitBill provides a mock function with given fields: ctx, in
+func (_m *MockCoreBanking) DebitBill(ctx context.Context, in *Debit) (*intrabank.OverbookingResult, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DebitBill")
+	}
+
+	var r0 *intrabank.OverbookingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Debit) (*intrabank.OverbookingResult, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Debit) *intrabank.OverbookingResult); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.OverbookingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Debit) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_DebitBill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DebitBill'
+type MockCoreBanking_DebitBill_Call struct {
+	*mock.Call
+}
+
+// DebitBill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *Debit
+func (_e *MockCoreBanking_Expecter) DebitBill(ctx interface{}, in interface{}) *MockCoreBanking_DebitBill_Call {
+	return &MockCoreBanking_DebitBill_Call{Call: _e.mock.On("DebitBill", ctx, in)}
+}
+
+func (_c *MockCoreBanking_DebitBill_Call) Run(run func(ctx context.Context, in *Debit)) *MockCoreBanking_DebitBill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Debit))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_DebitBill_Call) Return(_a0 *intrabank.OverbookingResult, _a1 error) *MockCoreBanking_DebitBill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_DebitBill_Call) RunAndReturn(run func(context.Context, *Debit) (*intrabank.OverbookingResult, error)) *MockCoreBanking_DebitBill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountDetails provides a mock function with given fields: ctx, accountNumber
+func (_m *MockCoreBanking) GetAccountDetails(ctx context.Context, accountNumber string) (*intrabank.Account, error) {
+	ret := _m.Called(ctx, accountNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountDetails")
+	}
+
+	var r0 *intrabank.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*intrabank.Account, error)); ok {
+		return rf(ctx, accountNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *intrabank.Account); ok {
+		r0 = rf(ctx, accountNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_GetAccountDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountDetails'
+type MockCoreBanking_GetAccountDetails_Call struct {
+	*mock.Call
+}
+
+// GetAccountDetails is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+func (_e *MockCoreBanking_Expecter) GetAccountDetails(ctx interface{}, accountNumber interface{}) *MockCoreBanking_GetAccountDetails_Call {
+	return &MockCoreBanking_GetAccountDetails_Call{Call: _e.mock.On("GetAccountDetails", ctx, accountNumber)}
+}
+
+func (_c *MockCoreBanking_GetAccountDetails_Call) Run(run func(ctx context.Context, accountNumber string)) *MockCoreBanking_GetAccountDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_GetAccountDetails_Call) Return(_a0 *intrabank.Account, _a1 error) *MockCoreBanking_GetAccountDetails_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_GetAccountDetails_Call) RunAndReturn(run func(context.Context, string) (*intrabank.Account, error)) *MockCoreBanking_GetAccountDetails_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoreStatus provides a mock function with given fields: ctx
+func (_m *MockCoreBanking) GetCoreStatus(ctx context.Context) (*intrabank.CoreStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoreStatus")
+	}
+
+	var r0 *intrabank.CoreStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*intrabank.CoreStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *intrabank.CoreStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.CoreStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_GetCoreStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoreStatus'
+type MockCoreBanking_GetCoreStatus_Call struct {
+	*mock.Call
+}
+
+// GetCoreStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCoreBanking_Expecter) GetCoreStatus(ctx interface{}) *MockCoreBanking_GetCoreStatus_Call {
+	return &MockCoreBanking_GetCoreStatus_Call{Call: _e.mock.On("GetCoreStatus", ctx)}
+}
+
+func (_c *MockCoreBanking_GetCoreStatus_Call) Run(run func(ctx context.Context)) *MockCoreBanking_GetCoreStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_GetCoreStatus_Call) Return(_a0 *intrabank.CoreStatus, _a1 error) *MockCoreBanking_GetCoreStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_GetCoreStatus_Call) RunAndReturn(run func(context.Context) (*intrabank.CoreStatus, error)) *MockCoreBanking_GetCoreStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPostingStatus provides a mock function with given fields: ctx, reference
+func (_m *MockCoreBanking) GetPostingStatus(ctx context.Context, reference string) (*intrabank.OverbookingResult, error) {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostingStatus")
+	}
+
+	var r0 *intrabank.OverbookingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*intrabank.OverbookingResult, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *intrabank.OverbookingResult); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.OverbookingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_GetPostingStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPostingStatus'
+type MockCoreBanking_GetPostingStatus_Call struct {
+	*mock.Call
+}
+
+// GetPostingStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockCoreBanking_Expecter) GetPostingStatus(ctx interface{}, reference interface{}) *MockCoreBanking_GetPostingStatus_Call {
+	return &MockCoreBanking_GetPostingStatus_Call{Call: _e.mock.On("GetPostingStatus", ctx, reference)}
+}
+
+func (_c *MockCoreBanking_GetPostingStatus_Call) Run(run func(ctx context.Context, reference string)) *MockCoreBanking_GetPostingStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_GetPostingStatus_Call) Return(_a0 *intrabank.OverbookingResult, _a1 error) *MockCoreBanking_GetPostingStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_GetPostingStatus_Call) RunAndReturn(run func(context.Context, string) (*intrabank.OverbookingResult, error)) *MockCoreBanking_GetPostingStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReverseBill provides a mock function with given fields: ctx, in
+func (_m *MockCoreBanking) ReverseBill(ctx context.Context, in *Debit) (*intrabank.OverbookingResult, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReverseBill")
+	}
+
+	var r0 *intrabank.OverbookingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Debit) (*intrabank.OverbookingResult, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Debit) *intrabank.OverbookingResult); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.OverbookingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Debit) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_ReverseBill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReverseBill'
+type MockCoreBanking_ReverseBill_Call struct {
+	*mock.Call
+}
+
+// ReverseBill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *Debit
+func (_e *MockCoreBanking_Expecter) ReverseBill(ctx interface{}, in interface{}) *MockCoreBanking_ReverseBill_Call {
+	return &MockCoreBanking_ReverseBill_Call{Call: _e.mock.On("ReverseBill", ctx, in)}
+}
+
+func (_c *MockCoreBanking_ReverseBill_Call) Run(run func(ctx context.Context, in *Debit)) *MockCoreBanking_ReverseBill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Debit))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_ReverseBill_Call) Return(_a0 *intrabank.OverbookingResult, _a1 error) *MockCoreBanking_ReverseBill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_ReverseBill_Call) RunAndReturn(run func(context.Context, *Debit) (*intrabank.OverbookingResult, error)) *MockCoreBanking_ReverseBill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCoreBanking creates a new instance of MockCoreBanking. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCoreBanking(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCoreBanking {
+	mock := &MockCoreBanking{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
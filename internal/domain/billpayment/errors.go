@@ -0,0 +1,40 @@
+package billpayment
+
+import (
+	"errors"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+var (
+	// ErrGeneral indicates a general error, it is shared with the payment pipeline of the intrabank transfers.
+	ErrGeneral = intrabank.ErrGeneral
+
+	// ErrUnauthenticatedUser indicates that the user is not authenticated.
+	ErrUnauthenticatedUser = intrabank.ErrUnauthenticatedUser
+
+	// ErrProductNotFound is returned when the product is not in the biller catalog.
+	ErrProductNotFound = errors.New("bill product not found")
+
+	// ErrInvalidDenomination is returned when a prepaid product is not sold in the requested amount.
+	ErrInvalidDenomination = errors.New("invalid denomination")
+
+	// ErrCustomerNotFound is returned by the biller when the customer ID is unknown.
+	ErrCustomerNotFound = errors.New("bill customer not found")
+
+	// ErrNoOutstandingBill is returned by the biller when the customer has nothing to pay.
+	ErrNoOutstandingBill = errors.New("no outstanding bill")
+
+	// ErrRailUnavailable is returned by the biller when the billers cannot be reached.
+	ErrRailUnavailable = errors.New("rail unavailable")
+
+	// ErrPaymentNotFound is returned when the sequence has no bill payment.
+	ErrPaymentNotFound = errors.New("bill payment not found")
+
+	// ErrPaymentNotReceived is returned by the biller when it has never received the payment.
+	ErrPaymentNotReceived = errors.New("bill payment not received")
+
+	// ErrPaymentPending is returned when the outcome of the payment is unknown,
+	// the payment stays pending until it is reconciled.
+	ErrPaymentPending = intrabank.ErrPaymentPending
+)
@@ -0,0 +1,63 @@
+package billpayment
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// Repository defines methods to persist and retrieve the bill payments and the biller catalog.
+type Repository interface {
+	intrabank.PaymentRepository
+
+	// GetProducts retrieves the products of the biller catalog.
+	// Returns an error if the operation fails.
+	GetProducts(ctx context.Context) ([]*Product, error)
+
+	// GetProduct retrieves the product with the code.
+	// Returns ErrProductNotFound if the product is not in the catalog.
+	GetProduct(ctx context.Context, code string) (*Product, error)
+
+	// GetLimits retrieves the fee, limits and operating hours of the bill payments.
+	// Returns an error if the operation fails.
+	GetLimits(ctx context.Context) (*intrabank.Limits, error)
+
+	// InsertSequence persists a new sequence.
+	// Returns an error if the operation fails.
+	InsertSequence(ctx context.Context, seq *intrabank.Sequence) error
+
+	// InsertPayment persists the bill payment of a sequence.
+	// Returns an error if the operation fails.
+	InsertPayment(ctx context.Context, payment *Payment) error
+
+	// GetPayment retrieves the bill payment of the sequence.
+	// Returns ErrPaymentNotFound if the sequence has no bill payment.
+	GetPayment(ctx context.Context, sequenceNumber string) (*Payment, error)
+
+	// UpdateReceipt stores the reference and the receipt data returned by the biller.
+	// Returns an error if the operation fails.
+	UpdateReceipt(ctx context.Context, payment *Payment) error
+
+	// InsertReceiptRecovery stores the recovery entry of a receipt that could not be stored with its bill payment.
+	// Returns an error if the operation fails.
+	InsertReceiptRecovery(ctx context.Context, recovery *ReceiptRecovery) error
+
+	// GetOpenReceiptRecoveries retrieves the oldest open receipt recovery entries, limited to limit rows.
+	// Returns an error if the operation fails.
+	GetOpenReceiptRecoveries(ctx context.Context, limit int) ([]*ReceiptRecovery, error)
+
+	// UpdateReceiptRecovery stores the status, attempts and resolution of the receipt recovery entry.
+	// Returns an error if the operation fails.
+	UpdateReceiptRecovery(ctx context.Context, recovery *ReceiptRecovery) error
+
+	// CountTransfersOfType counts the user's successful and pending transfers of the transaction type
+	// created within the [from, to) time range.
+	// Returns an error if the operation fails.
+	CountTransfersOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (int, error)
+
+	// SumTransferAmountOfType sums the amount of the user's successful and pending transfers
+	// of the transaction type created within the [from, to) time range.
+	// Returns an error if the operation fails.
+	SumTransferAmountOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (intrabank.Money, error)
+}
@@ -0,0 +1,657 @@
+package billpayment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+// recoveryBatchSize is the number of receipt recovery entries handled per run.
+const recoveryBatchSize = 50
+
+const (
+	domainName            = "billpayment"
+	paymentSuccessSubject = "Pembayaran Tagihan Berhasil"
+	paymentFailedSubject  = "Pembayaran Tagihan Gagal"
+)
+
+// InquiryInput represents the bill the user wants to pay.
+// The Amount is only used for a prepaid product, it must be one of its denominations.
+type InquiryInput struct {
+	ProductCode   string
+	CustomerID    string
+	Amount        intrabank.Money
+	SourceAccount string
+	Channel       string
+}
+
+// Service handles the bill payments.
+type Service struct {
+	log         *logger.Logger
+	repo        Repository
+	corebanking CoreBanking
+	biller      Biller
+	seqGen      SequenceGenerator
+	validity    intrabank.SequenceValidity
+	authorizer  TransactionAuthorizer
+	stepUp      intrabank.StepUpPolicy
+	fees        intrabank.FeePolicy
+	payer       *intrabank.Payer[*paymentDetails]
+}
+
+// NewService creates a new instance of Service.
+func NewService(
+	log *logger.Logger,
+	repo Repository,
+	corebanking CoreBanking,
+	biller Biller,
+	seqGen SequenceGenerator,
+	validity intrabank.SequenceValidity,
+	authorizer TransactionAuthorizer,
+	stepUp intrabank.StepUpPolicy,
+	fees intrabank.FeePolicy,
+) *Service {
+	s := &Service{
+		log:         log,
+		repo:        repo,
+		corebanking: corebanking,
+		biller:      biller,
+		seqGen:      seqGen,
+		validity:    validity,
+		authorizer:  authorizer,
+		stepUp:      stepUp,
+		fees:        fees,
+	}
+	s.payer = intrabank.NewPayer[*paymentDetails](log, domainName, repo, corebanking, authorizer, stepUp, &paymentMethod{s})
+	return s
+}
+
+// Catalog retrieves the products of the biller catalog.
+func (s *Service) Catalog(ctx context.Context) ([]*Product, error) {
+	products, err := s.repo.GetProducts(ctx)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Catalog").Errorf("GetProducts: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return products, nil
+}
+
+// Inquiry checks the bill of the customer at its biller and creates the sequence of its payment.
+// The amount of the sequence is the bill plus the admin fee of the biller, it is credited to the customer ID.
+func (s *Service) Inquiry(ctx context.Context, in *InquiryInput) (*Payment, error) {
+	if err := s.checkEOD(ctx, "Inquiry"); err != nil {
+		return nil, err
+	}
+
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	product, err := s.repo.GetProduct(ctx, in.ProductCode)
+	if errors.Is(err, ErrProductNotFound) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetProduct (%v): %v", in.ProductCode, err)
+		return nil, pkgerror.New(codes.BadRequest, ErrProductNotFound).
+			SetMsg("This bill cannot be paid at the moment.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetProduct: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	amount := intrabank.Money(0)
+	if product.Prepaid() {
+		if !product.Sells(in.Amount) {
+			s.log.DomainUsecase(domainName, "Inquiry").Errorf("product (%v) amount %v: %v", product.Code, in.Amount, ErrInvalidDenomination)
+			return nil, pkgerror.New(codes.BadRequest, ErrInvalidDenomination).
+				SetMsg("Please choose one of the available amounts.")
+		}
+		amount = in.Amount
+	}
+
+	bill, err := s.biller.Inquiry(ctx, product, in.CustomerID, amount)
+	if errors.Is(err, ErrCustomerNotFound) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("Inquiry (%v %v): %v", product.Code, in.CustomerID, err)
+		return nil, pkgerror.New(codes.BadRequest, ErrCustomerNotFound).
+			SetMsg("The customer ID is not found. Please check it and try again.")
+	}
+	if errors.Is(err, ErrNoOutstandingBill) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("Inquiry (%v %v): %v", product.Code, in.CustomerID, err)
+		return nil, pkgerror.New(codes.BadRequest, ErrNoOutstandingBill).
+			SetMsg("There is no outstanding bill to pay.")
+	}
+	if errors.Is(err, ErrRailUnavailable) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("Inquiry: %v", err)
+		return nil, pkgerror.New(codes.Forbidden, ErrRailUnavailable).
+			SetMsg("Bill payments are temporarily unavailable. Please try again later.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("Inquiry: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	seq := &intrabank.Sequence{
+		Amount:             bill.Amount + bill.AdminFee,
+		SourceAccount:      in.SourceAccount,
+		DestinationAccount: bill.CustomerID,
+		DestinationName:    bill.CustomerName,
+		Channel:            in.Channel,
+	}
+
+	limits, err := s.limits(ctx, "Inquiry")
+	if err != nil {
+		return nil, err
+	}
+	if !limits.CanTransfer(seq.Amount) {
+		s.log.DomainUsecase(domainName, "Inquiry").Error(intrabank.ErrInvalidAmount)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+			SetMsg("Your bill amount is not within the limits of bill payments.")
+	}
+
+	from, to := intrabank.BusinessDay(time.Now())
+	dailyAmount, err := s.repo.SumTransferAmountOfType(ctx, strconv.Itoa(user.ID), TransactionType, from, to)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("SumTransferAmountOfType: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !limits.WithinDailyLimit(dailyAmount + seq.Amount) {
+		s.log.DomainUsecase(domainName, "Inquiry").Error(intrabank.ErrDailyLimitExceeded)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrDailyLimitExceeded).
+			SetMsg("You have reached your daily bill payment limit. Please try again tomorrow.")
+	}
+
+	srcAccount, err := s.corebanking.GetAccountDetails(ctx, seq.SourceAccount)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetAccountDetails: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !srcAccount.IsOwnedBy(user.CIF) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) not owned by user (%v)", seq.SourceAccount, user.ID)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrSourceAccountNotOwned).
+			SetMsg("You can only pay from your own account.")
+	}
+	if !srcAccount.IsAccountActive() {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) not active", seq.SourceAccount)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrSourceAccountInactive)
+	}
+
+	seq.Fee, err = s.paymentFee(ctx, user.ID, seq, limits.Fee, srcAccount.ProductType)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("CountTransfersOfType: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !srcAccount.CanDebit(seq.Amount + seq.Fee) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) cannot be debited by %v", seq.SourceAccount, seq.Amount+seq.Fee)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrInsufficientBalance).
+			SetMsg("Your balance is not enough for this payment.")
+	}
+
+	seq.SourceName = srcAccount.Name
+
+	sequenceNo, err := s.seqGen.Generate()
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("Generate failed: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	now := time.Now()
+	seq.SequenceNumber = sequenceNo
+	seq.TransactionType = TransactionType
+	seq.Status = intrabank.SequenceCreated
+	seq.UserID = user.ID
+	seq.DeviceID = user.DeviceID
+	seq.CreatedAt = now
+	seq.ExpiresAt = now.Add(s.validity.For(TransactionType))
+
+	err = s.repo.InsertSequence(ctx, seq)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("InsertSequence: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	payment := &Payment{
+		SequenceNumber:   seq.SequenceNumber,
+		Product:          product,
+		CustomerID:       bill.CustomerID,
+		CustomerName:     bill.CustomerName,
+		Period:           bill.Period,
+		Amount:           bill.Amount,
+		AdminFee:         bill.AdminFee,
+		Fee:              seq.Fee,
+		InquiryReference: bill.Reference,
+		SourceAccount:    seq.SourceAccount,
+		ExpiresAt:        seq.ExpiresAt,
+		Details:          bill.Details,
+	}
+
+	err = s.repo.InsertPayment(ctx, payment)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("InsertPayment: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return payment, nil
+}
+
+// DoPayment debits the bill payment of the sequence to the settlement account of the biller and delivers it to the biller.
+// A payment rejected by the biller is reversed to the source account.
+// A payment whose outcome is unknown is left pending, because the money may have moved.
+func (s *Service) DoPayment(ctx context.Context, in *intrabank.PaymentInput) (*Receipt, error) {
+	payment, err := s.payer.Pay(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Details == nil {
+		// The sequence has already been paid, its receipt is read back with the receipt data of the biller.
+		return s.receipt(ctx, "DoPayment", payment.Transaction)
+	}
+
+	return &Receipt{
+		Transaction: payment.Transaction,
+		Payment:     payment.Details.payment,
+	}, nil
+}
+
+// SettlePending settles the bill payments left pending, e.g. when the outcome at the biller was unknown.
+func (s *Service) SettlePending(ctx context.Context) error {
+	return s.payer.SettlePending(ctx)
+}
+
+// GetReceipt retrieves the receipt of the user's paid bill with the receipt data of the biller.
+func (s *Service) GetReceipt(ctx context.Context, sequenceNumber string) (*Receipt, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "GetReceipt").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	sequence, err := s.repo.GetSequence(ctx, sequenceNumber)
+	if errors.Is(err, intrabank.ErrSequenceNotFound) ||
+		(err == nil && (sequence.UserID != user.ID || sequence.TransactionType != TransactionType || !sequence.IsCompleted())) {
+		s.log.DomainUsecase(domainName, "GetReceipt").Errorf("sequence (%v) of user (%v): %v", sequenceNumber, user.ID, ErrPaymentNotFound)
+		return nil, pkgerror.New(codes.NotFound, ErrPaymentNotFound).
+			SetMsg("Bill payment not found.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "GetReceipt").Errorf("GetSequence: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	transaction, err := s.repo.GetTransactionBySequenceNumber(ctx, sequence.SequenceNumber)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "GetReceipt").Errorf("GetTransactionBySequenceNumber: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return s.receipt(ctx, "GetReceipt", transaction)
+}
+
+// RecoverReceipts stores the receipts of the open recovery entries with their bill payments,
+// i.e. the receipts returned by the biller that could not be stored when the bill was paid.
+func (s *Service) RecoverReceipts(ctx context.Context) error {
+	recoveries, err := s.repo.GetOpenReceiptRecoveries(ctx, recoveryBatchSize)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "RecoverReceipts").Errorf("GetOpenReceiptRecoveries: %v", err)
+		return err
+	}
+
+	for _, recovery := range recoveries {
+		if err := s.repo.UpdateReceipt(ctx, recovery.Payment()); err != nil {
+			s.log.DomainUsecase(domainName, "RecoverReceipts").Errorf("recovery (%v) UpdateReceipt: %v", recovery.ID, err)
+			recovery.Retry(err)
+		} else {
+			recovery.Resolve("receipt stored by the reconciler", time.Now())
+		}
+
+		if err := s.repo.UpdateReceiptRecovery(ctx, recovery); err != nil {
+			s.log.DomainUsecase(domainName, "RecoverReceipts").Errorf("recovery (%v) UpdateReceiptRecovery: %v", recovery.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// checkEOD rejects the payment while the end of day process of the core banking system is running.
+func (s *Service) checkEOD(ctx context.Context, usecase string) error {
+	coreStatus, err := s.corebanking.GetCoreStatus(ctx)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("CheckEOD: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if coreStatus.IsEODRunning() {
+		s.log.DomainUsecase(domainName, usecase).Errorf("CheckEOD: %v", intrabank.ErrEODInProgress)
+		return pkgerror.New(codes.Internal, intrabank.ErrEODInProgress)
+	}
+	return nil
+}
+
+// limits loads the limits of the bill payments and rejects the payment when they are not available.
+func (s *Service) limits(ctx context.Context, usecase string) (*intrabank.Limits, error) {
+	limits, err := s.repo.GetLimits(ctx)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetLimits: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if limits.Disabled {
+		s.log.DomainUsecase(domainName, usecase).Error(intrabank.ErrTransferMethodDisabled)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrTransferMethodDisabled).
+			SetMsg("Bill payments are temporarily unavailable. Please try again later.")
+	}
+	if now := time.Now(); !limits.IsOpen(now) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("%v at %v", intrabank.ErrOutsideOperatingHours, now)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrOutsideOperatingHours).
+			SetMsg(fmt.Sprintf("Bill payments are only available from %02d:00 to %02d:00 WIB.", limits.OpenHour, limits.CloseHour))
+	}
+	return limits, nil
+}
+
+// paymentFee calculates the fee of the sequence for the customer segment.
+// The payment is free while the user has not used up the monthly free quota of the bill payments.
+func (s *Service) paymentFee(ctx context.Context, userID int, seq *intrabank.Sequence, baseFee intrabank.Money, segment string) (intrabank.Money, error) {
+	in := &intrabank.FeeInput{
+		Method:  TransactionType,
+		Channel: seq.Channel,
+		Segment: segment,
+		Amount:  seq.Amount,
+		BaseFee: baseFee,
+	}
+	if quota := s.fees.FreeTransfers(in); quota > 0 {
+		from, to := intrabank.BusinessMonth(time.Now())
+		count, err := s.repo.CountTransfersOfType(ctx, strconv.Itoa(userID), TransactionType, from, to)
+		if err != nil {
+			return 0, err
+		}
+		if count < quota {
+			return 0, nil
+		}
+	}
+	return s.fees.Fee(in), nil
+}
+
+// receipt retrieves the bill payment of the paid transaction.
+func (s *Service) receipt(ctx context.Context, usecase string, transaction *intrabank.Transaction) (*Receipt, error) {
+	payment, err := s.repo.GetPayment(ctx, transaction.SequenceNumber)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetPayment: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return &Receipt{
+		Transaction: transaction,
+		Payment:     payment,
+	}, nil
+}
+
+// paymentDetails holds the bill payment of the sequence and the limits loaded for it.
+type paymentDetails struct {
+	payment *Payment
+	limits  *intrabank.Limits
+}
+
+// paymentMethod pays the bill payment sequences, it debits the bill to the settlement account of the biller
+// and delivers it to the biller.
+type paymentMethod struct {
+	*Service
+}
+
+var paymentMessages = &intrabank.PaymentMessages{
+	Rejected:    "Your payment request was rejected. Please try again.",
+	KeyReused:   "The idempotency key has been used for another payment.",
+	Expired:     "Your payment session has expired. Please check your bill again.",
+	OTPRequired: "Please verify this payment with the OTP sent to you.",
+	InProgress:  "Your payment is being processed. Please check your transaction history.",
+	Failed:      "Your payment has failed. Please check your bill again.",
+	Pending:     "Your bill payment is being processed. Please check your transaction history.",
+	NotRecorded: "Your payment has been processed but is not recorded yet. Please check your transaction history later.",
+}
+
+func (m *paymentMethod) Messages() *intrabank.PaymentMessages {
+	return paymentMessages
+}
+
+func (m *paymentMethod) Accepts(sequence *intrabank.Sequence) bool {
+	return sequence.TransactionType == TransactionType
+}
+
+// Prepare loads the bill payment and the limits, the limits are checked before the OTP,
+// so a closed payment method does not use it up.
+func (m *paymentMethod) Prepare(ctx context.Context, payment *intrabank.Payment[*paymentDetails]) error {
+	sequence := payment.Sequence
+	billPayment, err := m.repo.GetPayment(ctx, sequence.SequenceNumber)
+	if err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("GetPayment: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	limits, err := m.limits(ctx, "DoPayment")
+	if err != nil {
+		return err
+	}
+	if !limits.CanTransfer(sequence.Amount) {
+		m.log.DomainUsecase(domainName, "DoPayment").Error(intrabank.ErrInvalidAmount)
+		return pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+			SetMsg("Your bill amount is not within the limits of bill payments.")
+	}
+
+	payment.Details = &paymentDetails{payment: billPayment, limits: limits}
+	return nil
+}
+
+// KnownDestination treats every biller as known, the bill is checked at its biller on its own,
+// so only the step-up threshold requires an OTP.
+func (m *paymentMethod) KnownDestination(context.Context, *intrabank.Payment[*paymentDetails]) (bool, error) {
+	return true, nil
+}
+
+func (m *paymentMethod) Describe(payment *intrabank.Payment[*paymentDetails]) (string, string) {
+	return TransactionType, remark(payment.Sequence, payment.Details.payment.Product)
+}
+
+// CheckDailyLimit checks the daily limit of the bill payments, it includes the pending transaction of the payment.
+func (m *paymentMethod) CheckDailyLimit(ctx context.Context, payment *intrabank.Payment[*paymentDetails]) error {
+	from, to := intrabank.BusinessDay(time.Now())
+	dailyAmount, err := m.repo.SumTransferAmountOfType(ctx, payment.Transaction.UserID, TransactionType, from, to)
+	if err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("SumTransferAmountOfType: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !payment.Details.limits.WithinDailyLimit(dailyAmount) {
+		m.log.DomainUsecase(domainName, "DoPayment").Error(intrabank.ErrDailyLimitExceeded)
+		return pkgerror.New(codes.BadRequest, intrabank.ErrDailyLimitExceeded).
+			SetMsg("You have reached your daily bill payment limit. Please try again tomorrow.")
+	}
+	return nil
+}
+
+// Post debits the bill to the settlement account of the biller and delivers it to the biller.
+// The posting is recorded before the delivery and a receipt which cannot be stored is kept for the reconciler.
+// A payment rejected by the biller is reversed to the source account and returned as rejected,
+// it is left pending when the reversal fails, so the debited money is reconciled.
+func (m *paymentMethod) Post(ctx context.Context, payment *intrabank.Payment[*paymentDetails]) (*intrabank.OverbookingResult, error) {
+	sequence := payment.Sequence
+	transaction := payment.Transaction
+	billPayment := payment.Details.payment
+
+	debit := billDebit(payment)
+	result, err := m.corebanking.DebitBill(ctx, debit)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction.SequenceJournal = result.JournalSequence
+	transaction.TransactionReference = result.TransactionReference
+	if err := m.repo.RecordPosting(ctx, transaction); err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("RecordPosting: sequence (%v) journal (%v): %v",
+			transaction.SequenceNumber, transaction.SequenceJournal, err)
+	}
+
+	receipt, err := m.biller.Pay(ctx, &BillerPayment{
+		Product:              billPayment.Product,
+		CustomerID:           billPayment.CustomerID,
+		Amount:               billPayment.Amount,
+		AdminFee:             billPayment.AdminFee,
+		InquiryReference:     billPayment.InquiryReference,
+		SequenceNumber:       sequence.SequenceNumber,
+		TransactionReference: transaction.TransactionReference,
+	})
+	var rejection *PaymentRejection
+	if errors.As(err, &rejection) {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("Pay: %v", err)
+		if _, err := m.corebanking.ReverseBill(ctx, debit); err != nil {
+			return nil, fmt.Errorf("reverse bill journal (%v): %w", transaction.SequenceJournal, err)
+		}
+		return nil, &intrabank.OverbookingRejection{
+			StatusCode:  rejection.StatusCode,
+			Description: rejection.Description,
+			Payload:     rejection.Payload,
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pay biller journal (%v): %w", transaction.SequenceJournal, err)
+	}
+
+	m.storeReceipt(ctx, "DoPayment", billPayment, receipt)
+
+	return result, nil
+}
+
+// Load loads the bill payment of the sequence for its receipt.
+func (m *paymentMethod) Load(ctx context.Context, payment *intrabank.Payment[*paymentDetails]) error {
+	billPayment, err := m.repo.GetPayment(ctx, payment.Sequence.SequenceNumber)
+	if err != nil {
+		return err
+	}
+	payment.Details = &paymentDetails{payment: billPayment}
+	return nil
+}
+
+// Resolve checks the debit of the bill at the core banking system when its journal has not been recorded,
+// and then the payment at the biller, whose receipt is stored. A debited payment which has been rejected
+// or never received by the biller is reversed, it is left pending when the reversal fails.
+func (m *paymentMethod) Resolve(ctx context.Context, payment *intrabank.Payment[*paymentDetails]) (*intrabank.OverbookingResult, error) {
+	sequence := payment.Sequence
+	transaction := payment.Transaction
+	billPayment := payment.Details.payment
+
+	if transaction.SequenceJournal == "" {
+		posting, err := m.corebanking.GetPostingStatus(ctx, sequence.SequenceNumber)
+		if err != nil {
+			return nil, err
+		}
+		transaction.SequenceJournal = posting.JournalSequence
+		transaction.TransactionReference = posting.TransactionReference
+	}
+
+	receipt, err := m.biller.PaymentStatus(ctx, billPayment.Product, sequence.SequenceNumber)
+	var rejection *PaymentRejection
+	if errors.As(err, &rejection) || errors.Is(err, ErrPaymentNotReceived) {
+		if _, err := m.corebanking.ReverseBill(ctx, billDebit(payment)); err != nil {
+			return nil, fmt.Errorf("reverse bill journal (%v): %w", transaction.SequenceJournal, err)
+		}
+		if rejection == nil {
+			return nil, &intrabank.OverbookingRejection{Description: err.Error(), Payload: err.Error()}
+		}
+		return nil, &intrabank.OverbookingRejection{
+			StatusCode:  rejection.StatusCode,
+			Description: rejection.Description,
+			Payload:     rejection.Payload,
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("payment status journal (%v): %w", transaction.SequenceJournal, err)
+	}
+
+	m.storeReceipt(ctx, "Settle", billPayment, receipt)
+
+	return &intrabank.OverbookingResult{
+		JournalSequence:      transaction.SequenceJournal,
+		TransactionReference: transaction.TransactionReference,
+	}, nil
+}
+
+// storeReceipt stores the receipt of the biller with the bill payment,
+// a receipt which cannot be stored is kept as a recovery entry for the reconciler.
+func (m *paymentMethod) storeReceipt(ctx context.Context, usecase string, billPayment *Payment, receipt *BillerReceipt) {
+	billPayment.BillerReference = receipt.Reference
+	billPayment.Receipt = receipt.Details
+	if err := m.repo.UpdateReceipt(ctx, billPayment); err != nil {
+		m.log.DomainUsecase(domainName, usecase).Errorf("UpdateReceipt: %v", err)
+		if err := m.repo.InsertReceiptRecovery(ctx, NewReceiptRecovery(billPayment, err)); err != nil {
+			m.log.DomainUsecase(domainName, usecase).Errorf("InsertReceiptRecovery: sequence (%v) reference (%v) receipt (%v): %v",
+				billPayment.SequenceNumber, billPayment.BillerReference, billPayment.ReceiptNote(), err)
+		}
+	}
+}
+
+// billDebit returns the debit of the bill to the settlement account of the biller.
+func billDebit(payment *intrabank.Payment[*paymentDetails]) *Debit {
+	sequence := payment.Sequence
+	product := payment.Details.payment.Product
+	return &Debit{
+		SourceAccount:     sequence.SourceAccount,
+		SettlementAccount: product.SettlementAccount,
+		Provider:          product.Provider,
+		Amount:            sequence.Amount,
+		Fee:               sequence.Fee,
+		Remark:            payment.Transaction.Remarks,
+		Reference:         sequence.SequenceNumber,
+	}
+}
+
+// Rejected tells the user whether the debit was rejected or the biller rejected the debited payment,
+// which has been returned to the source account.
+func (m *paymentMethod) Rejected(payment *intrabank.Payment[*paymentDetails], _ *intrabank.OverbookingRejection) error {
+	if payment.Transaction.SequenceJournal != "" {
+		return pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+			SetMsg("The biller rejected your payment. The amount has been returned to your account.")
+	}
+	return pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+		SetMsg("Your bill payment was rejected. Please try again.")
+}
+
+// Receipt builds the receipt and the notification of the payment, they are delivered
+// by the outbox dispatcher of the intrabank transfers.
+// The receipt email carries the receipt data of the biller, e.g. the PLN token number.
+func (m *paymentMethod) Receipt(payment *intrabank.Payment[*paymentDetails]) (*intrabank.EmailData, *intrabank.Notification) {
+	transaction := payment.Transaction
+	billPayment := payment.Details.payment
+
+	subject := paymentSuccessSubject
+	note := transaction.Remarks
+	if transaction.Status == intrabank.TransactionFailed {
+		subject = paymentFailedSubject
+	} else if receiptNote := billPayment.ReceiptNote(); receiptNote != "" {
+		note = receiptNote
+	}
+
+	return &intrabank.EmailData{
+		Subject:            subject,
+		Recipient:          payment.User.Email,
+		Amount:             transaction.Amount,
+		Fee:                payment.Sequence.Fee,
+		SourceName:         payment.User.Name,
+		SourceAccount:      payment.Sequence.SourceAccount,
+		DestinationName:    transaction.DestinationName,
+		DestinationAccount: transaction.Destination,
+		DestinationBank:    billPayment.Product.Name,
+		TransactionRef:     transaction.TransactionReference,
+		Note:               note,
+		Status:             transaction.Status,
+	}, &intrabank.Notification{
+		Subject:     subject,
+		Amount:      transaction.Amount,
+		Destination: billPayment.Product.Name,
+		Status:      transaction.Status,
+	}
+}
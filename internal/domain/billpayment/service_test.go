@@ -0,0 +1,856 @@
+package billpayment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+var plnToken = &Product{
+	Code:              "PLN_PREPAID",
+	Name:              "PLN Token",
+	Category:          CategoryElectricity,
+	Provider:          "PLNPRE",
+	SettlementAccount: "009001000000001",
+	Denominations:     []intrabank.Money{20000, 50000, 100000},
+}
+
+func TestCatalogSuccess(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockCoreBanking(t), NewMockBiller(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+	)
+
+	repoMock.EXPECT().GetProducts(mock.Anything).
+		Return([]*Product{plnToken}, nil)
+
+	products, err := svc.Catalog(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*Product{plnToken}, products)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestBillInquirySuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		billerMock      = NewMockBiller(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, billerMock, seqGenMock, intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&intrabank.Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+		}, nil)
+
+	repoMock.EXPECT().GetProduct(mock.Anything, "PLN_PREPAID").
+		Return(plnToken, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000, Fee: 1000}, nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "bill_payment", mock.Anything, mock.Anything).
+		Return(0, nil)
+	repoMock.EXPECT().InsertSequence(mock.Anything, mock.MatchedBy(func(seq *intrabank.Sequence) bool {
+		return seq.SequenceNumber == "123456" &&
+			seq.TransactionType == "bill_payment" &&
+			seq.Amount == 102500 &&
+			seq.Fee == 1000 &&
+			seq.DestinationAccount == "532100000001" &&
+			seq.DestinationName == "OLIVIA RODRIGO"
+	})).Return(nil)
+	repoMock.EXPECT().InsertPayment(mock.Anything, mock.Anything).
+		Return(nil)
+
+	billerMock.EXPECT().Inquiry(mock.Anything, plnToken, "532100000001", intrabank.Money(100000)).
+		Return(&Bill{
+			CustomerID:   "532100000001",
+			CustomerName: "OLIVIA RODRIGO",
+			Amount:       100000,
+			AdminFee:     2500,
+			Reference:    "INQ-1",
+			Details:      []Detail{{Label: "Tariff/Power", Value: "R1/1300VA"}},
+		}, nil)
+
+	seqGenMock.EXPECT().Generate().
+		Return("123456", nil)
+
+	payment, err := svc.Inquiry(ctx, &InquiryInput{
+		ProductCode:   "PLN_PREPAID",
+		CustomerID:    "532100000001",
+		Amount:        100000,
+		SourceAccount: "001001234567891",
+	})
+
+	assert.Nil(t, err)
+	payment.ExpiresAt = payment.ExpiresAt.Truncate(0)
+	assert.Equal(t, "123456", payment.SequenceNumber)
+	assert.Equal(t, plnToken, payment.Product)
+	assert.Equal(t, "INQ-1", payment.InquiryReference)
+	assert.Equal(t, []Detail{{Label: "Tariff/Power", Value: "R1/1300VA"}}, payment.Details)
+	assert.Equal(t, intrabank.Money(103500), payment.Total())
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	billerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestBillInquiryFailed_InvalidDenomination(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockBiller(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetProduct(mock.Anything, "PLN_PREPAID").
+		Return(plnToken, nil)
+
+	payment, err := svc.Inquiry(ctx, &InquiryInput{
+		ProductCode:   "PLN_PREPAID",
+		CustomerID:    "532100000001",
+		Amount:        75000,
+		SourceAccount: "001001234567891",
+	})
+
+	assert.Nil(t, payment)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidDenomination).
+		SetMsg("Please choose one of the available amounts."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestBillInquiryFailed_ProductNotFound(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockBiller(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetProduct(mock.Anything, "GAS").
+		Return(nil, ErrProductNotFound)
+
+	payment, err := svc.Inquiry(ctx, &InquiryInput{
+		ProductCode:   "GAS",
+		CustomerID:    "532100000001",
+		SourceAccount: "001001234567891",
+	})
+
+	assert.Nil(t, payment)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrProductNotFound).
+		SetMsg("This bill cannot be paid at the moment."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestBillInquiryFailed_BillerErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		billerErr error
+		want      error
+	}{
+		{
+			name:      "customer not found",
+			billerErr: ErrCustomerNotFound,
+			want: pkgerror.New(codes.BadRequest, ErrCustomerNotFound).
+				SetMsg("The customer ID is not found. Please check it and try again."),
+		},
+		{
+			name:      "no outstanding bill",
+			billerErr: ErrNoOutstandingBill,
+			want: pkgerror.New(codes.BadRequest, ErrNoOutstandingBill).
+				SetMsg("There is no outstanding bill to pay."),
+		},
+		{
+			name:      "biller unavailable",
+			billerErr: errors.New("timeout"),
+			want:      pkgerror.New(codes.Internal, ErrGeneral),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				corebankingMock = NewMockCoreBanking(t)
+				repoMock        = NewMockRepository(t)
+				billerMock      = NewMockBiller(t)
+				svc             = NewService(logger.New(), repoMock, corebankingMock, billerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+				bpjs            = &Product{Code: "BPJS_KESEHATAN", Category: CategoryBPJS}
+				ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+					ID:       123,
+					CIF:      "1234567",
+					Name:     "Olivia Rodrigo",
+					Email:    "olivia@gmail.com",
+					DeviceID: "device-1",
+				})
+			)
+
+			corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+				Status:        "FINISHED",
+				StandInStatus: "N",
+			}, nil)
+
+			repoMock.EXPECT().GetProduct(mock.Anything, "BPJS_KESEHATAN").
+				Return(bpjs, nil)
+
+			billerMock.EXPECT().Inquiry(mock.Anything, bpjs, "0001234567890", intrabank.Money(0)).
+				Return(nil, tt.billerErr)
+
+			payment, err := svc.Inquiry(ctx, &InquiryInput{
+				ProductCode:   "BPJS_KESEHATAN",
+				CustomerID:    "0001234567890",
+				SourceAccount: "001001234567891",
+			})
+
+			assert.Nil(t, payment)
+			assert.Equal(t, tt.want, err)
+
+			corebankingMock.AssertExpectations(t)
+			repoMock.AssertExpectations(t)
+			billerMock.AssertExpectations(t)
+		})
+	}
+}
+
+var plnDebit = &Debit{
+	SourceAccount:     "001001234567891",
+	SettlementAccount: "009001000000001",
+	Provider:          "PLNPRE",
+	Amount:            102500,
+	Fee:               1000,
+	Remark:            "BILL PLN_PREPAID 001001234567891 532100000001 123456",
+	Reference:         "123456",
+}
+
+var plnPaymentInput = &intrabank.PaymentInput{
+	SequenceNumber:     "123456",
+	SourceAccount:      "001001234567891",
+	DestinationAccount: "532100000001",
+	Amount:             102500,
+}
+
+func TestBillDoPaymentSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		billerMock      = NewMockBiller(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, billerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		tokenReceipt    = []Detail{{Label: "Token", Value: "1234 5678 9012 3456 7890"}, {Label: "kWh", Value: "66.7"}}
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().DebitBill(mock.Anything, plnDebit).
+		Return(&intrabank.OverbookingResult{
+			JournalSequence:      "000001",
+			TransactionReference: "BILL000001",
+		}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             102500,
+			Fee:                1000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "532100000001",
+			SourceName:         "Olivia Rodrigo",
+			DestinationName:    "OLIVIA RODRIGO",
+			TransactionType:    "bill_payment",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").
+		Return(&Payment{
+			SequenceNumber:   "123456",
+			Product:          plnToken,
+			CustomerID:       "532100000001",
+			CustomerName:     "OLIVIA RODRIGO",
+			Amount:           100000,
+			AdminFee:         2500,
+			Fee:              1000,
+			InquiryReference: "INQ-1",
+			SourceAccount:    "001001234567891",
+		}, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "bill_payment", mock.Anything, mock.Anything).
+		Return(102500, nil)
+	repoMock.EXPECT().RecordPosting(mock.Anything, mock.MatchedBy(func(transaction *intrabank.Transaction) bool {
+		return transaction.SequenceJournal == "000001" && transaction.Status == intrabank.TransactionPending
+	})).Return(nil)
+	repoMock.EXPECT().UpdateReceipt(mock.Anything, mock.MatchedBy(func(payment *Payment) bool {
+		return payment.BillerReference == "PLN-REF-1" && len(payment.Receipt) == 2
+	})).Return(nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, mock.Anything, mock.MatchedBy(func(outbox []*intrabank.OutboxMessage) bool {
+		if len(outbox) != 1 {
+			return false
+		}
+		email, err := outbox[0].Receipt()
+		return err == nil &&
+			email.DestinationBank == "PLN Token" &&
+			email.Note == "Token: 1234 5678 9012 3456 7890, kWh: 66.7" &&
+			email.Status == intrabank.TransactionSuccess
+	})).Return(nil)
+
+	billerMock.EXPECT().Pay(mock.Anything, &BillerPayment{
+		Product:              plnToken,
+		CustomerID:           "532100000001",
+		Amount:               100000,
+		AdminFee:             2500,
+		InquiryReference:     "INQ-1",
+		SequenceNumber:       "123456",
+		TransactionReference: "BILL000001",
+	}).Return(&BillerReceipt{Reference: "PLN-REF-1", Details: tokenReceipt}, nil)
+
+	receipt, err := svc.DoPayment(ctx, plnPaymentInput)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &intrabank.Transaction{
+		SequenceNumber:       "123456",
+		UserID:               "123",
+		Destination:          "532100000001",
+		Amount:               102500,
+		TransactionType:      "bill_payment",
+		Remarks:              "BILL PLN_PREPAID 001001234567891 532100000001 123456",
+		Status:               intrabank.TransactionSuccess,
+		Fee:                  "1000",
+		DestinationName:      "OLIVIA RODRIGO",
+		SequenceJournal:      "000001",
+		TransactionReference: "BILL000001",
+	}, receipt.Transaction)
+	assert.Equal(t, tokenReceipt, receipt.Payment.Receipt)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	billerMock.AssertExpectations(t)
+}
+
+func TestBillDoPaymentSuccess_ReceiptRecovered(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		billerMock      = NewMockBiller(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, billerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		tokenReceipt    = []Detail{{Label: "Token", Value: "1234 5678 9012 3456 7890"}}
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().DebitBill(mock.Anything, plnDebit).
+		Return(&intrabank.OverbookingResult{JournalSequence: "000001", TransactionReference: "BILL000001"}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             102500,
+			Fee:                1000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "532100000001",
+			SourceName:         "Olivia Rodrigo",
+			DestinationName:    "OLIVIA RODRIGO",
+			TransactionType:    "bill_payment",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").
+		Return(&Payment{
+			SequenceNumber:   "123456",
+			Product:          plnToken,
+			CustomerID:       "532100000001",
+			CustomerName:     "OLIVIA RODRIGO",
+			Amount:           100000,
+			AdminFee:         2500,
+			Fee:              1000,
+			InquiryReference: "INQ-1",
+			SourceAccount:    "001001234567891",
+		}, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "bill_payment", mock.Anything, mock.Anything).
+		Return(102500, nil)
+	repoMock.EXPECT().RecordPosting(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().UpdateReceipt(mock.Anything, mock.Anything).
+		Return(errors.New("connection reset"))
+	repoMock.EXPECT().InsertReceiptRecovery(mock.Anything, &ReceiptRecovery{
+		SequenceNumber:  "123456",
+		BillerReference: "PLN-REF-1",
+		Receipt:         tokenReceipt,
+		Status:          intrabank.RecoveryOpen,
+		LastError:       "connection reset",
+	}).Return(nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	billerMock.EXPECT().Pay(mock.Anything, mock.Anything).
+		Return(&BillerReceipt{Reference: "PLN-REF-1", Details: tokenReceipt}, nil)
+
+	receipt, err := svc.DoPayment(ctx, plnPaymentInput)
+
+	assert.Nil(t, err)
+	assert.Equal(t, intrabank.TransactionSuccess, receipt.Transaction.Status)
+	assert.Equal(t, tokenReceipt, receipt.Payment.Receipt)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	billerMock.AssertExpectations(t)
+}
+
+func TestBillDoPaymentFailed_DebitRejected(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockBiller(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().DebitBill(mock.Anything, plnDebit).
+		Return(nil, &intrabank.OverbookingRejection{StatusCode: "51", Description: "insufficient funds"})
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             102500,
+			Fee:                1000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "532100000001",
+			SourceName:         "Olivia Rodrigo",
+			DestinationName:    "OLIVIA RODRIGO",
+			TransactionType:    "bill_payment",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").
+		Return(&Payment{
+			SequenceNumber:   "123456",
+			Product:          plnToken,
+			CustomerID:       "532100000001",
+			CustomerName:     "OLIVIA RODRIGO",
+			Amount:           100000,
+			AdminFee:         2500,
+			Fee:              1000,
+			InquiryReference: "INQ-1",
+			SourceAccount:    "001001234567891",
+		}, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "bill_payment", mock.Anything, mock.Anything).
+		Return(102500, nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("", nil)
+	repoMock.EXPECT().FailTransaction(mock.Anything, mock.MatchedBy(func(transaction *intrabank.Transaction) bool {
+		return transaction.Status == intrabank.TransactionFailed && transaction.StatusCode == "51"
+	}), mock.Anything).Return(nil)
+
+	receipt, err := svc.DoPayment(ctx, plnPaymentInput)
+
+	assert.Nil(t, receipt)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+		SetMsg("Your bill payment was rejected. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestBillDoPaymentFailed_BillerRejectedAndReversed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		billerMock      = NewMockBiller(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, billerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().DebitBill(mock.Anything, plnDebit).
+		Return(&intrabank.OverbookingResult{JournalSequence: "000001", TransactionReference: "BILL000001"}, nil)
+	corebankingMock.EXPECT().ReverseBill(mock.Anything, plnDebit).
+		Return(&intrabank.OverbookingResult{JournalSequence: "000002", TransactionReference: "BILL000002"}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             102500,
+			Fee:                1000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "532100000001",
+			SourceName:         "Olivia Rodrigo",
+			DestinationName:    "OLIVIA RODRIGO",
+			TransactionType:    "bill_payment",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").
+		Return(&Payment{
+			SequenceNumber:   "123456",
+			Product:          plnToken,
+			CustomerID:       "532100000001",
+			CustomerName:     "OLIVIA RODRIGO",
+			Amount:           100000,
+			AdminFee:         2500,
+			Fee:              1000,
+			InquiryReference: "INQ-1",
+			SourceAccount:    "001001234567891",
+		}, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "bill_payment", mock.Anything, mock.Anything).
+		Return(102500, nil)
+	repoMock.EXPECT().RecordPosting(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("firebase-1", nil)
+	repoMock.EXPECT().FailTransaction(mock.Anything, mock.MatchedBy(func(transaction *intrabank.Transaction) bool {
+		return transaction.Status == intrabank.TransactionFailed && transaction.StatusCode == "14"
+	}), mock.MatchedBy(func(outbox []*intrabank.OutboxMessage) bool {
+		return len(outbox) == 2
+	})).Return(nil)
+
+	billerMock.EXPECT().Pay(mock.Anything, mock.Anything).
+		Return(nil, &PaymentRejection{StatusCode: "14", Description: "meter blocked"})
+
+	receipt, err := svc.DoPayment(ctx, plnPaymentInput)
+
+	assert.Nil(t, receipt)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+		SetMsg("The biller rejected your payment. The amount has been returned to your account."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	billerMock.AssertExpectations(t)
+}
+
+func TestBillDoPaymentFailed_ReversalFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		billerMock      = NewMockBiller(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, billerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().DebitBill(mock.Anything, plnDebit).
+		Return(&intrabank.OverbookingResult{JournalSequence: "000001", TransactionReference: "BILL000001"}, nil)
+	corebankingMock.EXPECT().ReverseBill(mock.Anything, plnDebit).
+		Return(nil, errors.New("timeout"))
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             102500,
+			Fee:                1000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "532100000001",
+			SourceName:         "Olivia Rodrigo",
+			DestinationName:    "OLIVIA RODRIGO",
+			TransactionType:    "bill_payment",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").
+		Return(&Payment{
+			SequenceNumber:   "123456",
+			Product:          plnToken,
+			CustomerID:       "532100000001",
+			CustomerName:     "OLIVIA RODRIGO",
+			Amount:           100000,
+			AdminFee:         2500,
+			Fee:              1000,
+			InquiryReference: "INQ-1",
+			SourceAccount:    "001001234567891",
+		}, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "bill_payment", mock.Anything, mock.Anything).
+		Return(102500, nil)
+	repoMock.EXPECT().RecordPosting(mock.Anything, mock.Anything).
+		Return(nil)
+
+	billerMock.EXPECT().Pay(mock.Anything, mock.Anything).
+		Return(nil, &PaymentRejection{StatusCode: "14", Description: "meter blocked"})
+
+	receipt, err := svc.DoPayment(ctx, plnPaymentInput)
+
+	assert.Nil(t, receipt)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrPaymentPending).
+		SetMsg("Your bill payment is being processed. Please check your transaction history."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	billerMock.AssertExpectations(t)
+}
+
+func TestBillDoPaymentFailed_NotBillSequence(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockBiller(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             102500,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "532100000001",
+			TransactionType:    "qris",
+			Status:             "CREATED",
+		}, nil)
+
+	receipt, err := svc.DoPayment(ctx, plnPaymentInput)
+
+	assert.Nil(t, receipt)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrInvalidSequenceNumber).
+		SetMsg("Your payment request was rejected. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetReceiptFailed_OtherUser(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockCoreBanking(t), NewMockBiller(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:  "123456",
+			UserID:          456,
+			TransactionType: "bill_payment",
+			Status:          "COMPLETED",
+		}, nil)
+
+	receipt, err := svc.GetReceipt(ctx, "123456")
+
+	assert.Nil(t, receipt)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrPaymentNotFound).
+		SetMsg("Bill payment not found."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestRecoverReceipts(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockCoreBanking(t), NewMockBiller(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		receipt  = []Detail{{Label: "Token", Value: "1234 5678 9012 3456 7890"}}
+	)
+
+	repoMock.EXPECT().GetOpenReceiptRecoveries(mock.Anything, recoveryBatchSize).
+		Return([]*ReceiptRecovery{
+			{ID: 1, SequenceNumber: "123456", BillerReference: "PLN-REF-1", Receipt: receipt, Status: intrabank.RecoveryOpen},
+			{ID: 2, SequenceNumber: "123457", BillerReference: "PLN-REF-2", Status: intrabank.RecoveryOpen, Attempts: 9},
+		}, nil)
+	repoMock.EXPECT().UpdateReceipt(mock.Anything, &Payment{SequenceNumber: "123456", BillerReference: "PLN-REF-1", Receipt: receipt}).
+		Return(nil)
+	repoMock.EXPECT().UpdateReceipt(mock.Anything, &Payment{SequenceNumber: "123457", BillerReference: "PLN-REF-2"}).
+		Return(errors.New("connection reset"))
+	repoMock.EXPECT().UpdateReceiptRecovery(mock.Anything, mock.MatchedBy(func(recovery *ReceiptRecovery) bool {
+		return recovery.ID == 1 && recovery.Status == intrabank.RecoveryResolved
+	})).Return(nil)
+	repoMock.EXPECT().UpdateReceiptRecovery(mock.Anything, mock.MatchedBy(func(recovery *ReceiptRecovery) bool {
+		return recovery.ID == 2 && recovery.Status == intrabank.RecoveryManual && recovery.LastError == "connection reset"
+	})).Return(nil)
+
+	err := svc.RecoverReceipts(context.Background())
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestBillSettlePendingSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		billerMock      = NewMockBiller(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, billerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		tokenReceipt    = []Detail{{Label: "Token", Value: "1234 5678 9012 3456 7890"}}
+	)
+
+	repoMock.EXPECT().GetPendingTransactions(mock.Anything, mock.Anything, 50).Return([]*intrabank.Transaction{
+		{ID: 10, SequenceNumber: "123456", UserID: "123", Amount: 102500, Status: intrabank.TransactionPending},
+	}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").Return(&intrabank.Sequence{
+		SequenceNumber:     "123456",
+		UserID:             123,
+		Amount:             102500,
+		Fee:                1000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "532100000001",
+	}, nil)
+	repoMock.EXPECT().GetUser(mock.Anything, 123).Return(&ctxt.User{ID: 123, Email: "olivia@gmail.com"}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").Return(&Payment{
+		SequenceNumber: "123456",
+		Product:        plnToken,
+		CustomerID:     "532100000001",
+		Amount:         100000,
+		AdminFee:       2500,
+	}, nil)
+	corebankingMock.EXPECT().GetPostingStatus(mock.Anything, "123456").
+		Return(&intrabank.OverbookingResult{JournalSequence: "000001", TransactionReference: "BILL000001"}, nil)
+	billerMock.EXPECT().PaymentStatus(mock.Anything, plnToken, "123456").
+		Return(&BillerReceipt{Reference: "PLN-REF-1", Details: tokenReceipt}, nil)
+	repoMock.EXPECT().UpdateReceipt(mock.Anything, mock.MatchedBy(func(payment *Payment) bool {
+		return payment.BillerReference == "PLN-REF-1" && len(payment.Receipt) == 1
+	})).Return(nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).Return("", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, mock.MatchedBy(func(transaction *intrabank.Transaction) bool {
+		return transaction.ID == 10 && transaction.Status == intrabank.TransactionSuccess &&
+			transaction.SequenceJournal == "000001" && transaction.TransactionReference == "BILL000001"
+	}), mock.Anything).Return(nil)
+
+	err := svc.SettlePending(context.Background())
+
+	assert.Nil(t, err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	billerMock.AssertExpectations(t)
+}
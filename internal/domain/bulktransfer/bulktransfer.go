@@ -0,0 +1,180 @@
+// Package bulktransfer provides the bulk intrabank transfers uploaded as a CSV file.
+// Every row of the file is validated against the intrabank limits and accounts and shown as a preview,
+// the confirmed batch is run in the background and each row results in its own transaction.
+package bulktransfer
+
+import (
+	"fmt"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+const (
+	// StatusPreview represents a validated batch waiting for the user's confirmation.
+	StatusPreview = "PREVIEW"
+	// StatusQueued represents a confirmed batch waiting for the worker.
+	StatusQueued = "QUEUED"
+	// StatusProcessing represents a batch whose rows are being transferred.
+	StatusProcessing = "PROCESSING"
+	// StatusCompleted represents a batch whose rows have all been processed.
+	StatusCompleted = "COMPLETED"
+)
+
+const (
+	// RowValid represents a row that passed the validation and will be transferred.
+	RowValid = "VALID"
+	// RowInvalid represents a row rejected by the validation, it is never transferred.
+	RowInvalid = "INVALID"
+	// RowSuccess represents a row transferred successfully.
+	RowSuccess = "SUCCESS"
+	// RowFailed represents a row whose transfer failed.
+	RowFailed = "FAILED"
+)
+
+// Channel is the transfer channel of the bulk transfers, fee rules can match it.
+const Channel = "BULK"
+
+// User represents the owner of a batch.
+type User struct {
+	ID    int
+	CIF   string
+	Name  string
+	Email string
+}
+
+// Batch is an uploaded bulk transfer file from one source account.
+type Batch struct {
+	ID            int64
+	User          *User
+	SourceAccount string
+	FileName      string
+	Status        string
+	Rows          []*Row
+	CreatedAt     time.Time
+	CompletedAt   time.Time
+}
+
+// Reference returns the reference the transaction OTP confirming the batch is bound to.
+func (b *Batch) Reference() string {
+	return fmt.Sprintf("BULK-%d", b.ID)
+}
+
+// ValidRows returns the number of rows that will be transferred.
+func (b *Batch) ValidRows() int {
+	return b.countRows(RowValid)
+}
+
+// TotalAmount returns the sum of the amounts of the rows that passed the validation.
+func (b *Batch) TotalAmount() intrabank.Money {
+	var total intrabank.Money
+	for _, row := range b.Rows {
+		if row.Status != RowInvalid {
+			total += row.Amount
+		}
+	}
+	return total
+}
+
+// Succeeded returns the number of rows transferred successfully.
+func (b *Batch) Succeeded() int {
+	return b.countRows(RowSuccess)
+}
+
+// Failed returns the number of rows that were not transferred, including the invalid ones.
+func (b *Batch) Failed() int {
+	return b.countRows(RowFailed) + b.countRows(RowInvalid)
+}
+
+// Confirmable checks if the batch is still a preview with at least one row to transfer.
+func (b *Batch) Confirmable() bool {
+	return b.Status == StatusPreview && b.ValidRows() > 0
+}
+
+// IsCompleted checks if every row of the batch has been processed.
+func (b *Batch) IsCompleted() bool {
+	return b.Status == StatusCompleted
+}
+
+// Complete marks the batch as completed at now.
+func (b *Batch) Complete(now time.Time) {
+	b.Status = StatusCompleted
+	b.CompletedAt = now
+}
+
+func (b *Batch) countRows(status string) int {
+	count := 0
+	for _, row := range b.Rows {
+		if row.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Row is one transfer of a batch, Line is its line number in the uploaded file.
+// SequenceNumber is the sequence of the row's payment, it is empty until the row is paid.
+type Row struct {
+	ID                   int64
+	Line                 int
+	DestinationAccount   string
+	DestinationName      string
+	Amount               intrabank.Money
+	Fee                  intrabank.Money
+	Note                 string
+	Status               string
+	Error                string
+	TransactionReference string
+	SequenceNumber       string
+}
+
+// Sequence returns the intrabank sequence of the row's transfer from the source account.
+func (r *Row) Sequence(sourceAccount string) *intrabank.Sequence {
+	return &intrabank.Sequence{
+		SourceAccount:      sourceAccount,
+		DestinationAccount: r.DestinationAccount,
+		Amount:             r.Amount,
+		Channel:            Channel,
+		Note:               r.Note,
+	}
+}
+
+// IdempotencyKey returns the idempotency key of the row's payment,
+// so a row is never transferred twice.
+func (r *Row) IdempotencyKey(batchID int64) string {
+	return intrabank.InternalIdempotencyKey(fmt.Sprintf("bulk-%d-%d", batchID, r.ID))
+}
+
+// Validate marks the row as valid with the destination name and fee checked by the validation.
+func (r *Row) Validate(destinationName string, fee intrabank.Money) {
+	r.Status = RowValid
+	r.DestinationName = destinationName
+	r.Fee = fee
+	r.Error = ""
+}
+
+// Invalidate marks the row as rejected by the validation.
+func (r *Row) Invalidate(reason string) {
+	r.Status = RowInvalid
+	r.Error = reason
+}
+
+// Inquire sets the sequence created for the row's payment and the fee it charges.
+func (r *Row) Inquire(sequenceNumber string, fee intrabank.Money) {
+	r.SequenceNumber = sequenceNumber
+	r.Fee = fee
+}
+
+// Succeed marks the row as transferred.
+func (r *Row) Succeed(transactionReference string, fee intrabank.Money) {
+	r.Status = RowSuccess
+	r.TransactionReference = transactionReference
+	r.Fee = fee
+	r.Error = ""
+}
+
+// Fail marks the row's transfer as failed.
+func (r *Row) Fail(reason string) {
+	r.Status = RowFailed
+	r.Error = reason
+}
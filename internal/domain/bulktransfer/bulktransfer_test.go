@@ -0,0 +1,60 @@
+package bulktransfer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+func TestBatchSummary(t *testing.T) {
+	batch := &Batch{
+		ID:     7,
+		Status: StatusPreview,
+		Rows: []*Row{
+			{Amount: 100000, Status: RowValid},
+			{Amount: 200000, Status: RowValid},
+			{Amount: 300000, Status: RowInvalid},
+		},
+	}
+
+	assert.Equal(t, "BULK-7", batch.Reference())
+	assert.Equal(t, 2, batch.ValidRows())
+	assert.Equal(t, intrabank.Money(300000), batch.TotalAmount())
+	assert.Equal(t, 1, batch.Failed())
+	assert.True(t, batch.Confirmable())
+
+	batch.Rows[0].Succeed("REF001", 2500)
+	batch.Rows[1].Fail("Your balance is not enough for this transfer.")
+	now := time.Now()
+	batch.Complete(now)
+
+	assert.Equal(t, 1, batch.Succeeded())
+	assert.Equal(t, 2, batch.Failed())
+	assert.Equal(t, intrabank.Money(300000), batch.TotalAmount())
+	assert.True(t, batch.IsCompleted())
+	assert.Equal(t, now, batch.CompletedAt)
+	assert.False(t, batch.Confirmable())
+}
+
+func TestBatchConfirmable_NoValidRows(t *testing.T) {
+	batch := &Batch{
+		Status: StatusPreview,
+		Rows:   []*Row{{Amount: 100000, Status: RowInvalid}},
+	}
+
+	assert.False(t, batch.Confirmable())
+}
+
+func TestRowSequence(t *testing.T) {
+	row := &Row{ID: 3, DestinationAccount: "001001234567892", Amount: 100000}
+
+	assert.Equal(t, &intrabank.Sequence{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Channel:            "BULK",
+	}, row.Sequence("001001234567891"))
+	assert.Equal(t, "internal:bulk-7-3", row.IdempotencyKey(7))
+}
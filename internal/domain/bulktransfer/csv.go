@@ -0,0 +1,147 @@
+package bulktransfer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+const (
+	// MaxRows is the maximum number of transfers in one file.
+	MaxRows = 500
+	// maxNoteLength is the maximum length of the note of a row.
+	maxNoteLength = 100
+	// maxAccountLength is the maximum length of a destination account number.
+	maxAccountLength = 20
+)
+
+// resultHeader is the header of the result file.
+var resultHeader = []string{
+	"line", "destination_account", "destination_name", "amount", "fee",
+	"note", "status", "transaction_reference", "error",
+}
+
+// ParseRows reads the rows of a CSV file with the destination account, amount and an optional note.
+// A header line starting with destination_account is skipped. A malformed row is returned
+// as an invalid row, so its error can be shown in the preview.
+// Returns ErrInvalidFile if the file is not a CSV file, ErrEmptyFile if it has no rows
+// and ErrTooManyRows if it has more than MaxRows rows.
+func ParseRows(r io.Reader) ([]*Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []*Row
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidFile
+		}
+		if first && isHeader(record) {
+			continue
+		}
+		if isBlank(record) {
+			continue
+		}
+		if len(rows) == MaxRows {
+			return nil, ErrTooManyRows
+		}
+		// The reader skips the empty lines, so the line is taken from the record position.
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, parseRow(line, record))
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrEmptyFile
+	}
+	return rows, nil
+}
+
+// WriteResult writes the outcome of every row of the batch as a CSV file.
+func WriteResult(w io.Writer, batch *Batch) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(resultHeader); err != nil {
+		return err
+	}
+	for _, row := range batch.Rows {
+		err := writer.Write([]string{
+			strconv.Itoa(row.Line),
+			row.DestinationAccount,
+			row.DestinationName,
+			row.Amount.String(),
+			row.Fee.String(),
+			row.Note,
+			row.Status,
+			row.TransactionReference,
+			row.Error,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func parseRow(line int, record []string) *Row {
+	row := &Row{Line: line}
+	if len(record) < 2 || len(record) > 3 {
+		row.Invalidate("The row must have the destination account, the amount and an optional note.")
+		return row
+	}
+
+	row.DestinationAccount = strings.TrimSpace(record[0])
+	if len(record) == 3 {
+		row.Note = strings.TrimSpace(record[2])
+	}
+
+	amount, err := strconv.ParseInt(strings.TrimSpace(record[1]), 10, 64)
+	if err == nil {
+		row.Amount = intrabank.Money(amount)
+	}
+
+	switch {
+	case !validAccount(row.DestinationAccount):
+		row.Invalidate("The destination account must be a number.")
+	case err != nil || amount <= 0:
+		row.Invalidate("The amount must be a positive whole number.")
+	case len(row.Note) > maxNoteLength:
+		row.Invalidate("The note is too long.")
+	default:
+		row.Status = RowValid
+	}
+	return row
+}
+
+func isHeader(record []string) bool {
+	return strings.EqualFold(strings.TrimSpace(record[0]), "destination_account")
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func validAccount(account string) bool {
+	if account == "" || len(account) > maxAccountLength {
+		return false
+	}
+	for _, c := range account {
+		if !unicode.IsDigit(c) {
+			return false
+		}
+	}
+	return true
+}
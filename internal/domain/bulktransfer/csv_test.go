@@ -0,0 +1,101 @@
+package bulktransfer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRows(t *testing.T) {
+	file := strings.NewReader(`destination_account,amount,note
+001001234567892,100000,Salary March
+001001234567893, 250000
+
+abc,100000,Salary March
+001001234567894,-5,Salary March
+001001234567895,1.5
+001001234567896
+`)
+
+	rows, err := ParseRows(file)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Row{
+		{Line: 2, DestinationAccount: "001001234567892", Amount: 100000, Note: "Salary March", Status: RowValid},
+		{Line: 3, DestinationAccount: "001001234567893", Amount: 250000, Status: RowValid},
+		{Line: 5, DestinationAccount: "abc", Amount: 100000, Note: "Salary March", Status: RowInvalid,
+			Error: "The destination account must be a number."},
+		{Line: 6, DestinationAccount: "001001234567894", Amount: -5, Note: "Salary March", Status: RowInvalid,
+			Error: "The amount must be a positive whole number."},
+		{Line: 7, DestinationAccount: "001001234567895", Status: RowInvalid,
+			Error: "The amount must be a positive whole number."},
+		{Line: 8, Status: RowInvalid,
+			Error: "The row must have the destination account, the amount and an optional note."},
+	}, rows)
+}
+
+func TestParseRows_WithoutHeader(t *testing.T) {
+	rows, err := ParseRows(strings.NewReader("001001234567892,100000,Salary\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Row{
+		{Line: 1, DestinationAccount: "001001234567892", Amount: 100000, Note: "Salary", Status: RowValid},
+	}, rows)
+}
+
+func TestParseRows_NoteTooLong(t *testing.T) {
+	rows, err := ParseRows(strings.NewReader("001001234567892,100000," + strings.Repeat("a", 101) + "\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, RowInvalid, rows[0].Status)
+	assert.Equal(t, "The note is too long.", rows[0].Error)
+}
+
+func TestParseRows_EmptyFile(t *testing.T) {
+	rows, err := ParseRows(strings.NewReader("destination_account,amount,note\n\n"))
+
+	assert.Nil(t, rows)
+	assert.ErrorIs(t, err, ErrEmptyFile)
+}
+
+func TestParseRows_TooManyRows(t *testing.T) {
+	var file strings.Builder
+	for i := 0; i <= MaxRows; i++ {
+		fmt.Fprintf(&file, "001001234567892,%d\n", i+1)
+	}
+
+	rows, err := ParseRows(strings.NewReader(file.String()))
+
+	assert.Nil(t, rows)
+	assert.ErrorIs(t, err, ErrTooManyRows)
+}
+
+func TestParseRows_InvalidFile(t *testing.T) {
+	rows, err := ParseRows(strings.NewReader("001001234567892,\"100000\n"))
+
+	assert.Nil(t, rows)
+	assert.ErrorIs(t, err, ErrInvalidFile)
+}
+
+func TestWriteResult(t *testing.T) {
+	batch := &Batch{
+		Rows: []*Row{
+			{Line: 2, DestinationAccount: "001001234567892", DestinationName: "Taylor Swift", Amount: 100000, Fee: 2500,
+				Note: "Salary", Status: RowSuccess, TransactionReference: "REF001"},
+			{Line: 3, DestinationAccount: "001001234567893", Amount: 250000, Note: "Salary, bonus",
+				Status: RowFailed, Error: "Your balance is not enough for this transfer."},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := WriteResult(&buf, batch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `line,destination_account,destination_name,amount,fee,note,status,transaction_reference,error
+2,001001234567892,Taylor Swift,100000,2500,Salary,SUCCESS,REF001,
+3,001001234567893,,250000,0,"Salary, bonus",FAILED,,Your balance is not enough for this transfer.
+`, buf.String())
+}
@@ -0,0 +1,29 @@
+package bulktransfer
+
+import "errors"
+
+var (
+	// ErrGeneral indicates a general error.
+	ErrGeneral = errors.New("something went wrong")
+
+	// ErrUnauthenticatedUser indicates that the user is not authenticated.
+	ErrUnauthenticatedUser = errors.New("unauthenticated user")
+
+	// ErrInvalidFile is returned when the uploaded file cannot be read as a CSV file.
+	ErrInvalidFile = errors.New("invalid bulk transfer file")
+
+	// ErrEmptyFile is returned when the uploaded file has no transfers.
+	ErrEmptyFile = errors.New("empty bulk transfer file")
+
+	// ErrTooManyRows is returned when the uploaded file has more than MaxRows transfers.
+	ErrTooManyRows = errors.New("too many bulk transfer rows")
+
+	// ErrBatchNotFound is returned when the requested batch cannot be found.
+	ErrBatchNotFound = errors.New("bulk transfer not found")
+
+	// ErrBatchNotConfirmable is returned when the batch is no longer a preview or has no valid rows.
+	ErrBatchNotConfirmable = errors.New("bulk transfer not confirmable")
+
+	// ErrBatchNotCompleted is returned when the result of a batch is requested before it has completed.
+	ErrBatchNotCompleted = errors.New("bulk transfer not completed")
+)
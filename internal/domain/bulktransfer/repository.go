@@ -0,0 +1,40 @@
+package bulktransfer
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines methods for managing bulk transfer persistence.
+type Repository interface {
+	// Insert inserts a batch together with its rows.
+	// Returns an error if the operation fails.
+	Insert(ctx context.Context, batch *Batch) error
+
+	// Get retrieves the user's batch by its ID, with its rows ordered by line.
+	// Returns ErrBatchNotFound if the user has no batch with the ID.
+	Get(ctx context.Context, userID int, id int64) (*Batch, error)
+
+	// Queue atomically moves the user's batch from the preview to the queued status.
+	// Returns ErrBatchNotConfirmable if the batch is no longer a preview.
+	Queue(ctx context.Context, userID int, id int64) error
+
+	// AcquireQueued atomically moves at most limit queued batches to the processing status
+	// and leases them until leaseUntil, so concurrent workers never run the same batch.
+	// Processing batches whose lease has expired at now, e.g. after a crash, are acquired again.
+	// Returns the acquired batches together with their users and rows.
+	AcquireQueued(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*Batch, error)
+
+	// SetRowSequence stores the sequence number and the fee of the row's payment,
+	// so a batch acquired again resumes the same payment.
+	// Returns an error if the operation fails.
+	SetRowSequence(ctx context.Context, row *Row) error
+
+	// UpdateRow stores the outcome of the row's transfer.
+	// Returns an error if the operation fails.
+	UpdateRow(ctx context.Context, row *Row) error
+
+	// Finish stores the status of the processed batch.
+	// Returns an error if the operation fails.
+	Finish(ctx context.Context, batch *Batch) error
+}
@@ -0,0 +1,394 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package bulktransfer
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// AcquireQueued provides a mock function with given fields: ctx, now, leaseUntil, limit
+func (_m *MockRepository) AcquireQueued(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*Batch, error) {
+	ret := _m.Called(ctx, now, leaseUntil, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireQueued")
+	}
+
+	var r0 []*Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]*Batch, error)); ok {
+		return rf(ctx, now, leaseUntil, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []*Batch); ok {
+		r0 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Batch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_AcquireQueued_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireQueued'
+type MockRepository_AcquireQueued_Call struct {
+	*mock.Call
+}
+
+// AcquireQueued is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - leaseUntil time.Time
+//   - limit int
+func (_e *MockRepository_Expecter) AcquireQueued(ctx interface{}, now interface{}, leaseUntil interface{}, limit interface{}) *MockRepository_AcquireQueued_Call {
+	return &MockRepository_AcquireQueued_Call{Call: _e.mock.On("AcquireQueued", ctx, now, leaseUntil, limit)}
+}
+
+func (_c *MockRepository_AcquireQueued_Call) Run(run func(ctx context.Context, now time.Time, leaseUntil time.Time, limit int)) *MockRepository_AcquireQueued_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_AcquireQueued_Call) Return(_a0 []*Batch, _a1 error) *MockRepository_AcquireQueued_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_AcquireQueued_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, int) ([]*Batch, error)) *MockRepository_AcquireQueued_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Finish provides a mock function with given fields: ctx, batch
+func (_m *MockRepository) Finish(ctx context.Context, batch *Batch) error {
+	ret := _m.Called(ctx, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Finish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Batch) error); ok {
+		r0 = rf(ctx, batch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Finish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Finish'
+type MockRepository_Finish_Call struct {
+	*mock.Call
+}
+
+// Finish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batch *Batch
+func (_e *MockRepository_Expecter) Finish(ctx interface{}, batch interface{}) *MockRepository_Finish_Call {
+	return &MockRepository_Finish_Call{Call: _e.mock.On("Finish", ctx, batch)}
+}
+
+func (_c *MockRepository_Finish_Call) Run(run func(ctx context.Context, batch *Batch)) *MockRepository_Finish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Batch))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Finish_Call) Return(_a0 error) *MockRepository_Finish_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Finish_Call) RunAndReturn(run func(context.Context, *Batch) error) *MockRepository_Finish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, userID, id
+func (_m *MockRepository) Get(ctx context.Context, userID int, id int64) (*Batch, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) (*Batch, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) *Batch); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Batch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int64
+func (_e *MockRepository_Expecter) Get(ctx interface{}, userID interface{}, id interface{}) *MockRepository_Get_Call {
+	return &MockRepository_Get_Call{Call: _e.mock.On("Get", ctx, userID, id)}
+}
+
+func (_c *MockRepository_Get_Call) Run(run func(ctx context.Context, userID int, id int64)) *MockRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Get_Call) Return(_a0 *Batch, _a1 error) *MockRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_Get_Call) RunAndReturn(run func(context.Context, int, int64) (*Batch, error)) *MockRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function with given fields: ctx, batch
+func (_m *MockRepository) Insert(ctx context.Context, batch *Batch) error {
+	ret := _m.Called(ctx, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Batch) error); ok {
+		r0 = rf(ctx, batch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type MockRepository_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batch *Batch
+func (_e *MockRepository_Expecter) Insert(ctx interface{}, batch interface{}) *MockRepository_Insert_Call {
+	return &MockRepository_Insert_Call{Call: _e.mock.On("Insert", ctx, batch)}
+}
+
+func (_c *MockRepository_Insert_Call) Run(run func(ctx context.Context, batch *Batch)) *MockRepository_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Batch))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Insert_Call) Return(_a0 error) *MockRepository_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Insert_Call) RunAndReturn(run func(context.Context, *Batch) error) *MockRepository_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Queue provides a mock function with given fields: ctx, userID, id
+func (_m *MockRepository) Queue(ctx context.Context, userID int, id int64) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Queue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Queue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Queue'
+type MockRepository_Queue_Call struct {
+	*mock.Call
+}
+
+// Queue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int64
+func (_e *MockRepository_Expecter) Queue(ctx interface{}, userID interface{}, id interface{}) *MockRepository_Queue_Call {
+	return &MockRepository_Queue_Call{Call: _e.mock.On("Queue", ctx, userID, id)}
+}
+
+func (_c *MockRepository_Queue_Call) Run(run func(ctx context.Context, userID int, id int64)) *MockRepository_Queue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Queue_Call) Return(_a0 error) *MockRepository_Queue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Queue_Call) RunAndReturn(run func(context.Context, int, int64) error) *MockRepository_Queue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRowSequence provides a mock function with given fields: ctx, row
+func (_m *MockRepository) SetRowSequence(ctx context.Context, row *Row) error {
+	ret := _m.Called(ctx, row)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRowSequence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Row) error); ok {
+		r0 = rf(ctx, row)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetRowSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRowSequence'
+type MockRepository_SetRowSequence_Call struct {
+	*mock.Call
+}
+
+// SetRowSequence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - row *Row
+func (_e *MockRepository_Expecter) SetRowSequence(ctx interface{}, row interface{}) *MockRepository_SetRowSequence_Call {
+	return &MockRepository_SetRowSequence_Call{Call: _e.mock.On("SetRowSequence", ctx, row)}
+}
+
+func (_c *MockRepository_SetRowSequence_Call) Run(run func(ctx context.Context, row *Row)) *MockRepository_SetRowSequence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Row))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetRowSequence_Call) Return(_a0 error) *MockRepository_SetRowSequence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetRowSequence_Call) RunAndReturn(run func(context.Context, *Row) error) *MockRepository_SetRowSequence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRow provides a mock function with given fields: ctx, row
+func (_m *MockRepository) UpdateRow(ctx context.Context, row *Row) error {
+	ret := _m.Called(ctx, row)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Row) error); ok {
+		r0 = rf(ctx, row)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdateRow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRow'
+type MockRepository_UpdateRow_Call struct {
+	*mock.Call
+}
+
+// UpdateRow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - row *Row
+func (_e *MockRepository_Expecter) UpdateRow(ctx interface{}, row interface{}) *MockRepository_UpdateRow_Call {
+	return &MockRepository_UpdateRow_Call{Call: _e.mock.On("UpdateRow", ctx, row)}
+}
+
+func (_c *MockRepository_UpdateRow_Call) Run(run func(ctx context.Context, row *Row)) *MockRepository_UpdateRow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Row))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateRow_Call) Return(_a0 error) *MockRepository_UpdateRow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdateRow_Call) RunAndReturn(run func(context.Context, *Row) error) *MockRepository_UpdateRow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,318 @@
+package bulktransfer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+const (
+	domainName        = "bulk_transfer"
+	queuedBatchSize   = 5
+	leaseDuration     = 10 * time.Minute
+	batchNotFoundMsg  = "Bulk transfer not found."
+	invalidFileMsg    = "The file must be a CSV file with the destination account, amount and note columns."
+	batchNotReadyMsg  = "The result is available once every transfer of the bulk transfer has been processed."
+	notConfirmableMsg = "This bulk transfer cannot be confirmed."
+)
+
+// Service handles the bulk transfers uploaded as CSV files.
+type Service struct {
+	log        *logger.Logger
+	repo       Repository
+	transferer Transferer
+	authorizer TransactionAuthorizer
+}
+
+// NewService creates a new instance of Service.
+func NewService(
+	log *logger.Logger,
+	repo Repository,
+	transferer Transferer,
+	authorizer TransactionAuthorizer,
+) *Service {
+	return &Service{
+		log:        log,
+		repo:       repo,
+		transferer: transferer,
+		authorizer: authorizer,
+	}
+}
+
+// Preview validates every row of the uploaded file against the intrabank limits and accounts
+// and stores the batch with the per-row errors, so the user can review it before confirming.
+// No sequence is created until the rows are paid.
+// The daily limit is checked per row, the cumulative limit is enforced when the rows are paid.
+func (s *Service) Preview(ctx context.Context, sourceAccount, fileName string, file io.Reader) (*Batch, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Preview").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	rows, err := ParseRows(file)
+	switch {
+	case errors.Is(err, ErrInvalidFile):
+		s.log.DomainUsecase(domainName, "Preview").Error(err)
+		return nil, pkgerror.New(codes.BadRequest, err).SetMsg(invalidFileMsg)
+	case errors.Is(err, ErrEmptyFile):
+		s.log.DomainUsecase(domainName, "Preview").Error(err)
+		return nil, pkgerror.New(codes.BadRequest, err).SetMsg("The file has no transfers.")
+	case errors.Is(err, ErrTooManyRows):
+		s.log.DomainUsecase(domainName, "Preview").Error(err)
+		return nil, pkgerror.New(codes.BadRequest, err).SetMsg(fmt.Sprintf("The file can have at most %d transfers.", MaxRows))
+	case err != nil:
+		s.log.DomainUsecase(domainName, "Preview").Errorf("ParseRows: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	for _, row := range rows {
+		if row.Status == RowInvalid {
+			continue
+		}
+		sequence, err := s.transferer.Validate(ctx, row.Sequence(sourceAccount))
+		if err != nil {
+			// An internal error is not caused by the row, so the whole preview fails.
+			if isInternal(err) {
+				s.log.DomainUsecase(domainName, "Preview").Errorf("line (%v) Validate: %v", row.Line, err)
+				return nil, err
+			}
+			row.Invalidate(pkgerror.Message(err))
+			continue
+		}
+		row.Validate(sequence.DestinationName, sequence.Fee)
+	}
+
+	batch := &Batch{
+		User: &User{
+			ID:    user.ID,
+			CIF:   user.CIF,
+			Name:  user.Name,
+			Email: user.Email,
+		},
+		SourceAccount: sourceAccount,
+		FileName:      fileName,
+		Status:        StatusPreview,
+		Rows:          rows,
+	}
+
+	err = s.repo.Insert(ctx, batch)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Preview").Errorf("Insert: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return batch, nil
+}
+
+// Confirm queues the batch for the worker once the user has verified it
+// with the transaction OTP bound to the batch reference.
+// The rows are then paid without a further OTP.
+func (s *Service) Confirm(ctx context.Context, id int64, otpID int, otpCode string) (*Batch, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Confirm").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	batch, err := s.get(ctx, "Confirm", user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !batch.Confirmable() {
+		s.log.DomainUsecase(domainName, "Confirm").Errorf("batch (%v): %v", batch.ID, ErrBatchNotConfirmable)
+		return nil, pkgerror.New(codes.BadRequest, ErrBatchNotConfirmable).SetMsg(notConfirmableMsg)
+	}
+
+	if otpID == 0 || otpCode == "" {
+		s.log.DomainUsecase(domainName, "Confirm").Errorf("batch (%v): %v", batch.ID, intrabank.ErrOTPRequired)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrOTPRequired).
+			SetMsg("Please verify this bulk transfer with the OTP sent to you.")
+	}
+	if err := s.authorizer.VerifyTransaction(ctx, otpID, otpCode, batch.Reference()); err != nil {
+		s.log.DomainUsecase(domainName, "Confirm").Errorf("VerifyTransaction: %v", err)
+		return nil, err
+	}
+
+	// A concurrent confirmation may have queued the batch between the read and the update.
+	err = s.repo.Queue(ctx, user.ID, batch.ID)
+	if errors.Is(err, ErrBatchNotConfirmable) {
+		s.log.DomainUsecase(domainName, "Confirm").Errorf("Queue: %v", err)
+		return nil, pkgerror.New(codes.BadRequest, ErrBatchNotConfirmable).SetMsg(notConfirmableMsg)
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Confirm").Errorf("Queue: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	batch.Status = StatusQueued
+	return batch, nil
+}
+
+// Get returns the authenticated user's batch with the status of every row.
+func (s *Service) Get(ctx context.Context, id int64) (*Batch, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Get").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+	return s.get(ctx, "Get", user.ID, id)
+}
+
+// Result returns the result file of the authenticated user's completed batch.
+func (s *Service) Result(ctx context.Context, id int64) ([]byte, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Result").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	batch, err := s.get(ctx, "Result", user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !batch.IsCompleted() {
+		s.log.DomainUsecase(domainName, "Result").Errorf("batch (%v): %v", batch.ID, ErrBatchNotCompleted)
+		return nil, pkgerror.New(codes.BadRequest, ErrBatchNotCompleted).SetMsg(batchNotReadyMsg)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteResult(&buf, batch); err != nil {
+		s.log.DomainUsecase(domainName, "Result").Errorf("WriteResult: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return buf.Bytes(), nil
+}
+
+// RunQueued transfers the rows of the confirmed batches.
+// It is called periodically by the background worker.
+func (s *Service) RunQueued(ctx context.Context) error {
+	now := time.Now()
+	batches, err := s.repo.AcquireQueued(ctx, now, now.Add(leaseDuration), queuedBatchSize)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "RunQueued").Errorf("AcquireQueued: %v", err)
+		return err
+	}
+	for _, batch := range batches {
+		s.run(ctx, batch)
+	}
+	return nil
+}
+
+func (s *Service) get(ctx context.Context, usecase string, userID int, id int64) (*Batch, error) {
+	batch, err := s.repo.Get(ctx, userID, id)
+	if errors.Is(err, ErrBatchNotFound) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Get: %v", err)
+		return nil, pkgerror.New(codes.NotFound, ErrBatchNotFound).SetMsg(batchNotFoundMsg)
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Get: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return batch, nil
+}
+
+// run transfers the valid rows of the batch as its owner, one transaction per row.
+// The inquiry is made again, because the accounts and limits may have changed since the preview.
+// A row whose payment outcome is not known yet keeps the batch processing,
+// and the batch is resumed once its lease has expired.
+func (s *Service) run(ctx context.Context, batch *Batch) {
+	userCtx := ctxt.ContextWithUser(ctx, &ctxt.User{
+		ID:    batch.User.ID,
+		CIF:   batch.User.CIF,
+		Name:  batch.User.Name,
+		Email: batch.User.Email,
+	})
+
+	pending := false
+	for _, row := range batch.Rows {
+		if row.Status != RowValid {
+			continue
+		}
+
+		transaction, err := s.transfer(userCtx, batch, row)
+		switch {
+		case errors.Is(err, intrabank.ErrPaymentInProgress) || errors.Is(err, intrabank.ErrPaymentPending):
+			s.log.DomainUsecase(domainName, "RunQueued").Errorf("batch (%v) line (%v): %v", batch.ID, row.Line, err)
+			pending = true
+			continue
+		case err != nil:
+			s.log.DomainUsecase(domainName, "RunQueued").Errorf("batch (%v) line (%v): %v", batch.ID, row.Line, err)
+			row.Fail(pkgerror.Message(err))
+		default:
+			row.Succeed(transaction.TransactionReference, row.Fee)
+		}
+
+		if err := s.repo.UpdateRow(ctx, row); err != nil {
+			s.log.DomainUsecase(domainName, "RunQueued").Errorf("batch (%v) line (%v) UpdateRow: %v", batch.ID, row.Line, err)
+		}
+	}
+	if pending {
+		return
+	}
+
+	batch.Complete(time.Now())
+	if err := s.repo.Finish(ctx, batch); err != nil {
+		s.log.DomainUsecase(domainName, "RunQueued").Errorf("batch (%v) Finish: %v", batch.ID, err)
+	}
+}
+
+// transfer runs the intrabank inquiry and payment of the row.
+// A row of a batch acquired again after its lease has expired resumes the payment of its stored sequence,
+// which returns the outcome of a payment already made instead of moving the money twice.
+func (s *Service) transfer(ctx context.Context, batch *Batch, row *Row) (*intrabank.Transaction, error) {
+	if row.SequenceNumber != "" {
+		transaction, err := s.pay(ctx, batch, row)
+		if !errors.Is(err, intrabank.ErrSequenceExpired) {
+			return transaction, err
+		}
+		// An expired sequence has never been paid, so the row is paid with a new one.
+		s.log.DomainUsecase(domainName, "RunQueued").Errorf("batch (%v) line (%v) DoPayment: %v", batch.ID, row.Line, err)
+	}
+
+	sequence, err := s.transferer.Inquiry(ctx, row.Sequence(batch.SourceAccount))
+	if err != nil {
+		return nil, err
+	}
+	row.Inquire(sequence.SequenceNumber, sequence.Fee)
+
+	// The sequence is stored before the payment, so a crash during the payment does not pay the row twice.
+	// Without it the row is left pending, so it is tried again once the lease of the batch has expired.
+	if err := s.repo.SetRowSequence(ctx, row); err != nil {
+		s.log.DomainUsecase(domainName, "RunQueued").Errorf("batch (%v) line (%v) SetRowSequence: %v", batch.ID, row.Line, err)
+		return nil, pkgerror.New(codes.Internal, intrabank.ErrPaymentPending)
+	}
+
+	return s.pay(ctx, batch, row)
+}
+
+// pay pays the sequence of the row.
+func (s *Service) pay(ctx context.Context, batch *Batch, row *Row) (*intrabank.Transaction, error) {
+	return s.transferer.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     row.SequenceNumber,
+		SourceAccount:      batch.SourceAccount,
+		DestinationAccount: row.DestinationAccount,
+		Amount:             row.Amount,
+		IdempotencyKey:     row.IdempotencyKey(batch.ID),
+		Preauthorized:      true,
+	})
+}
+
+// isInternal checks if the error is an internal error rather than a rejection of the transfer.
+func isInternal(err error) bool {
+	var e *pkgerror.Error
+	return !errors.As(err, &e) || e.Code == codes.Internal
+}
@@ -0,0 +1,481 @@
+package bulktransfer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+func TestPreviewSuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+		file = strings.NewReader("destination_account,amount,note\n" +
+			"001001234567892,100000,Salary\n" +
+			"001001234567893,100000,Salary\n" +
+			"abc,100000,Salary\n")
+	)
+
+	transfererMock.EXPECT().Validate(mock.Anything, &intrabank.Sequence{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Channel:            "BULK",
+		Note:               "Salary",
+	}).Return(&intrabank.Sequence{
+		DestinationName: "Taylor Swift",
+		Fee:             2500,
+	}, nil)
+	transfererMock.EXPECT().Validate(mock.Anything, &intrabank.Sequence{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567893",
+		Amount:             100000,
+		Channel:            "BULK",
+		Note:               "Salary",
+	}).Return(nil, pkgerror.New(codes.BadRequest, intrabank.ErrDestinationAccountInactive))
+
+	repoMock.EXPECT().Insert(mock.Anything, mock.Anything).
+		Return(nil)
+
+	batch, err := svc.Preview(ctx, "001001234567891", "salary.csv", file)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Batch{
+		User:          &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com"},
+		SourceAccount: "001001234567891",
+		FileName:      "salary.csv",
+		Status:        StatusPreview,
+		Rows: []*Row{
+			{Line: 2, DestinationAccount: "001001234567892", DestinationName: "Taylor Swift", Amount: 100000, Fee: 2500,
+				Note: "Salary", Status: RowValid},
+			{Line: 3, DestinationAccount: "001001234567893", Amount: 100000, Note: "Salary", Status: RowInvalid,
+				Error: pkgerror.DefaultMsg},
+			{Line: 4, DestinationAccount: "abc", Amount: 100000, Note: "Salary", Status: RowInvalid,
+				Error: "The destination account must be a number."},
+		},
+	}, batch)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestPreviewFailed_GetUserFromContextFailed(t *testing.T) {
+	var (
+		svc = NewService(logger.New(), NewMockRepository(t), NewMockTransferer(t), NewMockTransactionAuthorizer(t))
+	)
+
+	batch, err := svc.Preview(context.Background(), "001001234567891", "salary.csv", strings.NewReader(""))
+
+	assert.Nil(t, batch)
+	assert.Equal(t, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+		SetMsg("Please login to continue."), err)
+}
+
+func TestPreviewFailed_EmptyFile(t *testing.T) {
+	var (
+		svc = NewService(logger.New(), NewMockRepository(t), NewMockTransferer(t), NewMockTransactionAuthorizer(t))
+		ctx = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	batch, err := svc.Preview(ctx, "001001234567891", "salary.csv", strings.NewReader("destination_account,amount,note\n"))
+
+	assert.Nil(t, batch)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrEmptyFile).
+		SetMsg("The file has no transfers."), err)
+}
+
+func TestPreviewFailed_ValidateInternalError(t *testing.T) {
+	var (
+		transfererMock = NewMockTransferer(t)
+		svc            = NewService(logger.New(), NewMockRepository(t), transfererMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	transfererMock.EXPECT().Validate(mock.Anything, mock.Anything).
+		Return(nil, pkgerror.New(codes.Internal, intrabank.ErrGeneral))
+
+	batch, err := svc.Preview(ctx, "001001234567891", "salary.csv", strings.NewReader("001001234567892,100000\n"))
+
+	assert.Nil(t, batch)
+	assert.Equal(t, pkgerror.New(codes.Internal, intrabank.ErrGeneral), err)
+
+	transfererMock.AssertExpectations(t)
+}
+
+func TestPreviewFailed_InsertFailed(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	transfererMock.EXPECT().Validate(mock.Anything, mock.Anything).
+		Return(&intrabank.Sequence{DestinationName: "Taylor Swift"}, nil)
+
+	repoMock.EXPECT().Insert(mock.Anything, mock.Anything).
+		Return(errors.New("unexpected error"))
+
+	batch, err := svc.Preview(ctx, "001001234567891", "salary.csv", strings.NewReader("001001234567892,100000\n"))
+
+	assert.Nil(t, batch)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestConfirmSuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		authorizerMock = NewMockTransactionAuthorizer(t)
+		svc            = NewService(logger.New(), repoMock, NewMockTransferer(t), authorizerMock)
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(7)).
+		Return(&Batch{ID: 7, Status: StatusPreview, Rows: []*Row{{Amount: 100000, Status: RowValid}}}, nil)
+	repoMock.EXPECT().Queue(mock.Anything, 123, int64(7)).
+		Return(nil)
+
+	authorizerMock.EXPECT().VerifyTransaction(mock.Anything, 1, "123456", "BULK-7").
+		Return(nil)
+
+	batch, err := svc.Confirm(ctx, 7, 1, "123456")
+
+	assert.Nil(t, err)
+	assert.Equal(t, StatusQueued, batch.Status)
+
+	repoMock.AssertExpectations(t)
+	authorizerMock.AssertExpectations(t)
+}
+
+func TestConfirmFailed_OTPRequired(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockTransferer(t), NewMockTransactionAuthorizer(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(7)).
+		Return(&Batch{ID: 7, Status: StatusPreview, Rows: []*Row{{Amount: 100000, Status: RowValid}}}, nil)
+
+	batch, err := svc.Confirm(ctx, 7, 0, "")
+
+	assert.Nil(t, batch)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, intrabank.ErrOTPRequired).
+		SetMsg("Please verify this bulk transfer with the OTP sent to you."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestConfirmFailed_InvalidOTP(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		authorizerMock = NewMockTransactionAuthorizer(t)
+		svc            = NewService(logger.New(), repoMock, NewMockTransferer(t), authorizerMock)
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+		otpErr         = pkgerror.New(codes.BadRequest, errors.New("invalid otp")).SetMsg("Invalid OTP.")
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(7)).
+		Return(&Batch{ID: 7, Status: StatusPreview, Rows: []*Row{{Amount: 100000, Status: RowValid}}}, nil)
+
+	authorizerMock.EXPECT().VerifyTransaction(mock.Anything, 1, "000000", "BULK-7").
+		Return(otpErr)
+
+	batch, err := svc.Confirm(ctx, 7, 1, "000000")
+
+	assert.Nil(t, batch)
+	assert.Equal(t, otpErr, err)
+
+	repoMock.AssertExpectations(t)
+	authorizerMock.AssertExpectations(t)
+}
+
+func TestConfirmFailed_NotConfirmable(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockTransferer(t), NewMockTransactionAuthorizer(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(7)).
+		Return(&Batch{ID: 7, Status: StatusQueued, Rows: []*Row{{Amount: 100000, Status: RowValid}}}, nil)
+
+	batch, err := svc.Confirm(ctx, 7, 1, "123456")
+
+	assert.Nil(t, batch)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrBatchNotConfirmable).
+		SetMsg("This bulk transfer cannot be confirmed."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestConfirmFailed_QueuedConcurrently(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		authorizerMock = NewMockTransactionAuthorizer(t)
+		svc            = NewService(logger.New(), repoMock, NewMockTransferer(t), authorizerMock)
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(7)).
+		Return(&Batch{ID: 7, Status: StatusPreview, Rows: []*Row{{Amount: 100000, Status: RowValid}}}, nil)
+	repoMock.EXPECT().Queue(mock.Anything, 123, int64(7)).
+		Return(ErrBatchNotConfirmable)
+
+	authorizerMock.EXPECT().VerifyTransaction(mock.Anything, 1, "123456", "BULK-7").
+		Return(nil)
+
+	batch, err := svc.Confirm(ctx, 7, 1, "123456")
+
+	assert.Nil(t, batch)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrBatchNotConfirmable).
+		SetMsg("This bulk transfer cannot be confirmed."), err)
+
+	repoMock.AssertExpectations(t)
+	authorizerMock.AssertExpectations(t)
+}
+
+func TestGetFailed_BatchNotFound(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockTransferer(t), NewMockTransactionAuthorizer(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(7)).
+		Return(nil, ErrBatchNotFound)
+
+	batch, err := svc.Get(ctx, 7)
+
+	assert.Nil(t, batch)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrBatchNotFound).
+		SetMsg("Bulk transfer not found."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestResultSuccess(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockTransferer(t), NewMockTransactionAuthorizer(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(7)).
+		Return(&Batch{ID: 7, Status: StatusCompleted, Rows: []*Row{
+			{Line: 1, DestinationAccount: "001001234567892", Amount: 100000, Status: RowSuccess, TransactionReference: "REF001"},
+		}}, nil)
+
+	result, err := svc.Result(ctx, 7)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "line,destination_account,destination_name,amount,fee,note,status,transaction_reference,error\n"+
+		"1,001001234567892,,100000,0,,SUCCESS,REF001,\n", string(result))
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestResultFailed_BatchNotCompleted(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockTransferer(t), NewMockTransactionAuthorizer(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(7)).
+		Return(&Batch{ID: 7, Status: StatusProcessing}, nil)
+
+	result, err := svc.Result(ctx, 7)
+
+	assert.Nil(t, result)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrBatchNotCompleted).
+		SetMsg("The result is available once every transfer of the bulk transfer has been processed."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestRunQueuedSuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, NewMockTransactionAuthorizer(t))
+		batch          = &Batch{
+			ID:            7,
+			User:          &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com"},
+			SourceAccount: "001001234567891",
+			Status:        StatusProcessing,
+			Rows: []*Row{
+				{ID: 1, Line: 1, DestinationAccount: "001001234567892", Amount: 100000, Note: "Salary", Status: RowValid},
+				{ID: 2, Line: 2, DestinationAccount: "001001234567893", Amount: 100000, Status: RowValid},
+				{ID: 3, Line: 3, DestinationAccount: "abc", Amount: 100000, Status: RowInvalid},
+			},
+		}
+	)
+
+	repoMock.EXPECT().AcquireQueued(mock.Anything, mock.Anything, mock.Anything, 5).
+		Return([]*Batch{batch}, nil)
+	repoMock.EXPECT().SetRowSequence(mock.Anything, mock.MatchedBy(func(row *Row) bool {
+		return row.ID == 1 && row.SequenceNumber == "123456" && row.Fee == 2500
+	})).Return(nil)
+	repoMock.EXPECT().UpdateRow(mock.Anything, mock.Anything).
+		Return(nil).Times(2)
+	repoMock.EXPECT().Finish(mock.Anything, mock.MatchedBy(func(batch *Batch) bool {
+		return batch.Status == StatusCompleted && !batch.CompletedAt.IsZero()
+	})).Return(nil)
+
+	transfererMock.EXPECT().Inquiry(mock.MatchedBy(func(ctx context.Context) bool {
+		user, ok := ctxt.UserFromContext(ctx)
+		return ok && user.ID == 123 && user.CIF == "1234567"
+	}), mock.MatchedBy(func(seq *intrabank.Sequence) bool {
+		return seq.DestinationAccount == "001001234567892" && seq.Note == "Salary"
+	})).Return(&intrabank.Sequence{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Fee:                2500,
+	}, nil)
+	transfererMock.EXPECT().Inquiry(mock.Anything, mock.MatchedBy(func(seq *intrabank.Sequence) bool {
+		return seq.DestinationAccount == "001001234567893"
+	})).Return(nil, pkgerror.New(codes.BadRequest, intrabank.ErrInsufficientBalance).
+		SetMsg("Your balance is not enough for this transfer."))
+	transfererMock.EXPECT().DoPayment(mock.Anything, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		IdempotencyKey:     "internal:bulk-7-1",
+		Preauthorized:      true,
+	}).Return(&intrabank.Transaction{TransactionReference: "REF001"}, nil)
+
+	err := svc.RunQueued(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Row{ID: 1, Line: 1, DestinationAccount: "001001234567892", Amount: 100000, Fee: 2500, Note: "Salary",
+		Status: RowSuccess, TransactionReference: "REF001", SequenceNumber: "123456"}, batch.Rows[0])
+	assert.Equal(t, &Row{ID: 2, Line: 2, DestinationAccount: "001001234567893", Amount: 100000,
+		Status: RowFailed, Error: "Your balance is not enough for this transfer."}, batch.Rows[1])
+	assert.Equal(t, RowInvalid, batch.Rows[2].Status)
+	assert.WithinDuration(t, time.Now(), batch.CompletedAt, time.Minute)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestRunQueuedSuccess_ResumeRow(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, NewMockTransactionAuthorizer(t))
+		batch          = &Batch{
+			ID:            7,
+			User:          &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com"},
+			SourceAccount: "001001234567891",
+			Status:        StatusProcessing,
+			Rows: []*Row{
+				{ID: 1, Line: 1, DestinationAccount: "001001234567892", Amount: 100000, Fee: 2500, Status: RowSuccess,
+					TransactionReference: "REF001", SequenceNumber: "123456"},
+				{ID: 2, Line: 2, DestinationAccount: "001001234567893", Amount: 100000, Fee: 2500, Status: RowValid,
+					SequenceNumber: "654321"},
+			},
+		}
+	)
+
+	repoMock.EXPECT().AcquireQueued(mock.Anything, mock.Anything, mock.Anything, 5).
+		Return([]*Batch{batch}, nil)
+	repoMock.EXPECT().UpdateRow(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().Finish(mock.Anything, mock.Anything).
+		Return(nil)
+
+	transfererMock.EXPECT().DoPayment(mock.Anything, &intrabank.PaymentInput{
+		SequenceNumber:     "654321",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567893",
+		Amount:             100000,
+		IdempotencyKey:     "internal:bulk-7-2",
+		Preauthorized:      true,
+	}).Return(&intrabank.Transaction{TransactionReference: "REF002"}, nil)
+
+	err := svc.RunQueued(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, RowSuccess, batch.Rows[1].Status)
+	assert.Equal(t, "REF002", batch.Rows[1].TransactionReference)
+	assert.Equal(t, StatusCompleted, batch.Status)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestRunQueuedSuccess_PaymentPending(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, NewMockTransactionAuthorizer(t))
+		batch          = &Batch{
+			ID:            7,
+			User:          &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com"},
+			SourceAccount: "001001234567891",
+			Status:        StatusProcessing,
+			Rows: []*Row{
+				{ID: 1, Line: 1, DestinationAccount: "001001234567892", Amount: 100000, Status: RowValid},
+			},
+		}
+	)
+
+	repoMock.EXPECT().AcquireQueued(mock.Anything, mock.Anything, mock.Anything, 5).
+		Return([]*Batch{batch}, nil)
+	repoMock.EXPECT().SetRowSequence(mock.Anything, mock.Anything).
+		Return(nil)
+
+	transfererMock.EXPECT().Inquiry(mock.Anything, mock.Anything).
+		Return(&intrabank.Sequence{SequenceNumber: "123456", Fee: 2500}, nil)
+	transfererMock.EXPECT().DoPayment(mock.Anything, mock.Anything).
+		Return(nil, pkgerror.New(codes.Internal, intrabank.ErrPaymentPending))
+
+	err := svc.RunQueued(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, RowValid, batch.Rows[0].Status)
+	assert.Equal(t, StatusProcessing, batch.Status)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestRunQueuedFailed_AcquireQueuedFailed(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockTransferer(t), NewMockTransactionAuthorizer(t))
+	)
+
+	repoMock.EXPECT().AcquireQueued(mock.Anything, mock.Anything, mock.Anything, 5).
+		Return(nil, errors.New("unexpected error"))
+
+	err := svc.RunQueued(context.Background())
+
+	assert.EqualError(t, err, "unexpected error")
+
+	repoMock.AssertExpectations(t)
+}
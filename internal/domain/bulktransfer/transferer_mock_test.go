@@ -0,0 +1,214 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package bulktransfer
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	intrabank "go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// MockTransferer is an autogenerated mock type for the Transferer type
+type MockTransferer struct {
+	mock.Mock
+}
+
+type MockTransferer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransferer) EXPECT() *MockTransferer_Expecter {
+	return &MockTransferer_Expecter{mock: &_m.Mock}
+}
+
+// DoPayment provides a mock function with given fields: ctx, in
+func (_m *MockTransferer) DoPayment(ctx context.Context, in *intrabank.PaymentInput) (*intrabank.Transaction, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DoPayment")
+	}
+
+	var r0 *intrabank.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.PaymentInput) (*intrabank.Transaction, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.PaymentInput) *intrabank.Transaction); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *intrabank.PaymentInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransferer_DoPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DoPayment'
+type MockTransferer_DoPayment_Call struct {
+	*mock.Call
+}
+
+// DoPayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *intrabank.PaymentInput
+func (_e *MockTransferer_Expecter) DoPayment(ctx interface{}, in interface{}) *MockTransferer_DoPayment_Call {
+	return &MockTransferer_DoPayment_Call{Call: _e.mock.On("DoPayment", ctx, in)}
+}
+
+func (_c *MockTransferer_DoPayment_Call) Run(run func(ctx context.Context, in *intrabank.PaymentInput)) *MockTransferer_DoPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*intrabank.PaymentInput))
+	})
+	return _c
+}
+
+func (_c *MockTransferer_DoPayment_Call) Return(_a0 *intrabank.Transaction, _a1 error) *MockTransferer_DoPayment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransferer_DoPayment_Call) RunAndReturn(run func(context.Context, *intrabank.PaymentInput) (*intrabank.Transaction, error)) *MockTransferer_DoPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Inquiry provides a mock function with given fields: ctx, seq
+func (_m *MockTransferer) Inquiry(ctx context.Context, seq *intrabank.Sequence) (*intrabank.Sequence, error) {
+	ret := _m.Called(ctx, seq)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Inquiry")
+	}
+
+	var r0 *intrabank.Sequence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.Sequence) (*intrabank.Sequence, error)); ok {
+		return rf(ctx, seq)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.Sequence) *intrabank.Sequence); ok {
+		r0 = rf(ctx, seq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.Sequence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *intrabank.Sequence) error); ok {
+		r1 = rf(ctx, seq)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransferer_Inquiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Inquiry'
+type MockTransferer_Inquiry_Call struct {
+	*mock.Call
+}
+
+// Inquiry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - seq *intrabank.Sequence
+func (_e *MockTransferer_Expecter) Inquiry(ctx interface{}, seq interface{}) *MockTransferer_Inquiry_Call {
+	return &MockTransferer_Inquiry_Call{Call: _e.mock.On("Inquiry", ctx, seq)}
+}
+
+func (_c *MockTransferer_Inquiry_Call) Run(run func(ctx context.Context, seq *intrabank.Sequence)) *MockTransferer_Inquiry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*intrabank.Sequence))
+	})
+	return _c
+}
+
+func (_c *MockTransferer_Inquiry_Call) Return(_a0 *intrabank.Sequence, _a1 error) *MockTransferer_Inquiry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransferer_Inquiry_Call) RunAndReturn(run func(context.Context, *intrabank.Sequence) (*intrabank.Sequence, error)) *MockTransferer_Inquiry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Validate provides a mock function with given fields: ctx, seq
+func (_m *MockTransferer) Validate(ctx context.Context, seq *intrabank.Sequence) (*intrabank.Sequence, error) {
+	ret := _m.Called(ctx, seq)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 *intrabank.Sequence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.Sequence) (*intrabank.Sequence, error)); ok {
+		return rf(ctx, seq)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.Sequence) *intrabank.Sequence); ok {
+		r0 = rf(ctx, seq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.Sequence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *intrabank.Sequence) error); ok {
+		r1 = rf(ctx, seq)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransferer_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type MockTransferer_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - seq *intrabank.Sequence
+func (_e *MockTransferer_Expecter) Validate(ctx interface{}, seq interface{}) *MockTransferer_Validate_Call {
+	return &MockTransferer_Validate_Call{Call: _e.mock.On("Validate", ctx, seq)}
+}
+
+func (_c *MockTransferer_Validate_Call) Run(run func(ctx context.Context, seq *intrabank.Sequence)) *MockTransferer_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*intrabank.Sequence))
+	})
+	return _c
+}
+
+func (_c *MockTransferer_Validate_Call) Return(_a0 *intrabank.Sequence, _a1 error) *MockTransferer_Validate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransferer_Validate_Call) RunAndReturn(run func(context.Context, *intrabank.Sequence) (*intrabank.Sequence, error)) *MockTransferer_Validate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTransferer creates a new instance of MockTransferer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransferer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransferer {
+	mock := &MockTransferer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
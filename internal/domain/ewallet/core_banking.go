@@ -0,0 +1,25 @@
+package ewallet
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// CoreBanking defines the core banking operations of the e-wallet top-ups.
+type CoreBanking interface {
+	// GetCoreStatus gets the current status of the core banking system.
+	GetCoreStatus(ctx context.Context) (*intrabank.CoreStatus, error)
+
+	// GetAccountDetails retrieves account information for the given account number.
+	GetAccountDetails(ctx context.Context, accountNumber string) (*intrabank.Account, error)
+
+	// GetPostingStatus retrieves the outcome of the posting with the reference.
+	// It returns the result of a completed posting, an *intrabank.OverbookingRejection if the posting was rejected
+	// and intrabank.ErrPostingNotFound if the core banking system has never received it.
+	GetPostingStatus(ctx context.Context, reference string) (*intrabank.OverbookingResult, error)
+
+	// TopUp posts the top-up from the source account to the e-wallet of the phone number.
+	// It returns an *intrabank.OverbookingRejection when the posting has been rejected.
+	TopUp(ctx context.Context, in *Posting) (*intrabank.OverbookingResult, error)
+}
@@ -0,0 +1,10 @@
+package ewallet
+
+import "context"
+
+// Directory defines methods to look up the e-wallet accounts at their providers.
+type Directory interface {
+	// FindAccount retrieves the e-wallet account of the phone number at the provider.
+	// Returns ErrAccountNotFound if the phone number has no account.
+	FindAccount(ctx context.Context, provider *Provider, phoneNumber string) (*Account, error)
+}
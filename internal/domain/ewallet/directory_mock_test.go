@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package ewallet
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDirectory is an autogenerated mock type for the Directory type
+type MockDirectory struct {
+	mock.Mock
+}
+
+type MockDirectory_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDirectory) EXPECT() *MockDirectory_Expecter {
+	return &MockDirectory_Expecter{mock: &_m.Mock}
+}
+
+// FindAccount provides a mock function with given fields: ctx, provider, phoneNumber
+func (_m *MockDirectory) FindAccount(ctx context.Context, provider *Provider, phoneNumber string) (*Account, error) {
+	ret := _m.Called(ctx, provider, phoneNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAccount")
+	}
+
+	var r0 *Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Provider, string) (*Account, error)); ok {
+		return rf(ctx, provider, phoneNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Provider, string) *Account); ok {
+		r0 = rf(ctx, provider, phoneNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Provider, string) error); ok {
+		r1 = rf(ctx, provider, phoneNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDirectory_FindAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindAccount'
+type MockDirectory_FindAccount_Call struct {
+	*mock.Call
+}
+
+// FindAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - provider *Provider
+//   - phoneNumber string
+func (_e *MockDirectory_Expecter) FindAccount(ctx interface{}, provider interface{}, phoneNumber interface{}) *MockDirectory_FindAccount_Call {
+	return &MockDirectory_FindAccount_Call{Call: _e.mock.On("FindAccount", ctx, provider, phoneNumber)}
+}
+
+func (_c *MockDirectory_FindAccount_Call) Run(run func(ctx context.Context, provider *Provider, phoneNumber string)) *MockDirectory_FindAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Provider), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDirectory_FindAccount_Call) Return(_a0 *Account, _a1 error) *MockDirectory_FindAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDirectory_FindAccount_Call) RunAndReturn(run func(context.Context, *Provider, string) (*Account, error)) *MockDirectory_FindAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDirectory creates a new instance of MockDirectory. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDirectory(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDirectory {
+	mock := &MockDirectory{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,34 @@
+package ewallet
+
+import (
+	"errors"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+var (
+	// ErrGeneral indicates a general error, it is shared with the payment pipeline of the intrabank transfers.
+	ErrGeneral = intrabank.ErrGeneral
+
+	// ErrUnauthenticatedUser indicates that the user is not authenticated.
+	ErrUnauthenticatedUser = intrabank.ErrUnauthenticatedUser
+
+	// ErrProviderNotFound is returned when the e-wallet provider is not configured.
+	ErrProviderNotFound = errors.New("e-wallet provider not found")
+
+	// ErrInvalidPhoneNumber is returned when the phone number is not an Indonesian mobile number.
+	ErrInvalidPhoneNumber = errors.New("invalid phone number")
+
+	// ErrAccountNotFound is returned by the provider when the phone number has no e-wallet account.
+	ErrAccountNotFound = errors.New("e-wallet account not found")
+
+	// ErrRailUnavailable is returned by the directory when the e-wallet providers cannot be reached.
+	ErrRailUnavailable = errors.New("rail unavailable")
+
+	// ErrTopUpNotFound is returned when the sequence has no e-wallet top-up.
+	ErrTopUpNotFound = errors.New("e-wallet top-up not found")
+
+	// ErrTopUpPending is returned when the outcome of the top-up is unknown,
+	// the top-up stays pending until it is reconciled.
+	ErrTopUpPending = intrabank.ErrPaymentPending
+)
@@ -0,0 +1,102 @@
+// Package ewallet provides the top-ups of the e-wallets, e.g. GoPay, OVO, DANA and ShopeePay.
+// The e-wallet account is looked up by the phone number of its holder and the top-up
+// is posted by the core banking system with the e-wallet type of the provider.
+// The top-ups reuse the intrabank sequence and transaction model with their own transaction type,
+// while the fee, limits and operating hours are configured per provider.
+package ewallet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// TransactionType is the transaction type of the e-wallet top-ups.
+const TransactionType = "ewallet_topup"
+
+// Provider represents an e-wallet provider the users can top up.
+type Provider struct {
+	Code string
+	Name string
+	// WalletType is the e-wallet type of the provider at the core banking system.
+	WalletType string
+	// Limits are the fee, limits and operating hours of the top-ups of the provider.
+	Limits intrabank.Limits
+}
+
+// Catalog is the configured list of the e-wallet providers.
+type Catalog []*Provider
+
+// Find returns the provider with the code, the code is matched case-insensitively.
+func (c Catalog) Find(code string) (*Provider, bool) {
+	for _, p := range c {
+		if strings.EqualFold(p.Code, code) {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Account represents the e-wallet account of a phone number as returned by its provider.
+type Account struct {
+	PhoneNumber string
+	Name        string
+}
+
+// TopUp represents the e-wallet top-up of a sequence.
+type TopUp struct {
+	ID             int64
+	SequenceNumber string
+	ProviderCode   string
+	ProviderName   string
+	PhoneNumber    string
+	AccountName    string
+	Amount         intrabank.Money
+	Fee            intrabank.Money
+	SourceAccount  string
+	ExpiresAt      time.Time
+}
+
+// Total returns the amount debited from the source account.
+func (t *TopUp) Total() intrabank.Money {
+	return t.Amount + t.Fee
+}
+
+// Posting represents the core banking posting of a top-up, from the source account to the e-wallet.
+// The Reference identifies the posting, its status is checked by it when the outcome is unknown.
+type Posting struct {
+	SourceAccount string
+	PhoneNumber   string
+	Provider      string
+	WalletType    string
+	Amount        intrabank.Money
+	Fee           intrabank.Money
+	Remark        string
+	Reference     string
+}
+
+// NormalizePhoneNumber returns the phone number in its local format, e.g. "+62 812-3456-7890" becomes "081234567890".
+// Returns ErrInvalidPhoneNumber if it is not an Indonesian mobile number.
+func NormalizePhoneNumber(phoneNumber string) (string, error) {
+	phone := strings.NewReplacer(" ", "", "-", "").Replace(phoneNumber)
+	phone = strings.TrimPrefix(phone, "+")
+	if strings.HasPrefix(phone, "62") {
+		phone = "0" + phone[2:]
+	}
+	if !strings.HasPrefix(phone, "08") || len(phone) < 10 || len(phone) > 13 {
+		return "", ErrInvalidPhoneNumber
+	}
+	for _, r := range phone {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+	return phone, nil
+}
+
+// remark builds the transaction remark of the top-up, e.g. "TOPUP GOPAY 081234567890 123456".
+func remark(sequence *intrabank.Sequence, provider *Provider) string {
+	return fmt.Sprintf("TOPUP %s %s %s", provider.Code, sequence.DestinationAccount, sequence.SequenceNumber)
+}
@@ -0,0 +1,45 @@
+package ewallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		phone   string
+		want    string
+		wantErr error
+	}{
+		{name: "local", phone: "081234567890", want: "081234567890"},
+		{name: "international", phone: "+62 812-3456-7890", want: "081234567890"},
+		{name: "country code without plus", phone: "6281234567890", want: "081234567890"},
+		{name: "landline", phone: "0215551234", wantErr: ErrInvalidPhoneNumber},
+		{name: "too short", phone: "08123", wantErr: ErrInvalidPhoneNumber},
+		{name: "too long", phone: "08123456789012", wantErr: ErrInvalidPhoneNumber},
+		{name: "letters", phone: "0812345678ab", wantErr: ErrInvalidPhoneNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePhoneNumber(tt.phone)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestCatalogFind(t *testing.T) {
+	gopay := &Provider{Code: "GOPAY", Name: "GoPay"}
+	catalog := Catalog{gopay, {Code: "OVO", Name: "OVO"}}
+
+	provider, ok := catalog.Find("gopay")
+	assert.True(t, ok)
+	assert.Equal(t, gopay, provider)
+
+	provider, ok = catalog.Find("LINKAJA")
+	assert.False(t, ok)
+	assert.Nil(t, provider)
+}
@@ -0,0 +1,30 @@
+package ewallet
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// Repository defines methods to persist and retrieve the e-wallet top-ups.
+type Repository interface {
+	intrabank.PaymentRepository
+
+	// InsertSequence persists a new sequence.
+	// Returns an error if the operation fails.
+	InsertSequence(ctx context.Context, seq *intrabank.Sequence) error
+
+	// InsertTopUp persists the e-wallet top-up of a sequence.
+	// Returns an error if the operation fails.
+	InsertTopUp(ctx context.Context, topUp *TopUp) error
+
+	// GetTopUp retrieves the e-wallet top-up of the sequence.
+	// Returns ErrTopUpNotFound if the sequence has no e-wallet top-up.
+	GetTopUp(ctx context.Context, sequenceNumber string) (*TopUp, error)
+
+	// SumTopUpAmount sums the amount of the user's successful and pending top-ups
+	// of the provider created within the [from, to) time range.
+	// Returns an error if the operation fails.
+	SumTopUpAmount(ctx context.Context, userID, providerCode string, from, to time.Time) (intrabank.Money, error)
+}
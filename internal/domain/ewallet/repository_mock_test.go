@@ -0,0 +1,942 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package ewallet
+
+import (
+	context "context"
+
+	intrabank "go.bankyaya.org/app/backend/internal/domain/intrabank"
+	ctxt "go.bankyaya.org/app/backend/internal/pkg/ctxt"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// AcquireSequence provides a mock function with given fields: ctx, sequenceNumber, idempotencyKey
+func (_m *MockRepository) AcquireSequence(ctx context.Context, sequenceNumber string, idempotencyKey string) error {
+	ret := _m.Called(ctx, sequenceNumber, idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireSequence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, sequenceNumber, idempotencyKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_AcquireSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireSequence'
+type MockRepository_AcquireSequence_Call struct {
+	*mock.Call
+}
+
+// AcquireSequence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+//   - idempotencyKey string
+func (_e *MockRepository_Expecter) AcquireSequence(ctx interface{}, sequenceNumber interface{}, idempotencyKey interface{}) *MockRepository_AcquireSequence_Call {
+	return &MockRepository_AcquireSequence_Call{Call: _e.mock.On("AcquireSequence", ctx, sequenceNumber, idempotencyKey)}
+}
+
+func (_c *MockRepository_AcquireSequence_Call) Run(run func(ctx context.Context, sequenceNumber string, idempotencyKey string)) *MockRepository_AcquireSequence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_AcquireSequence_Call) Return(_a0 error) *MockRepository_AcquireSequence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_AcquireSequence_Call) RunAndReturn(run func(context.Context, string, string) error) *MockRepository_AcquireSequence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteTransaction provides a mock function with given fields: ctx, transaction, outbox
+func (_m *MockRepository) CompleteTransaction(ctx context.Context, transaction *intrabank.Transaction, outbox []*intrabank.OutboxMessage) error {
+	ret := _m.Called(ctx, transaction, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.Transaction, []*intrabank.OutboxMessage) error); ok {
+		r0 = rf(ctx, transaction, outbox)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CompleteTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteTransaction'
+type MockRepository_CompleteTransaction_Call struct {
+	*mock.Call
+}
+
+// CompleteTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *intrabank.Transaction
+//   - outbox []*intrabank.OutboxMessage
+func (_e *MockRepository_Expecter) CompleteTransaction(ctx interface{}, transaction interface{}, outbox interface{}) *MockRepository_CompleteTransaction_Call {
+	return &MockRepository_CompleteTransaction_Call{Call: _e.mock.On("CompleteTransaction", ctx, transaction, outbox)}
+}
+
+func (_c *MockRepository_CompleteTransaction_Call) Run(run func(ctx context.Context, transaction *intrabank.Transaction, outbox []*intrabank.OutboxMessage)) *MockRepository_CompleteTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*intrabank.Transaction), args[2].([]*intrabank.OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CompleteTransaction_Call) Return(_a0 error) *MockRepository_CompleteTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CompleteTransaction_Call) RunAndReturn(run func(context.Context, *intrabank.Transaction, []*intrabank.OutboxMessage) error) *MockRepository_CompleteTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailTransaction provides a mock function with given fields: ctx, transaction, outbox
+func (_m *MockRepository) FailTransaction(ctx context.Context, transaction *intrabank.Transaction, outbox []*intrabank.OutboxMessage) error {
+	ret := _m.Called(ctx, transaction, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.Transaction, []*intrabank.OutboxMessage) error); ok {
+		r0 = rf(ctx, transaction, outbox)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_FailTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailTransaction'
+type MockRepository_FailTransaction_Call struct {
+	*mock.Call
+}
+
+// FailTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *intrabank.Transaction
+//   - outbox []*intrabank.OutboxMessage
+func (_e *MockRepository_Expecter) FailTransaction(ctx interface{}, transaction interface{}, outbox interface{}) *MockRepository_FailTransaction_Call {
+	return &MockRepository_FailTransaction_Call{Call: _e.mock.On("FailTransaction", ctx, transaction, outbox)}
+}
+
+func (_c *MockRepository_FailTransaction_Call) Run(run func(ctx context.Context, transaction *intrabank.Transaction, outbox []*intrabank.OutboxMessage)) *MockRepository_FailTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*intrabank.Transaction), args[2].([]*intrabank.OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *MockRepository_FailTransaction_Call) Return(_a0 error) *MockRepository_FailTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_FailTransaction_Call) RunAndReturn(run func(context.Context, *intrabank.Transaction, []*intrabank.OutboxMessage) error) *MockRepository_FailTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFirebaseID provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) GetFirebaseID(ctx context.Context, userID int) (string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirebaseID")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetFirebaseID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirebaseID'
+type MockRepository_GetFirebaseID_Call struct {
+	*mock.Call
+}
+
+// GetFirebaseID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *MockRepository_Expecter) GetFirebaseID(ctx interface{}, userID interface{}) *MockRepository_GetFirebaseID_Call {
+	return &MockRepository_GetFirebaseID_Call{Call: _e.mock.On("GetFirebaseID", ctx, userID)}
+}
+
+func (_c *MockRepository_GetFirebaseID_Call) Run(run func(ctx context.Context, userID int)) *MockRepository_GetFirebaseID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetFirebaseID_Call) Return(_a0 string, _a1 error) *MockRepository_GetFirebaseID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetFirebaseID_Call) RunAndReturn(run func(context.Context, int) (string, error)) *MockRepository_GetFirebaseID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingTransactions provides a mock function with given fields: ctx, before, limit
+func (_m *MockRepository) GetPendingTransactions(ctx context.Context, before time.Time, limit int) ([]*intrabank.Transaction, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTransactions")
+	}
+
+	var r0 []*intrabank.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*intrabank.Transaction, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*intrabank.Transaction); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*intrabank.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetPendingTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTransactions'
+type MockRepository_GetPendingTransactions_Call struct {
+	*mock.Call
+}
+
+// GetPendingTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - limit int
+func (_e *MockRepository_Expecter) GetPendingTransactions(ctx interface{}, before interface{}, limit interface{}) *MockRepository_GetPendingTransactions_Call {
+	return &MockRepository_GetPendingTransactions_Call{Call: _e.mock.On("GetPendingTransactions", ctx, before, limit)}
+}
+
+func (_c *MockRepository_GetPendingTransactions_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *MockRepository_GetPendingTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetPendingTransactions_Call) Return(_a0 []*intrabank.Transaction, _a1 error) *MockRepository_GetPendingTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetPendingTransactions_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]*intrabank.Transaction, error)) *MockRepository_GetPendingTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSequence provides a mock function with given fields: ctx, sequenceNumber
+func (_m *MockRepository) GetSequence(ctx context.Context, sequenceNumber string) (*intrabank.Sequence, error) {
+	ret := _m.Called(ctx, sequenceNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequence")
+	}
+
+	var r0 *intrabank.Sequence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*intrabank.Sequence, error)); ok {
+		return rf(ctx, sequenceNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *intrabank.Sequence); ok {
+		r0 = rf(ctx, sequenceNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.Sequence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sequenceNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSequence'
+type MockRepository_GetSequence_Call struct {
+	*mock.Call
+}
+
+// GetSequence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+func (_e *MockRepository_Expecter) GetSequence(ctx interface{}, sequenceNumber interface{}) *MockRepository_GetSequence_Call {
+	return &MockRepository_GetSequence_Call{Call: _e.mock.On("GetSequence", ctx, sequenceNumber)}
+}
+
+func (_c *MockRepository_GetSequence_Call) Run(run func(ctx context.Context, sequenceNumber string)) *MockRepository_GetSequence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetSequence_Call) Return(_a0 *intrabank.Sequence, _a1 error) *MockRepository_GetSequence_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetSequence_Call) RunAndReturn(run func(context.Context, string) (*intrabank.Sequence, error)) *MockRepository_GetSequence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSequenceByIdempotencyKey provides a mock function with given fields: ctx, userID, idempotencyKey
+func (_m *MockRepository) GetSequenceByIdempotencyKey(ctx context.Context, userID int, idempotencyKey string) (*intrabank.Sequence, error) {
+	ret := _m.Called(ctx, userID, idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequenceByIdempotencyKey")
+	}
+
+	var r0 *intrabank.Sequence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*intrabank.Sequence, error)); ok {
+		return rf(ctx, userID, idempotencyKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *intrabank.Sequence); ok {
+		r0 = rf(ctx, userID, idempotencyKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.Sequence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, idempotencyKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetSequenceByIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSequenceByIdempotencyKey'
+type MockRepository_GetSequenceByIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// GetSequenceByIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - idempotencyKey string
+func (_e *MockRepository_Expecter) GetSequenceByIdempotencyKey(ctx interface{}, userID interface{}, idempotencyKey interface{}) *MockRepository_GetSequenceByIdempotencyKey_Call {
+	return &MockRepository_GetSequenceByIdempotencyKey_Call{Call: _e.mock.On("GetSequenceByIdempotencyKey", ctx, userID, idempotencyKey)}
+}
+
+func (_c *MockRepository_GetSequenceByIdempotencyKey_Call) Run(run func(ctx context.Context, userID int, idempotencyKey string)) *MockRepository_GetSequenceByIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetSequenceByIdempotencyKey_Call) Return(_a0 *intrabank.Sequence, _a1 error) *MockRepository_GetSequenceByIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetSequenceByIdempotencyKey_Call) RunAndReturn(run func(context.Context, int, string) (*intrabank.Sequence, error)) *MockRepository_GetSequenceByIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTopUp provides a mock function with given fields: ctx, sequenceNumber
+func (_m *MockRepository) GetTopUp(ctx context.Context, sequenceNumber string) (*TopUp, error) {
+	ret := _m.Called(ctx, sequenceNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTopUp")
+	}
+
+	var r0 *TopUp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*TopUp, error)); ok {
+		return rf(ctx, sequenceNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *TopUp); ok {
+		r0 = rf(ctx, sequenceNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*TopUp)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sequenceNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetTopUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTopUp'
+type MockRepository_GetTopUp_Call struct {
+	*mock.Call
+}
+
+// GetTopUp is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+func (_e *MockRepository_Expecter) GetTopUp(ctx interface{}, sequenceNumber interface{}) *MockRepository_GetTopUp_Call {
+	return &MockRepository_GetTopUp_Call{Call: _e.mock.On("GetTopUp", ctx, sequenceNumber)}
+}
+
+func (_c *MockRepository_GetTopUp_Call) Run(run func(ctx context.Context, sequenceNumber string)) *MockRepository_GetTopUp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetTopUp_Call) Return(_a0 *TopUp, _a1 error) *MockRepository_GetTopUp_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetTopUp_Call) RunAndReturn(run func(context.Context, string) (*TopUp, error)) *MockRepository_GetTopUp_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionBySequenceNumber provides a mock function with given fields: ctx, sequenceNumber
+func (_m *MockRepository) GetTransactionBySequenceNumber(ctx context.Context, sequenceNumber string) (*intrabank.Transaction, error) {
+	ret := _m.Called(ctx, sequenceNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionBySequenceNumber")
+	}
+
+	var r0 *intrabank.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*intrabank.Transaction, error)); ok {
+		return rf(ctx, sequenceNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *intrabank.Transaction); ok {
+		r0 = rf(ctx, sequenceNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sequenceNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetTransactionBySequenceNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionBySequenceNumber'
+type MockRepository_GetTransactionBySequenceNumber_Call struct {
+	*mock.Call
+}
+
+// GetTransactionBySequenceNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+func (_e *MockRepository_Expecter) GetTransactionBySequenceNumber(ctx interface{}, sequenceNumber interface{}) *MockRepository_GetTransactionBySequenceNumber_Call {
+	return &MockRepository_GetTransactionBySequenceNumber_Call{Call: _e.mock.On("GetTransactionBySequenceNumber", ctx, sequenceNumber)}
+}
+
+func (_c *MockRepository_GetTransactionBySequenceNumber_Call) Run(run func(ctx context.Context, sequenceNumber string)) *MockRepository_GetTransactionBySequenceNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetTransactionBySequenceNumber_Call) Return(_a0 *intrabank.Transaction, _a1 error) *MockRepository_GetTransactionBySequenceNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetTransactionBySequenceNumber_Call) RunAndReturn(run func(context.Context, string) (*intrabank.Transaction, error)) *MockRepository_GetTransactionBySequenceNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) GetUser(ctx context.Context, userID int) (*ctxt.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *ctxt.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*ctxt.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *ctxt.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ctxt.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockRepository_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *MockRepository_Expecter) GetUser(ctx interface{}, userID interface{}) *MockRepository_GetUser_Call {
+	return &MockRepository_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+}
+
+func (_c *MockRepository_GetUser_Call) Run(run func(ctx context.Context, userID int)) *MockRepository_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetUser_Call) Return(_a0 *ctxt.User, _a1 error) *MockRepository_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetUser_Call) RunAndReturn(run func(context.Context, int) (*ctxt.User, error)) *MockRepository_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertRecovery provides a mock function with given fields: ctx, recovery, outbox
+func (_m *MockRepository) InsertRecovery(ctx context.Context, recovery *intrabank.Recovery, outbox []*intrabank.OutboxMessage) error {
+	ret := _m.Called(ctx, recovery, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertRecovery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.Recovery, []*intrabank.OutboxMessage) error); ok {
+		r0 = rf(ctx, recovery, outbox)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_InsertRecovery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertRecovery'
+type MockRepository_InsertRecovery_Call struct {
+	*mock.Call
+}
+
+// InsertRecovery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recovery *intrabank.Recovery
+//   - outbox []*intrabank.OutboxMessage
+func (_e *MockRepository_Expecter) InsertRecovery(ctx interface{}, recovery interface{}, outbox interface{}) *MockRepository_InsertRecovery_Call {
+	return &MockRepository_InsertRecovery_Call{Call: _e.mock.On("InsertRecovery", ctx, recovery, outbox)}
+}
+
+func (_c *MockRepository_InsertRecovery_Call) Run(run func(ctx context.Context, recovery *intrabank.Recovery, outbox []*intrabank.OutboxMessage)) *MockRepository_InsertRecovery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*intrabank.Recovery), args[2].([]*intrabank.OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *MockRepository_InsertRecovery_Call) Return(_a0 error) *MockRepository_InsertRecovery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_InsertRecovery_Call) RunAndReturn(run func(context.Context, *intrabank.Recovery, []*intrabank.OutboxMessage) error) *MockRepository_InsertRecovery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertSequence provides a mock function with given fields: ctx, seq
+func (_m *MockRepository) InsertSequence(ctx context.Context, seq *intrabank.Sequence) error {
+	ret := _m.Called(ctx, seq)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertSequence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.Sequence) error); ok {
+		r0 = rf(ctx, seq)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_InsertSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertSequence'
+type MockRepository_InsertSequence_Call struct {
+	*mock.Call
+}
+
+// InsertSequence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - seq *intrabank.Sequence
+func (_e *MockRepository_Expecter) InsertSequence(ctx interface{}, seq interface{}) *MockRepository_InsertSequence_Call {
+	return &MockRepository_InsertSequence_Call{Call: _e.mock.On("InsertSequence", ctx, seq)}
+}
+
+func (_c *MockRepository_InsertSequence_Call) Run(run func(ctx context.Context, seq *intrabank.Sequence)) *MockRepository_InsertSequence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*intrabank.Sequence))
+	})
+	return _c
+}
+
+func (_c *MockRepository_InsertSequence_Call) Return(_a0 error) *MockRepository_InsertSequence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_InsertSequence_Call) RunAndReturn(run func(context.Context, *intrabank.Sequence) error) *MockRepository_InsertSequence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertTopUp provides a mock function with given fields: ctx, topUp
+func (_m *MockRepository) InsertTopUp(ctx context.Context, topUp *TopUp) error {
+	ret := _m.Called(ctx, topUp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertTopUp")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *TopUp) error); ok {
+		r0 = rf(ctx, topUp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_InsertTopUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertTopUp'
+type MockRepository_InsertTopUp_Call struct {
+	*mock.Call
+}
+
+// InsertTopUp is a helper method to define mock.On call
+//   - ctx context.Context
+//   - topUp *TopUp
+func (_e *MockRepository_Expecter) InsertTopUp(ctx interface{}, topUp interface{}) *MockRepository_InsertTopUp_Call {
+	return &MockRepository_InsertTopUp_Call{Call: _e.mock.On("InsertTopUp", ctx, topUp)}
+}
+
+func (_c *MockRepository_InsertTopUp_Call) Run(run func(ctx context.Context, topUp *TopUp)) *MockRepository_InsertTopUp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*TopUp))
+	})
+	return _c
+}
+
+func (_c *MockRepository_InsertTopUp_Call) Return(_a0 error) *MockRepository_InsertTopUp_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_InsertTopUp_Call) RunAndReturn(run func(context.Context, *TopUp) error) *MockRepository_InsertTopUp_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertTransaction provides a mock function with given fields: ctx, transaction
+func (_m *MockRepository) InsertTransaction(ctx context.Context, transaction *intrabank.Transaction) error {
+	ret := _m.Called(ctx, transaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.Transaction) error); ok {
+		r0 = rf(ctx, transaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_InsertTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertTransaction'
+type MockRepository_InsertTransaction_Call struct {
+	*mock.Call
+}
+
+// InsertTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *intrabank.Transaction
+func (_e *MockRepository_Expecter) InsertTransaction(ctx interface{}, transaction interface{}) *MockRepository_InsertTransaction_Call {
+	return &MockRepository_InsertTransaction_Call{Call: _e.mock.On("InsertTransaction", ctx, transaction)}
+}
+
+func (_c *MockRepository_InsertTransaction_Call) Run(run func(ctx context.Context, transaction *intrabank.Transaction)) *MockRepository_InsertTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*intrabank.Transaction))
+	})
+	return _c
+}
+
+func (_c *MockRepository_InsertTransaction_Call) Return(_a0 error) *MockRepository_InsertTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_InsertTransaction_Call) RunAndReturn(run func(context.Context, *intrabank.Transaction) error) *MockRepository_InsertTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordPosting provides a mock function with given fields: ctx, transaction
+func (_m *MockRepository) RecordPosting(ctx context.Context, transaction *intrabank.Transaction) error {
+	ret := _m.Called(ctx, transaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPosting")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.Transaction) error); ok {
+		r0 = rf(ctx, transaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_RecordPosting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPosting'
+type MockRepository_RecordPosting_Call struct {
+	*mock.Call
+}
+
+// RecordPosting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *intrabank.Transaction
+func (_e *MockRepository_Expecter) RecordPosting(ctx interface{}, transaction interface{}) *MockRepository_RecordPosting_Call {
+	return &MockRepository_RecordPosting_Call{Call: _e.mock.On("RecordPosting", ctx, transaction)}
+}
+
+func (_c *MockRepository_RecordPosting_Call) Run(run func(ctx context.Context, transaction *intrabank.Transaction)) *MockRepository_RecordPosting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*intrabank.Transaction))
+	})
+	return _c
+}
+
+func (_c *MockRepository_RecordPosting_Call) Return(_a0 error) *MockRepository_RecordPosting_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_RecordPosting_Call) RunAndReturn(run func(context.Context, *intrabank.Transaction) error) *MockRepository_RecordPosting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SumTopUpAmount provides a mock function with given fields: ctx, userID, providerCode, from, to
+func (_m *MockRepository) SumTopUpAmount(ctx context.Context, userID string, providerCode string, from time.Time, to time.Time) (intrabank.Money, error) {
+	ret := _m.Called(ctx, userID, providerCode, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumTopUpAmount")
+	}
+
+	var r0 intrabank.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (intrabank.Money, error)); ok {
+		return rf(ctx, userID, providerCode, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) intrabank.Money); ok {
+		r0 = rf(ctx, userID, providerCode, from, to)
+	} else {
+		r0 = ret.Get(0).(intrabank.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, providerCode, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_SumTopUpAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumTopUpAmount'
+type MockRepository_SumTopUpAmount_Call struct {
+	*mock.Call
+}
+
+// SumTopUpAmount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - providerCode string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockRepository_Expecter) SumTopUpAmount(ctx interface{}, userID interface{}, providerCode interface{}, from interface{}, to interface{}) *MockRepository_SumTopUpAmount_Call {
+	return &MockRepository_SumTopUpAmount_Call{Call: _e.mock.On("SumTopUpAmount", ctx, userID, providerCode, from, to)}
+}
+
+func (_c *MockRepository_SumTopUpAmount_Call) Run(run func(ctx context.Context, userID string, providerCode string, from time.Time, to time.Time)) *MockRepository_SumTopUpAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Time), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SumTopUpAmount_Call) Return(_a0 intrabank.Money, _a1 error) *MockRepository_SumTopUpAmount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_SumTopUpAmount_Call) RunAndReturn(run func(context.Context, string, string, time.Time, time.Time) (intrabank.Money, error)) *MockRepository_SumTopUpAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSequenceStatus provides a mock function with given fields: ctx, sequenceNumber, status
+func (_m *MockRepository) UpdateSequenceStatus(ctx context.Context, sequenceNumber string, status string) error {
+	ret := _m.Called(ctx, sequenceNumber, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSequenceStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, sequenceNumber, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdateSequenceStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSequenceStatus'
+type MockRepository_UpdateSequenceStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateSequenceStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+//   - status string
+func (_e *MockRepository_Expecter) UpdateSequenceStatus(ctx interface{}, sequenceNumber interface{}, status interface{}) *MockRepository_UpdateSequenceStatus_Call {
+	return &MockRepository_UpdateSequenceStatus_Call{Call: _e.mock.On("UpdateSequenceStatus", ctx, sequenceNumber, status)}
+}
+
+func (_c *MockRepository_UpdateSequenceStatus_Call) Run(run func(ctx context.Context, sequenceNumber string, status string)) *MockRepository_UpdateSequenceStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateSequenceStatus_Call) Return(_a0 error) *MockRepository_UpdateSequenceStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdateSequenceStatus_Call) RunAndReturn(run func(context.Context, string, string) error) *MockRepository_UpdateSequenceStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
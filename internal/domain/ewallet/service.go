@@ -0,0 +1,412 @@
+package ewallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+const (
+	domainName          = "ewallet"
+	topUpSuccessSubject = "Top Up E-Wallet Berhasil"
+	topUpFailedSubject  = "Top Up E-Wallet Gagal"
+)
+
+// InquiryInput represents the e-wallet the user wants to top up.
+type InquiryInput struct {
+	ProviderCode  string
+	PhoneNumber   string
+	Amount        intrabank.Money
+	SourceAccount string
+	Channel       string
+}
+
+// Service handles the e-wallet top-ups.
+type Service struct {
+	log         *logger.Logger
+	repo        Repository
+	corebanking CoreBanking
+	directory   Directory
+	seqGen      SequenceGenerator
+	validity    intrabank.SequenceValidity
+	authorizer  TransactionAuthorizer
+	stepUp      intrabank.StepUpPolicy
+	providers   Catalog
+	payer       *intrabank.Payer[*topUpDetails]
+}
+
+// NewService creates a new instance of Service.
+func NewService(
+	log *logger.Logger,
+	repo Repository,
+	corebanking CoreBanking,
+	directory Directory,
+	seqGen SequenceGenerator,
+	validity intrabank.SequenceValidity,
+	authorizer TransactionAuthorizer,
+	stepUp intrabank.StepUpPolicy,
+	providers Catalog,
+) *Service {
+	s := &Service{
+		log:         log,
+		repo:        repo,
+		corebanking: corebanking,
+		directory:   directory,
+		seqGen:      seqGen,
+		validity:    validity,
+		authorizer:  authorizer,
+		stepUp:      stepUp,
+		providers:   providers,
+	}
+	s.payer = intrabank.NewPayer[*topUpDetails](log, domainName, repo, corebanking, authorizer, stepUp, &topUpMethod{s})
+	return s
+}
+
+// Providers returns the configured e-wallet providers that are not disabled.
+func (s *Service) Providers(ctx context.Context) []*Provider {
+	providers := make([]*Provider, 0, len(s.providers))
+	for _, p := range s.providers {
+		if !p.Limits.Disabled {
+			providers = append(providers, p)
+		}
+	}
+	return providers
+}
+
+// Inquiry looks up the e-wallet account of the phone number and creates the sequence of its top-up.
+// The amount of the sequence is credited to the phone number, the fee is the fee of the provider.
+func (s *Service) Inquiry(ctx context.Context, in *InquiryInput) (*TopUp, error) {
+	if err := s.checkEOD(ctx, "Inquiry"); err != nil {
+		return nil, err
+	}
+
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	provider, err := s.provider(in.ProviderCode, "Inquiry")
+	if err != nil {
+		return nil, err
+	}
+
+	phoneNumber, err := NormalizePhoneNumber(in.PhoneNumber)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("NormalizePhoneNumber (%v): %v", in.PhoneNumber, err)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidPhoneNumber).
+			SetMsg("Please enter a valid phone number.")
+	}
+
+	if !provider.Limits.CanTransfer(in.Amount) {
+		s.log.DomainUsecase(domainName, "Inquiry").Error(intrabank.ErrInvalidAmount)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+			SetMsg(fmt.Sprintf("Your top-up amount is not within the limits of %s top-ups.", provider.Name))
+	}
+
+	from, to := intrabank.BusinessDay(time.Now())
+	dailyAmount, err := s.repo.SumTopUpAmount(ctx, strconv.Itoa(user.ID), provider.Code, from, to)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("SumTopUpAmount: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !provider.Limits.WithinDailyLimit(dailyAmount + in.Amount) {
+		s.log.DomainUsecase(domainName, "Inquiry").Error(intrabank.ErrDailyLimitExceeded)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrDailyLimitExceeded).
+			SetMsg(fmt.Sprintf("You have reached your daily %s top-up limit. Please try again tomorrow.", provider.Name))
+	}
+
+	account, err := s.directory.FindAccount(ctx, provider, phoneNumber)
+	if errors.Is(err, ErrAccountNotFound) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("FindAccount (%v %v): %v", provider.Code, phoneNumber, err)
+		return nil, pkgerror.New(codes.BadRequest, ErrAccountNotFound).
+			SetMsg(fmt.Sprintf("The %s account is not found. Please check the phone number and try again.", provider.Name))
+	}
+	if errors.Is(err, ErrRailUnavailable) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("FindAccount: %v", err)
+		return nil, pkgerror.New(codes.Forbidden, ErrRailUnavailable).
+			SetMsg("E-wallet top-ups are temporarily unavailable. Please try again later.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("FindAccount: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	seq := &intrabank.Sequence{
+		Amount:             in.Amount,
+		Fee:                provider.Limits.Fee,
+		SourceAccount:      in.SourceAccount,
+		DestinationAccount: account.PhoneNumber,
+		DestinationName:    account.Name,
+		Channel:            in.Channel,
+	}
+
+	srcAccount, err := s.corebanking.GetAccountDetails(ctx, seq.SourceAccount)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetAccountDetails: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !srcAccount.IsOwnedBy(user.CIF) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) not owned by user (%v)", seq.SourceAccount, user.ID)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrSourceAccountNotOwned).
+			SetMsg("You can only top up from your own account.")
+	}
+	if !srcAccount.IsAccountActive() {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) not active", seq.SourceAccount)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrSourceAccountInactive)
+	}
+	if !srcAccount.CanDebit(seq.Amount + seq.Fee) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) cannot be debited by %v", seq.SourceAccount, seq.Amount+seq.Fee)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrInsufficientBalance).
+			SetMsg("Your balance is not enough for this top-up.")
+	}
+
+	seq.SourceName = srcAccount.Name
+
+	sequenceNo, err := s.seqGen.Generate()
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("Generate failed: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	now := time.Now()
+	seq.SequenceNumber = sequenceNo
+	seq.TransactionType = TransactionType
+	seq.Status = intrabank.SequenceCreated
+	seq.UserID = user.ID
+	seq.DeviceID = user.DeviceID
+	seq.CreatedAt = now
+	seq.ExpiresAt = now.Add(s.validity.For(TransactionType))
+
+	err = s.repo.InsertSequence(ctx, seq)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("InsertSequence: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	topUp := &TopUp{
+		SequenceNumber: seq.SequenceNumber,
+		ProviderCode:   provider.Code,
+		ProviderName:   provider.Name,
+		PhoneNumber:    account.PhoneNumber,
+		AccountName:    account.Name,
+		Amount:         seq.Amount,
+		Fee:            seq.Fee,
+		SourceAccount:  seq.SourceAccount,
+		ExpiresAt:      seq.ExpiresAt,
+	}
+
+	err = s.repo.InsertTopUp(ctx, topUp)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("InsertTopUp: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return topUp, nil
+}
+
+// SettlePending settles the top-ups left pending, e.g. when the outcome of the posting was unknown.
+func (s *Service) SettlePending(ctx context.Context) error {
+	return s.payer.SettlePending(ctx)
+}
+
+// DoPayment posts the top-up of the sequence to the e-wallet through the core banking system.
+// A top-up whose outcome is unknown is left pending, because the money may have moved.
+func (s *Service) DoPayment(ctx context.Context, in *intrabank.PaymentInput) (*intrabank.Transaction, error) {
+	payment, err := s.payer.Pay(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return payment.Transaction, nil
+}
+
+// checkEOD rejects the top-up while the end of day process of the core banking system is running.
+func (s *Service) checkEOD(ctx context.Context, usecase string) error {
+	coreStatus, err := s.corebanking.GetCoreStatus(ctx)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("CheckEOD: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if coreStatus.IsEODRunning() {
+		s.log.DomainUsecase(domainName, usecase).Errorf("CheckEOD: %v", intrabank.ErrEODInProgress)
+		return pkgerror.New(codes.Internal, intrabank.ErrEODInProgress)
+	}
+	return nil
+}
+
+// provider finds the configured provider and rejects the top-up when it is disabled or closed.
+func (s *Service) provider(code, usecase string) (*Provider, error) {
+	provider, ok := s.providers.Find(code)
+	if !ok {
+		s.log.DomainUsecase(domainName, usecase).Errorf("provider (%v): %v", code, ErrProviderNotFound)
+		return nil, pkgerror.New(codes.BadRequest, ErrProviderNotFound).
+			SetMsg("This e-wallet is not supported.")
+	}
+	if provider.Limits.Disabled {
+		s.log.DomainUsecase(domainName, usecase).Errorf("provider (%v): %v", provider.Code, intrabank.ErrTransferMethodDisabled)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrTransferMethodDisabled).
+			SetMsg(fmt.Sprintf("%s top-ups are temporarily unavailable. Please try again later.", provider.Name))
+	}
+	if now := time.Now(); !provider.Limits.IsOpen(now) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("provider (%v): %v at %v", provider.Code, intrabank.ErrOutsideOperatingHours, now)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrOutsideOperatingHours).
+			SetMsg(fmt.Sprintf("%s top-ups are only available from %02d:00 to %02d:00 WIB.",
+				provider.Name, provider.Limits.OpenHour, provider.Limits.CloseHour))
+	}
+	return provider, nil
+}
+
+// topUpDetails holds the e-wallet top-up of the sequence and its provider.
+type topUpDetails struct {
+	topUp    *TopUp
+	provider *Provider
+}
+
+// topUpMethod pays the e-wallet top-up sequences through the core banking system.
+type topUpMethod struct {
+	*Service
+}
+
+var topUpMessages = &intrabank.PaymentMessages{
+	Rejected:    "Your payment request was rejected. Please try again.",
+	KeyReused:   "The idempotency key has been used for another payment.",
+	Expired:     "Your top-up session has expired. Please enter the phone number again.",
+	OTPRequired: "Please verify this top-up with the OTP sent to you.",
+	InProgress:  "Your payment is being processed. Please check your transaction history.",
+	Failed:      "Your top-up has failed. Please enter the phone number again.",
+	Pending:     "Your top-up is being processed. Please check your transaction history.",
+	NotRecorded: "Your payment has been processed but is not recorded yet. Please check your transaction history later.",
+}
+
+func (m *topUpMethod) Messages() *intrabank.PaymentMessages {
+	return topUpMessages
+}
+
+func (m *topUpMethod) Accepts(sequence *intrabank.Sequence) bool {
+	return sequence.TransactionType == TransactionType
+}
+
+// Prepare loads the top-up and its provider, the provider is checked before the OTP,
+// so a disabled provider does not use it up.
+func (m *topUpMethod) Prepare(ctx context.Context, payment *intrabank.Payment[*topUpDetails]) error {
+	sequence := payment.Sequence
+	topUp, err := m.repo.GetTopUp(ctx, sequence.SequenceNumber)
+	if err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("GetTopUp: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	provider, err := m.provider(topUp.ProviderCode, "DoPayment")
+	if err != nil {
+		return err
+	}
+	if !provider.Limits.CanTransfer(sequence.Amount) {
+		m.log.DomainUsecase(domainName, "DoPayment").Error(intrabank.ErrInvalidAmount)
+		return pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+			SetMsg(fmt.Sprintf("Your top-up amount is not within the limits of %s top-ups.", provider.Name))
+	}
+
+	payment.Details = &topUpDetails{topUp: topUp, provider: provider}
+	return nil
+}
+
+// KnownDestination treats every e-wallet as known, so only the step-up threshold requires an OTP.
+func (m *topUpMethod) KnownDestination(context.Context, *intrabank.Payment[*topUpDetails]) (bool, error) {
+	return true, nil
+}
+
+func (m *topUpMethod) Describe(payment *intrabank.Payment[*topUpDetails]) (string, string) {
+	return TransactionType, remark(payment.Sequence, payment.Details.provider)
+}
+
+// CheckDailyLimit checks the daily limit of the provider, it includes the pending transaction of the top-up.
+func (m *topUpMethod) CheckDailyLimit(ctx context.Context, payment *intrabank.Payment[*topUpDetails]) error {
+	provider := payment.Details.provider
+	from, to := intrabank.BusinessDay(time.Now())
+	dailyAmount, err := m.repo.SumTopUpAmount(ctx, payment.Transaction.UserID, provider.Code, from, to)
+	if err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("SumTopUpAmount: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !provider.Limits.WithinDailyLimit(dailyAmount) {
+		m.log.DomainUsecase(domainName, "DoPayment").Error(intrabank.ErrDailyLimitExceeded)
+		return pkgerror.New(codes.BadRequest, intrabank.ErrDailyLimitExceeded).
+			SetMsg(fmt.Sprintf("You have reached your daily %s top-up limit. Please try again tomorrow.", provider.Name))
+	}
+	return nil
+}
+
+func (m *topUpMethod) Post(ctx context.Context, payment *intrabank.Payment[*topUpDetails]) (*intrabank.OverbookingResult, error) {
+	sequence := payment.Sequence
+	provider := payment.Details.provider
+	return m.corebanking.TopUp(ctx, &Posting{
+		SourceAccount: sequence.SourceAccount,
+		PhoneNumber:   sequence.DestinationAccount,
+		Provider:      provider.Code,
+		WalletType:    provider.WalletType,
+		Amount:        sequence.Amount,
+		Fee:           sequence.Fee,
+		Remark:        payment.Transaction.Remarks,
+		Reference:     sequence.SequenceNumber,
+	})
+}
+
+// Load loads the top-up of the sequence for its receipt.
+func (m *topUpMethod) Load(ctx context.Context, payment *intrabank.Payment[*topUpDetails]) error {
+	topUp, err := m.repo.GetTopUp(ctx, payment.Sequence.SequenceNumber)
+	if err != nil {
+		return err
+	}
+	payment.Details = &topUpDetails{topUp: topUp}
+	return nil
+}
+
+// Resolve checks the posting of the top-up at the core banking system, which credits the e-wallet with it.
+func (m *topUpMethod) Resolve(ctx context.Context, payment *intrabank.Payment[*topUpDetails]) (*intrabank.OverbookingResult, error) {
+	return m.corebanking.GetPostingStatus(ctx, payment.Sequence.SequenceNumber)
+}
+
+func (m *topUpMethod) Rejected(*intrabank.Payment[*topUpDetails], *intrabank.OverbookingRejection) error {
+	return pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+		SetMsg("Your top-up was rejected. Please try again.")
+}
+
+// Receipt builds the receipt and the notification of the top-up, they are delivered
+// by the outbox dispatcher of the intrabank transfers.
+func (m *topUpMethod) Receipt(payment *intrabank.Payment[*topUpDetails]) (*intrabank.EmailData, *intrabank.Notification) {
+	transaction := payment.Transaction
+	topUp := payment.Details.topUp
+	subject := topUpSuccessSubject
+	if transaction.Status == intrabank.TransactionFailed {
+		subject = topUpFailedSubject
+	}
+
+	return &intrabank.EmailData{
+		Subject:            subject,
+		Recipient:          payment.User.Email,
+		Amount:             transaction.Amount,
+		Fee:                payment.Sequence.Fee,
+		SourceName:         payment.User.Name,
+		SourceAccount:      payment.Sequence.SourceAccount,
+		DestinationName:    transaction.DestinationName,
+		DestinationAccount: transaction.Destination,
+		DestinationBank:    topUp.ProviderName,
+		TransactionRef:     transaction.TransactionReference,
+		Note:               transaction.Remarks,
+		Status:             transaction.Status,
+	}, &intrabank.Notification{
+		Subject:     subject,
+		Amount:      transaction.Amount,
+		Destination: topUp.ProviderName,
+		Status:      transaction.Status,
+	}
+}
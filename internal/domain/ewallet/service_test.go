@@ -0,0 +1,483 @@
+package ewallet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+var (
+	gopay = &Provider{
+		Code:       "GOPAY",
+		Name:       "GoPay",
+		WalletType: "GOPAY",
+		Limits:     intrabank.Limits{MinAmount: 10000, MaxAmount: 2_000_000, MaxDailyAmount: 5_000_000, Fee: 1000},
+	}
+	ovo = &Provider{
+		Code:       "OVO",
+		Name:       "OVO",
+		WalletType: "OVO",
+		Limits:     intrabank.Limits{MinAmount: 10000, MaxAmount: 2_000_000, MaxDailyAmount: 5_000_000, Fee: 1500, Disabled: true},
+	}
+	testCatalog = Catalog{gopay, ovo}
+)
+
+func TestProviders(t *testing.T) {
+	svc := NewService(logger.New(), NewMockRepository(t), NewMockCoreBanking(t), NewMockDirectory(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, testCatalog)
+
+	assert.Equal(t, []*Provider{gopay}, svc.Providers(context.Background()))
+}
+
+func TestTopUpInquirySuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		directoryMock   = NewMockDirectory(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, directoryMock, seqGenMock, intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, testCatalog)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&intrabank.Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+		}, nil)
+
+	repoMock.EXPECT().SumTopUpAmount(mock.Anything, "123", "GOPAY", mock.Anything, mock.Anything).
+		Return(0, nil)
+	repoMock.EXPECT().InsertSequence(mock.Anything, mock.MatchedBy(func(seq *intrabank.Sequence) bool {
+		return seq.SequenceNumber == "123456" &&
+			seq.TransactionType == "ewallet_topup" &&
+			seq.Amount == 100000 &&
+			seq.Fee == 1000 &&
+			seq.DestinationAccount == "081234567890" &&
+			seq.DestinationName == "OLIVIA RODRIGO"
+	})).Return(nil)
+	repoMock.EXPECT().InsertTopUp(mock.Anything, mock.Anything).
+		Return(nil)
+
+	directoryMock.EXPECT().FindAccount(mock.Anything, gopay, "081234567890").
+		Return(&Account{PhoneNumber: "081234567890", Name: "OLIVIA RODRIGO"}, nil)
+
+	seqGenMock.EXPECT().Generate().
+		Return("123456", nil)
+
+	topUp, err := svc.Inquiry(ctx, &InquiryInput{
+		ProviderCode:  "GOPAY",
+		PhoneNumber:   "+62 812-3456-7890",
+		Amount:        100000,
+		SourceAccount: "001001234567891",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "123456", topUp.SequenceNumber)
+	assert.Equal(t, "GoPay", topUp.ProviderName)
+	assert.Equal(t, "081234567890", topUp.PhoneNumber)
+	assert.Equal(t, intrabank.Money(101000), topUp.Total())
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	directoryMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTopUpInquiryFailed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *InquiryInput
+		want  error
+	}{
+		{
+			name:  "provider not found",
+			input: &InquiryInput{ProviderCode: "LINKAJA", PhoneNumber: "081234567890", Amount: 100000},
+			want: pkgerror.New(codes.BadRequest, ErrProviderNotFound).
+				SetMsg("This e-wallet is not supported."),
+		},
+		{
+			name:  "provider disabled",
+			input: &InquiryInput{ProviderCode: "OVO", PhoneNumber: "081234567890", Amount: 100000},
+			want: pkgerror.New(codes.Forbidden, intrabank.ErrTransferMethodDisabled).
+				SetMsg("OVO top-ups are temporarily unavailable. Please try again later."),
+		},
+		{
+			name:  "invalid phone number",
+			input: &InquiryInput{ProviderCode: "GOPAY", PhoneNumber: "0215551234", Amount: 100000},
+			want: pkgerror.New(codes.BadRequest, ErrInvalidPhoneNumber).
+				SetMsg("Please enter a valid phone number."),
+		},
+		{
+			name:  "above provider limit",
+			input: &InquiryInput{ProviderCode: "GOPAY", PhoneNumber: "081234567890", Amount: 2_500_000},
+			want: pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+				SetMsg("Your top-up amount is not within the limits of GoPay top-ups."),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				corebankingMock = NewMockCoreBanking(t)
+				svc             = NewService(logger.New(), NewMockRepository(t), corebankingMock, NewMockDirectory(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, testCatalog)
+				ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+					ID:       123,
+					CIF:      "1234567",
+					Name:     "Olivia Rodrigo",
+					Email:    "olivia@gmail.com",
+					DeviceID: "device-1",
+				})
+			)
+
+			corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+				Status:        "FINISHED",
+				StandInStatus: "N",
+			}, nil)
+
+			topUp, err := svc.Inquiry(ctx, tt.input)
+
+			assert.Nil(t, topUp)
+			assert.Equal(t, tt.want, err)
+
+			corebankingMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTopUpInquiryFailed_DailyLimit(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockDirectory(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, testCatalog)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().SumTopUpAmount(mock.Anything, "123", "GOPAY", mock.Anything, mock.Anything).
+		Return(4_950_000, nil)
+
+	topUp, err := svc.Inquiry(ctx, &InquiryInput{
+		ProviderCode:  "GOPAY",
+		PhoneNumber:   "081234567890",
+		Amount:        100000,
+		SourceAccount: "001001234567891",
+	})
+
+	assert.Nil(t, topUp)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrDailyLimitExceeded).
+		SetMsg("You have reached your daily GoPay top-up limit. Please try again tomorrow."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestTopUpInquiryFailed_AccountNotFound(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		directoryMock   = NewMockDirectory(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, directoryMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, testCatalog)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().SumTopUpAmount(mock.Anything, "123", "GOPAY", mock.Anything, mock.Anything).
+		Return(0, nil)
+
+	directoryMock.EXPECT().FindAccount(mock.Anything, gopay, "081234560000").
+		Return(nil, ErrAccountNotFound)
+
+	topUp, err := svc.Inquiry(ctx, &InquiryInput{
+		ProviderCode:  "GOPAY",
+		PhoneNumber:   "081234560000",
+		Amount:        100000,
+		SourceAccount: "001001234567891",
+	})
+
+	assert.Nil(t, topUp)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrAccountNotFound).
+		SetMsg("The GoPay account is not found. Please check the phone number and try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	directoryMock.AssertExpectations(t)
+}
+
+var (
+	gopayPosting = &Posting{
+		SourceAccount: "001001234567891",
+		PhoneNumber:   "081234567890",
+		Provider:      "GOPAY",
+		WalletType:    "GOPAY",
+		Amount:        100000,
+		Fee:           1000,
+		Remark:        "TOPUP GOPAY 081234567890 123456",
+		Reference:     "123456",
+	}
+	gopayPaymentInput = &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "081234567890",
+		Amount:             100000,
+	}
+)
+
+func TestTopUpDoPaymentSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockDirectory(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, testCatalog)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().TopUp(mock.Anything, gopayPosting).
+		Return(&intrabank.OverbookingResult{
+			JournalSequence:      "000001",
+			TransactionReference: "TOPUP000001",
+		}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             100000,
+			Fee:                1000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "081234567890",
+			SourceName:         "Olivia Rodrigo",
+			DestinationName:    "OLIVIA RODRIGO",
+			TransactionType:    "ewallet_topup",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetTopUp(mock.Anything, "123456").
+		Return(&TopUp{
+			SequenceNumber: "123456",
+			ProviderCode:   "GOPAY",
+			ProviderName:   "GoPay",
+			PhoneNumber:    "081234567890",
+			AccountName:    "OLIVIA RODRIGO",
+			Amount:         100000,
+			Fee:            1000,
+			SourceAccount:  "001001234567891",
+		}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTopUpAmount(mock.Anything, "123", "GOPAY", mock.Anything, mock.Anything).
+		Return(100000, nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, mock.Anything, mock.MatchedBy(func(outbox []*intrabank.OutboxMessage) bool {
+		if len(outbox) != 1 {
+			return false
+		}
+		email, err := outbox[0].Receipt()
+		return err == nil &&
+			email.Subject == "Top Up E-Wallet Berhasil" &&
+			email.DestinationBank == "GoPay" &&
+			email.Status == intrabank.TransactionSuccess
+	})).Return(nil)
+
+	transaction, err := svc.DoPayment(ctx, gopayPaymentInput)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &intrabank.Transaction{
+		SequenceNumber:       "123456",
+		UserID:               "123",
+		Destination:          "081234567890",
+		Amount:               100000,
+		TransactionType:      "ewallet_topup",
+		Remarks:              "TOPUP GOPAY 081234567890 123456",
+		Status:               intrabank.TransactionSuccess,
+		Fee:                  "1000",
+		DestinationName:      "OLIVIA RODRIGO",
+		SequenceJournal:      "000001",
+		TransactionReference: "TOPUP000001",
+	}, transaction)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestTopUpDoPaymentFailed_Rejected(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockDirectory(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, testCatalog)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().TopUp(mock.Anything, gopayPosting).
+		Return(nil, &intrabank.OverbookingRejection{StatusCode: "61", Description: "e-wallet balance limit exceeded"})
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             100000,
+			Fee:                1000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "081234567890",
+			SourceName:         "Olivia Rodrigo",
+			DestinationName:    "OLIVIA RODRIGO",
+			TransactionType:    "ewallet_topup",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetTopUp(mock.Anything, "123456").
+		Return(&TopUp{
+			SequenceNumber: "123456",
+			ProviderCode:   "GOPAY",
+			ProviderName:   "GoPay",
+			PhoneNumber:    "081234567890",
+			AccountName:    "OLIVIA RODRIGO",
+			Amount:         100000,
+			Fee:            1000,
+			SourceAccount:  "001001234567891",
+		}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTopUpAmount(mock.Anything, "123", "GOPAY", mock.Anything, mock.Anything).
+		Return(100000, nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("firebase-1", nil)
+	repoMock.EXPECT().FailTransaction(mock.Anything, mock.MatchedBy(func(transaction *intrabank.Transaction) bool {
+		return transaction.Status == intrabank.TransactionFailed && transaction.StatusCode == "61"
+	}), mock.MatchedBy(func(outbox []*intrabank.OutboxMessage) bool {
+		return len(outbox) == 2
+	})).Return(nil)
+
+	transaction, err := svc.DoPayment(ctx, gopayPaymentInput)
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+		SetMsg("Your top-up was rejected. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestTopUpDoPaymentFailed_OutcomeUnknown(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockDirectory(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, testCatalog)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().TopUp(mock.Anything, gopayPosting).
+		Return(nil, errors.New("timeout"))
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             100000,
+			Fee:                1000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "081234567890",
+			SourceName:         "Olivia Rodrigo",
+			DestinationName:    "OLIVIA RODRIGO",
+			TransactionType:    "ewallet_topup",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetTopUp(mock.Anything, "123456").
+		Return(&TopUp{
+			SequenceNumber: "123456",
+			ProviderCode:   "GOPAY",
+			ProviderName:   "GoPay",
+			PhoneNumber:    "081234567890",
+			AccountName:    "OLIVIA RODRIGO",
+			Amount:         100000,
+			Fee:            1000,
+			SourceAccount:  "001001234567891",
+		}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTopUpAmount(mock.Anything, "123", "GOPAY", mock.Anything, mock.Anything).
+		Return(100000, nil)
+
+	transaction, err := svc.DoPayment(ctx, gopayPaymentInput)
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrTopUpPending).
+		SetMsg("Your top-up is being processed. Please check your transaction history."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package ewallet
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTransactionAuthorizer is an autogenerated mock type for the TransactionAuthorizer type
+type MockTransactionAuthorizer struct {
+	mock.Mock
+}
+
+type MockTransactionAuthorizer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransactionAuthorizer) EXPECT() *MockTransactionAuthorizer_Expecter {
+	return &MockTransactionAuthorizer_Expecter{mock: &_m.Mock}
+}
+
+// VerifyTransaction provides a mock function with given fields: ctx, id, code, reference
+func (_m *MockTransactionAuthorizer) VerifyTransaction(ctx context.Context, id int, code string, reference string) error {
+	ret := _m.Called(ctx, id, code, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) error); ok {
+		r0 = rf(ctx, id, code, reference)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionAuthorizer_VerifyTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyTransaction'
+type MockTransactionAuthorizer_VerifyTransaction_Call struct {
+	*mock.Call
+}
+
+// VerifyTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - code string
+//   - reference string
+func (_e *MockTransactionAuthorizer_Expecter) VerifyTransaction(ctx interface{}, id interface{}, code interface{}, reference interface{}) *MockTransactionAuthorizer_VerifyTransaction_Call {
+	return &MockTransactionAuthorizer_VerifyTransaction_Call{Call: _e.mock.On("VerifyTransaction", ctx, id, code, reference)}
+}
+
+func (_c *MockTransactionAuthorizer_VerifyTransaction_Call) Run(run func(ctx context.Context, id int, code string, reference string)) *MockTransactionAuthorizer_VerifyTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockTransactionAuthorizer_VerifyTransaction_Call) Return(_a0 error) *MockTransactionAuthorizer_VerifyTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionAuthorizer_VerifyTransaction_Call) RunAndReturn(run func(context.Context, int, string, string) error) *MockTransactionAuthorizer_VerifyTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTransactionAuthorizer creates a new instance of MockTransactionAuthorizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransactionAuthorizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransactionAuthorizer {
+	mock := &MockTransactionAuthorizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,29 @@
+package interbank
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// CoreBanking defines the core banking operations of the interbank transfers.
+type CoreBanking interface {
+	// GetCoreStatus gets the current status of the core banking system.
+	GetCoreStatus(ctx context.Context) (*intrabank.CoreStatus, error)
+
+	// GetAccountDetails retrieves account information for the given account number.
+	GetAccountDetails(ctx context.Context, accountNumber string) (*intrabank.Account, error)
+
+	// GetPostingStatus retrieves the outcome of the posting with the reference.
+	// It returns the result of a completed posting, an *intrabank.OverbookingRejection if the posting was rejected
+	// and intrabank.ErrPostingNotFound if the core banking system has never received it.
+	GetPostingStatus(ctx context.Context, reference string) (*intrabank.OverbookingResult, error)
+
+	// DebitTransfer posts the transfer from the source account to the settlement account of the switching network.
+	// It returns an *intrabank.OverbookingRejection when the posting has been rejected.
+	DebitTransfer(ctx context.Context, in *Debit) (*intrabank.OverbookingResult, error)
+
+	// ReverseTransfer returns a debited transfer from the settlement account of the switching network to the source account.
+	// Returns an error if the operation fails.
+	ReverseTransfer(ctx context.Context, in *Debit) (*intrabank.OverbookingResult, error)
+}
@@ -0,0 +1,34 @@
+package interbank
+
+import (
+	"errors"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+var (
+	// ErrGeneral indicates a general error, it is shared with the payment pipeline of the intrabank transfers.
+	ErrGeneral = intrabank.ErrGeneral
+
+	// ErrUnauthenticatedUser indicates that the user is not authenticated.
+	ErrUnauthenticatedUser = intrabank.ErrUnauthenticatedUser
+
+	// ErrBankNotFound is returned when the bank code is not in the bank directory.
+	ErrBankNotFound = errors.New("bank not found")
+
+	// ErrRailNotSupported indicates that the destination bank cannot receive transfers through the rail.
+	ErrRailNotSupported = errors.New("rail not supported")
+
+	// ErrRailUnavailable is returned by the gateway when the switching network cannot be reached.
+	ErrRailUnavailable = errors.New("rail unavailable")
+
+	// ErrDestinationAccountNotFound is returned when the destination account does not exist at the bank.
+	ErrDestinationAccountNotFound = errors.New("destination account not found")
+
+	// ErrTransferNotFound is returned by the gateway when the switching network has never received the transfer.
+	ErrTransferNotFound = errors.New("transfer not found")
+
+	// ErrTransferPending indicates that the outcome of the transfer at the gateway is unknown,
+	// so the transaction is left pending until it is settled.
+	ErrTransferPending = intrabank.ErrPaymentPending
+)
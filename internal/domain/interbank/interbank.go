@@ -0,0 +1,126 @@
+// Package interbank provides the transfers to accounts at other banks through the interbank rails.
+// The transfers reuse the intrabank sequence and transaction model,
+// a sequence of an interbank transfer carries the bank code and the rail of the destination.
+package interbank
+
+import (
+	"fmt"
+	"slices"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// Rail is an interbank payment rail.
+type Rail string
+
+const (
+	// RailOnline is the real-time online transfer through the ATM switching networks.
+	RailOnline Rail = "ONLINE"
+	// RailBIFast is the real-time BI-FAST transfer.
+	RailBIFast Rail = "BIFAST"
+	// RailSKN is the batch clearing transfer of the SKN system.
+	RailSKN Rail = "SKN"
+	// RailRTGS is the real-time gross settlement transfer for large amounts.
+	RailRTGS Rail = "RTGS"
+)
+
+// rails are all the interbank rails.
+var rails = []Rail{RailOnline, RailBIFast, RailSKN, RailRTGS}
+
+// TransactionTypes returns the transaction types of the transfers through all the rails.
+func TransactionTypes() []string {
+	types := make([]string, 0, len(rails))
+	for _, rail := range rails {
+		types = append(types, rail.TransactionType())
+	}
+	return types
+}
+
+// NewRail creates a new Rail from the given string.
+func NewRail(rail string) Rail {
+	return Rail(rail)
+}
+
+// String converts the Rail value to its string representation.
+func (r Rail) String() string {
+	return string(r)
+}
+
+// TransactionType returns the transaction type of the transfers through the rail,
+// the transfer method with this type holds the fee, limits and operating hours of the rail.
+func (r Rail) TransactionType() string {
+	return fmt.Sprintf("interbank_%s", r)
+}
+
+// Bank is an entry of the bank directory.
+type Bank struct {
+	Code  string
+	Name  string
+	Rails []Rail
+}
+
+// Supports checks if the bank can receive transfers through the rail.
+func (b *Bank) Supports(rail Rail) bool {
+	return slices.Contains(b.Rails, rail)
+}
+
+// DestinationAccount is the account at another bank, as returned by the account name check.
+type DestinationAccount struct {
+	BankCode      string
+	AccountNumber string
+	Name          string
+}
+
+// TransferInput represents the transfer sent to the interbank gateway.
+type TransferInput struct {
+	Rail               Rail
+	BankCode           string
+	SourceAccount      string
+	SourceName         string
+	DestinationAccount string
+	DestinationName    string
+	Amount             intrabank.Money
+	Fee                intrabank.Money
+	Reference          string
+	Remark             string
+}
+
+// TransferResult represents the outcome of a transfer accepted by the interbank gateway.
+type TransferResult struct {
+	JournalSequence      string
+	TransactionReference string
+}
+
+// TransferRejection is returned by the gateway when the transfer has been rejected,
+// so no money has been moved.
+type TransferRejection struct {
+	StatusCode  string
+	Description string
+	Payload     string
+}
+
+func (r *TransferRejection) Error() string {
+	return fmt.Sprintf("interbank transfer rejected: %s (%s)", r.Description, r.StatusCode)
+}
+
+// Debit is the posting of an interbank transfer from the source account to the settlement account
+// of the switching network, the money leaves the account before the transfer is sent to the destination bank.
+// The Reference identifies the posting, its reversal refers to it, so the debit can only be reversed once.
+type Debit struct {
+	SourceAccount string
+	BankCode      string
+	Amount        intrabank.Money
+	Fee           intrabank.Money
+	Remark        string
+	Reference     string
+}
+
+// remark returns the transfer remark of the sequence.
+func remark(seq *intrabank.Sequence) string {
+	return fmt.Sprintf("TRF %v %v %v %v",
+		seq.SourceAccount,
+		seq.BankCode,
+		seq.DestinationAccount,
+		seq.SequenceNumber,
+	)
+}
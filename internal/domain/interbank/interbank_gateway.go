@@ -0,0 +1,20 @@
+package interbank
+
+import "context"
+
+// InterbankGateway defines methods of the switching network connecting the banks.
+type InterbankGateway interface {
+	// CheckAccount retrieves the name of the destination account at the bank.
+	// Returns ErrDestinationAccountNotFound if the account does not exist.
+	CheckAccount(ctx context.Context, bankCode, accountNumber string) (*DestinationAccount, error)
+
+	// Transfer credits the destination account at the other bank through the rail,
+	// the transfer has been debited to the settlement account of the switching network.
+	// It returns a *TransferRejection when the transfer has been rejected.
+	Transfer(ctx context.Context, in *TransferInput) (*TransferResult, error)
+
+	// TransferStatus retrieves the outcome of the transfer with the reference.
+	// It returns a *TransferRejection when the transfer has been rejected
+	// and ErrTransferNotFound if the switching network has never received it.
+	TransferStatus(ctx context.Context, reference string) (*TransferResult, error)
+}
@@ -0,0 +1,214 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package interbank
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockInterbankGateway is an autogenerated mock type for the InterbankGateway type
+type MockInterbankGateway struct {
+	mock.Mock
+}
+
+type MockInterbankGateway_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInterbankGateway) EXPECT() *MockInterbankGateway_Expecter {
+	return &MockInterbankGateway_Expecter{mock: &_m.Mock}
+}
+
+// CheckAccount provides a mock function with given fields: ctx, bankCode, accountNumber
+func (_m *MockInterbankGateway) CheckAccount(ctx context.Context, bankCode string, accountNumber string) (*DestinationAccount, error) {
+	ret := _m.Called(ctx, bankCode, accountNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAccount")
+	}
+
+	var r0 *DestinationAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*DestinationAccount, error)); ok {
+		return rf(ctx, bankCode, accountNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *DestinationAccount); ok {
+		r0 = rf(ctx, bankCode, accountNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*DestinationAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bankCode, accountNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterbankGateway_CheckAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckAccount'
+type MockInterbankGateway_CheckAccount_Call struct {
+	*mock.Call
+}
+
+// CheckAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bankCode string
+//   - accountNumber string
+func (_e *MockInterbankGateway_Expecter) CheckAccount(ctx interface{}, bankCode interface{}, accountNumber interface{}) *MockInterbankGateway_CheckAccount_Call {
+	return &MockInterbankGateway_CheckAccount_Call{Call: _e.mock.On("CheckAccount", ctx, bankCode, accountNumber)}
+}
+
+func (_c *MockInterbankGateway_CheckAccount_Call) Run(run func(ctx context.Context, bankCode string, accountNumber string)) *MockInterbankGateway_CheckAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockInterbankGateway_CheckAccount_Call) Return(_a0 *DestinationAccount, _a1 error) *MockInterbankGateway_CheckAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterbankGateway_CheckAccount_Call) RunAndReturn(run func(context.Context, string, string) (*DestinationAccount, error)) *MockInterbankGateway_CheckAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Transfer provides a mock function with given fields: ctx, in
+func (_m *MockInterbankGateway) Transfer(ctx context.Context, in *TransferInput) (*TransferResult, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transfer")
+	}
+
+	var r0 *TransferResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *TransferInput) (*TransferResult, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *TransferInput) *TransferResult); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*TransferResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *TransferInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterbankGateway_Transfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transfer'
+type MockInterbankGateway_Transfer_Call struct {
+	*mock.Call
+}
+
+// Transfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *TransferInput
+func (_e *MockInterbankGateway_Expecter) Transfer(ctx interface{}, in interface{}) *MockInterbankGateway_Transfer_Call {
+	return &MockInterbankGateway_Transfer_Call{Call: _e.mock.On("Transfer", ctx, in)}
+}
+
+func (_c *MockInterbankGateway_Transfer_Call) Run(run func(ctx context.Context, in *TransferInput)) *MockInterbankGateway_Transfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*TransferInput))
+	})
+	return _c
+}
+
+func (_c *MockInterbankGateway_Transfer_Call) Return(_a0 *TransferResult, _a1 error) *MockInterbankGateway_Transfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterbankGateway_Transfer_Call) RunAndReturn(run func(context.Context, *TransferInput) (*TransferResult, error)) *MockInterbankGateway_Transfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransferStatus provides a mock function with given fields: ctx, reference
+func (_m *MockInterbankGateway) TransferStatus(ctx context.Context, reference string) (*TransferResult, error) {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferStatus")
+	}
+
+	var r0 *TransferResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*TransferResult, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *TransferResult); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*TransferResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterbankGateway_TransferStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransferStatus'
+type MockInterbankGateway_TransferStatus_Call struct {
+	*mock.Call
+}
+
+// TransferStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockInterbankGateway_Expecter) TransferStatus(ctx interface{}, reference interface{}) *MockInterbankGateway_TransferStatus_Call {
+	return &MockInterbankGateway_TransferStatus_Call{Call: _e.mock.On("TransferStatus", ctx, reference)}
+}
+
+func (_c *MockInterbankGateway_TransferStatus_Call) Run(run func(ctx context.Context, reference string)) *MockInterbankGateway_TransferStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockInterbankGateway_TransferStatus_Call) Return(_a0 *TransferResult, _a1 error) *MockInterbankGateway_TransferStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterbankGateway_TransferStatus_Call) RunAndReturn(run func(context.Context, string) (*TransferResult, error)) *MockInterbankGateway_TransferStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInterbankGateway creates a new instance of MockInterbankGateway. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInterbankGateway(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInterbankGateway {
+	mock := &MockInterbankGateway{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,45 @@
+package interbank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+func TestRailTransactionType(t *testing.T) {
+	assert.Equal(t, "interbank_ONLINE", RailOnline.TransactionType())
+	assert.Equal(t, "interbank_BIFAST", RailBIFast.TransactionType())
+	assert.Equal(t, "interbank_SKN", RailSKN.TransactionType())
+	assert.Equal(t, "interbank_RTGS", RailRTGS.TransactionType())
+}
+
+func TestBankSupports(t *testing.T) {
+	bank := &Bank{
+		Code:  "014",
+		Name:  "BCA",
+		Rails: []Rail{RailOnline, RailBIFast},
+	}
+
+	assert.True(t, bank.Supports(RailOnline))
+	assert.True(t, bank.Supports(RailBIFast))
+	assert.False(t, bank.Supports(RailRTGS))
+	assert.False(t, bank.Supports(NewRail("")))
+}
+
+func TestRemark(t *testing.T) {
+	seq := &intrabank.Sequence{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		BankCode:           "014",
+	}
+
+	assert.Equal(t, "TRF 001001234567891 014 1234567890 123456", remark(seq))
+}
+
+func TestTransferRejectionError(t *testing.T) {
+	err := &TransferRejection{StatusCode: "76", Description: "invalid account"}
+
+	assert.EqualError(t, err, "interbank transfer rejected: invalid account (76)")
+}
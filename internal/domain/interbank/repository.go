@@ -0,0 +1,43 @@
+package interbank
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// Repository defines methods to persist and retrieve the bank directory and the interbank transfers.
+type Repository interface {
+	intrabank.PaymentRepository
+
+	// GetBanks retrieves the bank directory ordered by the bank name.
+	// Returns an error if the operation fails.
+	GetBanks(ctx context.Context) ([]*Bank, error)
+
+	// GetBank retrieves the bank with the code.
+	// Returns ErrBankNotFound if the bank is not in the directory.
+	GetBank(ctx context.Context, code string) (*Bank, error)
+
+	// GetRailLimits retrieves the fee, limits and operating hours of the rail.
+	// Returns an error if the operation fails.
+	GetRailLimits(ctx context.Context, rail Rail) (*intrabank.Limits, error)
+
+	// InsertSequence persists a new sequence.
+	// Returns an error if the operation fails.
+	InsertSequence(ctx context.Context, seq *intrabank.Sequence) error
+
+	// HasTransferredToBank checks if the user has a successful transfer to the destination account at the bank.
+	// Returns an error if the operation fails.
+	HasTransferredToBank(ctx context.Context, userID, bankCode, destination string) (bool, error)
+
+	// CountTransfersOfType counts the user's successful and pending transfers of the transaction type
+	// created within the [from, to) time range.
+	// Returns an error if the operation fails.
+	CountTransfersOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (int, error)
+
+	// SumTransferAmountOfType sums the amount of the user's successful and pending transfers
+	// of the transaction type created within the [from, to) time range.
+	// Returns an error if the operation fails.
+	SumTransferAmountOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (intrabank.Money, error)
+}
@@ -0,0 +1,503 @@
+package interbank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+const (
+	domainName             = "interbank"
+	transferSuccessSubject = "Transfer Berhasil"
+	transferFailedSubject  = "Transfer Gagal"
+)
+
+// Service handles the transfers to accounts at other banks.
+type Service struct {
+	log         *logger.Logger
+	repo        Repository
+	corebanking CoreBanking
+	gateway     InterbankGateway
+	seqGen      SequenceGenerator
+	validity    intrabank.SequenceValidity
+	authorizer  TransactionAuthorizer
+	stepUp      intrabank.StepUpPolicy
+	fees        intrabank.FeePolicy
+	payer       *intrabank.Payer[*transfer]
+}
+
+// NewService creates a new instance of Service.
+func NewService(
+	log *logger.Logger,
+	repo Repository,
+	corebanking CoreBanking,
+	gateway InterbankGateway,
+	seqGen SequenceGenerator,
+	validity intrabank.SequenceValidity,
+	authorizer TransactionAuthorizer,
+	stepUp intrabank.StepUpPolicy,
+	fees intrabank.FeePolicy,
+) *Service {
+	s := &Service{
+		log:         log,
+		repo:        repo,
+		corebanking: corebanking,
+		gateway:     gateway,
+		seqGen:      seqGen,
+		validity:    validity,
+		authorizer:  authorizer,
+		stepUp:      stepUp,
+		fees:        fees,
+	}
+	s.payer = intrabank.NewPayer[*transfer](log, domainName, repo, corebanking, authorizer, stepUp, &transferMethod{s})
+	return s
+}
+
+// Banks returns the bank directory.
+func (s *Service) Banks(ctx context.Context) ([]*Bank, error) {
+	banks, err := s.repo.GetBanks(ctx)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Banks").Errorf("GetBanks: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return banks, nil
+}
+
+// Inquiry checks the transfer to the account at another bank and creates its sequence.
+// The sequence carries the bank code and the rail, the destination name is verified through the gateway.
+func (s *Service) Inquiry(ctx context.Context, seq *intrabank.Sequence) (*intrabank.Sequence, error) {
+	if err := s.checkEOD(ctx, "Inquiry"); err != nil {
+		return nil, err
+	}
+
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	rail := NewRail(seq.Rail)
+	_, limits, err := s.railLimits(ctx, "Inquiry", seq.BankCode, rail)
+	if err != nil {
+		return nil, err
+	}
+	if !limits.CanTransfer(seq.Amount) {
+		s.log.DomainUsecase(domainName, "Inquiry").Error(intrabank.ErrInvalidAmount)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+			SetMsg("Your transfer amount is not within the limits of this transfer method.")
+	}
+
+	from, to := intrabank.BusinessDay(time.Now())
+	dailyAmount, err := s.repo.SumTransferAmountOfType(ctx, strconv.Itoa(user.ID), rail.TransactionType(), from, to)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("SumTransferAmountOfType: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !limits.WithinDailyLimit(dailyAmount + seq.Amount) {
+		s.log.DomainUsecase(domainName, "Inquiry").Error(intrabank.ErrDailyLimitExceeded)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrDailyLimitExceeded).
+			SetMsg("You have reached your daily transfer limit. Please try again tomorrow.")
+	}
+
+	srcAccount, err := s.corebanking.GetAccountDetails(ctx, seq.SourceAccount)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetAccountDetails: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !srcAccount.IsOwnedBy(user.CIF) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) not owned by user (%v)", seq.SourceAccount, user.ID)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrSourceAccountNotOwned).
+			SetMsg("You can only transfer from your own account.")
+	}
+	if !srcAccount.IsAccountActive() {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) not active", seq.SourceAccount)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrSourceAccountInactive)
+	}
+
+	seq.Fee, err = s.transferFee(ctx, user.ID, rail, seq, limits.Fee, srcAccount.ProductType)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("CountTransfersOfType: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !srcAccount.CanDebit(seq.Amount + seq.Fee) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) cannot be debited by %v", seq.SourceAccount, seq.Amount+seq.Fee)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrInsufficientBalance).
+			SetMsg("Your balance is not enough for this transfer.")
+	}
+
+	seq.SourceName = srcAccount.Name
+
+	destAccount, err := s.gateway.CheckAccount(ctx, seq.BankCode, seq.DestinationAccount)
+	if errors.Is(err, ErrDestinationAccountNotFound) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("CheckAccount: %v", err)
+		return nil, pkgerror.New(codes.BadRequest, ErrDestinationAccountNotFound).
+			SetMsg("The destination account was not found. Please check the bank and account number.")
+	}
+	if errors.Is(err, ErrRailUnavailable) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("CheckAccount: %v", err)
+		return nil, pkgerror.New(codes.Forbidden, ErrRailUnavailable).
+			SetMsg("Interbank transfers are temporarily unavailable. Please try again later.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("CheckAccount: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	seq.DestinationName = destAccount.Name
+
+	sequenceNo, err := s.seqGen.Generate()
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("Generate failed: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	now := time.Now()
+	seq.SequenceNumber = sequenceNo
+	seq.TransactionType = rail.TransactionType()
+	seq.Status = intrabank.SequenceCreated
+	seq.UserID = user.ID
+	seq.DeviceID = user.DeviceID
+	seq.CreatedAt = now
+	seq.ExpiresAt = now.Add(s.validity.For(rail.TransactionType()))
+
+	err = s.repo.InsertSequence(ctx, seq)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("InsertSequence: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return seq, nil
+}
+
+// SettlePending settles the transfers left pending, e.g. when the outcome at the switching network was unknown.
+func (s *Service) SettlePending(ctx context.Context) error {
+	return s.payer.SettlePending(ctx)
+}
+
+// DoPayment pays the interbank sequence through the gateway.
+// A transfer whose outcome is unknown is left pending, because the money may have moved.
+func (s *Service) DoPayment(ctx context.Context, in *intrabank.PaymentInput) (*intrabank.Transaction, error) {
+	payment, err := s.payer.Pay(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return payment.Transaction, nil
+}
+
+// checkEOD rejects the transfer while the end of day process of the core banking system is running.
+func (s *Service) checkEOD(ctx context.Context, usecase string) error {
+	coreStatus, err := s.corebanking.GetCoreStatus(ctx)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("CheckEOD: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if coreStatus.IsEODRunning() {
+		s.log.DomainUsecase(domainName, usecase).Errorf("CheckEOD: %v", intrabank.ErrEODInProgress)
+		return pkgerror.New(codes.Internal, intrabank.ErrEODInProgress)
+	}
+	return nil
+}
+
+// railLimits loads the destination bank and the limits of the rail,
+// and rejects the transfer when the bank does not support the rail or the rail is not available.
+func (s *Service) railLimits(ctx context.Context, usecase, bankCode string, rail Rail) (*Bank, *intrabank.Limits, error) {
+	bank, err := s.repo.GetBank(ctx, bankCode)
+	if errors.Is(err, ErrBankNotFound) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetBank (%v): %v", bankCode, err)
+		return nil, nil, pkgerror.New(codes.NotFound, ErrBankNotFound).
+			SetMsg("The destination bank is not available.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetBank: %v", err)
+		return nil, nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !bank.Supports(rail) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("bank (%v) rail (%v): %v", bankCode, rail, ErrRailNotSupported)
+		return nil, nil, pkgerror.New(codes.BadRequest, ErrRailNotSupported).
+			SetMsg(fmt.Sprintf("%s does not accept %s transfers.", bank.Name, rail))
+	}
+
+	limits, err := s.repo.GetRailLimits(ctx, rail)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetRailLimits: %v", err)
+		return nil, nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if limits.Disabled {
+		s.log.DomainUsecase(domainName, usecase).Errorf("rail (%v): %v", rail, intrabank.ErrTransferMethodDisabled)
+		return nil, nil, pkgerror.New(codes.Forbidden, intrabank.ErrTransferMethodDisabled).
+			SetMsg("Transfers are temporarily unavailable. Please try again later.")
+	}
+	if now := time.Now(); !limits.IsOpen(now) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("rail (%v): %v at %v", rail, intrabank.ErrOutsideOperatingHours, now)
+		return nil, nil, pkgerror.New(codes.Forbidden, intrabank.ErrOutsideOperatingHours).
+			SetMsg(fmt.Sprintf("%s transfers are only available from %02d:00 to %02d:00 WIB.", rail, limits.OpenHour, limits.CloseHour))
+	}
+
+	return bank, limits, nil
+}
+
+// transferFee calculates the fee of the sequence on the rail for the customer segment.
+// The transfer is free while the user has not used up the monthly free quota of the rail.
+func (s *Service) transferFee(ctx context.Context, userID int, rail Rail, seq *intrabank.Sequence, baseFee intrabank.Money, segment string) (intrabank.Money, error) {
+	in := &intrabank.FeeInput{
+		Method:  rail.TransactionType(),
+		Channel: seq.Channel,
+		Segment: segment,
+		Amount:  seq.Amount,
+		BaseFee: baseFee,
+	}
+	if quota := s.fees.FreeTransfers(in); quota > 0 {
+		from, to := intrabank.BusinessMonth(time.Now())
+		count, err := s.repo.CountTransfersOfType(ctx, strconv.Itoa(userID), rail.TransactionType(), from, to)
+		if err != nil {
+			return 0, err
+		}
+		if count < quota {
+			return 0, nil
+		}
+	}
+	return s.fees.Fee(in), nil
+}
+
+// transfer holds the rail, the destination bank and the rail limits loaded for an interbank payment.
+type transfer struct {
+	rail   Rail
+	bank   *Bank
+	limits *intrabank.Limits
+}
+
+// transferMethod pays the interbank sequences through the interbank gateway.
+type transferMethod struct {
+	*Service
+}
+
+var transferMessages = &intrabank.PaymentMessages{
+	Rejected:    "Your transfer request was rejected. Please try again.",
+	KeyReused:   "The idempotency key has been used for another transfer.",
+	Expired:     "Your transfer session has expired. Please start the transfer again.",
+	OTPRequired: "Please verify this transfer with the OTP sent to you.",
+	InProgress:  "Your transfer is being processed. Please check your transaction history.",
+	Failed:      "Your transfer has failed. Please create a new transfer.",
+	Pending:     "Your transfer is being processed. Please check your transaction history.",
+	NotRecorded: "Your transfer has been processed but is not recorded yet. Please check your transaction history later.",
+}
+
+func (m *transferMethod) Messages() *intrabank.PaymentMessages {
+	return transferMessages
+}
+
+func (m *transferMethod) Accepts(sequence *intrabank.Sequence) bool {
+	return sequence.IsInterbank()
+}
+
+// Prepare loads the destination bank and the rail limits, the rail is checked before the OTP,
+// so a closed rail does not use it up.
+func (m *transferMethod) Prepare(ctx context.Context, payment *intrabank.Payment[*transfer]) error {
+	sequence := payment.Sequence
+	rail := NewRail(sequence.Rail)
+	bank, limits, err := m.railLimits(ctx, "DoPayment", sequence.BankCode, rail)
+	if err != nil {
+		return err
+	}
+	if !limits.CanTransfer(sequence.Amount) {
+		m.log.DomainUsecase(domainName, "DoPayment").Error(intrabank.ErrInvalidAmount)
+		return pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+			SetMsg("Your transfer amount is not within the limits of this transfer method.")
+	}
+	payment.Details = &transfer{rail: rail, bank: bank, limits: limits}
+	return nil
+}
+
+func (m *transferMethod) KnownDestination(ctx context.Context, payment *intrabank.Payment[*transfer]) (bool, error) {
+	sequence := payment.Sequence
+	return m.repo.HasTransferredToBank(ctx, strconv.Itoa(payment.User.ID), sequence.BankCode, sequence.DestinationAccount)
+}
+
+func (m *transferMethod) Describe(payment *intrabank.Payment[*transfer]) (string, string) {
+	return payment.Details.rail.TransactionType(), remark(payment.Sequence)
+}
+
+// CheckDailyLimit checks the daily limit of the rail, it includes the pending transaction of the payment.
+func (m *transferMethod) CheckDailyLimit(ctx context.Context, payment *intrabank.Payment[*transfer]) error {
+	from, to := intrabank.BusinessDay(time.Now())
+	dailyAmount, err := m.repo.SumTransferAmountOfType(ctx, payment.Transaction.UserID, payment.Details.rail.TransactionType(), from, to)
+	if err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("SumTransferAmountOfType: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !payment.Details.limits.WithinDailyLimit(dailyAmount) {
+		m.log.DomainUsecase(domainName, "DoPayment").Error(intrabank.ErrDailyLimitExceeded)
+		return pkgerror.New(codes.BadRequest, intrabank.ErrDailyLimitExceeded).
+			SetMsg("You have reached your daily transfer limit. Please try again tomorrow.")
+	}
+	return nil
+}
+
+// Post debits the transfer to the settlement account of the switching network and sends it to the destination bank.
+// A transfer rejected by the network is reversed to the source account and returned as rejected,
+// it is left pending when the reversal fails, so the debited money is reconciled.
+func (m *transferMethod) Post(ctx context.Context, payment *intrabank.Payment[*transfer]) (*intrabank.OverbookingResult, error) {
+	sequence := payment.Sequence
+	transaction := payment.Transaction
+
+	debit := transferDebit(payment)
+	posting, err := m.corebanking.DebitTransfer(ctx, debit)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction.SequenceJournal = posting.JournalSequence
+	transaction.TransactionReference = posting.TransactionReference
+	if err := m.repo.RecordPosting(ctx, transaction); err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("RecordPosting: sequence (%v) journal (%v): %v",
+			transaction.SequenceNumber, transaction.SequenceJournal, err)
+	}
+
+	result, err := m.gateway.Transfer(ctx, &TransferInput{
+		Rail:               payment.Details.rail,
+		BankCode:           sequence.BankCode,
+		SourceAccount:      sequence.SourceAccount,
+		SourceName:         sequence.SourceName,
+		DestinationAccount: sequence.DestinationAccount,
+		DestinationName:    sequence.DestinationName,
+		Amount:             sequence.Amount,
+		Fee:                sequence.Fee,
+		Reference:          sequence.SequenceNumber,
+		Remark:             transaction.Remarks,
+	})
+	var rejection *TransferRejection
+	if errors.As(err, &rejection) {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("Transfer: %v", err)
+		if _, err := m.corebanking.ReverseTransfer(ctx, debit); err != nil {
+			return nil, fmt.Errorf("reverse transfer journal (%v): %w", transaction.SequenceJournal, err)
+		}
+		return nil, &intrabank.OverbookingRejection{
+			StatusCode:  rejection.StatusCode,
+			Description: rejection.Description,
+			Payload:     rejection.Payload,
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("transfer journal (%v): %w", transaction.SequenceJournal, err)
+	}
+
+	return &intrabank.OverbookingResult{
+		JournalSequence:      posting.JournalSequence,
+		TransactionReference: result.TransactionReference,
+	}, nil
+}
+
+// Rejected tells the user whether the debit was rejected or the destination bank rejected the debited transfer,
+// which has been returned to the source account.
+func (m *transferMethod) Rejected(payment *intrabank.Payment[*transfer], _ *intrabank.OverbookingRejection) error {
+	if payment.Transaction.SequenceJournal != "" {
+		return pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+			SetMsg("Your transfer was rejected by the destination bank. The amount has been returned to your account.")
+	}
+	return pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+		SetMsg("Your transfer was rejected. Please try again.")
+}
+
+// Load loads the destination bank of the transfer for its receipt.
+func (m *transferMethod) Load(ctx context.Context, payment *intrabank.Payment[*transfer]) error {
+	sequence := payment.Sequence
+	bank, err := m.repo.GetBank(ctx, sequence.BankCode)
+	if err != nil {
+		return err
+	}
+	payment.Details = &transfer{rail: NewRail(sequence.Rail), bank: bank}
+	return nil
+}
+
+// Resolve checks the debit of the transfer at the core banking system when its journal has not been recorded,
+// and then the transfer at the switching network. A debited transfer which has been rejected or never received
+// by the network is reversed, it is left pending when the reversal fails.
+func (m *transferMethod) Resolve(ctx context.Context, payment *intrabank.Payment[*transfer]) (*intrabank.OverbookingResult, error) {
+	sequence := payment.Sequence
+	transaction := payment.Transaction
+
+	if transaction.SequenceJournal == "" {
+		posting, err := m.corebanking.GetPostingStatus(ctx, sequence.SequenceNumber)
+		if err != nil {
+			return nil, err
+		}
+		transaction.SequenceJournal = posting.JournalSequence
+		transaction.TransactionReference = posting.TransactionReference
+	}
+
+	result, err := m.gateway.TransferStatus(ctx, sequence.SequenceNumber)
+	var rejection *TransferRejection
+	if errors.As(err, &rejection) || errors.Is(err, ErrTransferNotFound) {
+		if _, err := m.corebanking.ReverseTransfer(ctx, transferDebit(payment)); err != nil {
+			return nil, fmt.Errorf("reverse transfer journal (%v): %w", transaction.SequenceJournal, err)
+		}
+		if rejection == nil {
+			return nil, &intrabank.OverbookingRejection{Description: err.Error(), Payload: err.Error()}
+		}
+		return nil, &intrabank.OverbookingRejection{
+			StatusCode:  rejection.StatusCode,
+			Description: rejection.Description,
+			Payload:     rejection.Payload,
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("transfer status journal (%v): %w", transaction.SequenceJournal, err)
+	}
+
+	return &intrabank.OverbookingResult{
+		JournalSequence:      transaction.SequenceJournal,
+		TransactionReference: result.TransactionReference,
+	}, nil
+}
+
+// transferDebit returns the debit of the transfer to the settlement account of the switching network.
+func transferDebit(payment *intrabank.Payment[*transfer]) *Debit {
+	sequence := payment.Sequence
+	return &Debit{
+		SourceAccount: sequence.SourceAccount,
+		BankCode:      sequence.BankCode,
+		Amount:        sequence.Amount,
+		Fee:           sequence.Fee,
+		Remark:        payment.Transaction.Remarks,
+		Reference:     sequence.SequenceNumber,
+	}
+}
+
+// Receipt builds the receipt and the notification of the transfer, they are delivered
+// by the outbox dispatcher of the intrabank transfers.
+func (m *transferMethod) Receipt(payment *intrabank.Payment[*transfer]) (*intrabank.EmailData, *intrabank.Notification) {
+	transaction := payment.Transaction
+	subject := transferSuccessSubject
+	if transaction.Status == intrabank.TransactionFailed {
+		subject = transferFailedSubject
+	}
+
+	return &intrabank.EmailData{
+		Subject:            subject,
+		Recipient:          payment.User.Email,
+		Amount:             transaction.Amount,
+		Fee:                payment.Sequence.Fee,
+		SourceName:         payment.User.Name,
+		SourceAccount:      payment.Sequence.SourceAccount,
+		DestinationName:    transaction.DestinationName,
+		DestinationAccount: transaction.Destination,
+		DestinationBank:    payment.Details.bank.Name,
+		TransactionRef:     transaction.TransactionReference,
+		Note:               transaction.Remarks,
+		Status:             transaction.Status,
+	}, &intrabank.Notification{
+		Subject:     subject,
+		Amount:      transaction.Amount,
+		Destination: transaction.Destination,
+		Status:      transaction.Status,
+	}
+}
@@ -0,0 +1,837 @@
+package interbank
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+func TestBanksSuccess(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockCoreBanking(t), NewMockInterbankGateway(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+	)
+
+	repoMock.EXPECT().GetBanks(mock.Anything).
+		Return([]*Bank{{Code: "014", Name: "BCA", Rails: []Rail{RailOnline, RailBIFast}}}, nil)
+
+	banks, err := svc.Banks(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*Bank{{Code: "014", Name: "BCA", Rails: []Rail{RailOnline, RailBIFast}}}, banks)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestBanksFailed_GetBanksFailed(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockCoreBanking(t), NewMockInterbankGateway(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+	)
+
+	repoMock.EXPECT().GetBanks(mock.Anything).
+		Return(nil, errors.New("unexpected error"))
+
+	banks, err := svc.Banks(context.Background())
+
+	assert.Nil(t, banks)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestInterbankInquirySuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		gatewayMock     = NewMockInterbankGateway(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		fees            = intrabank.FeePolicy{
+			Rules: []intrabank.FeeRule{{Method: "interbank_BIFAST", Fee: 2500}, {Method: "interbank_ONLINE", Fee: 6500}},
+		}
+		svc = NewService(logger.New(), repoMock, corebankingMock, gatewayMock, seqGenMock, intrabank.SequenceValidity{"interbank_BIFAST": 10 * time.Minute}, authorizerMock, intrabank.StepUpPolicy{}, fees)
+		ctx = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&intrabank.Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
+		}, nil)
+
+	repoMock.EXPECT().GetBank(mock.Anything, "014").
+		Return(&Bank{Code: "014", Name: "BCA", Rails: []Rail{RailOnline, RailBIFast}}, nil)
+	repoMock.EXPECT().GetRailLimits(mock.Anything, RailBIFast).
+		Return(&intrabank.Limits{
+			MinAmount:      1,
+			MaxAmount:      250_000_000,
+			MaxDailyAmount: 250_000_000,
+			Fee:            6500,
+		}, nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "interbank_BIFAST", mock.Anything, mock.Anything).
+		Return(0, nil)
+	repoMock.EXPECT().InsertSequence(mock.Anything, mock.MatchedBy(func(seq *intrabank.Sequence) bool {
+		return seq.SequenceNumber == "123456" &&
+			seq.TransactionType == "interbank_BIFAST" &&
+			seq.ExpiresAt.Sub(seq.CreatedAt) == 10*time.Minute
+	})).Return(nil)
+
+	gatewayMock.EXPECT().CheckAccount(mock.Anything, "014", "1234567890").
+		Return(&DestinationAccount{BankCode: "014", AccountNumber: "1234567890", Name: "Taylor Swift"}, nil)
+
+	seqGenMock.EXPECT().Generate().
+		Return("123456", nil)
+
+	sequence, err := svc.Inquiry(ctx, &intrabank.Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		BankCode:           "014",
+		Rail:               "BIFAST",
+	})
+
+	assert.Nil(t, err)
+	sequence.CreatedAt, sequence.ExpiresAt = time.Time{}, time.Time{}
+	assert.Equal(t, &intrabank.Sequence{
+		SequenceNumber:     "123456",
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		SourceName:         "Olivia Rodrigo",
+		DestinationName:    "Taylor Swift",
+		TransactionType:    "interbank_BIFAST",
+		Status:             "CREATED",
+		UserID:             123,
+		DeviceID:           "device-1",
+		Fee:                2500,
+		BankCode:           "014",
+		Rail:               "BIFAST",
+	}, sequence)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	gatewayMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestInterbankInquiryFailed_BankNotFound(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockInterbankGateway(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:   123,
+			CIF:  "1234567",
+			Name: "Olivia Rodrigo",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetBank(mock.Anything, "999").
+		Return(nil, ErrBankNotFound)
+
+	sequence, err := svc.Inquiry(ctx, &intrabank.Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		BankCode:           "999",
+		Rail:               "BIFAST",
+	})
+
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrBankNotFound).
+		SetMsg("The destination bank is not available."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestInterbankInquiryFailed_RailNotSupported(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockInterbankGateway(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:   123,
+			CIF:  "1234567",
+			Name: "Olivia Rodrigo",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetBank(mock.Anything, "014").
+		Return(&Bank{Code: "014", Name: "BCA", Rails: []Rail{RailOnline}}, nil)
+
+	sequence, err := svc.Inquiry(ctx, &intrabank.Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		BankCode:           "014",
+		Rail:               "RTGS",
+	})
+
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrRailNotSupported).
+		SetMsg("BCA does not accept RTGS transfers."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestInterbankInquiryFailed_RailDisabled(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockInterbankGateway(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:   123,
+			CIF:  "1234567",
+			Name: "Olivia Rodrigo",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetBank(mock.Anything, "014").
+		Return(&Bank{Code: "014", Name: "BCA", Rails: []Rail{RailSKN}}, nil)
+	repoMock.EXPECT().GetRailLimits(mock.Anything, RailSKN).
+		Return(&intrabank.Limits{MaxAmount: 500_000_000, Disabled: true}, nil)
+
+	sequence, err := svc.Inquiry(ctx, &intrabank.Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		BankCode:           "014",
+		Rail:               "SKN",
+	})
+
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, intrabank.ErrTransferMethodDisabled).
+		SetMsg("Transfers are temporarily unavailable. Please try again later."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestInterbankInquiryFailed_DestinationAccountNotFound(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		gatewayMock     = NewMockInterbankGateway(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, gatewayMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:   123,
+			CIF:  "1234567",
+			Name: "Olivia Rodrigo",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&intrabank.Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+		}, nil)
+
+	repoMock.EXPECT().GetBank(mock.Anything, "014").
+		Return(&Bank{Code: "014", Name: "BCA", Rails: []Rail{RailOnline}}, nil)
+	repoMock.EXPECT().GetRailLimits(mock.Anything, RailOnline).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 50_000_000, MaxDailyAmount: 100_000_000}, nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "interbank_ONLINE", mock.Anything, mock.Anything).
+		Return(0, nil)
+
+	gatewayMock.EXPECT().CheckAccount(mock.Anything, "014", "1234560000").
+		Return(nil, ErrDestinationAccountNotFound)
+
+	sequence, err := svc.Inquiry(ctx, &intrabank.Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234560000",
+		BankCode:           "014",
+		Rail:               "ONLINE",
+	})
+
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrDestinationAccountNotFound).
+		SetMsg("The destination account was not found. Please check the bank and account number."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	gatewayMock.AssertExpectations(t)
+}
+
+func TestInterbankInquiryFailed_DailyLimitExceeded(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockInterbankGateway(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:   123,
+			CIF:  "1234567",
+			Name: "Olivia Rodrigo",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetBank(mock.Anything, "014").
+		Return(&Bank{Code: "014", Name: "BCA", Rails: []Rail{RailOnline}}, nil)
+	repoMock.EXPECT().GetRailLimits(mock.Anything, RailOnline).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 50_000_000, MaxDailyAmount: 100_000_000}, nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "interbank_ONLINE", mock.Anything, mock.Anything).
+		Return(99_950_000, nil)
+
+	sequence, err := svc.Inquiry(ctx, &intrabank.Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		BankCode:           "014",
+		Rail:               "ONLINE",
+	})
+
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrDailyLimitExceeded).
+		SetMsg("You have reached your daily transfer limit. Please try again tomorrow."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestInterbankDoPaymentSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		gatewayMock     = NewMockInterbankGateway(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, gatewayMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, authorizerMock, intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             100000,
+			Fee:                2500,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "1234567890",
+			SourceName:         "Olivia Rodrigo",
+			DestinationName:    "Taylor Swift",
+			BankCode:           "014",
+			Rail:               "BIFAST",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetBank(mock.Anything, "014").
+		Return(&Bank{Code: "014", Name: "BCA", Rails: []Rail{RailBIFast}}, nil)
+	repoMock.EXPECT().GetRailLimits(mock.Anything, RailBIFast).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 250_000_000, MaxDailyAmount: 250_000_000}, nil)
+	repoMock.EXPECT().HasTransferredToBank(mock.Anything, "123", "014", "1234567890").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.MatchedBy(func(transaction *intrabank.Transaction) bool {
+		return transaction.BankCode == "014" &&
+			transaction.TransactionType == "interbank_BIFAST" &&
+			transaction.Status == intrabank.TransactionPending &&
+			transaction.Fee == "2500"
+	})).Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "interbank_BIFAST", mock.Anything, mock.Anything).
+		Return(100000, nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, mock.Anything, mock.MatchedBy(func(outbox []*intrabank.OutboxMessage) bool {
+		if len(outbox) != 1 {
+			return false
+		}
+		email, err := outbox[0].Receipt()
+		return err == nil && email.DestinationBank == "BCA" && email.Status == intrabank.TransactionSuccess
+	})).Return(nil)
+
+	corebankingMock.EXPECT().DebitTransfer(mock.Anything, &Debit{
+		SourceAccount: "001001234567891",
+		BankCode:      "014",
+		Amount:        100000,
+		Fee:           2500,
+		Remark:        "TRF 001001234567891 014 1234567890 123456",
+		Reference:     "123456",
+	}).Return(&intrabank.OverbookingResult{
+		JournalSequence:      "JRN001",
+		TransactionReference: "123456",
+	}, nil)
+	repoMock.EXPECT().RecordPosting(mock.Anything, mock.MatchedBy(func(transaction *intrabank.Transaction) bool {
+		return transaction.SequenceJournal == "JRN001" && transaction.Status == intrabank.TransactionPending
+	})).Return(nil)
+
+	gatewayMock.EXPECT().Transfer(mock.Anything, &TransferInput{
+		Rail:               RailBIFast,
+		BankCode:           "014",
+		SourceAccount:      "001001234567891",
+		SourceName:         "Olivia Rodrigo",
+		DestinationAccount: "1234567890",
+		DestinationName:    "Taylor Swift",
+		Amount:             100000,
+		Fee:                2500,
+		Reference:          "123456",
+		Remark:             "TRF 001001234567891 014 1234567890 123456",
+	}).Return(&TransferResult{
+		JournalSequence:      "000001",
+		TransactionReference: "BIFAST000001",
+	}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &intrabank.Transaction{
+		SequenceNumber:       "123456",
+		UserID:               "123",
+		Destination:          "1234567890",
+		BankCode:             "014",
+		Amount:               100000,
+		TransactionType:      "interbank_BIFAST",
+		Remarks:              "TRF 001001234567891 014 1234567890 123456",
+		Status:               intrabank.TransactionSuccess,
+		Fee:                  "2500",
+		DestinationName:      "Taylor Swift",
+		SequenceJournal:      "JRN001",
+		TransactionReference: "BIFAST000001",
+	}, transaction)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	gatewayMock.AssertExpectations(t)
+	authorizerMock.AssertExpectations(t)
+}
+
+func TestInterbankDoPaymentFailed_IntrabankSequence(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockInterbankGateway(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			Status:             "CREATED",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrInvalidSequenceNumber).
+		SetMsg("Your transfer request was rejected. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestInterbankDoPaymentFailed_OTPRequiredForNewDestination(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockInterbankGateway(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "1234567890",
+			BankCode:           "014",
+			Rail:               "ONLINE",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetBank(mock.Anything, "014").
+		Return(&Bank{Code: "014", Name: "BCA", Rails: []Rail{RailOnline}}, nil)
+	repoMock.EXPECT().GetRailLimits(mock.Anything, RailOnline).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 50_000_000, MaxDailyAmount: 100_000_000}, nil)
+	repoMock.EXPECT().HasTransferredToBank(mock.Anything, "123", "014", "1234567890").
+		Return(false, nil)
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, intrabank.ErrOTPRequired).
+		SetMsg("Please verify this transfer with the OTP sent to you."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestInterbankDoPaymentFailed_TransferRejected(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		gatewayMock     = NewMockInterbankGateway(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, gatewayMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "1234569999",
+			BankCode:           "014",
+			Rail:               "ONLINE",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetBank(mock.Anything, "014").
+		Return(&Bank{Code: "014", Name: "BCA", Rails: []Rail{RailOnline}}, nil)
+	repoMock.EXPECT().GetRailLimits(mock.Anything, RailOnline).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 50_000_000, MaxDailyAmount: 100_000_000}, nil)
+	repoMock.EXPECT().HasTransferredToBank(mock.Anything, "123", "014", "1234569999").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "interbank_ONLINE", mock.Anything, mock.Anything).
+		Return(100000, nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("firebase-1", nil)
+	repoMock.EXPECT().FailTransaction(mock.Anything, mock.MatchedBy(func(transaction *intrabank.Transaction) bool {
+		return transaction.Status == intrabank.TransactionFailed && transaction.StatusCode == "76"
+	}), mock.MatchedBy(func(outbox []*intrabank.OutboxMessage) bool {
+		return len(outbox) == 2
+	})).Return(nil)
+
+	corebankingMock.EXPECT().DebitTransfer(mock.Anything, mock.Anything).
+		Return(&intrabank.OverbookingResult{JournalSequence: "JRN001", TransactionReference: "123456"}, nil)
+	repoMock.EXPECT().RecordPosting(mock.Anything, mock.Anything).
+		Return(nil)
+	corebankingMock.EXPECT().ReverseTransfer(mock.Anything, mock.MatchedBy(func(debit *Debit) bool {
+		return debit.Reference == "123456" && debit.SourceAccount == "001001234567891"
+	})).Return(&intrabank.OverbookingResult{JournalSequence: "JRN002"}, nil)
+
+	gatewayMock.EXPECT().Transfer(mock.Anything, mock.Anything).
+		Return(nil, &TransferRejection{StatusCode: "76", Description: "invalid account"})
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234569999",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+		SetMsg("Your transfer was rejected by the destination bank. The amount has been returned to your account."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	gatewayMock.AssertExpectations(t)
+}
+
+func TestInterbankDoPaymentFailed_TransferOutcomeUnknown(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		gatewayMock     = NewMockInterbankGateway(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, gatewayMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "1234567890",
+			BankCode:           "014",
+			Rail:               "ONLINE",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetBank(mock.Anything, "014").
+		Return(&Bank{Code: "014", Name: "BCA", Rails: []Rail{RailOnline}}, nil)
+	repoMock.EXPECT().GetRailLimits(mock.Anything, RailOnline).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 50_000_000, MaxDailyAmount: 100_000_000}, nil)
+	repoMock.EXPECT().HasTransferredToBank(mock.Anything, "123", "014", "1234567890").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "interbank_ONLINE", mock.Anything, mock.Anything).
+		Return(100000, nil)
+
+	corebankingMock.EXPECT().DebitTransfer(mock.Anything, mock.Anything).
+		Return(&intrabank.OverbookingResult{JournalSequence: "JRN001", TransactionReference: "123456"}, nil)
+	repoMock.EXPECT().RecordPosting(mock.Anything, mock.Anything).
+		Return(nil)
+
+	gatewayMock.EXPECT().Transfer(mock.Anything, mock.Anything).
+		Return(nil, errors.New("timeout"))
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrTransferPending).
+		SetMsg("Your transfer is being processed. Please check your transaction history."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	gatewayMock.AssertExpectations(t)
+}
+
+func TestInterbankDoPaymentFailed_DebitRejected(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		gatewayMock     = NewMockInterbankGateway(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, gatewayMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "1234567890",
+			BankCode:           "014",
+			Rail:               "ONLINE",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetBank(mock.Anything, "014").
+		Return(&Bank{Code: "014", Name: "BCA", Rails: []Rail{RailOnline}}, nil)
+	repoMock.EXPECT().GetRailLimits(mock.Anything, RailOnline).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 50_000_000, MaxDailyAmount: 100_000_000}, nil)
+	repoMock.EXPECT().HasTransferredToBank(mock.Anything, "123", "014", "1234567890").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "interbank_ONLINE", mock.Anything, mock.Anything).
+		Return(100000, nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("", nil)
+	repoMock.EXPECT().FailTransaction(mock.Anything, mock.MatchedBy(func(transaction *intrabank.Transaction) bool {
+		return transaction.Status == intrabank.TransactionFailed && transaction.StatusCode == "51" && transaction.SequenceJournal == ""
+	}), mock.Anything).Return(nil)
+
+	corebankingMock.EXPECT().DebitTransfer(mock.Anything, mock.Anything).
+		Return(nil, &intrabank.OverbookingRejection{StatusCode: "51", Description: "insufficient funds"})
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+		SetMsg("Your transfer was rejected. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	gatewayMock.AssertExpectations(t)
+}
+
+func TestInterbankSettlePendingSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		gatewayMock     = NewMockInterbankGateway(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, gatewayMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{})
+		user            = &ctxt.User{
+			ID:    123,
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		}
+	)
+
+	repoMock.EXPECT().GetPendingTransactions(mock.Anything, mock.Anything, 50).Return([]*intrabank.Transaction{
+		{ID: 10, SequenceNumber: "111111", UserID: "123", Amount: 100000, Status: "pending", SequenceJournal: "JRN001"},
+		{ID: 20, SequenceNumber: "222222", UserID: "123", Amount: 200000, Status: "pending"},
+	}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "111111").Return(&intrabank.Sequence{
+		SequenceNumber:     "111111",
+		UserID:             123,
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		BankCode:           "014",
+		Rail:               "ONLINE",
+	}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "222222").Return(&intrabank.Sequence{
+		SequenceNumber:     "222222",
+		UserID:             123,
+		Amount:             200000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		BankCode:           "014",
+		Rail:               "ONLINE",
+	}, nil)
+	repoMock.EXPECT().GetUser(mock.Anything, 123).Return(user, nil)
+	repoMock.EXPECT().GetBank(mock.Anything, "014").
+		Return(&Bank{Code: "014", Name: "BCA", Rails: []Rail{RailOnline}}, nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).Return("", nil)
+
+	gatewayMock.EXPECT().TransferStatus(mock.Anything, "111111").Return(nil, ErrTransferNotFound)
+	corebankingMock.EXPECT().ReverseTransfer(mock.Anything, mock.MatchedBy(func(debit *Debit) bool {
+		return debit.Reference == "111111" && debit.Amount == 100000
+	})).Return(&intrabank.OverbookingResult{JournalSequence: "JRN002"}, nil)
+	repoMock.EXPECT().FailTransaction(mock.Anything, mock.MatchedBy(func(tx *intrabank.Transaction) bool {
+		return tx.ID == 10 && tx.Status == intrabank.TransactionFailed && tx.CoreResponsePayload == ErrTransferNotFound.Error()
+	}), mock.Anything).Return(nil)
+
+	corebankingMock.EXPECT().GetPostingStatus(mock.Anything, "222222").
+		Return(&intrabank.OverbookingResult{JournalSequence: "JRN003", TransactionReference: "222222"}, nil)
+	gatewayMock.EXPECT().TransferStatus(mock.Anything, "222222").
+		Return(&TransferResult{JournalSequence: "000001", TransactionReference: "ONLINE20260101000000000001"}, nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, mock.MatchedBy(func(tx *intrabank.Transaction) bool {
+		return tx.ID == 20 && tx.Status == intrabank.TransactionSuccess &&
+			tx.SequenceJournal == "JRN003" && tx.TransactionReference == "ONLINE20260101000000000001"
+	}), mock.Anything).Return(nil)
+
+	err := svc.SettlePending(context.Background())
+
+	assert.NoError(t, err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	gatewayMock.AssertExpectations(t)
+}
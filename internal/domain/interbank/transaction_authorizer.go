@@ -0,0 +1,10 @@
+package interbank
+
+import "context"
+
+// TransactionAuthorizer verifies the step-up authorization of a transfer.
+type TransactionAuthorizer interface {
+	// VerifyTransaction verifies the OTP the user received for the transaction with the reference,
+	// and marks it as used.
+	VerifyTransaction(ctx context.Context, id int, code, reference string) error
+}
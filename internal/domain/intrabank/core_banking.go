@@ -13,4 +13,15 @@ type CoreBanking interface {
 	// PerformOverbooking executes a transfer between two accounts with the specified amount and remark.
 	// It returns an OverbookingResponse and an error if the operation fails.
 	PerformOverbooking(ctx context.Context, req *OverbookingInput) (*OverbookingResult, error)
+
+	// GetPostingStatus retrieves the outcome of the posting with the reference.
+	// It returns the result of a completed posting, an *OverbookingRejection if the posting was rejected
+	// and ErrPostingNotFound if the core banking system has never received it.
+	GetPostingStatus(ctx context.Context, reference string) (*OverbookingResult, error)
+}
+
+// CoreStatusChecker gets the status of the core banking system, a payment is not started during its end of day.
+type CoreStatusChecker interface {
+	// GetCoreStatus gets the current status of the core banking system.
+	GetCoreStatus(ctx context.Context) (*CoreStatus, error)
 }
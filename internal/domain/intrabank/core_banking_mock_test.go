@@ -138,6 +138,65 @@ func (_c *MockCoreBanking_GetCoreStatus_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// GetPostingStatus provides a mock function with given fields: ctx, reference
+func (_m *MockCoreBanking) GetPostingStatus(ctx context.Context, reference string) (*OverbookingResult, error) {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostingStatus")
+	}
+
+	var r0 *OverbookingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*OverbookingResult, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *OverbookingResult); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*OverbookingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_GetPostingStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPostingStatus'
+type MockCoreBanking_GetPostingStatus_Call struct {
+	*mock.Call
+}
+
+// GetPostingStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockCoreBanking_Expecter) GetPostingStatus(ctx interface{}, reference interface{}) *MockCoreBanking_GetPostingStatus_Call {
+	return &MockCoreBanking_GetPostingStatus_Call{Call: _e.mock.On("GetPostingStatus", ctx, reference)}
+}
+
+func (_c *MockCoreBanking_GetPostingStatus_Call) Run(run func(ctx context.Context, reference string)) *MockCoreBanking_GetPostingStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_GetPostingStatus_Call) Return(_a0 *OverbookingResult, _a1 error) *MockCoreBanking_GetPostingStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_GetPostingStatus_Call) RunAndReturn(run func(context.Context, string) (*OverbookingResult, error)) *MockCoreBanking_GetPostingStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PerformOverbooking provides a mock function with given fields: ctx, req
 func (_m *MockCoreBanking) PerformOverbooking(ctx context.Context, req *OverbookingInput) (*OverbookingResult, error) {
 	ret := _m.Called(ctx, req)
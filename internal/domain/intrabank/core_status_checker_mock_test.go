@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package intrabank
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCoreStatusChecker is an autogenerated mock type for the CoreStatusChecker type
+type MockCoreStatusChecker struct {
+	mock.Mock
+}
+
+type MockCoreStatusChecker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCoreStatusChecker) EXPECT() *MockCoreStatusChecker_Expecter {
+	return &MockCoreStatusChecker_Expecter{mock: &_m.Mock}
+}
+
+// GetCoreStatus provides a mock function with given fields: ctx
+func (_m *MockCoreStatusChecker) GetCoreStatus(ctx context.Context) (*CoreStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoreStatus")
+	}
+
+	var r0 *CoreStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*CoreStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *CoreStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*CoreStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreStatusChecker_GetCoreStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoreStatus'
+type MockCoreStatusChecker_GetCoreStatus_Call struct {
+	*mock.Call
+}
+
+// GetCoreStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCoreStatusChecker_Expecter) GetCoreStatus(ctx interface{}) *MockCoreStatusChecker_GetCoreStatus_Call {
+	return &MockCoreStatusChecker_GetCoreStatus_Call{Call: _e.mock.On("GetCoreStatus", ctx)}
+}
+
+func (_c *MockCoreStatusChecker_GetCoreStatus_Call) Run(run func(ctx context.Context)) *MockCoreStatusChecker_GetCoreStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCoreStatusChecker_GetCoreStatus_Call) Return(_a0 *CoreStatus, _a1 error) *MockCoreStatusChecker_GetCoreStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreStatusChecker_GetCoreStatus_Call) RunAndReturn(run func(context.Context) (*CoreStatus, error)) *MockCoreStatusChecker_GetCoreStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCoreStatusChecker creates a new instance of MockCoreStatusChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCoreStatusChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCoreStatusChecker {
+	mock := &MockCoreStatusChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
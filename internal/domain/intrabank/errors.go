@@ -12,6 +12,9 @@ var (
 	// ErrSourceAccountInactive indicates that the source account is inactive.
 	ErrSourceAccountInactive = errors.New("source account is inactive")
 
+	// ErrSourceAccountNotOwned indicates that the source account does not belong to the user.
+	ErrSourceAccountNotOwned = errors.New("source account not owned by user")
+
 	// ErrSendEmailFailed is returned when an attempt to send an email fails.
 	ErrSendEmailFailed = errors.New("send email failed")
 
@@ -21,6 +24,18 @@ var (
 	// ErrInvalidSequenceNumber indicates that the sequence number is invalid.
 	ErrInvalidSequenceNumber = errors.New("invalid sequence number")
 
+	// ErrSequenceMismatch indicates that the payment does not match the user or the details of its inquiry.
+	ErrSequenceMismatch = errors.New("sequence mismatch")
+
+	// ErrOTPRequired indicates that the transfer must be authorized with a transaction OTP.
+	ErrOTPRequired = errors.New("OTP required")
+
+	// ErrTransferMethodDisabled indicates that the transfer method has been disabled by operations.
+	ErrTransferMethodDisabled = errors.New("transfer method disabled")
+
+	// ErrOutsideOperatingHours indicates that the transfer is requested outside the operating hours of the method.
+	ErrOutsideOperatingHours = errors.New("outside operating hours")
+
 	// ErrUnauthenticatedUser indicates that the user is not authenticated.
 	ErrUnauthenticatedUser = errors.New("unauthenticated user")
 
@@ -33,4 +48,62 @@ var (
 
 	// ErrNotifyFailed is returned when the notification fails to send.
 	ErrNotifyFailed = errors.New("notify failed")
+
+	// ErrBeneficiaryNotFound is returned when the user has no saved beneficiary with the requested ID.
+	ErrBeneficiaryNotFound = errors.New("beneficiary not found")
+
+	// ErrSequenceNotFound is returned when the requested sequence cannot be found.
+	ErrSequenceNotFound = errors.New("sequence not found")
+
+	// ErrSequenceAlreadyProcessed is returned when the sequence is no longer payable,
+	// because its payment has been started by another request.
+	ErrSequenceAlreadyProcessed = errors.New("sequence already processed")
+
+	// ErrPaymentInProgress indicates that the payment of the sequence is still in progress.
+	ErrPaymentInProgress = errors.New("payment in progress")
+
+	// ErrPaymentFailed indicates that the previous payment of the sequence has failed.
+	ErrPaymentFailed = errors.New("payment failed")
+
+	// ErrIdempotencyKeyReused indicates that the idempotency key has been used for another sequence.
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused")
+
+	// ErrIdempotencyKeyReserved is returned when a client uses an idempotency key of the internal namespace.
+	ErrIdempotencyKeyReserved = errors.New("idempotency key reserved")
+
+	// ErrDailyLimitExceeded is returned when the transfer exceeds the user's daily transfer limit.
+	ErrDailyLimitExceeded = errors.New("daily transfer limit exceeded")
+
+	// ErrInvalidTransactionFilter is returned when the transaction history filter is invalid.
+	ErrInvalidTransactionFilter = errors.New("invalid transaction filter")
+
+	// ErrTransactionNotFound is returned when the requested transaction cannot be found.
+	ErrTransactionNotFound = errors.New("transaction not found")
+
+	// ErrInsufficientBalance is returned when the source account balance cannot cover the transfer.
+	ErrInsufficientBalance = errors.New("insufficient balance")
+
+	// ErrReceiptUnavailable is returned when a receipt is requested for a transaction that has not succeeded.
+	ErrReceiptUnavailable = errors.New("receipt unavailable")
+
+	// ErrTransactionNotRecorded is returned when the money has been moved
+	// but neither the transaction nor its recovery entry could be stored.
+	ErrTransactionNotRecorded = errors.New("transaction not recorded")
+
+	// ErrUnknownOutboxKind is returned when an outbox message has a kind that cannot be delivered.
+	ErrUnknownOutboxKind = errors.New("unknown outbox message kind")
+
+	// ErrSequenceExpired indicates that the sequence is past its validity window and cannot be paid.
+	ErrSequenceExpired = errors.New("sequence expired")
+
+	// ErrPaymentPending is returned when the outcome of the core posting is unknown,
+	// the transaction stays pending until it is settled against the core banking system.
+	ErrPaymentPending = errors.New("payment pending")
+
+	// ErrPostingNotFound is returned when the core banking system has no posting with the reference,
+	// i.e. the posting has never reached it.
+	ErrPostingNotFound = errors.New("posting not found")
+
+	// ErrTransactionFinished is returned when the transaction to complete or fail is no longer pending.
+	ErrTransactionFinished = errors.New("transaction already finished")
 )
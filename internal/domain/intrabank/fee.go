@@ -0,0 +1,97 @@
+package intrabank
+
+// FeeRule is the fee of the transfers that match its method, channel, customer segment and amount band.
+// An empty method, channel or segment matches any value, and a zero maximum amount leaves the band open.
+type FeeRule struct {
+	Method    string
+	Channel   string
+	Segment   string
+	MinAmount Money
+	MaxAmount Money
+	Fee       Money
+}
+
+// Matches checks if the rule applies to the transfer.
+func (r *FeeRule) Matches(in *FeeInput) bool {
+	return matchesAny(r.Method, in.Method) &&
+		matchesAny(r.Channel, in.Channel) &&
+		matchesAny(r.Segment, in.Segment) &&
+		in.Amount >= r.MinAmount &&
+		(r.MaxAmount == 0 || in.Amount <= r.MaxAmount)
+}
+
+// specificity counts the criteria the rule narrows down, a more specific rule takes precedence.
+func (r *FeeRule) specificity() int {
+	n := 0
+	for _, v := range []string{r.Method, r.Channel, r.Segment} {
+		if v != "" {
+			n++
+		}
+	}
+	if r.MinAmount > 0 || r.MaxAmount > 0 {
+		n++
+	}
+	return n
+}
+
+// FreeQuota is the number of transfers a user of the segment can make for free every month.
+type FreeQuota struct {
+	Method    string
+	Segment   string
+	Transfers int
+}
+
+// FeeInput represents the transfer to calculate the fee of.
+// The BaseFee is the fee of the transaction method, used when no rule matches.
+type FeeInput struct {
+	Method  string
+	Channel string
+	Segment string
+	Amount  Money
+	BaseFee Money
+}
+
+// FeePolicy calculates the transfer fees.
+type FeePolicy struct {
+	Rules      []FeeRule
+	FreeQuotas []FreeQuota
+}
+
+// Fee returns the fee of the most specific matching rule, the first one on a tie,
+// or the base fee when no rule matches.
+func (p *FeePolicy) Fee(in *FeeInput) Money {
+	var match *FeeRule
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Matches(in) && (match == nil || rule.specificity() > match.specificity()) {
+			match = rule
+		}
+	}
+	if match == nil {
+		return in.BaseFee
+	}
+	return match.Fee
+}
+
+// FreeTransfers returns the monthly free transfers of the method for the segment,
+// preferring the quota configured for the segment over the one for everyone.
+func (p *FeePolicy) FreeTransfers(in *FeeInput) int {
+	var match *FreeQuota
+	for i := range p.FreeQuotas {
+		quota := &p.FreeQuotas[i]
+		if !matchesAny(quota.Method, in.Method) || !matchesAny(quota.Segment, in.Segment) {
+			continue
+		}
+		if match == nil || (quota.Segment != "" && match.Segment == "") {
+			match = quota
+		}
+	}
+	if match == nil {
+		return 0
+	}
+	return match.Transfers
+}
+
+func matchesAny(want, got string) bool {
+	return want == "" || want == got
+}
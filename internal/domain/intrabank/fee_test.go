@@ -0,0 +1,50 @@
+package intrabank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeRuleMatches(t *testing.T) {
+	rule := &FeeRule{Method: "internal_transfer", Channel: "android", MinAmount: 10_000, MaxAmount: 100_000, Fee: 2500}
+
+	assert.True(t, rule.Matches(&FeeInput{Method: "internal_transfer", Channel: "android", Segment: "payroll", Amount: 10_000}))
+	assert.True(t, rule.Matches(&FeeInput{Method: "internal_transfer", Channel: "android", Amount: 100_000}))
+	assert.False(t, rule.Matches(&FeeInput{Method: "internal_transfer", Channel: "ios", Amount: 50_000}))
+	assert.False(t, rule.Matches(&FeeInput{Method: "interbank_transfer", Channel: "android", Amount: 50_000}))
+	assert.False(t, rule.Matches(&FeeInput{Method: "internal_transfer", Channel: "android", Amount: 9_999}))
+	assert.False(t, rule.Matches(&FeeInput{Method: "internal_transfer", Channel: "android", Amount: 100_001}))
+
+	open := &FeeRule{MinAmount: 100_001}
+	assert.True(t, open.Matches(&FeeInput{Method: "internal_transfer", Amount: 1_000_000_000}))
+}
+
+func TestFeePolicyFee(t *testing.T) {
+	policy := &FeePolicy{
+		Rules: []FeeRule{
+			{Method: "internal_transfer", Fee: 2500},
+			{Method: "internal_transfer", Segment: "priority", Fee: 0},
+			{Method: "internal_transfer", MinAmount: 50_000_001, Fee: 5000},
+			{Method: "internal_transfer", Fee: 9999},
+		},
+	}
+
+	assert.Equal(t, Money(2500), policy.Fee(&FeeInput{Method: "internal_transfer", Amount: 100_000}))
+	assert.Equal(t, Money(0), policy.Fee(&FeeInput{Method: "internal_transfer", Segment: "priority", Amount: 100_000}))
+	assert.Equal(t, Money(5000), policy.Fee(&FeeInput{Method: "internal_transfer", Amount: 60_000_000}))
+	assert.Equal(t, Money(6500), policy.Fee(&FeeInput{Method: "interbank_transfer", Amount: 100_000, BaseFee: 6500}))
+}
+
+func TestFeePolicyFreeTransfers(t *testing.T) {
+	policy := &FeePolicy{
+		FreeQuotas: []FreeQuota{
+			{Method: "internal_transfer", Transfers: 5},
+			{Method: "internal_transfer", Segment: "payroll", Transfers: 25},
+		},
+	}
+
+	assert.Equal(t, 5, policy.FreeTransfers(&FeeInput{Method: "internal_transfer", Segment: "regular"}))
+	assert.Equal(t, 25, policy.FreeTransfers(&FeeInput{Method: "internal_transfer", Segment: "payroll"}))
+	assert.Equal(t, 0, policy.FreeTransfers(&FeeInput{Method: "interbank_transfer", Segment: "payroll"}))
+}
@@ -9,8 +9,10 @@
 package intrabank
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -41,10 +43,30 @@ func ParseMoney(s string) (Money, error) {
 // Limits represent the minimum and maximum amount and daily amount limits for a transfer.
 // The daily amount limit is the maximum amount that can be transferred within a 24-hour period.
 // The minimum and maximum amount limits are the minimum and maximum amount that can be transferred.
+// The Fee is the default fee of the transaction method.
+// The method can only be used between the OpenHour and the CloseHour, unless it is Disabled.
 type Limits struct {
 	MinAmount      Money
 	MaxAmount      Money
 	MaxDailyAmount Money
+	Fee            Money
+	OpenHour       int
+	CloseHour      int
+	Disabled       bool
+}
+
+// IsOpen checks if the method is within its operating hours at t, in the business time zone.
+// The hours wrap past midnight when the CloseHour is earlier than the OpenHour,
+// and the method is open all day when both hours are equal.
+func (l *Limits) IsOpen(t time.Time) bool {
+	if l.OpenHour == l.CloseHour {
+		return true
+	}
+	hour := t.In(BusinessLocation).Hour()
+	if l.OpenHour < l.CloseHour {
+		return hour >= l.OpenHour && hour < l.CloseHour
+	}
+	return hour >= l.OpenHour || hour < l.CloseHour
 }
 
 // CanTransfer checks if the amount is enough and within the daily amount limit.
@@ -52,34 +74,202 @@ func (l *Limits) CanTransfer(amount Money) bool {
 	return l.SufficientBalance(amount) && amount <= l.MaxDailyAmount
 }
 
+// WithinDailyLimit checks if the total amount transferred in a business day
+// does not exceed the daily amount limit.
+func (l *Limits) WithinDailyLimit(dailyAmount Money) bool {
+	return dailyAmount <= l.MaxDailyAmount
+}
+
 // SufficientBalance checks if the amount is within the minimum and maximum limits.
 func (l *Limits) SufficientBalance(amount Money) bool {
 	return amount >= l.MinAmount && amount <= l.MaxAmount
 }
 
+// BusinessLocation is the time zone used to determine the bank business day and operating hours
+// (WIB, UTC+7, the Asia/Jakarta time which has no daylight saving time).
+var BusinessLocation = time.FixedZone("WIB", 7*60*60)
+
+// BusinessDay returns the start and the end of the business day of t.
+// The end is exclusive, it is the start of the next business day.
+func BusinessDay(t time.Time) (start, end time.Time) {
+	t = t.In(BusinessLocation)
+	start = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, BusinessLocation)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// BusinessMonth returns the start and the end of the business month of t.
+// The end is exclusive, it is the start of the next business month.
+func BusinessMonth(t time.Time) (start, end time.Time) {
+	t = t.In(BusinessLocation)
+	start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, BusinessLocation)
+	return start, start.AddDate(0, 1, 0)
+}
+
+const (
+	// SequenceCreated indicates that the sequence has been inquired and is ready to be paid.
+	SequenceCreated = "CREATED"
+	// SequencePending indicates that the payment of the sequence is in progress.
+	SequencePending = "PENDING"
+	// SequenceCompleted indicates that the sequence has been paid successfully.
+	SequenceCompleted = "COMPLETED"
+	// SequenceFailed indicates that the payment of the sequence has failed.
+	SequenceFailed = "FAILED"
+)
+
+// DefaultSequenceValidity is how long a sequence can be paid
+// when no validity window is configured for its transaction type.
+const DefaultSequenceValidity = 15 * time.Minute
+
+// SequenceValidity maps a transaction type to how long its inquiry sequence can be paid.
+type SequenceValidity map[string]time.Duration
+
+// For returns the validity window of the transaction type, or DefaultSequenceValidity when it is not configured.
+func (v SequenceValidity) For(transactionType string) time.Duration {
+	if d, ok := v[transactionType]; ok && d > 0 {
+		return d
+	}
+	return DefaultSequenceValidity
+}
+
+// StepUpPolicy decides when a transfer needs a transaction OTP before it is paid.
+type StepUpPolicy struct {
+	// Threshold is the amount above which a transfer needs an OTP. Zero disables the threshold.
+	Threshold Money
+}
+
+// AboveThreshold checks if the amount needs an OTP regardless of the destination.
+func (p StepUpPolicy) AboveThreshold(amount Money) bool {
+	return p.Threshold > 0 && amount > p.Threshold
+}
+
 // Sequence represents transfer sequence.
+// A sequence can only be paid once, its status moves from CREATED to PENDING
+// when the payment starts and ends as COMPLETED or FAILED.
+// The account names and limits checked by the inquiry can become stale,
+// so a sequence can only be paid until it expires.
 type Sequence struct {
 	ID                 int
 	SequenceNumber     string
 	Amount             Money
 	SourceAccount      string
 	DestinationAccount string
-	SourceName         string
-	DestinationName    string
-	TransactionType    string
+	// BeneficiaryID identifies the saved beneficiary of the user the transfer is inquired to,
+	// its account replaces the destination account. It is zero when the account is entered directly.
+	BeneficiaryID   int64
+	SourceName      string
+	DestinationName string
+	TransactionType string
+	Status          string
+	IdempotencyKey  string
+	UserID          int
+	DeviceID        string
+	Channel         string
+	Fee             Money
+	// Note is the free text of the payer, e.g. the note of a bulk transfer row, it is appended to the remark.
+	Note string
+	// BankCode and Rail identify the destination bank and the rail of an interbank transfer,
+	// they are empty for a transfer between Bank Yaya accounts.
+	BankCode  string
+	Rail      string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// OwnedBy checks if the sequence was inquired by the user from the device.
+func (seq *Sequence) OwnedBy(userID int, deviceID string) bool {
+	return seq.UserID == userID && seq.DeviceID == deviceID
+}
+
+// Matches checks if the payment carries the same transfer details that were inquired.
+func (seq *Sequence) Matches(in *PaymentInput) bool {
+	return seq.SourceAccount == in.SourceAccount &&
+		seq.DestinationAccount == in.DestinationAccount &&
+		seq.Amount == in.Amount
+}
+
+// IsInterbank checks if the sequence is a transfer to an account at another bank.
+func (seq *Sequence) IsInterbank() bool {
+	return seq.BankCode != ""
+}
+
+// IsIntrabank checks if the sequence is a transfer between Bank Yaya accounts.
+// The other payments, such as the QRIS payments, store their sequences with their own transaction type.
+func (seq *Sequence) IsIntrabank() bool {
+	return !seq.IsInterbank() && (seq.TransactionType == "" || seq.TransactionType == transferType)
+}
+
+// Expired checks if the sequence can no longer be paid at now.
+func (seq *Sequence) Expired(now time.Time) bool {
+	return !seq.ExpiresAt.IsZero() && !now.Before(seq.ExpiresAt)
 }
 
 func (seq *Sequence) Valid(sequenceNumber string) bool {
 	return seq.SequenceNumber == sequenceNumber
 }
 
-// Remark returns the remark for the transfer sequence.
+// Payable checks if the sequence has not been paid yet.
+func (seq *Sequence) Payable() bool {
+	return seq.Status == SequenceCreated
+}
+
+// IsPending checks if the payment of the sequence is still in progress.
+func (seq *Sequence) IsPending() bool {
+	return seq.Status == SequencePending
+}
+
+// IsCompleted checks if the sequence has been paid successfully.
+func (seq *Sequence) IsCompleted() bool {
+	return seq.Status == SequenceCompleted
+}
+
+// Remark returns the remark for the transfer sequence, followed by its note if it has one.
 func (seq *Sequence) Remark() string {
-	return fmt.Sprintf("TRF %v %v BNKYAYA %v",
+	remark := fmt.Sprintf("TRF %v %v BNKYAYA %v",
 		seq.SourceAccount,
 		seq.DestinationAccount,
 		seq.SequenceNumber,
 	)
+	if seq.Note != "" {
+		remark += " " + seq.Note
+	}
+	return remark
+}
+
+// internalKeyPrefix namespaces the idempotency keys of the payments the bank makes on behalf of the user,
+// e.g. the scheduled transfers and the bulk transfer rows, so a client key can never take their place.
+const internalKeyPrefix = "internal:"
+
+// InternalIdempotencyKey returns the key in the namespace of the payments the bank makes on behalf of the user.
+func InternalIdempotencyKey(key string) string {
+	return internalKeyPrefix + key
+}
+
+// ValidateIdempotencyKey rejects the idempotency key of a client request in the internal namespace.
+func ValidateIdempotencyKey(key string) error {
+	if strings.HasPrefix(key, internalKeyPrefix) {
+		return ErrIdempotencyKeyReserved
+	}
+	return nil
+}
+
+// PaymentInput contains the information required to pay a transfer sequence.
+// The IdempotencyKey is optional, a repeated payment of the user with the same key
+// returns the original transaction instead of moving money again.
+// The StandingOrderID links the transaction to the standing order that runs it, if any.
+type PaymentInput struct {
+	SequenceNumber     string
+	SourceAccount      string
+	DestinationAccount string
+	Amount             Money
+	IdempotencyKey     string
+	StandingOrderID    int64
+	// OTPID and OTPCode identify the transaction OTP bound to the sequence number.
+	OTPID   int
+	OTPCode string
+	// Preauthorized is set for the scheduled transfers, the standing order runs and the bulk transfer rows,
+	// which the user authorized with a transaction OTP bound to the instruction when it was created or confirmed,
+	// so they are paid without a further OTP.
+	Preauthorized bool
 }
 
 // Transaction represents a transfer transaction.
@@ -110,6 +300,135 @@ type Transaction struct {
 	SequenceNumber          string
 	BankCode                string
 	SuccessTransactionDate  time.Time
+	StandingOrderID         int64
+}
+
+// TransactionDetail is a stored transaction together with the sequence it was paid from.
+type TransactionDetail struct {
+	Transaction *Transaction
+	Sequence    *Sequence
+}
+
+// HasReceipt checks if a receipt can be issued for the transaction.
+func (d *TransactionDetail) HasReceipt() bool {
+	return d.Transaction.Status == TransactionSuccess
+}
+
+const (
+	// RecoveryOpen indicates that the completed core posting is not stored in its transaction yet.
+	RecoveryOpen = "OPEN"
+	// RecoveryResolved indicates that the transaction has been completed from the recovery entry.
+	RecoveryResolved = "RESOLVED"
+	// RecoveryManual indicates that the reconciler gave up and the entry needs a manual review.
+	RecoveryManual = "MANUAL"
+)
+
+// maxRecoveryAttempts is the number of times the reconciler tries to complete a transaction
+// before the recovery entry is handed over to a manual review.
+const maxRecoveryAttempts = 10
+
+// Recovery is a journal entry of a completed core banking posting
+// whose transaction could not be completed in the database.
+// The entry is never deleted, its status and resolution are the audit trail of the case.
+type Recovery struct {
+	ID                   int64
+	TransactionID        int64
+	SequenceNumber       string
+	JournalSequence      string
+	TransactionReference string
+	Status               string
+	Attempts             int
+	LastError            string
+	Resolution           string
+	CreatedAt            time.Time
+	ResolvedAt           time.Time
+}
+
+// NewRecovery creates an open recovery entry for the transaction
+// that has been posted by the core banking system.
+func NewRecovery(transaction *Transaction, err error) *Recovery {
+	return &Recovery{
+		TransactionID:        transaction.ID,
+		SequenceNumber:       transaction.SequenceNumber,
+		JournalSequence:      transaction.SequenceJournal,
+		TransactionReference: transaction.TransactionReference,
+		Status:               RecoveryOpen,
+		LastError:            err.Error(),
+	}
+}
+
+// Transaction returns the successful transaction to be completed from the entry.
+func (r *Recovery) Transaction() *Transaction {
+	return &Transaction{
+		ID:                   r.TransactionID,
+		SequenceNumber:       r.SequenceNumber,
+		SequenceJournal:      r.JournalSequence,
+		TransactionReference: r.TransactionReference,
+		Status:               TransactionSuccess,
+	}
+}
+
+// Resolve marks the entry as resolved with the description of how it was resolved.
+func (r *Recovery) Resolve(resolution string, at time.Time) {
+	r.Attempts++
+	r.Status = RecoveryResolved
+	r.Resolution = resolution
+	r.ResolvedAt = at
+}
+
+// Retry records a failed attempt. The entry is handed over to a manual review
+// once the attempts are exhausted.
+func (r *Recovery) Retry(err error) {
+	r.Attempts++
+	r.LastError = err.Error()
+	if r.Attempts >= maxRecoveryAttempts {
+		r.Status = RecoveryManual
+		r.Resolution = "reconciliation attempts exhausted, manual review required"
+	}
+}
+
+const (
+	// DefaultHistoryLimit is the number of transactions returned per page when no limit is requested.
+	DefaultHistoryLimit = 20
+	// MaxHistoryLimit is the maximum number of transactions returned per page.
+	MaxHistoryLimit = 100
+)
+
+// TransactionFilter describes the criteria used to search the transaction history of a user.
+// Zero values mean that the criterion is not applied.
+// The Cursor is the ID of the last transaction of the previous page,
+// transactions are returned from the newest to the oldest.
+type TransactionFilter struct {
+	UserID          string
+	From            time.Time
+	To              time.Time
+	MinAmount       Money
+	MaxAmount       Money
+	Status          string
+	TransactionType string
+	Cursor          int64
+	Limit           int
+}
+
+// Valid checks if the date and amount ranges of the filter are consistent.
+func (f *TransactionFilter) Valid() bool {
+	if !f.From.IsZero() && !f.To.IsZero() && f.From.After(f.To) {
+		return false
+	}
+	if f.MinAmount < 0 || f.MaxAmount < 0 {
+		return false
+	}
+	if f.MaxAmount > 0 && f.MinAmount > f.MaxAmount {
+		return false
+	}
+	return f.Cursor >= 0 && f.Limit >= 0 && f.Limit <= MaxHistoryLimit
+}
+
+// TransactionPage is a page of the transaction history.
+// NextCursor is zero when there are no more transactions to read.
+type TransactionPage struct {
+	Transactions []*Transaction
+	NextCursor   int64
 }
 
 const (
@@ -169,18 +488,51 @@ func (acc *Account) IsAccountActive() bool {
 	return false
 }
 
+// debitBlocked maps the core banking block code to whether the account is blocked for debit.
+var debitBlocked = map[string]bool{
+	"":  false,
+	"0": false,
+	"1": true,
+	"2": false,
+	"3": true,
+}
+
+// IsDebitBlocked checks if the account cannot be debited. An unknown block code is treated as blocked.
+func (acc *Account) IsDebitBlocked() bool {
+	if v, ok := debitBlocked[acc.Blocked]; ok {
+		return v
+	}
+	return true
+}
+
+// CanDebit checks if the account can be debited by the total amount
+// without going below its minimum balance.
+func (acc *Account) CanDebit(total Money) bool {
+	if acc.IsDebitBlocked() {
+		return false
+	}
+	return total <= acc.AvailableBalance-acc.MinBalance
+}
+
+// IsOwnedBy checks if the account is registered under the customer's CIF.
+func (acc *Account) IsOwnedBy(cif string) bool {
+	return cif != "" && acc.CIF == cif
+}
+
 // ABMsg is an array of strings containing transaction details from the core banking API.
 type ABMsg []string
 
 // OverbookingInput contains the required information to perform an overbooking transaction.
 // This includes the source and destination accounts, the transaction amount and fee,
 // as well as an optional remark for additional context.
+// The reference identifies the posting at the core banking system when its outcome has to be checked.
 type OverbookingInput struct {
 	SourceAccount      string
 	DestinationAccount string
 	Amount             Money
 	Fee                Money
 	Remark             string
+	Reference          string
 }
 
 // OverbookingResult represents the outcome of an overbooking transaction.
@@ -192,6 +544,28 @@ type OverbookingResult struct {
 	ABMsg                ABMsg
 }
 
+// insufficientFundsCode is the core status code of an overbooking rejected for an insufficient balance.
+const insufficientFundsCode = "51"
+
+// OverbookingRejection is returned by PerformOverbooking when the core banking system
+// has rejected the overbooking, so no money has been moved.
+// It carries the core status code and the raw response payload for the audit trail.
+type OverbookingRejection struct {
+	StatusCode  string
+	Description string
+	Payload     string
+}
+
+// InsufficientFunds checks if the core banking system has rejected the overbooking
+// because the source account balance cannot cover it.
+func (r *OverbookingRejection) InsufficientFunds() bool {
+	return r.StatusCode == insufficientFundsCode
+}
+
+func (r *OverbookingRejection) Error() string {
+	return fmt.Sprintf("core banking overbook rejected: %s (%s)", r.Description, r.StatusCode)
+}
+
 // EmailData holds the information required to construct a transaction-related email notification.
 // It includes sender and recipient details, transaction amounts, and metadata
 // such as the transaction reference and additional notes.
@@ -207,6 +581,7 @@ type EmailData struct {
 	DestinationBank    string
 	TransactionRef     string
 	Note               string
+	Status             string
 }
 
 const (
@@ -214,6 +589,9 @@ const (
 	TransactionSuccess = "success"
 	// TransactionFailed represents the status string for a failed transaction.
 	TransactionFailed = "failed"
+	// TransactionPending represents the status string for a transaction
+	// that has been recorded but not yet completed by the core banking system.
+	TransactionPending = "pending"
 )
 
 // Notification represents a transaction-related notification.
@@ -253,3 +631,107 @@ func (n *Notification) failed() string {
 		n.Amount.Rupiah(),
 	)
 }
+
+const (
+	// OutboxReceipt is the kind of the message delivered through the ReceiptMailer.
+	OutboxReceipt = "RECEIPT"
+	// OutboxNotification is the kind of the message delivered through the Notifier.
+	OutboxNotification = "NOTIFICATION"
+)
+
+const (
+	// OutboxPending indicates that the message is waiting to be delivered.
+	OutboxPending = "PENDING"
+	// OutboxSent indicates that the message has been delivered.
+	OutboxSent = "SENT"
+	// OutboxDead indicates that the delivery attempts are exhausted and the message is dead-lettered.
+	OutboxDead = "DEAD"
+)
+
+const (
+	// maxOutboxAttempts is the number of delivery attempts before a message is dead-lettered.
+	maxOutboxAttempts = 10
+	// outboxBaseBackoff is the delay after the first failed attempt, it doubles on every next attempt.
+	outboxBaseBackoff = 30 * time.Second
+	// outboxMaxBackoff caps the delay between two attempts.
+	outboxMaxBackoff = time.Hour
+)
+
+// OutboxMessage is a receipt email or a push notification of a transaction.
+// It is stored in the same database transaction as the transaction result
+// and delivered later, so the payment outcome never depends on the delivery.
+type OutboxMessage struct {
+	ID            int64
+	TransactionID int64
+	Kind          string
+	Payload       []byte
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	SentAt        time.Time
+}
+
+// NewReceiptMessage creates a pending message delivering the receipt email of the transaction.
+func NewReceiptMessage(transactionID int64, email *EmailData) *OutboxMessage {
+	return newOutboxMessage(transactionID, OutboxReceipt, email)
+}
+
+// NewNotificationMessage creates a pending message delivering the push notification of the transaction.
+func NewNotificationMessage(transactionID int64, notification *Notification) *OutboxMessage {
+	return newOutboxMessage(transactionID, OutboxNotification, notification)
+}
+
+func newOutboxMessage(transactionID int64, kind string, v any) *OutboxMessage {
+	// The payloads are plain structs, so marshalling them cannot fail.
+	payload, _ := json.Marshal(v)
+	return &OutboxMessage{
+		TransactionID: transactionID,
+		Kind:          kind,
+		Payload:       payload,
+		Status:        OutboxPending,
+	}
+}
+
+// Receipt decodes the payload of a receipt message.
+func (m *OutboxMessage) Receipt() (*EmailData, error) {
+	email := new(EmailData)
+	if err := json.Unmarshal(m.Payload, email); err != nil {
+		return nil, err
+	}
+	return email, nil
+}
+
+// Notification decodes the payload of a notification message.
+func (m *OutboxMessage) Notification() (*Notification, error) {
+	notification := new(Notification)
+	if err := json.Unmarshal(m.Payload, notification); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// Sent marks the message as delivered.
+func (m *OutboxMessage) Sent(at time.Time) {
+	m.Attempts++
+	m.Status = OutboxSent
+	m.SentAt = at
+}
+
+// Retry records a failed delivery and schedules the next attempt with an exponential backoff.
+// The message is dead-lettered once the attempts are exhausted.
+func (m *OutboxMessage) Retry(err error, at time.Time) {
+	m.Attempts++
+	m.LastError = err.Error()
+	if m.Attempts >= maxOutboxAttempts {
+		m.Status = OutboxDead
+		return
+	}
+
+	backoff := outboxBaseBackoff << (m.Attempts - 1)
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	m.NextAttemptAt = at.Add(backoff)
+}
@@ -1,7 +1,9 @@
 package intrabank
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -20,3 +22,203 @@ func TestParseMoneyFailed(t *testing.T) {
 	assert.Equal(t, ErrFailedParseMoney, err)
 	assert.Equal(t, Money(0), m)
 }
+
+func TestLimitsWithinDailyLimit(t *testing.T) {
+	limits := &Limits{
+		MinAmount:      1,
+		MaxAmount:      50_000_000,
+		MaxDailyAmount: 200_000_000,
+	}
+	assert.True(t, limits.WithinDailyLimit(200_000_000))
+	assert.False(t, limits.WithinDailyLimit(200_000_001))
+}
+
+func TestLimitsIsOpen(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2025, 3, 25, hour, 30, 0, 0, BusinessLocation)
+	}
+
+	day := &Limits{OpenHour: 6, CloseHour: 22}
+	assert.False(t, day.IsOpen(at(5)))
+	assert.True(t, day.IsOpen(at(6)))
+	assert.True(t, day.IsOpen(at(21)))
+	assert.False(t, day.IsOpen(at(22)))
+	// 2025-03-25 23:30 UTC is 2025-03-26 06:30 in Jakarta.
+	assert.True(t, day.IsOpen(time.Date(2025, 3, 25, 23, 30, 0, 0, time.UTC)))
+
+	night := &Limits{OpenHour: 22, CloseHour: 6}
+	assert.True(t, night.IsOpen(at(23)))
+	assert.True(t, night.IsOpen(at(5)))
+	assert.False(t, night.IsOpen(at(12)))
+
+	allDay := &Limits{}
+	assert.True(t, allDay.IsOpen(at(3)))
+}
+
+func TestBusinessDay(t *testing.T) {
+	// 2025-03-25 18:30 UTC is 2025-03-26 01:30 in Jakarta.
+	now := time.Date(2025, 3, 25, 18, 30, 0, 0, time.UTC)
+	start, end := BusinessDay(now)
+	assert.Equal(t, time.Date(2025, 3, 25, 17, 0, 0, 0, time.UTC), start.UTC())
+	assert.Equal(t, time.Date(2025, 3, 26, 17, 0, 0, 0, time.UTC), end.UTC())
+}
+
+func TestBusinessMonth(t *testing.T) {
+	// 2025-03-31 18:30 UTC is 2025-04-01 01:30 in Jakarta.
+	now := time.Date(2025, 3, 31, 18, 30, 0, 0, time.UTC)
+	start, end := BusinessMonth(now)
+	assert.Equal(t, time.Date(2025, 3, 31, 17, 0, 0, 0, time.UTC), start.UTC())
+	assert.Equal(t, time.Date(2025, 4, 30, 17, 0, 0, 0, time.UTC), end.UTC())
+}
+
+func TestTransactionFilterValid(t *testing.T) {
+	now := time.Now()
+	assert.True(t, (&TransactionFilter{}).Valid())
+	assert.True(t, (&TransactionFilter{From: now, To: now.Add(time.Hour), MinAmount: 1, MaxAmount: 10, Limit: 20}).Valid())
+	assert.False(t, (&TransactionFilter{From: now.Add(time.Hour), To: now}).Valid())
+	assert.False(t, (&TransactionFilter{MinAmount: 10, MaxAmount: 1}).Valid())
+	assert.False(t, (&TransactionFilter{MinAmount: -1}).Valid())
+	assert.False(t, (&TransactionFilter{Cursor: -1}).Valid())
+	assert.False(t, (&TransactionFilter{Limit: MaxHistoryLimit + 1}).Valid())
+}
+
+func TestAccountIsOwnedBy(t *testing.T) {
+	acc := &Account{CIF: "1234567"}
+
+	assert.True(t, acc.IsOwnedBy("1234567"))
+	assert.False(t, acc.IsOwnedBy("7654321"))
+	assert.False(t, (&Account{}).IsOwnedBy(""))
+}
+
+func TestAccountCanDebit(t *testing.T) {
+	acc := &Account{AvailableBalance: 150_000, MinBalance: 50_000}
+
+	assert.True(t, acc.CanDebit(100_000))
+	assert.False(t, acc.CanDebit(100_001))
+
+	acc.Blocked = "1"
+	assert.False(t, acc.CanDebit(1))
+
+	acc.Blocked = "2"
+	assert.True(t, acc.CanDebit(1))
+
+	acc.Blocked = "X"
+	assert.False(t, acc.CanDebit(1))
+}
+
+func TestRecoveryRetry(t *testing.T) {
+	r := NewRecovery(&Transaction{ID: 1, SequenceNumber: "123456"}, errors.New("some error"))
+
+	for i := 1; i < maxRecoveryAttempts; i++ {
+		r.Retry(errors.New("connection refused"))
+		assert.Equal(t, RecoveryOpen, r.Status)
+	}
+
+	r.Retry(errors.New("connection refused"))
+	assert.Equal(t, RecoveryManual, r.Status)
+	assert.Equal(t, maxRecoveryAttempts, r.Attempts)
+	assert.Equal(t, "connection refused", r.LastError)
+}
+
+func TestOutboxMessageRetry(t *testing.T) {
+	at := time.Date(2025, 3, 25, 10, 0, 0, 0, time.UTC)
+	m := NewReceiptMessage(1, &EmailData{Recipient: "olivia@gmail.com"})
+
+	m.Retry(errors.New("smtp unavailable"), at)
+	assert.Equal(t, OutboxPending, m.Status)
+	assert.Equal(t, at.Add(30*time.Second), m.NextAttemptAt)
+
+	m.Retry(errors.New("smtp unavailable"), at)
+	assert.Equal(t, at.Add(time.Minute), m.NextAttemptAt)
+
+	for m.Attempts < maxOutboxAttempts-1 {
+		m.Retry(errors.New("smtp unavailable"), at)
+	}
+	assert.Equal(t, at.Add(time.Hour), m.NextAttemptAt)
+
+	m.Retry(errors.New("smtp unavailable"), at)
+	assert.Equal(t, OutboxDead, m.Status)
+	assert.Equal(t, "smtp unavailable", m.LastError)
+}
+
+func TestOutboxMessagePayload(t *testing.T) {
+	email := &EmailData{Recipient: "olivia@gmail.com", Amount: 100000}
+	got, err := NewReceiptMessage(1, email).Receipt()
+
+	assert.NoError(t, err)
+	assert.Equal(t, email, got)
+}
+
+func TestSequenceExpired(t *testing.T) {
+	now := time.Date(2025, 3, 25, 10, 0, 0, 0, time.UTC)
+
+	assert.False(t, (&Sequence{ExpiresAt: now.Add(time.Second)}).Expired(now))
+	assert.True(t, (&Sequence{ExpiresAt: now}).Expired(now))
+	assert.True(t, (&Sequence{ExpiresAt: now.Add(-time.Minute)}).Expired(now))
+}
+
+func TestSequenceValidityFor(t *testing.T) {
+	v := SequenceValidity{"internal_transfer": 5 * time.Minute, "interbank_transfer": 0}
+
+	assert.Equal(t, 5*time.Minute, v.For("internal_transfer"))
+	assert.Equal(t, DefaultSequenceValidity, v.For("interbank_transfer"))
+	assert.Equal(t, DefaultSequenceValidity, v.For("unknown"))
+	assert.Equal(t, DefaultSequenceValidity, SequenceValidity(nil).For("internal_transfer"))
+}
+
+func TestSequenceOwnedBy(t *testing.T) {
+	seq := &Sequence{UserID: 123, DeviceID: "device-1"}
+
+	assert.True(t, seq.OwnedBy(123, "device-1"))
+	assert.False(t, seq.OwnedBy(456, "device-1"))
+	assert.False(t, seq.OwnedBy(123, "device-2"))
+}
+
+func TestSequenceMatches(t *testing.T) {
+	seq := &Sequence{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	}
+
+	assert.True(t, seq.Matches(&PaymentInput{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	}))
+	assert.False(t, seq.Matches(&PaymentInput{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567899",
+		Amount:             100000,
+	}))
+	assert.False(t, seq.Matches(&PaymentInput{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             1,
+	}))
+}
+
+func TestSequenceRemark(t *testing.T) {
+	seq := &Sequence{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	}
+
+	assert.Equal(t, "TRF 001001234567891 001001234567892 BNKYAYA 123456", seq.Remark())
+
+	seq.Note = "Salary March"
+	assert.Equal(t, "TRF 001001234567891 001001234567892 BNKYAYA 123456 Salary March", seq.Remark())
+}
+
+func TestStepUpPolicyAboveThreshold(t *testing.T) {
+	assert.True(t, StepUpPolicy{Threshold: 50_000}.AboveThreshold(50_001))
+	assert.False(t, StepUpPolicy{Threshold: 50_000}.AboveThreshold(50_000))
+	assert.False(t, StepUpPolicy{}.AboveThreshold(100_000_000))
+}
+
+func TestValidateIdempotencyKey(t *testing.T) {
+	assert.NoError(t, ValidateIdempotencyKey(""))
+	assert.NoError(t, ValidateIdempotencyKey("schedule-1"))
+	assert.ErrorIs(t, ValidateIdempotencyKey(InternalIdempotencyKey("schedule-1")), ErrIdempotencyKeyReserved)
+}
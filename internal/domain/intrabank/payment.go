@@ -0,0 +1,448 @@
+package intrabank
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+// Payment is a payment of a sequence with the details its payment method loaded for it,
+// e.g. the bill of a bill payment.
+type Payment[D any] struct {
+	User        *ctxt.User
+	Sequence    *Sequence
+	Transaction *Transaction
+	Details     D
+}
+
+// PaymentMessages are the messages shown to the user by the steps the payment methods share,
+// each method words them for its own payments.
+type PaymentMessages struct {
+	// Rejected is shown when the payment does not match its sequence.
+	Rejected string
+	// KeyReused is shown when the idempotency key has been used for another sequence.
+	KeyReused string
+	// Expired is shown when the sequence can no longer be paid.
+	Expired string
+	// OTPRequired is shown when the payment must be verified with a transaction OTP.
+	OTPRequired string
+	// InProgress is shown when another request is paying the sequence.
+	InProgress string
+	// Failed is shown when the previous payment of the sequence has failed.
+	Failed string
+	// Pending is shown when the outcome of the posting is unknown.
+	Pending string
+	// NotRecorded is shown when the money has moved but the payment could not be stored.
+	NotRecorded string
+}
+
+// PaymentMethod is a way of paying a sequence, e.g. an intrabank transfer or a QRIS payment.
+// The Payer runs the steps shared by all payment methods and calls the method for its own steps.
+// D is the type of the details the method loads for a payment.
+type PaymentMethod[D any] interface {
+	// Messages returns the messages of the shared steps worded for the payment method.
+	Messages() *PaymentMessages
+
+	// Accepts checks if the sequence is paid with the payment method.
+	Accepts(sequence *Sequence) bool
+
+	// Prepare loads the details of the payment and checks that the payment method can pay its amount,
+	// before the OTP is verified. It returns the error shown to the user.
+	Prepare(ctx context.Context, payment *Payment[D]) error
+
+	// KnownDestination checks if the user has paid the destination of the payment before,
+	// a payment to a new destination needs a transaction OTP.
+	KnownDestination(ctx context.Context, payment *Payment[D]) (bool, error)
+
+	// Describe returns the transaction type and the remark of the payment transaction.
+	Describe(payment *Payment[D]) (transactionType, remark string)
+
+	// CheckDailyLimit checks the daily limit of the user, including the pending transaction of the payment.
+	// It returns the error shown to the user.
+	CheckDailyLimit(ctx context.Context, payment *Payment[D]) error
+
+	// Post moves the money of the payment. It returns an *OverbookingRejection when the payment has been rejected
+	// and no money has moved, the outcome of any other error is unknown.
+	Post(ctx context.Context, payment *Payment[D]) (*OverbookingResult, error)
+
+	// Rejected returns the error shown to the user for the rejected payment.
+	Rejected(payment *Payment[D], rejection *OverbookingRejection) error
+
+	// Receipt builds the receipt email and the push notification of the payment result,
+	// the push notification is completed with the device of the user by the Payer.
+	Receipt(payment *Payment[D]) (*EmailData, *Notification)
+
+	// Load loads the details of a pending payment settled outside a request, the payment is not checked again.
+	Load(ctx context.Context, payment *Payment[D]) error
+
+	// Resolve checks the outcome of the pending payment at the core banking system and the partner of the method.
+	// It returns the result of a completed payment, an *OverbookingRejection or ErrPostingNotFound
+	// when no money has moved, and any other error when the outcome is still unknown.
+	// A debit whose payment has not been accepted by the partner is reversed before the rejection is returned.
+	Resolve(ctx context.Context, payment *Payment[D]) (*OverbookingResult, error)
+}
+
+// Payer pays the sequences of a payment method. It checks the sequence and the step-up authorization,
+// reserves the amount with a pending transaction before the money is moved and stores the result,
+// so a payment is never made twice and a posting whose outcome is unknown is never reported as failed.
+type Payer[D any] struct {
+	log         *logger.Logger
+	domain      string
+	repo        PaymentRepository
+	corebanking CoreStatusChecker
+	authorizer  TransactionAuthorizer
+	stepUp      StepUpPolicy
+	method      PaymentMethod[D]
+}
+
+// NewPayer creates a new Payer of the payment method, logging under the domain name of the method.
+func NewPayer[D any](
+	log *logger.Logger,
+	domain string,
+	repo PaymentRepository,
+	corebanking CoreStatusChecker,
+	authorizer TransactionAuthorizer,
+	stepUp StepUpPolicy,
+	method PaymentMethod[D],
+) *Payer[D] {
+	return &Payer[D]{
+		log:         log,
+		domain:      domain,
+		repo:        repo,
+		corebanking: corebanking,
+		authorizer:  authorizer,
+		stepUp:      stepUp,
+		method:      method,
+	}
+}
+
+// Pay pays the sequence of the payment input for the authenticated user.
+// A sequence that has already been paid returns its transaction without its details.
+func (p *Payer[D]) Pay(ctx context.Context, in *PaymentInput) (*Payment[D], error) {
+	messages := p.method.Messages()
+
+	coreStatus, err := p.corebanking.GetCoreStatus(ctx)
+	if err != nil {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("CheckEOD: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if coreStatus.IsEODRunning() {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("CheckEOD: %v", ErrEODInProgress)
+		return nil, pkgerror.New(codes.Internal, ErrEODInProgress)
+	}
+
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	sequence, err := p.repo.GetSequence(ctx, in.SequenceNumber)
+	if err != nil {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("GetSequence: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !sequence.Valid(in.SequenceNumber) || !p.method.Accepts(sequence) {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("GetSequence: %v", ErrInvalidSequenceNumber)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidSequenceNumber).
+			SetMsg(messages.Rejected)
+	}
+	if !sequence.OwnedBy(user.ID, user.DeviceID) || !sequence.Matches(in) {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf(
+			"possible tampering: sequence (%v) of user (%v) device (%v) %v/%v/%v paid by user (%v) device (%v) %v/%v/%v",
+			sequence.SequenceNumber, sequence.UserID, sequence.DeviceID,
+			sequence.SourceAccount, sequence.DestinationAccount, sequence.Amount,
+			user.ID, user.DeviceID, in.SourceAccount, in.DestinationAccount, in.Amount)
+		return nil, pkgerror.New(codes.Forbidden, ErrSequenceMismatch).
+			SetMsg(messages.Rejected)
+	}
+
+	if in.IdempotencyKey != "" {
+		keySequence, err := p.repo.GetSequenceByIdempotencyKey(ctx, user.ID, in.IdempotencyKey)
+		if err != nil && !errors.Is(err, ErrSequenceNotFound) {
+			p.log.DomainUsecase(p.domain, "DoPayment").Errorf("GetSequenceByIdempotencyKey: %v", err)
+			return nil, pkgerror.New(codes.Internal, ErrGeneral)
+		}
+		if err == nil && !keySequence.Valid(sequence.SequenceNumber) {
+			p.log.DomainUsecase(p.domain, "DoPayment").Errorf("GetSequenceByIdempotencyKey: %v", ErrIdempotencyKeyReused)
+			return nil, pkgerror.New(codes.Conflict, ErrIdempotencyKeyReused).
+				SetMsg(messages.KeyReused)
+		}
+	}
+
+	payment := &Payment[D]{
+		User:     user,
+		Sequence: sequence,
+	}
+
+	if !sequence.Payable() {
+		return p.previousPayment(ctx, payment)
+	}
+	if sequence.Expired(time.Now()) {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("sequence (%v): %v", sequence.SequenceNumber, ErrSequenceExpired)
+		return nil, pkgerror.New(codes.BadRequest, ErrSequenceExpired).
+			SetMsg(messages.Expired)
+	}
+
+	// The payment method is checked before the OTP, so a closed method does not use it up.
+	if err := p.method.Prepare(ctx, payment); err != nil {
+		return nil, err
+	}
+
+	if !in.Preauthorized {
+		if err := p.authorize(ctx, payment, in); err != nil {
+			return nil, err
+		}
+	}
+
+	err = p.repo.AcquireSequence(ctx, sequence.SequenceNumber, in.IdempotencyKey)
+	if errors.Is(err, ErrSequenceAlreadyProcessed) {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("AcquireSequence: %v", err)
+		return nil, pkgerror.New(codes.Conflict, ErrPaymentInProgress).
+			SetMsg(messages.InProgress)
+	}
+	if err != nil {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("AcquireSequence: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	transactionType, remark := p.method.Describe(payment)
+	transaction := &Transaction{
+		SequenceNumber:  sequence.SequenceNumber,
+		UserID:          strconv.Itoa(user.ID),
+		Destination:     sequence.DestinationAccount,
+		BankCode:        sequence.BankCode,
+		Amount:          sequence.Amount,
+		TransactionType: transactionType,
+		Remarks:         remark,
+		Status:          TransactionPending,
+		Fee:             sequence.Fee.String(),
+		DestinationName: sequence.DestinationName,
+		Note:            sequence.Note,
+		StandingOrderID: in.StandingOrderID,
+	}
+	payment.Transaction = transaction
+
+	// The pending transaction reserves the amount in the daily limit before the money is moved,
+	// so concurrent payments of the same user always see each other.
+	err = p.repo.InsertTransaction(ctx, transaction)
+	if err != nil {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("InsertTransaction: %v", err)
+		if err := p.repo.UpdateSequenceStatus(ctx, sequence.SequenceNumber, SequenceCreated); err != nil {
+			p.log.DomainUsecase(p.domain, "DoPayment").Errorf("UpdateSequenceStatus: %v", err)
+		}
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	if err := p.method.CheckDailyLimit(ctx, payment); err != nil {
+		p.failTransaction(ctx, transaction, nil)
+		return nil, err
+	}
+
+	result, err := p.method.Post(ctx, payment)
+	var rejection *OverbookingRejection
+	if errors.As(err, &rejection) {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("Post: %v", err)
+		transaction.Status = TransactionFailed
+		transaction.StatusCode = rejection.StatusCode
+		transaction.CoreResponsePayload = rejection.Payload
+		p.failTransaction(ctx, transaction, p.outbox(ctx, payment))
+		return nil, p.method.Rejected(payment, rejection)
+	}
+	if err != nil {
+		// The money may have moved, so the transaction is left pending and settled later
+		// instead of telling the user that the payment has failed.
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("Post: transaction (%v) sequence (%v): %v",
+			transaction.ID, transaction.SequenceNumber, err)
+		return nil, pkgerror.New(codes.Internal, ErrPaymentPending).
+			SetMsg(messages.Pending)
+	}
+
+	transaction.SequenceJournal = result.JournalSequence
+	transaction.TransactionReference = result.TransactionReference
+	transaction.Status = TransactionSuccess
+
+	// The receipt and the notification are delivered by the outbox dispatcher,
+	// so the payment result does not depend on the delivery.
+	outbox := p.outbox(ctx, payment)
+
+	err = p.repo.CompleteTransaction(ctx, transaction, outbox)
+	if err != nil {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("CompleteTransaction: %v", err)
+
+		// The money has moved, so the posting is journaled for the reconciler
+		// instead of failing a payment that has actually succeeded.
+		err = p.repo.InsertRecovery(ctx, NewRecovery(transaction, err), outbox)
+		if err != nil {
+			p.log.DomainUsecase(p.domain, "DoPayment").Errorf(
+				"InsertRecovery: transaction (%v) sequence (%v) journal (%v) reference (%v): %v",
+				transaction.ID, transaction.SequenceNumber, transaction.SequenceJournal, transaction.TransactionReference, err)
+			return nil, pkgerror.New(codes.Internal, ErrTransactionNotRecorded).
+				SetMsg(messages.NotRecorded)
+		}
+	}
+
+	return payment, nil
+}
+
+// SettlePending settles the payments left pending longer than pendingSettleDelay, i.e. the postings
+// with an unknown outcome and the completed postings that could not be recorded at all.
+func (p *Payer[D]) SettlePending(ctx context.Context) error {
+	transactions, err := p.repo.GetPendingTransactions(ctx, time.Now().Add(-pendingSettleDelay), reconcileBatchSize)
+	if err != nil {
+		p.log.DomainUsecase(p.domain, "Settle").Errorf("GetPendingTransactions: %v", err)
+		return err
+	}
+
+	for _, transaction := range transactions {
+		p.settle(ctx, transaction)
+	}
+
+	return nil
+}
+
+// settle finishes the pending transaction from the outcome of its payment checked by the payment method.
+// A completed payment completes the transaction, a rejected or never received posting fails it
+// and any other outcome leaves it pending for the next run.
+func (p *Payer[D]) settle(ctx context.Context, transaction *Transaction) {
+	sequence, err := p.repo.GetSequence(ctx, transaction.SequenceNumber)
+	if err != nil {
+		p.log.DomainUsecase(p.domain, "Settle").Errorf("transaction (%v) GetSequence: %v", transaction.ID, err)
+		return
+	}
+	user, err := p.repo.GetUser(ctx, sequence.UserID)
+	if err != nil {
+		p.log.DomainUsecase(p.domain, "Settle").Errorf("transaction (%v) GetUser: %v", transaction.ID, err)
+		return
+	}
+
+	payment := &Payment[D]{
+		User:        user,
+		Sequence:    sequence,
+		Transaction: transaction,
+	}
+	if err := p.method.Load(ctx, payment); err != nil {
+		p.log.DomainUsecase(p.domain, "Settle").Errorf("transaction (%v) Load: %v", transaction.ID, err)
+		return
+	}
+
+	result, err := p.method.Resolve(ctx, payment)
+	var rejection *OverbookingRejection
+	switch {
+	case err == nil:
+		transaction.SequenceJournal = result.JournalSequence
+		transaction.TransactionReference = result.TransactionReference
+		transaction.Status = TransactionSuccess
+	case errors.As(err, &rejection):
+		transaction.Status = TransactionFailed
+		transaction.StatusCode = rejection.StatusCode
+		transaction.CoreResponsePayload = rejection.Payload
+	case errors.Is(err, ErrPostingNotFound):
+		transaction.Status = TransactionFailed
+		transaction.CoreResponsePayload = err.Error()
+	default:
+		p.log.DomainUsecase(p.domain, "Settle").Errorf("transaction (%v) Resolve: %v", transaction.ID, err)
+		return
+	}
+
+	outbox := p.outbox(ctx, payment)
+	if transaction.Status == TransactionSuccess {
+		err = p.repo.CompleteTransaction(ctx, transaction, outbox)
+	} else {
+		err = p.repo.FailTransaction(ctx, transaction, outbox)
+	}
+	if err != nil && !errors.Is(err, ErrTransactionFinished) {
+		p.log.DomainUsecase(p.domain, "Settle").Errorf("transaction (%v) settle %v: %v", transaction.ID, transaction.Status, err)
+	}
+}
+
+// authorize requires a transaction OTP bound to the sequence number when the amount
+// is above the step-up threshold or the user has never paid the destination.
+func (p *Payer[D]) authorize(ctx context.Context, payment *Payment[D], in *PaymentInput) error {
+	sequence := payment.Sequence
+
+	required := p.stepUp.AboveThreshold(sequence.Amount)
+	if !required {
+		known, err := p.method.KnownDestination(ctx, payment)
+		if err != nil {
+			p.log.DomainUsecase(p.domain, "DoPayment").Errorf("KnownDestination: %v", err)
+			return pkgerror.New(codes.Internal, ErrGeneral)
+		}
+		required = !known
+	}
+	if !required {
+		return nil
+	}
+
+	if in.OTPID == 0 || in.OTPCode == "" {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("sequence (%v): %v", sequence.SequenceNumber, ErrOTPRequired)
+		return pkgerror.New(codes.Forbidden, ErrOTPRequired).
+			SetMsg(p.method.Messages().OTPRequired)
+	}
+	if err := p.authorizer.VerifyTransaction(ctx, in.OTPID, in.OTPCode, sequence.SequenceNumber); err != nil {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("VerifyTransaction: %v", err)
+		return err
+	}
+	return nil
+}
+
+// previousPayment returns the payment of a sequence that has already been paid,
+// so a repeated payment request never moves the money twice.
+func (p *Payer[D]) previousPayment(ctx context.Context, payment *Payment[D]) (*Payment[D], error) {
+	sequence := payment.Sequence
+	if sequence.IsPending() {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("sequence (%v): %v", sequence.SequenceNumber, ErrPaymentInProgress)
+		return nil, pkgerror.New(codes.Conflict, ErrPaymentInProgress).
+			SetMsg(p.method.Messages().InProgress)
+	}
+	if !sequence.IsCompleted() {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("sequence (%v): %v", sequence.SequenceNumber, ErrPaymentFailed)
+		return nil, pkgerror.New(codes.BadRequest, ErrPaymentFailed).
+			SetMsg(p.method.Messages().Failed)
+	}
+
+	transaction, err := p.repo.GetTransactionBySequenceNumber(ctx, sequence.SequenceNumber)
+	if err != nil {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("GetTransactionBySequenceNumber: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	payment.Transaction = transaction
+
+	return payment, nil
+}
+
+// failTransaction marks the transaction and its sequence as failed.
+// The failure is only logged, the caller has already decided the payment result.
+func (p *Payer[D]) failTransaction(ctx context.Context, transaction *Transaction, outbox []*OutboxMessage) {
+	transaction.Status = TransactionFailed
+	if err := p.repo.FailTransaction(ctx, transaction, outbox); err != nil {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("FailTransaction: %v", err)
+	}
+}
+
+// outbox builds the receipt email and the push notification of the payment result.
+// The push notification is left out when the user has no registered device.
+func (p *Payer[D]) outbox(ctx context.Context, payment *Payment[D]) []*OutboxMessage {
+	email, notification := p.method.Receipt(payment)
+	outbox := []*OutboxMessage{
+		NewReceiptMessage(payment.Transaction.ID, email),
+	}
+
+	firebaseID, err := p.repo.GetFirebaseID(ctx, payment.User.ID)
+	if err != nil {
+		p.log.DomainUsecase(p.domain, "DoPayment").Errorf("GetFirebaseID: %v", err)
+	}
+	if firebaseID == "" {
+		return outbox
+	}
+
+	notification.FirebaseID = firebaseID
+	return append(outbox, NewNotificationMessage(payment.Transaction.ID, notification))
+}
@@ -0,0 +1,608 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package intrabank
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPaymentMethod is an autogenerated mock type for the PaymentMethod type
+type MockPaymentMethod[D interface{}] struct {
+	mock.Mock
+}
+
+type MockPaymentMethod_Expecter[D interface{}] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPaymentMethod[D]) EXPECT() *MockPaymentMethod_Expecter[D] {
+	return &MockPaymentMethod_Expecter[D]{mock: &_m.Mock}
+}
+
+// Accepts provides a mock function with given fields: sequence
+func (_m *MockPaymentMethod[D]) Accepts(sequence *Sequence) bool {
+	ret := _m.Called(sequence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Accepts")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*Sequence) bool); ok {
+		r0 = rf(sequence)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockPaymentMethod_Accepts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Accepts'
+type MockPaymentMethod_Accepts_Call[D interface{}] struct {
+	*mock.Call
+}
+
+// Accepts is a helper method to define mock.On call
+//   - sequence *Sequence
+func (_e *MockPaymentMethod_Expecter[D]) Accepts(sequence interface{}) *MockPaymentMethod_Accepts_Call[D] {
+	return &MockPaymentMethod_Accepts_Call[D]{Call: _e.mock.On("Accepts", sequence)}
+}
+
+func (_c *MockPaymentMethod_Accepts_Call[D]) Run(run func(sequence *Sequence)) *MockPaymentMethod_Accepts_Call[D] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*Sequence))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethod_Accepts_Call[D]) Return(_a0 bool) *MockPaymentMethod_Accepts_Call[D] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentMethod_Accepts_Call[D]) RunAndReturn(run func(*Sequence) bool) *MockPaymentMethod_Accepts_Call[D] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckDailyLimit provides a mock function with given fields: ctx, payment
+func (_m *MockPaymentMethod[D]) CheckDailyLimit(ctx context.Context, payment *Payment[D]) error {
+	ret := _m.Called(ctx, payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckDailyLimit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Payment[D]) error); ok {
+		r0 = rf(ctx, payment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentMethod_CheckDailyLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckDailyLimit'
+type MockPaymentMethod_CheckDailyLimit_Call[D interface{}] struct {
+	*mock.Call
+}
+
+// CheckDailyLimit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payment *Payment[D]
+func (_e *MockPaymentMethod_Expecter[D]) CheckDailyLimit(ctx interface{}, payment interface{}) *MockPaymentMethod_CheckDailyLimit_Call[D] {
+	return &MockPaymentMethod_CheckDailyLimit_Call[D]{Call: _e.mock.On("CheckDailyLimit", ctx, payment)}
+}
+
+func (_c *MockPaymentMethod_CheckDailyLimit_Call[D]) Run(run func(ctx context.Context, payment *Payment[D])) *MockPaymentMethod_CheckDailyLimit_Call[D] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Payment[D]))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethod_CheckDailyLimit_Call[D]) Return(_a0 error) *MockPaymentMethod_CheckDailyLimit_Call[D] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentMethod_CheckDailyLimit_Call[D]) RunAndReturn(run func(context.Context, *Payment[D]) error) *MockPaymentMethod_CheckDailyLimit_Call[D] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Describe provides a mock function with given fields: payment
+func (_m *MockPaymentMethod[D]) Describe(payment *Payment[D]) (string, string) {
+	ret := _m.Called(payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Describe")
+	}
+
+	var r0 string
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*Payment[D]) (string, string)); ok {
+		return rf(payment)
+	}
+	if rf, ok := ret.Get(0).(func(*Payment[D]) string); ok {
+		r0 = rf(payment)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*Payment[D]) string); ok {
+		r1 = rf(payment)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethod_Describe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Describe'
+type MockPaymentMethod_Describe_Call[D interface{}] struct {
+	*mock.Call
+}
+
+// Describe is a helper method to define mock.On call
+//   - payment *Payment[D]
+func (_e *MockPaymentMethod_Expecter[D]) Describe(payment interface{}) *MockPaymentMethod_Describe_Call[D] {
+	return &MockPaymentMethod_Describe_Call[D]{Call: _e.mock.On("Describe", payment)}
+}
+
+func (_c *MockPaymentMethod_Describe_Call[D]) Run(run func(payment *Payment[D])) *MockPaymentMethod_Describe_Call[D] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*Payment[D]))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethod_Describe_Call[D]) Return(transactionType string, remark string) *MockPaymentMethod_Describe_Call[D] {
+	_c.Call.Return(transactionType, remark)
+	return _c
+}
+
+func (_c *MockPaymentMethod_Describe_Call[D]) RunAndReturn(run func(*Payment[D]) (string, string)) *MockPaymentMethod_Describe_Call[D] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// KnownDestination provides a mock function with given fields: ctx, payment
+func (_m *MockPaymentMethod[D]) KnownDestination(ctx context.Context, payment *Payment[D]) (bool, error) {
+	ret := _m.Called(ctx, payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KnownDestination")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Payment[D]) (bool, error)); ok {
+		return rf(ctx, payment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Payment[D]) bool); ok {
+		r0 = rf(ctx, payment)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Payment[D]) error); ok {
+		r1 = rf(ctx, payment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethod_KnownDestination_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'KnownDestination'
+type MockPaymentMethod_KnownDestination_Call[D interface{}] struct {
+	*mock.Call
+}
+
+// KnownDestination is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payment *Payment[D]
+func (_e *MockPaymentMethod_Expecter[D]) KnownDestination(ctx interface{}, payment interface{}) *MockPaymentMethod_KnownDestination_Call[D] {
+	return &MockPaymentMethod_KnownDestination_Call[D]{Call: _e.mock.On("KnownDestination", ctx, payment)}
+}
+
+func (_c *MockPaymentMethod_KnownDestination_Call[D]) Run(run func(ctx context.Context, payment *Payment[D])) *MockPaymentMethod_KnownDestination_Call[D] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Payment[D]))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethod_KnownDestination_Call[D]) Return(_a0 bool, _a1 error) *MockPaymentMethod_KnownDestination_Call[D] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethod_KnownDestination_Call[D]) RunAndReturn(run func(context.Context, *Payment[D]) (bool, error)) *MockPaymentMethod_KnownDestination_Call[D] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Load provides a mock function with given fields: ctx, payment
+func (_m *MockPaymentMethod[D]) Load(ctx context.Context, payment *Payment[D]) error {
+	ret := _m.Called(ctx, payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Load")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Payment[D]) error); ok {
+		r0 = rf(ctx, payment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentMethod_Load_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Load'
+type MockPaymentMethod_Load_Call[D interface{}] struct {
+	*mock.Call
+}
+
+// Load is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payment *Payment[D]
+func (_e *MockPaymentMethod_Expecter[D]) Load(ctx interface{}, payment interface{}) *MockPaymentMethod_Load_Call[D] {
+	return &MockPaymentMethod_Load_Call[D]{Call: _e.mock.On("Load", ctx, payment)}
+}
+
+func (_c *MockPaymentMethod_Load_Call[D]) Run(run func(ctx context.Context, payment *Payment[D])) *MockPaymentMethod_Load_Call[D] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Payment[D]))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethod_Load_Call[D]) Return(_a0 error) *MockPaymentMethod_Load_Call[D] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentMethod_Load_Call[D]) RunAndReturn(run func(context.Context, *Payment[D]) error) *MockPaymentMethod_Load_Call[D] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Messages provides a mock function with no fields
+func (_m *MockPaymentMethod[D]) Messages() *PaymentMessages {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Messages")
+	}
+
+	var r0 *PaymentMessages
+	if rf, ok := ret.Get(0).(func() *PaymentMessages); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaymentMessages)
+		}
+	}
+
+	return r0
+}
+
+// MockPaymentMethod_Messages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Messages'
+type MockPaymentMethod_Messages_Call[D interface{}] struct {
+	*mock.Call
+}
+
+// Messages is a helper method to define mock.On call
+func (_e *MockPaymentMethod_Expecter[D]) Messages() *MockPaymentMethod_Messages_Call[D] {
+	return &MockPaymentMethod_Messages_Call[D]{Call: _e.mock.On("Messages")}
+}
+
+func (_c *MockPaymentMethod_Messages_Call[D]) Run(run func()) *MockPaymentMethod_Messages_Call[D] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethod_Messages_Call[D]) Return(_a0 *PaymentMessages) *MockPaymentMethod_Messages_Call[D] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentMethod_Messages_Call[D]) RunAndReturn(run func() *PaymentMessages) *MockPaymentMethod_Messages_Call[D] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Post provides a mock function with given fields: ctx, payment
+func (_m *MockPaymentMethod[D]) Post(ctx context.Context, payment *Payment[D]) (*OverbookingResult, error) {
+	ret := _m.Called(ctx, payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Post")
+	}
+
+	var r0 *OverbookingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Payment[D]) (*OverbookingResult, error)); ok {
+		return rf(ctx, payment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Payment[D]) *OverbookingResult); ok {
+		r0 = rf(ctx, payment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*OverbookingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Payment[D]) error); ok {
+		r1 = rf(ctx, payment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethod_Post_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Post'
+type MockPaymentMethod_Post_Call[D interface{}] struct {
+	*mock.Call
+}
+
+// Post is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payment *Payment[D]
+func (_e *MockPaymentMethod_Expecter[D]) Post(ctx interface{}, payment interface{}) *MockPaymentMethod_Post_Call[D] {
+	return &MockPaymentMethod_Post_Call[D]{Call: _e.mock.On("Post", ctx, payment)}
+}
+
+func (_c *MockPaymentMethod_Post_Call[D]) Run(run func(ctx context.Context, payment *Payment[D])) *MockPaymentMethod_Post_Call[D] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Payment[D]))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethod_Post_Call[D]) Return(_a0 *OverbookingResult, _a1 error) *MockPaymentMethod_Post_Call[D] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethod_Post_Call[D]) RunAndReturn(run func(context.Context, *Payment[D]) (*OverbookingResult, error)) *MockPaymentMethod_Post_Call[D] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Prepare provides a mock function with given fields: ctx, payment
+func (_m *MockPaymentMethod[D]) Prepare(ctx context.Context, payment *Payment[D]) error {
+	ret := _m.Called(ctx, payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Prepare")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Payment[D]) error); ok {
+		r0 = rf(ctx, payment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentMethod_Prepare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Prepare'
+type MockPaymentMethod_Prepare_Call[D interface{}] struct {
+	*mock.Call
+}
+
+// Prepare is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payment *Payment[D]
+func (_e *MockPaymentMethod_Expecter[D]) Prepare(ctx interface{}, payment interface{}) *MockPaymentMethod_Prepare_Call[D] {
+	return &MockPaymentMethod_Prepare_Call[D]{Call: _e.mock.On("Prepare", ctx, payment)}
+}
+
+func (_c *MockPaymentMethod_Prepare_Call[D]) Run(run func(ctx context.Context, payment *Payment[D])) *MockPaymentMethod_Prepare_Call[D] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Payment[D]))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethod_Prepare_Call[D]) Return(_a0 error) *MockPaymentMethod_Prepare_Call[D] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentMethod_Prepare_Call[D]) RunAndReturn(run func(context.Context, *Payment[D]) error) *MockPaymentMethod_Prepare_Call[D] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Receipt provides a mock function with given fields: payment
+func (_m *MockPaymentMethod[D]) Receipt(payment *Payment[D]) (*EmailData, *Notification) {
+	ret := _m.Called(payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Receipt")
+	}
+
+	var r0 *EmailData
+	var r1 *Notification
+	if rf, ok := ret.Get(0).(func(*Payment[D]) (*EmailData, *Notification)); ok {
+		return rf(payment)
+	}
+	if rf, ok := ret.Get(0).(func(*Payment[D]) *EmailData); ok {
+		r0 = rf(payment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*EmailData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*Payment[D]) *Notification); ok {
+		r1 = rf(payment)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*Notification)
+		}
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethod_Receipt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Receipt'
+type MockPaymentMethod_Receipt_Call[D interface{}] struct {
+	*mock.Call
+}
+
+// Receipt is a helper method to define mock.On call
+//   - payment *Payment[D]
+func (_e *MockPaymentMethod_Expecter[D]) Receipt(payment interface{}) *MockPaymentMethod_Receipt_Call[D] {
+	return &MockPaymentMethod_Receipt_Call[D]{Call: _e.mock.On("Receipt", payment)}
+}
+
+func (_c *MockPaymentMethod_Receipt_Call[D]) Run(run func(payment *Payment[D])) *MockPaymentMethod_Receipt_Call[D] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*Payment[D]))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethod_Receipt_Call[D]) Return(_a0 *EmailData, _a1 *Notification) *MockPaymentMethod_Receipt_Call[D] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethod_Receipt_Call[D]) RunAndReturn(run func(*Payment[D]) (*EmailData, *Notification)) *MockPaymentMethod_Receipt_Call[D] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rejected provides a mock function with given fields: payment, rejection
+func (_m *MockPaymentMethod[D]) Rejected(payment *Payment[D], rejection *OverbookingRejection) error {
+	ret := _m.Called(payment, rejection)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rejected")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*Payment[D], *OverbookingRejection) error); ok {
+		r0 = rf(payment, rejection)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentMethod_Rejected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rejected'
+type MockPaymentMethod_Rejected_Call[D interface{}] struct {
+	*mock.Call
+}
+
+// Rejected is a helper method to define mock.On call
+//   - payment *Payment[D]
+//   - rejection *OverbookingRejection
+func (_e *MockPaymentMethod_Expecter[D]) Rejected(payment interface{}, rejection interface{}) *MockPaymentMethod_Rejected_Call[D] {
+	return &MockPaymentMethod_Rejected_Call[D]{Call: _e.mock.On("Rejected", payment, rejection)}
+}
+
+func (_c *MockPaymentMethod_Rejected_Call[D]) Run(run func(payment *Payment[D], rejection *OverbookingRejection)) *MockPaymentMethod_Rejected_Call[D] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*Payment[D]), args[1].(*OverbookingRejection))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethod_Rejected_Call[D]) Return(_a0 error) *MockPaymentMethod_Rejected_Call[D] {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentMethod_Rejected_Call[D]) RunAndReturn(run func(*Payment[D], *OverbookingRejection) error) *MockPaymentMethod_Rejected_Call[D] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resolve provides a mock function with given fields: ctx, payment
+func (_m *MockPaymentMethod[D]) Resolve(ctx context.Context, payment *Payment[D]) (*OverbookingResult, error) {
+	ret := _m.Called(ctx, payment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 *OverbookingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Payment[D]) (*OverbookingResult, error)); ok {
+		return rf(ctx, payment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Payment[D]) *OverbookingResult); ok {
+		r0 = rf(ctx, payment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*OverbookingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Payment[D]) error); ok {
+		r1 = rf(ctx, payment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentMethod_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type MockPaymentMethod_Resolve_Call[D interface{}] struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payment *Payment[D]
+func (_e *MockPaymentMethod_Expecter[D]) Resolve(ctx interface{}, payment interface{}) *MockPaymentMethod_Resolve_Call[D] {
+	return &MockPaymentMethod_Resolve_Call[D]{Call: _e.mock.On("Resolve", ctx, payment)}
+}
+
+func (_c *MockPaymentMethod_Resolve_Call[D]) Run(run func(ctx context.Context, payment *Payment[D])) *MockPaymentMethod_Resolve_Call[D] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Payment[D]))
+	})
+	return _c
+}
+
+func (_c *MockPaymentMethod_Resolve_Call[D]) Return(_a0 *OverbookingResult, _a1 error) *MockPaymentMethod_Resolve_Call[D] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentMethod_Resolve_Call[D]) RunAndReturn(run func(context.Context, *Payment[D]) (*OverbookingResult, error)) *MockPaymentMethod_Resolve_Call[D] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPaymentMethod creates a new instance of MockPaymentMethod. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPaymentMethod[D interface{}](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPaymentMethod[D] {
+	mock := &MockPaymentMethod[D]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
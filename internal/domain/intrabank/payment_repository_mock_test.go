@@ -0,0 +1,727 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package intrabank
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	ctxt "go.bankyaya.org/app/backend/internal/pkg/ctxt"
+
+	time "time"
+)
+
+// MockPaymentRepository is an autogenerated mock type for the PaymentRepository type
+type MockPaymentRepository struct {
+	mock.Mock
+}
+
+type MockPaymentRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPaymentRepository) EXPECT() *MockPaymentRepository_Expecter {
+	return &MockPaymentRepository_Expecter{mock: &_m.Mock}
+}
+
+// AcquireSequence provides a mock function with given fields: ctx, sequenceNumber, idempotencyKey
+func (_m *MockPaymentRepository) AcquireSequence(ctx context.Context, sequenceNumber string, idempotencyKey string) error {
+	ret := _m.Called(ctx, sequenceNumber, idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireSequence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, sequenceNumber, idempotencyKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentRepository_AcquireSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireSequence'
+type MockPaymentRepository_AcquireSequence_Call struct {
+	*mock.Call
+}
+
+// AcquireSequence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+//   - idempotencyKey string
+func (_e *MockPaymentRepository_Expecter) AcquireSequence(ctx interface{}, sequenceNumber interface{}, idempotencyKey interface{}) *MockPaymentRepository_AcquireSequence_Call {
+	return &MockPaymentRepository_AcquireSequence_Call{Call: _e.mock.On("AcquireSequence", ctx, sequenceNumber, idempotencyKey)}
+}
+
+func (_c *MockPaymentRepository_AcquireSequence_Call) Run(run func(ctx context.Context, sequenceNumber string, idempotencyKey string)) *MockPaymentRepository_AcquireSequence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_AcquireSequence_Call) Return(_a0 error) *MockPaymentRepository_AcquireSequence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentRepository_AcquireSequence_Call) RunAndReturn(run func(context.Context, string, string) error) *MockPaymentRepository_AcquireSequence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteTransaction provides a mock function with given fields: ctx, transaction, outbox
+func (_m *MockPaymentRepository) CompleteTransaction(ctx context.Context, transaction *Transaction, outbox []*OutboxMessage) error {
+	ret := _m.Called(ctx, transaction, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Transaction, []*OutboxMessage) error); ok {
+		r0 = rf(ctx, transaction, outbox)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentRepository_CompleteTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteTransaction'
+type MockPaymentRepository_CompleteTransaction_Call struct {
+	*mock.Call
+}
+
+// CompleteTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *Transaction
+//   - outbox []*OutboxMessage
+func (_e *MockPaymentRepository_Expecter) CompleteTransaction(ctx interface{}, transaction interface{}, outbox interface{}) *MockPaymentRepository_CompleteTransaction_Call {
+	return &MockPaymentRepository_CompleteTransaction_Call{Call: _e.mock.On("CompleteTransaction", ctx, transaction, outbox)}
+}
+
+func (_c *MockPaymentRepository_CompleteTransaction_Call) Run(run func(ctx context.Context, transaction *Transaction, outbox []*OutboxMessage)) *MockPaymentRepository_CompleteTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Transaction), args[2].([]*OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_CompleteTransaction_Call) Return(_a0 error) *MockPaymentRepository_CompleteTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentRepository_CompleteTransaction_Call) RunAndReturn(run func(context.Context, *Transaction, []*OutboxMessage) error) *MockPaymentRepository_CompleteTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailTransaction provides a mock function with given fields: ctx, transaction, outbox
+func (_m *MockPaymentRepository) FailTransaction(ctx context.Context, transaction *Transaction, outbox []*OutboxMessage) error {
+	ret := _m.Called(ctx, transaction, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Transaction, []*OutboxMessage) error); ok {
+		r0 = rf(ctx, transaction, outbox)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentRepository_FailTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailTransaction'
+type MockPaymentRepository_FailTransaction_Call struct {
+	*mock.Call
+}
+
+// FailTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *Transaction
+//   - outbox []*OutboxMessage
+func (_e *MockPaymentRepository_Expecter) FailTransaction(ctx interface{}, transaction interface{}, outbox interface{}) *MockPaymentRepository_FailTransaction_Call {
+	return &MockPaymentRepository_FailTransaction_Call{Call: _e.mock.On("FailTransaction", ctx, transaction, outbox)}
+}
+
+func (_c *MockPaymentRepository_FailTransaction_Call) Run(run func(ctx context.Context, transaction *Transaction, outbox []*OutboxMessage)) *MockPaymentRepository_FailTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Transaction), args[2].([]*OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_FailTransaction_Call) Return(_a0 error) *MockPaymentRepository_FailTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentRepository_FailTransaction_Call) RunAndReturn(run func(context.Context, *Transaction, []*OutboxMessage) error) *MockPaymentRepository_FailTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFirebaseID provides a mock function with given fields: ctx, userID
+func (_m *MockPaymentRepository) GetFirebaseID(ctx context.Context, userID int) (string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirebaseID")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentRepository_GetFirebaseID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirebaseID'
+type MockPaymentRepository_GetFirebaseID_Call struct {
+	*mock.Call
+}
+
+// GetFirebaseID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *MockPaymentRepository_Expecter) GetFirebaseID(ctx interface{}, userID interface{}) *MockPaymentRepository_GetFirebaseID_Call {
+	return &MockPaymentRepository_GetFirebaseID_Call{Call: _e.mock.On("GetFirebaseID", ctx, userID)}
+}
+
+func (_c *MockPaymentRepository_GetFirebaseID_Call) Run(run func(ctx context.Context, userID int)) *MockPaymentRepository_GetFirebaseID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetFirebaseID_Call) Return(_a0 string, _a1 error) *MockPaymentRepository_GetFirebaseID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetFirebaseID_Call) RunAndReturn(run func(context.Context, int) (string, error)) *MockPaymentRepository_GetFirebaseID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingTransactions provides a mock function with given fields: ctx, before, limit
+func (_m *MockPaymentRepository) GetPendingTransactions(ctx context.Context, before time.Time, limit int) ([]*Transaction, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTransactions")
+	}
+
+	var r0 []*Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*Transaction, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*Transaction); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentRepository_GetPendingTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTransactions'
+type MockPaymentRepository_GetPendingTransactions_Call struct {
+	*mock.Call
+}
+
+// GetPendingTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - limit int
+func (_e *MockPaymentRepository_Expecter) GetPendingTransactions(ctx interface{}, before interface{}, limit interface{}) *MockPaymentRepository_GetPendingTransactions_Call {
+	return &MockPaymentRepository_GetPendingTransactions_Call{Call: _e.mock.On("GetPendingTransactions", ctx, before, limit)}
+}
+
+func (_c *MockPaymentRepository_GetPendingTransactions_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *MockPaymentRepository_GetPendingTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetPendingTransactions_Call) Return(_a0 []*Transaction, _a1 error) *MockPaymentRepository_GetPendingTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetPendingTransactions_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]*Transaction, error)) *MockPaymentRepository_GetPendingTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSequence provides a mock function with given fields: ctx, sequenceNumber
+func (_m *MockPaymentRepository) GetSequence(ctx context.Context, sequenceNumber string) (*Sequence, error) {
+	ret := _m.Called(ctx, sequenceNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequence")
+	}
+
+	var r0 *Sequence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*Sequence, error)); ok {
+		return rf(ctx, sequenceNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *Sequence); ok {
+		r0 = rf(ctx, sequenceNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Sequence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sequenceNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentRepository_GetSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSequence'
+type MockPaymentRepository_GetSequence_Call struct {
+	*mock.Call
+}
+
+// GetSequence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+func (_e *MockPaymentRepository_Expecter) GetSequence(ctx interface{}, sequenceNumber interface{}) *MockPaymentRepository_GetSequence_Call {
+	return &MockPaymentRepository_GetSequence_Call{Call: _e.mock.On("GetSequence", ctx, sequenceNumber)}
+}
+
+func (_c *MockPaymentRepository_GetSequence_Call) Run(run func(ctx context.Context, sequenceNumber string)) *MockPaymentRepository_GetSequence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetSequence_Call) Return(_a0 *Sequence, _a1 error) *MockPaymentRepository_GetSequence_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetSequence_Call) RunAndReturn(run func(context.Context, string) (*Sequence, error)) *MockPaymentRepository_GetSequence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSequenceByIdempotencyKey provides a mock function with given fields: ctx, userID, idempotencyKey
+func (_m *MockPaymentRepository) GetSequenceByIdempotencyKey(ctx context.Context, userID int, idempotencyKey string) (*Sequence, error) {
+	ret := _m.Called(ctx, userID, idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequenceByIdempotencyKey")
+	}
+
+	var r0 *Sequence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*Sequence, error)); ok {
+		return rf(ctx, userID, idempotencyKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *Sequence); ok {
+		r0 = rf(ctx, userID, idempotencyKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Sequence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, idempotencyKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentRepository_GetSequenceByIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSequenceByIdempotencyKey'
+type MockPaymentRepository_GetSequenceByIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// GetSequenceByIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - idempotencyKey string
+func (_e *MockPaymentRepository_Expecter) GetSequenceByIdempotencyKey(ctx interface{}, userID interface{}, idempotencyKey interface{}) *MockPaymentRepository_GetSequenceByIdempotencyKey_Call {
+	return &MockPaymentRepository_GetSequenceByIdempotencyKey_Call{Call: _e.mock.On("GetSequenceByIdempotencyKey", ctx, userID, idempotencyKey)}
+}
+
+func (_c *MockPaymentRepository_GetSequenceByIdempotencyKey_Call) Run(run func(ctx context.Context, userID int, idempotencyKey string)) *MockPaymentRepository_GetSequenceByIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetSequenceByIdempotencyKey_Call) Return(_a0 *Sequence, _a1 error) *MockPaymentRepository_GetSequenceByIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetSequenceByIdempotencyKey_Call) RunAndReturn(run func(context.Context, int, string) (*Sequence, error)) *MockPaymentRepository_GetSequenceByIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionBySequenceNumber provides a mock function with given fields: ctx, sequenceNumber
+func (_m *MockPaymentRepository) GetTransactionBySequenceNumber(ctx context.Context, sequenceNumber string) (*Transaction, error) {
+	ret := _m.Called(ctx, sequenceNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionBySequenceNumber")
+	}
+
+	var r0 *Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*Transaction, error)); ok {
+		return rf(ctx, sequenceNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *Transaction); ok {
+		r0 = rf(ctx, sequenceNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sequenceNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentRepository_GetTransactionBySequenceNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionBySequenceNumber'
+type MockPaymentRepository_GetTransactionBySequenceNumber_Call struct {
+	*mock.Call
+}
+
+// GetTransactionBySequenceNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+func (_e *MockPaymentRepository_Expecter) GetTransactionBySequenceNumber(ctx interface{}, sequenceNumber interface{}) *MockPaymentRepository_GetTransactionBySequenceNumber_Call {
+	return &MockPaymentRepository_GetTransactionBySequenceNumber_Call{Call: _e.mock.On("GetTransactionBySequenceNumber", ctx, sequenceNumber)}
+}
+
+func (_c *MockPaymentRepository_GetTransactionBySequenceNumber_Call) Run(run func(ctx context.Context, sequenceNumber string)) *MockPaymentRepository_GetTransactionBySequenceNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetTransactionBySequenceNumber_Call) Return(_a0 *Transaction, _a1 error) *MockPaymentRepository_GetTransactionBySequenceNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetTransactionBySequenceNumber_Call) RunAndReturn(run func(context.Context, string) (*Transaction, error)) *MockPaymentRepository_GetTransactionBySequenceNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *MockPaymentRepository) GetUser(ctx context.Context, userID int) (*ctxt.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *ctxt.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*ctxt.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *ctxt.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ctxt.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentRepository_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockPaymentRepository_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *MockPaymentRepository_Expecter) GetUser(ctx interface{}, userID interface{}) *MockPaymentRepository_GetUser_Call {
+	return &MockPaymentRepository_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+}
+
+func (_c *MockPaymentRepository_GetUser_Call) Run(run func(ctx context.Context, userID int)) *MockPaymentRepository_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetUser_Call) Return(_a0 *ctxt.User, _a1 error) *MockPaymentRepository_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentRepository_GetUser_Call) RunAndReturn(run func(context.Context, int) (*ctxt.User, error)) *MockPaymentRepository_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertRecovery provides a mock function with given fields: ctx, recovery, outbox
+func (_m *MockPaymentRepository) InsertRecovery(ctx context.Context, recovery *Recovery, outbox []*OutboxMessage) error {
+	ret := _m.Called(ctx, recovery, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertRecovery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Recovery, []*OutboxMessage) error); ok {
+		r0 = rf(ctx, recovery, outbox)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentRepository_InsertRecovery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertRecovery'
+type MockPaymentRepository_InsertRecovery_Call struct {
+	*mock.Call
+}
+
+// InsertRecovery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recovery *Recovery
+//   - outbox []*OutboxMessage
+func (_e *MockPaymentRepository_Expecter) InsertRecovery(ctx interface{}, recovery interface{}, outbox interface{}) *MockPaymentRepository_InsertRecovery_Call {
+	return &MockPaymentRepository_InsertRecovery_Call{Call: _e.mock.On("InsertRecovery", ctx, recovery, outbox)}
+}
+
+func (_c *MockPaymentRepository_InsertRecovery_Call) Run(run func(ctx context.Context, recovery *Recovery, outbox []*OutboxMessage)) *MockPaymentRepository_InsertRecovery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Recovery), args[2].([]*OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_InsertRecovery_Call) Return(_a0 error) *MockPaymentRepository_InsertRecovery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentRepository_InsertRecovery_Call) RunAndReturn(run func(context.Context, *Recovery, []*OutboxMessage) error) *MockPaymentRepository_InsertRecovery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertTransaction provides a mock function with given fields: ctx, transaction
+func (_m *MockPaymentRepository) InsertTransaction(ctx context.Context, transaction *Transaction) error {
+	ret := _m.Called(ctx, transaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Transaction) error); ok {
+		r0 = rf(ctx, transaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentRepository_InsertTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertTransaction'
+type MockPaymentRepository_InsertTransaction_Call struct {
+	*mock.Call
+}
+
+// InsertTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *Transaction
+func (_e *MockPaymentRepository_Expecter) InsertTransaction(ctx interface{}, transaction interface{}) *MockPaymentRepository_InsertTransaction_Call {
+	return &MockPaymentRepository_InsertTransaction_Call{Call: _e.mock.On("InsertTransaction", ctx, transaction)}
+}
+
+func (_c *MockPaymentRepository_InsertTransaction_Call) Run(run func(ctx context.Context, transaction *Transaction)) *MockPaymentRepository_InsertTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Transaction))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_InsertTransaction_Call) Return(_a0 error) *MockPaymentRepository_InsertTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentRepository_InsertTransaction_Call) RunAndReturn(run func(context.Context, *Transaction) error) *MockPaymentRepository_InsertTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordPosting provides a mock function with given fields: ctx, transaction
+func (_m *MockPaymentRepository) RecordPosting(ctx context.Context, transaction *Transaction) error {
+	ret := _m.Called(ctx, transaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPosting")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Transaction) error); ok {
+		r0 = rf(ctx, transaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentRepository_RecordPosting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPosting'
+type MockPaymentRepository_RecordPosting_Call struct {
+	*mock.Call
+}
+
+// RecordPosting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *Transaction
+func (_e *MockPaymentRepository_Expecter) RecordPosting(ctx interface{}, transaction interface{}) *MockPaymentRepository_RecordPosting_Call {
+	return &MockPaymentRepository_RecordPosting_Call{Call: _e.mock.On("RecordPosting", ctx, transaction)}
+}
+
+func (_c *MockPaymentRepository_RecordPosting_Call) Run(run func(ctx context.Context, transaction *Transaction)) *MockPaymentRepository_RecordPosting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Transaction))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_RecordPosting_Call) Return(_a0 error) *MockPaymentRepository_RecordPosting_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentRepository_RecordPosting_Call) RunAndReturn(run func(context.Context, *Transaction) error) *MockPaymentRepository_RecordPosting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSequenceStatus provides a mock function with given fields: ctx, sequenceNumber, status
+func (_m *MockPaymentRepository) UpdateSequenceStatus(ctx context.Context, sequenceNumber string, status string) error {
+	ret := _m.Called(ctx, sequenceNumber, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSequenceStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, sequenceNumber, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentRepository_UpdateSequenceStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSequenceStatus'
+type MockPaymentRepository_UpdateSequenceStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateSequenceStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+//   - status string
+func (_e *MockPaymentRepository_Expecter) UpdateSequenceStatus(ctx interface{}, sequenceNumber interface{}, status interface{}) *MockPaymentRepository_UpdateSequenceStatus_Call {
+	return &MockPaymentRepository_UpdateSequenceStatus_Call{Call: _e.mock.On("UpdateSequenceStatus", ctx, sequenceNumber, status)}
+}
+
+func (_c *MockPaymentRepository_UpdateSequenceStatus_Call) Run(run func(ctx context.Context, sequenceNumber string, status string)) *MockPaymentRepository_UpdateSequenceStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRepository_UpdateSequenceStatus_Call) Return(_a0 error) *MockPaymentRepository_UpdateSequenceStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentRepository_UpdateSequenceStatus_Call) RunAndReturn(run func(context.Context, string, string) error) *MockPaymentRepository_UpdateSequenceStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPaymentRepository creates a new instance of MockPaymentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPaymentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPaymentRepository {
+	mock := &MockPaymentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
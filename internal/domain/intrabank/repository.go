@@ -1,9 +1,16 @@
 package intrabank
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+)
 
 // Repository defines methods for managing transfer sequence persistence.
 type Repository interface {
+	PaymentRepository
+
 	// GetTransactionLimit retrieves the transaction limit for the current day.
 	// Requires a context as an input parameter.
 	// Returns a Limits object and an error if retrieval fails.
@@ -14,13 +21,120 @@ type Repository interface {
 	// Returns an error if the operation fails.
 	InsertSequence(ctx context.Context, seq *Sequence) error
 
+	// DeleteExpiredSequences deletes the unpaid sequences that expired before the given time.
+	// Returns the number of deleted sequences and an error if the operation fails.
+	DeleteExpiredSequences(ctx context.Context, before time.Time) (int64, error)
+
+	// GetBeneficiaryAccount retrieves the account number of the user's saved beneficiary.
+	// Returns ErrBeneficiaryNotFound if the user has no beneficiary with the ID.
+	GetBeneficiaryAccount(ctx context.Context, userID int, id int64) (string, error)
+
+	// HasTransferredTo checks if the user has a successful transfer to the destination account.
+	// Returns an error if the operation fails.
+	HasTransferredTo(ctx context.Context, userID string, destination string) (bool, error)
+
+	// CountTransfers counts the user's successful and pending transfers
+	// created within the [from, to) time range.
+	// Returns the number of transfers and an error if the operation fails.
+	CountTransfers(ctx context.Context, userID string, from, to time.Time) (int, error)
+
+	// SumTransferAmount sums the amount of the user's successful and pending transfers
+	// created within the [from, to) time range.
+	// Returns the total amount and an error if the operation fails.
+	SumTransferAmount(ctx context.Context, userID string, from, to time.Time) (Money, error)
+
+	// GetTransactions retrieves the transactions matching the filter,
+	// ordered from the newest to the oldest and limited to filter.Limit rows.
+	// Returns the transactions and an error if retrieval fails.
+	GetTransactions(ctx context.Context, filter *TransactionFilter) ([]*Transaction, error)
+
+	// GetTransactionByReference retrieves the user's transaction with the core banking transaction reference.
+	// Requires a context, the user ID and the transaction reference as inputs.
+	// Returns ErrTransactionNotFound if the user has no transaction with the reference.
+	GetTransactionByReference(ctx context.Context, userID, transactionReference string) (*Transaction, error)
+
+	// GetOpenRecoveries retrieves the oldest open recovery entries, limited to limit rows.
+	// Returns the entries and an error if retrieval fails.
+	GetOpenRecoveries(ctx context.Context, limit int) ([]*Recovery, error)
+
+	// UpdateRecovery stores the status, attempts and resolution of the recovery entry.
+	// Returns an error if the operation fails.
+	UpdateRecovery(ctx context.Context, recovery *Recovery) error
+
+	// AcquireOutbox retrieves the pending outbox messages due at now, limited to limit rows,
+	// and postpones their next attempt to leaseUntil, so concurrent dispatchers do not deliver them twice.
+	// Returns the messages and an error if the operation fails.
+	AcquireOutbox(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*OutboxMessage, error)
+
+	// UpdateOutbox stores the delivery status and attempts of the outbox message.
+	// Returns an error if the operation fails.
+	UpdateOutbox(ctx context.Context, message *OutboxMessage) error
+}
+
+// PaymentRepository defines the persistence methods shared by the payments of a sequence,
+// the repositories of the other payment methods embed it.
+type PaymentRepository interface {
 	// GetSequence retrieves a transfer sequence based on the sequence number.
 	// Requires a context and the sequence number as inputs.
 	// Returns a Sequence object and an error if retrieval fails.
 	GetSequence(ctx context.Context, sequenceNumber string) (*Sequence, error)
 
+	// GetSequenceByIdempotencyKey retrieves the transfer sequence the user paid with the idempotency key.
+	// Requires a context, the user ID and the idempotency key as inputs, the keys are unique per user.
+	// Returns ErrSequenceNotFound if the user has never used the key.
+	GetSequenceByIdempotencyKey(ctx context.Context, userID int, idempotencyKey string) (*Sequence, error)
+
+	// AcquireSequence atomically moves a payable sequence to the pending status
+	// and stores the optional idempotency key, so only one payment can run for the sequence.
+	// Returns ErrSequenceAlreadyProcessed if the sequence is no longer payable
+	// or the idempotency key belongs to another sequence of the user.
+	AcquireSequence(ctx context.Context, sequenceNumber, idempotencyKey string) error
+
+	// UpdateSequenceStatus updates the status of a transfer sequence.
+	// Requires a context, the sequence number and the new status.
+	// Returns an error if the operation fails.
+	UpdateSequenceStatus(ctx context.Context, sequenceNumber, status string) error
+
 	// InsertTransaction inserts a transaction into the persistence repository.
 	// Requires a context and a Transaction object as input parameters.
 	// Returns an error if the operation fails.
 	InsertTransaction(ctx context.Context, transaction *Transaction) error
+
+	// RecordPosting stores the journal and the reference of the core posting of the pending transaction,
+	// before the payment is delivered to a partner, so a pending payment is settled knowing that the money has moved.
+	// Returns an error if the operation fails.
+	RecordPosting(ctx context.Context, transaction *Transaction) error
+
+	// CompleteTransaction stores the result of a successful transaction,
+	// marks its sequence as completed and inserts the outbox messages in the same database transaction.
+	// Returns ErrTransactionFinished if the transaction is no longer pending.
+	CompleteTransaction(ctx context.Context, transaction *Transaction, outbox []*OutboxMessage) error
+
+	// FailTransaction stores the result of a failed transaction, including the core status code and response payload,
+	// marks its sequence as failed and inserts the outbox messages in the same database transaction.
+	// Returns ErrTransactionFinished if the transaction is no longer pending.
+	FailTransaction(ctx context.Context, transaction *Transaction, outbox []*OutboxMessage) error
+
+	// GetTransactionBySequenceNumber retrieves the transaction created by paying the sequence.
+	// Requires a context and the sequence number as inputs.
+	// Returns a Transaction object and an error if retrieval fails.
+	GetTransactionBySequenceNumber(ctx context.Context, sequenceNumber string) (*Transaction, error)
+
+	// GetPendingTransactions retrieves the oldest payments of the payment method that are still pending
+	// and were created before the given time, limited to limit rows.
+	// Returns the transactions and an error if retrieval fails.
+	GetPendingTransactions(ctx context.Context, before time.Time, limit int) ([]*Transaction, error)
+
+	// InsertRecovery stores the recovery entry of a completed core posting
+	// and inserts the outbox messages in the same database transaction.
+	// Returns an error if the operation fails.
+	InsertRecovery(ctx context.Context, recovery *Recovery, outbox []*OutboxMessage) error
+
+	// GetFirebaseID retrieves the Firebase ID of the user's registered device.
+	// Returns an error if retrieval fails.
+	GetFirebaseID(ctx context.Context, userID int) (string, error)
+
+	// GetUser retrieves the user with the ID, for the receipts of the transactions settled outside a request.
+	// Returns an error if retrieval fails.
+	GetUser(ctx context.Context, userID int) (*ctxt.User, error)
 }
@@ -6,44 +6,957 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+	ctxt "go.bankyaya.org/app/backend/internal/pkg/ctxt"
+
+	time "time"
 )
 
-// MockRepository is an autogenerated mock type for the Repository type
-type MockRepository struct {
-	mock.Mock
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// AcquireOutbox provides a mock function with given fields: ctx, now, leaseUntil, limit
+func (_m *MockRepository) AcquireOutbox(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*OutboxMessage, error) {
+	ret := _m.Called(ctx, now, leaseUntil, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireOutbox")
+	}
+
+	var r0 []*OutboxMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]*OutboxMessage, error)); ok {
+		return rf(ctx, now, leaseUntil, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []*OutboxMessage); ok {
+		r0 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*OutboxMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_AcquireOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireOutbox'
+type MockRepository_AcquireOutbox_Call struct {
+	*mock.Call
+}
+
+// AcquireOutbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - leaseUntil time.Time
+//   - limit int
+func (_e *MockRepository_Expecter) AcquireOutbox(ctx interface{}, now interface{}, leaseUntil interface{}, limit interface{}) *MockRepository_AcquireOutbox_Call {
+	return &MockRepository_AcquireOutbox_Call{Call: _e.mock.On("AcquireOutbox", ctx, now, leaseUntil, limit)}
+}
+
+func (_c *MockRepository_AcquireOutbox_Call) Run(run func(ctx context.Context, now time.Time, leaseUntil time.Time, limit int)) *MockRepository_AcquireOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_AcquireOutbox_Call) Return(_a0 []*OutboxMessage, _a1 error) *MockRepository_AcquireOutbox_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_AcquireOutbox_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, int) ([]*OutboxMessage, error)) *MockRepository_AcquireOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AcquireSequence provides a mock function with given fields: ctx, sequenceNumber, idempotencyKey
+func (_m *MockRepository) AcquireSequence(ctx context.Context, sequenceNumber string, idempotencyKey string) error {
+	ret := _m.Called(ctx, sequenceNumber, idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireSequence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, sequenceNumber, idempotencyKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_AcquireSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireSequence'
+type MockRepository_AcquireSequence_Call struct {
+	*mock.Call
+}
+
+// AcquireSequence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+//   - idempotencyKey string
+func (_e *MockRepository_Expecter) AcquireSequence(ctx interface{}, sequenceNumber interface{}, idempotencyKey interface{}) *MockRepository_AcquireSequence_Call {
+	return &MockRepository_AcquireSequence_Call{Call: _e.mock.On("AcquireSequence", ctx, sequenceNumber, idempotencyKey)}
+}
+
+func (_c *MockRepository_AcquireSequence_Call) Run(run func(ctx context.Context, sequenceNumber string, idempotencyKey string)) *MockRepository_AcquireSequence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_AcquireSequence_Call) Return(_a0 error) *MockRepository_AcquireSequence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_AcquireSequence_Call) RunAndReturn(run func(context.Context, string, string) error) *MockRepository_AcquireSequence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteTransaction provides a mock function with given fields: ctx, transaction, outbox
+func (_m *MockRepository) CompleteTransaction(ctx context.Context, transaction *Transaction, outbox []*OutboxMessage) error {
+	ret := _m.Called(ctx, transaction, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Transaction, []*OutboxMessage) error); ok {
+		r0 = rf(ctx, transaction, outbox)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_CompleteTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteTransaction'
+type MockRepository_CompleteTransaction_Call struct {
+	*mock.Call
+}
+
+// CompleteTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *Transaction
+//   - outbox []*OutboxMessage
+func (_e *MockRepository_Expecter) CompleteTransaction(ctx interface{}, transaction interface{}, outbox interface{}) *MockRepository_CompleteTransaction_Call {
+	return &MockRepository_CompleteTransaction_Call{Call: _e.mock.On("CompleteTransaction", ctx, transaction, outbox)}
+}
+
+func (_c *MockRepository_CompleteTransaction_Call) Run(run func(ctx context.Context, transaction *Transaction, outbox []*OutboxMessage)) *MockRepository_CompleteTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Transaction), args[2].([]*OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CompleteTransaction_Call) Return(_a0 error) *MockRepository_CompleteTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_CompleteTransaction_Call) RunAndReturn(run func(context.Context, *Transaction, []*OutboxMessage) error) *MockRepository_CompleteTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountTransfers provides a mock function with given fields: ctx, userID, from, to
+func (_m *MockRepository) CountTransfers(ctx context.Context, userID string, from time.Time, to time.Time) (int, error) {
+	ret := _m.Called(ctx, userID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountTransfers")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (int, error)); ok {
+		return rf(ctx, userID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) int); ok {
+		r0 = rf(ctx, userID, from, to)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_CountTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountTransfers'
+type MockRepository_CountTransfers_Call struct {
+	*mock.Call
+}
+
+// CountTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockRepository_Expecter) CountTransfers(ctx interface{}, userID interface{}, from interface{}, to interface{}) *MockRepository_CountTransfers_Call {
+	return &MockRepository_CountTransfers_Call{Call: _e.mock.On("CountTransfers", ctx, userID, from, to)}
+}
+
+func (_c *MockRepository_CountTransfers_Call) Run(run func(ctx context.Context, userID string, from time.Time, to time.Time)) *MockRepository_CountTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_CountTransfers_Call) Return(_a0 int, _a1 error) *MockRepository_CountTransfers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_CountTransfers_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) (int, error)) *MockRepository_CountTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteExpiredSequences provides a mock function with given fields: ctx, before
+func (_m *MockRepository) DeleteExpiredSequences(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredSequences")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_DeleteExpiredSequences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredSequences'
+type MockRepository_DeleteExpiredSequences_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredSequences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockRepository_Expecter) DeleteExpiredSequences(ctx interface{}, before interface{}) *MockRepository_DeleteExpiredSequences_Call {
+	return &MockRepository_DeleteExpiredSequences_Call{Call: _e.mock.On("DeleteExpiredSequences", ctx, before)}
+}
+
+func (_c *MockRepository_DeleteExpiredSequences_Call) Run(run func(ctx context.Context, before time.Time)) *MockRepository_DeleteExpiredSequences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteExpiredSequences_Call) Return(_a0 int64, _a1 error) *MockRepository_DeleteExpiredSequences_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_DeleteExpiredSequences_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockRepository_DeleteExpiredSequences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailTransaction provides a mock function with given fields: ctx, transaction, outbox
+func (_m *MockRepository) FailTransaction(ctx context.Context, transaction *Transaction, outbox []*OutboxMessage) error {
+	ret := _m.Called(ctx, transaction, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Transaction, []*OutboxMessage) error); ok {
+		r0 = rf(ctx, transaction, outbox)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_FailTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailTransaction'
+type MockRepository_FailTransaction_Call struct {
+	*mock.Call
+}
+
+// FailTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *Transaction
+//   - outbox []*OutboxMessage
+func (_e *MockRepository_Expecter) FailTransaction(ctx interface{}, transaction interface{}, outbox interface{}) *MockRepository_FailTransaction_Call {
+	return &MockRepository_FailTransaction_Call{Call: _e.mock.On("FailTransaction", ctx, transaction, outbox)}
+}
+
+func (_c *MockRepository_FailTransaction_Call) Run(run func(ctx context.Context, transaction *Transaction, outbox []*OutboxMessage)) *MockRepository_FailTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Transaction), args[2].([]*OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *MockRepository_FailTransaction_Call) Return(_a0 error) *MockRepository_FailTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_FailTransaction_Call) RunAndReturn(run func(context.Context, *Transaction, []*OutboxMessage) error) *MockRepository_FailTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBeneficiaryAccount provides a mock function with given fields: ctx, userID, id
+func (_m *MockRepository) GetBeneficiaryAccount(ctx context.Context, userID int, id int64) (string, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBeneficiaryAccount")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) (string, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) string); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetBeneficiaryAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBeneficiaryAccount'
+type MockRepository_GetBeneficiaryAccount_Call struct {
+	*mock.Call
+}
+
+// GetBeneficiaryAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int64
+func (_e *MockRepository_Expecter) GetBeneficiaryAccount(ctx interface{}, userID interface{}, id interface{}) *MockRepository_GetBeneficiaryAccount_Call {
+	return &MockRepository_GetBeneficiaryAccount_Call{Call: _e.mock.On("GetBeneficiaryAccount", ctx, userID, id)}
+}
+
+func (_c *MockRepository_GetBeneficiaryAccount_Call) Run(run func(ctx context.Context, userID int, id int64)) *MockRepository_GetBeneficiaryAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetBeneficiaryAccount_Call) Return(_a0 string, _a1 error) *MockRepository_GetBeneficiaryAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetBeneficiaryAccount_Call) RunAndReturn(run func(context.Context, int, int64) (string, error)) *MockRepository_GetBeneficiaryAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFirebaseID provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) GetFirebaseID(ctx context.Context, userID int) (string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirebaseID")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetFirebaseID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFirebaseID'
+type MockRepository_GetFirebaseID_Call struct {
+	*mock.Call
+}
+
+// GetFirebaseID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *MockRepository_Expecter) GetFirebaseID(ctx interface{}, userID interface{}) *MockRepository_GetFirebaseID_Call {
+	return &MockRepository_GetFirebaseID_Call{Call: _e.mock.On("GetFirebaseID", ctx, userID)}
+}
+
+func (_c *MockRepository_GetFirebaseID_Call) Run(run func(ctx context.Context, userID int)) *MockRepository_GetFirebaseID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetFirebaseID_Call) Return(_a0 string, _a1 error) *MockRepository_GetFirebaseID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetFirebaseID_Call) RunAndReturn(run func(context.Context, int) (string, error)) *MockRepository_GetFirebaseID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenRecoveries provides a mock function with given fields: ctx, limit
+func (_m *MockRepository) GetOpenRecoveries(ctx context.Context, limit int) ([]*Recovery, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenRecoveries")
+	}
+
+	var r0 []*Recovery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*Recovery, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*Recovery); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Recovery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetOpenRecoveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenRecoveries'
+type MockRepository_GetOpenRecoveries_Call struct {
+	*mock.Call
+}
+
+// GetOpenRecoveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockRepository_Expecter) GetOpenRecoveries(ctx interface{}, limit interface{}) *MockRepository_GetOpenRecoveries_Call {
+	return &MockRepository_GetOpenRecoveries_Call{Call: _e.mock.On("GetOpenRecoveries", ctx, limit)}
+}
+
+func (_c *MockRepository_GetOpenRecoveries_Call) Run(run func(ctx context.Context, limit int)) *MockRepository_GetOpenRecoveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetOpenRecoveries_Call) Return(_a0 []*Recovery, _a1 error) *MockRepository_GetOpenRecoveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetOpenRecoveries_Call) RunAndReturn(run func(context.Context, int) ([]*Recovery, error)) *MockRepository_GetOpenRecoveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingTransactions provides a mock function with given fields: ctx, before, limit
+func (_m *MockRepository) GetPendingTransactions(ctx context.Context, before time.Time, limit int) ([]*Transaction, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTransactions")
+	}
+
+	var r0 []*Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*Transaction, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*Transaction); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetPendingTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingTransactions'
+type MockRepository_GetPendingTransactions_Call struct {
+	*mock.Call
+}
+
+// GetPendingTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - limit int
+func (_e *MockRepository_Expecter) GetPendingTransactions(ctx interface{}, before interface{}, limit interface{}) *MockRepository_GetPendingTransactions_Call {
+	return &MockRepository_GetPendingTransactions_Call{Call: _e.mock.On("GetPendingTransactions", ctx, before, limit)}
+}
+
+func (_c *MockRepository_GetPendingTransactions_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *MockRepository_GetPendingTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetPendingTransactions_Call) Return(_a0 []*Transaction, _a1 error) *MockRepository_GetPendingTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetPendingTransactions_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]*Transaction, error)) *MockRepository_GetPendingTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSequence provides a mock function with given fields: ctx, sequenceNumber
+func (_m *MockRepository) GetSequence(ctx context.Context, sequenceNumber string) (*Sequence, error) {
+	ret := _m.Called(ctx, sequenceNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequence")
+	}
+
+	var r0 *Sequence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*Sequence, error)); ok {
+		return rf(ctx, sequenceNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *Sequence); ok {
+		r0 = rf(ctx, sequenceNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Sequence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sequenceNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSequence'
+type MockRepository_GetSequence_Call struct {
+	*mock.Call
+}
+
+// GetSequence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+func (_e *MockRepository_Expecter) GetSequence(ctx interface{}, sequenceNumber interface{}) *MockRepository_GetSequence_Call {
+	return &MockRepository_GetSequence_Call{Call: _e.mock.On("GetSequence", ctx, sequenceNumber)}
+}
+
+func (_c *MockRepository_GetSequence_Call) Run(run func(ctx context.Context, sequenceNumber string)) *MockRepository_GetSequence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetSequence_Call) Return(_a0 *Sequence, _a1 error) *MockRepository_GetSequence_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetSequence_Call) RunAndReturn(run func(context.Context, string) (*Sequence, error)) *MockRepository_GetSequence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSequenceByIdempotencyKey provides a mock function with given fields: ctx, userID, idempotencyKey
+func (_m *MockRepository) GetSequenceByIdempotencyKey(ctx context.Context, userID int, idempotencyKey string) (*Sequence, error) {
+	ret := _m.Called(ctx, userID, idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequenceByIdempotencyKey")
+	}
+
+	var r0 *Sequence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*Sequence, error)); ok {
+		return rf(ctx, userID, idempotencyKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *Sequence); ok {
+		r0 = rf(ctx, userID, idempotencyKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Sequence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, idempotencyKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetSequenceByIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSequenceByIdempotencyKey'
+type MockRepository_GetSequenceByIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// GetSequenceByIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - idempotencyKey string
+func (_e *MockRepository_Expecter) GetSequenceByIdempotencyKey(ctx interface{}, userID interface{}, idempotencyKey interface{}) *MockRepository_GetSequenceByIdempotencyKey_Call {
+	return &MockRepository_GetSequenceByIdempotencyKey_Call{Call: _e.mock.On("GetSequenceByIdempotencyKey", ctx, userID, idempotencyKey)}
+}
+
+func (_c *MockRepository_GetSequenceByIdempotencyKey_Call) Run(run func(ctx context.Context, userID int, idempotencyKey string)) *MockRepository_GetSequenceByIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetSequenceByIdempotencyKey_Call) Return(_a0 *Sequence, _a1 error) *MockRepository_GetSequenceByIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetSequenceByIdempotencyKey_Call) RunAndReturn(run func(context.Context, int, string) (*Sequence, error)) *MockRepository_GetSequenceByIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionByReference provides a mock function with given fields: ctx, userID, transactionReference
+func (_m *MockRepository) GetTransactionByReference(ctx context.Context, userID string, transactionReference string) (*Transaction, error) {
+	ret := _m.Called(ctx, userID, transactionReference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionByReference")
+	}
+
+	var r0 *Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*Transaction, error)); ok {
+		return rf(ctx, userID, transactionReference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *Transaction); ok {
+		r0 = rf(ctx, userID, transactionReference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, transactionReference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetTransactionByReference_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionByReference'
+type MockRepository_GetTransactionByReference_Call struct {
+	*mock.Call
+}
+
+// GetTransactionByReference is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - transactionReference string
+func (_e *MockRepository_Expecter) GetTransactionByReference(ctx interface{}, userID interface{}, transactionReference interface{}) *MockRepository_GetTransactionByReference_Call {
+	return &MockRepository_GetTransactionByReference_Call{Call: _e.mock.On("GetTransactionByReference", ctx, userID, transactionReference)}
+}
+
+func (_c *MockRepository_GetTransactionByReference_Call) Run(run func(ctx context.Context, userID string, transactionReference string)) *MockRepository_GetTransactionByReference_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetTransactionByReference_Call) Return(_a0 *Transaction, _a1 error) *MockRepository_GetTransactionByReference_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetTransactionByReference_Call) RunAndReturn(run func(context.Context, string, string) (*Transaction, error)) *MockRepository_GetTransactionByReference_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionBySequenceNumber provides a mock function with given fields: ctx, sequenceNumber
+func (_m *MockRepository) GetTransactionBySequenceNumber(ctx context.Context, sequenceNumber string) (*Transaction, error) {
+	ret := _m.Called(ctx, sequenceNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionBySequenceNumber")
+	}
+
+	var r0 *Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*Transaction, error)); ok {
+		return rf(ctx, sequenceNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *Transaction); ok {
+		r0 = rf(ctx, sequenceNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sequenceNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetTransactionBySequenceNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionBySequenceNumber'
+type MockRepository_GetTransactionBySequenceNumber_Call struct {
+	*mock.Call
+}
+
+// GetTransactionBySequenceNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+func (_e *MockRepository_Expecter) GetTransactionBySequenceNumber(ctx interface{}, sequenceNumber interface{}) *MockRepository_GetTransactionBySequenceNumber_Call {
+	return &MockRepository_GetTransactionBySequenceNumber_Call{Call: _e.mock.On("GetTransactionBySequenceNumber", ctx, sequenceNumber)}
+}
+
+func (_c *MockRepository_GetTransactionBySequenceNumber_Call) Run(run func(ctx context.Context, sequenceNumber string)) *MockRepository_GetTransactionBySequenceNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetTransactionBySequenceNumber_Call) Return(_a0 *Transaction, _a1 error) *MockRepository_GetTransactionBySequenceNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetTransactionBySequenceNumber_Call) RunAndReturn(run func(context.Context, string) (*Transaction, error)) *MockRepository_GetTransactionBySequenceNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactionLimit provides a mock function with given fields: ctx
+func (_m *MockRepository) GetTransactionLimit(ctx context.Context) (*Limits, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionLimit")
+	}
+
+	var r0 *Limits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*Limits, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *Limits); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Limits)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetTransactionLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionLimit'
+type MockRepository_GetTransactionLimit_Call struct {
+	*mock.Call
+}
+
+// GetTransactionLimit is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) GetTransactionLimit(ctx interface{}) *MockRepository_GetTransactionLimit_Call {
+	return &MockRepository_GetTransactionLimit_Call{Call: _e.mock.On("GetTransactionLimit", ctx)}
+}
+
+func (_c *MockRepository_GetTransactionLimit_Call) Run(run func(ctx context.Context)) *MockRepository_GetTransactionLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetTransactionLimit_Call) Return(_a0 *Limits, _a1 error) *MockRepository_GetTransactionLimit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetTransactionLimit_Call) RunAndReturn(run func(context.Context) (*Limits, error)) *MockRepository_GetTransactionLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransactions provides a mock function with given fields: ctx, filter
+func (_m *MockRepository) GetTransactions(ctx context.Context, filter *TransactionFilter) ([]*Transaction, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactions")
+	}
+
+	var r0 []*Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *TransactionFilter) ([]*Transaction, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *TransactionFilter) []*Transaction); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *TransactionFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactions'
+type MockRepository_GetTransactions_Call struct {
+	*mock.Call
+}
+
+// GetTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter *TransactionFilter
+func (_e *MockRepository_Expecter) GetTransactions(ctx interface{}, filter interface{}) *MockRepository_GetTransactions_Call {
+	return &MockRepository_GetTransactions_Call{Call: _e.mock.On("GetTransactions", ctx, filter)}
+}
+
+func (_c *MockRepository_GetTransactions_Call) Run(run func(ctx context.Context, filter *TransactionFilter)) *MockRepository_GetTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*TransactionFilter))
+	})
+	return _c
 }
 
-type MockRepository_Expecter struct {
-	mock *mock.Mock
+func (_c *MockRepository_GetTransactions_Call) Return(_a0 []*Transaction, _a1 error) *MockRepository_GetTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
 }
 
-func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
-	return &MockRepository_Expecter{mock: &_m.Mock}
+func (_c *MockRepository_GetTransactions_Call) RunAndReturn(run func(context.Context, *TransactionFilter) ([]*Transaction, error)) *MockRepository_GetTransactions_Call {
+	_c.Call.Return(run)
+	return _c
 }
 
-// GetSequence provides a mock function with given fields: ctx, sequenceNumber
-func (_m *MockRepository) GetSequence(ctx context.Context, sequenceNumber string) (*Sequence, error) {
-	ret := _m.Called(ctx, sequenceNumber)
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) GetUser(ctx context.Context, userID int) (*ctxt.User, error) {
+	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSequence")
+		panic("no return value specified for GetUser")
 	}
 
-	var r0 *Sequence
+	var r0 *ctxt.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*Sequence, error)); ok {
-		return rf(ctx, sequenceNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*ctxt.User, error)); ok {
+		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *Sequence); ok {
-		r0 = rf(ctx, sequenceNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *ctxt.User); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*Sequence)
+			r0 = ret.Get(0).(*ctxt.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, sequenceNumber)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,58 +964,56 @@ func (_m *MockRepository) GetSequence(ctx context.Context, sequenceNumber string
 	return r0, r1
 }
 
-// MockRepository_GetSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSequence'
-type MockRepository_GetSequence_Call struct {
+// MockRepository_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockRepository_GetUser_Call struct {
 	*mock.Call
 }
 
-// GetSequence is a helper method to define mock.On call
+// GetUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sequenceNumber string
-func (_e *MockRepository_Expecter) GetSequence(ctx interface{}, sequenceNumber interface{}) *MockRepository_GetSequence_Call {
-	return &MockRepository_GetSequence_Call{Call: _e.mock.On("GetSequence", ctx, sequenceNumber)}
+//   - userID int
+func (_e *MockRepository_Expecter) GetUser(ctx interface{}, userID interface{}) *MockRepository_GetUser_Call {
+	return &MockRepository_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
 }
 
-func (_c *MockRepository_GetSequence_Call) Run(run func(ctx context.Context, sequenceNumber string)) *MockRepository_GetSequence_Call {
+func (_c *MockRepository_GetUser_Call) Run(run func(ctx context.Context, userID int)) *MockRepository_GetUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(int))
 	})
 	return _c
 }
 
-func (_c *MockRepository_GetSequence_Call) Return(_a0 *Sequence, _a1 error) *MockRepository_GetSequence_Call {
+func (_c *MockRepository_GetUser_Call) Return(_a0 *ctxt.User, _a1 error) *MockRepository_GetUser_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepository_GetSequence_Call) RunAndReturn(run func(context.Context, string) (*Sequence, error)) *MockRepository_GetSequence_Call {
+func (_c *MockRepository_GetUser_Call) RunAndReturn(run func(context.Context, int) (*ctxt.User, error)) *MockRepository_GetUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTransactionLimit provides a mock function with given fields: ctx
-func (_m *MockRepository) GetTransactionLimit(ctx context.Context) (*Limits, error) {
-	ret := _m.Called(ctx)
+// HasTransferredTo provides a mock function with given fields: ctx, userID, destination
+func (_m *MockRepository) HasTransferredTo(ctx context.Context, userID string, destination string) (bool, error) {
+	ret := _m.Called(ctx, userID, destination)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTransactionLimit")
+		panic("no return value specified for HasTransferredTo")
 	}
 
-	var r0 *Limits
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*Limits, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userID, destination)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *Limits); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, destination)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*Limits)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, destination)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,30 +1021,80 @@ func (_m *MockRepository) GetTransactionLimit(ctx context.Context) (*Limits, err
 	return r0, r1
 }
 
-// MockRepository_GetTransactionLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransactionLimit'
-type MockRepository_GetTransactionLimit_Call struct {
+// MockRepository_HasTransferredTo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasTransferredTo'
+type MockRepository_HasTransferredTo_Call struct {
 	*mock.Call
 }
 
-// GetTransactionLimit is a helper method to define mock.On call
+// HasTransferredTo is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockRepository_Expecter) GetTransactionLimit(ctx interface{}) *MockRepository_GetTransactionLimit_Call {
-	return &MockRepository_GetTransactionLimit_Call{Call: _e.mock.On("GetTransactionLimit", ctx)}
+//   - userID string
+//   - destination string
+func (_e *MockRepository_Expecter) HasTransferredTo(ctx interface{}, userID interface{}, destination interface{}) *MockRepository_HasTransferredTo_Call {
+	return &MockRepository_HasTransferredTo_Call{Call: _e.mock.On("HasTransferredTo", ctx, userID, destination)}
 }
 
-func (_c *MockRepository_GetTransactionLimit_Call) Run(run func(ctx context.Context)) *MockRepository_GetTransactionLimit_Call {
+func (_c *MockRepository_HasTransferredTo_Call) Run(run func(ctx context.Context, userID string, destination string)) *MockRepository_HasTransferredTo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockRepository_GetTransactionLimit_Call) Return(_a0 *Limits, _a1 error) *MockRepository_GetTransactionLimit_Call {
+func (_c *MockRepository_HasTransferredTo_Call) Return(_a0 bool, _a1 error) *MockRepository_HasTransferredTo_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepository_GetTransactionLimit_Call) RunAndReturn(run func(context.Context) (*Limits, error)) *MockRepository_GetTransactionLimit_Call {
+func (_c *MockRepository_HasTransferredTo_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockRepository_HasTransferredTo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertRecovery provides a mock function with given fields: ctx, recovery, outbox
+func (_m *MockRepository) InsertRecovery(ctx context.Context, recovery *Recovery, outbox []*OutboxMessage) error {
+	ret := _m.Called(ctx, recovery, outbox)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertRecovery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Recovery, []*OutboxMessage) error); ok {
+		r0 = rf(ctx, recovery, outbox)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_InsertRecovery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertRecovery'
+type MockRepository_InsertRecovery_Call struct {
+	*mock.Call
+}
+
+// InsertRecovery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recovery *Recovery
+//   - outbox []*OutboxMessage
+func (_e *MockRepository_Expecter) InsertRecovery(ctx interface{}, recovery interface{}, outbox interface{}) *MockRepository_InsertRecovery_Call {
+	return &MockRepository_InsertRecovery_Call{Call: _e.mock.On("InsertRecovery", ctx, recovery, outbox)}
+}
+
+func (_c *MockRepository_InsertRecovery_Call) Run(run func(ctx context.Context, recovery *Recovery, outbox []*OutboxMessage)) *MockRepository_InsertRecovery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Recovery), args[2].([]*OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *MockRepository_InsertRecovery_Call) Return(_a0 error) *MockRepository_InsertRecovery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_InsertRecovery_Call) RunAndReturn(run func(context.Context, *Recovery, []*OutboxMessage) error) *MockRepository_InsertRecovery_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -232,6 +1193,254 @@ func (_c *MockRepository_InsertTransaction_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// RecordPosting provides a mock function with given fields: ctx, transaction
+func (_m *MockRepository) RecordPosting(ctx context.Context, transaction *Transaction) error {
+	ret := _m.Called(ctx, transaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPosting")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Transaction) error); ok {
+		r0 = rf(ctx, transaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_RecordPosting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPosting'
+type MockRepository_RecordPosting_Call struct {
+	*mock.Call
+}
+
+// RecordPosting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transaction *Transaction
+func (_e *MockRepository_Expecter) RecordPosting(ctx interface{}, transaction interface{}) *MockRepository_RecordPosting_Call {
+	return &MockRepository_RecordPosting_Call{Call: _e.mock.On("RecordPosting", ctx, transaction)}
+}
+
+func (_c *MockRepository_RecordPosting_Call) Run(run func(ctx context.Context, transaction *Transaction)) *MockRepository_RecordPosting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Transaction))
+	})
+	return _c
+}
+
+func (_c *MockRepository_RecordPosting_Call) Return(_a0 error) *MockRepository_RecordPosting_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_RecordPosting_Call) RunAndReturn(run func(context.Context, *Transaction) error) *MockRepository_RecordPosting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SumTransferAmount provides a mock function with given fields: ctx, userID, from, to
+func (_m *MockRepository) SumTransferAmount(ctx context.Context, userID string, from time.Time, to time.Time) (Money, error) {
+	ret := _m.Called(ctx, userID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumTransferAmount")
+	}
+
+	var r0 Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) (Money, error)); ok {
+		return rf(ctx, userID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) Money); ok {
+		r0 = rf(ctx, userID, from, to)
+	} else {
+		r0 = ret.Get(0).(Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_SumTransferAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumTransferAmount'
+type MockRepository_SumTransferAmount_Call struct {
+	*mock.Call
+}
+
+// SumTransferAmount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - from time.Time
+//   - to time.Time
+func (_e *MockRepository_Expecter) SumTransferAmount(ctx interface{}, userID interface{}, from interface{}, to interface{}) *MockRepository_SumTransferAmount_Call {
+	return &MockRepository_SumTransferAmount_Call{Call: _e.mock.On("SumTransferAmount", ctx, userID, from, to)}
+}
+
+func (_c *MockRepository_SumTransferAmount_Call) Run(run func(ctx context.Context, userID string, from time.Time, to time.Time)) *MockRepository_SumTransferAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SumTransferAmount_Call) Return(_a0 Money, _a1 error) *MockRepository_SumTransferAmount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_SumTransferAmount_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) (Money, error)) *MockRepository_SumTransferAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOutbox provides a mock function with given fields: ctx, message
+func (_m *MockRepository) UpdateOutbox(ctx context.Context, message *OutboxMessage) error {
+	ret := _m.Called(ctx, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOutbox")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *OutboxMessage) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdateOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOutbox'
+type MockRepository_UpdateOutbox_Call struct {
+	*mock.Call
+}
+
+// UpdateOutbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message *OutboxMessage
+func (_e *MockRepository_Expecter) UpdateOutbox(ctx interface{}, message interface{}) *MockRepository_UpdateOutbox_Call {
+	return &MockRepository_UpdateOutbox_Call{Call: _e.mock.On("UpdateOutbox", ctx, message)}
+}
+
+func (_c *MockRepository_UpdateOutbox_Call) Run(run func(ctx context.Context, message *OutboxMessage)) *MockRepository_UpdateOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateOutbox_Call) Return(_a0 error) *MockRepository_UpdateOutbox_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdateOutbox_Call) RunAndReturn(run func(context.Context, *OutboxMessage) error) *MockRepository_UpdateOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRecovery provides a mock function with given fields: ctx, recovery
+func (_m *MockRepository) UpdateRecovery(ctx context.Context, recovery *Recovery) error {
+	ret := _m.Called(ctx, recovery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRecovery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Recovery) error); ok {
+		r0 = rf(ctx, recovery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdateRecovery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRecovery'
+type MockRepository_UpdateRecovery_Call struct {
+	*mock.Call
+}
+
+// UpdateRecovery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recovery *Recovery
+func (_e *MockRepository_Expecter) UpdateRecovery(ctx interface{}, recovery interface{}) *MockRepository_UpdateRecovery_Call {
+	return &MockRepository_UpdateRecovery_Call{Call: _e.mock.On("UpdateRecovery", ctx, recovery)}
+}
+
+func (_c *MockRepository_UpdateRecovery_Call) Run(run func(ctx context.Context, recovery *Recovery)) *MockRepository_UpdateRecovery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Recovery))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateRecovery_Call) Return(_a0 error) *MockRepository_UpdateRecovery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdateRecovery_Call) RunAndReturn(run func(context.Context, *Recovery) error) *MockRepository_UpdateRecovery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSequenceStatus provides a mock function with given fields: ctx, sequenceNumber, status
+func (_m *MockRepository) UpdateSequenceStatus(ctx context.Context, sequenceNumber string, status string) error {
+	ret := _m.Called(ctx, sequenceNumber, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSequenceStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, sequenceNumber, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_UpdateSequenceStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSequenceStatus'
+type MockRepository_UpdateSequenceStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateSequenceStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sequenceNumber string
+//   - status string
+func (_e *MockRepository_Expecter) UpdateSequenceStatus(ctx interface{}, sequenceNumber interface{}, status interface{}) *MockRepository_UpdateSequenceStatus_Call {
+	return &MockRepository_UpdateSequenceStatus_Call{Call: _e.mock.On("UpdateSequenceStatus", ctx, sequenceNumber, status)}
+}
+
+func (_c *MockRepository_UpdateSequenceStatus_Call) Run(run func(ctx context.Context, sequenceNumber string, status string)) *MockRepository_UpdateSequenceStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateSequenceStatus_Call) Return(_a0 error) *MockRepository_UpdateSequenceStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_UpdateSequenceStatus_Call) RunAndReturn(run func(context.Context, string, string) error) *MockRepository_UpdateSequenceStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRepository(t interface {
@@ -2,7 +2,10 @@ package intrabank
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strconv"
+	"time"
 
 	"go.bankyaya.org/app/backend/internal/pkg/codes"
 	"go.bankyaya.org/app/backend/internal/pkg/constant"
@@ -15,8 +18,11 @@ const (
 	domainName             = "transfer"
 	transferType           = "internal_transfer"
 	transferSuccessSubject = "Transfer Berhasil"
-	transferFee            = 0
-	stringTransferFee      = "0"
+	transferFailedSubject  = "Transfer Gagal"
+	reconcileBatchSize     = 50
+	pendingSettleDelay     = 10 * time.Minute
+	outboxBatchSize        = 100
+	outboxLease            = 5 * time.Minute
 )
 
 // Service handles the intra-bank transfer process.
@@ -27,6 +33,11 @@ type Service struct {
 	seqGen      SequenceGenerator
 	mailer      ReceiptMailer
 	notifier    Notifier
+	validity    SequenceValidity
+	authorizer  TransactionAuthorizer
+	stepUp      StepUpPolicy
+	fees        FeePolicy
+	payer       *Payer[*Limits]
 }
 
 func NewService(
@@ -36,15 +47,25 @@ func NewService(
 	seqGen SequenceGenerator,
 	mailer ReceiptMailer,
 	notifier Notifier,
+	validity SequenceValidity,
+	authorizer TransactionAuthorizer,
+	stepUp StepUpPolicy,
+	fees FeePolicy,
 ) *Service {
-	return &Service{
+	s := &Service{
 		log:         log,
 		corebanking: corebanking,
 		repo:        repo,
 		seqGen:      seqGen,
 		mailer:      mailer,
 		notifier:    notifier,
+		validity:    validity,
+		authorizer:  authorizer,
+		stepUp:      stepUp,
+		fees:        fees,
 	}
+	s.payer = NewPayer[*Limits](log, domainName, repo, corebanking, authorizer, stepUp, &transferMethod{s})
+	return s
 }
 
 func (s *Service) Inquiry(ctx context.Context, seq *Sequence) (*Sequence, error) {
@@ -58,157 +79,575 @@ func (s *Service) Inquiry(ctx context.Context, seq *Sequence) (*Sequence, error)
 		return nil, pkgerror.New(codes.Internal, ErrEODInProgress)
 	}
 
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
 	intrabankLimit, err := s.repo.GetTransactionLimit(ctx)
 	if err != nil {
 		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetTransactionLimit: %v", err)
 		return nil, pkgerror.New(codes.Internal, ErrGeneral)
 	}
-	if !intrabankLimit.CanTransfer(seq.Amount) {
-		s.log.DomainUsecase(domainName, "Inquiry").Error(ErrInvalidAmount)
-		return nil, pkgerror.New(codes.BadRequest, ErrInvalidAmount).
-			SetMsg("Your transfer amount is too high. Please try again with a lower amount.")
+	if err := s.checkAvailability("Inquiry", intrabankLimit, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.resolveBeneficiary(ctx, "Inquiry", user, seq); err != nil {
+		return nil, err
+	}
+	if err := s.validate(ctx, "Inquiry", user, seq, intrabankLimit); err != nil {
+		return nil, err
 	}
 
-	srcAccount, err := s.corebanking.GetAccountDetails(ctx, seq.SourceAccount)
+	sequenceNo, err := s.seqGen.Generate()
 	if err != nil {
-		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetAccountDetails: %v", err)
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("Generate failed: %v", err)
 		return nil, pkgerror.New(codes.Internal, ErrGeneral)
 	}
-	if !srcAccount.IsAccountActive() {
-		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) not active", seq.SourceAccount)
-		return nil, pkgerror.New(codes.BadRequest, ErrSourceAccountInactive)
+
+	now := time.Now()
+	seq.SequenceNumber = sequenceNo
+	seq.Status = SequenceCreated
+	seq.UserID = user.ID
+	seq.DeviceID = user.DeviceID
+	seq.CreatedAt = now
+	seq.ExpiresAt = now.Add(s.validity.For(transferType))
+
+	err = s.repo.InsertSequence(ctx, seq)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("InsertSequence: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
 	}
 
-	seq.SourceName = srcAccount.Name
+	return seq, nil
+}
+
+// Validate checks the transfer the way Inquiry does, without creating a sequence,
+// e.g. to preview the rows of a bulk transfer. The end of day process and the operating hours are not checked,
+// they are checked again when the transfer is inquired and paid.
+// It returns the transfer with its fee and the names of both accounts.
+func (s *Service) Validate(ctx context.Context, seq *Sequence) (*Sequence, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Validate").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
 
-	destAccount, err := s.corebanking.GetAccountDetails(ctx, seq.DestinationAccount)
+	intrabankLimit, err := s.repo.GetTransactionLimit(ctx)
 	if err != nil {
-		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetAccountDetails: %v", err)
+		s.log.DomainUsecase(domainName, "Validate").Errorf("GetTransactionLimit: %v", err)
 		return nil, pkgerror.New(codes.Internal, ErrGeneral)
 	}
-	if !destAccount.IsAccountActive() {
-		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetAccountDetails: %v", err)
-		return nil, pkgerror.New(codes.BadRequest, ErrDestinationAccountInactive)
+	if err := s.validate(ctx, "Validate", user, seq, intrabankLimit); err != nil {
+		return nil, err
 	}
+	return seq, nil
+}
 
-	seq.DestinationName = destAccount.Name
+// resolveBeneficiary sets the destination of a transfer inquired to a saved beneficiary to its account.
+// The lookup is scoped to the user, so only their own beneficiaries can be used.
+func (s *Service) resolveBeneficiary(ctx context.Context, usecase string, user *ctxt.User, seq *Sequence) error {
+	if seq.BeneficiaryID == 0 {
+		return nil
+	}
+	accountNumber, err := s.repo.GetBeneficiaryAccount(ctx, user.ID, seq.BeneficiaryID)
+	if errors.Is(err, ErrBeneficiaryNotFound) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetBeneficiaryAccount: %v", err)
+		return pkgerror.New(codes.NotFound, ErrBeneficiaryNotFound).
+			SetMsg("Beneficiary not found.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetBeneficiaryAccount: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	seq.DestinationAccount = accountNumber
+	return nil
+}
 
-	sequenceNo, err := s.seqGen.Generate()
+// validate checks the amount against the limits, the daily limit of the user, the accounts and the balance
+// of the transfer, and fills its fee and account names.
+func (s *Service) validate(ctx context.Context, usecase string, user *ctxt.User, seq *Sequence, intrabankLimit *Limits) error {
+	if !intrabankLimit.CanTransfer(seq.Amount) {
+		s.log.DomainUsecase(domainName, usecase).Error(ErrInvalidAmount)
+		return pkgerror.New(codes.BadRequest, ErrInvalidAmount).
+			SetMsg("Your transfer amount is too high. Please try again with a lower amount.")
+	}
+
+	from, to := BusinessDay(time.Now())
+	dailyAmount, err := s.repo.SumTransferAmount(ctx, strconv.Itoa(user.ID), from, to)
 	if err != nil {
-		s.log.DomainUsecase(domainName, "Inquiry").Errorf("Generate failed: %v", err)
-		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+		s.log.DomainUsecase(domainName, usecase).Errorf("SumTransferAmount: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !intrabankLimit.WithinDailyLimit(dailyAmount + seq.Amount) {
+		s.log.DomainUsecase(domainName, usecase).Error(ErrDailyLimitExceeded)
+		return pkgerror.New(codes.BadRequest, ErrDailyLimitExceeded).
+			SetMsg("You have reached your daily transfer limit. Please try again tomorrow.")
 	}
 
-	seq.SequenceNumber = sequenceNo
+	srcAccount, err := s.sourceAccount(ctx, usecase, user, seq.SourceAccount)
+	if err != nil {
+		return err
+	}
 
-	err = s.repo.InsertSequence(ctx, seq)
+	seq.Fee, err = s.transferFee(ctx, user.ID, seq, intrabankLimit.Fee, srcAccount.ProductType)
 	if err != nil {
-		s.log.DomainUsecase(domainName, "Inquiry").Errorf("InsertSequence: %v", err)
-		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+		s.log.DomainUsecase(domainName, usecase).Errorf("CountTransfers: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !srcAccount.CanDebit(seq.Amount + seq.Fee) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("source account (%v) cannot be debited by %v", seq.SourceAccount, seq.Amount+seq.Fee)
+		return pkgerror.New(codes.BadRequest, ErrInsufficientBalance).
+			SetMsg("Your balance is not enough for this transfer.")
+	}
+
+	seq.SourceName = srcAccount.Name
+
+	destAccount, err := s.destinationAccount(ctx, usecase, seq.DestinationAccount)
+	if err != nil {
+		return err
+	}
+
+	seq.DestinationName = destAccount.Name
+	return nil
+}
+
+// ValidateAccounts checks the accounts of a transfer instruction that is paid later, e.g. a scheduled transfer:
+// the source account must be an active account of the user and the destination account must be active.
+// The limits, the fee and the balance are checked when the instruction is paid, so no sequence is created.
+// It returns the instruction with the names of both accounts.
+func (s *Service) ValidateAccounts(ctx context.Context, seq *Sequence) (*Sequence, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "ValidateAccounts").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
 	}
 
+	srcAccount, err := s.sourceAccount(ctx, "ValidateAccounts", user, seq.SourceAccount)
+	if err != nil {
+		return nil, err
+	}
+	destAccount, err := s.destinationAccount(ctx, "ValidateAccounts", seq.DestinationAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	seq.SourceName = srcAccount.Name
+	seq.DestinationName = destAccount.Name
 	return seq, nil
 }
 
-func (s *Service) DoPayment(ctx context.Context, sequenceNumber string) (*Transaction, error) {
-	coreStatus, err := s.corebanking.GetCoreStatus(ctx)
+// sourceAccount retrieves the source account of a transfer, which must be an active account of the user.
+func (s *Service) sourceAccount(ctx context.Context, usecase string, user *ctxt.User, accountNumber string) (*Account, error) {
+	account, err := s.corebanking.GetAccountDetails(ctx, accountNumber)
 	if err != nil {
-		s.log.DomainUsecase(domainName, "DoPayment").Errorf("CheckEOD: %v", err)
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetAccountDetails: %v", err)
 		return nil, pkgerror.New(codes.Internal, ErrGeneral)
 	}
-	if coreStatus.IsEODRunning() {
-		s.log.DomainUsecase(domainName, "DoPayment").Errorf("CheckEOD: %v", ErrEODInProgress)
-		return nil, pkgerror.New(codes.Internal, ErrEODInProgress)
+	if !account.IsOwnedBy(user.CIF) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("source account (%v) not owned by user (%v)", accountNumber, user.ID)
+		return nil, pkgerror.New(codes.Forbidden, ErrSourceAccountNotOwned).
+			SetMsg("You can only transfer from your own account.")
 	}
+	if !account.IsAccountActive() {
+		s.log.DomainUsecase(domainName, usecase).Errorf("source account (%v) not active", accountNumber)
+		return nil, pkgerror.New(codes.BadRequest, ErrSourceAccountInactive)
+	}
+	return account, nil
+}
 
-	sequence, err := s.repo.GetSequence(ctx, sequenceNumber)
+// destinationAccount retrieves the destination account of a transfer, which must be active.
+func (s *Service) destinationAccount(ctx context.Context, usecase, accountNumber string) (*Account, error) {
+	account, err := s.corebanking.GetAccountDetails(ctx, accountNumber)
 	if err != nil {
-		s.log.DomainUsecase(domainName, "DoPayment").Errorf("GetSequence: %v", err)
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetAccountDetails: %v", err)
 		return nil, pkgerror.New(codes.Internal, ErrGeneral)
 	}
-	if !sequence.Valid(sequenceNumber) {
-		s.log.DomainUsecase(domainName, "DoPayment").Errorf("GetSequence: %v", ErrInvalidSequenceNumber)
-		return nil, pkgerror.New(codes.BadRequest, ErrInvalidSequenceNumber).
-			SetMsg("Your transfer request was rejected. Please try again.")
+	if !account.IsAccountActive() {
+		s.log.DomainUsecase(domainName, usecase).Errorf("destination account (%v) not active", accountNumber)
+		return nil, pkgerror.New(codes.BadRequest, ErrDestinationAccountInactive)
 	}
+	return account, nil
+}
 
-	intrabankLimit, err := s.repo.GetTransactionLimit(ctx)
+// DoPayment pays the intrabank transfer sequence through the shared payment pipeline.
+func (s *Service) DoPayment(ctx context.Context, in *PaymentInput) (*Transaction, error) {
+	payment, err := s.payer.Pay(ctx, in)
 	if err != nil {
-		s.log.DomainUsecase(domainName, "DoPayment").Errorf("GetTransactionLimit: %v", err)
-		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+		return nil, err
 	}
-	if !intrabankLimit.CanTransfer(sequence.Amount) {
-		s.log.DomainUsecase(domainName, "DoPayment").Error(ErrInvalidAmount)
-		return nil, pkgerror.New(codes.BadRequest, ErrInvalidAmount).
-			SetMsg("Your transfer amount is too high. Please try again with a lower amount.")
+	return payment.Transaction, nil
+}
+
+// PurgeExpiredSequences deletes the sequences that expired without being paid.
+// Paid sequences are kept, because the transaction detail is read from them.
+func (s *Service) PurgeExpiredSequences(ctx context.Context) error {
+	deleted, err := s.repo.DeleteExpiredSequences(ctx, time.Now())
+	if err != nil {
+		s.log.DomainUsecase(domainName, "PurgeExpiredSequences").Errorf("DeleteExpiredSequences: %v", err)
+		return err
+	}
+	if deleted > 0 {
+		s.log.DomainUsecase(domainName, "PurgeExpiredSequences").Infof("deleted %v expired sequences", deleted)
 	}
+	return nil
+}
 
-	result, err := s.corebanking.PerformOverbooking(ctx, &OverbookingInput{
-		SourceAccount:      sequence.SourceAccount,
-		DestinationAccount: sequence.DestinationAccount,
-		Amount:             sequence.Amount,
-		Fee:                transferFee,
-		Remark:             sequence.Remark(),
-	})
+// Reconcile completes the transactions of the open recovery entries,
+// i.e. the core postings that succeeded while their transaction could not be completed,
+// and then settles the transfers left pending against the core banking system.
+func (s *Service) Reconcile(ctx context.Context) error {
+	recoveries, err := s.repo.GetOpenRecoveries(ctx, reconcileBatchSize)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Reconcile").Errorf("GetOpenRecoveries: %v", err)
+		return err
+	}
+
+	for _, recovery := range recoveries {
+		// The outbox messages have been stored together with the recovery entry.
+		err := s.repo.CompleteTransaction(ctx, recovery.Transaction(), nil)
+		switch {
+		case errors.Is(err, ErrTransactionFinished):
+			recovery.Resolve("transaction already finished", time.Now())
+		case err != nil:
+			s.log.DomainUsecase(domainName, "Reconcile").Errorf("recovery (%v) CompleteTransaction: %v", recovery.ID, err)
+			recovery.Retry(err)
+		default:
+			recovery.Resolve("transaction completed by the reconciler", time.Now())
+		}
+
+		if err := s.repo.UpdateRecovery(ctx, recovery); err != nil {
+			s.log.DomainUsecase(domainName, "Reconcile").Errorf("recovery (%v) UpdateRecovery: %v", recovery.ID, err)
+		}
+	}
+
+	return s.payer.SettlePending(ctx)
+}
+
+// DispatchOutbox delivers the due outbox messages. A failed delivery is retried with a backoff
+// and the message is dead-lettered once its attempts are exhausted.
+func (s *Service) DispatchOutbox(ctx context.Context) error {
+	now := time.Now()
+	messages, err := s.repo.AcquireOutbox(ctx, now, now.Add(outboxLease), outboxBatchSize)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "DispatchOutbox").Errorf("AcquireOutbox: %v", err)
+		return err
+	}
+
+	for _, message := range messages {
+		err := s.deliver(ctx, message)
+		if err != nil {
+			s.log.DomainUsecase(domainName, "DispatchOutbox").Errorf("message (%v) deliver: %v", message.ID, err)
+			message.Retry(err, time.Now())
+			if message.Status == OutboxDead {
+				s.log.DomainUsecase(domainName, "DispatchOutbox").Errorf("message (%v) dead-lettered after %v attempts", message.ID, message.Attempts)
+			}
+		} else {
+			message.Sent(time.Now())
+		}
+
+		if err := s.repo.UpdateOutbox(ctx, message); err != nil {
+			s.log.DomainUsecase(domainName, "DispatchOutbox").Errorf("message (%v) UpdateOutbox: %v", message.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// deliver sends the outbox message through the port of its kind.
+func (s *Service) deliver(ctx context.Context, message *OutboxMessage) error {
+	switch message.Kind {
+	case OutboxReceipt:
+		email, err := message.Receipt()
+		if err != nil {
+			return err
+		}
+		return s.mailer.SendReceipt(ctx, email)
+	case OutboxNotification:
+		notification, err := message.Notification()
+		if err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, notification)
+	}
+	return ErrUnknownOutboxKind
+}
+
+// History returns a page of the transaction history of the authenticated user.
+func (s *Service) History(ctx context.Context, filter *TransactionFilter) (*TransactionPage, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "History").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+	if !filter.Valid() {
+		s.log.DomainUsecase(domainName, "History").Error(ErrInvalidTransactionFilter)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidTransactionFilter).
+			SetMsg("Your history filter is invalid. Please check the dates and amounts.")
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = DefaultHistoryLimit
+	}
+
+	// Only the authenticated user's transactions can be read,
+	// one extra row is requested to know if there is a next page.
+	filter.UserID = strconv.Itoa(user.ID)
+	filter.Limit = limit + 1
+
+	transactions, err := s.repo.GetTransactions(ctx, filter)
 	if err != nil {
-		s.log.DomainUsecase(domainName, "DoPayment").Errorf("PerformOverbooking: %v", err)
+		s.log.DomainUsecase(domainName, "History").Errorf("GetTransactions: %v", err)
 		return nil, pkgerror.New(codes.Internal, ErrGeneral)
 	}
 
+	page := &TransactionPage{Transactions: transactions}
+	if len(transactions) > limit {
+		page.Transactions = transactions[:limit]
+		page.NextCursor = page.Transactions[limit-1].ID
+	}
+
+	return page, nil
+}
+
+// Detail returns the authenticated user's transaction with the transaction reference.
+func (s *Service) Detail(ctx context.Context, transactionReference string) (*TransactionDetail, error) {
 	user, ok := ctxt.UserFromContext(ctx)
 	if !ok {
-		s.log.DomainUsecase(domainName, "DoPayment").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		s.log.DomainUsecase(domainName, "Detail").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
 		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
 			SetMsg("Please login to continue.")
 	}
+	return s.detail(ctx, "Detail", user, transactionReference)
+}
+
+// ResendReceipt sends the receipt email of the authenticated user's successful transaction again.
+func (s *Service) ResendReceipt(ctx context.Context, transactionReference string) error {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "ResendReceipt").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	detail, err := s.detail(ctx, "ResendReceipt", user, transactionReference)
+	if err != nil {
+		return err
+	}
+	if !detail.HasReceipt() {
+		s.log.DomainUsecase(domainName, "ResendReceipt").Error(ErrReceiptUnavailable)
+		return pkgerror.New(codes.BadRequest, ErrReceiptUnavailable).
+			SetMsg("A receipt is only available for a successful transfer.")
+	}
+
+	err = s.mailer.SendReceipt(ctx, receiptEmail(user, detail.Sequence, detail.Transaction))
+	if err != nil {
+		s.log.DomainUsecase(domainName, "ResendReceipt").Errorf("SendReceipt: %v", err)
+		return pkgerror.New(codes.Internal, ErrSendEmailFailed)
+	}
 
-	transaction := &Transaction{
-		SequenceNumber:       sequence.SequenceNumber,
-		SequenceJournal:      result.JournalSequence,
-		UserID:               strconv.Itoa(user.ID),
-		Destination:          sequence.DestinationAccount,
-		Amount:               sequence.Amount,
-		TransactionType:      transferType,
-		TransactionReference: result.TransactionReference,
-		Remarks:              sequence.Remark(),
-		Fee:                  stringTransferFee,
-		DestinationName:      sequence.DestinationName,
+	return nil
+}
+
+// detail loads the user's transaction and the sequence it was paid from.
+func (s *Service) detail(ctx context.Context, usecase string, user *ctxt.User, transactionReference string) (*TransactionDetail, error) {
+	transaction, err := s.repo.GetTransactionByReference(ctx, strconv.Itoa(user.ID), transactionReference)
+	if errors.Is(err, ErrTransactionNotFound) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetTransactionByReference: %v", err)
+		return nil, pkgerror.New(codes.NotFound, ErrTransactionNotFound).
+			SetMsg("Transaction not found.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetTransactionByReference: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
 	}
 
-	err = s.repo.InsertTransaction(ctx, transaction)
+	sequence, err := s.repo.GetSequence(ctx, transaction.SequenceNumber)
 	if err != nil {
-		s.log.DomainUsecase(domainName, "DoPayment").Errorf("InsertTransaction: %v", err)
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetSequence: %v", err)
 		return nil, pkgerror.New(codes.Internal, ErrGeneral)
 	}
 
-	err = s.mailer.SendReceipt(ctx, &EmailData{
-		Subject:            transferSuccessSubject,
-		Recipient:          user.Email,
-		Amount:             sequence.Amount,
-		Fee:                transferFee,
-		SourceName:         user.Name,
+	return &TransactionDetail{
+		Transaction: transaction,
+		Sequence:    sequence,
+	}, nil
+}
+
+// checkAvailability rejects the transfer when the method is disabled or outside its operating hours at now.
+func (s *Service) checkAvailability(usecase string, limits *Limits, now time.Time) error {
+	if limits.Disabled {
+		s.log.DomainUsecase(domainName, usecase).Error(ErrTransferMethodDisabled)
+		return pkgerror.New(codes.Forbidden, ErrTransferMethodDisabled).
+			SetMsg("Transfers are temporarily unavailable. Please try again later.")
+	}
+	if !limits.IsOpen(now) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("%v at %v", ErrOutsideOperatingHours, now)
+		return pkgerror.New(codes.Forbidden, ErrOutsideOperatingHours).
+			SetMsg(fmt.Sprintf("Transfers are only available from %02d:00 to %02d:00 WIB.", limits.OpenHour, limits.CloseHour))
+	}
+	return nil
+}
+
+// transferFee calculates the fee of the sequence for the customer segment.
+// The transfer is free while the user has not used up the monthly free quota.
+func (s *Service) transferFee(ctx context.Context, userID int, seq *Sequence, baseFee Money, segment string) (Money, error) {
+	in := &FeeInput{
+		Method:  transferType,
+		Channel: seq.Channel,
+		Segment: segment,
+		Amount:  seq.Amount,
+		BaseFee: baseFee,
+	}
+	if quota := s.fees.FreeTransfers(in); quota > 0 {
+		from, to := BusinessMonth(time.Now())
+		count, err := s.repo.CountTransfers(ctx, strconv.Itoa(userID), from, to)
+		if err != nil {
+			return 0, err
+		}
+		if count < quota {
+			return 0, nil
+		}
+	}
+	return s.fees.Fee(in), nil
+}
+
+// transferMethod pays the intrabank transfer sequences with an overbooking at the core banking system.
+type transferMethod struct {
+	*Service
+}
+
+var transferMessages = &PaymentMessages{
+	Rejected:    "Your transfer request was rejected. Please try again.",
+	KeyReused:   "The idempotency key has been used for another transfer.",
+	Expired:     "Your transfer session has expired. Please start the transfer again.",
+	OTPRequired: "Please verify this transfer with the OTP sent to you.",
+	InProgress:  "Your transfer is being processed. Please check your transaction history.",
+	Failed:      "Your transfer has failed. Please create a new transfer.",
+	Pending:     "Your transfer is being processed. Please check your transaction history.",
+	NotRecorded: "Your transfer has been processed but is not recorded yet. Please check your transaction history later.",
+}
+
+func (m *transferMethod) Messages() *PaymentMessages {
+	return transferMessages
+}
+
+func (m *transferMethod) Accepts(sequence *Sequence) bool {
+	return sequence.IsIntrabank()
+}
+
+// Prepare loads the intrabank limits and checks that the transfer method is open for the amount.
+func (m *transferMethod) Prepare(ctx context.Context, payment *Payment[*Limits]) error {
+	intrabankLimit, err := m.repo.GetTransactionLimit(ctx)
+	if err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("GetTransactionLimit: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if err := m.checkAvailability("DoPayment", intrabankLimit, time.Now()); err != nil {
+		return err
+	}
+	if !intrabankLimit.CanTransfer(payment.Sequence.Amount) {
+		m.log.DomainUsecase(domainName, "DoPayment").Error(ErrInvalidAmount)
+		return pkgerror.New(codes.BadRequest, ErrInvalidAmount).
+			SetMsg("Your transfer amount is too high. Please try again with a lower amount.")
+	}
+	payment.Details = intrabankLimit
+	return nil
+}
+
+func (m *transferMethod) KnownDestination(ctx context.Context, payment *Payment[*Limits]) (bool, error) {
+	return m.repo.HasTransferredTo(ctx, strconv.Itoa(payment.User.ID), payment.Sequence.DestinationAccount)
+}
+
+func (m *transferMethod) Describe(payment *Payment[*Limits]) (string, string) {
+	return transferType, payment.Sequence.Remark()
+}
+
+func (m *transferMethod) CheckDailyLimit(ctx context.Context, payment *Payment[*Limits]) error {
+	from, to := BusinessDay(time.Now())
+	dailyAmount, err := m.repo.SumTransferAmount(ctx, payment.Transaction.UserID, from, to)
+	if err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("SumTransferAmount: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !payment.Details.WithinDailyLimit(dailyAmount) {
+		m.log.DomainUsecase(domainName, "DoPayment").Error(ErrDailyLimitExceeded)
+		return pkgerror.New(codes.BadRequest, ErrDailyLimitExceeded).
+			SetMsg("You have reached your daily transfer limit. Please try again tomorrow.")
+	}
+	return nil
+}
+
+func (m *transferMethod) Post(ctx context.Context, payment *Payment[*Limits]) (*OverbookingResult, error) {
+	sequence := payment.Sequence
+	return m.corebanking.PerformOverbooking(ctx, &OverbookingInput{
 		SourceAccount:      sequence.SourceAccount,
-		DestinationName:    sequence.DestinationName,
 		DestinationAccount: sequence.DestinationAccount,
-		DestinationBank:    constant.BankYayaCompanyName,
-		TransactionRef:     result.TransactionReference,
-		Note:               sequence.Remark(),
+		Amount:             sequence.Amount,
+		Fee:                sequence.Fee,
+		Remark:             sequence.Remark(),
+		Reference:          sequence.SequenceNumber,
 	})
-	if err != nil {
-		s.log.DomainUsecase(domainName, "DoPayment").Errorf("SendReceipt: %v", err)
-		return nil, pkgerror.New(codes.Internal, ErrSendEmailFailed)
+}
+
+// Rejected maps an insufficient balance at the core banking system to ErrInsufficientBalance,
+// the balance may have changed since the inquiry, e.g. for a standing order that retries its run.
+func (m *transferMethod) Rejected(_ *Payment[*Limits], rejection *OverbookingRejection) error {
+	if rejection.InsufficientFunds() {
+		return pkgerror.New(codes.BadRequest, ErrInsufficientBalance).
+			SetMsg("Your balance is not enough for this transfer.")
 	}
+	return pkgerror.New(codes.Internal, ErrGeneral)
+}
 
-	err = s.notifier.Notify(ctx, &Notification{
-		Subject:     transferSuccessSubject,
+func (m *transferMethod) Receipt(payment *Payment[*Limits]) (*EmailData, *Notification) {
+	transaction := payment.Transaction
+	return receiptEmail(payment.User, payment.Sequence, transaction), &Notification{
+		Subject:     transferSubject(transaction),
 		Amount:      transaction.Amount,
 		Destination: transaction.Destination,
-		Status:      TransactionSuccess,
-	})
-	if err != nil {
-		s.log.DomainUsecase(domainName, "DoPayment").Errorf("Notify: %v", err)
-		return nil, pkgerror.New(codes.Internal, ErrNotifyFailed)
+		Status:      transaction.Status,
 	}
+}
 
-	return transaction, nil
+// Load needs no details, the receipt of a transfer is built from its sequence and transaction.
+func (m *transferMethod) Load(context.Context, *Payment[*Limits]) error {
+	return nil
+}
+
+// Resolve checks the posting of the transfer at the core banking system, it is the only posting of the transfer.
+func (m *transferMethod) Resolve(ctx context.Context, payment *Payment[*Limits]) (*OverbookingResult, error) {
+	return m.corebanking.GetPostingStatus(ctx, payment.Transaction.SequenceNumber)
+}
+
+// transferSubject returns the email and notification subject of the transaction result.
+func transferSubject(transaction *Transaction) string {
+	if transaction.Status == TransactionFailed {
+		return transferFailedSubject
+	}
+	return transferSuccessSubject
+}
+
+// receiptEmail builds the receipt email of the transaction result.
+func receiptEmail(user *ctxt.User, sequence *Sequence, transaction *Transaction) *EmailData {
+	return &EmailData{
+		Subject:            transferSubject(transaction),
+		Recipient:          user.Email,
+		Amount:             transaction.Amount,
+		Fee:                sequence.Fee,
+		SourceName:         user.Name,
+		SourceAccount:      sequence.SourceAccount,
+		DestinationName:    transaction.DestinationName,
+		DestinationAccount: transaction.Destination,
+		DestinationBank:    constant.BankYayaCompanyName,
+		TransactionRef:     transaction.TransactionReference,
+		Note:               transaction.Remarks,
+		Status:             transaction.Status,
+	}
 }
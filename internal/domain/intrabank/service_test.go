@@ -3,11 +3,14 @@ package intrabank
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/constant"
 	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
 	"go.bankyaya.org/app/backend/internal/pkg/logger"
 	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
@@ -20,12 +23,14 @@ func TestTransferInquirySuccess(t *testing.T) {
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{"internal_transfer": 10 * time.Minute}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
-			ID:    123,
-			CIF:   "1234567",
-			Name:  "Olivia Rodrigo",
-			Email: "olivia@gmail.com",
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
 		})
 	)
 
@@ -36,8 +41,11 @@ func TestTransferInquirySuccess(t *testing.T) {
 	}, nil)
 	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
 		Return(&Account{
-			Name:   "Olivia Rodrigo",
-			Status: "1",
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
 		}, nil)
 	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
 		Return(&Account{
@@ -51,14 +59,17 @@ func TestTransferInquirySuccess(t *testing.T) {
 			MaxAmount:      50_000_000,
 			MaxDailyAmount: 200_000_000,
 		}, nil)
-	repoMock.EXPECT().InsertSequence(mock.Anything, &Sequence{
-		SequenceNumber:     "123456",
-		Amount:             100000,
-		SourceAccount:      "001001234567891",
-		DestinationAccount: "001001234567892",
-		DestinationName:    "Destination Account",
-		SourceName:         "Olivia Rodrigo",
-	}).Return(nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
+	repoMock.EXPECT().InsertSequence(mock.Anything, mock.MatchedBy(func(seq *Sequence) bool {
+		return seq.SequenceNumber == "123456" &&
+			seq.DestinationName == "Destination Account" &&
+			seq.SourceName == "Olivia Rodrigo" &&
+			seq.Status == "CREATED" &&
+			seq.UserID == 123 &&
+			seq.DeviceID == "device-1" &&
+			seq.ExpiresAt.Sub(seq.CreatedAt) == 10*time.Minute
+	})).Return(nil)
 
 	seqGenMock.EXPECT().Generate().
 		Return("123456", nil)
@@ -71,6 +82,8 @@ func TestTransferInquirySuccess(t *testing.T) {
 	})
 
 	assert.Nil(t, err)
+	assert.Equal(t, 10*time.Minute, sequence.ExpiresAt.Sub(sequence.CreatedAt))
+	sequence.CreatedAt, sequence.ExpiresAt = time.Time{}, time.Time{}
 	assert.Equal(t, sequence, &Sequence{
 		SequenceNumber:     "123456",
 		Amount:             100000,
@@ -78,6 +91,9 @@ func TestTransferInquirySuccess(t *testing.T) {
 		DestinationAccount: "001001234567892",
 		DestinationName:    "Destination Account",
 		SourceName:         "Olivia Rodrigo",
+		Status:             "CREATED",
+		UserID:             123,
+		DeviceID:           "device-1",
 	})
 
 	corebankingMock.AssertExpectations(t)
@@ -86,48 +102,84 @@ func TestTransferInquirySuccess(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferInquiryFailed_CheckEODFailed(t *testing.T) {
+func TestTransferInquirySuccess_Beneficiary(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{"internal_transfer": 10 * time.Minute}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
-			ID:    123,
-			CIF:   "1234567",
-			Name:  "Olivia Rodrigo",
-			Email: "olivia@gmail.com",
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
 		})
 	)
 
-	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).
-		Return(nil, errors.New("check EOD failed"))
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
+		}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&Account{
+			Name:   "Destination Account",
+			Status: "1",
+		}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().GetBeneficiaryAccount(mock.Anything, 123, int64(7)).
+		Return("001001234567892", nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
+	repoMock.EXPECT().InsertSequence(mock.Anything, mock.MatchedBy(func(seq *Sequence) bool {
+		return seq.DestinationAccount == "001001234567892" &&
+			seq.DestinationName == "Destination Account"
+	})).Return(nil)
+
+	seqGenMock.EXPECT().Generate().
+		Return("123456", nil)
 
 	sequence, err := svc.Inquiry(ctx, &Sequence{
-		SequenceNumber:     "123456",
-		Amount:             100000,
-		SourceAccount:      "001001234567891",
-		DestinationAccount: "001001234567892",
+		Amount:        100000,
+		SourceAccount: "001001234567891",
+		BeneficiaryID: 7,
 	})
-	assert.Nil(t, sequence)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "001001234567892", sequence.DestinationAccount)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
-	mailerMock.AssertExpectations(t)
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferInquiryFailed_EODIsRunning(t *testing.T) {
+func TestTransferInquiryFailed_BeneficiaryNotFound(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{"internal_transfer": 10 * time.Minute}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -138,38 +190,52 @@ func TestTransferInquiryFailed_EODIsRunning(t *testing.T) {
 
 	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
 		SystemDate:    "25-03-2025",
-		Status:        "STARTED",
+		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
 
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().GetBeneficiaryAccount(mock.Anything, 123, int64(7)).
+		Return("", ErrBeneficiaryNotFound)
+
 	sequence, err := svc.Inquiry(ctx, &Sequence{
-		SequenceNumber:     "123456",
-		Amount:             100000,
-		SourceAccount:      "001001234567891",
-		DestinationAccount: "001001234567892",
+		Amount:        100000,
+		SourceAccount: "001001234567891",
+		BeneficiaryID: 7,
 	})
+
 	assert.Nil(t, sequence)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrEODInProgress), err)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrBeneficiaryNotFound).
+		SetMsg("Beneficiary not found."), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
-	mailerMock.AssertExpectations(t)
-	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferInquiryFailed_GetTransactionLimitFailed(t *testing.T) {
+func TestTransferInquirySuccess_WithFee(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
-		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
-			ID:    123,
-			CIF:   "1234567",
-			Name:  "Olivia Rodrigo",
-			Email: "olivia@gmail.com",
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		fees            = FeePolicy{
+			Rules:      []FeeRule{{Method: "internal_transfer", Segment: "payroll", Fee: 2500}, {Fee: 6500}},
+			FreeQuotas: []FreeQuota{{Method: "internal_transfer", Transfers: 5}},
+		}
+		svc = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{"internal_transfer": 10 * time.Minute}, authorizerMock, StepUpPolicy{}, fees)
+		ctx = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
 		})
 	)
 
@@ -178,19 +244,56 @@ func TestTransferInquiryFailed_GetTransactionLimitFailed(t *testing.T) {
 		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			ProductType:      "payroll",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
+		}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&Account{
+			Name:   "Destination Account",
+			Status: "1",
+		}, nil)
 
 	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
-		Return(nil, errors.New("some error"))
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
+	repoMock.EXPECT().CountTransfers(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(5, nil)
+	repoMock.EXPECT().InsertSequence(mock.Anything, mock.MatchedBy(func(seq *Sequence) bool {
+		return seq.SequenceNumber == "123456" &&
+			seq.DestinationName == "Destination Account" &&
+			seq.SourceName == "Olivia Rodrigo" &&
+			seq.Status == "CREATED" &&
+			seq.UserID == 123 &&
+			seq.DeviceID == "device-1" &&
+			seq.Channel == "android" &&
+			seq.Fee == 2500 &&
+			seq.ExpiresAt.Sub(seq.CreatedAt) == 10*time.Minute
+	})).Return(nil)
+
+	seqGenMock.EXPECT().Generate().
+		Return("123456", nil)
 
 	sequence, err := svc.Inquiry(ctx, &Sequence{
 		SequenceNumber:     "123456",
 		Amount:             100000,
 		SourceAccount:      "001001234567891",
 		DestinationAccount: "001001234567892",
+		Channel:            "android",
 	})
 
-	assert.Nil(t, sequence)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+	assert.Nil(t, err)
+	assert.Equal(t, Money(2500), sequence.Fee)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -198,19 +301,25 @@ func TestTransferInquiryFailed_GetTransactionLimitFailed(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferInquiryFailed_TransactionLimitCannotTransfer(t *testing.T) {
+func TestTransferInquirySuccess_FreeQuota(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
-		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
-			ID:    123,
-			CIF:   "1234567",
-			Name:  "Olivia Rodrigo",
-			Email: "olivia@gmail.com",
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		fees            = FeePolicy{
+			Rules:      []FeeRule{{Method: "internal_transfer", Segment: "payroll", Fee: 2500}, {Fee: 6500}},
+			FreeQuotas: []FreeQuota{{Method: "internal_transfer", Transfers: 5}},
+		}
+		svc = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{"internal_transfer": 10 * time.Minute}, authorizerMock, StepUpPolicy{}, fees)
+		ctx = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
 		})
 	)
 
@@ -219,23 +328,56 @@ func TestTransferInquiryFailed_TransactionLimitCannotTransfer(t *testing.T) {
 		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			ProductType:      "payroll",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
+		}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&Account{
+			Name:   "Destination Account",
+			Status: "1",
+		}, nil)
 
 	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
 		Return(&Limits{
 			MinAmount:      1,
-			MaxAmount:      100_000_000,
-			MaxDailyAmount: 50_000,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
 		}, nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
+	repoMock.EXPECT().CountTransfers(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(4, nil)
+	repoMock.EXPECT().InsertSequence(mock.Anything, mock.MatchedBy(func(seq *Sequence) bool {
+		return seq.SequenceNumber == "123456" &&
+			seq.DestinationName == "Destination Account" &&
+			seq.SourceName == "Olivia Rodrigo" &&
+			seq.Status == "CREATED" &&
+			seq.UserID == 123 &&
+			seq.DeviceID == "device-1" &&
+			seq.Channel == "android" &&
+			seq.Fee == 0 &&
+			seq.ExpiresAt.Sub(seq.CreatedAt) == 10*time.Minute
+	})).Return(nil)
+
+	seqGenMock.EXPECT().Generate().
+		Return("123456", nil)
 
 	sequence, err := svc.Inquiry(ctx, &Sequence{
 		SequenceNumber:     "123456",
 		Amount:             100000,
 		SourceAccount:      "001001234567891",
 		DestinationAccount: "001001234567892",
+		Channel:            "android",
 	})
 
-	assert.Nil(t, sequence)
-	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidAmount), err)
+	assert.Nil(t, err)
+	assert.Equal(t, Money(0), sequence.Fee)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -243,19 +385,25 @@ func TestTransferInquiryFailed_TransactionLimitCannotTransfer(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferInquiryFailed_FailedCheckSourceAccount(t *testing.T) {
+func TestTransferInquiryFailed_CountTransfersFailed(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
-		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
-			ID:    123,
-			CIF:   "1234567",
-			Name:  "Olivia Rodrigo",
-			Email: "olivia@gmail.com",
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		fees            = FeePolicy{
+			Rules:      []FeeRule{{Method: "internal_transfer", Segment: "payroll", Fee: 2500}, {Fee: 6500}},
+			FreeQuotas: []FreeQuota{{Method: "internal_transfer", Transfers: 5}},
+		}
+		svc = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{"internal_transfer": 10 * time.Minute}, authorizerMock, StepUpPolicy{}, fees)
+		ctx = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
 		})
 	)
 
@@ -265,7 +413,14 @@ func TestTransferInquiryFailed_FailedCheckSourceAccount(t *testing.T) {
 		StandInStatus: "N",
 	}, nil)
 	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
-		Return(nil, errors.New("GetAccountDetails failed"))
+		Return(&Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			ProductType:      "payroll",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
+		}, nil)
 
 	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
 		Return(&Limits{
@@ -273,12 +428,16 @@ func TestTransferInquiryFailed_FailedCheckSourceAccount(t *testing.T) {
 			MaxAmount:      50_000_000,
 			MaxDailyAmount: 200_000_000,
 		}, nil)
-
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
+	repoMock.EXPECT().CountTransfers(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, errors.New("unexpected error"))
 	sequence, err := svc.Inquiry(ctx, &Sequence{
 		SequenceNumber:     "123456",
 		Amount:             100000,
 		SourceAccount:      "001001234567891",
 		DestinationAccount: "001001234567892",
+		Channel:            "android",
 	})
 
 	assert.Nil(t, sequence)
@@ -290,14 +449,15 @@ func TestTransferInquiryFailed_FailedCheckSourceAccount(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferInquiryFailed_SourceAccountIsInactive(t *testing.T) {
+func TestTransferInquiryFailed_CheckEODFailed(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -306,22 +466,8 @@ func TestTransferInquiryFailed_SourceAccountIsInactive(t *testing.T) {
 		})
 	)
 
-	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
-		SystemDate:    "25-03-2025",
-		Status:        "FINISHED",
-		StandInStatus: "N",
-	}, nil)
-	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
-		Return(&Account{
-			Status: "9",
-		}, nil)
-
-	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
-		Return(&Limits{
-			MinAmount:      1,
-			MaxAmount:      50_000_000,
-			MaxDailyAmount: 200_000_000,
-		}, nil)
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).
+		Return(nil, errors.New("check EOD failed"))
 
 	sequence, err := svc.Inquiry(ctx, &Sequence{
 		SequenceNumber:     "123456",
@@ -329,9 +475,8 @@ func TestTransferInquiryFailed_SourceAccountIsInactive(t *testing.T) {
 		SourceAccount:      "001001234567891",
 		DestinationAccount: "001001234567892",
 	})
-
 	assert.Nil(t, sequence)
-	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrSourceAccountInactive), err)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -339,14 +484,15 @@ func TestTransferInquiryFailed_SourceAccountIsInactive(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferInquiryFailed_CheckDestinationAccountFailed(t *testing.T) {
+func TestTransferInquiryFailed_EODIsRunning(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -357,23 +503,9 @@ func TestTransferInquiryFailed_CheckDestinationAccountFailed(t *testing.T) {
 
 	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
 		SystemDate:    "25-03-2025",
-		Status:        "FINISHED",
+		Status:        "STARTED",
 		StandInStatus: "N",
 	}, nil)
-	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
-		Return(&Account{
-			Name:   "Olivia Rodrigo",
-			Status: "1",
-		}, nil)
-	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
-		Return(nil, errors.New("GetAccountDetails failed"))
-
-	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
-		Return(&Limits{
-			MinAmount:      1,
-			MaxAmount:      50_000_000,
-			MaxDailyAmount: 200_000_000,
-		}, nil)
 
 	sequence, err := svc.Inquiry(ctx, &Sequence{
 		SequenceNumber:     "123456",
@@ -381,9 +513,8 @@ func TestTransferInquiryFailed_CheckDestinationAccountFailed(t *testing.T) {
 		SourceAccount:      "001001234567891",
 		DestinationAccount: "001001234567892",
 	})
-
 	assert.Nil(t, sequence)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrEODInProgress), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -391,14 +522,15 @@ func TestTransferInquiryFailed_CheckDestinationAccountFailed(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferInquiryFailed_DestinationAccountInactive(t *testing.T) {
+func TestTransferInquiryFailed_GetTransactionLimitFailed(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -407,27 +539,14 @@ func TestTransferInquiryFailed_DestinationAccountInactive(t *testing.T) {
 		})
 	)
 
-	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
-		Return(&Limits{
-			MinAmount:      1,
-			MaxAmount:      50_000_000,
-			MaxDailyAmount: 200_000_000,
-		}, nil)
-
 	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
 		SystemDate:    "25-03-2025",
 		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
-	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
-		Return(&Account{
-			Name:   "Olivia Rodrigo",
-			Status: "1",
-		}, nil)
-	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
-		Return(&Account{
-			Status: "9",
-		}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(nil, errors.New("some error"))
 
 	sequence, err := svc.Inquiry(ctx, &Sequence{
 		SequenceNumber:     "123456",
@@ -437,7 +556,7 @@ func TestTransferInquiryFailed_DestinationAccountInactive(t *testing.T) {
 	})
 
 	assert.Nil(t, sequence)
-	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrDestinationAccountInactive), err)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -445,14 +564,15 @@ func TestTransferInquiryFailed_DestinationAccountInactive(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferInquiryFailed_GenerateSequenceFailed(t *testing.T) {
+func TestTransferInquiryFailed_TransferMethodDisabled(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -461,31 +581,19 @@ func TestTransferInquiryFailed_GenerateSequenceFailed(t *testing.T) {
 		})
 	)
 
-	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
-		Return(&Limits{
-			MinAmount:      1,
-			MaxAmount:      50_000_000,
-			MaxDailyAmount: 200_000_000,
-		}, nil)
-
 	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
 		SystemDate:    "25-03-2025",
 		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
-	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
-		Return(&Account{
-			Name:   "Olivia Rodrigo",
-			Status: "1",
-		}, nil)
-	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
-		Return(&Account{
-			Name:   "Destination Account",
-			Status: "1",
-		}, nil)
 
-	seqGenMock.EXPECT().Generate().
-		Return("", errors.New("some error"))
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+			Disabled:       true,
+		}, nil)
 
 	sequence, err := svc.Inquiry(ctx, &Sequence{
 		SequenceNumber:     "123456",
@@ -495,7 +603,8 @@ func TestTransferInquiryFailed_GenerateSequenceFailed(t *testing.T) {
 	})
 
 	assert.Nil(t, sequence)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, ErrTransferMethodDisabled).
+		SetMsg("Transfers are temporarily unavailable. Please try again later."), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -503,14 +612,15 @@ func TestTransferInquiryFailed_GenerateSequenceFailed(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferInquiryFailed_InsertSequenceFailed(t *testing.T) {
+func TestTransferInquiryFailed_TransactionLimitCannotTransfer(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -524,34 +634,13 @@ func TestTransferInquiryFailed_InsertSequenceFailed(t *testing.T) {
 		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
-	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
-		Return(&Account{
-			Name:   "Olivia Rodrigo",
-			Status: "1",
-		}, nil)
-	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
-		Return(&Account{
-			Name:   "Destination Account",
-			Status: "1",
-		}, nil)
-
-	seqGenMock.EXPECT().Generate().
-		Return("123456", nil)
 
 	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
 		Return(&Limits{
 			MinAmount:      1,
-			MaxAmount:      50_000_000,
-			MaxDailyAmount: 200_000_000,
+			MaxAmount:      100_000_000,
+			MaxDailyAmount: 50_000,
 		}, nil)
-	repoMock.EXPECT().InsertSequence(mock.Anything, &Sequence{
-		SequenceNumber:     "123456",
-		Amount:             100000,
-		SourceAccount:      "001001234567891",
-		DestinationAccount: "001001234567892",
-		DestinationName:    "Destination Account",
-		SourceName:         "Olivia Rodrigo",
-	}).Return(errors.New("some error"))
 
 	sequence, err := svc.Inquiry(ctx, &Sequence{
 		SequenceNumber:     "123456",
@@ -561,7 +650,7 @@ func TestTransferInquiryFailed_InsertSequenceFailed(t *testing.T) {
 	})
 
 	assert.Nil(t, sequence)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidAmount), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -569,14 +658,15 @@ func TestTransferInquiryFailed_InsertSequenceFailed(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentSuccess(t *testing.T) {
+func TestTransferInquiryFailed_FailedCheckSourceAccount(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -590,6 +680,8 @@ func TestTransferDoPaymentSuccess(t *testing.T) {
 		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(nil, errors.New("GetAccountDetails failed"))
 
 	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
 		Return(&Limits{
@@ -597,61 +689,18 @@ func TestTransferDoPaymentSuccess(t *testing.T) {
 			MaxAmount:      50_000_000,
 			MaxDailyAmount: 200_000_000,
 		}, nil)
-	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
-		Return(&Sequence{
-			SequenceNumber:     "123456",
-			Amount:             100000,
-			SourceAccount:      "001001234567891",
-			DestinationAccount: "001001234567892",
-			DestinationName:    "Destination Account",
-			SourceName:         "Olivia Rodrigo",
-		}, nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
 
-	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &OverbookingInput{
+	sequence, err := svc.Inquiry(ctx, &Sequence{
+		SequenceNumber:     "123456",
+		Amount:             100000,
 		SourceAccount:      "001001234567891",
 		DestinationAccount: "001001234567892",
-		Amount:             100000,
-		Fee:                0,
-		Remark:             "TRF 001001234567891 001001234567892 BNKYAYA 123456",
-	}).Return(&OverbookingResult{
-		JournalSequence:      "111111",
-		TransactionReference: "222222",
-	}, nil)
-
-	repoMock.EXPECT().InsertTransaction(mock.Anything, &Transaction{
-		SequenceNumber:       "123456",
-		SequenceJournal:      "111111",
-		UserID:               "123",
-		Destination:          "001001234567892",
-		Amount:               100000,
-		TransactionType:      "internal_transfer",
-		TransactionReference: "222222",
-		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
-		Fee:                  "0",
-		DestinationName:      "Destination Account",
-	}).Return(nil)
-
-	mailerMock.EXPECT().SendReceipt(mock.Anything, mock.Anything).
-		Return(nil)
-
-	notifierMock.EXPECT().Notify(mock.Anything, mock.Anything).
-		Return(nil)
-
-	transaction, err := svc.DoPayment(ctx, "123456")
+	})
 
-	assert.NoError(t, err)
-	assert.Equal(t, &Transaction{
-		SequenceNumber:       "123456",
-		SequenceJournal:      "111111",
-		UserID:               "123",
-		Destination:          "001001234567892",
-		Amount:               100000,
-		TransactionType:      "internal_transfer",
-		TransactionReference: "222222",
-		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
-		Fee:                  "0",
-		DestinationName:      "Destination Account",
-	}, transaction)
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -659,14 +708,15 @@ func TestTransferDoPaymentSuccess(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentFailed_CheckEODFailed(t *testing.T) {
+func TestTransferInquiryFailed_SourceAccountIsInactive(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -675,13 +725,35 @@ func TestTransferDoPaymentFailed_CheckEODFailed(t *testing.T) {
 		})
 	)
 
-	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).
-		Return(nil, errors.New("some error"))
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:    "1234567",
+			Status: "9",
+		}, nil)
 
-	transaction, err := svc.DoPayment(ctx, "123456")
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
 
-	assert.Nil(t, transaction)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+	sequence, err := svc.Inquiry(ctx, &Sequence{
+		SequenceNumber:     "123456",
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	})
+
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrSourceAccountInactive), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -689,14 +761,15 @@ func TestTransferDoPaymentFailed_CheckEODFailed(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentFailed_EODIsRunning(t *testing.T) {
+func TestTransferInquiryFailed_SourceAccountNotOwned(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -705,17 +778,36 @@ func TestTransferDoPaymentFailed_EODIsRunning(t *testing.T) {
 		})
 	)
 
-	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).
-		Return(&CoreStatus{
-			SystemDate:    "25-03-2025",
-			Status:        "STARTED",
-			StandInStatus: "N",
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:    "7654321",
+			Status: "1",
 		}, nil)
 
-	transaction, err := svc.DoPayment(ctx, "123456")
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
 
-	assert.Nil(t, transaction)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrEODInProgress), err)
+	sequence, err := svc.Inquiry(ctx, &Sequence{
+		SequenceNumber:     "123456",
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	})
+
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, ErrSourceAccountNotOwned).
+		SetMsg("You can only transfer from your own account."), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -723,14 +815,15 @@ func TestTransferDoPaymentFailed_EODIsRunning(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentFailed_GetSequenceFailed(t *testing.T) {
+func TestTransferInquiryFailed_InsufficientBalance(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -744,14 +837,33 @@ func TestTransferDoPaymentFailed_GetSequenceFailed(t *testing.T) {
 		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:              "1234567",
+			Status:           "1",
+			AvailableBalance: 120_000,
+			MinBalance:       50_000,
+		}, nil)
 
-	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
-		Return(nil, errors.New("some error"))
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
 
-	transaction, err := svc.DoPayment(ctx, "123456")
+	sequence, err := svc.Inquiry(ctx, &Sequence{
+		SequenceNumber:     "123456",
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	})
 
-	assert.Nil(t, transaction)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInsufficientBalance).
+		SetMsg("Your balance is not enough for this transfer."), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -759,14 +871,15 @@ func TestTransferDoPaymentFailed_GetSequenceFailed(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentFailed_InvalidSequenceNumber(t *testing.T) {
+func TestTransferInquiryFailed_SourceAccountBlocked(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -780,21 +893,33 @@ func TestTransferDoPaymentFailed_InvalidSequenceNumber(t *testing.T) {
 		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:              "1234567",
+			Status:           "1",
+			Blocked:          "1",
+			AvailableBalance: 10_000_000,
+		}, nil)
 
-	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
-		Return(&Sequence{
-			SequenceNumber:     "111111",
-			Amount:             100000,
-			SourceAccount:      "001001234567891",
-			DestinationAccount: "001001234567892",
-			DestinationName:    "Destination Account",
-			SourceName:         "Olivia Rodrigo",
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
 		}, nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
 
-	transaction, err := svc.DoPayment(ctx, "123456")
+	sequence, err := svc.Inquiry(ctx, &Sequence{
+		SequenceNumber:     "123456",
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	})
 
-	assert.Nil(t, transaction)
-	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidSequenceNumber), err)
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInsufficientBalance).
+		SetMsg("Your balance is not enough for this transfer."), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -802,14 +927,15 @@ func TestTransferDoPaymentFailed_InvalidSequenceNumber(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentFailed_GetTransactionLimitFailed(t *testing.T) {
+func TestTransferInquiryFailed_CheckDestinationAccountFailed(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -823,22 +949,34 @@ func TestTransferDoPaymentFailed_GetTransactionLimitFailed(t *testing.T) {
 		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
-
-	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
-		Return(&Sequence{
-			SequenceNumber:     "123456",
-			Amount:             100000,
-			SourceAccount:      "001001234567891",
-			DestinationAccount: "001001234567892",
-			DestinationName:    "Destination Account",
-			SourceName:         "Olivia Rodrigo",
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
 		}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(nil, errors.New("GetAccountDetails failed"))
+
 	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
-		Return(nil, errors.New("some error"))
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
 
-	transaction, err := svc.DoPayment(ctx, "123456")
+	sequence, err := svc.Inquiry(ctx, &Sequence{
+		SequenceNumber:     "123456",
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	})
 
-	assert.Nil(t, transaction)
+	assert.Nil(t, sequence)
 	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
 
 	corebankingMock.AssertExpectations(t)
@@ -847,14 +985,15 @@ func TestTransferDoPaymentFailed_GetTransactionLimitFailed(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentFailed_TransactionLimitCannotTransfer(t *testing.T) {
+func TestTransferInquiryFailed_DestinationAccountInactive(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -863,32 +1002,42 @@ func TestTransferDoPaymentFailed_TransactionLimitCannotTransfer(t *testing.T) {
 		})
 	)
 
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
+
 	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
 		SystemDate:    "25-03-2025",
 		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
-
-	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
-		Return(&Sequence{
-			SequenceNumber:     "123456",
-			Amount:             100000,
-			SourceAccount:      "001001234567891",
-			DestinationAccount: "001001234567892",
-			DestinationName:    "Destination Account",
-			SourceName:         "Olivia Rodrigo",
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
 		}, nil)
-	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
-		Return(&Limits{
-			MinAmount:      1,
-			MaxAmount:      100_000_000,
-			MaxDailyAmount: 50_000,
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&Account{
+			Status: "9",
 		}, nil)
 
-	transaction, err := svc.DoPayment(ctx, "123456")
+	sequence, err := svc.Inquiry(ctx, &Sequence{
+		SequenceNumber:     "123456",
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	})
 
-	assert.Nil(t, transaction)
-	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidAmount), err)
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrDestinationAccountInactive), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -896,14 +1045,15 @@ func TestTransferDoPaymentFailed_TransactionLimitCannotTransfer(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentFailed_PerformOverbookingFailed(t *testing.T) {
+func TestTransferInquiryFailed_GenerateSequenceFailed(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -912,40 +1062,46 @@ func TestTransferDoPaymentFailed_PerformOverbookingFailed(t *testing.T) {
 		})
 	)
 
-	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
-		SystemDate:    "25-03-2025",
-		Status:        "FINISHED",
-		StandInStatus: "N",
-	}, nil)
-
 	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
 		Return(&Limits{
 			MinAmount:      1,
 			MaxAmount:      50_000_000,
 			MaxDailyAmount: 200_000_000,
 		}, nil)
-	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
-		Return(&Sequence{
-			SequenceNumber:     "123456",
-			Amount:             100000,
-			SourceAccount:      "001001234567891",
-			DestinationAccount: "001001234567892",
-			DestinationName:    "Destination Account",
-			SourceName:         "Olivia Rodrigo",
-		}, nil)
-
-	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &OverbookingInput{
-		SourceAccount:      "001001234567891",
-		DestinationAccount: "001001234567892",
-		Amount:             100000,
-		Fee:                0,
-		Remark:             "TRF 001001234567891 001001234567892 BNKYAYA 123456",
-	}).Return(nil, errors.New("some error"))
-
-	transaction, err := svc.DoPayment(ctx, "123456")
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
 
-	assert.Nil(t, transaction)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
+		}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&Account{
+			Name:   "Destination Account",
+			Status: "1",
+		}, nil)
+
+	seqGenMock.EXPECT().Generate().
+		Return("", errors.New("some error"))
+
+	sequence, err := svc.Inquiry(ctx, &Sequence{
+		SequenceNumber:     "123456",
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	})
+
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -953,15 +1109,21 @@ func TestTransferDoPaymentFailed_PerformOverbookingFailed(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentFailed_GetUserFromContextFailed(t *testing.T) {
+func TestTransferInquiryFailed_InsertSequenceFailed(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
-		ctx             = context.Background()
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
 	)
 
 	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
@@ -969,6 +1131,22 @@ func TestTransferDoPaymentFailed_GetUserFromContextFailed(t *testing.T) {
 		Status:        "FINISHED",
 		StandInStatus: "N",
 	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
+		}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&Account{
+			Name:   "Destination Account",
+			Status: "1",
+		}, nil)
+
+	seqGenMock.EXPECT().Generate().
+		Return("123456", nil)
 
 	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
 		Return(&Limits{
@@ -976,31 +1154,25 @@ func TestTransferDoPaymentFailed_GetUserFromContextFailed(t *testing.T) {
 			MaxAmount:      50_000_000,
 			MaxDailyAmount: 200_000_000,
 		}, nil)
-	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
-		Return(&Sequence{
-			SequenceNumber:     "123456",
-			Amount:             100000,
-			SourceAccount:      "001001234567891",
-			DestinationAccount: "001001234567892",
-			DestinationName:    "Destination Account",
-			SourceName:         "Olivia Rodrigo",
-		}, nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
+	repoMock.EXPECT().InsertSequence(mock.Anything, mock.MatchedBy(func(seq *Sequence) bool {
+		return seq.SequenceNumber == "123456" &&
+			seq.DestinationName == "Destination Account" &&
+			seq.SourceName == "Olivia Rodrigo" &&
+			seq.Status == "CREATED" &&
+			seq.ExpiresAt.Sub(seq.CreatedAt) == DefaultSequenceValidity
+	})).Return(errors.New("some error"))
 
-	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &OverbookingInput{
+	sequence, err := svc.Inquiry(ctx, &Sequence{
+		SequenceNumber:     "123456",
+		Amount:             100000,
 		SourceAccount:      "001001234567891",
 		DestinationAccount: "001001234567892",
-		Amount:             100000,
-		Fee:                0,
-		Remark:             "TRF 001001234567891 001001234567892 BNKYAYA 123456",
-	}).Return(&OverbookingResult{
-		JournalSequence:      "111111",
-		TransactionReference: "222222",
-	}, nil)
-
-	transaction, err := svc.DoPayment(ctx, "123456")
+	})
 
-	assert.Nil(t, transaction)
-	assert.Equal(t, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser), err)
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -1008,14 +1180,15 @@ func TestTransferDoPaymentFailed_GetUserFromContextFailed(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentFailed_InsertTransactionFailed(t *testing.T) {
+func TestValidateSuccess(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -1024,71 +1197,176 @@ func TestTransferDoPaymentFailed_InsertTransactionFailed(t *testing.T) {
 		})
 	)
 
-	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
-		SystemDate:    "25-03-2025",
-		Status:        "FINISHED",
-		StandInStatus: "N",
-	}, nil)
-
 	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
 		Return(&Limits{
 			MinAmount:      1,
 			MaxAmount:      50_000_000,
 			MaxDailyAmount: 200_000_000,
 		}, nil)
-	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
-		Return(&Sequence{
-			SequenceNumber:     "123456",
-			Amount:             100000,
-			SourceAccount:      "001001234567891",
-			DestinationAccount: "001001234567892",
-			DestinationName:    "Destination Account",
-			SourceName:         "Olivia Rodrigo",
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(0, nil)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
+		}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&Account{
+			Name:   "Destination Account",
+			Status: "1",
 		}, nil)
 
-	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &OverbookingInput{
+	sequence, err := svc.Validate(ctx, &Sequence{
+		Amount:             100000,
 		SourceAccount:      "001001234567891",
 		DestinationAccount: "001001234567892",
-		Amount:             100000,
-		Fee:                0,
-		Remark:             "TRF 001001234567891 001001234567892 BNKYAYA 123456",
-	}).Return(&OverbookingResult{
-		JournalSequence:      "111111",
-		TransactionReference: "222222",
-	}, nil)
+	})
 
-	repoMock.EXPECT().InsertTransaction(mock.Anything, &Transaction{
-		SequenceNumber:       "123456",
-		SequenceJournal:      "111111",
-		UserID:               "123",
-		Destination:          "001001234567892",
-		Amount:               100000,
-		TransactionType:      "internal_transfer",
-		TransactionReference: "222222",
-		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
-		Fee:                  "0",
-		DestinationName:      "Destination Account",
-	}).Return(errors.New("some error"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Olivia Rodrigo", sequence.SourceName)
+	assert.Equal(t, "Destination Account", sequence.DestinationName)
+	assert.Empty(t, sequence.SequenceNumber)
 
-	transaction, err := svc.DoPayment(ctx, "123456")
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
 
-	assert.Nil(t, transaction)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+func TestValidateAccountsSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:    "1234567",
+			Name:   "Olivia Rodrigo",
+			Status: "1",
+		}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&Account{
+			Name:   "Destination Account",
+			Status: "1",
+		}, nil)
+
+	sequence, err := svc.ValidateAccounts(ctx, &Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Olivia Rodrigo", sequence.SourceName)
+	assert.Equal(t, "Destination Account", sequence.DestinationName)
+	assert.Empty(t, sequence.SequenceNumber)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
-	mailerMock.AssertExpectations(t)
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentFailed_SendEmailFailed(t *testing.T) {
+func TestValidateAccountsFailed_SourceAccountNotOwned(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:    "7654321",
+			Status: "1",
+		}, nil)
+
+	sequence, err := svc.ValidateAccounts(ctx, &Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	})
+
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, ErrSourceAccountNotOwned).
+		SetMsg("You can only transfer from your own account."), err)
+
+	corebankingMock.AssertExpectations(t)
+}
+
+func TestValidateAccountsFailed_DestinationAccountInactive(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&Account{
+			CIF:    "1234567",
+			Name:   "Olivia Rodrigo",
+			Status: "1",
+		}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&Account{
+			Name:   "Destination Account",
+			Status: "2",
+		}, nil)
+
+	sequence, err := svc.ValidateAccounts(ctx, &Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	})
+
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrDestinationAccountInactive), err)
+
+	corebankingMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentSuccess(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -1112,12 +1390,31 @@ func TestTransferDoPaymentFailed_SendEmailFailed(t *testing.T) {
 	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
 		Return(&Sequence{
 			SequenceNumber:     "123456",
+			UserID:             123,
 			Amount:             100000,
 			SourceAccount:      "001001234567891",
 			DestinationAccount: "001001234567892",
 			DestinationName:    "Destination Account",
 			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
 		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, &Transaction{
+		SequenceNumber:  "123456",
+		UserID:          "123",
+		Destination:     "001001234567892",
+		Amount:          100000,
+		TransactionType: "internal_transfer",
+		Remarks:         "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:          "pending",
+		Fee:             "0",
+		DestinationName: "Destination Account",
+	}).Return(nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(100000, nil)
 
 	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &OverbookingInput{
 		SourceAccount:      "001001234567891",
@@ -1125,12 +1422,15 @@ func TestTransferDoPaymentFailed_SendEmailFailed(t *testing.T) {
 		Amount:             100000,
 		Fee:                0,
 		Remark:             "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Reference:          "123456",
 	}).Return(&OverbookingResult{
 		JournalSequence:      "111111",
 		TransactionReference: "222222",
 	}, nil)
 
-	repoMock.EXPECT().InsertTransaction(mock.Anything, &Transaction{
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("firebase-id", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, &Transaction{
 		SequenceNumber:       "123456",
 		SequenceJournal:      "111111",
 		UserID:               "123",
@@ -1139,17 +1439,36 @@ func TestTransferDoPaymentFailed_SendEmailFailed(t *testing.T) {
 		TransactionType:      "internal_transfer",
 		TransactionReference: "222222",
 		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:               "success",
 		Fee:                  "0",
 		DestinationName:      "Destination Account",
-	}).Return(nil)
+	}, mock.MatchedBy(func(outbox []*OutboxMessage) bool {
+		return len(outbox) == 2 &&
+			outbox[0].Kind == OutboxReceipt &&
+			outbox[1].Kind == OutboxNotification
+	})).Return(nil)
 
-	mailerMock.EXPECT().SendReceipt(mock.Anything, mock.Anything).
-		Return(errors.New("some error"))
-
-	transaction, err := svc.DoPayment(ctx, "123456")
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
 
-	assert.Nil(t, transaction)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrSendEmailFailed), err)
+	assert.NoError(t, err)
+	assert.Equal(t, &Transaction{
+		SequenceNumber:       "123456",
+		SequenceJournal:      "111111",
+		UserID:               "123",
+		Destination:          "001001234567892",
+		Amount:               100000,
+		TransactionType:      "internal_transfer",
+		TransactionReference: "222222",
+		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:               "success",
+		Fee:                  "0",
+		DestinationName:      "Destination Account",
+	}, transaction)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
@@ -1157,14 +1476,15 @@ func TestTransferDoPaymentFailed_SendEmailFailed(t *testing.T) {
 	seqGenMock.AssertExpectations(t)
 }
 
-func TestTransferDoPaymentFailed_NotifyFailed(t *testing.T) {
+func TestTransferDoPaymentSuccess_WithFee(t *testing.T) {
 	var (
 		corebankingMock = NewMockCoreBanking(t)
 		repoMock        = NewMockRepository(t)
 		mailerMock      = NewMockReceiptMailer(t)
 		seqGenMock      = NewMockSequenceGenerator(t)
 		notifierMock    = NewMockNotifier(t)
-		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
 		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
 			ID:    123,
 			CIF:   "1234567",
@@ -1188,25 +1508,48 @@ func TestTransferDoPaymentFailed_NotifyFailed(t *testing.T) {
 	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
 		Return(&Sequence{
 			SequenceNumber:     "123456",
+			UserID:             123,
 			Amount:             100000,
 			SourceAccount:      "001001234567891",
 			DestinationAccount: "001001234567892",
 			DestinationName:    "Destination Account",
 			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+			Fee:                2500,
 		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, &Transaction{
+		SequenceNumber:  "123456",
+		UserID:          "123",
+		Destination:     "001001234567892",
+		Amount:          100000,
+		TransactionType: "internal_transfer",
+		Remarks:         "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:          "pending",
+		Fee:             "2500",
+		DestinationName: "Destination Account",
+	}).Return(nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(100000, nil)
 
 	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &OverbookingInput{
 		SourceAccount:      "001001234567891",
 		DestinationAccount: "001001234567892",
 		Amount:             100000,
-		Fee:                0,
+		Fee:                2500,
 		Remark:             "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Reference:          "123456",
 	}).Return(&OverbookingResult{
 		JournalSequence:      "111111",
 		TransactionReference: "222222",
 	}, nil)
 
-	repoMock.EXPECT().InsertTransaction(mock.Anything, &Transaction{
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("firebase-id", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, &Transaction{
 		SequenceNumber:       "123456",
 		SequenceJournal:      "111111",
 		UserID:               "123",
@@ -1215,23 +1558,2818 @@ func TestTransferDoPaymentFailed_NotifyFailed(t *testing.T) {
 		TransactionType:      "internal_transfer",
 		TransactionReference: "222222",
 		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
-		Fee:                  "0",
+		Status:               "success",
+		Fee:                  "2500",
 		DestinationName:      "Destination Account",
-	}).Return(nil)
+	}, mock.MatchedBy(func(outbox []*OutboxMessage) bool {
+		return len(outbox) == 2 &&
+			outbox[0].Kind == OutboxReceipt &&
+			outbox[1].Kind == OutboxNotification
+	})).Return(nil)
 
-	mailerMock.EXPECT().SendReceipt(mock.Anything, mock.Anything).
-		Return(nil)
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
 
-	notifierMock.EXPECT().Notify(mock.Anything, mock.Anything).
-		Return(errors.New("some error"))
+	assert.NoError(t, err)
+	assert.Equal(t, &Transaction{
+		SequenceNumber:       "123456",
+		SequenceJournal:      "111111",
+		UserID:               "123",
+		Destination:          "001001234567892",
+		Amount:               100000,
+		TransactionType:      "internal_transfer",
+		TransactionReference: "222222",
+		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:               "success",
+		Fee:                  "2500",
+		DestinationName:      "Destination Account",
+	}, transaction)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentSuccess_WithOTP(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(false, nil)
+	authorizerMock.EXPECT().VerifyTransaction(mock.Anything, 1, "654321", "123456").
+		Return(nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, &Transaction{
+		SequenceNumber:  "123456",
+		UserID:          "123",
+		Destination:     "001001234567892",
+		Amount:          100000,
+		TransactionType: "internal_transfer",
+		Remarks:         "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:          "pending",
+		Fee:             "0",
+		DestinationName: "Destination Account",
+	}).Return(nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(100000, nil)
+
+	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &OverbookingInput{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Fee:                0,
+		Remark:             "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Reference:          "123456",
+	}).Return(&OverbookingResult{
+		JournalSequence:      "111111",
+		TransactionReference: "222222",
+	}, nil)
+
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("firebase-id", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, &Transaction{
+		SequenceNumber:       "123456",
+		SequenceJournal:      "111111",
+		UserID:               "123",
+		Destination:          "001001234567892",
+		Amount:               100000,
+		TransactionType:      "internal_transfer",
+		TransactionReference: "222222",
+		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:               "success",
+		Fee:                  "0",
+		DestinationName:      "Destination Account",
+	}, mock.MatchedBy(func(outbox []*OutboxMessage) bool {
+		return len(outbox) == 2 &&
+			outbox[0].Kind == OutboxReceipt &&
+			outbox[1].Kind == OutboxNotification
+	})).Return(nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		OTPID:              1,
+		OTPCode:            "654321",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Transaction{
+		SequenceNumber:       "123456",
+		SequenceJournal:      "111111",
+		UserID:               "123",
+		Destination:          "001001234567892",
+		Amount:               100000,
+		TransactionType:      "internal_transfer",
+		TransactionReference: "222222",
+		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:               "success",
+		Fee:                  "0",
+		DestinationName:      "Destination Account",
+	}, transaction)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_CoreInsufficientFunds(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(100000, nil)
+
+	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, mock.Anything).
+		Return(nil, &OverbookingRejection{StatusCode: "51", Description: "Insufficient funds"})
+
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("", nil)
+	repoMock.EXPECT().FailTransaction(mock.Anything, mock.MatchedBy(func(transaction *Transaction) bool {
+		return transaction.Status == "failed" && transaction.StatusCode == "51"
+	}), mock.Anything).Return(nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInsufficientBalance).
+		SetMsg("Your balance is not enough for this transfer."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_CheckEODFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).
+		Return(nil, errors.New("some error"))
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{SequenceNumber: "123456"})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
 
-	transaction, err := svc.DoPayment(ctx, "123456")
+func TestTransferDoPaymentFailed_EODIsRunning(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).
+		Return(&CoreStatus{
+			SystemDate:    "25-03-2025",
+			Status:        "STARTED",
+			StandInStatus: "N",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{SequenceNumber: "123456"})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrEODInProgress), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_GetSequenceFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(nil, errors.New("some error"))
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{SequenceNumber: "123456"})
 
 	assert.Nil(t, transaction)
-	assert.Equal(t, pkgerror.New(codes.Internal, ErrNotifyFailed), err)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
 
 	corebankingMock.AssertExpectations(t)
 	repoMock.AssertExpectations(t)
 	mailerMock.AssertExpectations(t)
 	seqGenMock.AssertExpectations(t)
 }
+
+func TestTransferDoPaymentFailed_InvalidSequenceNumber(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "111111",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidSequenceNumber), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_GetTransactionLimitFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(nil, errors.New("some error"))
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_OutsideOperatingHours(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	// The method opens an hour from now and closes an hour later.
+	openHour := (time.Now().In(BusinessLocation).Hour() + 1) % 24
+	closeHour := (openHour + 1) % 24
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+			OpenHour:       openHour,
+			CloseHour:      closeHour,
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, ErrOutsideOperatingHours).
+		SetMsg(fmt.Sprintf("Transfers are only available from %02d:00 to %02d:00 WIB.", openHour, closeHour)), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_SequenceExpired(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+			CreatedAt:          time.Now().Add(-20 * time.Minute),
+			ExpiresAt:          time.Now().Add(-5 * time.Minute),
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrSequenceExpired).
+		SetMsg("Your transfer session has expired. Please start the transfer again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_OTPRequiredForNewDestination(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{Threshold: 50_000_000}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(false, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, ErrOTPRequired).
+		SetMsg("Please verify this transfer with the OTP sent to you."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_OTPRequiredAboveThreshold(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{Threshold: 50_000}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, ErrOTPRequired).
+		SetMsg("Please verify this transfer with the OTP sent to you."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_HasTransferredToFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(false, errors.New("unexpected error"))
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_InvalidOTP(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{Threshold: 50_000}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	authorizerMock.EXPECT().VerifyTransaction(mock.Anything, 1, "654321", "123456").
+		Return(pkgerror.New(codes.BadRequest, errors.New("invalid OTP")).
+			SetMsg("Invalid OTP. Please try again."))
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		OTPID:              1,
+		OTPCode:            "654321",
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, errors.New("invalid OTP")).
+		SetMsg("Invalid OTP. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_SequenceOwnedByAnotherUser(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             456,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+			DeviceID:           "device-1",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, ErrSequenceMismatch).
+		SetMsg("Your transfer request was rejected. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_SequenceFromAnotherDevice(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-2",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+			DeviceID:           "device-1",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, ErrSequenceMismatch).
+		SetMsg("Your transfer request was rejected. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_PayloadMismatch(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+			DeviceID:           "device-1",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             9000000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, ErrSequenceMismatch).
+		SetMsg("Your transfer request was rejected. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_InterbankSequence(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "1234567890",
+			BankCode:           "014",
+			Rail:               "BIFAST",
+			Status:             "CREATED",
+			DeviceID:           "device-1",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "1234567890",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidSequenceNumber).
+		SetMsg("Your transfer request was rejected. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_OtherTransactionTypeSequence(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "936000140000000001",
+			TransactionType:    "qris",
+			Status:             "CREATED",
+			DeviceID:           "device-1",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "936000140000000001",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidSequenceNumber).
+		SetMsg("Your transfer request was rejected. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_TransactionLimitCannotTransfer(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      100_000_000,
+			MaxDailyAmount: 50_000,
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidAmount), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentPending_PerformOverbookingFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, &Transaction{
+		SequenceNumber:  "123456",
+		UserID:          "123",
+		Destination:     "001001234567892",
+		Amount:          100000,
+		TransactionType: "internal_transfer",
+		Remarks:         "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:          "pending",
+		Fee:             "0",
+		DestinationName: "Destination Account",
+	}).Return(nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(100000, nil)
+
+	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &OverbookingInput{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Fee:                0,
+		Remark:             "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Reference:          "123456",
+	}).Return(nil, errors.New("some error"))
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrPaymentPending).
+		SetMsg("Your transfer is being processed. Please check your transaction history."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_OverbookingRejected(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, &Transaction{
+		SequenceNumber:  "123456",
+		UserID:          "123",
+		Destination:     "001001234567892",
+		Amount:          100000,
+		TransactionType: "internal_transfer",
+		Remarks:         "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:          "pending",
+		Fee:             "0",
+		DestinationName: "Destination Account",
+	}).Return(nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(100000, nil)
+
+	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &OverbookingInput{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Fee:                0,
+		Remark:             "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Reference:          "123456",
+	}).Return(nil, &OverbookingRejection{
+		StatusCode:  "05",
+		Description: "do not honor",
+		Payload:     `{"statusCode":"05","statusDescription":"do not honor"}`,
+	})
+
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("firebase-id", nil)
+	repoMock.EXPECT().FailTransaction(mock.Anything, mock.MatchedBy(func(tx *Transaction) bool {
+		return tx.Status == "failed" &&
+			tx.StatusCode == "05" &&
+			tx.CoreResponsePayload == `{"statusCode":"05","statusDescription":"do not honor"}`
+	}), mock.MatchedBy(func(outbox []*OutboxMessage) bool {
+		if len(outbox) != 2 {
+			return false
+		}
+		email, _ := outbox[0].Receipt()
+		notification, _ := outbox[1].Notification()
+		return email.Subject == "Transfer Gagal" && email.Status == "failed" &&
+			notification.Subject == "Transfer Gagal" && notification.Status == "failed" &&
+			notification.FirebaseID == "firebase-id"
+	})).
+		Return(nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_GetUserFromContextFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = context.Background()
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{SequenceNumber: "123456"})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+		SetMsg("Please login to continue."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_InsertTransactionFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(errors.New("some error"))
+	repoMock.EXPECT().UpdateSequenceStatus(mock.Anything, "123456", "CREATED").
+		Return(nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_CompleteTransactionFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, &Transaction{
+		SequenceNumber:  "123456",
+		UserID:          "123",
+		Destination:     "001001234567892",
+		Amount:          100000,
+		TransactionType: "internal_transfer",
+		Remarks:         "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:          "pending",
+		Fee:             "0",
+		DestinationName: "Destination Account",
+	}).Return(nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(100000, nil)
+
+	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &OverbookingInput{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Fee:                0,
+		Remark:             "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Reference:          "123456",
+	}).Return(&OverbookingResult{
+		JournalSequence:      "111111",
+		TransactionReference: "222222",
+	}, nil)
+
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("firebase-id", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, &Transaction{
+		SequenceNumber:       "123456",
+		SequenceJournal:      "111111",
+		UserID:               "123",
+		Destination:          "001001234567892",
+		Amount:               100000,
+		TransactionType:      "internal_transfer",
+		TransactionReference: "222222",
+		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:               "success",
+		Fee:                  "0",
+		DestinationName:      "Destination Account",
+	}, mock.Anything).Return(errors.New("some error"))
+	repoMock.EXPECT().InsertRecovery(mock.Anything, &Recovery{
+		SequenceNumber:       "123456",
+		JournalSequence:      "111111",
+		TransactionReference: "222222",
+		Status:               "OPEN",
+		LastError:            "some error",
+	}, mock.Anything).Return(errors.New("some error"))
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrTransactionNotRecorded).
+		SetMsg("Your transfer has been processed but is not recorded yet. Please check your transaction history later."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentSuccess_CompleteTransactionRecovered(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, &Transaction{
+		SequenceNumber:  "123456",
+		UserID:          "123",
+		Destination:     "001001234567892",
+		Amount:          100000,
+		TransactionType: "internal_transfer",
+		Remarks:         "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:          "pending",
+		Fee:             "0",
+		DestinationName: "Destination Account",
+	}).Return(nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(100000, nil)
+
+	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &OverbookingInput{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Fee:                0,
+		Remark:             "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Reference:          "123456",
+	}).Return(&OverbookingResult{
+		JournalSequence:      "111111",
+		TransactionReference: "222222",
+	}, nil)
+
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("firebase-id", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, &Transaction{
+		SequenceNumber:       "123456",
+		SequenceJournal:      "111111",
+		UserID:               "123",
+		Destination:          "001001234567892",
+		Amount:               100000,
+		TransactionType:      "internal_transfer",
+		TransactionReference: "222222",
+		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:               "success",
+		Fee:                  "0",
+		DestinationName:      "Destination Account",
+	}, mock.Anything).Return(errors.New("some error"))
+	repoMock.EXPECT().InsertRecovery(mock.Anything, &Recovery{
+		SequenceNumber:       "123456",
+		JournalSequence:      "111111",
+		TransactionReference: "222222",
+		Status:               "OPEN",
+		LastError:            "some error",
+	}, mock.Anything).Return(nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "success", transaction.Status)
+	assert.Equal(t, "222222", transaction.TransactionReference)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentSuccess_SequenceAlreadyCompleted(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "COMPLETED",
+		}, nil)
+	repoMock.EXPECT().GetTransactionBySequenceNumber(mock.Anything, "123456").
+		Return(&Transaction{
+			SequenceNumber:       "123456",
+			SequenceJournal:      "111111",
+			UserID:               "123",
+			Destination:          "001001234567892",
+			Amount:               100000,
+			TransactionType:      "internal_transfer",
+			TransactionReference: "222222",
+			Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+			Fee:                  "0",
+			DestinationName:      "Destination Account",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Transaction{
+		SequenceNumber:       "123456",
+		SequenceJournal:      "111111",
+		UserID:               "123",
+		Destination:          "001001234567892",
+		Amount:               100000,
+		TransactionType:      "internal_transfer",
+		TransactionReference: "222222",
+		Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Fee:                  "0",
+		DestinationName:      "Destination Account",
+	}, transaction)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_SequencePending(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "PENDING",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Conflict, ErrPaymentInProgress).
+		SetMsg("Your transfer is being processed. Please check your transaction history."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_SequenceFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "FAILED",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrPaymentFailed).
+		SetMsg("Your transfer has failed. Please create a new transfer."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_AcquireSequenceAlreadyProcessed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(ErrSequenceAlreadyProcessed)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Conflict, ErrPaymentInProgress).
+		SetMsg("Your transfer is being processed. Please check your transaction history."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_IdempotencyKeyReused(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetSequenceByIdempotencyKey(mock.Anything, 123, "key-1").
+		Return(&Sequence{
+			SequenceNumber: "654321",
+			Status:         "COMPLETED",
+			IdempotencyKey: "key-1",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		IdempotencyKey:     "key-1",
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Conflict, ErrIdempotencyKeyReused).
+		SetMsg("The idempotency key has been used for another transfer."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestTransferInquiryFailed_DailyLimitExceeded(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(199_950_000, nil)
+
+	sequence, err := svc.Inquiry(ctx, &Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	})
+
+	assert.Nil(t, sequence)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrDailyLimitExceeded).
+		SetMsg("You have reached your daily transfer limit. Please try again tomorrow."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestTransferDoPaymentFailed_DailyLimitExceeded(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&CoreStatus{
+		SystemDate:    "25-03-2025",
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetTransactionLimit(mock.Anything).
+		Return(&Limits{
+			MinAmount:      1,
+			MaxAmount:      50_000_000,
+			MaxDailyAmount: 200_000_000,
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().HasTransferredTo(mock.Anything, "123", "001001234567892").
+		Return(true, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmount(mock.Anything, "123", mock.Anything, mock.Anything).
+		Return(200_050_000, nil)
+	repoMock.EXPECT().FailTransaction(mock.Anything, mock.Anything, []*OutboxMessage(nil)).
+		Return(nil)
+
+	transaction, err := svc.DoPayment(ctx, &PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrDailyLimitExceeded).
+		SetMsg("You have reached your daily transfer limit. Please try again tomorrow."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestTransferHistorySuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().GetTransactions(mock.Anything, &TransactionFilter{
+		UserID: "123",
+		Status: "success",
+		Limit:  3,
+	}).Return([]*Transaction{
+		{ID: 30, Status: "success"},
+		{ID: 20, Status: "success"},
+		{ID: 10, Status: "success"},
+	}, nil)
+
+	page, err := svc.History(ctx, &TransactionFilter{
+		UserID: "456",
+		Status: "success",
+		Limit:  2,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &TransactionPage{
+		Transactions: []*Transaction{
+			{ID: 30, Status: "success"},
+			{ID: 20, Status: "success"},
+		},
+		NextCursor: 20,
+	}, page)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestTransferHistorySuccess_LastPage(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().GetTransactions(mock.Anything, &TransactionFilter{
+		UserID: "123",
+		Cursor: 20,
+		Limit:  DefaultHistoryLimit + 1,
+	}).Return([]*Transaction{
+		{ID: 10, Status: "success"},
+	}, nil)
+
+	page, err := svc.History(ctx, &TransactionFilter{Cursor: 20})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &TransactionPage{
+		Transactions: []*Transaction{
+			{ID: 10, Status: "success"},
+		},
+	}, page)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestTransferHistoryFailed_GetUserFromContextFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+	)
+
+	page, err := svc.History(context.Background(), &TransactionFilter{})
+
+	assert.Nil(t, page)
+	assert.Equal(t, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+		SetMsg("Please login to continue."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestTransferHistoryFailed_InvalidFilter(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	page, err := svc.History(ctx, &TransactionFilter{MinAmount: 100, MaxAmount: 10})
+
+	assert.Nil(t, page)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidTransactionFilter).
+		SetMsg("Your history filter is invalid. Please check the dates and amounts."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestTransferHistoryFailed_GetTransactionsFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().GetTransactions(mock.Anything, mock.Anything).
+		Return(nil, errors.New("unexpected error"))
+
+	page, err := svc.History(ctx, &TransactionFilter{})
+
+	assert.Nil(t, page)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestTransferDetailSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().GetTransactionByReference(mock.Anything, "123", "REF123").
+		Return(&Transaction{
+			ID:                   1,
+			SequenceNumber:       "123456",
+			UserID:               "123",
+			Destination:          "001001234567892",
+			Amount:               100000,
+			TransactionType:      "internal_transfer",
+			TransactionReference: "REF123",
+			SequenceJournal:      "JRN123",
+			Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+			Status:               "success",
+			Fee:                  "0",
+			DestinationName:      "Destination Account",
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "COMPLETED",
+		}, nil)
+
+	detail, err := svc.Detail(ctx, "REF123")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &TransactionDetail{
+		Transaction: &Transaction{
+			ID:                   1,
+			SequenceNumber:       "123456",
+			UserID:               "123",
+			Destination:          "001001234567892",
+			Amount:               100000,
+			TransactionType:      "internal_transfer",
+			TransactionReference: "REF123",
+			SequenceJournal:      "JRN123",
+			Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+			Status:               "success",
+			Fee:                  "0",
+			DestinationName:      "Destination Account",
+		},
+		Sequence: &Sequence{
+			SequenceNumber:     "123456",
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "COMPLETED",
+		},
+	}, detail)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestTransferDetailFailed_GetUserFromContextFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+	)
+
+	detail, err := svc.Detail(context.Background(), "REF123")
+
+	assert.Nil(t, detail)
+	assert.Equal(t, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+		SetMsg("Please login to continue."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestTransferDetailFailed_TransactionNotFound(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().GetTransactionByReference(mock.Anything, "123", "REF123").
+		Return(nil, ErrTransactionNotFound)
+
+	detail, err := svc.Detail(ctx, "REF123")
+
+	assert.Nil(t, detail)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrTransactionNotFound).
+		SetMsg("Transaction not found."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestTransferDetailFailed_GetSequenceFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().GetTransactionByReference(mock.Anything, "123", "REF123").
+		Return(&Transaction{
+			ID:                   1,
+			SequenceNumber:       "123456",
+			UserID:               "123",
+			Destination:          "001001234567892",
+			Amount:               100000,
+			TransactionType:      "internal_transfer",
+			TransactionReference: "REF123",
+			SequenceJournal:      "JRN123",
+			Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+			Status:               "success",
+			Fee:                  "0",
+			DestinationName:      "Destination Account",
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(nil, errors.New("unexpected error"))
+
+	detail, err := svc.Detail(ctx, "REF123")
+
+	assert.Nil(t, detail)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestTransferResendReceiptSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().GetTransactionByReference(mock.Anything, "123", "REF123").
+		Return(&Transaction{
+			ID:                   1,
+			SequenceNumber:       "123456",
+			UserID:               "123",
+			Destination:          "001001234567892",
+			Amount:               100000,
+			TransactionType:      "internal_transfer",
+			TransactionReference: "REF123",
+			SequenceJournal:      "JRN123",
+			Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+			Status:               "success",
+			Fee:                  "0",
+			DestinationName:      "Destination Account",
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "COMPLETED",
+		}, nil)
+
+	mailerMock.EXPECT().SendReceipt(mock.Anything, &EmailData{
+		Subject:            "Transfer Berhasil",
+		Recipient:          "olivia@gmail.com",
+		Amount:             100000,
+		Fee:                0,
+		SourceName:         "Olivia Rodrigo",
+		SourceAccount:      "001001234567891",
+		DestinationName:    "Destination Account",
+		DestinationAccount: "001001234567892",
+		DestinationBank:    constant.BankYayaCompanyName,
+		TransactionRef:     "REF123",
+		Note:               "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+		Status:             "success",
+	}).Return(nil)
+
+	err := svc.ResendReceipt(ctx, "REF123")
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+}
+
+func TestTransferResendReceiptFailed_ReceiptUnavailable(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().GetTransactionByReference(mock.Anything, "123", "REF123").
+		Return(&Transaction{
+			ID:                   1,
+			SequenceNumber:       "123456",
+			UserID:               "123",
+			Destination:          "001001234567892",
+			Amount:               100000,
+			TransactionType:      "internal_transfer",
+			TransactionReference: "REF123",
+			SequenceJournal:      "JRN123",
+			Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+			Status:               "failed",
+			Fee:                  "0",
+			DestinationName:      "Destination Account",
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "COMPLETED",
+		}, nil)
+
+	err := svc.ResendReceipt(ctx, "REF123")
+
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrReceiptUnavailable).
+		SetMsg("A receipt is only available for a successful transfer."), err)
+
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+}
+
+func TestTransferResendReceiptFailed_SendReceiptFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().GetTransactionByReference(mock.Anything, "123", "REF123").
+		Return(&Transaction{
+			ID:                   1,
+			SequenceNumber:       "123456",
+			UserID:               "123",
+			Destination:          "001001234567892",
+			Amount:               100000,
+			TransactionType:      "internal_transfer",
+			TransactionReference: "REF123",
+			SequenceJournal:      "JRN123",
+			Remarks:              "TRF 001001234567891 001001234567892 BNKYAYA 123456",
+			Status:               "success",
+			Fee:                  "0",
+			DestinationName:      "Destination Account",
+		}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&Sequence{
+			SequenceNumber:     "123456",
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			SourceName:         "Olivia Rodrigo",
+			Status:             "COMPLETED",
+		}, nil)
+
+	mailerMock.EXPECT().SendReceipt(mock.Anything, mock.Anything).
+		Return(errors.New("unexpected error"))
+
+	err := svc.ResendReceipt(ctx, "REF123")
+
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrSendEmailFailed), err)
+
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+}
+
+func TestReconcileSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = context.Background()
+	)
+
+	repoMock.EXPECT().GetOpenRecoveries(mock.Anything, 50).Return([]*Recovery{
+		{
+			ID:                   1,
+			TransactionID:        10,
+			SequenceNumber:       "123456",
+			JournalSequence:      "111111",
+			TransactionReference: "222222",
+			Status:               "OPEN",
+			LastError:            "some error",
+		},
+		{
+			ID:                   2,
+			TransactionID:        20,
+			SequenceNumber:       "654321",
+			JournalSequence:      "333333",
+			TransactionReference: "444444",
+			Status:               "OPEN",
+			Attempts:             9,
+			LastError:            "some error",
+		},
+	}, nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, &Transaction{
+		ID:                   10,
+		SequenceNumber:       "123456",
+		SequenceJournal:      "111111",
+		TransactionReference: "222222",
+		Status:               "success",
+	}, []*OutboxMessage(nil)).Return(nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, &Transaction{
+		ID:                   20,
+		SequenceNumber:       "654321",
+		SequenceJournal:      "333333",
+		TransactionReference: "444444",
+		Status:               "success",
+	}, []*OutboxMessage(nil)).Return(errors.New("connection refused"))
+	repoMock.EXPECT().UpdateRecovery(mock.Anything, mock.MatchedBy(func(r *Recovery) bool {
+		return r.ID == 1 && r.Status == RecoveryResolved && r.Attempts == 1 && !r.ResolvedAt.IsZero()
+	})).Return(nil)
+	repoMock.EXPECT().UpdateRecovery(mock.Anything, mock.MatchedBy(func(r *Recovery) bool {
+		return r.ID == 2 && r.Status == RecoveryManual && r.Attempts == 10 && r.LastError == "connection refused"
+	})).Return(nil)
+	repoMock.EXPECT().GetPendingTransactions(mock.Anything, mock.Anything, 50).Return(nil, nil)
+
+	err := svc.Reconcile(ctx)
+
+	assert.NoError(t, err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestReconcileSuccess_RecoveryAlreadyFinished(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = context.Background()
+	)
+
+	repoMock.EXPECT().GetOpenRecoveries(mock.Anything, 50).Return([]*Recovery{
+		{
+			ID:                   1,
+			TransactionID:        10,
+			SequenceNumber:       "123456",
+			JournalSequence:      "111111",
+			TransactionReference: "222222",
+			Status:               "OPEN",
+		},
+	}, nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, mock.Anything, []*OutboxMessage(nil)).
+		Return(ErrTransactionFinished)
+	repoMock.EXPECT().UpdateRecovery(mock.Anything, mock.MatchedBy(func(r *Recovery) bool {
+		return r.ID == 1 && r.Status == RecoveryResolved && r.Resolution == "transaction already finished"
+	})).Return(nil)
+	repoMock.EXPECT().GetPendingTransactions(mock.Anything, mock.Anything, 50).Return(nil, nil)
+
+	err := svc.Reconcile(ctx)
+
+	assert.NoError(t, err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestReconcileSuccess_SettlePending(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = context.Background()
+		user            = &ctxt.User{
+			ID:    123,
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		}
+	)
+
+	repoMock.EXPECT().GetOpenRecoveries(mock.Anything, 50).Return(nil, nil)
+	repoMock.EXPECT().GetPendingTransactions(mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return before.Before(time.Now().Add(-9 * time.Minute))
+	}), 50).Return([]*Transaction{
+		{ID: 10, SequenceNumber: "111111", UserID: "123", Amount: 100000, Status: "pending"},
+		{ID: 20, SequenceNumber: "222222", UserID: "123", Amount: 200000, Status: "pending"},
+		{ID: 30, SequenceNumber: "333333", UserID: "123", Amount: 300000, Status: "pending"},
+	}, nil)
+
+	corebankingMock.EXPECT().GetPostingStatus(mock.Anything, "111111").Return(&OverbookingResult{
+		JournalSequence:      "J111111",
+		TransactionReference: "R111111",
+	}, nil)
+	corebankingMock.EXPECT().GetPostingStatus(mock.Anything, "222222").Return(nil, ErrPostingNotFound)
+	corebankingMock.EXPECT().GetPostingStatus(mock.Anything, "333333").Return(nil, errors.New("timeout"))
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "111111").Return(&Sequence{SequenceNumber: "111111", UserID: 123}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "222222").Return(&Sequence{SequenceNumber: "222222", UserID: 123}, nil)
+	repoMock.EXPECT().GetSequence(mock.Anything, "333333").Return(&Sequence{SequenceNumber: "333333", UserID: 123}, nil)
+	repoMock.EXPECT().GetUser(mock.Anything, 123).Return(user, nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).Return("", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, mock.MatchedBy(func(tx *Transaction) bool {
+		return tx.ID == 10 && tx.Status == "success" && tx.SequenceJournal == "J111111" && tx.TransactionReference == "R111111"
+	}), mock.MatchedBy(func(outbox []*OutboxMessage) bool {
+		return len(outbox) == 1 && outbox[0].Kind == OutboxReceipt
+	})).Return(nil)
+	repoMock.EXPECT().FailTransaction(mock.Anything, mock.MatchedBy(func(tx *Transaction) bool {
+		return tx.ID == 20 && tx.Status == "failed" && tx.CoreResponsePayload == ErrPostingNotFound.Error()
+	}), mock.MatchedBy(func(outbox []*OutboxMessage) bool {
+		return len(outbox) == 1 && outbox[0].Kind == OutboxReceipt
+	})).Return(nil)
+
+	err := svc.Reconcile(ctx)
+
+	assert.NoError(t, err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestReconcileFailed_GetOpenRecoveriesFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = context.Background()
+	)
+
+	repoMock.EXPECT().GetOpenRecoveries(mock.Anything, 50).Return(nil, errors.New("connection refused"))
+
+	err := svc.Reconcile(ctx)
+
+	assert.Error(t, err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestDispatchOutboxSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = context.Background()
+	)
+
+	receipt := NewReceiptMessage(10, &EmailData{Recipient: "olivia@gmail.com", TransactionRef: "222222"})
+	receipt.ID = 1
+	notification := NewNotificationMessage(10, &Notification{Subject: "Transfer Berhasil", Amount: 100000, Status: "success"})
+	notification.ID = 2
+
+	repoMock.EXPECT().AcquireOutbox(mock.Anything, mock.Anything, mock.Anything, 100).
+		Return([]*OutboxMessage{receipt, notification}, nil)
+	mailerMock.EXPECT().SendReceipt(mock.Anything, &EmailData{Recipient: "olivia@gmail.com", TransactionRef: "222222"}).
+		Return(nil)
+	notifierMock.EXPECT().Notify(mock.Anything, &Notification{Subject: "Transfer Berhasil", Amount: 100000, Status: "success"}).
+		Return(errors.New("firebase unavailable"))
+	repoMock.EXPECT().UpdateOutbox(mock.Anything, mock.MatchedBy(func(m *OutboxMessage) bool {
+		return m.ID == 1 && m.Status == OutboxSent && m.Attempts == 1 && !m.SentAt.IsZero()
+	})).Return(nil)
+	repoMock.EXPECT().UpdateOutbox(mock.Anything, mock.MatchedBy(func(m *OutboxMessage) bool {
+		return m.ID == 2 && m.Status == OutboxPending && m.Attempts == 1 && m.LastError == "firebase unavailable"
+	})).Return(nil)
+
+	err := svc.DispatchOutbox(ctx)
+
+	assert.NoError(t, err)
+
+	repoMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestDispatchOutboxFailed_AcquireOutboxFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireOutbox(mock.Anything, mock.Anything, mock.Anything, 100).
+		Return(nil, errors.New("connection refused"))
+
+	err := svc.DispatchOutbox(ctx)
+
+	assert.Error(t, err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestPurgeExpiredSequencesSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = context.Background()
+	)
+
+	repoMock.EXPECT().DeleteExpiredSequences(mock.Anything, mock.Anything).Return(3, nil)
+
+	err := svc.PurgeExpiredSequences(ctx)
+
+	assert.NoError(t, err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestPurgeExpiredSequencesFailed_DeleteExpiredSequencesFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		mailerMock      = NewMockReceiptMailer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		notifierMock    = NewMockNotifier(t)
+		authorizerMock  = NewMockTransactionAuthorizer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, seqGenMock, mailerMock, notifierMock, SequenceValidity{}, authorizerMock, StepUpPolicy{}, FeePolicy{})
+		ctx             = context.Background()
+	)
+
+	repoMock.EXPECT().DeleteExpiredSequences(mock.Anything, mock.Anything).Return(0, errors.New("connection refused"))
+
+	err := svc.PurgeExpiredSequences(ctx)
+
+	assert.Error(t, err)
+
+	repoMock.AssertExpectations(t)
+}
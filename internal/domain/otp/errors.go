@@ -17,4 +17,10 @@ var (
 
 	// ErrOTPExpired indicates that the one-time password (OTP) has expired and is no longer valid.
 	ErrOTPExpired = errors.New("OTP expired")
+
+	// ErrOTPNotFound is returned by the repository when no OTP has the given ID.
+	ErrOTPNotFound = errors.New("OTP not found")
+
+	// ErrOTPAttemptsExceeded indicates that the OTP has been invalidated after too many verification attempts.
+	ErrOTPAttemptsExceeded = errors.New("OTP attempts exceeded")
 )
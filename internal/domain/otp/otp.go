@@ -10,8 +10,9 @@ import (
 type Purpose string
 
 const (
-	PurposeLogin    Purpose = "login"
-	PurposeRegister Purpose = "register"
+	PurposeLogin       Purpose = "login"
+	PurposeRegister    Purpose = "register"
+	PurposeTransaction Purpose = "transaction"
 )
 
 // NewPurpose creates a new Purpose from the given string.
@@ -53,6 +54,8 @@ type OTP struct {
 	Purpose    Purpose
 	Channel    Channel
 	User       *User
+	Reference  string
+	Attempts   int
 	CreatedAt  time.Time
 	ExpiredAt  time.Time
 	VerifiedAt time.Time
@@ -69,18 +72,30 @@ func (o *OTP) IsVerified() bool {
 }
 
 // Equal compares two OTP instances and returns true if their ID,
-// Code, Purpose, Channel, and UserID match.
+// Code, Purpose, Channel, Reference, and UserID match.
 func (o *OTP) Equal(other *OTP) bool {
 	if o != nil && other != nil {
 		return o.ID == other.ID &&
 			o.Code == other.Code &&
 			o.Purpose == other.Purpose &&
 			o.Channel == other.Channel &&
+			o.Reference == other.Reference &&
 			o.User.Equal(other.User)
 	}
 	return false
 }
 
+// Authorizes returns true if the OTP was issued to the user for the transaction
+// with the given reference and carries the given code.
+func (o *OTP) Authorizes(userID int, code, reference string) bool {
+	return o.Purpose == PurposeTransaction &&
+		o.Reference != "" &&
+		o.Reference == reference &&
+		o.Code == code &&
+		o.User != nil &&
+		o.User.ID == userID
+}
+
 // User represents a user with an ID, name, email, and phone.
 type User struct {
 	ID    int
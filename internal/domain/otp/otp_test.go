@@ -84,3 +84,20 @@ func TestUserEqual(t *testing.T) {
 	assert.False(t, user1.Equal(user2))
 	assert.False(t, user2.Equal(nil))
 }
+
+func TestOTPAuthorizes(t *testing.T) {
+	otp := &OTP{
+		Code:      "123456",
+		Purpose:   PurposeTransaction,
+		User:      &User{ID: 1},
+		Reference: "seq-1",
+	}
+
+	assert.True(t, otp.Authorizes(1, "123456", "seq-1"))
+	assert.False(t, otp.Authorizes(2, "123456", "seq-1"))
+	assert.False(t, otp.Authorizes(1, "654321", "seq-1"))
+	assert.False(t, otp.Authorizes(1, "123456", "seq-2"))
+
+	otp.Purpose = PurposeLogin
+	assert.False(t, otp.Authorizes(1, "123456", "seq-1"))
+}
@@ -10,10 +10,14 @@ type Repository interface {
 
 	// Get retrieves an OTP entity from the data storage by its ID.
 	// It takes a context and an ID as parameters.
-	// Returns the OTP entity if found,
-	// or an error if the operation fails or the OTP is not found.
+	// Returns the OTP entity if found, ErrOTPNotFound if the OTP is not found,
+	// or an error if the operation fails.
 	Get(ctx context.Context, id int) (*OTP, error)
 
+	// AddAttempt atomically counts a verification attempt of the OTP with the ID.
+	// Returns ErrOTPAttemptsExceeded if the OTP has already used up the maxAttempts attempts.
+	AddAttempt(ctx context.Context, id, maxAttempts int) error
+
 	// Update updates an existing OTP entity in the data storage.
 	// Returns an error if the operation fails.
 	Update(ctx context.Context, otp *OTP) error
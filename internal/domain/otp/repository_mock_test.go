@@ -21,6 +21,54 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddAttempt provides a mock function with given fields: ctx, id, maxAttempts
+func (_m *MockRepository) AddAttempt(ctx context.Context, id int, maxAttempts int) error {
+	ret := _m.Called(ctx, id, maxAttempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, maxAttempts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_AddAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAttempt'
+type MockRepository_AddAttempt_Call struct {
+	*mock.Call
+}
+
+// AddAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - maxAttempts int
+func (_e *MockRepository_Expecter) AddAttempt(ctx interface{}, id interface{}, maxAttempts interface{}) *MockRepository_AddAttempt_Call {
+	return &MockRepository_AddAttempt_Call{Call: _e.mock.On("AddAttempt", ctx, id, maxAttempts)}
+}
+
+func (_c *MockRepository_AddAttempt_Call) Run(run func(ctx context.Context, id int, maxAttempts int)) *MockRepository_AddAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_AddAttempt_Call) Return(_a0 error) *MockRepository_AddAttempt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_AddAttempt_Call) RunAndReturn(run func(context.Context, int, int) error) *MockRepository_AddAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function with given fields: ctx, id
 func (_m *MockRepository) Get(ctx context.Context, id int) (*OTP, error) {
 	ret := _m.Called(ctx, id)
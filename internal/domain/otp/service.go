@@ -2,6 +2,7 @@ package otp
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.bankyaya.org/app/backend/internal/pkg/codes"
@@ -14,6 +15,8 @@ const (
 	domainName = "otp"
 	otpLength  = 6
 	otpExpiry  = 5 * time.Minute
+	// maxAttempts is how many times a transaction OTP can be verified before it is invalidated.
+	maxAttempts = 3
 )
 
 type Service struct {
@@ -37,7 +40,9 @@ func NewService(
 	}
 }
 
-func (s *Service) Send(ctx context.Context, purpose Purpose, channel Channel) (*OTP, error) {
+// Send generates an OTP for the purpose and sends it through the channel.
+// The reference binds the OTP to a single action, e.g. the sequence number of a transfer.
+func (s *Service) Send(ctx context.Context, purpose Purpose, channel Channel, reference string) (*OTP, error) {
 	user, ok := ctxt.UserFromContext(ctx)
 	if !ok {
 		s.log.DomainUsecase(domainName, "Send").Error(ctxt.ErrUserFromContext)
@@ -64,6 +69,7 @@ func (s *Service) Send(ctx context.Context, purpose Purpose, channel Channel) (*
 			Email: user.Email,
 			Phone: user.Phone,
 		},
+		Reference: reference,
 		CreatedAt: createdAt,
 		ExpiredAt: expiredAt,
 	}
@@ -92,32 +98,92 @@ func (s *Service) Verify(ctx context.Context, in *OTP) error {
 
 	in.User = NewUser(user.ID, user.Name, user.Email, user.Phone)
 
-	otp, err := s.repo.Get(ctx, in.ID)
+	otp, err := s.get(ctx, "Verify", in.ID)
 	if err != nil {
-		s.log.DomainUsecase(domainName, "Verify").Error(err)
-		return pkgerror.New(codes.Internal, ErrGeneral)
+		return err
 	}
 	if !otp.Equal(in) {
 		s.log.DomainUsecase(domainName, "Verify").Error(ErrInvalidOTP)
 		return pkgerror.New(codes.BadRequest, ErrInvalidOTP).
 			SetMsg("Invalid OTP. Please try again.")
 	}
+
+	return s.consume(ctx, "Verify", otp)
+}
+
+// VerifyTransaction verifies the transaction OTP of the user for the transaction with the reference,
+// so an OTP issued for one transfer cannot authorize another.
+func (s *Service) VerifyTransaction(ctx context.Context, id int, code, reference string) error {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "VerifyTransaction").Error(ctxt.ErrUserFromContext)
+		return pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser)
+	}
+
+	otp, err := s.get(ctx, "VerifyTransaction", id)
+	if err != nil {
+		return err
+	}
+	if otp.User == nil || otp.User.ID != user.ID {
+		s.log.DomainUsecase(domainName, "VerifyTransaction").Errorf("otp (%v) of another user: %v", id, ErrInvalidOTP)
+		return pkgerror.New(codes.BadRequest, ErrInvalidOTP).
+			SetMsg("Invalid OTP. Please try again.")
+	}
+
+	// Every attempt is counted before the code is compared, so the code cannot be guessed.
+	err = s.repo.AddAttempt(ctx, id, maxAttempts)
+	if errors.Is(err, ErrOTPAttemptsExceeded) {
+		s.log.DomainUsecase(domainName, "VerifyTransaction").Errorf("otp (%v): %v", id, err)
+		return pkgerror.New(codes.BadRequest, ErrOTPAttemptsExceeded).
+			SetMsg("Too many wrong OTP attempts. Please request a new OTP.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "VerifyTransaction").Error(err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	if !otp.Authorizes(user.ID, code, reference) {
+		s.log.DomainUsecase(domainName, "VerifyTransaction").Errorf("otp (%v) for reference (%v): %v", id, reference, ErrInvalidOTP)
+		return pkgerror.New(codes.BadRequest, ErrInvalidOTP).
+			SetMsg("Invalid OTP. Please try again.")
+	}
+
+	return s.consume(ctx, "VerifyTransaction", otp)
+}
+
+// get retrieves the OTP with the ID, an unknown ID is reported as an invalid OTP.
+func (s *Service) get(ctx context.Context, usecase string, id int) (*OTP, error) {
+	otp, err := s.repo.Get(ctx, id)
+	if errors.Is(err, ErrOTPNotFound) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("otp (%v): %v", id, err)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidOTP).
+			SetMsg("Invalid OTP. Please try again.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Error(err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return otp, nil
+}
+
+// consume marks the matched OTP as verified, unless it has been used or has expired.
+func (s *Service) consume(ctx context.Context, usecase string, otp *OTP) error {
 	if otp.IsVerified() {
-		s.log.DomainUsecase(domainName, "Verify").Error(ErrOTPAlreadyUsed)
+		s.log.DomainUsecase(domainName, usecase).Error(ErrOTPAlreadyUsed)
 		return pkgerror.New(codes.BadRequest, ErrOTPAlreadyUsed).
 			SetMsg("OTP already used. Please try again.")
 	}
 	if otp.IsExpired(time.Now()) {
-		s.log.DomainUsecase(domainName, "Verify").Error(ErrOTPExpired)
+		s.log.DomainUsecase(domainName, usecase).Error(ErrOTPExpired)
 		return pkgerror.New(codes.BadRequest, ErrOTPExpired).
 			SetMsg("OTP expired. Please try again.")
 	}
 
 	otp.VerifiedAt = time.Now()
 
-	err = s.repo.Update(ctx, otp)
+	err := s.repo.Update(ctx, otp)
 	if err != nil {
-		s.log.DomainUsecase(domainName, "Verify").Error(err)
+		s.log.DomainUsecase(domainName, usecase).Error(err)
 		return pkgerror.New(codes.Internal, ErrGeneral)
 	}
 
@@ -38,7 +38,7 @@ func TestSendSuccess(t *testing.T) {
 	repoMock.EXPECT().Save(mock.Anything, mock.Anything).
 		Return(nil)
 
-	res, err := svc.Send(ctx, PurposeLogin, ChannelEmail)
+	res, err := svc.Send(ctx, PurposeLogin, ChannelEmail, "")
 
 	assert.NoError(t, err)
 	assert.Equal(t, "123456", res.Code)
@@ -59,7 +59,7 @@ func TestSend_GetUserFromContextFailed(t *testing.T) {
 		ctx           = context.Background()
 	)
 
-	res, err := svc.Send(ctx, PurposeLogin, ChannelEmail)
+	res, err := svc.Send(ctx, PurposeLogin, ChannelEmail, "")
 
 	assert.Nil(t, res)
 	assert.Equal(t, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser), err)
@@ -87,7 +87,7 @@ func TestSend_GenerateOTPFailed(t *testing.T) {
 	generatorMock.EXPECT().Generate(otpLength).
 		Return("", errors.New("failed to generate otp"))
 
-	res, err := svc.Send(ctx, PurposeLogin, ChannelEmail)
+	res, err := svc.Send(ctx, PurposeLogin, ChannelEmail, "")
 
 	assert.Nil(t, res)
 	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
@@ -148,3 +148,187 @@ func TestVerifySuccess(t *testing.T) {
 	repoMock.AssertExpectations(t)
 	senderMock.AssertExpectations(t)
 }
+
+func TestVerifyTransactionSuccess(t *testing.T) {
+	var (
+		repoMock      = NewMockRepository(t)
+		generatorMock = NewMockGenerator(t)
+		senderMock    = NewMockSender(t)
+		svc           = NewService(logger.New(), repoMock, generatorMock, senderMock)
+		ctx           = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+			Phone: "081234567890",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 1).
+		Return(&OTP{
+			ID:        1,
+			Code:      "123456",
+			Purpose:   PurposeTransaction,
+			Channel:   ChannelSMS,
+			User:      &User{ID: 123},
+			Reference: "seq-1",
+			ExpiredAt: time.Now().Add(time.Minute),
+		}, nil)
+	repoMock.EXPECT().AddAttempt(mock.Anything, 1, 3).
+		Return(nil)
+	repoMock.EXPECT().Update(mock.Anything, mock.MatchedBy(func(otp *OTP) bool {
+		return otp.IsVerified()
+	})).Return(nil)
+
+	err := svc.VerifyTransaction(ctx, 1, "123456", "seq-1")
+
+	assert.NoError(t, err)
+
+	generatorMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	senderMock.AssertExpectations(t)
+}
+
+func TestVerifyTransactionFailed_AnotherReference(t *testing.T) {
+	var (
+		repoMock      = NewMockRepository(t)
+		generatorMock = NewMockGenerator(t)
+		senderMock    = NewMockSender(t)
+		svc           = NewService(logger.New(), repoMock, generatorMock, senderMock)
+		ctx           = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+			Phone: "081234567890",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 1).
+		Return(&OTP{
+			ID:        1,
+			Code:      "123456",
+			Purpose:   PurposeTransaction,
+			Channel:   ChannelSMS,
+			User:      &User{ID: 123},
+			Reference: "seq-1",
+			ExpiredAt: time.Now().Add(time.Minute),
+		}, nil)
+	repoMock.EXPECT().AddAttempt(mock.Anything, 1, 3).
+		Return(nil)
+
+	err := svc.VerifyTransaction(ctx, 1, "123456", "seq-2")
+
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidOTP).
+		SetMsg("Invalid OTP. Please try again."), err)
+
+	generatorMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	senderMock.AssertExpectations(t)
+}
+
+func TestVerifyTransactionFailed_AlreadyUsed(t *testing.T) {
+	var (
+		repoMock      = NewMockRepository(t)
+		generatorMock = NewMockGenerator(t)
+		senderMock    = NewMockSender(t)
+		svc           = NewService(logger.New(), repoMock, generatorMock, senderMock)
+		ctx           = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+			Phone: "081234567890",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 1).
+		Return(&OTP{
+			ID:         1,
+			Code:       "123456",
+			Purpose:    PurposeTransaction,
+			Channel:    ChannelSMS,
+			User:       &User{ID: 123},
+			Reference:  "seq-1",
+			ExpiredAt:  time.Now().Add(time.Minute),
+			VerifiedAt: time.Now(),
+		}, nil)
+	repoMock.EXPECT().AddAttempt(mock.Anything, 1, 3).
+		Return(nil)
+
+	err := svc.VerifyTransaction(ctx, 1, "123456", "seq-1")
+
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrOTPAlreadyUsed).
+		SetMsg("OTP already used. Please try again."), err)
+
+	generatorMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	senderMock.AssertExpectations(t)
+}
+
+func TestVerifyTransactionFailed_AttemptsExceeded(t *testing.T) {
+	var (
+		repoMock      = NewMockRepository(t)
+		generatorMock = NewMockGenerator(t)
+		senderMock    = NewMockSender(t)
+		svc           = NewService(logger.New(), repoMock, generatorMock, senderMock)
+		ctx           = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+			Phone: "081234567890",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 1).
+		Return(&OTP{
+			ID:        1,
+			Code:      "123456",
+			Purpose:   PurposeTransaction,
+			Channel:   ChannelSMS,
+			User:      &User{ID: 123},
+			Reference: "seq-1",
+			Attempts:  3,
+			ExpiredAt: time.Now().Add(time.Minute),
+		}, nil)
+	repoMock.EXPECT().AddAttempt(mock.Anything, 1, 3).
+		Return(ErrOTPAttemptsExceeded)
+
+	err := svc.VerifyTransaction(ctx, 1, "123456", "seq-1")
+
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrOTPAttemptsExceeded).
+		SetMsg("Too many wrong OTP attempts. Please request a new OTP."), err)
+
+	generatorMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	senderMock.AssertExpectations(t)
+}
+
+func TestVerifyTransactionFailed_OTPNotFound(t *testing.T) {
+	var (
+		repoMock      = NewMockRepository(t)
+		generatorMock = NewMockGenerator(t)
+		senderMock    = NewMockSender(t)
+		svc           = NewService(logger.New(), repoMock, generatorMock, senderMock)
+		ctx           = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+			Phone: "081234567890",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 99).
+		Return(nil, ErrOTPNotFound)
+
+	err := svc.VerifyTransaction(ctx, 99, "123456", "seq-1")
+
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidOTP).
+		SetMsg("Invalid OTP. Please try again."), err)
+
+	generatorMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	senderMock.AssertExpectations(t)
+}
@@ -0,0 +1,29 @@
+package paymentrequest
+
+import "errors"
+
+var (
+	// ErrGeneral indicates a general error.
+	ErrGeneral = errors.New("something went wrong")
+
+	// ErrUnauthenticatedUser indicates that the user is not authenticated.
+	ErrUnauthenticatedUser = errors.New("unauthenticated user")
+
+	// ErrInvalidRequest is returned when the amount, the note or the validity of the request is invalid.
+	ErrInvalidRequest = errors.New("invalid payment request")
+
+	// ErrPayerNotFound is returned when no user is registered with the payer's phone number.
+	ErrPayerNotFound = errors.New("payer not found")
+
+	// ErrSelfRequest is returned when the user requests money from themselves.
+	ErrSelfRequest = errors.New("cannot request money from yourself")
+
+	// ErrRequestNotFound is returned when the requested payment request cannot be found.
+	ErrRequestNotFound = errors.New("payment request not found")
+
+	// ErrNotPayer is returned when the user answers a request that is not addressed to them.
+	ErrNotPayer = errors.New("user is not the payer")
+
+	// ErrRequestNotPayable is returned when the request has been answered or has expired.
+	ErrRequestNotPayable = errors.New("payment request not payable")
+)
@@ -0,0 +1,9 @@
+package paymentrequest
+
+import "context"
+
+// Notifier sends payment request notifications to users.
+type Notifier interface {
+	// Notify sends a payment request notification to the specified user.
+	Notify(ctx context.Context, notification *Notification) error
+}
@@ -0,0 +1,139 @@
+// Package paymentrequest provides structures and functionality for requesting money from another user.
+// The requester asks a payer for an amount, and the payer either pays it through an intrabank
+// transfer pre-filled from the request or declines it before the request expires.
+package paymentrequest
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+const (
+	// StatusPending represents a request that is waiting for the payer.
+	StatusPending = "PENDING"
+	// StatusPaid represents a request that has been paid by the payer.
+	StatusPaid = "PAID"
+	// StatusDeclined represents a request that has been declined by the payer.
+	StatusDeclined = "DECLINED"
+	// StatusExpired represents a request that was not answered before its expiry.
+	StatusExpired = "EXPIRED"
+)
+
+const (
+	// DefaultValidity is how long a request stays payable when the requester does not choose.
+	DefaultValidity = 7 * 24 * time.Hour
+	// MaxValidity is the longest a request can stay payable.
+	MaxValidity = 30 * 24 * time.Hour
+	// maxNoteLength is the maximum number of characters of the note.
+	maxNoteLength = 100
+)
+
+// User represents the requester or the payer of a payment request.
+type User struct {
+	ID            int
+	CIF           string
+	Name          string
+	Email         string
+	AccountNumber string
+	FirebaseID    string
+}
+
+// PaymentRequest represents a request for money from the requester to the payer.
+type PaymentRequest struct {
+	ID                   int64
+	Requester            *User
+	Payer                *User
+	DestinationAccount   string
+	Amount               intrabank.Money
+	Note                 string
+	Status               string
+	ExpiresAt            time.Time
+	TransactionReference string
+	RespondedAt          time.Time
+	CreatedAt            time.Time
+}
+
+// Valid checks if the request can be created at the given time.
+// The request must expire after the given time and at most MaxValidity later.
+func (r *PaymentRequest) Valid(now time.Time) bool {
+	return r.Amount > 0 &&
+		utf8.RuneCountInString(r.Note) <= maxNoteLength &&
+		r.ExpiresAt.After(now) &&
+		!r.ExpiresAt.After(now.Add(MaxValidity))
+}
+
+// Expired checks if the pending request has passed its expiry at the given time.
+func (r *PaymentRequest) Expired(now time.Time) bool {
+	return r.Status == StatusPending && !now.Before(r.ExpiresAt)
+}
+
+// Refresh marks the pending request as expired once it has passed its expiry,
+// so the state shown to the users does not wait for the expiry worker.
+func (r *PaymentRequest) Refresh(now time.Time) {
+	if r.Expired(now) {
+		r.Status = StatusExpired
+	}
+}
+
+// Payable checks if the payer can still pay or decline the request at the given time.
+func (r *PaymentRequest) Payable(now time.Time) bool {
+	return r.Status == StatusPending && now.Before(r.ExpiresAt)
+}
+
+// Sequence returns the inquiry sequence of the transfer that pays the request from the source account.
+func (r *PaymentRequest) Sequence(sourceAccount, channel string) *intrabank.Sequence {
+	return &intrabank.Sequence{
+		Amount:             r.Amount,
+		SourceAccount:      sourceAccount,
+		DestinationAccount: r.DestinationAccount,
+		Channel:            channel,
+	}
+}
+
+// IdempotencyKey returns the payment idempotency key of the request,
+// so the request can never be paid twice.
+func (r *PaymentRequest) IdempotencyKey() string {
+	return intrabank.InternalIdempotencyKey("payment-request-" + strconv.FormatInt(r.ID, 10))
+}
+
+// Pay marks the request as paid by the transaction with the reference.
+func (r *PaymentRequest) Pay(transactionReference string, paidAt time.Time) {
+	r.Status = StatusPaid
+	r.TransactionReference = transactionReference
+	r.RespondedAt = paidAt
+}
+
+// Decline marks the request as declined by the payer.
+func (r *PaymentRequest) Decline(declinedAt time.Time) {
+	r.Status = StatusDeclined
+	r.RespondedAt = declinedAt
+}
+
+// Notification represents a payment request notification.
+// Requested notifications are sent to the payer, paid and declined notifications to the requester.
+type Notification struct {
+	FirebaseID string
+	Subject    string
+	Name       string
+	Amount     intrabank.Money
+	Status     string
+}
+
+// String returns the string representation of the Notification based on its Status field.
+func (n *Notification) String() string {
+	switch n.Status {
+	case StatusPaid:
+		return fmt.Sprintf("Permintaan dana sebesar %s telah dibayar oleh %s.", n.Amount.Rupiah(), n.Name)
+	case StatusDeclined:
+		return fmt.Sprintf("Permintaan dana sebesar %s ditolak oleh %s.", n.Amount.Rupiah(), n.Name)
+	}
+	return fmt.Sprintf(
+		"%s meminta dana sebesar %s. Buka aplikasi untuk membayar atau menolak permintaan ini.",
+		n.Name,
+		n.Amount.Rupiah(),
+	)
+}
@@ -0,0 +1,69 @@
+package paymentrequest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentRequestValid(t *testing.T) {
+	now := time.Date(2025, 3, 25, 3, 0, 0, 0, time.UTC)
+
+	valid := func() *PaymentRequest {
+		return &PaymentRequest{
+			Amount:    100000,
+			Note:      "Dinner",
+			ExpiresAt: now.Add(DefaultValidity),
+		}
+	}
+	assert.True(t, valid().Valid(now))
+
+	r := valid()
+	r.Amount = 0
+	assert.False(t, r.Valid(now))
+
+	r = valid()
+	r.Note = strings.Repeat("a", 101)
+	assert.False(t, r.Valid(now))
+
+	r = valid()
+	r.ExpiresAt = now
+	assert.False(t, r.Valid(now))
+
+	r = valid()
+	r.ExpiresAt = now.Add(MaxValidity + time.Second)
+	assert.False(t, r.Valid(now))
+}
+
+func TestPaymentRequestRefresh(t *testing.T) {
+	now := time.Date(2025, 3, 25, 3, 0, 0, 0, time.UTC)
+
+	r := &PaymentRequest{Status: StatusPending, ExpiresAt: now.Add(time.Minute)}
+	r.Refresh(now)
+	assert.Equal(t, StatusPending, r.Status)
+	assert.True(t, r.Payable(now))
+
+	r = &PaymentRequest{Status: StatusPending, ExpiresAt: now}
+	r.Refresh(now)
+	assert.Equal(t, StatusExpired, r.Status)
+	assert.False(t, r.Payable(now))
+
+	r = &PaymentRequest{Status: StatusPaid, ExpiresAt: now.Add(-time.Minute)}
+	r.Refresh(now)
+	assert.Equal(t, StatusPaid, r.Status)
+	assert.False(t, r.Payable(now))
+}
+
+func TestNotificationString(t *testing.T) {
+	n := &Notification{Name: "Olivia Rodrigo", Amount: 100000, Status: StatusPending}
+	assert.Equal(t, "Olivia Rodrigo meminta dana sebesar Rp100000. "+
+		"Buka aplikasi untuk membayar atau menolak permintaan ini.", n.String())
+
+	n.Status = StatusPaid
+	assert.Equal(t, "Permintaan dana sebesar Rp100000 telah dibayar oleh Olivia Rodrigo.", n.String())
+
+	n.Status = StatusDeclined
+	assert.Equal(t, "Permintaan dana sebesar Rp100000 ditolak oleh Olivia Rodrigo.", n.String())
+}
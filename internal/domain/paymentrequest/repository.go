@@ -0,0 +1,40 @@
+package paymentrequest
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines methods for managing payment request persistence.
+type Repository interface {
+	// Insert inserts a payment request into the persistence repository.
+	// Returns an error if the operation fails.
+	Insert(ctx context.Context, request *PaymentRequest) error
+
+	// Get retrieves the payment request by its ID if the user is its requester or its payer.
+	// The requester and the payer are loaded together with the request.
+	// Returns ErrRequestNotFound if the user takes no part in a request with the ID.
+	Get(ctx context.Context, userID int, id int64) (*PaymentRequest, error)
+
+	// ListSent retrieves the payment requests made by the requester, newest first.
+	// Returns the requests and an error if retrieval fails.
+	ListSent(ctx context.Context, requesterID int) ([]*PaymentRequest, error)
+
+	// ListReceived retrieves the payment requests addressed to the payer, newest first.
+	// Returns the requests and an error if retrieval fails.
+	ListReceived(ctx context.Context, payerID int) ([]*PaymentRequest, error)
+
+	// Decline atomically declines the payer's request if it is still pending and not expired at the given time,
+	// and the payer has not started a payment with the payment idempotency key of the request.
+	// Returns ErrRequestNotPayable if the request has been answered, has expired or is being paid.
+	Decline(ctx context.Context, payerID int, id int64, paymentKey string, now time.Time) error
+
+	// MarkPaid stores the transaction that paid the request if the request is pending or has expired,
+	// a request can expire while it is being paid.
+	// Returns ErrRequestNotPayable if the request has been answered.
+	MarkPaid(ctx context.Context, request *PaymentRequest) error
+
+	// ExpireDue marks the pending requests that have expired at the given time.
+	// Returns the number of expired requests and an error if the operation fails.
+	ExpireDue(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,416 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package paymentrequest
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// Decline provides a mock function with given fields: ctx, payerID, id, paymentKey, now
+func (_m *MockRepository) Decline(ctx context.Context, payerID int, id int64, paymentKey string, now time.Time) error {
+	ret := _m.Called(ctx, payerID, id, paymentKey, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decline")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64, string, time.Time) error); ok {
+		r0 = rf(ctx, payerID, id, paymentKey, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Decline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decline'
+type MockRepository_Decline_Call struct {
+	*mock.Call
+}
+
+// Decline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payerID int
+//   - id int64
+//   - paymentKey string
+//   - now time.Time
+func (_e *MockRepository_Expecter) Decline(ctx interface{}, payerID interface{}, id interface{}, paymentKey interface{}, now interface{}) *MockRepository_Decline_Call {
+	return &MockRepository_Decline_Call{Call: _e.mock.On("Decline", ctx, payerID, id, paymentKey, now)}
+}
+
+func (_c *MockRepository_Decline_Call) Run(run func(ctx context.Context, payerID int, id int64, paymentKey string, now time.Time)) *MockRepository_Decline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Decline_Call) Return(_a0 error) *MockRepository_Decline_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Decline_Call) RunAndReturn(run func(context.Context, int, int64, string, time.Time) error) *MockRepository_Decline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExpireDue provides a mock function with given fields: ctx, now
+func (_m *MockRepository) ExpireDue(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireDue")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ExpireDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireDue'
+type MockRepository_ExpireDue_Call struct {
+	*mock.Call
+}
+
+// ExpireDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockRepository_Expecter) ExpireDue(ctx interface{}, now interface{}) *MockRepository_ExpireDue_Call {
+	return &MockRepository_ExpireDue_Call{Call: _e.mock.On("ExpireDue", ctx, now)}
+}
+
+func (_c *MockRepository_ExpireDue_Call) Run(run func(ctx context.Context, now time.Time)) *MockRepository_ExpireDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ExpireDue_Call) Return(_a0 int64, _a1 error) *MockRepository_ExpireDue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ExpireDue_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockRepository_ExpireDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, userID, id
+func (_m *MockRepository) Get(ctx context.Context, userID int, id int64) (*PaymentRequest, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) (*PaymentRequest, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) *PaymentRequest); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int64
+func (_e *MockRepository_Expecter) Get(ctx interface{}, userID interface{}, id interface{}) *MockRepository_Get_Call {
+	return &MockRepository_Get_Call{Call: _e.mock.On("Get", ctx, userID, id)}
+}
+
+func (_c *MockRepository_Get_Call) Run(run func(ctx context.Context, userID int, id int64)) *MockRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Get_Call) Return(_a0 *PaymentRequest, _a1 error) *MockRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_Get_Call) RunAndReturn(run func(context.Context, int, int64) (*PaymentRequest, error)) *MockRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function with given fields: ctx, request
+func (_m *MockRepository) Insert(ctx context.Context, request *PaymentRequest) error {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *PaymentRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type MockRepository_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *PaymentRequest
+func (_e *MockRepository_Expecter) Insert(ctx interface{}, request interface{}) *MockRepository_Insert_Call {
+	return &MockRepository_Insert_Call{Call: _e.mock.On("Insert", ctx, request)}
+}
+
+func (_c *MockRepository_Insert_Call) Run(run func(ctx context.Context, request *PaymentRequest)) *MockRepository_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*PaymentRequest))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Insert_Call) Return(_a0 error) *MockRepository_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Insert_Call) RunAndReturn(run func(context.Context, *PaymentRequest) error) *MockRepository_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListReceived provides a mock function with given fields: ctx, payerID
+func (_m *MockRepository) ListReceived(ctx context.Context, payerID int) ([]*PaymentRequest, error) {
+	ret := _m.Called(ctx, payerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReceived")
+	}
+
+	var r0 []*PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*PaymentRequest, error)); ok {
+		return rf(ctx, payerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*PaymentRequest); ok {
+		r0 = rf(ctx, payerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, payerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListReceived_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReceived'
+type MockRepository_ListReceived_Call struct {
+	*mock.Call
+}
+
+// ListReceived is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payerID int
+func (_e *MockRepository_Expecter) ListReceived(ctx interface{}, payerID interface{}) *MockRepository_ListReceived_Call {
+	return &MockRepository_ListReceived_Call{Call: _e.mock.On("ListReceived", ctx, payerID)}
+}
+
+func (_c *MockRepository_ListReceived_Call) Run(run func(ctx context.Context, payerID int)) *MockRepository_ListReceived_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListReceived_Call) Return(_a0 []*PaymentRequest, _a1 error) *MockRepository_ListReceived_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListReceived_Call) RunAndReturn(run func(context.Context, int) ([]*PaymentRequest, error)) *MockRepository_ListReceived_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSent provides a mock function with given fields: ctx, requesterID
+func (_m *MockRepository) ListSent(ctx context.Context, requesterID int) ([]*PaymentRequest, error) {
+	ret := _m.Called(ctx, requesterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSent")
+	}
+
+	var r0 []*PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*PaymentRequest, error)); ok {
+		return rf(ctx, requesterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*PaymentRequest); ok {
+		r0 = rf(ctx, requesterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, requesterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_ListSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSent'
+type MockRepository_ListSent_Call struct {
+	*mock.Call
+}
+
+// ListSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - requesterID int
+func (_e *MockRepository_Expecter) ListSent(ctx interface{}, requesterID interface{}) *MockRepository_ListSent_Call {
+	return &MockRepository_ListSent_Call{Call: _e.mock.On("ListSent", ctx, requesterID)}
+}
+
+func (_c *MockRepository_ListSent_Call) Run(run func(ctx context.Context, requesterID int)) *MockRepository_ListSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListSent_Call) Return(_a0 []*PaymentRequest, _a1 error) *MockRepository_ListSent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_ListSent_Call) RunAndReturn(run func(context.Context, int) ([]*PaymentRequest, error)) *MockRepository_ListSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkPaid provides a mock function with given fields: ctx, request
+func (_m *MockRepository) MarkPaid(ctx context.Context, request *PaymentRequest) error {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPaid")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *PaymentRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_MarkPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkPaid'
+type MockRepository_MarkPaid_Call struct {
+	*mock.Call
+}
+
+// MarkPaid is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *PaymentRequest
+func (_e *MockRepository_Expecter) MarkPaid(ctx interface{}, request interface{}) *MockRepository_MarkPaid_Call {
+	return &MockRepository_MarkPaid_Call{Call: _e.mock.On("MarkPaid", ctx, request)}
+}
+
+func (_c *MockRepository_MarkPaid_Call) Run(run func(ctx context.Context, request *PaymentRequest)) *MockRepository_MarkPaid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*PaymentRequest))
+	})
+	return _c
+}
+
+func (_c *MockRepository_MarkPaid_Call) Return(_a0 error) *MockRepository_MarkPaid_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_MarkPaid_Call) RunAndReturn(run func(context.Context, *PaymentRequest) error) *MockRepository_MarkPaid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,331 @@
+package paymentrequest
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+const (
+	domainName      = "payment_request"
+	requestSubject  = "Permintaan Dana"
+	paidSubject     = "Permintaan Dana Dibayar"
+	declinedSubject = "Permintaan Dana Ditolak"
+)
+
+// Service handles the payment requests between users.
+type Service struct {
+	log        *logger.Logger
+	repo       Repository
+	users      UserDirectory
+	transferer Transferer
+	notifier   Notifier
+}
+
+// NewService creates a new instance of Service.
+func NewService(
+	log *logger.Logger,
+	repo Repository,
+	users UserDirectory,
+	transferer Transferer,
+	notifier Notifier,
+) *Service {
+	return &Service{
+		log:        log,
+		repo:       repo,
+		users:      users,
+		transferer: transferer,
+		notifier:   notifier,
+	}
+}
+
+// Create asks the user registered with the phone number to pay the request into the requester's account.
+// The request expires after DefaultValidity when it has no expiry.
+func (s *Service) Create(ctx context.Context, payerPhoneNumber string, request *PaymentRequest) (*PaymentRequest, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Create").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	now := time.Now()
+	if request.ExpiresAt.IsZero() {
+		request.ExpiresAt = now.Add(DefaultValidity)
+	}
+	if !request.Valid(now) {
+		s.log.DomainUsecase(domainName, "Create").Error(ErrInvalidRequest)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidRequest).
+			SetMsg("Your payment request is invalid. Please check the amount, the note and the expiry.")
+	}
+
+	payer, err := s.users.GetUserByPhoneNumber(ctx, payerPhoneNumber)
+	if errors.Is(err, ErrPayerNotFound) {
+		s.log.DomainUsecase(domainName, "Create").Errorf("GetUserByPhoneNumber: %v", err)
+		return nil, pkgerror.New(codes.NotFound, ErrPayerNotFound).
+			SetMsg("No Bank Yaya user is registered with this phone number.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Create").Errorf("GetUserByPhoneNumber: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if payer.ID == user.ID {
+		s.log.DomainUsecase(domainName, "Create").Error(ErrSelfRequest)
+		return nil, pkgerror.New(codes.BadRequest, ErrSelfRequest).
+			SetMsg("You cannot request money from yourself.")
+	}
+
+	requester, err := s.users.GetUser(ctx, user.ID)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Create").Errorf("GetUser: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	request.Requester = requester
+	request.Payer = payer
+	request.DestinationAccount = requester.AccountNumber
+	request.Status = StatusPending
+
+	err = s.repo.Insert(ctx, request)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Create").Errorf("Insert: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	s.notify(ctx, "Create", payer, &Notification{
+		Subject: requestSubject,
+		Name:    requester.Name,
+		Amount:  request.Amount,
+		Status:  StatusPending,
+	})
+
+	return request, nil
+}
+
+// ListSent returns the payment requests made by the authenticated user.
+func (s *Service) ListSent(ctx context.Context) ([]*PaymentRequest, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "ListSent").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	requests, err := s.repo.ListSent(ctx, user.ID)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "ListSent").Errorf("ListSent: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	refresh(requests, time.Now())
+
+	return requests, nil
+}
+
+// ListReceived returns the payment requests addressed to the authenticated user.
+func (s *Service) ListReceived(ctx context.Context) ([]*PaymentRequest, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "ListReceived").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	requests, err := s.repo.ListReceived(ctx, user.ID)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "ListReceived").Errorf("ListReceived: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	refresh(requests, time.Now())
+
+	return requests, nil
+}
+
+// Get returns the payment request if the authenticated user is its requester or its payer.
+func (s *Service) Get(ctx context.Context, id int64) (*PaymentRequest, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Get").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+	return s.get(ctx, "Get", user.ID, id)
+}
+
+// Inquiry runs the intrabank inquiry of the transfer that pays the request from the payer's source account.
+// The destination and the amount are taken from the request.
+func (s *Service) Inquiry(ctx context.Context, id int64, sourceAccount, channel string) (*intrabank.Sequence, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	request, err := s.payable(ctx, "Inquiry", user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sequence, err := s.transferer.Inquiry(ctx, request.Sequence(sourceAccount, channel))
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("Inquiry: %v", err)
+		return nil, err
+	}
+
+	return sequence, nil
+}
+
+// Pay pays the request with the sequence created by Inquiry.
+// The OTP of the input is passed on to the intrabank payment, which decides whether it is required.
+func (s *Service) Pay(ctx context.Context, id int64, in *intrabank.PaymentInput) (*PaymentRequest, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Pay").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	request, err := s.payable(ctx, "Pay", user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The payment is bound to the inquired payload, so a sequence for another destination
+	// or amount is rejected by the intrabank payment.
+	transaction, err := s.transferer.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     in.SequenceNumber,
+		SourceAccount:      in.SourceAccount,
+		DestinationAccount: request.DestinationAccount,
+		Amount:             request.Amount,
+		IdempotencyKey:     request.IdempotencyKey(),
+		OTPID:              in.OTPID,
+		OTPCode:            in.OTPCode,
+	})
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Pay").Errorf("DoPayment: %v", err)
+		return nil, err
+	}
+	request.Pay(transaction.TransactionReference, time.Now())
+
+	// The money has moved, so a failure to store it is only logged. The request cannot be declined
+	// once its payment has started, and paying again with the same sequence returns this payment and records it.
+	if err := s.repo.MarkPaid(ctx, request); err != nil {
+		s.log.DomainUsecase(domainName, "Pay").Errorf("request (%v) MarkPaid: %v", request.ID, err)
+	}
+
+	s.notify(ctx, "Pay", request.Requester, &Notification{
+		Subject: paidSubject,
+		Name:    request.Payer.Name,
+		Amount:  request.Amount,
+		Status:  StatusPaid,
+	})
+
+	return request, nil
+}
+
+// Decline declines the request addressed to the authenticated user.
+func (s *Service) Decline(ctx context.Context, id int64) (*PaymentRequest, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Decline").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	request, err := s.payable(ctx, "Decline", user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The payer may pay the request from another device between the read and the decline.
+	now := time.Now()
+	err = s.repo.Decline(ctx, user.ID, id, request.IdempotencyKey(), now)
+	if errors.Is(err, ErrRequestNotPayable) {
+		s.log.DomainUsecase(domainName, "Decline").Errorf("Decline: %v", err)
+		return nil, pkgerror.New(codes.BadRequest, ErrRequestNotPayable).
+			SetMsg("This payment request has already been answered or has expired.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Decline").Errorf("Decline: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	request.Decline(now)
+
+	s.notify(ctx, "Decline", request.Requester, &Notification{
+		Subject: declinedSubject,
+		Name:    request.Payer.Name,
+		Amount:  request.Amount,
+		Status:  StatusDeclined,
+	})
+
+	return request, nil
+}
+
+// ExpireDue marks the pending requests that have expired.
+// It is called periodically by the background worker.
+func (s *Service) ExpireDue(ctx context.Context) error {
+	n, err := s.repo.ExpireDue(ctx, time.Now())
+	if err != nil {
+		s.log.DomainUsecase(domainName, "ExpireDue").Errorf("ExpireDue: %v", err)
+		return err
+	}
+	if n > 0 {
+		s.log.DomainUsecase(domainName, "ExpireDue").Infof("expired %d payment requests", n)
+	}
+	return nil
+}
+
+func (s *Service) get(ctx context.Context, usecase string, userID int, id int64) (*PaymentRequest, error) {
+	request, err := s.repo.Get(ctx, userID, id)
+	if errors.Is(err, ErrRequestNotFound) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Get: %v", err)
+		return nil, pkgerror.New(codes.NotFound, ErrRequestNotFound).
+			SetMsg("Payment request not found.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Get: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	request.Refresh(time.Now())
+	return request, nil
+}
+
+// payable returns the request if the user is its payer and it can still be answered.
+func (s *Service) payable(ctx context.Context, usecase string, userID int, id int64) (*PaymentRequest, error) {
+	request, err := s.get(ctx, usecase, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.Payer.ID != userID {
+		s.log.DomainUsecase(domainName, usecase).Error(ErrNotPayer)
+		return nil, pkgerror.New(codes.Forbidden, ErrNotPayer).
+			SetMsg("Only the user asked for the money can answer this payment request.")
+	}
+	if !request.Payable(time.Now()) {
+		s.log.DomainUsecase(domainName, usecase).Error(ErrRequestNotPayable)
+		return nil, pkgerror.New(codes.BadRequest, ErrRequestNotPayable).
+			SetMsg("This payment request has already been answered or has expired.")
+	}
+	return request, nil
+}
+
+// notify sends the notification to the user, a failure does not affect the request.
+func (s *Service) notify(ctx context.Context, usecase string, user *User, notification *Notification) {
+	notification.FirebaseID = user.FirebaseID
+	if err := s.notifier.Notify(ctx, notification); err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Notify: %v", err)
+	}
+}
+
+// refresh marks the requests that have passed their expiry as expired.
+func refresh(requests []*PaymentRequest, now time.Time) {
+	for _, r := range requests {
+		r.Refresh(now)
+	}
+}
@@ -0,0 +1,453 @@
+package paymentrequest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+var (
+	requester = &User{
+		ID:            123,
+		CIF:           "1234567",
+		Name:          "Olivia Rodrigo",
+		Email:         "olivia@gmail.com",
+		AccountNumber: "001001234567891",
+		FirebaseID:    "firebase-olivia",
+	}
+	payer = &User{
+		ID:            456,
+		CIF:           "7654321",
+		Name:          "Taylor Swift",
+		Email:         "taylor@gmail.com",
+		AccountNumber: "001001234567892",
+		FirebaseID:    "firebase-taylor",
+	}
+)
+
+func pendingRequest() *PaymentRequest {
+	return &PaymentRequest{
+		ID:                 7,
+		Requester:          requester,
+		Payer:              payer,
+		DestinationAccount: requester.AccountNumber,
+		Amount:             100000,
+		Note:               "Dinner",
+		Status:             StatusPending,
+		ExpiresAt:          time.Now().Add(time.Hour),
+	}
+}
+
+func TestCreateSuccess(t *testing.T) {
+	var (
+		repoMock     = NewMockRepository(t)
+		usersMock    = NewMockUserDirectory(t)
+		notifierMock = NewMockNotifier(t)
+		svc          = NewService(logger.New(), repoMock, usersMock, NewMockTransferer(t), notifierMock)
+		ctx          = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	usersMock.EXPECT().GetUserByPhoneNumber(mock.Anything, "081234567890").
+		Return(payer, nil)
+	usersMock.EXPECT().GetUser(mock.Anything, 123).
+		Return(requester, nil)
+
+	repoMock.EXPECT().Insert(mock.Anything, mock.Anything).
+		Return(nil)
+
+	notifierMock.EXPECT().Notify(mock.Anything, &Notification{
+		FirebaseID: "firebase-taylor",
+		Subject:    "Permintaan Dana",
+		Name:       "Olivia Rodrigo",
+		Amount:     100000,
+		Status:     StatusPending,
+	}).Return(nil)
+
+	request, err := svc.Create(ctx, "081234567890", &PaymentRequest{Amount: 100000, Note: "Dinner"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, requester, request.Requester)
+	assert.Equal(t, payer, request.Payer)
+	assert.Equal(t, "001001234567891", request.DestinationAccount)
+	assert.Equal(t, StatusPending, request.Status)
+	assert.WithinDuration(t, time.Now().Add(DefaultValidity), request.ExpiresAt, time.Minute)
+
+	repoMock.AssertExpectations(t)
+	usersMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestCreateSuccess_NotifyFailed(t *testing.T) {
+	var (
+		repoMock     = NewMockRepository(t)
+		usersMock    = NewMockUserDirectory(t)
+		notifierMock = NewMockNotifier(t)
+		svc          = NewService(logger.New(), repoMock, usersMock, NewMockTransferer(t), notifierMock)
+		ctx          = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	usersMock.EXPECT().GetUserByPhoneNumber(mock.Anything, "081234567890").
+		Return(payer, nil)
+	usersMock.EXPECT().GetUser(mock.Anything, 123).
+		Return(requester, nil)
+
+	repoMock.EXPECT().Insert(mock.Anything, mock.Anything).
+		Return(nil)
+
+	notifierMock.EXPECT().Notify(mock.Anything, mock.Anything).
+		Return(errors.New("unexpected error"))
+
+	request, err := svc.Create(ctx, "081234567890", &PaymentRequest{Amount: 100000})
+
+	assert.Nil(t, err)
+	assert.Equal(t, StatusPending, request.Status)
+
+	repoMock.AssertExpectations(t)
+	usersMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestCreateFailed_GetUserFromContextFailed(t *testing.T) {
+	var (
+		svc = NewService(logger.New(), NewMockRepository(t), NewMockUserDirectory(t), NewMockTransferer(t), NewMockNotifier(t))
+	)
+
+	request, err := svc.Create(context.Background(), "081234567890", &PaymentRequest{Amount: 100000})
+
+	assert.Nil(t, request)
+	assert.Equal(t, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+		SetMsg("Please login to continue."), err)
+}
+
+func TestCreateFailed_InvalidRequest(t *testing.T) {
+	var (
+		svc = NewService(logger.New(), NewMockRepository(t), NewMockUserDirectory(t), NewMockTransferer(t), NewMockNotifier(t))
+		ctx = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	request, err := svc.Create(ctx, "081234567890", &PaymentRequest{
+		Amount:    100000,
+		ExpiresAt: time.Now().Add(MaxValidity + time.Hour),
+	})
+
+	assert.Nil(t, request)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidRequest).
+		SetMsg("Your payment request is invalid. Please check the amount, the note and the expiry."), err)
+}
+
+func TestCreateFailed_PayerNotFound(t *testing.T) {
+	var (
+		usersMock = NewMockUserDirectory(t)
+		svc       = NewService(logger.New(), NewMockRepository(t), usersMock, NewMockTransferer(t), NewMockNotifier(t))
+		ctx       = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	usersMock.EXPECT().GetUserByPhoneNumber(mock.Anything, "081234567890").
+		Return(nil, ErrPayerNotFound)
+
+	request, err := svc.Create(ctx, "081234567890", &PaymentRequest{Amount: 100000})
+
+	assert.Nil(t, request)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrPayerNotFound).
+		SetMsg("No Bank Yaya user is registered with this phone number."), err)
+
+	usersMock.AssertExpectations(t)
+}
+
+func TestCreateFailed_SelfRequest(t *testing.T) {
+	var (
+		usersMock = NewMockUserDirectory(t)
+		svc       = NewService(logger.New(), NewMockRepository(t), usersMock, NewMockTransferer(t), NewMockNotifier(t))
+		ctx       = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	usersMock.EXPECT().GetUserByPhoneNumber(mock.Anything, "081234567891").
+		Return(requester, nil)
+
+	request, err := svc.Create(ctx, "081234567891", &PaymentRequest{Amount: 100000})
+
+	assert.Nil(t, request)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrSelfRequest).
+		SetMsg("You cannot request money from yourself."), err)
+
+	usersMock.AssertExpectations(t)
+}
+
+func TestListReceivedSuccess_RefreshesExpired(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockUserDirectory(t), NewMockTransferer(t), NewMockNotifier(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 456})
+		expired  = pendingRequest()
+	)
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+	repoMock.EXPECT().ListReceived(mock.Anything, 456).
+		Return([]*PaymentRequest{pendingRequest(), expired}, nil)
+
+	requests, err := svc.ListReceived(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, StatusPending, requests[0].Status)
+	assert.Equal(t, StatusExpired, requests[1].Status)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestListSentFailed(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockUserDirectory(t), NewMockTransferer(t), NewMockNotifier(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	repoMock.EXPECT().ListSent(mock.Anything, 123).
+		Return(nil, errors.New("unexpected error"))
+
+	requests, err := svc.ListSent(ctx)
+
+	assert.Nil(t, requests)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetFailed_RequestNotFound(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockUserDirectory(t), NewMockTransferer(t), NewMockNotifier(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(7)).
+		Return(nil, ErrRequestNotFound)
+
+	request, err := svc.Get(ctx, 7)
+
+	assert.Nil(t, request)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrRequestNotFound).
+		SetMsg("Payment request not found."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestInquirySuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		svc            = NewService(logger.New(), repoMock, NewMockUserDirectory(t), transfererMock, NewMockNotifier(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 456})
+		sequence       = &intrabank.Sequence{SequenceNumber: "123456"}
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 456, int64(7)).
+		Return(pendingRequest(), nil)
+
+	transfererMock.EXPECT().Inquiry(mock.Anything, &intrabank.Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567892",
+		DestinationAccount: "001001234567891",
+		Channel:            "MOBILE",
+	}).Return(sequence, nil)
+
+	result, err := svc.Inquiry(ctx, 7, "001001234567892", "MOBILE")
+
+	assert.Nil(t, err)
+	assert.Equal(t, sequence, result)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestInquiryFailed_NotPayer(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockUserDirectory(t), NewMockTransferer(t), NewMockNotifier(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 123})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(7)).
+		Return(pendingRequest(), nil)
+
+	result, err := svc.Inquiry(ctx, 7, "001001234567891", "MOBILE")
+
+	assert.Nil(t, result)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, ErrNotPayer).
+		SetMsg("Only the user asked for the money can answer this payment request."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestPaySuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, NewMockUserDirectory(t), transfererMock, notifierMock)
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 456})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 456, int64(7)).
+		Return(pendingRequest(), nil)
+	repoMock.EXPECT().MarkPaid(mock.Anything, mock.MatchedBy(func(r *PaymentRequest) bool {
+		return r.Status == StatusPaid && r.TransactionReference == "REF001"
+	})).Return(nil)
+
+	transfererMock.EXPECT().DoPayment(mock.Anything, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567892",
+		DestinationAccount: "001001234567891",
+		Amount:             100000,
+		IdempotencyKey:     "internal:payment-request-7",
+		OTPID:              1,
+		OTPCode:            "123456",
+	}).Return(&intrabank.Transaction{TransactionReference: "REF001"}, nil)
+
+	notifierMock.EXPECT().Notify(mock.Anything, &Notification{
+		FirebaseID: "firebase-olivia",
+		Subject:    "Permintaan Dana Dibayar",
+		Name:       "Taylor Swift",
+		Amount:     100000,
+		Status:     StatusPaid,
+	}).Return(nil)
+
+	request, err := svc.Pay(ctx, 7, &intrabank.PaymentInput{
+		SequenceNumber: "123456",
+		SourceAccount:  "001001234567892",
+		// The destination and the amount are always taken from the request.
+		DestinationAccount: "001001234567899",
+		Amount:             1,
+		OTPID:              1,
+		OTPCode:            "123456",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, StatusPaid, request.Status)
+	assert.Equal(t, "REF001", request.TransactionReference)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestPayFailed_Expired(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockUserDirectory(t), NewMockTransferer(t), NewMockNotifier(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 456})
+		request  = pendingRequest()
+	)
+	request.ExpiresAt = time.Now().Add(-time.Minute)
+
+	repoMock.EXPECT().Get(mock.Anything, 456, int64(7)).
+		Return(request, nil)
+
+	result, err := svc.Pay(ctx, 7, &intrabank.PaymentInput{SequenceNumber: "123456"})
+
+	assert.Nil(t, result)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrRequestNotPayable).
+		SetMsg("This payment request has already been answered or has expired."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestPayFailed_DoPaymentFailed(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		svc            = NewService(logger.New(), repoMock, NewMockUserDirectory(t), transfererMock, NewMockNotifier(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 456})
+		paymentErr     = pkgerror.New(codes.Forbidden, intrabank.ErrOTPRequired)
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 456, int64(7)).
+		Return(pendingRequest(), nil)
+
+	transfererMock.EXPECT().DoPayment(mock.Anything, mock.Anything).
+		Return(nil, paymentErr)
+
+	request, err := svc.Pay(ctx, 7, &intrabank.PaymentInput{SequenceNumber: "123456"})
+
+	assert.Nil(t, request)
+	assert.Equal(t, paymentErr, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestDeclineSuccess(t *testing.T) {
+	var (
+		repoMock     = NewMockRepository(t)
+		notifierMock = NewMockNotifier(t)
+		svc          = NewService(logger.New(), repoMock, NewMockUserDirectory(t), NewMockTransferer(t), notifierMock)
+		ctx          = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 456})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 456, int64(7)).
+		Return(pendingRequest(), nil)
+	repoMock.EXPECT().Decline(mock.Anything, 456, int64(7), "internal:payment-request-7", mock.Anything).
+		Return(nil)
+
+	notifierMock.EXPECT().Notify(mock.Anything, &Notification{
+		FirebaseID: "firebase-olivia",
+		Subject:    "Permintaan Dana Ditolak",
+		Name:       "Taylor Swift",
+		Amount:     100000,
+		Status:     StatusDeclined,
+	}).Return(nil)
+
+	request, err := svc.Decline(ctx, 7)
+
+	assert.Nil(t, err)
+	assert.Equal(t, StatusDeclined, request.Status)
+	assert.False(t, request.RespondedAt.IsZero())
+
+	repoMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestDeclineFailed_AnsweredConcurrently(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockUserDirectory(t), NewMockTransferer(t), NewMockNotifier(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{ID: 456})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 456, int64(7)).
+		Return(pendingRequest(), nil)
+	repoMock.EXPECT().Decline(mock.Anything, 456, int64(7), "internal:payment-request-7", mock.Anything).
+		Return(ErrRequestNotPayable)
+
+	request, err := svc.Decline(ctx, 7)
+
+	assert.Nil(t, request)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrRequestNotPayable).
+		SetMsg("This payment request has already been answered or has expired."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestExpireDueFailed(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockUserDirectory(t), NewMockTransferer(t), NewMockNotifier(t))
+	)
+
+	repoMock.EXPECT().ExpireDue(mock.Anything, mock.Anything).
+		Return(0, errors.New("unexpected error"))
+
+	err := svc.ExpireDue(context.Background())
+
+	assert.EqualError(t, err, "unexpected error")
+
+	repoMock.AssertExpectations(t)
+}
@@ -0,0 +1,16 @@
+package paymentrequest
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// Transferer runs intrabank transfers on behalf of the user in the context.
+type Transferer interface {
+	// Inquiry validates the transfer and creates its payable sequence.
+	Inquiry(ctx context.Context, seq *intrabank.Sequence) (*intrabank.Sequence, error)
+
+	// DoPayment pays the sequence and returns the resulting transaction.
+	DoPayment(ctx context.Context, in *intrabank.PaymentInput) (*intrabank.Transaction, error)
+}
@@ -0,0 +1,14 @@
+package paymentrequest
+
+import "context"
+
+// UserDirectory looks up the registered users taking part in payment requests.
+type UserDirectory interface {
+	// GetUser retrieves the user by their ID, including the account that receives the requested money.
+	// Returns an error if retrieval fails.
+	GetUser(ctx context.Context, id int) (*User, error)
+
+	// GetUserByPhoneNumber retrieves the user registered with the phone number.
+	// Returns ErrPayerNotFound if no user is registered with the phone number.
+	GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (*User, error)
+}
@@ -0,0 +1,154 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package paymentrequest
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUserDirectory is an autogenerated mock type for the UserDirectory type
+type MockUserDirectory struct {
+	mock.Mock
+}
+
+type MockUserDirectory_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserDirectory) EXPECT() *MockUserDirectory_Expecter {
+	return &MockUserDirectory_Expecter{mock: &_m.Mock}
+}
+
+// GetUser provides a mock function with given fields: ctx, id
+func (_m *MockUserDirectory) GetUser(ctx context.Context, id int) (*User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserDirectory_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockUserDirectory_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockUserDirectory_Expecter) GetUser(ctx interface{}, id interface{}) *MockUserDirectory_GetUser_Call {
+	return &MockUserDirectory_GetUser_Call{Call: _e.mock.On("GetUser", ctx, id)}
+}
+
+func (_c *MockUserDirectory_GetUser_Call) Run(run func(ctx context.Context, id int)) *MockUserDirectory_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockUserDirectory_GetUser_Call) Return(_a0 *User, _a1 error) *MockUserDirectory_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserDirectory_GetUser_Call) RunAndReturn(run func(context.Context, int) (*User, error)) *MockUserDirectory_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByPhoneNumber provides a mock function with given fields: ctx, phoneNumber
+func (_m *MockUserDirectory) GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (*User, error) {
+	ret := _m.Called(ctx, phoneNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByPhoneNumber")
+	}
+
+	var r0 *User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*User, error)); ok {
+		return rf(ctx, phoneNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *User); ok {
+		r0 = rf(ctx, phoneNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, phoneNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserDirectory_GetUserByPhoneNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByPhoneNumber'
+type MockUserDirectory_GetUserByPhoneNumber_Call struct {
+	*mock.Call
+}
+
+// GetUserByPhoneNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phoneNumber string
+func (_e *MockUserDirectory_Expecter) GetUserByPhoneNumber(ctx interface{}, phoneNumber interface{}) *MockUserDirectory_GetUserByPhoneNumber_Call {
+	return &MockUserDirectory_GetUserByPhoneNumber_Call{Call: _e.mock.On("GetUserByPhoneNumber", ctx, phoneNumber)}
+}
+
+func (_c *MockUserDirectory_GetUserByPhoneNumber_Call) Run(run func(ctx context.Context, phoneNumber string)) *MockUserDirectory_GetUserByPhoneNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserDirectory_GetUserByPhoneNumber_Call) Return(_a0 *User, _a1 error) *MockUserDirectory_GetUserByPhoneNumber_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserDirectory_GetUserByPhoneNumber_Call) RunAndReturn(run func(context.Context, string) (*User, error)) *MockUserDirectory_GetUserByPhoneNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserDirectory creates a new instance of MockUserDirectory. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserDirectory(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserDirectory {
+	mock := &MockUserDirectory{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
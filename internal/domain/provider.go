@@ -2,13 +2,37 @@ package domain
 
 import (
 	"github.com/google/wire"
+	"go.bankyaya.org/app/backend/internal/domain/beneficiary"
+	"go.bankyaya.org/app/backend/internal/domain/billpayment"
+	"go.bankyaya.org/app/backend/internal/domain/bulktransfer"
+	"go.bankyaya.org/app/backend/internal/domain/ewallet"
+	"go.bankyaya.org/app/backend/internal/domain/interbank"
 	"go.bankyaya.org/app/backend/internal/domain/intrabank"
 	"go.bankyaya.org/app/backend/internal/domain/otp"
+	"go.bankyaya.org/app/backend/internal/domain/paymentrequest"
+	"go.bankyaya.org/app/backend/internal/domain/qris"
+	"go.bankyaya.org/app/backend/internal/domain/schedule"
+	"go.bankyaya.org/app/backend/internal/domain/standingorder"
+	"go.bankyaya.org/app/backend/internal/domain/statement"
 	"go.bankyaya.org/app/backend/internal/domain/user"
 )
 
 var ProviderSet = wire.NewSet(
 	intrabank.NewService,
 	user.NewService,
-	otp.NewService,
+	interbank.NewService,
+	otp.NewService, wire.Bind(new(intrabank.TransactionAuthorizer), new(*otp.Service)),
+	wire.Bind(new(interbank.TransactionAuthorizer), new(*otp.Service)),
+	schedule.NewService, wire.Bind(new(schedule.Transferer), new(*intrabank.Service)),
+	wire.Bind(new(schedule.TransactionAuthorizer), new(*otp.Service)),
+	standingorder.NewService, wire.Bind(new(standingorder.Transferer), new(*intrabank.Service)),
+	wire.Bind(new(standingorder.TransactionAuthorizer), new(*otp.Service)),
+	beneficiary.NewService,
+	bulktransfer.NewService, wire.Bind(new(bulktransfer.Transferer), new(*intrabank.Service)),
+	wire.Bind(new(bulktransfer.TransactionAuthorizer), new(*otp.Service)),
+	paymentrequest.NewService, wire.Bind(new(paymentrequest.Transferer), new(*intrabank.Service)),
+	qris.NewService, wire.Bind(new(qris.TransactionAuthorizer), new(*otp.Service)),
+	billpayment.NewService, wire.Bind(new(billpayment.TransactionAuthorizer), new(*otp.Service)),
+	ewallet.NewService, wire.Bind(new(ewallet.TransactionAuthorizer), new(*otp.Service)),
+	statement.NewService,
 )
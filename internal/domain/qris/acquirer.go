@@ -0,0 +1,19 @@
+package qris
+
+import "context"
+
+// Acquirer defines methods of the QRIS network that reaches the acquirers of the merchants.
+type Acquirer interface {
+	// CheckMerchant retrieves the merchant of the payload as registered at its acquirer.
+	// Returns ErrMerchantNotFound if the merchant is unknown or inactive.
+	CheckMerchant(ctx context.Context, payload *Payload) (*Merchant, error)
+
+	// Pay credits the merchant through its acquirer, the payment has been debited to the QRIS settlement account.
+	// It returns a *PaymentRejection when the payment has been rejected.
+	Pay(ctx context.Context, in *AcquirerPayment) (*AcquirerResult, error)
+
+	// PaymentStatus retrieves the outcome of the payment with the reference.
+	// It returns a *PaymentRejection when the payment has been rejected
+	// and ErrPaymentNotReceived if the QRIS network has never received it.
+	PaymentStatus(ctx context.Context, reference string) (*AcquirerResult, error)
+}
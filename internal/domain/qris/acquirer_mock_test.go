@@ -0,0 +1,213 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package qris
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAcquirer is an autogenerated mock type for the Acquirer type
+type MockAcquirer struct {
+	mock.Mock
+}
+
+type MockAcquirer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAcquirer) EXPECT() *MockAcquirer_Expecter {
+	return &MockAcquirer_Expecter{mock: &_m.Mock}
+}
+
+// CheckMerchant provides a mock function with given fields: ctx, payload
+func (_m *MockAcquirer) CheckMerchant(ctx context.Context, payload *Payload) (*Merchant, error) {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckMerchant")
+	}
+
+	var r0 *Merchant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Payload) (*Merchant, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Payload) *Merchant); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Merchant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Payload) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAcquirer_CheckMerchant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckMerchant'
+type MockAcquirer_CheckMerchant_Call struct {
+	*mock.Call
+}
+
+// CheckMerchant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payload *Payload
+func (_e *MockAcquirer_Expecter) CheckMerchant(ctx interface{}, payload interface{}) *MockAcquirer_CheckMerchant_Call {
+	return &MockAcquirer_CheckMerchant_Call{Call: _e.mock.On("CheckMerchant", ctx, payload)}
+}
+
+func (_c *MockAcquirer_CheckMerchant_Call) Run(run func(ctx context.Context, payload *Payload)) *MockAcquirer_CheckMerchant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Payload))
+	})
+	return _c
+}
+
+func (_c *MockAcquirer_CheckMerchant_Call) Return(_a0 *Merchant, _a1 error) *MockAcquirer_CheckMerchant_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAcquirer_CheckMerchant_Call) RunAndReturn(run func(context.Context, *Payload) (*Merchant, error)) *MockAcquirer_CheckMerchant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pay provides a mock function with given fields: ctx, in
+func (_m *MockAcquirer) Pay(ctx context.Context, in *AcquirerPayment) (*AcquirerResult, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pay")
+	}
+
+	var r0 *AcquirerResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *AcquirerPayment) (*AcquirerResult, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *AcquirerPayment) *AcquirerResult); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*AcquirerResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *AcquirerPayment) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAcquirer_Pay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pay'
+type MockAcquirer_Pay_Call struct {
+	*mock.Call
+}
+
+// Pay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *AcquirerPayment
+func (_e *MockAcquirer_Expecter) Pay(ctx interface{}, in interface{}) *MockAcquirer_Pay_Call {
+	return &MockAcquirer_Pay_Call{Call: _e.mock.On("Pay", ctx, in)}
+}
+
+func (_c *MockAcquirer_Pay_Call) Run(run func(ctx context.Context, in *AcquirerPayment)) *MockAcquirer_Pay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*AcquirerPayment))
+	})
+	return _c
+}
+
+func (_c *MockAcquirer_Pay_Call) Return(_a0 *AcquirerResult, _a1 error) *MockAcquirer_Pay_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAcquirer_Pay_Call) RunAndReturn(run func(context.Context, *AcquirerPayment) (*AcquirerResult, error)) *MockAcquirer_Pay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PaymentStatus provides a mock function with given fields: ctx, reference
+func (_m *MockAcquirer) PaymentStatus(ctx context.Context, reference string) (*AcquirerResult, error) {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PaymentStatus")
+	}
+
+	var r0 *AcquirerResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*AcquirerResult, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *AcquirerResult); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*AcquirerResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAcquirer_PaymentStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PaymentStatus'
+type MockAcquirer_PaymentStatus_Call struct {
+	*mock.Call
+}
+
+// PaymentStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockAcquirer_Expecter) PaymentStatus(ctx interface{}, reference interface{}) *MockAcquirer_PaymentStatus_Call {
+	return &MockAcquirer_PaymentStatus_Call{Call: _e.mock.On("PaymentStatus", ctx, reference)}
+}
+
+func (_c *MockAcquirer_PaymentStatus_Call) Run(run func(ctx context.Context, reference string)) *MockAcquirer_PaymentStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAcquirer_PaymentStatus_Call) Return(_a0 *AcquirerResult, _a1 error) *MockAcquirer_PaymentStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAcquirer_PaymentStatus_Call) RunAndReturn(run func(context.Context, string) (*AcquirerResult, error)) *MockAcquirer_PaymentStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAcquirer creates a new instance of MockAcquirer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAcquirer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAcquirer {
+	mock := &MockAcquirer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
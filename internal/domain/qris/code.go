@@ -0,0 +1,222 @@
+package qris
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+const (
+	// DefaultCodeValidity is how long a code with an amount can be paid when the user sets no expiry.
+	DefaultCodeValidity = time.Hour
+	// MaxCodeValidity is the longest expiry of a code with an amount.
+	MaxCodeValidity = 24 * time.Hour
+	// transferCategoryCode is the merchant category code of the money transfers,
+	// printed on the codes generated for the customers.
+	transferCategoryCode = "4829"
+	// codeNumberLength is the number of digits of the code ID in the PAN and the reference label of a generated code.
+	codeNumberLength = 10
+	// codeReferencePrefix starts the reference label of a generated code.
+	codeReferencePrefix = "QR"
+)
+
+// Issuer is the identity of Bank Yaya in the QRIS network, printed on the codes generated for its customers.
+type Issuer struct {
+	// GUID is the reverse domain name of Bank Yaya.
+	GUID string
+	// NNS is the national numbering system prefix of Bank Yaya, the PANs of the generated codes start with it.
+	NNS        string
+	City       string
+	PostalCode string
+}
+
+// PAN returns the primary account number of the generated code,
+// the NNS followed by the zero-padded code ID and a Luhn check digit.
+func (i Issuer) PAN(codeID int64) string {
+	pan := fmt.Sprintf("%s%0*d", i.NNS, codeNumberLength, codeID)
+	return pan + luhn(pan)
+}
+
+// CodeID returns the ID of the generated code credited by the merchant, if the merchant is one of the codes of Bank Yaya.
+func (i Issuer) CodeID(merchant *Merchant) (int64, bool) {
+	if merchant.GUID != i.GUID {
+		return 0, false
+	}
+	return i.codeIDByPAN(merchant.PAN)
+}
+
+// CreditedCode returns the ID of the generated code paid by the inbound credit.
+// The credit is linked by the reference label printed on the code or by the PAN of the code,
+// not every network echoes the label. When both are present they must identify the same code.
+func (i Issuer) CreditedCode(credit *InboundCredit) (int64, bool) {
+	byLabel, labelOK := codeIDByReference(credit.ReferenceLabel)
+	byPAN, panOK := i.codeIDByPAN(credit.PAN)
+	switch {
+	case labelOK && panOK:
+		return byLabel, byLabel == byPAN
+	case labelOK:
+		return byLabel, true
+	default:
+		return byPAN, panOK
+	}
+}
+
+// codeIDByPAN returns the ID of the generated code with the PAN, if the PAN is one of the codes of Bank Yaya.
+func (i Issuer) codeIDByPAN(pan string) (int64, bool) {
+	if len(pan) != len(i.NNS)+codeNumberLength+1 {
+		return 0, false
+	}
+	digits := pan[:len(pan)-1]
+	if !strings.HasPrefix(digits, i.NNS) || !isDigits(digits) || luhn(digits) != pan[len(digits):] {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(digits[len(i.NNS):], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// codeIDByReference returns the ID of the generated code with the reference label, e.g. "QR0000000042".
+func codeIDByReference(label string) (int64, bool) {
+	digits, ok := strings.CutPrefix(label, codeReferencePrefix)
+	if !ok || len(digits) != codeNumberLength || !isDigits(digits) {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// Encode returns the merchant-presented payload of the generated code.
+// A code with an amount is dynamic, so the payer cannot change the amount.
+func (i Issuer) Encode(code *Code) string {
+	initiation, amount := InitiationStatic, ""
+	if code.HasAmount() {
+		initiation, amount = InitiationDynamic, strconv.FormatInt(int64(code.Amount), 10)
+	}
+	body := encodeTLV(
+		dataObject{ID: idPayloadFormat, Value: payloadFormatIndicator},
+		dataObject{ID: idInitiationMethod, Value: initiation},
+		dataObject{ID: strconv.Itoa(idMerchantAccountFrom), Value: encodeTLV(
+			dataObject{ID: idGUID, Value: i.GUID},
+			dataObject{ID: idMerchantPAN, Value: i.PAN(code.ID)},
+			dataObject{ID: idMerchantID, Value: code.AccountNumber},
+		)},
+		dataObject{ID: idCategoryCode, Value: transferCategoryCode},
+		dataObject{ID: idCurrency, Value: currencyRupiah},
+		dataObject{ID: idAmount, Value: amount},
+		dataObject{ID: idCountryCode, Value: countryIndonesia},
+		dataObject{ID: idMerchantName, Value: truncate(strings.ToUpper(code.Name), maxMerchantNameLength)},
+		dataObject{ID: idMerchantCity, Value: truncate(strings.ToUpper(i.City), maxMerchantCityLength)},
+		dataObject{ID: idPostalCode, Value: i.PostalCode},
+		dataObject{ID: idAdditionalData, Value: encodeTLV(
+			dataObject{ID: idReferenceLabel, Value: code.Reference()},
+		)},
+	) + idCRC + "04"
+	return body + checksum(body)
+}
+
+// Code represents a QRIS code generated for the user to receive money into their account.
+type Code struct {
+	ID            int64
+	UserID        int
+	AccountNumber string
+	// Name is the name of the account holder, shown to the payer as the merchant name.
+	Name string
+	// Amount is zero for a static code, where the payer enters the amount.
+	Amount intrabank.Money
+	// ExpiresAt is zero for a static code, which never expires.
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	// Payload and Image are rendered from the code, they are not stored.
+	Payload string
+	Image   []byte
+	// Transfers are the successful transfers received through the code from Bank Yaya accounts.
+	Transfers []*intrabank.Transaction
+	// Credits are the credits received through the code from other banks over the QRIS network.
+	Credits []*InboundCredit
+}
+
+// HasAmount reports whether the payer pays the fixed amount of the code.
+func (c *Code) HasAmount() bool {
+	return c.Amount > 0
+}
+
+// Expired reports whether the code can no longer be paid.
+func (c *Code) Expired(now time.Time) bool {
+	return !c.ExpiresAt.IsZero() && !now.Before(c.ExpiresAt)
+}
+
+// Reference returns the reference label of the code, printed in its additional data.
+func (c *Code) Reference() string {
+	return fmt.Sprintf("%s%0*d", codeReferencePrefix, codeNumberLength, c.ID)
+}
+
+// InboundCredit represents money sent to a code of Bank Yaya over the QRIS network from the app of another bank.
+// The network has settled it to the QRIS settlement account, it is credited from there to the account of the code.
+type InboundCredit struct {
+	ID int64
+	// NetworkReference identifies the credit at the QRIS network, a retried credit carries the same reference.
+	NetworkReference string
+	// PAN and ReferenceLabel identify the paid code.
+	PAN            string
+	ReferenceLabel string
+	Amount         intrabank.Money
+	PayerName      string
+	PayerBank      string
+	CodeID         int64
+	AccountNumber  string
+	// JournalSequence and TransactionReference identify the posting to the account of the code,
+	// they are empty until the credit has been posted.
+	JournalSequence      string
+	TransactionReference string
+	CreatedAt            time.Time
+}
+
+// Posted reports whether the credit has been posted to the account of the code.
+func (c *InboundCredit) Posted() bool {
+	return c.JournalSequence != ""
+}
+
+// Remark returns the transaction remark of the posting of the credit.
+func (c *InboundCredit) Remark() string {
+	return fmt.Sprintf("QRIS %v %v %v", c.PayerBank, c.PayerName, c.NetworkReference)
+}
+
+// CodeInput represents the code requested by the user.
+// The ExpiresIn is only used for a code with an amount, DefaultCodeValidity applies when it is zero.
+type CodeInput struct {
+	AccountNumber string
+	Amount        intrabank.Money
+	ExpiresIn     time.Duration
+}
+
+// luhn returns the Luhn check digit of the digits.
+func luhn(digits string) string {
+	sum := 0
+	for i := range len(digits) {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return strconv.Itoa((10 - sum%10) % 10)
+}
+
+// truncate shortens the value to the maximum length of its data object.
+func truncate(value string, n int) string {
+	value = strings.TrimSpace(value)
+	if len(value) > n {
+		return strings.TrimSpace(value[:n])
+	}
+	return value
+}
@@ -0,0 +1,8 @@
+package qris
+
+// CodeRenderer defines methods to render the QRIS codes generated for the users.
+type CodeRenderer interface {
+	// PNG renders the payload as a QR code image in the PNG format.
+	// Returns an error if the operation fails.
+	PNG(payload string) ([]byte, error)
+}
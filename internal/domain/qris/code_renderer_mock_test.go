@@ -0,0 +1,90 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package qris
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockCodeRenderer is an autogenerated mock type for the CodeRenderer type
+type MockCodeRenderer struct {
+	mock.Mock
+}
+
+type MockCodeRenderer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCodeRenderer) EXPECT() *MockCodeRenderer_Expecter {
+	return &MockCodeRenderer_Expecter{mock: &_m.Mock}
+}
+
+// PNG provides a mock function with given fields: payload
+func (_m *MockCodeRenderer) PNG(payload string) ([]byte, error) {
+	ret := _m.Called(payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PNG")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]byte, error)); ok {
+		return rf(payload)
+	}
+	if rf, ok := ret.Get(0).(func(string) []byte); ok {
+		r0 = rf(payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCodeRenderer_PNG_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PNG'
+type MockCodeRenderer_PNG_Call struct {
+	*mock.Call
+}
+
+// PNG is a helper method to define mock.On call
+//   - payload string
+func (_e *MockCodeRenderer_Expecter) PNG(payload interface{}) *MockCodeRenderer_PNG_Call {
+	return &MockCodeRenderer_PNG_Call{Call: _e.mock.On("PNG", payload)}
+}
+
+func (_c *MockCodeRenderer_PNG_Call) Run(run func(payload string)) *MockCodeRenderer_PNG_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockCodeRenderer_PNG_Call) Return(_a0 []byte, _a1 error) *MockCodeRenderer_PNG_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCodeRenderer_PNG_Call) RunAndReturn(run func(string) ([]byte, error)) *MockCodeRenderer_PNG_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCodeRenderer creates a new instance of MockCodeRenderer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCodeRenderer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCodeRenderer {
+	mock := &MockCodeRenderer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,102 @@
+package qris
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+func TestIssuerPAN(t *testing.T) {
+	pan := testIssuer.PAN(42)
+
+	assert.Equal(t, "9360099900000000424", pan)
+	assert.Len(t, pan, 19)
+
+	id, ok := testIssuer.CodeID(&Merchant{GUID: testIssuer.GUID, PAN: pan})
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), id)
+}
+
+func TestIssuerCodeIDFailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		merchant *Merchant
+	}{
+		{name: "other network", merchant: &Merchant{GUID: "ID.CO.OTHER.WWW", PAN: "9360099900000000424"}},
+		{name: "other nns", merchant: &Merchant{GUID: testIssuer.GUID, PAN: "9360001400000000422"}},
+		{name: "invalid check digit", merchant: &Merchant{GUID: testIssuer.GUID, PAN: "9360099900000000421"}},
+		{name: "invalid length", merchant: &Merchant{GUID: testIssuer.GUID, PAN: "936009990000000042"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := testIssuer.CodeID(tt.merchant)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestIssuerCreditedCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		credit *InboundCredit
+		id     int64
+		ok     bool
+	}{
+		{name: "by label", credit: &InboundCredit{ReferenceLabel: "QR0000000042"}, id: 42, ok: true},
+		{name: "by pan", credit: &InboundCredit{PAN: "9360099900000000424"}, id: 42, ok: true},
+		{name: "by label and pan", credit: &InboundCredit{ReferenceLabel: "QR0000000042", PAN: "9360099900000000424"}, id: 42, ok: true},
+		{name: "label and pan of different codes", credit: &InboundCredit{ReferenceLabel: "QR0000000043", PAN: "9360099900000000424"}, ok: false},
+		{name: "other bank", credit: &InboundCredit{PAN: "936000140000000001"}, ok: false},
+		{name: "none", credit: &InboundCredit{}, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := testIssuer.CreditedCode(tt.credit)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.id, id)
+			}
+		})
+	}
+}
+
+func TestIssuerEncodeStatic(t *testing.T) {
+	code := &Code{ID: 42, AccountNumber: "001001234567891", Name: "Olivia Rodrigo"}
+
+	p, err := Parse(testIssuer.Encode(code))
+
+	assert.Nil(t, err)
+	assert.Equal(t, InitiationStatic, p.InitiationMethod)
+	assert.False(t, p.HasAmount())
+	assert.Equal(t, &Merchant{
+		GUID:         "ID.CO.BANKYAYA.WWW",
+		PAN:          "9360099900000000424",
+		ID:           "001001234567891",
+		Name:         "OLIVIA RODRIGO",
+		City:         "JAKARTA",
+		PostalCode:   "10110",
+		CategoryCode: "4829",
+	}, p.Merchant)
+	assert.Equal(t, "QR0000000042", p.ReferenceLabel)
+}
+
+func TestIssuerEncodeDynamic(t *testing.T) {
+	code := &Code{ID: 7, AccountNumber: "001001234567891", Name: "Olivia Isabel Rodrigo Dela Cruz", Amount: 150000}
+
+	p, err := Parse(testIssuer.Encode(code))
+
+	assert.Nil(t, err)
+	assert.Equal(t, InitiationDynamic, p.InitiationMethod)
+	assert.Equal(t, intrabank.Money(150000), p.Amount)
+	assert.Equal(t, "OLIVIA ISABEL RODRIGO DEL", p.Merchant.Name)
+}
+
+func TestCodeExpired(t *testing.T) {
+	now := time.Now()
+
+	assert.False(t, (&Code{}).Expired(now))
+	assert.False(t, (&Code{Amount: 1000, ExpiresAt: now.Add(time.Minute)}).Expired(now))
+	assert.True(t, (&Code{Amount: 1000, ExpiresAt: now}).Expired(now))
+}
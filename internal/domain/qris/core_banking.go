@@ -0,0 +1,38 @@
+package qris
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// CoreBanking defines the core banking operations of the QRIS payments.
+type CoreBanking interface {
+	// GetCoreStatus gets the current status of the core banking system.
+	GetCoreStatus(ctx context.Context) (*intrabank.CoreStatus, error)
+
+	// GetAccountDetails retrieves account information for the given account number.
+	GetAccountDetails(ctx context.Context, accountNumber string) (*intrabank.Account, error)
+
+	// GetPostingStatus retrieves the outcome of the posting with the reference.
+	// It returns the result of a completed posting, an *intrabank.OverbookingRejection if the posting was rejected
+	// and intrabank.ErrPostingNotFound if the core banking system has never received it.
+	GetPostingStatus(ctx context.Context, reference string) (*intrabank.OverbookingResult, error)
+
+	// PerformOverbooking posts a payment of a code of Bank Yaya from the source account to the account of the code.
+	// It returns an *intrabank.OverbookingRejection when the posting has been rejected.
+	PerformOverbooking(ctx context.Context, in *intrabank.OverbookingInput) (*intrabank.OverbookingResult, error)
+
+	// CreditInbound posts the inbound credit from the QRIS settlement account to the account of the code,
+	// the network reference of the credit is its posting reference, so it is posted only once.
+	// It returns an *intrabank.OverbookingRejection when the posting has been rejected.
+	CreditInbound(ctx context.Context, credit *InboundCredit) (*intrabank.OverbookingResult, error)
+
+	// DebitPayment posts the QRIS payment from the source account to the QRIS settlement account.
+	// It returns an *intrabank.OverbookingRejection when the posting has been rejected.
+	DebitPayment(ctx context.Context, in *Debit) (*intrabank.OverbookingResult, error)
+
+	// ReversePayment returns a debited QRIS payment from the QRIS settlement account to the source account.
+	// Returns an error if the operation fails.
+	ReversePayment(ctx context.Context, in *Debit) (*intrabank.OverbookingResult, error)
+}
@@ -0,0 +1,449 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package qris
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	intrabank "go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// MockCoreBanking is an autogenerated mock type for the CoreBanking type
+type MockCoreBanking struct {
+	mock.Mock
+}
+
+type MockCoreBanking_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCoreBanking) EXPECT() *MockCoreBanking_Expecter {
+	return &MockCoreBanking_Expecter{mock: &_m.Mock}
+}
+
+// CreditInbound provides a mock function with given fields: ctx, credit
+func (_m *MockCoreBanking) CreditInbound(ctx context.Context, credit *InboundCredit) (*intrabank.OverbookingResult, error) {
+	ret := _m.Called(ctx, credit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreditInbound")
+	}
+
+	var r0 *intrabank.OverbookingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *InboundCredit) (*intrabank.OverbookingResult, error)); ok {
+		return rf(ctx, credit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *InboundCredit) *intrabank.OverbookingResult); ok {
+		r0 = rf(ctx, credit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.OverbookingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *InboundCredit) error); ok {
+		r1 = rf(ctx, credit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_CreditInbound_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreditInbound'
+type MockCoreBanking_CreditInbound_Call struct {
+	*mock.Call
+}
+
+// CreditInbound is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credit *InboundCredit
+func (_e *MockCoreBanking_Expecter) CreditInbound(ctx interface{}, credit interface{}) *MockCoreBanking_CreditInbound_Call {
+	return &MockCoreBanking_CreditInbound_Call{Call: _e.mock.On("CreditInbound", ctx, credit)}
+}
+
+func (_c *MockCoreBanking_CreditInbound_Call) Run(run func(ctx context.Context, credit *InboundCredit)) *MockCoreBanking_CreditInbound_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*InboundCredit))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_CreditInbound_Call) Return(_a0 *intrabank.OverbookingResult, _a1 error) *MockCoreBanking_CreditInbound_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_CreditInbound_Call) RunAndReturn(run func(context.Context, *InboundCredit) (*intrabank.OverbookingResult, error)) *MockCoreBanking_CreditInbound_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DebitPayment provides a mock function with given fields: ctx, in
+func (_m *MockCoreBanking) DebitPayment(ctx context.Context, in *Debit) (*intrabank.OverbookingResult, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DebitPayment")
+	}
+
+	var r0 *intrabank.OverbookingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Debit) (*intrabank.OverbookingResult, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Debit) *intrabank.OverbookingResult); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.OverbookingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Debit) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_DebitPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DebitPayment'
+type MockCoreBanking_DebitPayment_Call struct {
+	*mock.Call
+}
+
+// DebitPayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *Debit
+func (_e *MockCoreBanking_Expecter) DebitPayment(ctx interface{}, in interface{}) *MockCoreBanking_DebitPayment_Call {
+	return &MockCoreBanking_DebitPayment_Call{Call: _e.mock.On("DebitPayment", ctx, in)}
+}
+
+func (_c *MockCoreBanking_DebitPayment_Call) Run(run func(ctx context.Context, in *Debit)) *MockCoreBanking_DebitPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Debit))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_DebitPayment_Call) Return(_a0 *intrabank.OverbookingResult, _a1 error) *MockCoreBanking_DebitPayment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_DebitPayment_Call) RunAndReturn(run func(context.Context, *Debit) (*intrabank.OverbookingResult, error)) *MockCoreBanking_DebitPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountDetails provides a mock function with given fields: ctx, accountNumber
+func (_m *MockCoreBanking) GetAccountDetails(ctx context.Context, accountNumber string) (*intrabank.Account, error) {
+	ret := _m.Called(ctx, accountNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountDetails")
+	}
+
+	var r0 *intrabank.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*intrabank.Account, error)); ok {
+		return rf(ctx, accountNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *intrabank.Account); ok {
+		r0 = rf(ctx, accountNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accountNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_GetAccountDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountDetails'
+type MockCoreBanking_GetAccountDetails_Call struct {
+	*mock.Call
+}
+
+// GetAccountDetails is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountNumber string
+func (_e *MockCoreBanking_Expecter) GetAccountDetails(ctx interface{}, accountNumber interface{}) *MockCoreBanking_GetAccountDetails_Call {
+	return &MockCoreBanking_GetAccountDetails_Call{Call: _e.mock.On("GetAccountDetails", ctx, accountNumber)}
+}
+
+func (_c *MockCoreBanking_GetAccountDetails_Call) Run(run func(ctx context.Context, accountNumber string)) *MockCoreBanking_GetAccountDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_GetAccountDetails_Call) Return(_a0 *intrabank.Account, _a1 error) *MockCoreBanking_GetAccountDetails_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_GetAccountDetails_Call) RunAndReturn(run func(context.Context, string) (*intrabank.Account, error)) *MockCoreBanking_GetAccountDetails_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoreStatus provides a mock function with given fields: ctx
+func (_m *MockCoreBanking) GetCoreStatus(ctx context.Context) (*intrabank.CoreStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoreStatus")
+	}
+
+	var r0 *intrabank.CoreStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*intrabank.CoreStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *intrabank.CoreStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.CoreStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_GetCoreStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoreStatus'
+type MockCoreBanking_GetCoreStatus_Call struct {
+	*mock.Call
+}
+
+// GetCoreStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCoreBanking_Expecter) GetCoreStatus(ctx interface{}) *MockCoreBanking_GetCoreStatus_Call {
+	return &MockCoreBanking_GetCoreStatus_Call{Call: _e.mock.On("GetCoreStatus", ctx)}
+}
+
+func (_c *MockCoreBanking_GetCoreStatus_Call) Run(run func(ctx context.Context)) *MockCoreBanking_GetCoreStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_GetCoreStatus_Call) Return(_a0 *intrabank.CoreStatus, _a1 error) *MockCoreBanking_GetCoreStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_GetCoreStatus_Call) RunAndReturn(run func(context.Context) (*intrabank.CoreStatus, error)) *MockCoreBanking_GetCoreStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPostingStatus provides a mock function with given fields: ctx, reference
+func (_m *MockCoreBanking) GetPostingStatus(ctx context.Context, reference string) (*intrabank.OverbookingResult, error) {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostingStatus")
+	}
+
+	var r0 *intrabank.OverbookingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*intrabank.OverbookingResult, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *intrabank.OverbookingResult); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.OverbookingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_GetPostingStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPostingStatus'
+type MockCoreBanking_GetPostingStatus_Call struct {
+	*mock.Call
+}
+
+// GetPostingStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockCoreBanking_Expecter) GetPostingStatus(ctx interface{}, reference interface{}) *MockCoreBanking_GetPostingStatus_Call {
+	return &MockCoreBanking_GetPostingStatus_Call{Call: _e.mock.On("GetPostingStatus", ctx, reference)}
+}
+
+func (_c *MockCoreBanking_GetPostingStatus_Call) Run(run func(ctx context.Context, reference string)) *MockCoreBanking_GetPostingStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_GetPostingStatus_Call) Return(_a0 *intrabank.OverbookingResult, _a1 error) *MockCoreBanking_GetPostingStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_GetPostingStatus_Call) RunAndReturn(run func(context.Context, string) (*intrabank.OverbookingResult, error)) *MockCoreBanking_GetPostingStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PerformOverbooking provides a mock function with given fields: ctx, in
+func (_m *MockCoreBanking) PerformOverbooking(ctx context.Context, in *intrabank.OverbookingInput) (*intrabank.OverbookingResult, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PerformOverbooking")
+	}
+
+	var r0 *intrabank.OverbookingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.OverbookingInput) (*intrabank.OverbookingResult, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *intrabank.OverbookingInput) *intrabank.OverbookingResult); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.OverbookingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *intrabank.OverbookingInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_PerformOverbooking_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PerformOverbooking'
+type MockCoreBanking_PerformOverbooking_Call struct {
+	*mock.Call
+}
+
+// PerformOverbooking is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *intrabank.OverbookingInput
+func (_e *MockCoreBanking_Expecter) PerformOverbooking(ctx interface{}, in interface{}) *MockCoreBanking_PerformOverbooking_Call {
+	return &MockCoreBanking_PerformOverbooking_Call{Call: _e.mock.On("PerformOverbooking", ctx, in)}
+}
+
+func (_c *MockCoreBanking_PerformOverbooking_Call) Run(run func(ctx context.Context, in *intrabank.OverbookingInput)) *MockCoreBanking_PerformOverbooking_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*intrabank.OverbookingInput))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_PerformOverbooking_Call) Return(_a0 *intrabank.OverbookingResult, _a1 error) *MockCoreBanking_PerformOverbooking_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_PerformOverbooking_Call) RunAndReturn(run func(context.Context, *intrabank.OverbookingInput) (*intrabank.OverbookingResult, error)) *MockCoreBanking_PerformOverbooking_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReversePayment provides a mock function with given fields: ctx, in
+func (_m *MockCoreBanking) ReversePayment(ctx context.Context, in *Debit) (*intrabank.OverbookingResult, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReversePayment")
+	}
+
+	var r0 *intrabank.OverbookingResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Debit) (*intrabank.OverbookingResult, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Debit) *intrabank.OverbookingResult); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*intrabank.OverbookingResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Debit) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoreBanking_ReversePayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReversePayment'
+type MockCoreBanking_ReversePayment_Call struct {
+	*mock.Call
+}
+
+// ReversePayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *Debit
+func (_e *MockCoreBanking_Expecter) ReversePayment(ctx interface{}, in interface{}) *MockCoreBanking_ReversePayment_Call {
+	return &MockCoreBanking_ReversePayment_Call{Call: _e.mock.On("ReversePayment", ctx, in)}
+}
+
+func (_c *MockCoreBanking_ReversePayment_Call) Run(run func(ctx context.Context, in *Debit)) *MockCoreBanking_ReversePayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Debit))
+	})
+	return _c
+}
+
+func (_c *MockCoreBanking_ReversePayment_Call) Return(_a0 *intrabank.OverbookingResult, _a1 error) *MockCoreBanking_ReversePayment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoreBanking_ReversePayment_Call) RunAndReturn(run func(context.Context, *Debit) (*intrabank.OverbookingResult, error)) *MockCoreBanking_ReversePayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCoreBanking creates a new instance of MockCoreBanking. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCoreBanking(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCoreBanking {
+	mock := &MockCoreBanking{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
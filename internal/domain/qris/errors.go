@@ -0,0 +1,52 @@
+package qris
+
+import (
+	"errors"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+var (
+	// ErrGeneral indicates a general error, it is shared with the payment pipeline of the intrabank transfers.
+	ErrGeneral = intrabank.ErrGeneral
+
+	// ErrUnauthenticatedUser indicates that the user is not authenticated.
+	ErrUnauthenticatedUser = intrabank.ErrUnauthenticatedUser
+
+	// ErrInvalidPayload is returned when the QRIS payload is malformed or misses a mandatory data object.
+	ErrInvalidPayload = errors.New("invalid qris payload")
+
+	// ErrInvalidChecksum is returned when the CRC of the QRIS payload does not match its content.
+	ErrInvalidChecksum = errors.New("invalid qris checksum")
+
+	// ErrMerchantNotFound is returned by the acquirer when the merchant is unknown or inactive.
+	ErrMerchantNotFound = errors.New("merchant not found")
+
+	// ErrRailUnavailable is returned by the acquirer when the QRIS network cannot be reached.
+	ErrRailUnavailable = errors.New("rail unavailable")
+
+	// ErrPaymentNotFound is returned when the sequence has no QRIS payment.
+	ErrPaymentNotFound = errors.New("qris payment not found")
+
+	// ErrPaymentNotReceived is returned by the acquirer when the QRIS network has never received the payment.
+	ErrPaymentNotReceived = errors.New("qris payment not received")
+
+	// ErrPaymentPending is returned when the acquirer has not confirmed the payment,
+	// the payment stays pending until it is reconciled.
+	ErrPaymentPending = intrabank.ErrPaymentPending
+
+	// ErrInvalidCode is returned when the requested code has an invalid amount or expiry.
+	ErrInvalidCode = errors.New("invalid qris code")
+
+	// ErrCodeNotFound is returned when the generated code does not exist or belongs to another user.
+	ErrCodeNotFound = errors.New("qris code not found")
+
+	// ErrCodeExpired is returned when the generated code is paid after its expiry.
+	ErrCodeExpired = errors.New("qris code expired")
+
+	// ErrCreditExists is returned when an inbound credit with the network reference has already been received.
+	ErrCreditExists = errors.New("qris credit already exists")
+
+	// ErrCreditNotFound is returned when no inbound credit with the network reference has been received.
+	ErrCreditNotFound = errors.New("qris credit not found")
+)
@@ -0,0 +1,369 @@
+// Package qris provides the payments to merchants by scanning their QRIS codes.
+// A QRIS code carries an EMVCo merchant-presented payload, the payment is sent to the merchant
+// through the acquirer and reuses the intrabank sequence and transaction model with its own transaction type.
+// The users also generate QRIS codes of their accounts to receive money, the payments of these codes are linked to them.
+package qris
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// TransactionType is the transaction type of the QRIS payments,
+// the transfer method with this type holds their fee, limits and operating hours.
+const TransactionType = "qris"
+
+const (
+	// InitiationStatic is the point of initiation method of a code that can be paid many times.
+	InitiationStatic = "11"
+	// InitiationDynamic is the point of initiation method of a code that is paid once.
+	InitiationDynamic = "12"
+)
+
+const (
+	// TipPrompt asks the customer to enter the tip.
+	TipPrompt = "01"
+	// TipFixed adds the fixed convenience fee of the code.
+	TipFixed = "02"
+	// TipPercentage adds the percentage convenience fee of the code.
+	TipPercentage = "03"
+)
+
+const (
+	payloadFormatIndicator = "01"
+	currencyRupiah         = "360"
+	countryIndonesia       = "ID"
+	// maxMerchantNameLength and maxMerchantCityLength are the EMVCo limits of the merchant name and city.
+	maxMerchantNameLength = 25
+	maxMerchantCityLength = 15
+)
+
+// Data object IDs of the merchant-presented payload.
+const (
+	idPayloadFormat       = "00"
+	idInitiationMethod    = "01"
+	idMerchantAccountFrom = 26
+	idMerchantAccountTo   = 45
+	idQRISMerchant        = "51"
+	idCategoryCode        = "52"
+	idCurrency            = "53"
+	idAmount              = "54"
+	idTipIndicator        = "55"
+	idFixedTip            = "56"
+	idTipPercentage       = "57"
+	idCountryCode         = "58"
+	idMerchantName        = "59"
+	idMerchantCity        = "60"
+	idPostalCode          = "61"
+	idAdditionalData      = "62"
+	idCRC                 = "63"
+)
+
+// Data object IDs of the merchant account information and the additional data templates.
+const (
+	idGUID             = "00"
+	idMerchantPAN      = "01"
+	idMerchantID       = "02"
+	idMerchantCriteria = "03"
+	idBillNumber       = "01"
+	idReferenceLabel   = "05"
+	idTerminalLabel    = "07"
+)
+
+// Merchant represents the merchant of a QRIS code.
+type Merchant struct {
+	// GUID is the reverse domain name of the network that issued the merchant PAN.
+	GUID string
+	// PAN is the primary account number of the merchant, the payments are credited to it.
+	PAN string
+	// ID is the merchant ID at the acquirer.
+	ID string
+	// NMID is the national merchant ID registered at QRIS.
+	NMID         string
+	Criteria     string
+	Name         string
+	City         string
+	PostalCode   string
+	CategoryCode string
+}
+
+// Payload represents a parsed QRIS merchant-presented payload.
+type Payload struct {
+	Raw              string
+	InitiationMethod string
+	Merchant         *Merchant
+	// Amount is the amount of the transaction, it is zero when the customer enters the amount.
+	Amount       intrabank.Money
+	TipIndicator string
+	FixedTip     intrabank.Money
+	// TipBasisPoints is the percentage convenience fee in hundredths of a percent.
+	TipBasisPoints int64
+	BillNumber     string
+	ReferenceLabel string
+	TerminalLabel  string
+}
+
+// IsDynamic checks if the code is a dynamic code for a single transaction.
+func (p *Payload) IsDynamic() bool {
+	return p.InitiationMethod == InitiationDynamic
+}
+
+// HasAmount checks if the amount is set by the code instead of entered by the customer.
+func (p *Payload) HasAmount() bool {
+	return p.Amount > 0
+}
+
+// PromptsTip checks if the customer is asked to enter the tip.
+func (p *Payload) PromptsTip() bool {
+	return p.TipIndicator == TipPrompt
+}
+
+// Tip returns the tip of the amount.
+// The customer's tip is only used when the code prompts for it, a percentage tip is rounded to the nearest rupiah.
+func (p *Payload) Tip(amount, customerTip intrabank.Money) intrabank.Money {
+	switch p.TipIndicator {
+	case TipPrompt:
+		return max(customerTip, 0)
+	case TipFixed:
+		return p.FixedTip
+	case TipPercentage:
+		return intrabank.Money((int64(amount)*p.TipBasisPoints + 5000) / 10000)
+	}
+	return 0
+}
+
+// Parse parses and validates the QRIS merchant-presented payload.
+// It returns ErrInvalidChecksum when the CRC does not match and ErrInvalidPayload for any other invalid content.
+func Parse(raw string) (*Payload, error) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) < 8 || raw[len(raw)-8:len(raw)-4] != idCRC+"04" {
+		return nil, fmt.Errorf("%w: missing CRC", ErrInvalidPayload)
+	}
+	if !strings.EqualFold(checksum(raw[:len(raw)-4]), raw[len(raw)-4:]) {
+		return nil, ErrInvalidChecksum
+	}
+
+	objects, err := parseTLV(raw)
+	if err != nil {
+		return nil, err
+	}
+	if lookup(objects, idPayloadFormat) != payloadFormatIndicator {
+		return nil, fmt.Errorf("%w: unsupported payload format", ErrInvalidPayload)
+	}
+
+	p := &Payload{
+		Raw:              raw,
+		InitiationMethod: lookup(objects, idInitiationMethod),
+		TipIndicator:     lookup(objects, idTipIndicator),
+	}
+	if p.InitiationMethod != InitiationStatic && p.InitiationMethod != InitiationDynamic {
+		return nil, fmt.Errorf("%w: invalid point of initiation method", ErrInvalidPayload)
+	}
+	if lookup(objects, idCurrency) != currencyRupiah {
+		return nil, fmt.Errorf("%w: unsupported currency", ErrInvalidPayload)
+	}
+	if lookup(objects, idCountryCode) != countryIndonesia {
+		return nil, fmt.Errorf("%w: unsupported country", ErrInvalidPayload)
+	}
+
+	if p.Merchant, err = parseMerchant(objects); err != nil {
+		return nil, err
+	}
+
+	if amount := lookup(objects, idAmount); amount != "" {
+		if p.Amount, err = parseAmount(amount); err != nil || p.Amount == 0 {
+			return nil, fmt.Errorf("%w: invalid amount", ErrInvalidPayload)
+		}
+	}
+	if p.IsDynamic() && !p.HasAmount() {
+		return nil, fmt.Errorf("%w: dynamic code without amount", ErrInvalidPayload)
+	}
+
+	switch p.TipIndicator {
+	case "", TipPrompt:
+	case TipFixed:
+		if p.FixedTip, err = parseAmount(lookup(objects, idFixedTip)); err != nil || p.FixedTip == 0 {
+			return nil, fmt.Errorf("%w: invalid fixed tip", ErrInvalidPayload)
+		}
+	case TipPercentage:
+		if p.TipBasisPoints, err = parseBasisPoints(lookup(objects, idTipPercentage)); err != nil {
+			return nil, fmt.Errorf("%w: invalid tip percentage", ErrInvalidPayload)
+		}
+	default:
+		return nil, fmt.Errorf("%w: invalid tip indicator", ErrInvalidPayload)
+	}
+
+	if data := lookup(objects, idAdditionalData); data != "" {
+		additional, err := parseTLV(data)
+		if err != nil {
+			return nil, err
+		}
+		p.BillNumber = lookup(additional, idBillNumber)
+		p.ReferenceLabel = lookup(additional, idReferenceLabel)
+		p.TerminalLabel = lookup(additional, idTerminalLabel)
+	}
+
+	return p, nil
+}
+
+// parseMerchant reads the merchant from the first merchant account information template
+// that carries a merchant PAN, together with the QRIS national merchant ID.
+func parseMerchant(objects []dataObject) (*Merchant, error) {
+	m := &Merchant{
+		Name:         strings.TrimSpace(lookup(objects, idMerchantName)),
+		City:         strings.TrimSpace(lookup(objects, idMerchantCity)),
+		PostalCode:   lookup(objects, idPostalCode),
+		CategoryCode: lookup(objects, idCategoryCode),
+	}
+	for _, o := range objects {
+		id, _ := strconv.Atoi(o.ID)
+		if id < idMerchantAccountFrom || id > idMerchantAccountTo {
+			continue
+		}
+		account, err := parseTLV(o.Value)
+		if err != nil {
+			return nil, err
+		}
+		if pan := lookup(account, idMerchantPAN); pan != "" {
+			m.GUID = lookup(account, idGUID)
+			m.PAN = pan
+			m.ID = lookup(account, idMerchantID)
+			m.Criteria = lookup(account, idMerchantCriteria)
+			break
+		}
+	}
+	if data := lookup(objects, idQRISMerchant); data != "" {
+		qris, err := parseTLV(data)
+		if err != nil {
+			return nil, err
+		}
+		m.NMID = lookup(qris, idMerchantID)
+		if m.Criteria == "" {
+			m.Criteria = lookup(qris, idMerchantCriteria)
+		}
+	}
+
+	switch {
+	case m.PAN == "" || !isDigits(m.PAN):
+		return nil, fmt.Errorf("%w: missing merchant PAN", ErrInvalidPayload)
+	case len(m.CategoryCode) != 4 || !isDigits(m.CategoryCode):
+		return nil, fmt.Errorf("%w: invalid merchant category code", ErrInvalidPayload)
+	case m.Name == "" || len(m.Name) > maxMerchantNameLength:
+		return nil, fmt.Errorf("%w: invalid merchant name", ErrInvalidPayload)
+	case m.City == "" || len(m.City) > maxMerchantCityLength:
+		return nil, fmt.Errorf("%w: invalid merchant city", ErrInvalidPayload)
+	}
+	return m, nil
+}
+
+// parseAmount parses an EMVCo amount into whole rupiah, a fraction is only accepted when it is zero.
+func parseAmount(s string) (intrabank.Money, error) {
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" || !isDigits(whole) || len(fraction) > 2 || strings.Trim(fraction, "0") != "" {
+		return 0, ErrInvalidPayload
+	}
+	return intrabank.ParseMoney(whole)
+}
+
+// parseBasisPoints parses a percentage between 00.01 and 99.99 into hundredths of a percent.
+func parseBasisPoints(s string) (int64, error) {
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" || !isDigits(whole) || len(fraction) > 2 || (fraction != "" && !isDigits(fraction)) {
+		return 0, ErrInvalidPayload
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+	bp, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || bp <= 0 || bp >= 10000 {
+		return 0, ErrInvalidPayload
+	}
+	return bp, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Payment represents the QRIS payment of an inquiry sequence.
+// The amount of the sequence is the amount plus the tip, the fee is charged on top of it.
+type Payment struct {
+	ID             int64
+	SequenceNumber string
+	Payload        string
+	Merchant       *Merchant
+	Amount         intrabank.Money
+	Tip            intrabank.Money
+	Fee            intrabank.Money
+	SourceAccount  string
+	ExpiresAt      time.Time
+	// CodeID is the generated code paid by the payment, zero when the merchant is not one of the codes of Bank Yaya.
+	CodeID int64
+}
+
+// OnUs reports whether the payment pays a code of Bank Yaya, it is credited to the account of the code.
+func (p *Payment) OnUs() bool {
+	return p.CodeID != 0
+}
+
+// Total returns the amount debited from the source account.
+func (p *Payment) Total() intrabank.Money {
+	return p.Amount + p.Tip + p.Fee
+}
+
+// Debit is the posting of a QRIS payment from the source account to the QRIS settlement account,
+// the money leaves the account before the payment is sent to the acquirer of the merchant.
+// The Reference identifies the posting, its reversal refers to it, so the debit can only be reversed once.
+type Debit struct {
+	SourceAccount string
+	// Amount is the amount of the payment with the tip.
+	Amount    intrabank.Money
+	Fee       intrabank.Money
+	Remark    string
+	Reference string
+}
+
+// AcquirerPayment represents the debited payment sent to the acquirer of the merchant.
+type AcquirerPayment struct {
+	SourceAccount string
+	SourceName    string
+	Merchant      *Merchant
+	Payload       string
+	Amount        intrabank.Money
+	Tip           intrabank.Money
+	Fee           intrabank.Money
+	Reference     string
+	Remark        string
+	// TransactionReference is the reference of the debit of the payment at the core banking system.
+	TransactionReference string
+}
+
+// AcquirerResult represents the outcome of a payment approved by the acquirer.
+type AcquirerResult struct {
+	JournalSequence      string
+	TransactionReference string
+}
+
+// PaymentRejection is returned by the acquirer when the payment has been rejected,
+// so the merchant has not been credited.
+type PaymentRejection struct {
+	StatusCode  string
+	Description string
+	Payload     string
+}
+
+func (r *PaymentRejection) Error() string {
+	return fmt.Sprintf("qris payment rejected: %s (%s)", r.Description, r.StatusCode)
+}
+
+// remark returns the transaction remark of the QRIS payment of the sequence.
+func remark(seq *intrabank.Sequence, merchant *Merchant) string {
+	return fmt.Sprintf("QRIS %v %v %v", seq.SourceAccount, merchant.PAN, seq.SequenceNumber)
+}
@@ -0,0 +1,160 @@
+package qris
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// payload encodes the data objects into a payload ending with its CRC.
+func payload(objects ...dataObject) string {
+	data := encodeTLV(objects...) + idCRC + "04"
+	return data + checksum(data)
+}
+
+// merchantObjects returns the data objects of a static code of a test merchant, overridden by the given objects.
+func merchantObjects(overrides ...dataObject) []dataObject {
+	objects := []dataObject{
+		{ID: idPayloadFormat, Value: "01"},
+		{ID: idInitiationMethod, Value: InitiationStatic},
+		{ID: "26", Value: encodeTLV(
+			dataObject{ID: idGUID, Value: "ID.CO.BANKYAYA.WWW"},
+			dataObject{ID: idMerchantPAN, Value: "936000140000000001"},
+			dataObject{ID: idMerchantID, Value: "000000000000001"},
+			dataObject{ID: idMerchantCriteria, Value: "UMI"},
+		)},
+		{ID: idQRISMerchant, Value: encodeTLV(
+			dataObject{ID: idGUID, Value: "ID.CO.QRIS.WWW"},
+			dataObject{ID: idMerchantID, Value: "ID1020000000001"},
+		)},
+		{ID: idCategoryCode, Value: "5812"},
+		{ID: idCurrency, Value: "360"},
+		{ID: idCountryCode, Value: "ID"},
+		{ID: idMerchantName, Value: "KOPI KENANGAN"},
+		{ID: idMerchantCity, Value: "JAKARTA"},
+		{ID: idPostalCode, Value: "12190"},
+	}
+	for _, o := range overrides {
+		replaced := false
+		for i := range objects {
+			if objects[i].ID == o.ID {
+				objects[i] = o
+				replaced = true
+			}
+		}
+		if !replaced {
+			objects = append(objects, o)
+		}
+	}
+	return objects
+}
+
+func TestCRC16(t *testing.T) {
+	assert.Equal(t, uint16(0x29B1), crc16("123456789"))
+}
+
+func TestParseStatic(t *testing.T) {
+	raw := payload(merchantObjects()...)
+
+	p, err := Parse(raw)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &Payload{
+		Raw:              raw,
+		InitiationMethod: InitiationStatic,
+		Merchant: &Merchant{
+			GUID:         "ID.CO.BANKYAYA.WWW",
+			PAN:          "936000140000000001",
+			ID:           "000000000000001",
+			NMID:         "ID1020000000001",
+			Criteria:     "UMI",
+			Name:         "KOPI KENANGAN",
+			City:         "JAKARTA",
+			PostalCode:   "12190",
+			CategoryCode: "5812",
+		},
+	}, p)
+	assert.False(t, p.IsDynamic())
+	assert.False(t, p.HasAmount())
+}
+
+func TestParseDynamic(t *testing.T) {
+	raw := payload(merchantObjects(
+		dataObject{ID: idInitiationMethod, Value: InitiationDynamic},
+		dataObject{ID: idAmount, Value: "25000.00"},
+		dataObject{ID: idAdditionalData, Value: encodeTLV(
+			dataObject{ID: idBillNumber, Value: "INV-001"},
+			dataObject{ID: idTerminalLabel, Value: "K01"},
+		)},
+	)...)
+
+	p, err := Parse(raw)
+
+	assert.Nil(t, err)
+	assert.True(t, p.IsDynamic())
+	assert.Equal(t, 25000, int(p.Amount))
+	assert.Equal(t, "INV-001", p.BillNumber)
+	assert.Equal(t, "K01", p.TerminalLabel)
+}
+
+func TestParseTip(t *testing.T) {
+	p, err := Parse(payload(merchantObjects(dataObject{ID: idTipIndicator, Value: TipPrompt})...))
+	assert.Nil(t, err)
+	assert.True(t, p.PromptsTip())
+	assert.Equal(t, 2000, int(p.Tip(50000, 2000)))
+
+	p, err = Parse(payload(merchantObjects(
+		dataObject{ID: idTipIndicator, Value: TipFixed},
+		dataObject{ID: idFixedTip, Value: "1500"},
+	)...))
+	assert.Nil(t, err)
+	assert.Equal(t, 1500, int(p.Tip(50000, 2000)))
+
+	p, err = Parse(payload(merchantObjects(
+		dataObject{ID: idTipIndicator, Value: TipPercentage},
+		dataObject{ID: idTipPercentage, Value: "2.5"},
+	)...))
+	assert.Nil(t, err)
+	assert.Equal(t, 250, int(p.TipBasisPoints))
+	assert.Equal(t, 1250, int(p.Tip(50000, 2000)))
+
+	p, err = Parse(payload(merchantObjects()...))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, int(p.Tip(50000, 2000)))
+}
+
+func TestParseFailed_InvalidChecksum(t *testing.T) {
+	raw := payload(merchantObjects()...)
+	raw = raw[:len(raw)-4] + "0000"
+
+	p, err := Parse(raw)
+
+	assert.Nil(t, p)
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+}
+
+func TestParseFailed_InvalidPayload(t *testing.T) {
+	tests := map[string]string{
+		"missing CRC":         "000201",
+		"truncated":           "00020101021" + "6304" + checksum("00020101021"+"6304"),
+		"unsupported format":  payload(merchantObjects(dataObject{ID: idPayloadFormat, Value: "02"})...),
+		"dynamic no amount":   payload(merchantObjects(dataObject{ID: idInitiationMethod, Value: InitiationDynamic})...),
+		"fractional amount":   payload(merchantObjects(dataObject{ID: idAmount, Value: "100.50"})...),
+		"foreign currency":    payload(merchantObjects(dataObject{ID: idCurrency, Value: "840"})...),
+		"invalid tip":         payload(merchantObjects(dataObject{ID: idTipIndicator, Value: "04"})...),
+		"missing fixed tip":   payload(merchantObjects(dataObject{ID: idTipIndicator, Value: TipFixed})...),
+		"invalid percentage":  payload(merchantObjects(dataObject{ID: idTipIndicator, Value: TipPercentage}, dataObject{ID: idTipPercentage, Value: "100"})...),
+		"missing merchant":    payload(merchantObjects(dataObject{ID: "26", Value: encodeTLV(dataObject{ID: idGUID, Value: "ID.CO.BANKYAYA.WWW"})})...),
+		"invalid category":    payload(merchantObjects(dataObject{ID: idCategoryCode, Value: "58"})...),
+		"merchant name long":  payload(merchantObjects(dataObject{ID: idMerchantName, Value: "KOPI KENANGAN GRAND INDONESIA"})...),
+		"duplicate object id": payload(append(merchantObjects(), dataObject{ID: idMerchantCity, Value: "BANDUNG"})...),
+	}
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := Parse(raw)
+
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidPayload)
+		})
+	}
+}
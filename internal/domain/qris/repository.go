@@ -0,0 +1,67 @@
+package qris
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// Repository defines methods to persist and retrieve the QRIS payments.
+type Repository interface {
+	intrabank.PaymentRepository
+
+	// GetLimits retrieves the fee, limits and operating hours of the QRIS payments.
+	// Returns an error if the operation fails.
+	GetLimits(ctx context.Context) (*intrabank.Limits, error)
+
+	// InsertSequence persists a new sequence.
+	// Returns an error if the operation fails.
+	InsertSequence(ctx context.Context, seq *intrabank.Sequence) error
+
+	// InsertPayment persists the QRIS payment of a sequence.
+	// Returns an error if the operation fails.
+	InsertPayment(ctx context.Context, payment *Payment) error
+
+	// GetPayment retrieves the QRIS payment of the sequence.
+	// Returns ErrPaymentNotFound if the sequence has no QRIS payment.
+	GetPayment(ctx context.Context, sequenceNumber string) (*Payment, error)
+
+	// InsertCode persists a new code generated for the user.
+	// Returns an error if the operation fails.
+	InsertCode(ctx context.Context, code *Code) error
+
+	// GetCode retrieves the generated code with the ID.
+	// Returns ErrCodeNotFound if the code does not exist.
+	GetCode(ctx context.Context, id int64) (*Code, error)
+
+	// ListCodeTransfers retrieves the successful transfers paid through the generated code, the latest first.
+	// Returns an error if the operation fails.
+	ListCodeTransfers(ctx context.Context, codeID int64) ([]*intrabank.Transaction, error)
+
+	// InsertCredit persists a new inbound credit of a generated code.
+	// Returns ErrCreditExists if a credit with the network reference has already been received.
+	InsertCredit(ctx context.Context, credit *InboundCredit) error
+
+	// GetCredit retrieves the inbound credit with the network reference.
+	// Returns ErrCreditNotFound if no credit with the reference has been received.
+	GetCredit(ctx context.Context, networkReference string) (*InboundCredit, error)
+
+	// UpdateCreditPosting stores the journal and the reference of the posting of the inbound credit.
+	// Returns an error if the operation fails.
+	UpdateCreditPosting(ctx context.Context, credit *InboundCredit) error
+
+	// ListCodeCredits retrieves the posted inbound credits of the generated code, the latest first.
+	// Returns an error if the operation fails.
+	ListCodeCredits(ctx context.Context, codeID int64) ([]*InboundCredit, error)
+
+	// CountTransfersOfType counts the user's successful and pending transfers of the transaction type
+	// created within the [from, to) time range.
+	// Returns an error if the operation fails.
+	CountTransfersOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (int, error)
+
+	// SumTransferAmountOfType sums the amount of the user's successful and pending transfers
+	// of the transaction type created within the [from, to) time range.
+	// Returns an error if the operation fails.
+	SumTransferAmountOfType(ctx context.Context, userID, transactionType string, from, to time.Time) (intrabank.Money, error)
+}
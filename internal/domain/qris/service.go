@@ -0,0 +1,853 @@
+package qris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+const (
+	domainName            = "qris"
+	paymentSuccessSubject = "Pembayaran QRIS Berhasil"
+	paymentFailedSubject  = "Pembayaran QRIS Gagal"
+	// receiptNetwork is shown as the destination bank on the receipts.
+	receiptNetwork = "QRIS"
+)
+
+// InquiryInput represents the QRIS code scanned by the user and the payment details entered by them.
+// The Amount is only used when the code has no amount, the Tip only when the code prompts for it.
+type InquiryInput struct {
+	Payload       string
+	SourceAccount string
+	Amount        intrabank.Money
+	Tip           intrabank.Money
+	Channel       string
+}
+
+// Service handles the QRIS merchant payments.
+type Service struct {
+	log         *logger.Logger
+	repo        Repository
+	corebanking CoreBanking
+	acquirer    Acquirer
+	seqGen      SequenceGenerator
+	validity    intrabank.SequenceValidity
+	authorizer  TransactionAuthorizer
+	stepUp      intrabank.StepUpPolicy
+	fees        intrabank.FeePolicy
+	issuer      Issuer
+	renderer    CodeRenderer
+	payer       *intrabank.Payer[*paymentDetails]
+}
+
+// NewService creates a new instance of Service.
+func NewService(
+	log *logger.Logger,
+	repo Repository,
+	corebanking CoreBanking,
+	acquirer Acquirer,
+	seqGen SequenceGenerator,
+	validity intrabank.SequenceValidity,
+	authorizer TransactionAuthorizer,
+	stepUp intrabank.StepUpPolicy,
+	fees intrabank.FeePolicy,
+	issuer Issuer,
+	renderer CodeRenderer,
+) *Service {
+	s := &Service{
+		log:         log,
+		repo:        repo,
+		corebanking: corebanking,
+		acquirer:    acquirer,
+		seqGen:      seqGen,
+		validity:    validity,
+		authorizer:  authorizer,
+		stepUp:      stepUp,
+		fees:        fees,
+		issuer:      issuer,
+		renderer:    renderer,
+	}
+	s.payer = intrabank.NewPayer[*paymentDetails](log, domainName, repo, corebanking, authorizer, stepUp, &paymentMethod{s})
+	return s
+}
+
+// Scan parses the QRIS code and returns it with the merchant as registered at its acquirer,
+// so the user can check the merchant before paying.
+func (s *Service) Scan(ctx context.Context, raw string) (*Payload, error) {
+	payload, err := s.parse("Scan", raw)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Merchant, _, err = s.merchant(ctx, "Scan", payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// Inquiry checks the payment of the QRIS code and creates its sequence.
+// The amount of the sequence is the amount plus the tip, it is credited to the merchant PAN.
+func (s *Service) Inquiry(ctx context.Context, in *InquiryInput) (*Payment, error) {
+	if err := s.checkEOD(ctx, "Inquiry"); err != nil {
+		return nil, err
+	}
+
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	payload, err := s.parse("Inquiry", in.Payload)
+	if err != nil {
+		return nil, err
+	}
+	merchant, code, err := s.merchant(ctx, "Inquiry", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := in.Amount
+	if payload.HasAmount() {
+		amount = payload.Amount
+	}
+	if code != nil && code.HasAmount() && code.Amount != payload.Amount {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("code (%v) amount %v paid with %v", code.ID, code.Amount, payload.Amount)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidPayload).
+			SetMsg("The QR code is not a valid QRIS code.")
+	}
+
+	if amount <= 0 || in.Tip < 0 {
+		s.log.DomainUsecase(domainName, "Inquiry").Error(intrabank.ErrInvalidAmount)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+			SetMsg("Please enter the amount to pay.")
+	}
+	tip := payload.Tip(amount, in.Tip)
+
+	seq := &intrabank.Sequence{
+		Amount:             amount + tip,
+		SourceAccount:      in.SourceAccount,
+		DestinationAccount: merchant.PAN,
+		DestinationName:    merchant.Name,
+		Channel:            in.Channel,
+	}
+	if code != nil {
+		// A code of Bank Yaya is paid with an intrabank credit to its account, it does not go through the network.
+		if err := s.checkCodeAccount(ctx, "Inquiry", code); err != nil {
+			return nil, err
+		}
+		seq.DestinationAccount = code.AccountNumber
+		seq.DestinationName = code.Name
+	}
+
+	limits, err := s.limits(ctx, "Inquiry")
+	if err != nil {
+		return nil, err
+	}
+	if !limits.CanTransfer(seq.Amount) {
+		s.log.DomainUsecase(domainName, "Inquiry").Error(intrabank.ErrInvalidAmount)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+			SetMsg("Your payment amount is not within the limits of QRIS payments.")
+	}
+
+	from, to := intrabank.BusinessDay(time.Now())
+	dailyAmount, err := s.repo.SumTransferAmountOfType(ctx, strconv.Itoa(user.ID), TransactionType, from, to)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("SumTransferAmountOfType: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !limits.WithinDailyLimit(dailyAmount + seq.Amount) {
+		s.log.DomainUsecase(domainName, "Inquiry").Error(intrabank.ErrDailyLimitExceeded)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrDailyLimitExceeded).
+			SetMsg("You have reached your daily QRIS payment limit. Please try again tomorrow.")
+	}
+
+	srcAccount, err := s.corebanking.GetAccountDetails(ctx, seq.SourceAccount)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("GetAccountDetails: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !srcAccount.IsOwnedBy(user.CIF) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) not owned by user (%v)", seq.SourceAccount, user.ID)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrSourceAccountNotOwned).
+			SetMsg("You can only pay from your own account.")
+	}
+	if !srcAccount.IsAccountActive() {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) not active", seq.SourceAccount)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrSourceAccountInactive)
+	}
+
+	seq.Fee, err = s.paymentFee(ctx, user.ID, seq, limits.Fee, srcAccount.ProductType)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("CountTransfersOfType: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !srcAccount.CanDebit(seq.Amount + seq.Fee) {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("source account (%v) cannot be debited by %v", seq.SourceAccount, seq.Amount+seq.Fee)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrInsufficientBalance).
+			SetMsg("Your balance is not enough for this payment.")
+	}
+
+	seq.SourceName = srcAccount.Name
+
+	sequenceNo, err := s.seqGen.Generate()
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("Generate failed: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	now := time.Now()
+	seq.SequenceNumber = sequenceNo
+	seq.TransactionType = TransactionType
+	seq.Status = intrabank.SequenceCreated
+	seq.UserID = user.ID
+	seq.DeviceID = user.DeviceID
+	seq.CreatedAt = now
+	seq.ExpiresAt = now.Add(s.validity.For(TransactionType))
+
+	err = s.repo.InsertSequence(ctx, seq)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("InsertSequence: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	payment := &Payment{
+		SequenceNumber: seq.SequenceNumber,
+		Payload:        payload.Raw,
+		Merchant:       merchant,
+		Amount:         amount,
+		Tip:            tip,
+		Fee:            seq.Fee,
+		SourceAccount:  seq.SourceAccount,
+		ExpiresAt:      seq.ExpiresAt,
+	}
+	if code != nil {
+		payment.CodeID = code.ID
+	}
+
+	err = s.repo.InsertPayment(ctx, payment)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Inquiry").Errorf("InsertPayment: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return payment, nil
+}
+
+// SettlePending settles the QRIS payments left pending, e.g. when the outcome at the acquirer was unknown.
+func (s *Service) SettlePending(ctx context.Context) error {
+	return s.payer.SettlePending(ctx)
+}
+
+// DoPayment pays the QRIS sequence through the acquirer of the merchant.
+// A payment whose outcome is unknown is left pending, because the money may have moved.
+func (s *Service) DoPayment(ctx context.Context, in *intrabank.PaymentInput) (*intrabank.Transaction, error) {
+	payment, err := s.payer.Pay(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return payment.Transaction, nil
+}
+
+// GenerateCode generates a QRIS code for the user to receive money into their account.
+// A code without an amount is static and never expires, a code with an amount is paid with that amount until it expires.
+func (s *Service) GenerateCode(ctx context.Context, in *CodeInput) (*Code, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "GenerateCode").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	if in.Amount < 0 {
+		s.log.DomainUsecase(domainName, "GenerateCode").Errorf("amount %v: %v", in.Amount, ErrInvalidCode)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidCode).
+			SetMsg("Please enter a valid amount.")
+	}
+	if in.ExpiresIn < 0 || in.ExpiresIn > MaxCodeValidity || (in.Amount == 0 && in.ExpiresIn != 0) {
+		s.log.DomainUsecase(domainName, "GenerateCode").Errorf("expires in %v: %v", in.ExpiresIn, ErrInvalidCode)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidCode).
+			SetMsg(fmt.Sprintf("Only a QR code with an amount can expire, within %v.", MaxCodeValidity))
+	}
+
+	account, err := s.corebanking.GetAccountDetails(ctx, in.AccountNumber)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "GenerateCode").Errorf("GetAccountDetails: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !account.IsOwnedBy(user.CIF) {
+		s.log.DomainUsecase(domainName, "GenerateCode").Errorf("account (%v) not owned by user (%v)", in.AccountNumber, user.ID)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrSourceAccountNotOwned).
+			SetMsg("You can only receive money into your own account.")
+	}
+	if !account.IsAccountActive() {
+		s.log.DomainUsecase(domainName, "GenerateCode").Errorf("account (%v) not active", in.AccountNumber)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrDestinationAccountInactive).
+			SetMsg("Your account cannot receive money at the moment.")
+	}
+
+	now := time.Now()
+	code := &Code{
+		UserID:        user.ID,
+		AccountNumber: in.AccountNumber,
+		Name:          account.Name,
+		Amount:        in.Amount,
+		CreatedAt:     now,
+	}
+	if code.HasAmount() {
+		validity := in.ExpiresIn
+		if validity == 0 {
+			validity = DefaultCodeValidity
+		}
+		code.ExpiresAt = now.Add(validity)
+	}
+
+	err = s.repo.InsertCode(ctx, code)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "GenerateCode").Errorf("InsertCode: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	if err := s.render(code); err != nil {
+		s.log.DomainUsecase(domainName, "GenerateCode").Errorf("PNG: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return code, nil
+}
+
+// GetCode retrieves the code generated for the user with the transfers received through it.
+func (s *Service) GetCode(ctx context.Context, id int64) (*Code, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "GetCode").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	code, err := s.repo.GetCode(ctx, id)
+	if errors.Is(err, ErrCodeNotFound) || (err == nil && code.UserID != user.ID) {
+		s.log.DomainUsecase(domainName, "GetCode").Errorf("code (%v) of user (%v): %v", id, user.ID, ErrCodeNotFound)
+		return nil, pkgerror.New(codes.NotFound, ErrCodeNotFound).
+			SetMsg("QR code not found.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "GetCode").Errorf("GetCode: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	code.Transfers, err = s.repo.ListCodeTransfers(ctx, code.ID)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "GetCode").Errorf("ListCodeTransfers: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	code.Credits, err = s.repo.ListCodeCredits(ctx, code.ID)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "GetCode").Errorf("ListCodeCredits: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	if err := s.render(code); err != nil {
+		s.log.DomainUsecase(domainName, "GetCode").Errorf("PNG: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return code, nil
+}
+
+// ReceiveCredit credits the money sent to a code of Bank Yaya over the QRIS network to the account of the code,
+// and links the credit to the code by its reference label or PAN.
+// The network retries a credit with the same reference until it is answered, a retry of a posted credit returns it
+// and a retry of a credit whose posting failed posts it again, the core banking system posts the reference only once.
+func (s *Service) ReceiveCredit(ctx context.Context, in *InboundCredit) (*InboundCredit, error) {
+	credit, err := s.repo.GetCredit(ctx, in.NetworkReference)
+	if err == nil && credit.Posted() {
+		return credit, nil
+	}
+	if err != nil && !errors.Is(err, ErrCreditNotFound) {
+		s.log.DomainUsecase(domainName, "ReceiveCredit").Errorf("GetCredit: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if err != nil {
+		if credit, err = s.linkCredit(ctx, in); err != nil {
+			return nil, err
+		}
+	}
+
+	posting, err := s.corebanking.CreditInbound(ctx, credit)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "ReceiveCredit").Errorf("CreditInbound: credit (%v): %v", credit.NetworkReference, err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	credit.JournalSequence = posting.JournalSequence
+	credit.TransactionReference = posting.TransactionReference
+
+	err = s.repo.UpdateCreditPosting(ctx, credit)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "ReceiveCredit").Errorf("UpdateCreditPosting: credit (%v) journal (%v): %v",
+			credit.NetworkReference, credit.JournalSequence, err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return credit, nil
+}
+
+// linkCredit links the new inbound credit to the code it pays and records it.
+// The code must still be payable with the amount of the credit and its account must be able to receive it.
+func (s *Service) linkCredit(ctx context.Context, credit *InboundCredit) (*InboundCredit, error) {
+	id, ok := s.issuer.CreditedCode(credit)
+	if !ok {
+		s.log.DomainUsecase(domainName, "ReceiveCredit").Errorf("credit (%v) PAN (%v) label (%v): %v",
+			credit.NetworkReference, credit.PAN, credit.ReferenceLabel, ErrCodeNotFound)
+		return nil, pkgerror.New(codes.NotFound, ErrCodeNotFound).
+			SetMsg("The QR code is not a code of Bank Yaya.")
+	}
+	code, err := s.payableCode(ctx, "ReceiveCredit", id)
+	if err != nil {
+		return nil, err
+	}
+	if credit.Amount <= 0 || (code.HasAmount() && code.Amount != credit.Amount) {
+		s.log.DomainUsecase(domainName, "ReceiveCredit").Errorf("code (%v) amount %v credited with %v", code.ID, code.Amount, credit.Amount)
+		return nil, pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+			SetMsg("The amount does not match the QR code.")
+	}
+	if err := s.checkCodeAccount(ctx, "ReceiveCredit", code); err != nil {
+		return nil, err
+	}
+
+	credit.CodeID = code.ID
+	credit.AccountNumber = code.AccountNumber
+	err = s.repo.InsertCredit(ctx, credit)
+	if errors.Is(err, ErrCreditExists) {
+		s.log.DomainUsecase(domainName, "ReceiveCredit").Errorf("InsertCredit (%v): %v", credit.NetworkReference, err)
+		return nil, pkgerror.New(codes.Conflict, ErrCreditExists).
+			SetMsg("The credit is being processed.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "ReceiveCredit").Errorf("InsertCredit: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return credit, nil
+}
+
+// render encodes the payload of the code and renders its image.
+func (s *Service) render(code *Code) error {
+	code.Payload = s.issuer.Encode(code)
+	image, err := s.renderer.PNG(code.Payload)
+	if err != nil {
+		return err
+	}
+	code.Image = image
+	return nil
+}
+
+// merchant returns the merchant of the payload with the generated code it credits.
+// A code of Bank Yaya is checked in the repository, any other merchant at its acquirer and the code is nil.
+func (s *Service) merchant(ctx context.Context, usecase string, payload *Payload) (*Merchant, *Code, error) {
+	id, ok := s.issuer.CodeID(payload.Merchant)
+	if !ok {
+		merchant, err := s.checkMerchant(ctx, usecase, payload)
+		return merchant, nil, err
+	}
+	code, err := s.payableCode(ctx, usecase, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return payload.Merchant, code, nil
+}
+
+// checkCodeAccount checks that the account of the generated code can still be credited.
+func (s *Service) checkCodeAccount(ctx context.Context, usecase string, code *Code) error {
+	account, err := s.corebanking.GetAccountDetails(ctx, code.AccountNumber)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetAccountDetails: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !account.IsAccountActive() {
+		s.log.DomainUsecase(domainName, usecase).Errorf("code (%v) account (%v) not active", code.ID, code.AccountNumber)
+		return pkgerror.New(codes.BadRequest, intrabank.ErrDestinationAccountInactive).
+			SetMsg("The recipient's account cannot receive money at the moment.")
+	}
+	return nil
+}
+
+// payableCode retrieves the generated code and checks that it can still be paid.
+func (s *Service) payableCode(ctx context.Context, usecase string, id int64) (*Code, error) {
+	code, err := s.repo.GetCode(ctx, id)
+	if errors.Is(err, ErrCodeNotFound) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetCode (%v): %v", id, err)
+		return nil, pkgerror.New(codes.BadRequest, ErrCodeNotFound).
+			SetMsg("The QR code is not a valid QRIS code.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetCode: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if code.Expired(time.Now()) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("code (%v): %v", id, ErrCodeExpired)
+		return nil, pkgerror.New(codes.BadRequest, ErrCodeExpired).
+			SetMsg("This QR code has expired. Please ask the recipient for a new one.")
+	}
+	return code, nil
+}
+
+// parse parses the QRIS code scanned by the user.
+func (s *Service) parse(usecase, raw string) (*Payload, error) {
+	payload, err := Parse(raw)
+	if errors.Is(err, ErrInvalidChecksum) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Parse: %v", err)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidChecksum).
+			SetMsg("The QR code is damaged. Please scan it again.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Parse: %v", err)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidPayload).
+			SetMsg("The QR code is not a valid QRIS code.")
+	}
+	return payload, nil
+}
+
+// checkMerchant checks the merchant of the payload at its acquirer.
+func (s *Service) checkMerchant(ctx context.Context, usecase string, payload *Payload) (*Merchant, error) {
+	merchant, err := s.acquirer.CheckMerchant(ctx, payload)
+	if errors.Is(err, ErrMerchantNotFound) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("CheckMerchant (%v): %v", payload.Merchant.PAN, err)
+		return nil, pkgerror.New(codes.BadRequest, ErrMerchantNotFound).
+			SetMsg("The merchant cannot receive payments at the moment.")
+	}
+	if errors.Is(err, ErrRailUnavailable) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("CheckMerchant: %v", err)
+		return nil, pkgerror.New(codes.Forbidden, ErrRailUnavailable).
+			SetMsg("QRIS payments are temporarily unavailable. Please try again later.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("CheckMerchant: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return merchant, nil
+}
+
+// checkEOD rejects the payment while the end of day process of the core banking system is running.
+func (s *Service) checkEOD(ctx context.Context, usecase string) error {
+	coreStatus, err := s.corebanking.GetCoreStatus(ctx)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("CheckEOD: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if coreStatus.IsEODRunning() {
+		s.log.DomainUsecase(domainName, usecase).Errorf("CheckEOD: %v", intrabank.ErrEODInProgress)
+		return pkgerror.New(codes.Internal, intrabank.ErrEODInProgress)
+	}
+	return nil
+}
+
+// limits loads the limits of the QRIS payments and rejects the payment when they are not available.
+func (s *Service) limits(ctx context.Context, usecase string) (*intrabank.Limits, error) {
+	limits, err := s.repo.GetLimits(ctx)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetLimits: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if limits.Disabled {
+		s.log.DomainUsecase(domainName, usecase).Error(intrabank.ErrTransferMethodDisabled)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrTransferMethodDisabled).
+			SetMsg("QRIS payments are temporarily unavailable. Please try again later.")
+	}
+	if now := time.Now(); !limits.IsOpen(now) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("%v at %v", intrabank.ErrOutsideOperatingHours, now)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrOutsideOperatingHours).
+			SetMsg(fmt.Sprintf("QRIS payments are only available from %02d:00 to %02d:00 WIB.", limits.OpenHour, limits.CloseHour))
+	}
+	return limits, nil
+}
+
+// paymentFee calculates the fee of the sequence for the customer segment.
+// The payment is free while the user has not used up the monthly free quota of the QRIS payments.
+func (s *Service) paymentFee(ctx context.Context, userID int, seq *intrabank.Sequence, baseFee intrabank.Money, segment string) (intrabank.Money, error) {
+	in := &intrabank.FeeInput{
+		Method:  TransactionType,
+		Channel: seq.Channel,
+		Segment: segment,
+		Amount:  seq.Amount,
+		BaseFee: baseFee,
+	}
+	if quota := s.fees.FreeTransfers(in); quota > 0 {
+		from, to := intrabank.BusinessMonth(time.Now())
+		count, err := s.repo.CountTransfersOfType(ctx, strconv.Itoa(userID), TransactionType, from, to)
+		if err != nil {
+			return 0, err
+		}
+		if count < quota {
+			return 0, nil
+		}
+	}
+	return s.fees.Fee(in), nil
+}
+
+// paymentDetails holds the QRIS payment of the sequence and the limits loaded for it.
+type paymentDetails struct {
+	payment *Payment
+	limits  *intrabank.Limits
+}
+
+// paymentMethod pays the QRIS sequences through the acquirer of the merchant,
+// a payment of a code of Bank Yaya is credited to the account of the code by the core banking system.
+type paymentMethod struct {
+	*Service
+}
+
+var paymentMessages = &intrabank.PaymentMessages{
+	Rejected:    "Your payment request was rejected. Please try again.",
+	KeyReused:   "The idempotency key has been used for another payment.",
+	Expired:     "Your payment session has expired. Please scan the QR code again.",
+	OTPRequired: "Please verify this payment with the OTP sent to you.",
+	InProgress:  "Your payment is being processed. Please check your transaction history.",
+	Failed:      "Your payment has failed. Please scan the QR code again.",
+	Pending:     "Your payment is being processed. Please check your transaction history.",
+	NotRecorded: "Your payment has been processed but is not recorded yet. Please check your transaction history later.",
+}
+
+func (m *paymentMethod) Messages() *intrabank.PaymentMessages {
+	return paymentMessages
+}
+
+func (m *paymentMethod) Accepts(sequence *intrabank.Sequence) bool {
+	return sequence.TransactionType == TransactionType
+}
+
+// Prepare loads the QRIS payment and the limits, the limits are checked before the OTP,
+// so a closed payment method does not use it up.
+func (m *paymentMethod) Prepare(ctx context.Context, payment *intrabank.Payment[*paymentDetails]) error {
+	sequence := payment.Sequence
+	qrisPayment, err := m.repo.GetPayment(ctx, sequence.SequenceNumber)
+	if err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("GetPayment: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if qrisPayment.OnUs() {
+		if _, err := m.payableCode(ctx, "DoPayment", qrisPayment.CodeID); err != nil {
+			return err
+		}
+	}
+
+	limits, err := m.limits(ctx, "DoPayment")
+	if err != nil {
+		return err
+	}
+	if !limits.CanTransfer(sequence.Amount) {
+		m.log.DomainUsecase(domainName, "DoPayment").Error(intrabank.ErrInvalidAmount)
+		return pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+			SetMsg("Your payment amount is not within the limits of QRIS payments.")
+	}
+
+	payment.Details = &paymentDetails{payment: qrisPayment, limits: limits}
+	return nil
+}
+
+// KnownDestination treats every merchant as known, a QRIS code identifies the merchant on its own,
+// so only the step-up threshold requires an OTP.
+func (m *paymentMethod) KnownDestination(context.Context, *intrabank.Payment[*paymentDetails]) (bool, error) {
+	return true, nil
+}
+
+func (m *paymentMethod) Describe(payment *intrabank.Payment[*paymentDetails]) (string, string) {
+	return TransactionType, remark(payment.Sequence, payment.Details.payment.Merchant)
+}
+
+// CheckDailyLimit checks the daily limit of the QRIS payments, it includes the pending transaction of the payment.
+func (m *paymentMethod) CheckDailyLimit(ctx context.Context, payment *intrabank.Payment[*paymentDetails]) error {
+	from, to := intrabank.BusinessDay(time.Now())
+	dailyAmount, err := m.repo.SumTransferAmountOfType(ctx, payment.Transaction.UserID, TransactionType, from, to)
+	if err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("SumTransferAmountOfType: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !payment.Details.limits.WithinDailyLimit(dailyAmount) {
+		m.log.DomainUsecase(domainName, "DoPayment").Error(intrabank.ErrDailyLimitExceeded)
+		return pkgerror.New(codes.BadRequest, intrabank.ErrDailyLimitExceeded).
+			SetMsg("You have reached your daily QRIS payment limit. Please try again tomorrow.")
+	}
+	return nil
+}
+
+// Post debits the payment to the QRIS settlement account and sends it to the acquirer of the merchant.
+// A payment rejected by the acquirer is reversed to the source account and returned as rejected,
+// it is left pending when the reversal fails, so the debited money is reconciled.
+// A payment of a code of Bank Yaya is posted from the source account to the account of the code in one overbooking.
+func (m *paymentMethod) Post(ctx context.Context, payment *intrabank.Payment[*paymentDetails]) (*intrabank.OverbookingResult, error) {
+	sequence := payment.Sequence
+	transaction := payment.Transaction
+	qrisPayment := payment.Details.payment
+
+	if qrisPayment.OnUs() {
+		return m.corebanking.PerformOverbooking(ctx, &intrabank.OverbookingInput{
+			SourceAccount:      sequence.SourceAccount,
+			DestinationAccount: sequence.DestinationAccount,
+			Amount:             sequence.Amount,
+			Fee:                sequence.Fee,
+			Remark:             transaction.Remarks,
+			Reference:          sequence.SequenceNumber,
+		})
+	}
+
+	debit := paymentDebit(payment)
+	posting, err := m.corebanking.DebitPayment(ctx, debit)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction.SequenceJournal = posting.JournalSequence
+	transaction.TransactionReference = posting.TransactionReference
+	if err := m.repo.RecordPosting(ctx, transaction); err != nil {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("RecordPosting: sequence (%v) journal (%v): %v",
+			transaction.SequenceNumber, transaction.SequenceJournal, err)
+	}
+
+	result, err := m.acquirer.Pay(ctx, &AcquirerPayment{
+		SourceAccount:        sequence.SourceAccount,
+		SourceName:           sequence.SourceName,
+		Merchant:             qrisPayment.Merchant,
+		Payload:              qrisPayment.Payload,
+		Amount:               qrisPayment.Amount,
+		Tip:                  qrisPayment.Tip,
+		Fee:                  sequence.Fee,
+		Reference:            sequence.SequenceNumber,
+		Remark:               transaction.Remarks,
+		TransactionReference: transaction.TransactionReference,
+	})
+	var rejection *PaymentRejection
+	if errors.As(err, &rejection) {
+		m.log.DomainUsecase(domainName, "DoPayment").Errorf("Pay: %v", err)
+		if _, err := m.corebanking.ReversePayment(ctx, debit); err != nil {
+			return nil, fmt.Errorf("reverse payment journal (%v): %w", transaction.SequenceJournal, err)
+		}
+		return nil, &intrabank.OverbookingRejection{
+			StatusCode:  rejection.StatusCode,
+			Description: rejection.Description,
+			Payload:     rejection.Payload,
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pay acquirer journal (%v): %w", transaction.SequenceJournal, err)
+	}
+
+	return &intrabank.OverbookingResult{
+		JournalSequence:      posting.JournalSequence,
+		TransactionReference: result.TransactionReference,
+	}, nil
+}
+
+// Rejected tells the user whether the debit was rejected or the acquirer rejected the debited payment,
+// which has been returned to the source account.
+func (m *paymentMethod) Rejected(payment *intrabank.Payment[*paymentDetails], _ *intrabank.OverbookingRejection) error {
+	if payment.Transaction.SequenceJournal != "" {
+		return pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+			SetMsg("Your payment was rejected by the merchant's bank. The amount has been returned to your account.")
+	}
+	return pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+		SetMsg("Your payment was rejected. Please try again.")
+}
+
+// Load loads the QRIS payment of the sequence.
+func (m *paymentMethod) Load(ctx context.Context, payment *intrabank.Payment[*paymentDetails]) error {
+	qrisPayment, err := m.repo.GetPayment(ctx, payment.Sequence.SequenceNumber)
+	if err != nil {
+		return err
+	}
+	payment.Details = &paymentDetails{payment: qrisPayment}
+	return nil
+}
+
+// Resolve checks the debit of the payment at the core banking system when its journal has not been recorded,
+// and then the payment at the QRIS network. A debited payment which has been rejected or never received
+// by the network is reversed, it is left pending when the reversal fails.
+// A payment of a code of Bank Yaya is only checked at the core banking system.
+func (m *paymentMethod) Resolve(ctx context.Context, payment *intrabank.Payment[*paymentDetails]) (*intrabank.OverbookingResult, error) {
+	sequence := payment.Sequence
+	transaction := payment.Transaction
+
+	if payment.Details.payment.OnUs() {
+		return m.corebanking.GetPostingStatus(ctx, sequence.SequenceNumber)
+	}
+
+	if transaction.SequenceJournal == "" {
+		posting, err := m.corebanking.GetPostingStatus(ctx, sequence.SequenceNumber)
+		if err != nil {
+			return nil, err
+		}
+		transaction.SequenceJournal = posting.JournalSequence
+		transaction.TransactionReference = posting.TransactionReference
+	}
+
+	result, err := m.acquirer.PaymentStatus(ctx, sequence.SequenceNumber)
+	var rejection *PaymentRejection
+	if errors.As(err, &rejection) || errors.Is(err, ErrPaymentNotReceived) {
+		if _, err := m.corebanking.ReversePayment(ctx, paymentDebit(payment)); err != nil {
+			return nil, fmt.Errorf("reverse payment journal (%v): %w", transaction.SequenceJournal, err)
+		}
+		if rejection == nil {
+			return nil, &intrabank.OverbookingRejection{Description: err.Error(), Payload: err.Error()}
+		}
+		return nil, &intrabank.OverbookingRejection{
+			StatusCode:  rejection.StatusCode,
+			Description: rejection.Description,
+			Payload:     rejection.Payload,
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("payment status journal (%v): %w", transaction.SequenceJournal, err)
+	}
+
+	return &intrabank.OverbookingResult{
+		JournalSequence:      transaction.SequenceJournal,
+		TransactionReference: result.TransactionReference,
+	}, nil
+}
+
+// paymentDebit returns the debit of the payment to the QRIS settlement account.
+func paymentDebit(payment *intrabank.Payment[*paymentDetails]) *Debit {
+	sequence := payment.Sequence
+	return &Debit{
+		SourceAccount: sequence.SourceAccount,
+		Amount:        sequence.Amount,
+		Fee:           sequence.Fee,
+		Remark:        payment.Transaction.Remarks,
+		Reference:     sequence.SequenceNumber,
+	}
+}
+
+// Receipt builds the receipt and the notification of the payment, they are delivered
+// by the outbox dispatcher of the intrabank transfers.
+func (m *paymentMethod) Receipt(payment *intrabank.Payment[*paymentDetails]) (*intrabank.EmailData, *intrabank.Notification) {
+	transaction := payment.Transaction
+	subject := paymentSuccessSubject
+	if transaction.Status == intrabank.TransactionFailed {
+		subject = paymentFailedSubject
+	}
+
+	return &intrabank.EmailData{
+		Subject:            subject,
+		Recipient:          payment.User.Email,
+		Amount:             transaction.Amount,
+		Fee:                payment.Sequence.Fee,
+		SourceName:         payment.User.Name,
+		SourceAccount:      payment.Sequence.SourceAccount,
+		DestinationName:    transaction.DestinationName,
+		DestinationAccount: transaction.Destination,
+		DestinationBank:    receiptNetwork,
+		TransactionRef:     transaction.TransactionReference,
+		Note:               transaction.Remarks,
+		Status:             transaction.Status,
+	}, &intrabank.Notification{
+		Subject:     subject,
+		Amount:      transaction.Amount,
+		Destination: transaction.DestinationName,
+		Status:      transaction.Status,
+	}
+}
@@ -0,0 +1,1205 @@
+package qris
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+var testIssuer = Issuer{
+	GUID:       "ID.CO.BANKYAYA.WWW",
+	NNS:        "93600999",
+	City:       "Jakarta",
+	PostalCode: "10110",
+}
+
+var registeredMerchant = &Merchant{
+	PAN:          "936000140000000001",
+	ID:           "000000000000001",
+	NMID:         "ID1020000000001",
+	Name:         "KOPI KENANGAN",
+	City:         "JAKARTA",
+	CategoryCode: "5812",
+}
+
+func TestScanSuccess(t *testing.T) {
+	var (
+		acquirerMock = NewMockAcquirer(t)
+		svc          = NewService(logger.New(), NewMockRepository(t), NewMockCoreBanking(t), acquirerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		raw          = payload(merchantObjects()...)
+		ctx          = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	acquirerMock.EXPECT().CheckMerchant(mock.Anything, mock.MatchedBy(func(p *Payload) bool {
+		return p.Raw == raw && p.Merchant.PAN == "936000140000000001"
+	})).Return(registeredMerchant, nil)
+
+	p, err := svc.Scan(ctx, raw)
+
+	assert.Nil(t, err)
+	assert.Equal(t, registeredMerchant, p.Merchant)
+
+	acquirerMock.AssertExpectations(t)
+}
+
+func TestScanFailed_InvalidChecksum(t *testing.T) {
+	var (
+		svc = NewService(logger.New(), NewMockRepository(t), NewMockCoreBanking(t), NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		raw = payload(merchantObjects()...)
+		ctx = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	p, err := svc.Scan(ctx, raw[:len(raw)-4]+"FFFF")
+
+	assert.Nil(t, p)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidChecksum).
+		SetMsg("The QR code is damaged. Please scan it again."), err)
+}
+
+func TestScanFailed_InvalidPayload(t *testing.T) {
+	var (
+		svc = NewService(logger.New(), NewMockRepository(t), NewMockCoreBanking(t), NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	p, err := svc.Scan(ctx, "https://bankyaya.co.id")
+
+	assert.Nil(t, p)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidPayload).
+		SetMsg("The QR code is not a valid QRIS code."), err)
+}
+
+func TestScanFailed_MerchantNotFound(t *testing.T) {
+	var (
+		acquirerMock = NewMockAcquirer(t)
+		svc          = NewService(logger.New(), NewMockRepository(t), NewMockCoreBanking(t), acquirerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx          = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	acquirerMock.EXPECT().CheckMerchant(mock.Anything, mock.Anything).
+		Return(nil, ErrMerchantNotFound)
+
+	p, err := svc.Scan(ctx, payload(merchantObjects()...))
+
+	assert.Nil(t, p)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrMerchantNotFound).
+		SetMsg("The merchant cannot receive payments at the moment."), err)
+
+	acquirerMock.AssertExpectations(t)
+}
+
+func TestQRISInquirySuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		acquirerMock    = NewMockAcquirer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		fees            = intrabank.FeePolicy{Rules: []intrabank.FeeRule{{Method: "qris", Fee: 500}}}
+		svc             = NewService(logger.New(), repoMock, corebankingMock, acquirerMock, seqGenMock, intrabank.SequenceValidity{"qris": 5 * time.Minute}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, fees, testIssuer, NewMockCodeRenderer(t))
+		raw             = payload(merchantObjects(dataObject{ID: idTipIndicator, Value: TipPrompt})...)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&intrabank.Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+			MinBalance:       50_000,
+		}, nil)
+
+	acquirerMock.EXPECT().CheckMerchant(mock.Anything, mock.Anything).
+		Return(registeredMerchant, nil)
+
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "qris", mock.Anything, mock.Anything).
+		Return(0, nil)
+	repoMock.EXPECT().InsertSequence(mock.Anything, mock.MatchedBy(func(seq *intrabank.Sequence) bool {
+		return seq.SequenceNumber == "123456" &&
+			seq.TransactionType == "qris" &&
+			seq.Amount == 52000 &&
+			seq.Fee == 500 &&
+			seq.DestinationAccount == "936000140000000001" &&
+			seq.DestinationName == "KOPI KENANGAN" &&
+			seq.ExpiresAt.Sub(seq.CreatedAt) == 5*time.Minute
+	})).Return(nil)
+	repoMock.EXPECT().InsertPayment(mock.Anything, mock.Anything).
+		Return(nil)
+
+	seqGenMock.EXPECT().Generate().
+		Return("123456", nil)
+
+	p, err := svc.Inquiry(ctx, &InquiryInput{
+		Payload:       raw,
+		SourceAccount: "001001234567891",
+		Amount:        50000,
+		Tip:           2000,
+	})
+
+	assert.Nil(t, err)
+	p.ExpiresAt = time.Time{}
+	assert.Equal(t, &Payment{
+		SequenceNumber: "123456",
+		Payload:        raw,
+		Merchant:       registeredMerchant,
+		Amount:         50000,
+		Tip:            2000,
+		Fee:            500,
+		SourceAccount:  "001001234567891",
+	}, p)
+	assert.Equal(t, intrabank.Money(52500), p.Total())
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	acquirerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestQRISInquiryFailed_AmountRequired(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		acquirerMock    = NewMockAcquirer(t)
+		svc             = NewService(logger.New(), NewMockRepository(t), corebankingMock, acquirerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	acquirerMock.EXPECT().CheckMerchant(mock.Anything, mock.Anything).
+		Return(registeredMerchant, nil)
+
+	p, err := svc.Inquiry(ctx, &InquiryInput{
+		Payload:       payload(merchantObjects()...),
+		SourceAccount: "001001234567891",
+	})
+
+	assert.Nil(t, p)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+		SetMsg("Please enter the amount to pay."), err)
+
+	corebankingMock.AssertExpectations(t)
+	acquirerMock.AssertExpectations(t)
+}
+
+func TestQRISInquiryFailed_MethodDisabled(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		acquirerMock    = NewMockAcquirer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, acquirerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	acquirerMock.EXPECT().CheckMerchant(mock.Anything, mock.Anything).
+		Return(registeredMerchant, nil)
+
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{Disabled: true}, nil)
+
+	p, err := svc.Inquiry(ctx, &InquiryInput{
+		Payload:       payload(merchantObjects(dataObject{ID: idInitiationMethod, Value: InitiationDynamic}, dataObject{ID: idAmount, Value: "25000"})...),
+		SourceAccount: "001001234567891",
+	})
+
+	assert.Nil(t, p)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, intrabank.ErrTransferMethodDisabled).
+		SetMsg("QRIS payments are temporarily unavailable. Please try again later."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	acquirerMock.AssertExpectations(t)
+}
+
+func TestQRISDoPaymentSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		acquirerMock    = NewMockAcquirer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, acquirerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             52000,
+			Fee:                500,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "936000140000000001",
+			SourceName:         "Olivia Rodrigo",
+			DestinationName:    "KOPI KENANGAN",
+			TransactionType:    "qris",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").
+		Return(&Payment{
+			SequenceNumber: "123456",
+			Payload:        "000201",
+			Merchant:       registeredMerchant,
+			Amount:         50000,
+			Tip:            2000,
+			Fee:            500,
+		}, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "qris", mock.Anything, mock.Anything).
+		Return(52000, nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, mock.Anything, mock.MatchedBy(func(outbox []*intrabank.OutboxMessage) bool {
+		if len(outbox) != 1 {
+			return false
+		}
+		email, err := outbox[0].Receipt()
+		return err == nil && email.DestinationBank == "QRIS" && email.Status == intrabank.TransactionSuccess
+	})).Return(nil)
+
+	corebankingMock.EXPECT().DebitPayment(mock.Anything, &Debit{
+		SourceAccount: "001001234567891",
+		Amount:        52000,
+		Fee:           500,
+		Remark:        "QRIS 001001234567891 936000140000000001 123456",
+		Reference:     "123456",
+	}).Return(&intrabank.OverbookingResult{
+		JournalSequence:      "JRN001",
+		TransactionReference: "123456",
+	}, nil)
+	repoMock.EXPECT().RecordPosting(mock.Anything, mock.MatchedBy(func(transaction *intrabank.Transaction) bool {
+		return transaction.SequenceJournal == "JRN001" && transaction.Status == intrabank.TransactionPending
+	})).Return(nil)
+
+	acquirerMock.EXPECT().Pay(mock.Anything, &AcquirerPayment{
+		SourceAccount:        "001001234567891",
+		SourceName:           "Olivia Rodrigo",
+		Merchant:             registeredMerchant,
+		Payload:              "000201",
+		Amount:               50000,
+		Tip:                  2000,
+		Fee:                  500,
+		Reference:            "123456",
+		Remark:               "QRIS 001001234567891 936000140000000001 123456",
+		TransactionReference: "123456",
+	}).Return(&AcquirerResult{
+		JournalSequence:      "000001",
+		TransactionReference: "QRIS000001",
+	}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "936000140000000001",
+		Amount:             52000,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &intrabank.Transaction{
+		SequenceNumber:       "123456",
+		UserID:               "123",
+		Destination:          "936000140000000001",
+		Amount:               52000,
+		TransactionType:      "qris",
+		Remarks:              "QRIS 001001234567891 936000140000000001 123456",
+		Status:               intrabank.TransactionSuccess,
+		Fee:                  "500",
+		DestinationName:      "KOPI KENANGAN",
+		SequenceJournal:      "JRN001",
+		TransactionReference: "QRIS000001",
+	}, transaction)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	acquirerMock.AssertExpectations(t)
+}
+
+func TestQRISDoPaymentFailed_NotQRISSequence(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             100000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			Status:             "CREATED",
+		}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrInvalidSequenceNumber).
+		SetMsg("Your payment request was rejected. Please try again."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestQRISDoPaymentFailed_OTPRequired(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{Threshold: 1_000_000}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             2_000_000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "936000140000000001",
+			TransactionType:    "qris",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").
+		Return(&Payment{SequenceNumber: "123456", Merchant: registeredMerchant, Amount: 2_000_000}, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "936000140000000001",
+		Amount:             2_000_000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, intrabank.ErrOTPRequired).
+		SetMsg("Please verify this payment with the OTP sent to you."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestQRISDoPaymentFailed_PaymentRejected(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		acquirerMock    = NewMockAcquirer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, acquirerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             50000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "936000140000000001",
+			TransactionType:    "qris",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").
+		Return(&Payment{SequenceNumber: "123456", Merchant: registeredMerchant, Amount: 50000}, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "qris", mock.Anything, mock.Anything).
+		Return(50000, nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("firebase-1", nil)
+	repoMock.EXPECT().FailTransaction(mock.Anything, mock.MatchedBy(func(transaction *intrabank.Transaction) bool {
+		return transaction.Status == intrabank.TransactionFailed && transaction.StatusCode == "05"
+	}), mock.MatchedBy(func(outbox []*intrabank.OutboxMessage) bool {
+		return len(outbox) == 2
+	})).Return(nil)
+
+	corebankingMock.EXPECT().DebitPayment(mock.Anything, mock.Anything).
+		Return(&intrabank.OverbookingResult{JournalSequence: "JRN001", TransactionReference: "123456"}, nil)
+	repoMock.EXPECT().RecordPosting(mock.Anything, mock.Anything).
+		Return(nil)
+	corebankingMock.EXPECT().ReversePayment(mock.Anything, mock.MatchedBy(func(debit *Debit) bool {
+		return debit.Reference == "123456" && debit.SourceAccount == "001001234567891"
+	})).Return(&intrabank.OverbookingResult{JournalSequence: "JRN002"}, nil)
+
+	acquirerMock.EXPECT().Pay(mock.Anything, mock.Anything).
+		Return(nil, &PaymentRejection{StatusCode: "05", Description: "do not honor"})
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "936000140000000001",
+		Amount:             50000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrPaymentFailed).
+		SetMsg("Your payment was rejected by the merchant's bank. The amount has been returned to your account."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	acquirerMock.AssertExpectations(t)
+}
+
+func TestQRISDoPaymentFailed_PaymentOutcomeUnknown(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		acquirerMock    = NewMockAcquirer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, acquirerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             50000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "936000140000000001",
+			TransactionType:    "qris",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").
+		Return(&Payment{SequenceNumber: "123456", Merchant: registeredMerchant, Amount: 50000}, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "qris", mock.Anything, mock.Anything).
+		Return(50000, nil)
+
+	corebankingMock.EXPECT().DebitPayment(mock.Anything, mock.Anything).
+		Return(&intrabank.OverbookingResult{JournalSequence: "JRN001", TransactionReference: "123456"}, nil)
+	repoMock.EXPECT().RecordPosting(mock.Anything, mock.Anything).
+		Return(nil)
+
+	acquirerMock.EXPECT().Pay(mock.Anything, mock.Anything).
+		Return(nil, errors.New("timeout"))
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "936000140000000001",
+		Amount:             50000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrPaymentPending).
+		SetMsg("Your payment is being processed. Please check your transaction history."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	acquirerMock.AssertExpectations(t)
+}
+
+func TestQRISDoPaymentFailed_ReversalFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		acquirerMock    = NewMockAcquirer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, acquirerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             50000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "936000140000000001",
+			TransactionType:    "qris",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").
+		Return(&Payment{SequenceNumber: "123456", Merchant: registeredMerchant, Amount: 50000}, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "qris", mock.Anything, mock.Anything).
+		Return(50000, nil)
+	repoMock.EXPECT().RecordPosting(mock.Anything, mock.Anything).
+		Return(nil)
+
+	corebankingMock.EXPECT().DebitPayment(mock.Anything, mock.Anything).
+		Return(&intrabank.OverbookingResult{JournalSequence: "JRN001", TransactionReference: "123456"}, nil)
+	corebankingMock.EXPECT().ReversePayment(mock.Anything, mock.Anything).
+		Return(nil, errors.New("timeout"))
+	acquirerMock.EXPECT().Pay(mock.Anything, mock.Anything).
+		Return(nil, &PaymentRejection{StatusCode: "05", Description: "do not honor"})
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "936000140000000001",
+		Amount:             50000,
+	})
+
+	assert.Nil(t, transaction)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrPaymentPending).
+		SetMsg("Your payment is being processed. Please check your transaction history."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	acquirerMock.AssertExpectations(t)
+}
+
+func TestGenerateCodeSuccess_Static(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		rendererMock    = NewMockCodeRenderer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, rendererMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&intrabank.Account{
+			CIF:    "1234567",
+			Name:   "Olivia Rodrigo",
+			Status: "1",
+		}, nil)
+
+	repoMock.EXPECT().InsertCode(mock.Anything, mock.MatchedBy(func(code *Code) bool {
+		return code.UserID == 123 && code.AccountNumber == "001001234567891" &&
+			code.Name == "Olivia Rodrigo" && code.Amount == 0 && code.ExpiresAt.IsZero()
+	})).Run(func(_ context.Context, code *Code) {
+		code.ID = 42
+	}).Return(nil)
+
+	rendererMock.EXPECT().PNG(mock.Anything).
+		Return([]byte("png"), nil)
+
+	code, err := svc.GenerateCode(ctx, &CodeInput{AccountNumber: "001001234567891"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("png"), code.Image)
+	p, err := Parse(code.Payload)
+	assert.Nil(t, err)
+	assert.Equal(t, "9360099900000000424", p.Merchant.PAN)
+	assert.False(t, p.IsDynamic())
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	rendererMock.AssertExpectations(t)
+}
+
+func TestGenerateCodeSuccess_FixedAmount(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		rendererMock    = NewMockCodeRenderer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, rendererMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&intrabank.Account{
+			CIF:    "1234567",
+			Name:   "Olivia Rodrigo",
+			Status: "1",
+		}, nil)
+
+	repoMock.EXPECT().InsertCode(mock.Anything, mock.MatchedBy(func(code *Code) bool {
+		return code.Amount == 75000 && code.ExpiresAt.Sub(code.CreatedAt) == 30*time.Minute
+	})).Return(nil)
+
+	rendererMock.EXPECT().PNG(mock.Anything).
+		Return([]byte("png"), nil)
+
+	code, err := svc.GenerateCode(ctx, &CodeInput{
+		AccountNumber: "001001234567891",
+		Amount:        75000,
+		ExpiresIn:     30 * time.Minute,
+	})
+
+	assert.Nil(t, err)
+	p, err := Parse(code.Payload)
+	assert.Nil(t, err)
+	assert.True(t, p.IsDynamic())
+	assert.Equal(t, intrabank.Money(75000), p.Amount)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	rendererMock.AssertExpectations(t)
+}
+
+func TestGenerateCodeFailed_InvalidExpiry(t *testing.T) {
+	var (
+		svc = NewService(logger.New(), NewMockRepository(t), NewMockCoreBanking(t), NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	tests := []struct {
+		name string
+		in   *CodeInput
+	}{
+		{name: "static code", in: &CodeInput{AccountNumber: "001001234567891", ExpiresIn: time.Hour}},
+		{name: "too long", in: &CodeInput{AccountNumber: "001001234567891", Amount: 75000, ExpiresIn: 48 * time.Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := svc.GenerateCode(ctx, tt.in)
+
+			assert.Nil(t, code)
+			assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidCode).
+				SetMsg("Only a QR code with an amount can expire, within 24h0m0s."), err)
+		})
+	}
+}
+
+func TestGenerateCodeFailed_AccountNotOwned(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), NewMockRepository(t), corebankingMock, NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&intrabank.Account{
+			CIF:    "7654321",
+			Name:   "Sabrina Carpenter",
+			Status: "1",
+		}, nil)
+
+	code, err := svc.GenerateCode(ctx, &CodeInput{AccountNumber: "001001234567892"})
+
+	assert.Nil(t, code)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, intrabank.ErrSourceAccountNotOwned).
+		SetMsg("You can only receive money into your own account."), err)
+
+	corebankingMock.AssertExpectations(t)
+}
+
+func TestGetCodeSuccess(t *testing.T) {
+	var (
+		repoMock     = NewMockRepository(t)
+		rendererMock = NewMockCodeRenderer(t)
+		svc          = NewService(logger.New(), repoMock, NewMockCoreBanking(t), NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, rendererMock)
+		transfers    = []*intrabank.Transaction{{SequenceNumber: "123456", Amount: 50000, Status: intrabank.TransactionSuccess}}
+		credits      = []*InboundCredit{{NetworkReference: "NET-1", Amount: 25000, JournalSequence: "J-1"}}
+		ctx          = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	repoMock.EXPECT().GetCode(mock.Anything, int64(42)).
+		Return(&Code{ID: 42, UserID: 123, AccountNumber: "001001234567891", Name: "Olivia Rodrigo"}, nil)
+	repoMock.EXPECT().ListCodeTransfers(mock.Anything, int64(42)).
+		Return(transfers, nil)
+	repoMock.EXPECT().ListCodeCredits(mock.Anything, int64(42)).
+		Return(credits, nil)
+
+	rendererMock.EXPECT().PNG(mock.Anything).
+		Return([]byte("png"), nil)
+
+	code, err := svc.GetCode(ctx, 42)
+
+	assert.Nil(t, err)
+	assert.Equal(t, transfers, code.Transfers)
+	assert.Equal(t, credits, code.Credits)
+	assert.NotEmpty(t, code.Payload)
+
+	repoMock.AssertExpectations(t)
+	rendererMock.AssertExpectations(t)
+}
+
+func TestGetCodeFailed_OtherUser(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockCoreBanking(t), NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx      = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	repoMock.EXPECT().GetCode(mock.Anything, int64(42)).
+		Return(&Code{ID: 42, UserID: 456}, nil)
+
+	code, err := svc.GetCode(ctx, 42)
+
+	assert.Nil(t, code)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrCodeNotFound).
+		SetMsg("QR code not found."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestQRISInquiryFailed_CodeExpired(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		acquirerMock    = NewMockAcquirer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, acquirerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		code            = &Code{ID: 42, UserID: 456, AccountNumber: "001001234567892", Name: "Sabrina Carpenter", Amount: 75000, ExpiresAt: time.Now().Add(-time.Minute)}
+		raw             = testIssuer.Encode(code)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetCode(mock.Anything, int64(42)).
+		Return(code, nil)
+
+	p, err := svc.Inquiry(ctx, &InquiryInput{
+		Payload:       raw,
+		SourceAccount: "001001234567891",
+	})
+
+	assert.Nil(t, p)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrCodeExpired).
+		SetMsg("This QR code has expired. Please ask the recipient for a new one."), err)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	acquirerMock.AssertExpectations(t)
+}
+
+func TestQRISInquirySuccess_GeneratedCode(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		acquirerMock    = NewMockAcquirer(t)
+		seqGenMock      = NewMockSequenceGenerator(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, acquirerMock, seqGenMock, intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		code            = &Code{ID: 42, UserID: 456, AccountNumber: "001001234567892", Name: "Sabrina Carpenter", Amount: 75000, ExpiresAt: time.Now().Add(time.Hour)}
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(&intrabank.Account{
+			CIF:              "1234567",
+			Name:             "Olivia Rodrigo",
+			Status:           "1",
+			AvailableBalance: 10_000_000,
+		}, nil)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&intrabank.Account{
+			CIF:    "7654321",
+			Name:   "Sabrina Carpenter",
+			Status: "1",
+		}, nil)
+
+	repoMock.EXPECT().GetCode(mock.Anything, int64(42)).
+		Return(code, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "qris", mock.Anything, mock.Anything).
+		Return(0, nil)
+	repoMock.EXPECT().InsertSequence(mock.Anything, mock.MatchedBy(func(seq *intrabank.Sequence) bool {
+		return seq.Amount == 75000 && seq.DestinationAccount == "001001234567892" && seq.DestinationName == "Sabrina Carpenter"
+	})).Return(nil)
+	repoMock.EXPECT().InsertPayment(mock.Anything, mock.MatchedBy(func(payment *Payment) bool {
+		return payment.CodeID == 42
+	})).Return(nil)
+
+	seqGenMock.EXPECT().Generate().
+		Return("123456", nil)
+
+	p, err := svc.Inquiry(ctx, &InquiryInput{
+		Payload:       testIssuer.Encode(code),
+		SourceAccount: "001001234567891",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), p.CodeID)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	acquirerMock.AssertExpectations(t)
+	seqGenMock.AssertExpectations(t)
+}
+
+func TestQRISDoPaymentSuccess_GeneratedCode(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		acquirerMock    = NewMockAcquirer(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, acquirerMock, NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetCoreStatus(mock.Anything).Return(&intrabank.CoreStatus{
+		Status:        "FINISHED",
+		StandInStatus: "N",
+	}, nil)
+
+	repoMock.EXPECT().GetSequence(mock.Anything, "123456").
+		Return(&intrabank.Sequence{
+			SequenceNumber:     "123456",
+			UserID:             123,
+			DeviceID:           "device-1",
+			Amount:             75000,
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			SourceName:         "Olivia Rodrigo",
+			DestinationName:    "Sabrina Carpenter",
+			TransactionType:    "qris",
+			Status:             "CREATED",
+		}, nil)
+	repoMock.EXPECT().GetPayment(mock.Anything, "123456").
+		Return(&Payment{
+			SequenceNumber: "123456",
+			Payload:        "000201",
+			Merchant:       &Merchant{GUID: testIssuer.GUID, PAN: "9360099900000000424", ID: "001001234567892", Name: "SABRINA CARPENTER"},
+			CodeID:         42,
+			Amount:         75000,
+		}, nil)
+	repoMock.EXPECT().GetCode(mock.Anything, int64(42)).
+		Return(&Code{ID: 42, UserID: 456, AccountNumber: "001001234567892", Name: "Sabrina Carpenter", Amount: 75000, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	repoMock.EXPECT().GetLimits(mock.Anything).
+		Return(&intrabank.Limits{MinAmount: 1, MaxAmount: 10_000_000, MaxDailyAmount: 20_000_000}, nil)
+	repoMock.EXPECT().AcquireSequence(mock.Anything, "123456", "").
+		Return(nil)
+	repoMock.EXPECT().InsertTransaction(mock.Anything, mock.Anything).
+		Return(nil)
+	repoMock.EXPECT().SumTransferAmountOfType(mock.Anything, "123", "qris", mock.Anything, mock.Anything).
+		Return(75000, nil)
+	repoMock.EXPECT().GetFirebaseID(mock.Anything, 123).
+		Return("", nil)
+	repoMock.EXPECT().CompleteTransaction(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	corebankingMock.EXPECT().PerformOverbooking(mock.Anything, &intrabank.OverbookingInput{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             75000,
+		Remark:             "QRIS 001001234567891 9360099900000000424 123456",
+		Reference:          "123456",
+	}).Return(&intrabank.OverbookingResult{
+		JournalSequence:      "JRN001",
+		TransactionReference: "123456",
+	}, nil)
+
+	transaction, err := svc.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             75000,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, intrabank.TransactionSuccess, transaction.Status)
+	assert.Equal(t, "JRN001", transaction.SequenceJournal)
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+	acquirerMock.AssertExpectations(t)
+}
+
+func TestReceiveCreditSuccess(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		repoMock        = NewMockRepository(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+	)
+
+	repoMock.EXPECT().GetCredit(mock.Anything, "NET-1").
+		Return(nil, ErrCreditNotFound)
+	repoMock.EXPECT().GetCode(mock.Anything, int64(42)).
+		Return(&Code{ID: 42, UserID: 456, AccountNumber: "001001234567892", Name: "Sabrina Carpenter"}, nil)
+	repoMock.EXPECT().InsertCredit(mock.Anything, mock.MatchedBy(func(credit *InboundCredit) bool {
+		return credit.CodeID == 42 && credit.AccountNumber == "001001234567892"
+	})).Return(nil)
+	repoMock.EXPECT().UpdateCreditPosting(mock.Anything, mock.MatchedBy(func(credit *InboundCredit) bool {
+		return credit.JournalSequence == "JRN001" && credit.TransactionReference == "NET-1"
+	})).Return(nil)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567892").
+		Return(&intrabank.Account{CIF: "7654321", Name: "Sabrina Carpenter", Status: "1"}, nil)
+	corebankingMock.EXPECT().CreditInbound(mock.Anything, mock.Anything).
+		Return(&intrabank.OverbookingResult{JournalSequence: "JRN001", TransactionReference: "NET-1"}, nil)
+
+	credit, err := svc.ReceiveCredit(context.Background(), &InboundCredit{
+		NetworkReference: "NET-1",
+		ReferenceLabel:   "QR0000000042",
+		Amount:           25000,
+		PayerName:        "Taylor Swift",
+		PayerBank:        "BANK LAIN",
+	})
+
+	assert.Nil(t, err)
+	assert.True(t, credit.Posted())
+
+	corebankingMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestReceiveCreditSuccess_Retried(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockCoreBanking(t), NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+		posted   = &InboundCredit{NetworkReference: "NET-1", CodeID: 42, Amount: 25000, JournalSequence: "JRN001"}
+	)
+
+	repoMock.EXPECT().GetCredit(mock.Anything, "NET-1").
+		Return(posted, nil)
+
+	credit, err := svc.ReceiveCredit(context.Background(), &InboundCredit{
+		NetworkReference: "NET-1",
+		ReferenceLabel:   "QR0000000042",
+		Amount:           25000,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, posted, credit)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestReceiveCreditFailed_AmountMismatch(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockCoreBanking(t), NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+	)
+
+	repoMock.EXPECT().GetCredit(mock.Anything, "NET-1").
+		Return(nil, ErrCreditNotFound)
+	repoMock.EXPECT().GetCode(mock.Anything, int64(42)).
+		Return(&Code{ID: 42, UserID: 456, AccountNumber: "001001234567892", Amount: 75000, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	credit, err := svc.ReceiveCredit(context.Background(), &InboundCredit{
+		NetworkReference: "NET-1",
+		PAN:              testIssuer.PAN(42),
+		Amount:           25000,
+	})
+
+	assert.Nil(t, credit)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, intrabank.ErrInvalidAmount).
+		SetMsg("The amount does not match the QR code."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestReceiveCreditFailed_UnknownCode(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockCoreBanking(t), NewMockAcquirer(t), NewMockSequenceGenerator(t), intrabank.SequenceValidity{}, NewMockTransactionAuthorizer(t), intrabank.StepUpPolicy{}, intrabank.FeePolicy{}, testIssuer, NewMockCodeRenderer(t))
+	)
+
+	repoMock.EXPECT().GetCredit(mock.Anything, "NET-1").
+		Return(nil, ErrCreditNotFound)
+
+	credit, err := svc.ReceiveCredit(context.Background(), &InboundCredit{
+		NetworkReference: "NET-1",
+		PAN:              "936000140000000001",
+		Amount:           25000,
+	})
+
+	assert.Nil(t, credit)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrCodeNotFound).
+		SetMsg("The QR code is not a code of Bank Yaya."), err)
+
+	repoMock.AssertExpectations(t)
+}
@@ -0,0 +1,87 @@
+package qris
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// dataObject is an EMVCo data object, a two digit ID followed by a two digit length and the value.
+type dataObject struct {
+	ID    string
+	Value string
+}
+
+// parseTLV parses the data objects of the EMVCo template.
+// The IDs must be unique and every length must fit the remaining data.
+func parseTLV(s string) ([]dataObject, error) {
+	var objects []dataObject
+	seen := make(map[string]bool)
+	for i := 0; i < len(s); {
+		if i+4 > len(s) {
+			return nil, fmt.Errorf("%w: truncated data object at %d", ErrInvalidPayload, i)
+		}
+		id := s[i : i+2]
+		if _, err := strconv.Atoi(id); err != nil {
+			return nil, fmt.Errorf("%w: invalid data object id %q", ErrInvalidPayload, id)
+		}
+		length, err := strconv.Atoi(s[i+2 : i+4])
+		if err != nil || length == 0 {
+			return nil, fmt.Errorf("%w: invalid length of data object %s", ErrInvalidPayload, id)
+		}
+		if i+4+length > len(s) {
+			return nil, fmt.Errorf("%w: data object %s exceeds the payload", ErrInvalidPayload, id)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("%w: duplicate data object %s", ErrInvalidPayload, id)
+		}
+		seen[id] = true
+		objects = append(objects, dataObject{ID: id, Value: s[i+4 : i+4+length]})
+		i += 4 + length
+	}
+	return objects, nil
+}
+
+// encodeTLV encodes the data objects, the objects with an empty value are left out.
+func encodeTLV(objects ...dataObject) string {
+	var b strings.Builder
+	for _, o := range objects {
+		if o.Value == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "%s%02d%s", o.ID, len(o.Value), o.Value)
+	}
+	return b.String()
+}
+
+// lookup returns the value of the data object with the ID.
+func lookup(objects []dataObject, id string) string {
+	for _, o := range objects {
+		if o.ID == id {
+			return o.Value
+		}
+	}
+	return ""
+}
+
+// crc16 calculates the CRC-16/CCITT-FALSE checksum used by the EMVCo payloads,
+// with the polynomial 0x1021 and the initial value 0xFFFF.
+func crc16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// checksum returns the CRC of the payload as four uppercase hexadecimal digits.
+func checksum(data string) string {
+	return fmt.Sprintf("%04X", crc16(data))
+}
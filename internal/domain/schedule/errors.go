@@ -0,0 +1,20 @@
+package schedule
+
+import "errors"
+
+var (
+	// ErrGeneral indicates a general error.
+	ErrGeneral = errors.New("something went wrong")
+
+	// ErrUnauthenticatedUser indicates that the user is not authenticated.
+	ErrUnauthenticatedUser = errors.New("unauthenticated user")
+
+	// ErrInvalidSchedule is returned when the transfer instruction or its execution date is invalid.
+	ErrInvalidSchedule = errors.New("invalid schedule")
+
+	// ErrScheduleNotFound is returned when the requested schedule cannot be found.
+	ErrScheduleNotFound = errors.New("schedule not found")
+
+	// ErrScheduleNotCancellable is returned when the schedule is no longer waiting for execution.
+	ErrScheduleNotCancellable = errors.New("schedule not cancellable")
+)
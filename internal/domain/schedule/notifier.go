@@ -0,0 +1,13 @@
+package schedule
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// Notifier sends scheduled transfer notifications to users.
+type Notifier interface {
+	// Notify sends a transfer notification to the specified user.
+	Notify(ctx context.Context, notification *intrabank.Notification) error
+}
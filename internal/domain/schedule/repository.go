@@ -0,0 +1,40 @@
+package schedule
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines methods for managing schedule persistence.
+type Repository interface {
+	// Insert inserts a schedule into the persistence repository.
+	// Returns an error if the operation fails.
+	Insert(ctx context.Context, schedule *Schedule) error
+
+	// Get retrieves the user's schedule by its ID.
+	// Returns ErrScheduleNotFound if the user has no schedule with the ID.
+	Get(ctx context.Context, userID int, id int64) (*Schedule, error)
+
+	// List retrieves all schedules of the user, ordered by the execution time.
+	// Returns the schedules and an error if retrieval fails.
+	List(ctx context.Context, userID int) ([]*Schedule, error)
+
+	// Cancel atomically cancels the user's schedule if it is still waiting for execution.
+	// Returns ErrScheduleNotCancellable if the schedule has already been picked up.
+	Cancel(ctx context.Context, userID int, id int64) error
+
+	// AcquireDue atomically moves at most limit schedules due at the given time to the processing status
+	// and leases them until leaseUntil, so concurrent workers never execute the same schedule.
+	// Processing schedules whose lease has expired, e.g. after a crash, are acquired again.
+	// Returns the acquired schedules together with their users.
+	AcquireDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*Schedule, error)
+
+	// SetSequence stores the sequence number created for the payment of the schedule,
+	// so a schedule acquired again resumes the same payment.
+	// Returns an error if the operation fails.
+	SetSequence(ctx context.Context, id int64, sequenceNumber string) error
+
+	// Finish stores the outcome of an executed schedule.
+	// Returns an error if the operation fails.
+	Finish(ctx context.Context, schedule *Schedule) error
+}
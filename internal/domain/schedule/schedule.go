@@ -0,0 +1,120 @@
+// Package schedule provides structures and functionality for future-dated intrabank transfers.
+// A schedule stores a validated transfer instruction that a background worker executes
+// through the intrabank transfer flow on its execution date.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+const (
+	// StatusScheduled represents a schedule that is waiting for its execution date.
+	StatusScheduled = "SCHEDULED"
+	// StatusProcessing represents a schedule that has been picked up by the worker.
+	StatusProcessing = "PROCESSING"
+	// StatusExecuted represents a schedule whose transfer has succeeded.
+	StatusExecuted = "EXECUTED"
+	// StatusFailed represents a schedule whose transfer has failed.
+	StatusFailed = "FAILED"
+	// StatusCancelled represents a schedule cancelled by the user.
+	StatusCancelled = "CANCELLED"
+)
+
+const (
+	// executionHour is the hour in Jakarta time at which a schedule is executed on its execution date.
+	executionHour = 8
+	// maxScheduleDays is how many days ahead a transfer can be scheduled.
+	maxScheduleDays = 365
+	// referenceDateLayout is the layout of the execution date in the reference of a schedule.
+	referenceDateLayout = "20060102"
+)
+
+// ExecutionTime returns the time at which a schedule for the given date is executed.
+// Only the year, month and day of the date are used.
+func ExecutionTime(date time.Time) time.Time {
+	start, _ := intrabank.BusinessDay(time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, date.Location()))
+	return start.Add(executionHour * time.Hour)
+}
+
+// User represents the owner of a schedule.
+type User struct {
+	ID         int
+	CIF        string
+	Name       string
+	Email      string
+	FirebaseID string
+}
+
+// Schedule represents a future-dated intrabank transfer instruction.
+type Schedule struct {
+	ID                   int64
+	User                 *User
+	SourceAccount        string
+	DestinationAccount   string
+	DestinationName      string
+	Amount               intrabank.Money
+	ExecuteAt            time.Time
+	Status               string
+	SequenceNumber       string
+	TransactionReference string
+	FailureReason        string
+	ExecutedAt           time.Time
+	CreatedAt            time.Time
+}
+
+// Valid checks if the schedule can be created at the given time.
+// The execution time must be after the current business day and at most maxScheduleDays ahead.
+func (s *Schedule) Valid(now time.Time) bool {
+	_, today := intrabank.BusinessDay(now)
+	return s.Amount > 0 &&
+		s.SourceAccount != "" &&
+		s.DestinationAccount != "" &&
+		s.SourceAccount != s.DestinationAccount &&
+		!s.ExecuteAt.Before(today) &&
+		!s.ExecuteAt.After(now.AddDate(0, 0, maxScheduleDays))
+}
+
+// Cancellable checks if the schedule can still be cancelled.
+func (s *Schedule) Cancellable() bool {
+	return s.Status == StatusScheduled
+}
+
+// Reference returns the reference the transaction OTP authorizing the schedule is bound to,
+// e.g. "SCHEDULE-001001234567891-001001234567892-100000-20250325" for its accounts, amount and execution date.
+func (s *Schedule) Reference() string {
+	return fmt.Sprintf("SCHEDULE-%s-%s-%d-%s",
+		s.SourceAccount, s.DestinationAccount, s.Amount, s.ExecuteAt.In(intrabank.BusinessLocation).Format(referenceDateLayout))
+}
+
+// IdempotencyKey returns the payment idempotency key of the schedule,
+// so the schedule can never move money twice.
+func (s *Schedule) IdempotencyKey() string {
+	return intrabank.InternalIdempotencyKey("schedule-" + strconv.FormatInt(s.ID, 10))
+}
+
+// Sequence returns the inquiry sequence of the scheduled transfer.
+func (s *Schedule) Sequence() *intrabank.Sequence {
+	return &intrabank.Sequence{
+		Amount:             s.Amount,
+		SourceAccount:      s.SourceAccount,
+		DestinationAccount: s.DestinationAccount,
+	}
+}
+
+// Execute marks the schedule as executed by the transaction with the reference.
+func (s *Schedule) Execute(transactionReference string, executedAt time.Time) {
+	s.Status = StatusExecuted
+	s.TransactionReference = transactionReference
+	s.ExecutedAt = executedAt
+}
+
+// Fail marks the schedule as failed with the reason shown to the user.
+func (s *Schedule) Fail(reason string, executedAt time.Time) {
+	s.Status = StatusFailed
+	s.FailureReason = reason
+	s.ExecutedAt = executedAt
+}
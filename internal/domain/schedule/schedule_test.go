@@ -0,0 +1,63 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionTime(t *testing.T) {
+	date := time.Date(2025, 3, 26, 0, 0, 0, 0, time.UTC)
+	// 08:00 in Jakarta is 01:00 UTC.
+	assert.Equal(t, time.Date(2025, 3, 26, 1, 0, 0, 0, time.UTC), ExecutionTime(date).UTC())
+}
+
+func TestScheduleValid(t *testing.T) {
+	// 2025-03-25 10:00 in Jakarta.
+	now := time.Date(2025, 3, 25, 3, 0, 0, 0, time.UTC)
+	tomorrow := ExecutionTime(time.Date(2025, 3, 26, 0, 0, 0, 0, time.UTC))
+	today := ExecutionTime(time.Date(2025, 3, 25, 0, 0, 0, 0, time.UTC))
+
+	valid := func() *Schedule {
+		return &Schedule{
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			Amount:             100000,
+			ExecuteAt:          tomorrow,
+		}
+	}
+	assert.True(t, valid().Valid(now))
+
+	sch := valid()
+	sch.ExecuteAt = today
+	assert.False(t, sch.Valid(now))
+
+	sch = valid()
+	sch.ExecuteAt = now.AddDate(2, 0, 0)
+	assert.False(t, sch.Valid(now))
+
+	sch = valid()
+	sch.Amount = 0
+	assert.False(t, sch.Valid(now))
+
+	sch = valid()
+	sch.DestinationAccount = sch.SourceAccount
+	assert.False(t, sch.Valid(now))
+}
+
+func TestScheduleCancellable(t *testing.T) {
+	assert.True(t, (&Schedule{Status: StatusScheduled}).Cancellable())
+	assert.False(t, (&Schedule{Status: StatusProcessing}).Cancellable())
+	assert.False(t, (&Schedule{Status: StatusExecuted}).Cancellable())
+}
+
+func TestScheduleReference(t *testing.T) {
+	s := &Schedule{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		ExecuteAt:          ExecutionTime(time.Date(2025, 3, 25, 0, 0, 0, 0, time.UTC)),
+	}
+	assert.Equal(t, "SCHEDULE-001001234567891-001001234567892-100000-20250325", s.Reference())
+}
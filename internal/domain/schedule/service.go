@@ -0,0 +1,280 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+const (
+	domainName          = "schedule"
+	dueBatchSize        = 50
+	leaseDuration       = 10 * time.Minute
+	scheduleFailSubject = "Transfer Terjadwal Gagal"
+)
+
+// Service handles scheduled intrabank transfers.
+type Service struct {
+	log        *logger.Logger
+	repo       Repository
+	transferer Transferer
+	notifier   Notifier
+	authorizer TransactionAuthorizer
+}
+
+// NewService creates a new instance of Service.
+func NewService(
+	log *logger.Logger,
+	repo Repository,
+	transferer Transferer,
+	notifier Notifier,
+	authorizer TransactionAuthorizer,
+) *Service {
+	return &Service{
+		log:        log,
+		repo:       repo,
+		transferer: transferer,
+		notifier:   notifier,
+		authorizer: authorizer,
+	}
+}
+
+// Create validates the accounts of the transfer instruction and stores it for the execution time,
+// once the user has authorized it with the transaction OTP bound to its reference.
+// The limits, the fee and the balance are checked by the inquiry on the execution date,
+// and the schedule is then paid without a further OTP.
+func (s *Service) Create(ctx context.Context, schedule *Schedule, otpID int, otpCode string) (*Schedule, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Create").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+	if !schedule.Valid(time.Now()) {
+		s.log.DomainUsecase(domainName, "Create").Error(ErrInvalidSchedule)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidSchedule).
+			SetMsg("Your scheduled transfer is invalid. Please choose a date within the next year.")
+	}
+
+	sequence, err := s.transferer.ValidateAccounts(ctx, schedule.Sequence())
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Create").Errorf("ValidateAccounts: %v", err)
+		return nil, err
+	}
+
+	// The instruction is checked before the OTP, so an invalid instruction does not use it up.
+	if otpID == 0 || otpCode == "" {
+		s.log.DomainUsecase(domainName, "Create").Errorf("reference (%v): %v", schedule.Reference(), intrabank.ErrOTPRequired)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrOTPRequired).
+			SetMsg("Please verify this scheduled transfer with the OTP sent to you.")
+	}
+	if err := s.authorizer.VerifyTransaction(ctx, otpID, otpCode, schedule.Reference()); err != nil {
+		s.log.DomainUsecase(domainName, "Create").Errorf("VerifyTransaction: %v", err)
+		return nil, err
+	}
+
+	schedule.User = &User{
+		ID:    user.ID,
+		CIF:   user.CIF,
+		Name:  user.Name,
+		Email: user.Email,
+	}
+	schedule.DestinationName = sequence.DestinationName
+	schedule.Status = StatusScheduled
+
+	err = s.repo.Insert(ctx, schedule)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Create").Errorf("Insert: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return schedule, nil
+}
+
+// List returns all schedules of the authenticated user.
+func (s *Service) List(ctx context.Context) ([]*Schedule, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "List").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	schedules, err := s.repo.List(ctx, user.ID)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "List").Errorf("List: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return schedules, nil
+}
+
+// Get returns the authenticated user's schedule, including the outcome of its execution.
+func (s *Service) Get(ctx context.Context, id int64) (*Schedule, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Get").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+	return s.get(ctx, "Get", user.ID, id)
+}
+
+// Cancel cancels the authenticated user's schedule before it is executed.
+func (s *Service) Cancel(ctx context.Context, id int64) error {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Cancel").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	schedule, err := s.get(ctx, "Cancel", user.ID, id)
+	if err != nil {
+		return err
+	}
+	if !schedule.Cancellable() {
+		s.log.DomainUsecase(domainName, "Cancel").Error(ErrScheduleNotCancellable)
+		return pkgerror.New(codes.BadRequest, ErrScheduleNotCancellable).
+			SetMsg("Your scheduled transfer can no longer be cancelled.")
+	}
+
+	// The worker may pick the schedule up between the read and the cancellation.
+	err = s.repo.Cancel(ctx, user.ID, id)
+	if errors.Is(err, ErrScheduleNotCancellable) {
+		s.log.DomainUsecase(domainName, "Cancel").Errorf("Cancel: %v", err)
+		return pkgerror.New(codes.BadRequest, ErrScheduleNotCancellable).
+			SetMsg("Your scheduled transfer can no longer be cancelled.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Cancel").Errorf("Cancel: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return nil
+}
+
+// RunDue executes the schedules that are due.
+// It is called periodically by the background worker.
+func (s *Service) RunDue(ctx context.Context) error {
+	now := time.Now()
+	schedules, err := s.repo.AcquireDue(ctx, now, now.Add(leaseDuration), dueBatchSize)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("AcquireDue: %v", err)
+		return err
+	}
+	for _, schedule := range schedules {
+		s.execute(ctx, schedule)
+	}
+	return nil
+}
+
+func (s *Service) get(ctx context.Context, usecase string, userID int, id int64) (*Schedule, error) {
+	schedule, err := s.repo.Get(ctx, userID, id)
+	if errors.Is(err, ErrScheduleNotFound) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Get: %v", err)
+		return nil, pkgerror.New(codes.NotFound, ErrScheduleNotFound).
+			SetMsg("Scheduled transfer not found.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Get: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return schedule, nil
+}
+
+// execute runs the schedule through the intrabank inquiry and payment as its owner.
+// A schedule acquired again after its lease has expired resumes the payment of its stored sequence,
+// which returns the outcome of a payment already made instead of moving the money twice.
+func (s *Service) execute(ctx context.Context, schedule *Schedule) {
+	userCtx := ctxt.ContextWithUser(ctx, &ctxt.User{
+		ID:    schedule.User.ID,
+		CIF:   schedule.User.CIF,
+		Name:  schedule.User.Name,
+		Email: schedule.User.Email,
+	})
+
+	if schedule.SequenceNumber != "" {
+		transaction, err := s.pay(userCtx, schedule)
+		if !errors.Is(err, intrabank.ErrSequenceExpired) {
+			s.finish(ctx, schedule, transaction, err)
+			return
+		}
+		// An expired sequence has never been paid, so the schedule is paid with a new one.
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("schedule (%v) DoPayment: %v", schedule.ID, err)
+	}
+
+	sequence, err := s.transferer.Inquiry(userCtx, schedule.Sequence())
+	if err != nil {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("schedule (%v) Inquiry: %v", schedule.ID, err)
+		s.fail(ctx, schedule, err)
+		return
+	}
+	schedule.SequenceNumber = sequence.SequenceNumber
+
+	// The sequence is stored before the payment, so a crash during the payment does not pay the schedule twice.
+	if err := s.repo.SetSequence(ctx, schedule.ID, schedule.SequenceNumber); err != nil {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("schedule (%v) SetSequence: %v", schedule.ID, err)
+		return
+	}
+
+	transaction, err := s.pay(userCtx, schedule)
+	s.finish(ctx, schedule, transaction, err)
+}
+
+// pay pays the sequence of the schedule.
+func (s *Service) pay(ctx context.Context, schedule *Schedule) (*intrabank.Transaction, error) {
+	return s.transferer.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     schedule.SequenceNumber,
+		SourceAccount:      schedule.SourceAccount,
+		DestinationAccount: schedule.DestinationAccount,
+		Amount:             schedule.Amount,
+		IdempotencyKey:     schedule.IdempotencyKey(),
+		Preauthorized:      true,
+	})
+}
+
+// finish stores the outcome of the payment of the schedule.
+// A payment whose outcome is not known yet leaves the schedule processing,
+// and the payment is resumed once the lease of the schedule has expired.
+func (s *Service) finish(ctx context.Context, schedule *Schedule, transaction *intrabank.Transaction, err error) {
+	if errors.Is(err, intrabank.ErrPaymentInProgress) || errors.Is(err, intrabank.ErrPaymentPending) {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("schedule (%v) DoPayment: %v", schedule.ID, err)
+		return
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("schedule (%v) DoPayment: %v", schedule.ID, err)
+		s.fail(ctx, schedule, err)
+		return
+	}
+	schedule.Execute(transaction.TransactionReference, time.Now())
+
+	if err := s.repo.Finish(ctx, schedule); err != nil {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("schedule (%v) Finish: %v", schedule.ID, err)
+	}
+}
+
+// fail stores the failed outcome of the schedule and notifies its owner.
+func (s *Service) fail(ctx context.Context, schedule *Schedule, cause error) {
+	schedule.Fail(pkgerror.Message(cause), time.Now())
+	if err := s.repo.Finish(ctx, schedule); err != nil {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("schedule (%v) Finish: %v", schedule.ID, err)
+	}
+
+	err := s.notifier.Notify(ctx, &intrabank.Notification{
+		FirebaseID:  schedule.User.FirebaseID,
+		Subject:     scheduleFailSubject,
+		Amount:      schedule.Amount,
+		Destination: schedule.DestinationAccount,
+		Status:      intrabank.TransactionFailed,
+	})
+	if err != nil {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("schedule (%v) Notify: %v", schedule.ID, err)
+	}
+}
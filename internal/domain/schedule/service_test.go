@@ -0,0 +1,656 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+func TestCreateSuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		authorizerMock = NewMockTransactionAuthorizer(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, authorizerMock)
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	in := &Schedule{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		ExecuteAt:          time.Now().AddDate(0, 0, 7),
+	}
+
+	transfererMock.EXPECT().ValidateAccounts(mock.Anything, &intrabank.Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	}).Return(&intrabank.Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		DestinationName:    "Destination Account",
+	}, nil)
+
+	authorizerMock.EXPECT().VerifyTransaction(mock.Anything, 1, "123456", in.Reference()).
+		Return(nil)
+
+	repoMock.EXPECT().Insert(mock.Anything, mock.MatchedBy(func(sch *Schedule) bool {
+		return sch.Status == StatusScheduled &&
+			sch.User.ID == 123 &&
+			sch.DestinationName == "Destination Account"
+	})).Return(nil)
+
+	sch, err := svc.Create(ctx, in, 1, "123456")
+
+	assert.Nil(t, err)
+	assert.Equal(t, StatusScheduled, sch.Status)
+	assert.Equal(t, "Destination Account", sch.DestinationName)
+	assert.Equal(t, &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com"}, sch.User)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	authorizerMock.AssertExpectations(t)
+}
+
+func TestCreateFailed_GetUserFromContextFailed(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	sch, err := svc.Create(ctx, &Schedule{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		ExecuteAt:          time.Now().AddDate(0, 0, 7),
+	}, 1, "123456")
+
+	assert.Nil(t, sch)
+	assert.Equal(t, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+		SetMsg("Please login to continue."), err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestCreateFailed_InvalidSchedule(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	in := &Schedule{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		ExecuteAt:          time.Now().AddDate(0, 0, 7),
+	}
+	in.ExecuteAt = time.Now()
+
+	sch, err := svc.Create(ctx, in, 1, "123456")
+
+	assert.Nil(t, sch)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidSchedule).
+		SetMsg("Your scheduled transfer is invalid. Please choose a date within the next year."), err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestCreateFailed_ValidateAccountsFailed(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	validateErr := pkgerror.New(codes.BadRequest, intrabank.ErrDestinationAccountInactive).
+		SetMsg("Destination account is inactive.")
+
+	transfererMock.EXPECT().ValidateAccounts(mock.Anything, mock.Anything).
+		Return(nil, validateErr)
+
+	sch, err := svc.Create(ctx, &Schedule{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		ExecuteAt:          time.Now().AddDate(0, 0, 7),
+	}, 1, "123456")
+
+	assert.Nil(t, sch)
+	assert.Equal(t, validateErr, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestCreateFailed_InsertFailed(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		authorizerMock = NewMockTransactionAuthorizer(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, authorizerMock)
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	transfererMock.EXPECT().ValidateAccounts(mock.Anything, mock.Anything).
+		Return(&intrabank.Sequence{SequenceNumber: "123456"}, nil)
+
+	authorizerMock.EXPECT().VerifyTransaction(mock.Anything, 1, "123456", mock.Anything).
+		Return(nil)
+
+	repoMock.EXPECT().Insert(mock.Anything, mock.Anything).
+		Return(errors.New("unexpected error"))
+
+	sch, err := svc.Create(ctx, &Schedule{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		ExecuteAt:          time.Now().AddDate(0, 0, 7),
+	}, 1, "123456")
+
+	assert.Nil(t, sch)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	authorizerMock.AssertExpectations(t)
+}
+
+func TestCreateFailed_OTPRequired(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		authorizerMock = NewMockTransactionAuthorizer(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, authorizerMock)
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	transfererMock.EXPECT().ValidateAccounts(mock.Anything, mock.Anything).
+		Return(&intrabank.Sequence{SequenceNumber: "123456"}, nil)
+
+	sch, err := svc.Create(ctx, &Schedule{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		ExecuteAt:          time.Now().AddDate(0, 0, 7),
+	}, 0, "")
+
+	assert.Nil(t, sch)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, intrabank.ErrOTPRequired).
+		SetMsg("Please verify this scheduled transfer with the OTP sent to you."), err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	authorizerMock.AssertExpectations(t)
+}
+
+func TestListSuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().List(mock.Anything, 123).
+		Return([]*Schedule{{ID: 1, Status: StatusScheduled}}, nil)
+
+	schedules, err := svc.List(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*Schedule{{ID: 1, Status: StatusScheduled}}, schedules)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetFailed_ScheduleNotFound(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(1)).
+		Return(nil, ErrScheduleNotFound)
+
+	sch, err := svc.Get(ctx, 1)
+
+	assert.Nil(t, sch)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrScheduleNotFound).
+		SetMsg("Scheduled transfer not found."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestCancelSuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(1)).
+		Return(&Schedule{ID: 1, Status: StatusScheduled}, nil)
+	repoMock.EXPECT().Cancel(mock.Anything, 123, int64(1)).
+		Return(nil)
+
+	err := svc.Cancel(ctx, 1)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestCancelFailed_ScheduleExecuted(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(1)).
+		Return(&Schedule{ID: 1, Status: StatusExecuted}, nil)
+
+	err := svc.Cancel(ctx, 1)
+
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrScheduleNotCancellable).
+		SetMsg("Your scheduled transfer can no longer be cancelled."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestCancelFailed_ScheduleAcquiredByWorker(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(1)).
+		Return(&Schedule{ID: 1, Status: StatusScheduled}, nil)
+	repoMock.EXPECT().Cancel(mock.Anything, 123, int64(1)).
+		Return(ErrScheduleNotCancellable)
+
+	err := svc.Cancel(ctx, 1)
+
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrScheduleNotCancellable).
+		SetMsg("Your scheduled transfer can no longer be cancelled."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestRunDueSuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*Schedule{&Schedule{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Status:             StatusProcessing,
+		}}, nil)
+
+	transfererMock.EXPECT().Inquiry(mock.MatchedBy(func(ctx context.Context) bool {
+		user, ok := ctxt.UserFromContext(ctx)
+		return ok && user.ID == 123 && user.Email == "olivia@gmail.com"
+	}), &intrabank.Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	}).Return(&intrabank.Sequence{SequenceNumber: "123456"}, nil)
+	repoMock.EXPECT().SetSequence(mock.Anything, int64(1), "123456").
+		Return(nil)
+	transfererMock.EXPECT().DoPayment(mock.Anything, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		IdempotencyKey:     "internal:schedule-1",
+		Preauthorized:      true,
+	}).Return(&intrabank.Transaction{TransactionReference: "REF123"}, nil)
+
+	repoMock.EXPECT().Finish(mock.Anything, mock.MatchedBy(func(sch *Schedule) bool {
+		return sch.Status == StatusExecuted &&
+			sch.SequenceNumber == "123456" &&
+			sch.TransactionReference == "REF123"
+	})).Return(nil)
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDueFailed_InquiryFailed(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*Schedule{&Schedule{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Status:             StatusProcessing,
+		}}, nil)
+
+	transfererMock.EXPECT().Inquiry(mock.Anything, mock.Anything).
+		Return(nil, pkgerror.New(codes.BadRequest, intrabank.ErrEODInProgress).
+			SetMsg("End of day process is running."))
+
+	repoMock.EXPECT().Finish(mock.Anything, mock.MatchedBy(func(sch *Schedule) bool {
+		return sch.Status == StatusFailed &&
+			sch.FailureReason == "End of day process is running." &&
+			!sch.ExecutedAt.IsZero()
+	})).Return(nil)
+
+	notifierMock.EXPECT().Notify(mock.Anything, &intrabank.Notification{
+		FirebaseID:  "firebase-id",
+		Subject:     "Transfer Terjadwal Gagal",
+		Amount:      100000,
+		Destination: "001001234567892",
+		Status:      "failed",
+	}).Return(nil)
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDueFailed_DoPaymentFailed(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*Schedule{&Schedule{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Status:             StatusProcessing,
+		}}, nil)
+
+	transfererMock.EXPECT().Inquiry(mock.Anything, mock.Anything).
+		Return(&intrabank.Sequence{SequenceNumber: "123456"}, nil)
+	repoMock.EXPECT().SetSequence(mock.Anything, int64(1), "123456").
+		Return(nil)
+	transfererMock.EXPECT().DoPayment(mock.Anything, mock.Anything).
+		Return(nil, errors.New("unexpected error"))
+
+	repoMock.EXPECT().Finish(mock.Anything, mock.MatchedBy(func(sch *Schedule) bool {
+		return sch.Status == StatusFailed &&
+			sch.SequenceNumber == "123456" &&
+			sch.FailureReason == pkgerror.DefaultMsg
+	})).Return(nil)
+
+	notifierMock.EXPECT().Notify(mock.Anything, mock.Anything).
+		Return(nil)
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDueSuccess_ResumeSequence(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*Schedule{&Schedule{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Status:             StatusProcessing,
+			SequenceNumber:     "123456",
+		}}, nil)
+
+	transfererMock.EXPECT().DoPayment(mock.Anything, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		IdempotencyKey:     "internal:schedule-1",
+		Preauthorized:      true,
+	}).Return(&intrabank.Transaction{TransactionReference: "REF123"}, nil)
+
+	repoMock.EXPECT().Finish(mock.Anything, mock.MatchedBy(func(sch *Schedule) bool {
+		return sch.Status == StatusExecuted &&
+			sch.SequenceNumber == "123456" &&
+			sch.TransactionReference == "REF123"
+	})).Return(nil)
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDueSuccess_ResumeExpiredSequence(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*Schedule{&Schedule{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Status:             StatusProcessing,
+			SequenceNumber:     "123456",
+		}}, nil)
+
+	transfererMock.EXPECT().DoPayment(mock.Anything, mock.MatchedBy(func(in *intrabank.PaymentInput) bool {
+		return in.SequenceNumber == "123456"
+	})).Return(nil, pkgerror.New(codes.BadRequest, intrabank.ErrSequenceExpired))
+	transfererMock.EXPECT().Inquiry(mock.Anything, mock.Anything).
+		Return(&intrabank.Sequence{SequenceNumber: "654321"}, nil)
+	repoMock.EXPECT().SetSequence(mock.Anything, int64(1), "654321").
+		Return(nil)
+	transfererMock.EXPECT().DoPayment(mock.Anything, mock.MatchedBy(func(in *intrabank.PaymentInput) bool {
+		return in.SequenceNumber == "654321"
+	})).Return(&intrabank.Transaction{TransactionReference: "REF123"}, nil)
+
+	repoMock.EXPECT().Finish(mock.Anything, mock.MatchedBy(func(sch *Schedule) bool {
+		return sch.Status == StatusExecuted &&
+			sch.SequenceNumber == "654321" &&
+			sch.TransactionReference == "REF123"
+	})).Return(nil)
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDueSuccess_PaymentPending(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*Schedule{&Schedule{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Status:             StatusProcessing,
+		}}, nil)
+
+	transfererMock.EXPECT().Inquiry(mock.Anything, mock.Anything).
+		Return(&intrabank.Sequence{SequenceNumber: "123456"}, nil)
+	repoMock.EXPECT().SetSequence(mock.Anything, int64(1), "123456").
+		Return(nil)
+	transfererMock.EXPECT().DoPayment(mock.Anything, mock.Anything).
+		Return(nil, pkgerror.New(codes.Internal, intrabank.ErrPaymentPending))
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDueFailed_AcquireDueFailed(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return(nil, errors.New("unexpected error"))
+
+	err := svc.RunDue(ctx)
+
+	assert.EqualError(t, err, "unexpected error")
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
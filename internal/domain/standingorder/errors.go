@@ -0,0 +1,20 @@
+package standingorder
+
+import "errors"
+
+var (
+	// ErrGeneral indicates a general error.
+	ErrGeneral = errors.New("something went wrong")
+
+	// ErrUnauthenticatedUser indicates that the user is not authenticated.
+	ErrUnauthenticatedUser = errors.New("unauthenticated user")
+
+	// ErrInvalidStandingOrder is returned when the standing order instruction is invalid.
+	ErrInvalidStandingOrder = errors.New("invalid standing order")
+
+	// ErrStandingOrderNotFound is returned when the requested standing order cannot be found.
+	ErrStandingOrderNotFound = errors.New("standing order not found")
+
+	// ErrStandingOrderNotCancellable is returned when the standing order is running or has ended.
+	ErrStandingOrderNotCancellable = errors.New("standing order not cancellable")
+)
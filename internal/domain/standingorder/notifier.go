@@ -0,0 +1,13 @@
+package standingorder
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// Notifier sends standing order notifications to users.
+type Notifier interface {
+	// Notify sends a transfer notification to the specified user.
+	Notify(ctx context.Context, notification *intrabank.Notification) error
+}
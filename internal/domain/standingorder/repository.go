@@ -0,0 +1,40 @@
+package standingorder
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines methods for managing standing order persistence.
+type Repository interface {
+	// Insert inserts a standing order into the persistence repository.
+	// Returns an error if the operation fails.
+	Insert(ctx context.Context, order *StandingOrder) error
+
+	// Get retrieves the user's standing order by its ID.
+	// Returns ErrStandingOrderNotFound if the user has no standing order with the ID.
+	Get(ctx context.Context, userID int, id int64) (*StandingOrder, error)
+
+	// List retrieves all standing orders of the user, the newest first.
+	// Returns the standing orders and an error if retrieval fails.
+	List(ctx context.Context, userID int) ([]*StandingOrder, error)
+
+	// Cancel atomically cancels the user's standing order if it is active.
+	// Returns ErrStandingOrderNotCancellable if the standing order is running or has ended.
+	Cancel(ctx context.Context, userID int, id int64) error
+
+	// AcquireDue atomically moves at most limit active standing orders due at the given time
+	// to the processing status and leases them until leaseUntil, so concurrent workers never run the same standing order.
+	// Processing standing orders whose lease has expired, e.g. after a crash, are acquired again.
+	// Returns the acquired standing orders together with their users.
+	AcquireDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*StandingOrder, error)
+
+	// SetSequence stores the sequence number created for the current attempt of the standing order,
+	// so a standing order acquired again resumes the same payment.
+	// Returns an error if the operation fails.
+	SetSequence(ctx context.Context, id int64, sequenceNumber string) error
+
+	// Finish stores the outcome of a run and the next run of the standing order.
+	// Returns an error if the operation fails.
+	Finish(ctx context.Context, order *StandingOrder) error
+}
@@ -0,0 +1,407 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package standingorder
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// AcquireDue provides a mock function with given fields: ctx, now, leaseUntil, limit
+func (_m *MockRepository) AcquireDue(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*StandingOrder, error) {
+	ret := _m.Called(ctx, now, leaseUntil, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireDue")
+	}
+
+	var r0 []*StandingOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) ([]*StandingOrder, error)); ok {
+		return rf(ctx, now, leaseUntil, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, int) []*StandingOrder); ok {
+		r0 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*StandingOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, int) error); ok {
+		r1 = rf(ctx, now, leaseUntil, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_AcquireDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireDue'
+type MockRepository_AcquireDue_Call struct {
+	*mock.Call
+}
+
+// AcquireDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - leaseUntil time.Time
+//   - limit int
+func (_e *MockRepository_Expecter) AcquireDue(ctx interface{}, now interface{}, leaseUntil interface{}, limit interface{}) *MockRepository_AcquireDue_Call {
+	return &MockRepository_AcquireDue_Call{Call: _e.mock.On("AcquireDue", ctx, now, leaseUntil, limit)}
+}
+
+func (_c *MockRepository_AcquireDue_Call) Run(run func(ctx context.Context, now time.Time, leaseUntil time.Time, limit int)) *MockRepository_AcquireDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_AcquireDue_Call) Return(_a0 []*StandingOrder, _a1 error) *MockRepository_AcquireDue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_AcquireDue_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, int) ([]*StandingOrder, error)) *MockRepository_AcquireDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Cancel provides a mock function with given fields: ctx, userID, id
+func (_m *MockRepository) Cancel(ctx context.Context, userID int, id int64) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cancel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Cancel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cancel'
+type MockRepository_Cancel_Call struct {
+	*mock.Call
+}
+
+// Cancel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int64
+func (_e *MockRepository_Expecter) Cancel(ctx interface{}, userID interface{}, id interface{}) *MockRepository_Cancel_Call {
+	return &MockRepository_Cancel_Call{Call: _e.mock.On("Cancel", ctx, userID, id)}
+}
+
+func (_c *MockRepository_Cancel_Call) Run(run func(ctx context.Context, userID int, id int64)) *MockRepository_Cancel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Cancel_Call) Return(_a0 error) *MockRepository_Cancel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Cancel_Call) RunAndReturn(run func(context.Context, int, int64) error) *MockRepository_Cancel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Finish provides a mock function with given fields: ctx, order
+func (_m *MockRepository) Finish(ctx context.Context, order *StandingOrder) error {
+	ret := _m.Called(ctx, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Finish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *StandingOrder) error); ok {
+		r0 = rf(ctx, order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Finish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Finish'
+type MockRepository_Finish_Call struct {
+	*mock.Call
+}
+
+// Finish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - order *StandingOrder
+func (_e *MockRepository_Expecter) Finish(ctx interface{}, order interface{}) *MockRepository_Finish_Call {
+	return &MockRepository_Finish_Call{Call: _e.mock.On("Finish", ctx, order)}
+}
+
+func (_c *MockRepository_Finish_Call) Run(run func(ctx context.Context, order *StandingOrder)) *MockRepository_Finish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*StandingOrder))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Finish_Call) Return(_a0 error) *MockRepository_Finish_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Finish_Call) RunAndReturn(run func(context.Context, *StandingOrder) error) *MockRepository_Finish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, userID, id
+func (_m *MockRepository) Get(ctx context.Context, userID int, id int64) (*StandingOrder, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *StandingOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) (*StandingOrder, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) *StandingOrder); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*StandingOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+//   - id int64
+func (_e *MockRepository_Expecter) Get(ctx interface{}, userID interface{}, id interface{}) *MockRepository_Get_Call {
+	return &MockRepository_Get_Call{Call: _e.mock.On("Get", ctx, userID, id)}
+}
+
+func (_c *MockRepository_Get_Call) Run(run func(ctx context.Context, userID int, id int64)) *MockRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Get_Call) Return(_a0 *StandingOrder, _a1 error) *MockRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_Get_Call) RunAndReturn(run func(context.Context, int, int64) (*StandingOrder, error)) *MockRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Insert provides a mock function with given fields: ctx, order
+func (_m *MockRepository) Insert(ctx context.Context, order *StandingOrder) error {
+	ret := _m.Called(ctx, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Insert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *StandingOrder) error); ok {
+		r0 = rf(ctx, order)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_Insert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Insert'
+type MockRepository_Insert_Call struct {
+	*mock.Call
+}
+
+// Insert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - order *StandingOrder
+func (_e *MockRepository_Expecter) Insert(ctx interface{}, order interface{}) *MockRepository_Insert_Call {
+	return &MockRepository_Insert_Call{Call: _e.mock.On("Insert", ctx, order)}
+}
+
+func (_c *MockRepository_Insert_Call) Run(run func(ctx context.Context, order *StandingOrder)) *MockRepository_Insert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*StandingOrder))
+	})
+	return _c
+}
+
+func (_c *MockRepository_Insert_Call) Return(_a0 error) *MockRepository_Insert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_Insert_Call) RunAndReturn(run func(context.Context, *StandingOrder) error) *MockRepository_Insert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, userID
+func (_m *MockRepository) List(ctx context.Context, userID int) ([]*StandingOrder, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*StandingOrder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*StandingOrder, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*StandingOrder); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*StandingOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int
+func (_e *MockRepository_Expecter) List(ctx interface{}, userID interface{}) *MockRepository_List_Call {
+	return &MockRepository_List_Call{Call: _e.mock.On("List", ctx, userID)}
+}
+
+func (_c *MockRepository_List_Call) Run(run func(ctx context.Context, userID int)) *MockRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockRepository_List_Call) Return(_a0 []*StandingOrder, _a1 error) *MockRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_List_Call) RunAndReturn(run func(context.Context, int) ([]*StandingOrder, error)) *MockRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSequence provides a mock function with given fields: ctx, id, sequenceNumber
+func (_m *MockRepository) SetSequence(ctx context.Context, id int64, sequenceNumber string) error {
+	ret := _m.Called(ctx, id, sequenceNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSequence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, sequenceNumber)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetSequence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSequence'
+type MockRepository_SetSequence_Call struct {
+	*mock.Call
+}
+
+// SetSequence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - sequenceNumber string
+func (_e *MockRepository_Expecter) SetSequence(ctx interface{}, id interface{}, sequenceNumber interface{}) *MockRepository_SetSequence_Call {
+	return &MockRepository_SetSequence_Call{Call: _e.mock.On("SetSequence", ctx, id, sequenceNumber)}
+}
+
+func (_c *MockRepository_SetSequence_Call) Run(run func(ctx context.Context, id int64, sequenceNumber string)) *MockRepository_SetSequence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetSequence_Call) Return(_a0 error) *MockRepository_SetSequence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetSequence_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockRepository_SetSequence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
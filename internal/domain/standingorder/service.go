@@ -0,0 +1,282 @@
+package standingorder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+const (
+	domainName       = "standing_order"
+	dueBatchSize     = 50
+	leaseDuration    = 10 * time.Minute
+	runFailedSubject = "Transfer Rutin Gagal"
+)
+
+// Service handles recurring intrabank transfers.
+type Service struct {
+	log        *logger.Logger
+	repo       Repository
+	transferer Transferer
+	notifier   Notifier
+	authorizer TransactionAuthorizer
+}
+
+// NewService creates a new instance of Service.
+func NewService(
+	log *logger.Logger,
+	repo Repository,
+	transferer Transferer,
+	notifier Notifier,
+	authorizer TransactionAuthorizer,
+) *Service {
+	return &Service{
+		log:        log,
+		repo:       repo,
+		transferer: transferer,
+		notifier:   notifier,
+		authorizer: authorizer,
+	}
+}
+
+// Create validates the accounts of the transfer instruction and stores the standing order,
+// once the user has authorized it with the transaction OTP bound to its reference.
+// The limits, the fee and the balance are checked by the inquiry of each run,
+// and the runs are then paid without a further OTP.
+func (s *Service) Create(ctx context.Context, order *StandingOrder, otpID int, otpCode string) (*StandingOrder, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Create").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+	if !order.Valid(time.Now()) {
+		s.log.DomainUsecase(domainName, "Create").Error(ErrInvalidStandingOrder)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidStandingOrder).
+			SetMsg("Your standing order is invalid. Please check the schedule and the amount.")
+	}
+
+	sequence, err := s.transferer.ValidateAccounts(ctx, order.Sequence())
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Create").Errorf("ValidateAccounts: %v", err)
+		return nil, err
+	}
+
+	// The instruction is checked before the OTP, so an invalid instruction does not use it up.
+	if otpID == 0 || otpCode == "" {
+		s.log.DomainUsecase(domainName, "Create").Errorf("reference (%v): %v", order.Reference(), intrabank.ErrOTPRequired)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrOTPRequired).
+			SetMsg("Please verify this standing order with the OTP sent to you.")
+	}
+	if err := s.authorizer.VerifyTransaction(ctx, otpID, otpCode, order.Reference()); err != nil {
+		s.log.DomainUsecase(domainName, "Create").Errorf("VerifyTransaction: %v", err)
+		return nil, err
+	}
+
+	order.User = &User{
+		ID:    user.ID,
+		CIF:   user.CIF,
+		Name:  user.Name,
+		Email: user.Email,
+	}
+	order.DestinationName = sequence.DestinationName
+	order.Schedule()
+
+	err = s.repo.Insert(ctx, order)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Create").Errorf("Insert: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return order, nil
+}
+
+// List returns all standing orders of the authenticated user.
+func (s *Service) List(ctx context.Context) ([]*StandingOrder, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "List").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	orders, err := s.repo.List(ctx, user.ID)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "List").Errorf("List: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return orders, nil
+}
+
+// Get returns the authenticated user's standing order, including the outcome of its last run.
+func (s *Service) Get(ctx context.Context, id int64) (*StandingOrder, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Get").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+	return s.get(ctx, "Get", user.ID, id)
+}
+
+// Cancel stops the authenticated user's standing order, no further runs are made.
+func (s *Service) Cancel(ctx context.Context, id int64) error {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Cancel").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	order, err := s.get(ctx, "Cancel", user.ID, id)
+	if err != nil {
+		return err
+	}
+	if !order.Cancellable() {
+		s.log.DomainUsecase(domainName, "Cancel").Error(ErrStandingOrderNotCancellable)
+		return pkgerror.New(codes.BadRequest, ErrStandingOrderNotCancellable).
+			SetMsg("Your standing order cannot be cancelled right now.")
+	}
+
+	// The worker may pick the standing order up between the read and the cancellation.
+	err = s.repo.Cancel(ctx, user.ID, id)
+	if errors.Is(err, ErrStandingOrderNotCancellable) {
+		s.log.DomainUsecase(domainName, "Cancel").Errorf("Cancel: %v", err)
+		return pkgerror.New(codes.BadRequest, ErrStandingOrderNotCancellable).
+			SetMsg("Your standing order cannot be cancelled right now.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Cancel").Errorf("Cancel: %v", err)
+		return pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return nil
+}
+
+// RunDue runs the standing orders that are due.
+// It is called periodically by the background worker.
+func (s *Service) RunDue(ctx context.Context) error {
+	now := time.Now()
+	orders, err := s.repo.AcquireDue(ctx, now, now.Add(leaseDuration), dueBatchSize)
+	if err != nil {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("AcquireDue: %v", err)
+		return err
+	}
+	for _, order := range orders {
+		s.run(ctx, order)
+	}
+	return nil
+}
+
+func (s *Service) get(ctx context.Context, usecase string, userID int, id int64) (*StandingOrder, error) {
+	order, err := s.repo.Get(ctx, userID, id)
+	if errors.Is(err, ErrStandingOrderNotFound) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Get: %v", err)
+		return nil, pkgerror.New(codes.NotFound, ErrStandingOrderNotFound).
+			SetMsg("Standing order not found.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Get: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return order, nil
+}
+
+// run makes one transfer of the standing order as its owner.
+// The transaction is linked to the standing order, so it shows up in the history and receipts.
+func (s *Service) run(ctx context.Context, order *StandingOrder) {
+	userCtx := ctxt.ContextWithUser(ctx, &ctxt.User{
+		ID:    order.User.ID,
+		CIF:   order.User.CIF,
+		Name:  order.User.Name,
+		Email: order.User.Email,
+	})
+
+	transactionReference, err := s.transfer(userCtx, order)
+	switch {
+	case errors.Is(err, intrabank.ErrPaymentInProgress) || errors.Is(err, intrabank.ErrPaymentPending):
+		// The outcome of the payment is not known yet, so the standing order stays processing
+		// and the payment is resumed once its lease has expired.
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("standing order (%v): %v", order.ID, err)
+		return
+	case err == nil:
+		order.Succeed(transactionReference, time.Now())
+	case errors.Is(err, intrabank.ErrInsufficientBalance) && order.RetryOnInsufficientBalance():
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("standing order (%v): %v", order.ID, err)
+		order.Retry(pkgerror.Message(err), time.Now())
+	default:
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("standing order (%v): %v", order.ID, err)
+		order.Skip(pkgerror.Message(err), time.Now())
+		s.notifyFailed(ctx, order)
+	}
+
+	if err := s.repo.Finish(ctx, order); err != nil {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("standing order (%v) Finish: %v", order.ID, err)
+	}
+}
+
+// transfer runs the intrabank inquiry and payment of the current attempt of the run.
+// A standing order acquired again after its lease has expired resumes the payment of its stored sequence,
+// which returns the outcome of a payment already made instead of moving the money twice.
+func (s *Service) transfer(ctx context.Context, order *StandingOrder) (string, error) {
+	if order.SequenceNumber != "" {
+		transactionReference, err := s.pay(ctx, order)
+		if !errors.Is(err, intrabank.ErrSequenceExpired) {
+			return transactionReference, err
+		}
+		// An expired sequence has never been paid, so the run is paid with a new one.
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("standing order (%v) DoPayment: %v", order.ID, err)
+	}
+
+	sequence, err := s.transferer.Inquiry(ctx, order.Sequence())
+	if err != nil {
+		return "", err
+	}
+	order.SequenceNumber = sequence.SequenceNumber
+
+	// The sequence is stored before the payment, so a crash during the payment does not pay the run twice.
+	// Without it the run is left pending, so it is tried again once its lease has expired.
+	if err := s.repo.SetSequence(ctx, order.ID, order.SequenceNumber); err != nil {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("standing order (%v) SetSequence: %v", order.ID, err)
+		return "", pkgerror.New(codes.Internal, intrabank.ErrPaymentPending)
+	}
+
+	return s.pay(ctx, order)
+}
+
+// pay pays the sequence of the current attempt of the run.
+func (s *Service) pay(ctx context.Context, order *StandingOrder) (string, error) {
+	transaction, err := s.transferer.DoPayment(ctx, &intrabank.PaymentInput{
+		SequenceNumber:     order.SequenceNumber,
+		SourceAccount:      order.SourceAccount,
+		DestinationAccount: order.DestinationAccount,
+		Amount:             order.Amount,
+		IdempotencyKey:     order.IdempotencyKey(),
+		StandingOrderID:    order.ID,
+		Preauthorized:      true,
+	})
+	if err != nil {
+		return "", err
+	}
+	return transaction.TransactionReference, nil
+}
+
+func (s *Service) notifyFailed(ctx context.Context, order *StandingOrder) {
+	err := s.notifier.Notify(ctx, &intrabank.Notification{
+		FirebaseID:  order.User.FirebaseID,
+		Subject:     runFailedSubject,
+		Amount:      order.Amount,
+		Destination: order.DestinationAccount,
+		Status:      intrabank.TransactionFailed,
+	})
+	if err != nil {
+		s.log.DomainUsecase(domainName, "RunDue").Errorf("standing order (%v) Notify: %v", order.ID, err)
+	}
+}
@@ -0,0 +1,618 @@
+package standingorder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+func TestCreateSuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		authorizerMock = NewMockTransactionAuthorizer(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, authorizerMock)
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	in := &StandingOrder{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Frequency:          FrequencyMonthly,
+		BalancePolicy:      BalancePolicyRetry,
+		StartAt:            StartTime(time.Now().AddDate(0, 0, 7)),
+	}
+
+	transfererMock.EXPECT().ValidateAccounts(mock.Anything, &intrabank.Sequence{
+		Amount:             100000,
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+	}).Return(&intrabank.Sequence{
+		DestinationName: "Destination Account",
+	}, nil)
+
+	authorizerMock.EXPECT().VerifyTransaction(mock.Anything, 1, "123456", in.Reference()).
+		Return(nil)
+
+	repoMock.EXPECT().Insert(mock.Anything, mock.MatchedBy(func(order *StandingOrder) bool {
+		return order.Status == StatusActive &&
+			order.User.ID == 123 &&
+			order.NextRunAt.Equal(order.StartAt)
+	})).Return(nil)
+
+	order, err := svc.Create(ctx, in, 1, "123456")
+
+	assert.Nil(t, err)
+	assert.Equal(t, StatusActive, order.Status)
+	assert.Equal(t, "Destination Account", order.DestinationName)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	authorizerMock.AssertExpectations(t)
+}
+
+func TestCreateFailed_GetUserFromContextFailed(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	order, err := svc.Create(ctx, &StandingOrder{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Frequency:          FrequencyMonthly,
+		BalancePolicy:      BalancePolicyRetry,
+		StartAt:            StartTime(time.Now().AddDate(0, 0, 7)),
+	}, 1, "123456")
+
+	assert.Nil(t, order)
+	assert.Equal(t, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+		SetMsg("Please login to continue."), err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestCreateFailed_InvalidStandingOrder(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	in := &StandingOrder{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Frequency:          FrequencyMonthly,
+		BalancePolicy:      BalancePolicyRetry,
+		StartAt:            StartTime(time.Now().AddDate(0, 0, 7)),
+	}
+	in.Frequency = "yearly"
+
+	order, err := svc.Create(ctx, in, 1, "123456")
+
+	assert.Nil(t, order)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidStandingOrder).
+		SetMsg("Your standing order is invalid. Please check the schedule and the amount."), err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
+
+func TestCreateFailed_InsertFailed(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		authorizerMock = NewMockTransactionAuthorizer(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, authorizerMock)
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	transfererMock.EXPECT().ValidateAccounts(mock.Anything, mock.Anything).
+		Return(&intrabank.Sequence{SequenceNumber: "123456"}, nil)
+
+	authorizerMock.EXPECT().VerifyTransaction(mock.Anything, 1, "123456", mock.Anything).
+		Return(nil)
+
+	repoMock.EXPECT().Insert(mock.Anything, mock.Anything).
+		Return(errors.New("unexpected error"))
+
+	order, err := svc.Create(ctx, &StandingOrder{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Frequency:          FrequencyMonthly,
+		BalancePolicy:      BalancePolicyRetry,
+		StartAt:            StartTime(time.Now().AddDate(0, 0, 7)),
+	}, 1, "123456")
+
+	assert.Nil(t, order)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral), err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	authorizerMock.AssertExpectations(t)
+}
+
+func TestCreateFailed_OTPRequired(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		authorizerMock = NewMockTransactionAuthorizer(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, authorizerMock)
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	transfererMock.EXPECT().ValidateAccounts(mock.Anything, mock.Anything).
+		Return(&intrabank.Sequence{SequenceNumber: "123456"}, nil)
+
+	order, err := svc.Create(ctx, &StandingOrder{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Frequency:          FrequencyMonthly,
+		BalancePolicy:      BalancePolicyRetry,
+		StartAt:            StartTime(time.Now().AddDate(0, 0, 7)),
+	}, 0, "")
+
+	assert.Nil(t, order)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, intrabank.ErrOTPRequired).
+		SetMsg("Please verify this standing order with the OTP sent to you."), err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	authorizerMock.AssertExpectations(t)
+}
+
+func TestCancelSuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(1)).
+		Return(&StandingOrder{ID: 1, Status: StatusActive}, nil)
+	repoMock.EXPECT().Cancel(mock.Anything, 123, int64(1)).
+		Return(nil)
+
+	err := svc.Cancel(ctx, 1)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestCancelFailed_StandingOrderNotFound(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(1)).
+		Return(nil, ErrStandingOrderNotFound)
+
+	err := svc.Cancel(ctx, 1)
+
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrStandingOrderNotFound).
+		SetMsg("Standing order not found."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestCancelFailed_StandingOrderProcessing(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().Get(mock.Anything, 123, int64(1)).
+		Return(&StandingOrder{ID: 1, Status: StatusProcessing}, nil)
+
+	err := svc.Cancel(ctx, 1)
+
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrStandingOrderNotCancellable).
+		SetMsg("Your standing order cannot be cancelled right now."), err)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestListSuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:    123,
+			CIF:   "1234567",
+			Name:  "Olivia Rodrigo",
+			Email: "olivia@gmail.com",
+		})
+	)
+
+	repoMock.EXPECT().List(mock.Anything, 123).
+		Return([]*StandingOrder{{ID: 1, Status: StatusActive}}, nil)
+
+	orders, err := svc.List(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*StandingOrder{{ID: 1, Status: StatusActive}}, orders)
+
+	repoMock.AssertExpectations(t)
+}
+
+func TestRunDueSuccess(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*StandingOrder{&StandingOrder{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Frequency:          FrequencyMonthly,
+			BalancePolicy:      BalancePolicyRetry,
+			StartAt:            StartTime(time.Now()),
+			Retries:            0,
+			Status:             StatusProcessing,
+		}}, nil)
+
+	transfererMock.EXPECT().Inquiry(mock.MatchedBy(func(ctx context.Context) bool {
+		user, ok := ctxt.UserFromContext(ctx)
+		return ok && user.ID == 123
+	}), mock.Anything).Return(&intrabank.Sequence{SequenceNumber: "123456"}, nil)
+	repoMock.EXPECT().SetSequence(mock.Anything, int64(1), "123456").
+		Return(nil)
+	transfererMock.EXPECT().DoPayment(mock.Anything, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		IdempotencyKey:     "internal:standing-order-1-0-0",
+		StandingOrderID:    1,
+		Preauthorized:      true,
+	}).Return(&intrabank.Transaction{TransactionReference: "REF123", StandingOrderID: 1}, nil)
+
+	repoMock.EXPECT().Finish(mock.Anything, mock.MatchedBy(func(order *StandingOrder) bool {
+		return order.Status == StatusActive &&
+			order.Runs == 1 &&
+			order.SequenceNumber == "" &&
+			order.LastTransactionReference == "REF123" &&
+			order.NextRunAt.Equal(order.Occurrence(1))
+	})).Return(nil)
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDue_InsufficientBalanceRetry(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*StandingOrder{&StandingOrder{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Frequency:          FrequencyMonthly,
+			BalancePolicy:      BalancePolicyRetry,
+			StartAt:            StartTime(time.Now()),
+			Retries:            0,
+			Status:             StatusProcessing,
+		}}, nil)
+
+	transfererMock.EXPECT().Inquiry(mock.Anything, mock.Anything).
+		Return(nil, pkgerror.New(codes.BadRequest, intrabank.ErrInsufficientBalance).
+			SetMsg("Your balance is insufficient."))
+
+	repoMock.EXPECT().Finish(mock.Anything, mock.MatchedBy(func(order *StandingOrder) bool {
+		return order.Status == StatusActive &&
+			order.Runs == 0 &&
+			order.Retries == 1 &&
+			order.LastFailureReason == "Your balance is insufficient."
+	})).Return(nil)
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDue_InsufficientBalanceRetriesExhausted(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*StandingOrder{&StandingOrder{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Frequency:          FrequencyMonthly,
+			BalancePolicy:      BalancePolicyRetry,
+			StartAt:            StartTime(time.Now()),
+			Retries:            maxRetries,
+			Status:             StatusProcessing,
+		}}, nil)
+
+	transfererMock.EXPECT().Inquiry(mock.Anything, mock.Anything).
+		Return(&intrabank.Sequence{SequenceNumber: "123456"}, nil)
+	repoMock.EXPECT().SetSequence(mock.Anything, int64(1), "123456").
+		Return(nil)
+	transfererMock.EXPECT().DoPayment(mock.Anything, &intrabank.PaymentInput{
+		SequenceNumber:     "123456",
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		IdempotencyKey:     "internal:standing-order-1-0-3",
+		StandingOrderID:    1,
+		Preauthorized:      true,
+	}).Return(nil, pkgerror.New(codes.BadRequest, intrabank.ErrInsufficientBalance).
+		SetMsg("Your balance is insufficient."))
+
+	repoMock.EXPECT().Finish(mock.Anything, mock.MatchedBy(func(order *StandingOrder) bool {
+		return order.Status == StatusActive &&
+			order.Runs == 1 &&
+			order.Retries == 0
+	})).Return(nil)
+
+	notifierMock.EXPECT().Notify(mock.Anything, &intrabank.Notification{
+		FirebaseID:  "firebase-id",
+		Subject:     "Transfer Rutin Gagal",
+		Amount:      100000,
+		Destination: "001001234567892",
+		Status:      "failed",
+	}).Return(nil)
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDue_InsufficientBalanceSkip(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*StandingOrder{&StandingOrder{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Frequency:          FrequencyMonthly,
+			BalancePolicy:      BalancePolicySkip,
+			StartAt:            StartTime(time.Now()),
+			Retries:            0,
+			Status:             StatusProcessing,
+		}}, nil)
+
+	transfererMock.EXPECT().Inquiry(mock.Anything, mock.Anything).
+		Return(nil, pkgerror.New(codes.BadRequest, intrabank.ErrInsufficientBalance).
+			SetMsg("Your balance is insufficient."))
+
+	repoMock.EXPECT().Finish(mock.Anything, mock.MatchedBy(func(order *StandingOrder) bool {
+		return order.Status == StatusActive &&
+			order.Runs == 1 &&
+			order.LastFailureReason == "Your balance is insufficient."
+	})).Return(nil)
+
+	notifierMock.EXPECT().Notify(mock.Anything, mock.Anything).
+		Return(nil)
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDueSuccess_ResumeSequence(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*StandingOrder{&StandingOrder{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Frequency:          FrequencyMonthly,
+			BalancePolicy:      BalancePolicyRetry,
+			StartAt:            StartTime(time.Now()),
+			Status:             StatusProcessing,
+			SequenceNumber:     "123456",
+		}}, nil)
+
+	transfererMock.EXPECT().DoPayment(mock.Anything, mock.MatchedBy(func(in *intrabank.PaymentInput) bool {
+		return in.SequenceNumber == "123456" && in.IdempotencyKey == "internal:standing-order-1-0-0"
+	})).Return(&intrabank.Transaction{TransactionReference: "REF123", StandingOrderID: 1}, nil)
+
+	repoMock.EXPECT().Finish(mock.Anything, mock.MatchedBy(func(order *StandingOrder) bool {
+		return order.Status == StatusActive &&
+			order.Runs == 1 &&
+			order.SequenceNumber == "" &&
+			order.LastTransactionReference == "REF123"
+	})).Return(nil)
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDueSuccess_PaymentPending(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return([]*StandingOrder{&StandingOrder{
+			ID:                 1,
+			User:               &User{ID: 123, CIF: "1234567", Name: "Olivia Rodrigo", Email: "olivia@gmail.com", FirebaseID: "firebase-id"},
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			DestinationName:    "Destination Account",
+			Amount:             100000,
+			Frequency:          FrequencyMonthly,
+			BalancePolicy:      BalancePolicyRetry,
+			StartAt:            StartTime(time.Now()),
+			Status:             StatusProcessing,
+		}}, nil)
+
+	transfererMock.EXPECT().Inquiry(mock.Anything, mock.Anything).
+		Return(&intrabank.Sequence{SequenceNumber: "123456"}, nil)
+	repoMock.EXPECT().SetSequence(mock.Anything, int64(1), "123456").
+		Return(nil)
+	transfererMock.EXPECT().DoPayment(mock.Anything, mock.Anything).
+		Return(nil, pkgerror.New(codes.Internal, intrabank.ErrPaymentPending))
+
+	err := svc.RunDue(ctx)
+
+	assert.Nil(t, err)
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestRunDueFailed_AcquireDueFailed(t *testing.T) {
+	var (
+		repoMock       = NewMockRepository(t)
+		transfererMock = NewMockTransferer(t)
+		notifierMock   = NewMockNotifier(t)
+		svc            = NewService(logger.New(), repoMock, transfererMock, notifierMock, NewMockTransactionAuthorizer(t))
+		ctx            = context.Background()
+	)
+
+	repoMock.EXPECT().AcquireDue(mock.Anything, mock.Anything, mock.Anything, dueBatchSize).
+		Return(nil, errors.New("unexpected error"))
+
+	err := svc.RunDue(ctx)
+
+	assert.EqualError(t, err, "unexpected error")
+
+	repoMock.AssertExpectations(t)
+	transfererMock.AssertExpectations(t)
+}
@@ -0,0 +1,222 @@
+// Package standingorder provides structures and functionality for recurring intrabank transfers.
+// A standing order repeats a transfer on a daily, weekly, monthly or end-of-month schedule
+// until its end date or maximum run count is reached.
+package standingorder
+
+import (
+	"fmt"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// Frequency defines how often a standing order runs.
+type Frequency string
+
+const (
+	FrequencyDaily      Frequency = "daily"
+	FrequencyWeekly     Frequency = "weekly"
+	FrequencyMonthly    Frequency = "monthly"
+	FrequencyEndOfMonth Frequency = "end_of_month"
+)
+
+// Valid checks if the frequency is supported.
+func (f Frequency) Valid() bool {
+	switch f {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyEndOfMonth:
+		return true
+	}
+	return false
+}
+
+// BalancePolicy defines what a run does when the source account balance is insufficient.
+type BalancePolicy string
+
+const (
+	// BalancePolicySkip skips the run and waits for the next one.
+	BalancePolicySkip BalancePolicy = "skip"
+	// BalancePolicyRetry retries the run up to maxRetries times before skipping it.
+	BalancePolicyRetry BalancePolicy = "retry"
+)
+
+// Valid checks if the balance policy is supported.
+func (p BalancePolicy) Valid() bool {
+	return p == BalancePolicySkip || p == BalancePolicyRetry
+}
+
+const (
+	// StatusActive represents a standing order waiting for its next run.
+	StatusActive = "ACTIVE"
+	// StatusProcessing represents a standing order whose run has been picked up by the worker.
+	StatusProcessing = "PROCESSING"
+	// StatusCompleted represents a standing order that has reached its end date or maximum run count.
+	StatusCompleted = "COMPLETED"
+	// StatusCancelled represents a standing order cancelled by the user.
+	StatusCancelled = "CANCELLED"
+)
+
+const (
+	// executionHour is the hour in Jakarta time at which a standing order runs.
+	executionHour = 8
+	// maxRetries is how many times a run is retried when the balance is insufficient.
+	maxRetries = 3
+	// retryInterval is the delay between two retries of a run.
+	retryInterval = 2 * time.Hour
+	// referenceDateLayout is the layout of the start date in the reference of a standing order.
+	referenceDateLayout = "20060102"
+)
+
+// StartTime returns the time of the first run for the given start date.
+// Only the year, month and day of the date are used.
+func StartTime(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), executionHour, 0, 0, 0, intrabank.BusinessLocation)
+}
+
+// User represents the owner of a standing order.
+type User struct {
+	ID         int
+	CIF        string
+	Name       string
+	Email      string
+	FirebaseID string
+}
+
+// StandingOrder represents a recurring intrabank transfer instruction.
+// MaxRuns and EndAt are optional, zero values mean the standing order has no such bound.
+// SequenceNumber is the sequence of the current attempt of the current run, it is empty until the attempt is paid.
+type StandingOrder struct {
+	ID                       int64
+	User                     *User
+	SourceAccount            string
+	DestinationAccount       string
+	DestinationName          string
+	Amount                   intrabank.Money
+	Frequency                Frequency
+	BalancePolicy            BalancePolicy
+	StartAt                  time.Time
+	EndAt                    time.Time
+	MaxRuns                  int
+	Runs                     int
+	Retries                  int
+	NextRunAt                time.Time
+	Status                   string
+	LastRunAt                time.Time
+	LastTransactionReference string
+	LastFailureReason        string
+	SequenceNumber           string
+	CreatedAt                time.Time
+}
+
+// Valid checks if the standing order can be created at the given time.
+func (o *StandingOrder) Valid(now time.Time) bool {
+	return o.Amount > 0 &&
+		o.SourceAccount != "" &&
+		o.DestinationAccount != "" &&
+		o.SourceAccount != o.DestinationAccount &&
+		o.Frequency.Valid() &&
+		o.BalancePolicy.Valid() &&
+		o.StartAt.After(now) &&
+		o.MaxRuns >= 0 &&
+		(o.EndAt.IsZero() || !o.EndAt.Before(o.StartAt))
+}
+
+// Occurrence returns the time of the n-th run, counted from zero.
+// Monthly runs keep the day of the start date, clamped to the last day of shorter months.
+func (o *StandingOrder) Occurrence(n int) time.Time {
+	start := o.StartAt.In(intrabank.BusinessLocation)
+	year, month, day := start.Date()
+	switch o.Frequency {
+	case FrequencyWeekly:
+		return start.AddDate(0, 0, 7*n)
+	case FrequencyMonthly:
+		if last := lastDay(year, month+time.Month(n)); day > last {
+			day = last
+		}
+		return time.Date(year, month+time.Month(n), day, start.Hour(), start.Minute(), 0, 0, start.Location())
+	case FrequencyEndOfMonth:
+		return time.Date(year, month+time.Month(n), lastDay(year, month+time.Month(n)), start.Hour(), start.Minute(), 0, 0, start.Location())
+	}
+	return start.AddDate(0, 0, n)
+}
+
+// lastDay returns the last day of the month, the month may overflow into the next years.
+func lastDay(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Schedule sets the first run of a new standing order.
+func (o *StandingOrder) Schedule() {
+	o.Status = StatusActive
+	o.NextRunAt = o.Occurrence(0)
+}
+
+// Cancellable checks if the standing order can be cancelled.
+func (o *StandingOrder) Cancellable() bool {
+	return o.Status == StatusActive
+}
+
+// RetryOnInsufficientBalance checks if the current run can be retried after an insufficient balance.
+func (o *StandingOrder) RetryOnInsufficientBalance() bool {
+	return o.BalancePolicy == BalancePolicyRetry && o.Retries < maxRetries
+}
+
+// Reference returns the reference the transaction OTP authorizing the standing order is bound to,
+// e.g. "STANDING-ORDER-001001234567891-001001234567892-100000-monthly-20250325"
+// for its accounts, amount, frequency and start date.
+func (o *StandingOrder) Reference() string {
+	return fmt.Sprintf("STANDING-ORDER-%s-%s-%d-%s-%s",
+		o.SourceAccount, o.DestinationAccount, o.Amount, o.Frequency, o.StartAt.In(intrabank.BusinessLocation).Format(referenceDateLayout))
+}
+
+// IdempotencyKey returns the payment idempotency key of the current attempt of the current run,
+// so a run can never move money twice.
+func (o *StandingOrder) IdempotencyKey() string {
+	return intrabank.InternalIdempotencyKey(fmt.Sprintf("standing-order-%d-%d-%d", o.ID, o.Runs, o.Retries))
+}
+
+// Sequence returns the inquiry sequence of a run.
+func (o *StandingOrder) Sequence() *intrabank.Sequence {
+	return &intrabank.Sequence{
+		Amount:             o.Amount,
+		SourceAccount:      o.SourceAccount,
+		DestinationAccount: o.DestinationAccount,
+	}
+}
+
+// Succeed records a successful run and moves to the next one.
+func (o *StandingOrder) Succeed(transactionReference string, now time.Time) {
+	o.LastTransactionReference = transactionReference
+	o.LastFailureReason = ""
+	o.finishRun(now)
+}
+
+// Skip records a failed run and moves to the next one.
+func (o *StandingOrder) Skip(reason string, now time.Time) {
+	o.LastFailureReason = reason
+	o.finishRun(now)
+}
+
+// Retry schedules another attempt of the current run after the retry interval.
+func (o *StandingOrder) Retry(reason string, now time.Time) {
+	o.Retries++
+	o.SequenceNumber = ""
+	o.Status = StatusActive
+	o.LastRunAt = now
+	o.LastFailureReason = reason
+	o.NextRunAt = now.Add(retryInterval)
+}
+
+// finishRun moves to the next run, or completes the standing order when it has reached its bounds.
+func (o *StandingOrder) finishRun(now time.Time) {
+	o.Runs++
+	o.Retries = 0
+	o.SequenceNumber = ""
+	o.LastRunAt = now
+	next := o.Occurrence(o.Runs)
+	if (o.MaxRuns > 0 && o.Runs >= o.MaxRuns) || (!o.EndAt.IsZero() && next.After(o.EndAt)) {
+		o.Status = StatusCompleted
+		return
+	}
+	o.Status = StatusActive
+	o.NextRunAt = next
+}
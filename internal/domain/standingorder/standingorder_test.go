@@ -0,0 +1,136 @@
+package standingorder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, executionHour, 0, 0, 0, intrabank.BusinessLocation)
+}
+
+func TestOccurrence(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency Frequency
+		start     time.Time
+		n         int
+		want      time.Time
+	}{
+		{name: "daily", frequency: FrequencyDaily, start: date(2025, 1, 31), n: 1, want: date(2025, 2, 1)},
+		{name: "weekly", frequency: FrequencyWeekly, start: date(2025, 1, 31), n: 2, want: date(2025, 2, 14)},
+		{name: "monthly clamped to february", frequency: FrequencyMonthly, start: date(2025, 1, 31), n: 1, want: date(2025, 2, 28)},
+		{name: "monthly keeps the start day", frequency: FrequencyMonthly, start: date(2025, 1, 31), n: 2, want: date(2025, 3, 31)},
+		{name: "monthly over the year", frequency: FrequencyMonthly, start: date(2025, 11, 15), n: 3, want: date(2026, 2, 15)},
+		{name: "end of month", frequency: FrequencyEndOfMonth, start: date(2024, 1, 31), n: 1, want: date(2024, 2, 29)},
+		{name: "end of month from mid month", frequency: FrequencyEndOfMonth, start: date(2025, 4, 10), n: 0, want: date(2025, 4, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &StandingOrder{Frequency: tt.frequency, StartAt: tt.start}
+			assert.True(t, tt.want.Equal(order.Occurrence(tt.n)), "got %v", order.Occurrence(tt.n))
+		})
+	}
+}
+
+func TestStandingOrderValid(t *testing.T) {
+	now := date(2025, 3, 25)
+	valid := func() *StandingOrder {
+		return &StandingOrder{
+			SourceAccount:      "001001234567891",
+			DestinationAccount: "001001234567892",
+			Amount:             100000,
+			Frequency:          FrequencyMonthly,
+			BalancePolicy:      BalancePolicySkip,
+			StartAt:            date(2025, 3, 26),
+		}
+	}
+	assert.True(t, valid().Valid(now))
+
+	order := valid()
+	order.Frequency = "yearly"
+	assert.False(t, order.Valid(now))
+
+	order = valid()
+	order.BalancePolicy = "wait"
+	assert.False(t, order.Valid(now))
+
+	order = valid()
+	order.StartAt = now
+	assert.False(t, order.Valid(now))
+
+	order = valid()
+	order.EndAt = date(2025, 3, 1)
+	assert.False(t, order.Valid(now))
+
+	order = valid()
+	order.MaxRuns = -1
+	assert.False(t, order.Valid(now))
+}
+
+func TestStandingOrderSucceed(t *testing.T) {
+	order := &StandingOrder{
+		Frequency: FrequencyWeekly,
+		StartAt:   date(2025, 3, 26),
+		MaxRuns:   2,
+		Retries:   1,
+	}
+	order.Schedule()
+	assert.Equal(t, StatusActive, order.Status)
+
+	order.Succeed("REF1", date(2025, 3, 26))
+	assert.Equal(t, StatusActive, order.Status)
+	assert.Equal(t, 1, order.Runs)
+	assert.Equal(t, 0, order.Retries)
+	assert.Equal(t, "REF1", order.LastTransactionReference)
+	assert.True(t, date(2025, 4, 2).Equal(order.NextRunAt))
+
+	order.Succeed("REF2", date(2025, 4, 2))
+	assert.Equal(t, StatusCompleted, order.Status)
+	assert.Equal(t, 2, order.Runs)
+}
+
+func TestStandingOrderSkip_EndDateReached(t *testing.T) {
+	order := &StandingOrder{
+		Frequency: FrequencyDaily,
+		StartAt:   date(2025, 3, 26),
+		EndAt:     date(2025, 3, 27),
+	}
+	order.Schedule()
+
+	order.Skip("Insufficient balance.", date(2025, 3, 26))
+	assert.Equal(t, StatusActive, order.Status)
+	assert.Equal(t, "Insufficient balance.", order.LastFailureReason)
+
+	order.Skip("Insufficient balance.", date(2025, 3, 27))
+	assert.Equal(t, StatusCompleted, order.Status)
+}
+
+func TestStandingOrderRetry(t *testing.T) {
+	now := date(2025, 3, 26)
+	order := &StandingOrder{BalancePolicy: BalancePolicyRetry}
+	for range maxRetries {
+		assert.True(t, order.RetryOnInsufficientBalance())
+		order.Retry("Insufficient balance.", now)
+	}
+	assert.False(t, order.RetryOnInsufficientBalance())
+	assert.Equal(t, 0, order.Runs)
+	assert.True(t, now.Add(retryInterval).Equal(order.NextRunAt))
+
+	order = &StandingOrder{BalancePolicy: BalancePolicySkip}
+	assert.False(t, order.RetryOnInsufficientBalance())
+}
+
+func TestStandingOrderReference(t *testing.T) {
+	o := &StandingOrder{
+		SourceAccount:      "001001234567891",
+		DestinationAccount: "001001234567892",
+		Amount:             100000,
+		Frequency:          FrequencyMonthly,
+		StartAt:            StartTime(time.Date(2025, 3, 25, 0, 0, 0, 0, time.UTC)),
+	}
+	assert.Equal(t, "STANDING-ORDER-001001234567891-001001234567892-100000-monthly-20250325", o.Reference())
+}
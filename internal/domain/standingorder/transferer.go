@@ -0,0 +1,19 @@
+package standingorder
+
+import (
+	"context"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// Transferer runs intrabank transfers on behalf of the user in the context.
+type Transferer interface {
+	// ValidateAccounts checks the source and destination accounts of the transfer without creating a sequence.
+	ValidateAccounts(ctx context.Context, seq *intrabank.Sequence) (*intrabank.Sequence, error)
+
+	// Inquiry validates the transfer and creates its payable sequence.
+	Inquiry(ctx context.Context, seq *intrabank.Sequence) (*intrabank.Sequence, error)
+
+	// DoPayment pays the sequence and returns the resulting transaction.
+	DoPayment(ctx context.Context, in *intrabank.PaymentInput) (*intrabank.Transaction, error)
+}
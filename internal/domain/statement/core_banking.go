@@ -0,0 +1,19 @@
+package statement
+
+import (
+	"context"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// CoreBanking defines the core banking operations of the account statements.
+type CoreBanking interface {
+	// GetAccountDetails retrieves account information for the given account number.
+	GetAccountDetails(ctx context.Context, accountNumber string) (*intrabank.Account, error)
+
+	// GetLedger retrieves the mutations posted to the account within the [from, to) time range, ordered from the oldest,
+	// with the balances of the account at both ends of the range.
+	// Returns an error if any part of the ledger cannot be retrieved, the ledger is never returned partially.
+	GetLedger(ctx context.Context, accountNumber string, from, to time.Time) (*Ledger, error)
+}
@@ -0,0 +1,23 @@
+package statement
+
+import "errors"
+
+var (
+	// ErrGeneral indicates a general error.
+	ErrGeneral = errors.New("something went wrong")
+
+	// ErrUnauthenticatedUser indicates that the user is not authenticated.
+	ErrUnauthenticatedUser = errors.New("unauthenticated user")
+
+	// ErrInvalidFormat is returned when the statement format is not supported.
+	ErrInvalidFormat = errors.New("invalid statement format")
+
+	// ErrInvalidPeriod is returned when the period is not in order, goes past today or is too long.
+	ErrInvalidPeriod = errors.New("invalid statement period")
+
+	// ErrIncompleteLedger is returned when the mutations of the ledger do not account for its balances.
+	ErrIncompleteLedger = errors.New("incomplete ledger")
+
+	// ErrStatementNotFound is returned when no statement has been issued with the reference.
+	ErrStatementNotFound = errors.New("statement not found")
+)
@@ -0,0 +1,10 @@
+package statement
+
+import "context"
+
+// Mailer defines methods to email the statements.
+type Mailer interface {
+	// SendStatement emails the statement document as an attachment.
+	// Returns an error if the email cannot be sent.
+	SendStatement(ctx context.Context, delivery *Delivery) error
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package statement
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockMailer is an autogenerated mock type for the Mailer type
+type MockMailer struct {
+	mock.Mock
+}
+
+type MockMailer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMailer) EXPECT() *MockMailer_Expecter {
+	return &MockMailer_Expecter{mock: &_m.Mock}
+}
+
+// SendStatement provides a mock function with given fields: ctx, delivery
+func (_m *MockMailer) SendStatement(ctx context.Context, delivery *Delivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendStatement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Delivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMailer_SendStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendStatement'
+type MockMailer_SendStatement_Call struct {
+	*mock.Call
+}
+
+// SendStatement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - delivery *Delivery
+func (_e *MockMailer_Expecter) SendStatement(ctx interface{}, delivery interface{}) *MockMailer_SendStatement_Call {
+	return &MockMailer_SendStatement_Call{Call: _e.mock.On("SendStatement", ctx, delivery)}
+}
+
+func (_c *MockMailer_SendStatement_Call) Run(run func(ctx context.Context, delivery *Delivery)) *MockMailer_SendStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Delivery))
+	})
+	return _c
+}
+
+func (_c *MockMailer_SendStatement_Call) Return(_a0 error) *MockMailer_SendStatement_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMailer_SendStatement_Call) RunAndReturn(run func(context.Context, *Delivery) error) *MockMailer_SendStatement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMailer creates a new instance of MockMailer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMailer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMailer {
+	mock := &MockMailer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
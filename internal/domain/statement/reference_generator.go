@@ -0,0 +1,8 @@
+package statement
+
+// ReferenceGenerator defines an interface for generating unique references.
+type ReferenceGenerator interface {
+	// Generate produces the unique reference as a string
+	// and error if the reference cannot be generated.
+	Generate() (string, error)
+}
@@ -0,0 +1,12 @@
+package statement
+
+// Renderer defines methods to render the statements as files.
+type Renderer interface {
+	// PDF renders the statement as a PDF document branded with the name of the bank.
+	// Returns an error if the document cannot be rendered.
+	PDF(statement *Statement) ([]byte, error)
+
+	// CSV renders the entries of the statement as a CSV file.
+	// Returns an error if the file cannot be rendered.
+	CSV(statement *Statement) ([]byte, error)
+}
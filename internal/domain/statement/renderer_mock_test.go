@@ -0,0 +1,148 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package statement
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockRenderer is an autogenerated mock type for the Renderer type
+type MockRenderer struct {
+	mock.Mock
+}
+
+type MockRenderer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRenderer) EXPECT() *MockRenderer_Expecter {
+	return &MockRenderer_Expecter{mock: &_m.Mock}
+}
+
+// CSV provides a mock function with given fields: statement
+func (_m *MockRenderer) CSV(statement *Statement) ([]byte, error) {
+	ret := _m.Called(statement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CSV")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*Statement) ([]byte, error)); ok {
+		return rf(statement)
+	}
+	if rf, ok := ret.Get(0).(func(*Statement) []byte); ok {
+		r0 = rf(statement)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*Statement) error); ok {
+		r1 = rf(statement)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRenderer_CSV_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CSV'
+type MockRenderer_CSV_Call struct {
+	*mock.Call
+}
+
+// CSV is a helper method to define mock.On call
+//   - statement *Statement
+func (_e *MockRenderer_Expecter) CSV(statement interface{}) *MockRenderer_CSV_Call {
+	return &MockRenderer_CSV_Call{Call: _e.mock.On("CSV", statement)}
+}
+
+func (_c *MockRenderer_CSV_Call) Run(run func(statement *Statement)) *MockRenderer_CSV_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*Statement))
+	})
+	return _c
+}
+
+func (_c *MockRenderer_CSV_Call) Return(_a0 []byte, _a1 error) *MockRenderer_CSV_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRenderer_CSV_Call) RunAndReturn(run func(*Statement) ([]byte, error)) *MockRenderer_CSV_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PDF provides a mock function with given fields: statement
+func (_m *MockRenderer) PDF(statement *Statement) ([]byte, error) {
+	ret := _m.Called(statement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PDF")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*Statement) ([]byte, error)); ok {
+		return rf(statement)
+	}
+	if rf, ok := ret.Get(0).(func(*Statement) []byte); ok {
+		r0 = rf(statement)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*Statement) error); ok {
+		r1 = rf(statement)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRenderer_PDF_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PDF'
+type MockRenderer_PDF_Call struct {
+	*mock.Call
+}
+
+// PDF is a helper method to define mock.On call
+//   - statement *Statement
+func (_e *MockRenderer_Expecter) PDF(statement interface{}) *MockRenderer_PDF_Call {
+	return &MockRenderer_PDF_Call{Call: _e.mock.On("PDF", statement)}
+}
+
+func (_c *MockRenderer_PDF_Call) Run(run func(statement *Statement)) *MockRenderer_PDF_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*Statement))
+	})
+	return _c
+}
+
+func (_c *MockRenderer_PDF_Call) Return(_a0 []byte, _a1 error) *MockRenderer_PDF_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRenderer_PDF_Call) RunAndReturn(run func(*Statement) ([]byte, error)) *MockRenderer_PDF_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRenderer creates a new instance of MockRenderer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRenderer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRenderer {
+	mock := &MockRenderer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
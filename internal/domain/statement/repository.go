@@ -0,0 +1,14 @@
+package statement
+
+import "context"
+
+// Repository defines methods to record the issued statements.
+type Repository interface {
+	// InsertStatement records the issued statement without its entries.
+	// Returns an error if the operation fails.
+	InsertStatement(ctx context.Context, statement *Statement) error
+
+	// GetStatementByReference retrieves the issued statement with the verification reference.
+	// Returns ErrStatementNotFound if no statement has been issued with the reference.
+	GetStatementByReference(ctx context.Context, reference string) (*Statement, error)
+}
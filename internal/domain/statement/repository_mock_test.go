@@ -0,0 +1,142 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package statement
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetStatementByReference provides a mock function with given fields: ctx, reference
+func (_m *MockRepository) GetStatementByReference(ctx context.Context, reference string) (*Statement, error) {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatementByReference")
+	}
+
+	var r0 *Statement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*Statement, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *Statement); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*Statement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepository_GetStatementByReference_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatementByReference'
+type MockRepository_GetStatementByReference_Call struct {
+	*mock.Call
+}
+
+// GetStatementByReference is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *MockRepository_Expecter) GetStatementByReference(ctx interface{}, reference interface{}) *MockRepository_GetStatementByReference_Call {
+	return &MockRepository_GetStatementByReference_Call{Call: _e.mock.On("GetStatementByReference", ctx, reference)}
+}
+
+func (_c *MockRepository_GetStatementByReference_Call) Run(run func(ctx context.Context, reference string)) *MockRepository_GetStatementByReference_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetStatementByReference_Call) Return(_a0 *Statement, _a1 error) *MockRepository_GetStatementByReference_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepository_GetStatementByReference_Call) RunAndReturn(run func(context.Context, string) (*Statement, error)) *MockRepository_GetStatementByReference_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertStatement provides a mock function with given fields: ctx, statement
+func (_m *MockRepository) InsertStatement(ctx context.Context, statement *Statement) error {
+	ret := _m.Called(ctx, statement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertStatement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Statement) error); ok {
+		r0 = rf(ctx, statement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_InsertStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertStatement'
+type MockRepository_InsertStatement_Call struct {
+	*mock.Call
+}
+
+// InsertStatement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - statement *Statement
+func (_e *MockRepository_Expecter) InsertStatement(ctx interface{}, statement interface{}) *MockRepository_InsertStatement_Call {
+	return &MockRepository_InsertStatement_Call{Call: _e.mock.On("InsertStatement", ctx, statement)}
+}
+
+func (_c *MockRepository_InsertStatement_Call) Run(run func(ctx context.Context, statement *Statement)) *MockRepository_InsertStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*Statement))
+	})
+	return _c
+}
+
+func (_c *MockRepository_InsertStatement_Call) Return(_a0 error) *MockRepository_InsertStatement_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_InsertStatement_Call) RunAndReturn(run func(context.Context, *Statement) error) *MockRepository_InsertStatement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
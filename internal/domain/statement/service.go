@@ -0,0 +1,195 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+const (
+	domainName      = "statement"
+	referencePrefix = "STM"
+	// referenceLength is the number of characters taken from the end of the generated ID,
+	// the random part of a UUIDv7.
+	referenceLength = 12
+)
+
+// Input represents the statement the user requests.
+type Input struct {
+	AccountNumber string
+	Period        Period
+	Format        Format
+}
+
+// Service handles the account statements.
+type Service struct {
+	log         *logger.Logger
+	repo        Repository
+	corebanking CoreBanking
+	renderer    Renderer
+	mailer      Mailer
+	refGen      ReferenceGenerator
+}
+
+// NewService creates a new instance of Service.
+func NewService(
+	log *logger.Logger,
+	repo Repository,
+	corebanking CoreBanking,
+	renderer Renderer,
+	mailer Mailer,
+	refGen ReferenceGenerator,
+) *Service {
+	return &Service{
+		log:         log,
+		repo:        repo,
+		corebanking: corebanking,
+		renderer:    renderer,
+		mailer:      mailer,
+		refGen:      refGen,
+	}
+}
+
+// Generate issues the statement of the user's account and renders it in the requested format.
+func (s *Service) Generate(ctx context.Context, in *Input) (*Document, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Generate").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+	return s.generate(ctx, "Generate", user, in)
+}
+
+// Email issues the statement of the user's account and emails it to the user in the requested format.
+func (s *Service) Email(ctx context.Context, in *Input) (*Document, error) {
+	user, ok := ctxt.UserFromContext(ctx)
+	if !ok {
+		s.log.DomainUsecase(domainName, "Email").Errorf("GetUserFromContext: %v", ctxt.ErrUserFromContext)
+		return nil, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+			SetMsg("Please login to continue.")
+	}
+
+	doc, err := s.generate(ctx, "Email", user, in)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.mailer.SendStatement(ctx, &Delivery{
+		Recipient: user.Email,
+		Name:      user.Name,
+		Document:  doc,
+	})
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Email").Errorf("SendStatement: statement (%v): %v", doc.Statement.Reference, err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral).
+			SetMsg("We could not email your statement. Please try again later.")
+	}
+
+	return doc, nil
+}
+
+// Verify retrieves the verification of the issued statement with the verification reference.
+// It is used by third parties to check that a statement has been issued by the bank,
+// so it only tells the period and the masked account of the statement.
+func (s *Service) Verify(ctx context.Context, reference string) (*Verification, error) {
+	st, err := s.repo.GetStatementByReference(ctx, strings.ToUpper(strings.TrimSpace(reference)))
+	if errors.Is(err, ErrStatementNotFound) {
+		s.log.DomainUsecase(domainName, "Verify").Errorf("GetStatementByReference (%v): %v", reference, err)
+		return nil, pkgerror.New(codes.NotFound, ErrStatementNotFound).
+			SetMsg("No statement has been issued with this reference.")
+	}
+	if err != nil {
+		s.log.DomainUsecase(domainName, "Verify").Errorf("GetStatementByReference: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	return st.Verification(), nil
+}
+
+// generate builds the statement of the period, renders it and records it with its verification reference.
+// The statement is only recorded once it has been rendered, so every recorded reference belongs to a rendered statement.
+func (s *Service) generate(ctx context.Context, usecase string, user *ctxt.User, in *Input) (*Document, error) {
+	now := time.Now()
+	if !in.Period.Valid(now) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("period %v - %v: %v", in.Period.From, in.Period.To, ErrInvalidPeriod)
+		return nil, pkgerror.New(codes.BadRequest, ErrInvalidPeriod).
+			SetMsg("Please choose a period of up to one year that does not go past today.")
+	}
+
+	account, err := s.corebanking.GetAccountDetails(ctx, in.AccountNumber)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetAccountDetails: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+	if !account.IsOwnedBy(user.CIF) {
+		s.log.DomainUsecase(domainName, usecase).Errorf("account (%v) not owned by user (%v)", in.AccountNumber, user.ID)
+		return nil, pkgerror.New(codes.Forbidden, intrabank.ErrSourceAccountNotOwned).
+			SetMsg("You can only request statements of your own account.")
+	}
+	// The account number is not always echoed by the core banking system.
+	account.AccountNumber = in.AccountNumber
+
+	// A statement is an official document, it is not issued unless the ledger of the whole period is complete.
+	ledger, err := s.corebanking.GetLedger(ctx, in.AccountNumber, in.Period.Start(), in.Period.End())
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetLedger: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral).
+			SetMsg("We could not retrieve your transactions. Please try again later.")
+	}
+	if !ledger.Balanced() {
+		s.log.DomainUsecase(domainName, usecase).Errorf("GetLedger: account (%v): %v", in.AccountNumber, ErrIncompleteLedger)
+		return nil, pkgerror.New(codes.Internal, ErrIncompleteLedger).
+			SetMsg("We could not retrieve your transactions. Please try again later.")
+	}
+
+	id, err := s.refGen.Generate()
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("Generate failed: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	st := newStatement(account, in.Period, ledger)
+	st.Reference = reference(id)
+	st.UserID = user.ID
+	st.CreatedAt = now
+
+	format, render := FormatPDF, s.renderer.PDF
+	if in.Format == FormatCSV {
+		format, render = FormatCSV, s.renderer.CSV
+	}
+	content, err := render(st)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("render %v: %v", format, err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	err = s.repo.InsertStatement(ctx, st)
+	if err != nil {
+		s.log.DomainUsecase(domainName, usecase).Errorf("InsertStatement: %v", err)
+		return nil, pkgerror.New(codes.Internal, ErrGeneral)
+	}
+
+	return &Document{
+		Statement:   st,
+		Format:      format,
+		FileName:    st.FileName(format),
+		ContentType: format.ContentType(),
+		Content:     content,
+	}, nil
+}
+
+// reference builds the verification reference from the generated ID, e.g. "STMB3C2D1E0F9A8".
+func reference(id string) string {
+	id = strings.ToUpper(strings.ReplaceAll(id, "-", ""))
+	if len(id) > referenceLength {
+		id = id[len(id)-referenceLength:]
+	}
+	return referencePrefix + id
+}
@@ -0,0 +1,351 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+	"go.bankyaya.org/app/backend/internal/pkg/codes"
+	"go.bankyaya.org/app/backend/internal/pkg/ctxt"
+	"go.bankyaya.org/app/backend/internal/pkg/logger"
+	"go.bankyaya.org/app/backend/internal/pkg/pkgerror"
+)
+
+var lastMonth = Period{From: date(2026, time.September, 1), To: date(2026, time.September, 30)}
+
+func ownAccount() *intrabank.Account {
+	return &intrabank.Account{
+		CIF:      "1234567",
+		Name:     "Olivia Rodrigo",
+		Currency: "IDR",
+		Status:   "1",
+		Balance:  1_000_000,
+	}
+}
+
+func TestGenerateSuccess(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		rendererMock    = NewMockRenderer(t)
+		refGenMock      = NewMockReferenceGenerator(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, rendererMock, NewMockMailer(t), refGenMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(ownAccount(), nil)
+
+	corebankingMock.EXPECT().GetLedger(mock.Anything, "001001234567891", lastMonth.Start(), lastMonth.End()).
+		Return(&Ledger{
+			OpeningBalance: 1_101_000,
+			ClosingBalance: 1_000_000,
+			Mutations: []*Mutation{
+				{PostedAt: date(2026, time.September, 5), Description: "TRANSFER", Debit: 101000},
+			},
+		}, nil)
+	repoMock.EXPECT().InsertStatement(mock.Anything, mock.MatchedBy(func(st *Statement) bool {
+		return st.Reference == "STMB3C2D1E0F9A8" &&
+			st.UserID == 123 &&
+			st.AccountNumber == "001001234567891" &&
+			st.OpeningBalance == 1_101_000 &&
+			st.ClosingBalance == 1_000_000
+	})).Return(nil)
+
+	rendererMock.EXPECT().CSV(mock.Anything).
+		Return([]byte("csv"), nil)
+
+	refGenMock.EXPECT().Generate().
+		Return("0198f2a4-c7e1-7a2b-9c3d-b3c2d1e0f9a8", nil)
+
+	doc, err := svc.Generate(ctx, &Input{
+		AccountNumber: "001001234567891",
+		Period:        lastMonth,
+		Format:        FormatCSV,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, FormatCSV, doc.Format)
+	assert.Equal(t, "statement-001001234567891-20260901-20260930.csv", doc.FileName)
+	assert.Equal(t, "text/csv", doc.ContentType)
+	assert.Equal(t, []byte("csv"), doc.Content)
+	assert.Len(t, doc.Statement.Entries, 1)
+
+	repoMock.AssertExpectations(t)
+	corebankingMock.AssertExpectations(t)
+	rendererMock.AssertExpectations(t)
+	refGenMock.AssertExpectations(t)
+}
+
+func TestGenerateFailed_InvalidPeriod(t *testing.T) {
+	svc := NewService(logger.New(), NewMockRepository(t), NewMockCoreBanking(t), NewMockRenderer(t), NewMockMailer(t), NewMockReferenceGenerator(t))
+	ctx := ctxt.ContextWithUser(context.Background(), &ctxt.User{
+		ID:       123,
+		CIF:      "1234567",
+		Name:     "Olivia Rodrigo",
+		Email:    "olivia@gmail.com",
+		DeviceID: "device-1",
+	})
+
+	doc, err := svc.Generate(ctx, &Input{
+		AccountNumber: "001001234567891",
+		Period:        Period{From: date(2026, time.September, 30), To: date(2026, time.September, 1)},
+	})
+
+	assert.Nil(t, doc)
+	assert.Equal(t, pkgerror.New(codes.BadRequest, ErrInvalidPeriod).
+		SetMsg("Please choose a period of up to one year that does not go past today."), err)
+}
+
+func TestGenerateFailed_AccountNotOwned(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), NewMockRepository(t), corebankingMock, NewMockRenderer(t), NewMockMailer(t), NewMockReferenceGenerator(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	account := ownAccount()
+	account.CIF = "7654321"
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001009876543210").
+		Return(account, nil)
+
+	doc, err := svc.Generate(ctx, &Input{
+		AccountNumber: "001009876543210",
+		Period:        lastMonth,
+	})
+
+	assert.Nil(t, doc)
+	assert.Equal(t, pkgerror.New(codes.Forbidden, intrabank.ErrSourceAccountNotOwned).
+		SetMsg("You can only request statements of your own account."), err)
+
+	corebankingMock.AssertExpectations(t)
+}
+
+func TestGenerateFailed_GetLedgerFailed(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), NewMockRepository(t), corebankingMock, NewMockRenderer(t), NewMockMailer(t), NewMockReferenceGenerator(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(ownAccount(), nil)
+	corebankingMock.EXPECT().GetLedger(mock.Anything, "001001234567891", lastMonth.Start(), lastMonth.End()).
+		Return(nil, errors.New("account history: expected 40 mutations, got 20"))
+
+	doc, err := svc.Generate(ctx, &Input{
+		AccountNumber: "001001234567891",
+		Period:        lastMonth,
+	})
+
+	assert.Nil(t, doc)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral).
+		SetMsg("We could not retrieve your transactions. Please try again later."), err)
+
+	corebankingMock.AssertExpectations(t)
+}
+
+func TestGenerateFailed_IncompleteLedger(t *testing.T) {
+	var (
+		corebankingMock = NewMockCoreBanking(t)
+		svc             = NewService(logger.New(), NewMockRepository(t), corebankingMock, NewMockRenderer(t), NewMockMailer(t), NewMockReferenceGenerator(t))
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(ownAccount(), nil)
+	corebankingMock.EXPECT().GetLedger(mock.Anything, "001001234567891", lastMonth.Start(), lastMonth.End()).
+		Return(&Ledger{
+			OpeningBalance: 1_500_000,
+			ClosingBalance: 1_000_000,
+			Mutations: []*Mutation{
+				{PostedAt: date(2026, time.September, 5), Description: "TRANSFER", Debit: 101000},
+			},
+		}, nil)
+
+	doc, err := svc.Generate(ctx, &Input{
+		AccountNumber: "001001234567891",
+		Period:        lastMonth,
+	})
+
+	assert.Nil(t, doc)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrIncompleteLedger).
+		SetMsg("We could not retrieve your transactions. Please try again later."), err)
+
+	corebankingMock.AssertExpectations(t)
+}
+
+func TestGenerateFailed_Unauthenticated(t *testing.T) {
+	svc := NewService(logger.New(), NewMockRepository(t), NewMockCoreBanking(t), NewMockRenderer(t), NewMockMailer(t), NewMockReferenceGenerator(t))
+
+	doc, err := svc.Generate(context.Background(), &Input{AccountNumber: "001001234567891", Period: lastMonth})
+
+	assert.Nil(t, doc)
+	assert.Equal(t, pkgerror.New(codes.Unauthenticated, ErrUnauthenticatedUser).
+		SetMsg("Please login to continue."), err)
+}
+
+func TestEmailSuccess(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		rendererMock    = NewMockRenderer(t)
+		mailerMock      = NewMockMailer(t)
+		refGenMock      = NewMockReferenceGenerator(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, rendererMock, mailerMock, refGenMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(ownAccount(), nil)
+
+	corebankingMock.EXPECT().GetLedger(mock.Anything, "001001234567891", lastMonth.Start(), lastMonth.End()).
+		Return(&Ledger{OpeningBalance: 1_000_000, ClosingBalance: 1_000_000}, nil)
+	repoMock.EXPECT().InsertStatement(mock.Anything, mock.Anything).
+		Return(nil)
+
+	rendererMock.EXPECT().PDF(mock.Anything).
+		Return([]byte("%PDF"), nil)
+
+	mailerMock.EXPECT().SendStatement(mock.Anything, mock.MatchedBy(func(delivery *Delivery) bool {
+		return delivery.Recipient == "olivia@gmail.com" &&
+			delivery.Name == "Olivia Rodrigo" &&
+			delivery.Document.FileName == "statement-001001234567891-20260901-20260930.pdf" &&
+			delivery.Document.ContentType == "application/pdf"
+	})).Return(nil)
+
+	refGenMock.EXPECT().Generate().
+		Return("0198f2a4-c7e1-7a2b-9c3d-b3c2d1e0f9a8", nil)
+
+	doc, err := svc.Email(ctx, &Input{
+		AccountNumber: "001001234567891",
+		Period:        lastMonth,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "STMB3C2D1E0F9A8", doc.Statement.Reference)
+	assert.Equal(t, intrabank.Money(1_000_000), doc.Statement.OpeningBalance)
+
+	repoMock.AssertExpectations(t)
+	corebankingMock.AssertExpectations(t)
+	rendererMock.AssertExpectations(t)
+	mailerMock.AssertExpectations(t)
+	refGenMock.AssertExpectations(t)
+}
+
+func TestEmailFailed_SendStatement(t *testing.T) {
+	var (
+		repoMock        = NewMockRepository(t)
+		corebankingMock = NewMockCoreBanking(t)
+		rendererMock    = NewMockRenderer(t)
+		mailerMock      = NewMockMailer(t)
+		refGenMock      = NewMockReferenceGenerator(t)
+		svc             = NewService(logger.New(), repoMock, corebankingMock, rendererMock, mailerMock, refGenMock)
+		ctx             = ctxt.ContextWithUser(context.Background(), &ctxt.User{
+			ID:       123,
+			CIF:      "1234567",
+			Name:     "Olivia Rodrigo",
+			Email:    "olivia@gmail.com",
+			DeviceID: "device-1",
+		})
+	)
+
+	corebankingMock.EXPECT().GetAccountDetails(mock.Anything, "001001234567891").
+		Return(ownAccount(), nil)
+
+	corebankingMock.EXPECT().GetLedger(mock.Anything, "001001234567891", lastMonth.Start(), lastMonth.End()).
+		Return(&Ledger{OpeningBalance: 1_000_000, ClosingBalance: 1_000_000}, nil)
+	repoMock.EXPECT().InsertStatement(mock.Anything, mock.Anything).
+		Return(nil)
+
+	rendererMock.EXPECT().PDF(mock.Anything).
+		Return([]byte("%PDF"), nil)
+
+	mailerMock.EXPECT().SendStatement(mock.Anything, mock.Anything).
+		Return(errors.New("smtp unavailable"))
+
+	refGenMock.EXPECT().Generate().
+		Return("0198f2a4-c7e1-7a2b-9c3d-b3c2d1e0f9a8", nil)
+
+	doc, err := svc.Email(ctx, &Input{
+		AccountNumber: "001001234567891",
+		Period:        lastMonth,
+	})
+
+	assert.Nil(t, doc)
+	assert.Equal(t, pkgerror.New(codes.Internal, ErrGeneral).
+		SetMsg("We could not email your statement. Please try again later."), err)
+}
+
+func TestVerify(t *testing.T) {
+	var (
+		repoMock = NewMockRepository(t)
+		svc      = NewService(logger.New(), repoMock, NewMockCoreBanking(t), NewMockRenderer(t), NewMockMailer(t), NewMockReferenceGenerator(t))
+		issuedAt = time.Date(2026, time.October, 1, 9, 0, 0, 0, intrabank.BusinessLocation)
+		issued   = &Statement{
+			Reference:      "STMB3C2D1E0F9A8",
+			AccountNumber:  "001001234567891",
+			AccountName:    "Budi Santoso",
+			Period:         lastMonth,
+			OpeningBalance: 1_000_000,
+			ClosingBalance: 750_000,
+			CreatedAt:      issuedAt,
+		}
+	)
+
+	repoMock.EXPECT().GetStatementByReference(mock.Anything, "STMB3C2D1E0F9A8").
+		Return(issued, nil)
+	repoMock.EXPECT().GetStatementByReference(mock.Anything, "STM000000000000").
+		Return(nil, ErrStatementNotFound)
+
+	st, err := svc.Verify(context.Background(), " stmb3c2d1e0f9a8 ")
+	assert.Nil(t, err)
+	assert.Equal(t, &Verification{
+		Reference:     "STMB3C2D1E0F9A8",
+		AccountNumber: "***********7891",
+		AccountName:   "B*** S******",
+		Period:        lastMonth,
+		IssuedAt:      issuedAt,
+	}, st)
+
+	st, err = svc.Verify(context.Background(), "STM000000000000")
+	assert.Nil(t, st)
+	assert.Equal(t, pkgerror.New(codes.NotFound, ErrStatementNotFound).
+		SetMsg("No statement has been issued with this reference."), err)
+
+	repoMock.AssertExpectations(t)
+}
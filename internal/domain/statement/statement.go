@@ -0,0 +1,212 @@
+// Package statement provides the account statements of the users, e.g. for visa and loan applications.
+// A statement lists the successful transactions of one account in a date range with its opening and closing balances,
+// and is rendered as a PDF or a CSV file that is downloaded or emailed to the user.
+// The transactions and the balances are taken from the ledger of the core banking system,
+// so the statement also lists the mutations that were not made through the app, e.g. teller deposits and charges.
+// Every statement is recorded with a verification reference printed on it,
+// so a third party can check that the statement has been issued by the bank.
+package statement
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+// MaxPeriodDays is the longest period of a statement, in days.
+const MaxPeriodDays = 366
+
+// Format is the file format of a statement.
+type Format string
+
+const (
+	FormatPDF Format = "pdf"
+	FormatCSV Format = "csv"
+)
+
+// ParseFormat returns the format with the name, an empty name is a PDF.
+// Returns ErrInvalidFormat if the format is not supported.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case "", FormatPDF:
+		return FormatPDF, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	default:
+		return "", ErrInvalidFormat
+	}
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv"
+	}
+	return "application/pdf"
+}
+
+// Period is the date range of a statement, both dates are included.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// Start returns the start of the first business day of the period.
+func (p Period) Start() time.Time {
+	start, _ := intrabank.BusinessDay(p.From)
+	return start
+}
+
+// End returns the start of the business day after the period, it is exclusive.
+func (p Period) End() time.Time {
+	_, end := intrabank.BusinessDay(p.To)
+	return end
+}
+
+// Valid checks that the period is in order, does not go past today and is not longer than MaxPeriodDays.
+func (p Period) Valid(now time.Time) bool {
+	if p.From.IsZero() || p.To.IsZero() || p.From.After(p.To) {
+		return false
+	}
+	if today, _ := intrabank.BusinessDay(now); p.Start().After(today) || p.End().After(today.AddDate(0, 0, 1)) {
+		return false
+	}
+	return p.End().Sub(p.Start()) <= MaxPeriodDays*24*time.Hour
+}
+
+// Mutation is a transaction posted to the account, the fee of a payment is a separate debit.
+type Mutation struct {
+	PostedAt    time.Time
+	Description string
+	Reference   string
+	Debit       intrabank.Money
+	Credit      intrabank.Money
+}
+
+// Ledger is the record of the core banking system of the mutations posted to an account within a time range,
+// with the balance of the account before the first and after the last of them.
+type Ledger struct {
+	OpeningBalance intrabank.Money
+	ClosingBalance intrabank.Money
+	Mutations      []*Mutation
+}
+
+// Balanced checks that the mutations account for the change from the opening to the closing balance,
+// the ledger is missing mutations when they do not.
+func (l *Ledger) Balanced() bool {
+	balance := l.OpeningBalance
+	for _, m := range l.Mutations {
+		balance += m.Credit - m.Debit
+	}
+	return balance == l.ClosingBalance
+}
+
+// Entry is a line of the statement, the mutation with the balance after it has been posted.
+type Entry struct {
+	Mutation
+	Balance intrabank.Money
+}
+
+// Statement represents the account statement of a period.
+type Statement struct {
+	ID int64
+	// Reference is the verification reference of the statement.
+	Reference      string
+	UserID         int
+	AccountNumber  string
+	AccountName    string
+	Currency       string
+	Period         Period
+	OpeningBalance intrabank.Money
+	ClosingBalance intrabank.Money
+	TotalDebit     intrabank.Money
+	TotalCredit    intrabank.Money
+	// Entries are only listed when the statement is generated, a verified statement has none.
+	Entries   []*Entry
+	CreatedAt time.Time
+}
+
+// FileName returns the file name of the statement in the format, e.g. "statement-001001234567891-20260901-20260930.pdf".
+func (s *Statement) FileName(format Format) string {
+	return fmt.Sprintf("statement-%s-%s-%s.%s",
+		s.AccountNumber, s.Period.From.Format("20060102"), s.Period.To.Format("20060102"), format)
+}
+
+// Verification is what a third party learns of an issued statement from its verification reference,
+// the account is masked and the balances are left out.
+type Verification struct {
+	Reference     string
+	AccountNumber string
+	AccountName   string
+	Period        Period
+	IssuedAt      time.Time
+}
+
+// Verification returns the verification of the statement.
+func (s *Statement) Verification() *Verification {
+	return &Verification{
+		Reference:     s.Reference,
+		AccountNumber: maskAccountNumber(s.AccountNumber),
+		AccountName:   maskName(s.AccountName),
+		Period:        s.Period,
+		IssuedAt:      s.CreatedAt,
+	}
+}
+
+// maskAccountNumber masks all but the last four digits of the account number, e.g. "***********7891".
+func maskAccountNumber(accountNumber string) string {
+	if len(accountNumber) <= 4 {
+		return strings.Repeat("*", len(accountNumber))
+	}
+	return strings.Repeat("*", len(accountNumber)-4) + accountNumber[len(accountNumber)-4:]
+}
+
+// maskName masks all but the first letter of every word of the name, e.g. "B*** S******".
+func maskName(name string) string {
+	words := strings.Fields(name)
+	for i, w := range words {
+		r := []rune(w)
+		words[i] = string(r[0]) + strings.Repeat("*", len(r)-1)
+	}
+	return strings.Join(words, " ")
+}
+
+// newStatement builds the statement of the period from the ledger of the account in the period.
+func newStatement(account *intrabank.Account, period Period, ledger *Ledger) *Statement {
+	st := &Statement{
+		AccountNumber:  account.AccountNumber,
+		AccountName:    account.Name,
+		Currency:       account.Currency,
+		Period:         period,
+		OpeningBalance: ledger.OpeningBalance,
+		ClosingBalance: ledger.ClosingBalance,
+		Entries:        make([]*Entry, 0, len(ledger.Mutations)),
+	}
+
+	balance := ledger.OpeningBalance
+	for _, m := range ledger.Mutations {
+		st.TotalDebit += m.Debit
+		st.TotalCredit += m.Credit
+		balance += m.Credit - m.Debit
+		st.Entries = append(st.Entries, &Entry{Mutation: *m, Balance: balance})
+	}
+	return st
+}
+
+// Document is a statement rendered in a file format.
+type Document struct {
+	Statement   *Statement
+	Format      Format
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// Delivery represents a statement emailed to its user.
+type Delivery struct {
+	Recipient string
+	Name      string
+	Document  *Document
+}
@@ -0,0 +1,105 @@
+package statement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.bankyaya.org/app/backend/internal/domain/intrabank"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, intrabank.BusinessLocation)
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatPDF, format)
+
+	format, err = ParseFormat("CSV")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+	assert.Equal(t, "text/csv", format.ContentType())
+
+	_, err = ParseFormat("xlsx")
+	assert.Equal(t, ErrInvalidFormat, err)
+}
+
+func TestPeriodValid(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 10, 0, 0, 0, intrabank.BusinessLocation)
+
+	tests := []struct {
+		name   string
+		period Period
+		want   bool
+	}{
+		{name: "last month", period: Period{From: date(2026, time.September, 1), To: date(2026, time.September, 30)}, want: true},
+		{name: "until today", period: Period{From: date(2026, time.October, 1), To: date(2026, time.October, 17)}, want: true},
+		{name: "one year", period: Period{From: date(2025, time.October, 17), To: date(2026, time.October, 17)}, want: true},
+		{name: "past today", period: Period{From: date(2026, time.October, 1), To: date(2026, time.October, 18)}, want: false},
+		{name: "reversed", period: Period{From: date(2026, time.September, 30), To: date(2026, time.September, 1)}, want: false},
+		{name: "too long", period: Period{From: date(2024, time.January, 1), To: date(2026, time.September, 30)}, want: false},
+		{name: "missing dates", period: Period{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.period.Valid(now))
+		})
+	}
+}
+
+func TestLedgerBalanced(t *testing.T) {
+	ledger := &Ledger{
+		OpeningBalance: 652_000,
+		ClosingBalance: 1_051_000,
+		Mutations: []*Mutation{
+			{PostedAt: date(2026, time.September, 5).Add(9 * time.Hour), Description: "TRANSFER", Debit: 101000},
+			{PostedAt: date(2026, time.September, 25).Add(14 * time.Hour), Description: "SALARY", Credit: 500000},
+		},
+	}
+	assert.True(t, ledger.Balanced())
+
+	ledger.Mutations = ledger.Mutations[:1]
+	assert.False(t, ledger.Balanced())
+}
+
+func TestNewStatement(t *testing.T) {
+	account := &intrabank.Account{AccountNumber: "001001234567891", Name: "Olivia Rodrigo", Currency: "IDR", Balance: 1_000_000}
+	period := Period{From: date(2026, time.September, 1), To: date(2026, time.September, 30)}
+	ledger := &Ledger{
+		OpeningBalance: 652_000,
+		ClosingBalance: 1_051_000,
+		Mutations: []*Mutation{
+			{PostedAt: date(2026, time.September, 5).Add(9 * time.Hour), Description: "TRANSFER", Debit: 101000},
+			{PostedAt: date(2026, time.September, 25).Add(14 * time.Hour), Description: "SALARY", Credit: 500000},
+		},
+	}
+
+	st := newStatement(account, period, ledger)
+
+	assert.Equal(t, intrabank.Money(1_051_000), st.ClosingBalance)
+	assert.Equal(t, intrabank.Money(652_000), st.OpeningBalance)
+	assert.Equal(t, intrabank.Money(101000), st.TotalDebit)
+	assert.Equal(t, intrabank.Money(500000), st.TotalCredit)
+	assert.Len(t, st.Entries, 2)
+	assert.Equal(t, intrabank.Money(551_000), st.Entries[0].Balance)
+	assert.Equal(t, intrabank.Money(1_051_000), st.Entries[1].Balance)
+	assert.Equal(t, "statement-001001234567891-20260901-20260930.pdf", st.FileName(FormatPDF))
+}
+
+func TestReference(t *testing.T) {
+	assert.Equal(t, "STMB3C2D1E0F9A8", reference("0198f2a4-c7e1-7a2b-9c3d-b3c2d1e0f9a8"))
+}
+
+func TestStatementVerification(t *testing.T) {
+	st := &Statement{Reference: "STMB3C2D1E0F9A8", AccountNumber: "001001234567891", AccountName: " Budi  Santoso "}
+	v := st.Verification()
+	assert.Equal(t, "***********7891", v.AccountNumber)
+	assert.Equal(t, "B*** S******", v.AccountName)
+
+	st = &Statement{AccountNumber: "7891", AccountName: "Ñ"}
+	v = st.Verification()
+	assert.Equal(t, "****", v.AccountNumber)
+	assert.Equal(t, "Ñ", v.AccountName)
+}
@@ -56,7 +56,9 @@ func (u *Service) Login(ctx context.Context, input *User) (*Token, error) {
 			SetMsg("Password is incorrect. Please try again.")
 	}
 
-	token, err := u.tokenService.Create(input, tokenExpiredTime)
+	// The token carries the registered identity of the user, the input only holds the login credentials.
+	user.Device.Client = input.Device.Client
+	token, err := u.tokenService.Create(user, tokenExpiredTime)
 	if err != nil {
 		u.log.DomainUsecase(domainName, "Login").Errorf("Create token: %v", err)
 		return nil, pkgerror.New(codes.Internal, ErrCreateTokenFailed).
@@ -56,6 +56,58 @@ func TestSuccessLogin(t *testing.T) {
 	tokenSvcMock.AssertExpectations(t)
 }
 
+func TestSuccessLogin_TokenCarriesRegisteredUser(t *testing.T) {
+	var (
+		repoMock     = NewMockRepository(t)
+		hasherMock   = NewMockPasswordHasher(t)
+		tokenSvcMock = NewMockTokenService(t)
+		svc          = NewService(logger.New(), repoMock, hasherMock, tokenSvcMock)
+	)
+
+	repoMock.EXPECT().GetUserByPhoneNumber(mock.Anything, "081338442777").
+		Return(&User{
+			ID:          7,
+			CIF:         "CIF0000007",
+			Password:    "hashed-password",
+			FullName:    "Budi Santoso",
+			Email:       "budi@example.com",
+			PhoneNumber: "081338442777",
+			Device: &Device{
+				FirebaseID: "123",
+				DeviceID:   "456",
+			},
+		}, nil)
+
+	hasherMock.EXPECT().Compare("password", "hashed-password").
+		Return(true)
+
+	tokenSvcMock.EXPECT().Create(mock.MatchedBy(func(u *User) bool {
+		return u.ID == 7 && u.CIF == "CIF0000007" && u.FullName == "Budi Santoso" &&
+			u.Email == "budi@example.com" && u.Device.DeviceID == "456" && u.Device.Client == "bankyaya-android"
+	}), 15*time.Minute).
+		Return(&Token{
+			AccessToken: "example-token-123",
+			ExpiresAt:   time.Now().Add(15 * time.Minute),
+		}, nil)
+
+	token, err := svc.Login(context.Background(), &User{
+		Password:    "password",
+		PhoneNumber: "081338442777",
+		Device: &Device{
+			FirebaseID: "123",
+			DeviceID:   "456",
+			Client:     "bankyaya-android",
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "example-token-123", token.AccessToken)
+
+	repoMock.AssertExpectations(t)
+	hasherMock.AssertExpectations(t)
+	tokenSvcMock.AssertExpectations(t)
+}
+
 func TestLoginFailed_UserNotFound(t *testing.T) {
 	var (
 		repoMock     = NewMockRepository(t)
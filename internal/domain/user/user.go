@@ -29,6 +29,8 @@ type Device struct {
 	FirebaseID    string
 	DeviceID      string
 	IsBlacklisted bool
+	// Client is the name of the app the device logged in with.
+	Client string
 }
 
 // Valid checks whether the provided device credentials match the device's credentials.
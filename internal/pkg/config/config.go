@@ -20,6 +20,12 @@ type Configs struct {
 	Email       internal.Email
 	Clients     internal.Clients
 	Token       internal.Token
+	Worker      internal.Worker
+	Transfer    internal.Transfer
+	QRIS        internal.QRIS
+	EWallet     internal.EWallet
+	Interbank   internal.Interbank
+	Partners    internal.Partners
 }
 
 type Config struct {
@@ -36,6 +42,9 @@ func Load() *Configs {
 	viper.SetConfigType("yaml")
 	viper.AddConfigPath("../../../.")
 
+	// The fake partners are enabled per environment, without editing the config file.
+	_ = viper.BindEnv("configs.partners.useFakes", "PARTNERS_USE_FAKES")
+
 	if err := viper.ReadInConfig(); err != nil {
 		log.Fatalf("failed to read config file: %v", err)
 	}
@@ -0,0 +1,21 @@
+package internal
+
+// EWallet config, the e-wallet providers the users can top up.
+type EWallet struct {
+	Providers []EWalletProvider
+}
+
+// EWalletProvider config, the operating hours are open all day when both hours are equal.
+type EWalletProvider struct {
+	Code string
+	Name string
+	// WalletType is the e-wallet type of the provider at the core banking system.
+	WalletType     string
+	Fee            int64
+	MinAmount      int64
+	MaxAmount      int64
+	MaxDailyAmount int64
+	OpenHour       int
+	CloseHour      int
+	Disabled       bool
+}
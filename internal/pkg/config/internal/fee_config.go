@@ -0,0 +1,25 @@
+package internal
+
+// Fees config.
+type Fees struct {
+	Rules      []FeeRule
+	FreeQuotas []FreeQuota
+}
+
+// FeeRule config, an empty method, channel or segment matches any value
+// and a zero MaxAmount leaves the amount band open.
+type FeeRule struct {
+	Method    string
+	Channel   string
+	Segment   string
+	MinAmount int64
+	MaxAmount int64
+	Fee       int64
+}
+
+// FreeQuota config, the number of free transfers per user every month.
+type FreeQuota struct {
+	Method    string
+	Segment   string
+	Transfers int
+}
@@ -0,0 +1,8 @@
+package internal
+
+// Interbank config.
+type Interbank struct {
+	// SettlementAccount is the account at the core banking system the interbank transfers are debited to
+	// before they are sent to the switching network.
+	SettlementAccount string
+}
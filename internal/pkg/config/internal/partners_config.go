@@ -0,0 +1,10 @@
+package internal
+
+// Partners config.
+type Partners struct {
+	// UseFakes wires the fake partner adapters, i.e. the switching network, the QRIS acquirer, the biller
+	// and the e-wallet directory, which accept the payments without moving any money.
+	// It must only be enabled for local development and tests, the partners are unavailable when it is disabled.
+	// It is set by partners.useFakes in the config file or by the PARTNERS_USE_FAKES environment variable.
+	UseFakes bool `mapstructure:"useFakes"`
+}
@@ -0,0 +1,17 @@
+package internal
+
+// QRIS config, the identity of the bank printed on the QRIS codes generated for the users
+// and the settlement account of the QRIS payments.
+type QRIS struct {
+	GUID string
+	// NNS is the national numbering system prefix of the bank.
+	NNS        string
+	City       string
+	PostalCode string
+	// SettlementAccount is the account at the core banking system the QRIS payments are debited to
+	// before they are sent to the acquirers of the merchants.
+	SettlementAccount string
+	// NetworkAPIKey authenticates the QRIS network when it notifies the credits paid to the codes of the bank,
+	// the notifications are refused when it is empty.
+	NetworkAPIKey string
+}
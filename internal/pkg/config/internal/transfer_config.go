@@ -0,0 +1,15 @@
+package internal
+
+import "time"
+
+// Transfer config.
+type Transfer struct {
+	// SequenceValidity maps a transaction type to how long its inquiry sequence can be paid,
+	// e.g. internal_transfer: 15m.
+	SequenceValidity map[string]time.Duration
+	// OTPThreshold is the amount above which a transfer needs a transaction OTP.
+	// Zero disables the threshold, a transfer to a new destination still needs an OTP.
+	OTPThreshold int64
+	// Fees calculate the transfer fee, the fee of the transaction method is used when no rule matches.
+	Fees Fees
+}
@@ -0,0 +1,15 @@
+package internal
+
+import "time"
+
+// Worker config.
+type Worker struct {
+	ScheduleInterval             time.Duration `envconfig:"WORKER_SCHEDULE_INTERVAL" default:"1m"`
+	StandingOrderInterval        time.Duration `envconfig:"WORKER_STANDING_ORDER_INTERVAL" default:"1m"`
+	ReconcileInterval            time.Duration `envconfig:"WORKER_RECONCILE_INTERVAL" default:"1m"`
+	OutboxInterval               time.Duration `envconfig:"WORKER_OUTBOX_INTERVAL" default:"10s"`
+	SequenceCleanupInterval      time.Duration `envconfig:"WORKER_SEQUENCE_CLEANUP_INTERVAL" default:"1h"`
+	BulkTransferInterval         time.Duration `envconfig:"WORKER_BULK_TRANSFER_INTERVAL" default:"30s"`
+	PaymentRequestExpiryInterval time.Duration `envconfig:"WORKER_PAYMENT_REQUEST_EXPIRY_INTERVAL" default:"5m"`
+	SettlementInterval           time.Duration `envconfig:"WORKER_SETTLEMENT_INTERVAL" default:"1m"`
+}
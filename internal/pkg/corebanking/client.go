@@ -72,6 +72,31 @@ func (c *Client) Overbook(ctx context.Context, req OverbookRequest) (*OverbookRe
 	return resp, nil
 }
 
+// PostingStatus retrieves the status of the posting with the reference.
+func (c *Client) PostingStatus(ctx context.Context, reference string) (*PostingStatusResponse, error) {
+	req := map[string]string{
+		"noReferensi":   reference,
+		"tipeTransaksi": "cek-status",
+	}
+	resp := new(PostingStatusResponse)
+	err := c.executeRequest(ctx, http.MethodPost, transactionEndpoint, req, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AccountHistory retrieves a page of the mutations posted to the account between the dates of the request.
+func (c *Client) AccountHistory(ctx context.Context, req AccountHistoryRequest) (*AccountHistoryResponse, error) {
+	req.TransactionType = "mutasi"
+	resp := new(AccountHistoryResponse)
+	err := c.executeRequest(ctx, http.MethodPost, transactionEndpoint, req, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // token gets the authentication token required for API calls.
 func (c *Client) token(ctx context.Context) (string, error) {
 	authURL := c.url + tokenEndpoint
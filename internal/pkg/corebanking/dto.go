@@ -51,6 +51,7 @@ type OverbookRequest struct {
 	Fee             string `json:"biaya"`
 	Provider        string `json:"provider"`
 	FreeFee         string `json:"bebasBiaya"`
+	Reference       string `json:"noReferensi,omitempty"`
 }
 
 type OverbookResponse struct {
@@ -60,3 +61,41 @@ type OverbookResponse struct {
 	TransactionReference string   `json:"transactionReference"`
 	ABMsg                []string `json:"abmsg"`
 }
+
+type PostingStatusResponse struct {
+	Code        string            `json:"statusCode"`
+	Description string            `json:"statusDescription"`
+	Data        *OverbookResponse `json:"data"`
+}
+
+type AccountHistoryRequest struct {
+	TransactionType string `json:"tipeTransaksi"`
+	AccountNumber   string `json:"noRekening"`
+	StartDate       string `json:"tanggalAwal"`
+	EndDate         string `json:"tanggalAkhir"`
+	Page            int    `json:"halaman"`
+}
+
+type AccountHistoryResponse struct {
+	Code        string              `json:"statusCode"`
+	Description string              `json:"statusDescription"`
+	Data        *AccountHistoryData `json:"data"`
+}
+
+type AccountHistoryData struct {
+	OpeningBalance string            `json:"saldoAwal"`
+	ClosingBalance string            `json:"saldoAkhir"`
+	Page           int               `json:"halaman"`
+	TotalPages     int               `json:"totalHalaman"`
+	TotalRecords   int               `json:"totalData"`
+	Mutations      []AccountMutation `json:"mutasi"`
+}
+
+type AccountMutation struct {
+	PostedAt    string `json:"tanggalTransaksi"`
+	Description string `json:"keterangan"`
+	Reference   string `json:"noReferensi"`
+	// Type is "D" for a debit and "C" for a credit.
+	Type   string `json:"jenis"`
+	Amount string `json:"nominal"`
+}
@@ -16,11 +16,14 @@ func (c ContextKey) String() string {
 var ErrUserFromContext = errors.New("failed to get user from context")
 
 type User struct {
-	ID    int
-	CIF   string
-	Name  string
-	Email string
-	Phone string
+	ID       int
+	CIF      string
+	Name     string
+	Email    string
+	Phone    string
+	DeviceID string
+	// Client is the name of the app the user logged in with.
+	Client string
 }
 
 // ContextWithUser set user data to the ctx context.
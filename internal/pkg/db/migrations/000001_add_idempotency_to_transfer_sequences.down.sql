@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "idx_transactions_seq_no";
+
+DROP INDEX IF EXISTS "idx_sequence_user_idempotency_key";
+DROP INDEX IF EXISTS "idx_transfer_sequences_seq_no";
+
+ALTER TABLE "_transfer_sequences"
+    DROP COLUMN IF EXISTS "UPDATED_AT",
+    DROP COLUMN IF EXISTS "CREATED_AT",
+    DROP COLUMN IF EXISTS "USER_ID",
+    DROP COLUMN IF EXISTS "IDEMPOTENCY_KEY",
+    DROP COLUMN IF EXISTS "STATUS";
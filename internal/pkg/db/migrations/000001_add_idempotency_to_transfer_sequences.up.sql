@@ -0,0 +1,12 @@
+ALTER TABLE "_transfer_sequences"
+    ADD COLUMN IF NOT EXISTS "STATUS"          VARCHAR(20) NOT NULL DEFAULT 'CREATED',
+    ADD COLUMN IF NOT EXISTS "IDEMPOTENCY_KEY" VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS "USER_ID"         INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "CREATED_AT"      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS "UPDATED_AT"      TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- A sequence is paid at most once and an idempotency key is only unique for the user who sent it.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_transfer_sequences_seq_no" ON "_transfer_sequences" ("SEQ_NO");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_sequence_user_idempotency_key" ON "_transfer_sequences" ("USER_ID", "IDEMPOTENCY_KEY");
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_transactions_seq_no" ON "_transactions" ("SEQ_NO");
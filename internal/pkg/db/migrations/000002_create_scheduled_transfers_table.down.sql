@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "_scheduled_transfers";
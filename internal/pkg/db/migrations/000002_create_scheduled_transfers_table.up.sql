@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS "_scheduled_transfers" (
+    "ID"                    BIGSERIAL PRIMARY KEY,
+    "USER_ID"               INTEGER      NOT NULL REFERENCES "_users" ("ID"),
+    "SOURCE_ACCOUNT"        VARCHAR(20)  NOT NULL,
+    "DESTINATION_ACCOUNT"   VARCHAR(20)  NOT NULL,
+    "DESTINATION_NAME"      VARCHAR(100) NOT NULL DEFAULT '',
+    "AMOUNT"                BIGINT       NOT NULL,
+    "EXECUTE_AT"            TIMESTAMPTZ  NOT NULL,
+    "STATUS"                VARCHAR(20)  NOT NULL,
+    "SEQ_NO"                VARCHAR(64)  NOT NULL DEFAULT '',
+    "TRANSACTION_REFERENCE" VARCHAR(64)  NOT NULL DEFAULT '',
+    "FAILURE_REASON"        TEXT         NOT NULL DEFAULT '',
+    "EXECUTED_AT"           TIMESTAMPTZ,
+    -- LEASED_UNTIL is set by the worker that claimed the schedule, another worker may only claim it once it has passed.
+    "LEASED_UNTIL"          TIMESTAMPTZ,
+    "CREATED_AT"            TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"            TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_scheduled_transfers_user_id" ON "_scheduled_transfers" ("USER_ID");
+CREATE INDEX IF NOT EXISTS "idx_scheduled_transfers_execute_at" ON "_scheduled_transfers" ("EXECUTE_AT");
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "idx_transactions_standing_order_id";
+
+ALTER TABLE "_transactions"
+    DROP COLUMN IF EXISTS "STANDING_ORDER_ID";
+
+DROP TABLE IF EXISTS "_standing_orders";
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS "_standing_orders" (
+    "ID"                         BIGSERIAL PRIMARY KEY,
+    "USER_ID"                    INTEGER      NOT NULL REFERENCES "_users" ("ID"),
+    "SOURCE_ACCOUNT"             VARCHAR(20)  NOT NULL,
+    "DESTINATION_ACCOUNT"        VARCHAR(20)  NOT NULL,
+    "DESTINATION_NAME"           VARCHAR(100) NOT NULL DEFAULT '',
+    "AMOUNT"                     BIGINT       NOT NULL,
+    "FREQUENCY"                  VARCHAR(20)  NOT NULL,
+    "BALANCE_POLICY"             VARCHAR(20)  NOT NULL,
+    "START_AT"                   TIMESTAMPTZ  NOT NULL,
+    "END_AT"                     TIMESTAMPTZ,
+    "MAX_RUNS"                   INTEGER      NOT NULL DEFAULT 0,
+    "RUNS"                       INTEGER      NOT NULL DEFAULT 0,
+    "RETRIES"                    INTEGER      NOT NULL DEFAULT 0,
+    "NEXT_RUN_AT"                TIMESTAMPTZ  NOT NULL,
+    "STATUS"                     VARCHAR(20)  NOT NULL,
+    "LAST_RUN_AT"                TIMESTAMPTZ,
+    "LAST_TRANSACTION_REFERENCE" VARCHAR(64)  NOT NULL DEFAULT '',
+    "LAST_FAILURE_REASON"        TEXT         NOT NULL DEFAULT '',
+    -- SEQ_NO is the sequence of the run in progress, it is reused when the run is retried.
+    "SEQ_NO"                     VARCHAR(64)  NOT NULL DEFAULT '',
+    "LEASED_UNTIL"               TIMESTAMPTZ,
+    "CREATED_AT"                 TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"                 TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_standing_orders_user_id" ON "_standing_orders" ("USER_ID");
+CREATE INDEX IF NOT EXISTS "idx_standing_orders_next_run_at" ON "_standing_orders" ("NEXT_RUN_AT");
+
+ALTER TABLE "_transactions"
+    ADD COLUMN IF NOT EXISTS "STANDING_ORDER_ID" BIGINT REFERENCES "_standing_orders" ("ID");
+
+CREATE INDEX IF NOT EXISTS "idx_transactions_standing_order_id" ON "_transactions" ("STANDING_ORDER_ID");
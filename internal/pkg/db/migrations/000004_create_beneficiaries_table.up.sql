@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "_beneficiaries" (
+    "ID"             BIGSERIAL PRIMARY KEY,
+    "USER_ID"        INTEGER      NOT NULL REFERENCES "_users" ("ID"),
+    "ACCOUNT_NUMBER" VARCHAR(20)  NOT NULL,
+    "ACCOUNT_NAME"   VARCHAR(100) NOT NULL,
+    "NICKNAME"       VARCHAR(50)  NOT NULL DEFAULT '',
+    "CREATED_AT"     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"     TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- An account is saved at most once per user.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_beneficiary_user_account" ON "_beneficiaries" ("USER_ID", "ACCOUNT_NUMBER");
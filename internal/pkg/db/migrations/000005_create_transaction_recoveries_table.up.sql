@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "_transaction_recoveries" (
+    "ID"                    BIGSERIAL PRIMARY KEY,
+    "TRANSACTION_ID"        BIGINT      NOT NULL REFERENCES "_transactions" ("ID"),
+    "SEQ_NO"                VARCHAR(64) NOT NULL,
+    "JOURNAL_SEQUENCE"      VARCHAR(64) NOT NULL DEFAULT '',
+    "TRANSACTION_REFERENCE" VARCHAR(64) NOT NULL DEFAULT '',
+    "STATUS"                VARCHAR(20) NOT NULL,
+    "ATTEMPTS"              INTEGER     NOT NULL DEFAULT 0,
+    "LAST_ERROR"            TEXT        NOT NULL DEFAULT '',
+    "RESOLUTION"            TEXT        NOT NULL DEFAULT '',
+    "CREATED_AT"            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "RESOLVED_AT"           TIMESTAMPTZ
+);
+
+-- A posting is journaled once, however many times its recording fails.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_transaction_recoveries_seq_no" ON "_transaction_recoveries" ("SEQ_NO");
+CREATE INDEX IF NOT EXISTS "idx_transaction_recoveries_transaction_id" ON "_transaction_recoveries" ("TRANSACTION_ID");
+CREATE INDEX IF NOT EXISTS "idx_transaction_recoveries_status" ON "_transaction_recoveries" ("STATUS");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "_outbox_messages";
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "_outbox_messages" (
+    "ID"              BIGSERIAL PRIMARY KEY,
+    "TRANSACTION_ID"  BIGINT      NOT NULL REFERENCES "_transactions" ("ID"),
+    "KIND"            VARCHAR(20) NOT NULL,
+    "PAYLOAD"         BYTEA       NOT NULL,
+    "STATUS"          VARCHAR(20) NOT NULL,
+    "ATTEMPTS"        INTEGER     NOT NULL DEFAULT 0,
+    "NEXT_ATTEMPT_AT" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "LAST_ERROR"      TEXT        NOT NULL DEFAULT '',
+    "CREATED_AT"      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "SENT_AT"         TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_transaction_id" ON "_outbox_messages" ("TRANSACTION_ID");
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_next_attempt_at" ON "_outbox_messages" ("NEXT_ATTEMPT_AT");
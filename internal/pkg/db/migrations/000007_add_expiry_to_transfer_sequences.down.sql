@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_transfer_sequences_expires_at";
+
+ALTER TABLE "_transfer_sequences"
+    DROP COLUMN IF EXISTS "EXPIRES_AT";
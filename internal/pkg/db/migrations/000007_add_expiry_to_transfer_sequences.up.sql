@@ -0,0 +1,4 @@
+ALTER TABLE "_transfer_sequences"
+    ADD COLUMN IF NOT EXISTS "EXPIRES_AT" TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS "idx_transfer_sequences_expires_at" ON "_transfer_sequences" ("EXPIRES_AT");
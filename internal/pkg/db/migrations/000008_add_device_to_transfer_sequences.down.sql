@@ -0,0 +1,2 @@
+ALTER TABLE "_transfer_sequences"
+    DROP COLUMN IF EXISTS "DEVICE_ID";
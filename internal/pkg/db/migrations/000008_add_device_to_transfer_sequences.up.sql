@@ -0,0 +1,2 @@
+ALTER TABLE "_transfer_sequences"
+    ADD COLUMN IF NOT EXISTS "DEVICE_ID" VARCHAR(100) NOT NULL DEFAULT '';
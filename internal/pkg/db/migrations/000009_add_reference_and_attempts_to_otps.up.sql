@@ -0,0 +1,5 @@
+-- REFERENCE binds a transaction OTP to the sequence or the instruction it authorizes,
+-- ATTEMPTS counts the wrong codes entered for it.
+ALTER TABLE "otps"
+    ADD COLUMN IF NOT EXISTS "reference" VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS "attempts"  INTEGER      NOT NULL DEFAULT 0;
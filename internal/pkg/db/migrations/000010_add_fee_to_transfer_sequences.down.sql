@@ -0,0 +1,3 @@
+ALTER TABLE "_transfer_sequences"
+    DROP COLUMN IF EXISTS "FEE",
+    DROP COLUMN IF EXISTS "CHANNEL";
@@ -0,0 +1,3 @@
+ALTER TABLE "_transfer_sequences"
+    ADD COLUMN IF NOT EXISTS "CHANNEL" VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS "FEE"     BIGINT      NOT NULL DEFAULT 0;
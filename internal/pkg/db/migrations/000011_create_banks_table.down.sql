@@ -0,0 +1,5 @@
+ALTER TABLE "_transfer_sequences"
+    DROP COLUMN IF EXISTS "RAIL",
+    DROP COLUMN IF EXISTS "BANK_CODE";
+
+DROP TABLE IF EXISTS "_banks";
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "_banks" (
+    "ID"        SERIAL PRIMARY KEY,
+    "BANK_CODE" VARCHAR(10)  NOT NULL,
+    "NAME"      VARCHAR(100) NOT NULL,
+    -- RAILS lists the rails the bank can be reached by, separated by commas.
+    "RAILS"     VARCHAR(100) NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_banks_bank_code" ON "_banks" ("BANK_CODE");
+
+ALTER TABLE "_transfer_sequences"
+    ADD COLUMN IF NOT EXISTS "BANK_CODE" VARCHAR(10) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS "RAIL"      VARCHAR(20) NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+ALTER TABLE "_transfer_sequences"
+    DROP COLUMN IF EXISTS "NOTE";
+
+DROP TABLE IF EXISTS "_bulk_transfer_rows";
+DROP TABLE IF EXISTS "_bulk_transfers";
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS "_bulk_transfers" (
+    "ID"             BIGSERIAL PRIMARY KEY,
+    "USER_ID"        INTEGER      NOT NULL REFERENCES "_users" ("ID"),
+    "SOURCE_ACCOUNT" VARCHAR(20)  NOT NULL,
+    "FILE_NAME"      VARCHAR(255) NOT NULL DEFAULT '',
+    "STATUS"         VARCHAR(20)  NOT NULL,
+    "COMPLETED_AT"   TIMESTAMPTZ,
+    "LEASED_UNTIL"   TIMESTAMPTZ,
+    "CREATED_AT"     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"     TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_bulk_transfers_user_id" ON "_bulk_transfers" ("USER_ID");
+CREATE INDEX IF NOT EXISTS "idx_bulk_transfers_status" ON "_bulk_transfers" ("STATUS");
+
+CREATE TABLE IF NOT EXISTS "_bulk_transfer_rows" (
+    "ID"                    BIGSERIAL PRIMARY KEY,
+    "BATCH_ID"              BIGINT       NOT NULL REFERENCES "_bulk_transfers" ("ID") ON DELETE CASCADE,
+    "LINE"                  INTEGER      NOT NULL,
+    "DESTINATION_ACCOUNT"   VARCHAR(20)  NOT NULL,
+    "DESTINATION_NAME"      VARCHAR(100) NOT NULL DEFAULT '',
+    "AMOUNT"                BIGINT       NOT NULL,
+    "FEE"                   BIGINT       NOT NULL DEFAULT 0,
+    "NOTE"                  VARCHAR(255) NOT NULL DEFAULT '',
+    "STATUS"                VARCHAR(20)  NOT NULL,
+    "ERROR"                 TEXT         NOT NULL DEFAULT '',
+    "TRANSACTION_REFERENCE" VARCHAR(64)  NOT NULL DEFAULT '',
+    -- SEQ_NO is only set once the row is processed, the preview does not create sequences.
+    "SEQ_NO"                VARCHAR(64)  NOT NULL DEFAULT '',
+    "CREATED_AT"            TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"            TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_bulk_transfer_rows_batch_id" ON "_bulk_transfer_rows" ("BATCH_ID");
+
+ALTER TABLE "_transfer_sequences"
+    ADD COLUMN IF NOT EXISTS "NOTE" VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "_payment_requests" (
+    "ID"                    BIGSERIAL PRIMARY KEY,
+    "REQUESTER_ID"          INTEGER      NOT NULL REFERENCES "_users" ("ID"),
+    "PAYER_ID"              INTEGER      NOT NULL REFERENCES "_users" ("ID"),
+    "DESTINATION_ACCOUNT"   VARCHAR(20)  NOT NULL,
+    "AMOUNT"                BIGINT       NOT NULL,
+    "NOTE"                  VARCHAR(255) NOT NULL DEFAULT '',
+    "STATUS"                VARCHAR(20)  NOT NULL,
+    "EXPIRES_AT"            TIMESTAMPTZ  NOT NULL,
+    "TRANSACTION_REFERENCE" VARCHAR(64)  NOT NULL DEFAULT '',
+    "RESPONDED_AT"          TIMESTAMPTZ,
+    "CREATED_AT"            TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"            TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_payment_requests_requester_id" ON "_payment_requests" ("REQUESTER_ID");
+CREATE INDEX IF NOT EXISTS "idx_payment_requests_payer_id" ON "_payment_requests" ("PAYER_ID");
+CREATE INDEX IF NOT EXISTS "idx_payment_requests_expires_at" ON "_payment_requests" ("EXPIRES_AT");
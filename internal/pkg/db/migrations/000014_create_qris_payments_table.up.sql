@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS "_qris_payments" (
+    "ID"                BIGSERIAL PRIMARY KEY,
+    "SEQ_NO"            VARCHAR(64)  NOT NULL,
+    "PAYLOAD"           TEXT         NOT NULL,
+    "GUID"              VARCHAR(99)  NOT NULL DEFAULT '',
+    "MERCHANT_PAN"      VARCHAR(99)  NOT NULL,
+    "MERCHANT_ID"       VARCHAR(99)  NOT NULL DEFAULT '',
+    "MERCHANT_NMID"     VARCHAR(99)  NOT NULL DEFAULT '',
+    "MERCHANT_CRITERIA" VARCHAR(99)  NOT NULL DEFAULT '',
+    "MERCHANT_NAME"     VARCHAR(25)  NOT NULL DEFAULT '',
+    "MERCHANT_CITY"     VARCHAR(15)  NOT NULL DEFAULT '',
+    "POSTAL_CODE"       VARCHAR(99)  NOT NULL DEFAULT '',
+    "MCC"               VARCHAR(4)   NOT NULL DEFAULT '',
+    "AMOUNT"            BIGINT       NOT NULL,
+    "TIP"               BIGINT       NOT NULL DEFAULT 0,
+    "FEE"               BIGINT       NOT NULL DEFAULT 0,
+    "SOURCE_ACCOUNT"    VARCHAR(20)  NOT NULL,
+    "EXPIRES_AT"        TIMESTAMPTZ  NOT NULL,
+    "CREATED_AT"        TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"        TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- A sequence carries a single QRIS payment.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_qris_payments_seq_no" ON "_qris_payments" ("SEQ_NO");
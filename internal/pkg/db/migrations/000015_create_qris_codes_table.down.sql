@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "_qris_code_credits";
+
+DROP INDEX IF EXISTS "idx_qris_payments_code_id";
+
+ALTER TABLE "_qris_payments"
+    DROP COLUMN IF EXISTS "CODE_ID";
+
+DROP TABLE IF EXISTS "_qris_codes";
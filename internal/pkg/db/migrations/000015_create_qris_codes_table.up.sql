@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS "_qris_codes" (
+    "ID"             BIGSERIAL PRIMARY KEY,
+    "USER_ID"        INTEGER      NOT NULL REFERENCES "_users" ("ID"),
+    "ACCOUNT_NUMBER" VARCHAR(20)  NOT NULL,
+    "NAME"           VARCHAR(100) NOT NULL,
+    "AMOUNT"         BIGINT       NOT NULL DEFAULT 0,
+    "EXPIRES_AT"     TIMESTAMPTZ,
+    "CREATED_AT"     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"     TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "idx_qris_codes_user_id" ON "_qris_codes" ("USER_ID");
+
+-- CODE_ID links the payment of a code of Bank Yaya to the code, it is empty for the other merchants.
+ALTER TABLE "_qris_payments"
+    ADD COLUMN IF NOT EXISTS "CODE_ID" BIGINT REFERENCES "_qris_codes" ("ID");
+
+CREATE INDEX IF NOT EXISTS "idx_qris_payments_code_id" ON "_qris_payments" ("CODE_ID");
+
+CREATE TABLE IF NOT EXISTS "_qris_code_credits" (
+    "ID"                    BIGSERIAL PRIMARY KEY,
+    "NETWORK_REFERENCE"     VARCHAR(64)  NOT NULL,
+    "CODE_ID"               BIGINT       NOT NULL REFERENCES "_qris_codes" ("ID"),
+    "ACCOUNT_NUMBER"        VARCHAR(20)  NOT NULL,
+    "PAN"                   VARCHAR(99)  NOT NULL DEFAULT '',
+    "REFERENCE_LABEL"       VARCHAR(99)  NOT NULL DEFAULT '',
+    "AMOUNT"                BIGINT       NOT NULL,
+    "PAYER_NAME"            VARCHAR(100) NOT NULL DEFAULT '',
+    "PAYER_BANK"            VARCHAR(100) NOT NULL DEFAULT '',
+    "JOURNAL_SEQUENCE"      VARCHAR(64)  NOT NULL DEFAULT '',
+    "TRANSACTION_REFERENCE" VARCHAR(64)  NOT NULL DEFAULT '',
+    "CREATED_AT"            TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"            TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- A credit retried by the QRIS network carries the same reference and is posted only once.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_qris_code_credits_network_reference" ON "_qris_code_credits" ("NETWORK_REFERENCE");
+CREATE INDEX IF NOT EXISTS "idx_qris_code_credits_code_id" ON "_qris_code_credits" ("CODE_ID");
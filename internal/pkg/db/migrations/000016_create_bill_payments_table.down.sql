@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "_bill_receipt_recoveries";
+DROP TABLE IF EXISTS "_bill_payments";
+DROP TABLE IF EXISTS "_bill_products";
@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS "_bill_products" (
+    "ID"                 SERIAL PRIMARY KEY,
+    "CODE"               VARCHAR(20)  NOT NULL,
+    "NAME"               VARCHAR(100) NOT NULL,
+    "CATEGORY"           VARCHAR(20)  NOT NULL,
+    "PROVIDER"           VARCHAR(20)  NOT NULL,
+    "SETTLEMENT_ACCOUNT" VARCHAR(20)  NOT NULL,
+    -- DENOMINATIONS lists the amounts of a prepaid product separated by commas, it is empty for a billed product.
+    "DENOMINATIONS"      VARCHAR(255) NOT NULL DEFAULT '',
+    "ACTIVE"             BOOLEAN      NOT NULL DEFAULT TRUE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_bill_products_code" ON "_bill_products" ("CODE");
+
+CREATE TABLE IF NOT EXISTS "_bill_payments" (
+    "ID"                BIGSERIAL PRIMARY KEY,
+    "SEQ_NO"            VARCHAR(64)  NOT NULL,
+    "PRODUCT_CODE"      VARCHAR(20)  NOT NULL,
+    "CUSTOMER_ID"       VARCHAR(50)  NOT NULL,
+    "CUSTOMER_NAME"     VARCHAR(100) NOT NULL DEFAULT '',
+    "PERIOD"            VARCHAR(20)  NOT NULL DEFAULT '',
+    "AMOUNT"            BIGINT       NOT NULL,
+    "ADMIN_FEE"         BIGINT       NOT NULL DEFAULT 0,
+    "FEE"               BIGINT       NOT NULL DEFAULT 0,
+    "INQUIRY_REFERENCE" VARCHAR(64)  NOT NULL DEFAULT '',
+    "SOURCE_ACCOUNT"    VARCHAR(20)  NOT NULL,
+    "EXPIRES_AT"        TIMESTAMPTZ  NOT NULL,
+    "DETAILS"           BYTEA,
+    "BILLER_REFERENCE"  VARCHAR(64)  NOT NULL DEFAULT '',
+    "RECEIPT"           BYTEA,
+    "CREATED_AT"        TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"        TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- A sequence carries a single bill payment.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_bill_payments_seq_no" ON "_bill_payments" ("SEQ_NO");
+
+CREATE TABLE IF NOT EXISTS "_bill_receipt_recoveries" (
+    "ID"               BIGSERIAL PRIMARY KEY,
+    "SEQ_NO"           VARCHAR(64) NOT NULL,
+    "BILLER_REFERENCE" VARCHAR(64) NOT NULL DEFAULT '',
+    "RECEIPT"          BYTEA,
+    "STATUS"           VARCHAR(20) NOT NULL,
+    "ATTEMPTS"         INTEGER     NOT NULL DEFAULT 0,
+    "LAST_ERROR"       TEXT        NOT NULL DEFAULT '',
+    "RESOLUTION"       TEXT        NOT NULL DEFAULT '',
+    "CREATED_AT"       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "RESOLVED_AT"      TIMESTAMPTZ
+);
+
+-- The receipt of a paid bill is recovered once, however many times its recording fails.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_bill_receipt_recoveries_seq_no" ON "_bill_receipt_recoveries" ("SEQ_NO");
+CREATE INDEX IF NOT EXISTS "idx_bill_receipt_recoveries_status" ON "_bill_receipt_recoveries" ("STATUS");
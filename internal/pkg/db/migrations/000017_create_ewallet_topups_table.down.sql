@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "_ewallet_topups";
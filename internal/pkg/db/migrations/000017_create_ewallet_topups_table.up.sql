@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "_ewallet_topups" (
+    "ID"             BIGSERIAL PRIMARY KEY,
+    "SEQ_NO"         VARCHAR(64)  NOT NULL,
+    "PROVIDER_CODE"  VARCHAR(20)  NOT NULL,
+    "PROVIDER_NAME"  VARCHAR(100) NOT NULL,
+    "PHONE_NUMBER"   VARCHAR(20)  NOT NULL,
+    "ACCOUNT_NAME"   VARCHAR(100) NOT NULL DEFAULT '',
+    "AMOUNT"         BIGINT       NOT NULL,
+    "FEE"            BIGINT       NOT NULL DEFAULT 0,
+    "SOURCE_ACCOUNT" VARCHAR(20)  NOT NULL,
+    "EXPIRES_AT"     TIMESTAMPTZ  NOT NULL,
+    "CREATED_AT"     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    "UPDATED_AT"     TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- A sequence carries a single top-up.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_ewallet_topups_seq_no" ON "_ewallet_topups" ("SEQ_NO");
+CREATE INDEX IF NOT EXISTS "idx_ewallet_topups_provider_code" ON "_ewallet_topups" ("PROVIDER_CODE");
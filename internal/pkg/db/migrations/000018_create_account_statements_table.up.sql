@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "_account_statements" (
+    "ID"              BIGSERIAL PRIMARY KEY,
+    -- REFERENCE is printed on the statement so third parties can verify it.
+    "REFERENCE"       VARCHAR(64)  NOT NULL,
+    "USER_ID"         INTEGER      NOT NULL REFERENCES "_users" ("ID"),
+    "ACCOUNT_NUMBER"  VARCHAR(20)  NOT NULL,
+    "ACCOUNT_NAME"    VARCHAR(100) NOT NULL,
+    "CURRENCY"        VARCHAR(3)   NOT NULL,
+    "PERIOD_FROM"     TIMESTAMPTZ  NOT NULL,
+    "PERIOD_TO"       TIMESTAMPTZ  NOT NULL,
+    "OPENING_BALANCE" BIGINT       NOT NULL,
+    "CLOSING_BALANCE" BIGINT       NOT NULL,
+    "TOTAL_DEBIT"     BIGINT       NOT NULL,
+    "TOTAL_CREDIT"    BIGINT       NOT NULL,
+    "CREATED_AT"      TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_account_statements_reference" ON "_account_statements" ("REFERENCE");
+CREATE INDEX IF NOT EXISTS "idx_account_statements_user_id" ON "_account_statements" ("USER_ID");
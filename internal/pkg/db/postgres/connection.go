@@ -11,7 +11,11 @@ import (
 func New(cfg *config.Configs) *gorm.DB {
 	log := logger.New()
 	dsn := cfg.Postgres.DSN
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		// TranslateError converts database specific errors, e.g. unique constraint
+		// violations into gorm errors such as gorm.ErrDuplicatedKey.
+		TranslateError: true,
+	})
 	if err != nil {
 		log.Fatalf("failed to connect database: %v", err)
 	}
@@ -1,6 +1,8 @@
 package mailtrap
 
 import (
+	"io"
+
 	"go.bankyaya.org/app/backend/internal/pkg/config"
 	"gopkg.in/gomail.v2"
 )
@@ -13,6 +15,18 @@ type Data struct {
 	Subject string
 	// Body is the email body.
 	Body []byte
+	// Attachments are the files attached to the email.
+	Attachments []Attachment
+}
+
+// Attachment describe a file attached to the email.
+type Attachment struct {
+	// Name is the file name of the attachment.
+	Name string
+	// ContentType is the MIME type of the attachment.
+	ContentType string
+	// Content is the file content.
+	Content []byte
 }
 
 // Client is MailTrap email service client.
@@ -42,6 +56,16 @@ func (c *Client) Send(data Data) error {
 	msg.SetHeader("To", data.Recipient)
 	msg.SetHeader("Subject", data.Subject)
 	msg.SetBody("text/plain", string(data.Body))
+	for _, a := range data.Attachments {
+		content := a.Content
+		msg.Attach(a.Name,
+			gomail.SetHeader(map[string][]string{"Content-Type": {a.ContentType}}),
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			}),
+		)
+	}
 
 	dialer := gomail.NewDialer(c.host, c.port, c.username, c.password)
 	err := dialer.DialAndSend(msg)
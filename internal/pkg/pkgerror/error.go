@@ -1,6 +1,8 @@
 package pkgerror
 
 import (
+	"errors"
+
 	"go.bankyaya.org/app/backend/internal/pkg/codes"
 )
 
@@ -38,3 +40,18 @@ func (err *Error) SetMsg(msg string) *Error {
 func (err *Error) Error() string {
 	return err.Err.Error()
 }
+
+// Unwrap returns the wrapped error, so errors.Is and errors.As can inspect it.
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Message returns the message of the error that can be displayed to the client.
+// If the error is not an Error, the default message is returned.
+func Message(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Msg
+	}
+	return DefaultMsg
+}